@@ -0,0 +1,328 @@
+// Package batch 按厂商的单次请求上限与每日配额拆分刷新预热请求,并发提交并汇总结果
+package batch
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency 默认并发提交数
+const DefaultConcurrency = 4
+
+// Kind 任务类型
+type Kind int
+
+const (
+	KindPurgeUrls  Kind = iota // URL刷新
+	KindPurgePaths             // 目录刷新
+	KindPushUrls               // URL预热
+)
+
+// Quota 配额
+type Quota struct {
+	PerRequest int // 单次请求最多提交的条数,小于等于0时不拆分
+	PerDay     int // 每日最多提交的条数,小于等于0时不限制
+}
+
+// Quotas 各任务类型的配额
+type Quotas map[Kind]Quota
+
+// defaultQuotas 各厂商公开的配额
+var defaultQuotas = map[string]Quotas{
+	types.TencentSdkName: {
+		KindPurgeUrls:  {PerRequest: 1000, PerDay: 10000},
+		KindPurgePaths: {PerRequest: 20, PerDay: 100},
+		KindPushUrls:   {PerRequest: 500, PerDay: 1000},
+	},
+	types.HuaWeiSdkName: {
+		KindPurgeUrls:  {PerRequest: 1000, PerDay: 2000},
+		KindPurgePaths: {PerRequest: 100, PerDay: 100},
+		KindPushUrls:   {PerRequest: 1000, PerDay: 1000},
+	},
+	types.WangsuSdkName: {
+		KindPurgeUrls:  {PerRequest: 1000, PerDay: 10000},
+		KindPurgePaths: {PerRequest: 500, PerDay: 500},
+		KindPushUrls:   {PerRequest: 1000, PerDay: 1000},
+	},
+	types.AliyunSdkName: {
+		KindPurgeUrls:  {PerRequest: 1000, PerDay: 10000},
+		KindPurgePaths: {PerRequest: 100, PerDay: 100},
+		KindPushUrls:   {PerRequest: 100, PerDay: 1000},
+	},
+	types.BaiduSdkName: {
+		KindPurgeUrls:  {PerRequest: 1000, PerDay: 10000},
+		KindPurgePaths: {PerRequest: 100, PerDay: 100},
+		KindPushUrls:   {PerRequest: 1000, PerDay: 1000},
+	},
+	types.KsYunSdkName: {
+		KindPurgeUrls:  {PerRequest: 1000, PerDay: 10000},
+		KindPurgePaths: {PerRequest: 50, PerDay: 500},
+		KindPushUrls:   {PerRequest: 1000, PerDay: 1000},
+	},
+	types.VolcengineSdkName: {
+		KindPurgeUrls:  {PerRequest: 1000, PerDay: 10000},
+		KindPurgePaths: {PerRequest: 50, PerDay: 500},
+		KindPushUrls:   {PerRequest: 100, PerDay: 1000},
+	},
+}
+
+// fallbackQuotas 未单独配置配额的厂商按请求类型注释中的上限拆分
+var fallbackQuotas = Quotas{
+	KindPurgeUrls:  {PerRequest: 100},
+	KindPurgePaths: {PerRequest: 10},
+	KindPushUrls:   {PerRequest: 1000},
+}
+
+// quotaLocation 每日配额按北京时间零点重置
+var quotaLocation = time.FixedZone("CST", 8*3600)
+
+// DefaultQuotas 获取厂商的默认配额,返回副本,可修改后通过Config传入
+func DefaultQuotas(provider string) Quotas {
+	quotas, ok := defaultQuotas[provider]
+	if !ok {
+		quotas = fallbackQuotas
+	}
+	res := make(Quotas, len(quotas))
+	for k, v := range quotas {
+		res[k] = v
+	}
+	return res
+}
+
+// Config 批量提交配置
+type Config struct {
+	Quotas      Quotas // 配额,为空时使用 DefaultQuotas
+	Concurrency int    // 并发提交数,小于等于0时为 DefaultConcurrency
+}
+
+// Failure 提交失败的一批URL或目录
+type Failure struct {
+	Urls []string // URL或目录
+	Err  error    // 失败原因,超出每日配额时为 cdnerr.ErrQuotaExceeded
+}
+
+// Result 批量提交结果
+type Result struct {
+	TaskIds []string   // 提交成功的任务ID,按拆分顺序排列
+	Failed  []*Failure // 提交失败的批次
+}
+
+// FailedUrls 返回所有提交失败的URL或目录
+func (r *Result) FailedUrls() []string {
+	urls := make([]string, 0)
+	for _, v := range r.Failed {
+		urls = append(urls, v.Urls...)
+	}
+	return urls
+}
+
+// Batcher 批量提交刷新预热任务,每日配额按实例统计,同一厂商账号应共享同一个Batcher
+type Batcher struct {
+	cdn         cdn.CdnWithContext
+	quotas      Quotas
+	concurrency int
+	now         func() time.Time
+
+	mu   sync.Mutex
+	day  string
+	used map[Kind]int
+}
+
+// New 创建批量提交器,conf为空时使用厂商的默认配额
+func New(c cdn.Cdn, conf *Config) *Batcher {
+	b := &Batcher{
+		cdn:         cdn.WithContext(c),
+		concurrency: DefaultConcurrency,
+		now:         time.Now,
+		used:        make(map[Kind]int),
+	}
+	if conf != nil {
+		b.quotas = conf.Quotas
+		if conf.Concurrency > 0 {
+			b.concurrency = conf.Concurrency
+		}
+	}
+	if b.quotas == nil {
+		b.quotas = DefaultQuotas(c.GetSdkName())
+	}
+	return b
+}
+
+// PurgeUrls 批量刷新URL,部分批次失败时同时返回结果与错误
+func (b *Batcher) PurgeUrls(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*Result, error) {
+	if req == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	return b.submit(ctx, KindPurgeUrls, req.Urls, func(ctx context.Context, urls []string) (string, error) {
+		res, err := b.cdn.PurgeUrlsCacheWithContext(ctx, &types.PurgeUrlsCacheRequest{Urls: urls, UrlEncode: req.UrlEncode})
+		if err != nil {
+			return "", err
+		}
+		return res.TaskId, nil
+	})
+}
+
+// PurgePaths 批量刷新目录,部分批次失败时同时返回结果与错误
+func (b *Batcher) PurgePaths(ctx context.Context, req *types.PurgePathCacheRequest) (*Result, error) {
+	if req == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	return b.submit(ctx, KindPurgePaths, req.Paths, func(ctx context.Context, paths []string) (string, error) {
+		res, err := b.cdn.PurgePathCacheWithContext(ctx, &types.PurgePathCacheRequest{Paths: paths, Mode: req.Mode, UrlEncode: req.UrlEncode})
+		if err != nil {
+			return "", err
+		}
+		return res.TaskId, nil
+	})
+}
+
+// PushUrls 批量预热URL,部分批次失败时同时返回结果与错误
+func (b *Batcher) PushUrls(ctx context.Context, req *types.PushUrlsCacheRequest) (*Result, error) {
+	if req == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	return b.submit(ctx, KindPushUrls, req.Urls, func(ctx context.Context, urls []string) (string, error) {
+		res, err := b.cdn.PushUrlsCacheWithContext(ctx, &types.PushUrlsCacheRequest{Urls: urls, UrlEncode: req.UrlEncode})
+		if err != nil {
+			return "", err
+		}
+		return res.TaskId, nil
+	})
+}
+
+// submit 去重后按每日配额截断,按单次上限拆分并发提交
+func (b *Batcher) submit(ctx context.Context, kind Kind, urls []string, fn func(ctx context.Context, urls []string) (string, error)) (*Result, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	urls = unique(urls)
+	if len(urls) == 0 {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, b.cdn.GetSdkName(), "urls is empty")
+	}
+	quota := b.quotas[kind]
+	result := &Result{}
+	allowed, day := b.reserve(kind, quota, len(urls))
+	if allowed < len(urls) {
+		result.Failed = append(result.Failed, &Failure{
+			Urls: urls[allowed:],
+			Err:  cdnerr.New(cdnerr.ErrQuotaExceeded, b.cdn.GetSdkName(), "daily quota exceeded"),
+		})
+		urls = urls[:allowed]
+	}
+	chunks := split(urls, quota.PerRequest)
+	taskIds := make([]string, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, b.concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		i, chunk := i, chunk
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			taskIds[i], errs[i] = fn(ctx, chunk)
+		}()
+	}
+	wg.Wait()
+	failures := make([]*Failure, 0)
+	for i, chunk := range chunks {
+		if errs[i] != nil {
+			b.release(kind, quota, day, len(chunk))
+			failures = append(failures, &Failure{Urls: chunk, Err: errs[i]})
+			continue
+		}
+		result.TaskIds = append(result.TaskIds, taskIds[i])
+	}
+	//提交失败的批次排在超出配额的URL之前
+	result.Failed = append(failures, result.Failed...)
+	if len(result.Failed) == 0 {
+		return result, nil
+	}
+	list := make([]error, 0, len(result.Failed))
+	for _, v := range result.Failed {
+		list = append(list, v.Err)
+	}
+	return result, errors.Join(list...)
+}
+
+// reserve 预占每日配额,返回可以提交的条数与配额所属日期
+func (b *Batcher) reserve(kind Kind, quota Quota, n int) (int, string) {
+	if quota.PerDay <= 0 {
+		return n, ""
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if day := b.now().In(quotaLocation).Format(time.DateOnly); day != b.day {
+		b.day = day
+		b.used = make(map[Kind]int)
+	}
+	left := quota.PerDay - b.used[kind]
+	if left < 0 {
+		left = 0
+	}
+	if n > left {
+		n = left
+	}
+	b.used[kind] += n
+	return n, b.day
+}
+
+// release 归还提交失败的批次预占的配额,配额已按日重置时不再归还
+func (b *Batcher) release(kind Kind, quota Quota, day string, n int) {
+	if quota.PerDay <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if day != b.day {
+		return
+	}
+	b.used[kind] -= n
+	if b.used[kind] < 0 {
+		b.used[kind] = 0
+	}
+}
+
+// unique 去除空值与重复值,保持原有顺序
+func unique(urls []string) []string {
+	seen := make(map[string]bool, len(urls))
+	list := make([]string, 0, len(urls))
+	for _, v := range urls {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		list = append(list, v)
+	}
+	return list
+}
+
+// split 按size拆分,size小于等于0时不拆分
+func split(urls []string, size int) [][]string {
+	if len(urls) == 0 {
+		return nil
+	}
+	if size <= 0 || len(urls) <= size {
+		return [][]string{urls}
+	}
+	chunks := make([][]string, 0, (len(urls)+size-1)/size)
+	for i := 0; i < len(urls); i += size {
+		end := i + size
+		if end > len(urls) {
+			end = len(urls)
+		}
+		chunks = append(chunks, urls[i:end])
+	}
+	return chunks
+}
@@ -0,0 +1,337 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errSubmit = errors.New("submit failed")
+
+// newUrls 生成n个不重复的URL
+func newUrls(n int) []string {
+	urls := make([]string, n)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://www.example.com/%d.png", i)
+	}
+	return urls
+}
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// blockingCdn 统计同时进行中的刷新请求数,每个请求等待release或ctx结束后才返回
+type blockingCdn struct {
+	*cdntest.Fake
+	release  chan struct{}
+	inflight atomic.Int64
+	max      atomic.Int64
+}
+
+func (b *blockingCdn) PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	n := b.inflight.Add(1)
+	defer b.inflight.Add(-1)
+	for {
+		max := b.max.Load()
+		if n <= max || b.max.CompareAndSwap(max, n) {
+			break
+		}
+	}
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return b.Fake.PurgeUrlsCacheWithContext(ctx, req)
+}
+
+func TestSplitAndUnique(t *testing.T) {
+	urls := []string{"a", "b", "", "a", "c", "d", "b", "e"}
+	if got := unique(urls); !reflect.DeepEqual(got, []string{"a", "b", "c", "d", "e"}) {
+		t.Fatalf("got %v", got)
+	}
+	cases := []struct {
+		size int
+		want [][]string
+	}{
+		{2, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{5, [][]string{{"a", "b", "c", "d", "e"}}},
+		{10, [][]string{{"a", "b", "c", "d", "e"}}},
+		//小于等于0时不拆分
+		{0, [][]string{{"a", "b", "c", "d", "e"}}},
+	}
+	for _, c := range cases {
+		if got := split(unique(urls), c.size); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("size %d: got %v", c.size, got)
+		}
+	}
+	if got := split(nil, 2); got != nil {
+		t.Fatalf("got %v for empty urls", got)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	f := cdntest.New(context.Background(), nil)
+	b := New(f, &Config{Quotas: Quotas{KindPurgeUrls: {PerRequest: 100}}, Concurrency: 1})
+	urls := newUrls(250)
+	//重复的URL只提交一次
+	res, err := b.PurgeUrls(context.Background(), &types.PurgeUrlsCacheRequest{Urls: append(urls, urls[:10]...)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.TaskIds) != 3 || len(res.Failed) != 0 {
+		t.Fatalf("got %+v", res)
+	}
+	//任务ID按拆分顺序排列
+	for i, id := range res.TaskIds {
+		got, ok := f.TaskUrls(id)
+		end := (i + 1) * 100
+		if end > len(urls) {
+			end = len(urls)
+		}
+		if !ok || !reflect.DeepEqual(got, urls[i*100:end]) {
+			t.Fatalf("task %d: got %d urls", i, len(got))
+		}
+	}
+	if n := f.CallCount("PurgeUrlsCache"); n != 3 {
+		t.Fatalf("got %d calls", n)
+	}
+}
+
+func TestDefaultQuotas(t *testing.T) {
+	for _, name := range []string{types.TencentSdkName, types.HuaWeiSdkName, types.WangsuSdkName, types.AliyunSdkName, types.BaiduSdkName, types.KsYunSdkName, types.VolcengineSdkName} {
+		quotas := DefaultQuotas(name)
+		for _, kind := range []Kind{KindPurgeUrls, KindPurgePaths, KindPushUrls} {
+			if q := quotas[kind]; q.PerRequest <= 0 || q.PerDay <= 0 {
+				t.Fatalf("%s kind %d: got %+v", name, kind, q)
+			}
+		}
+	}
+	//返回副本,修改不影响默认配额
+	quotas := DefaultQuotas(types.TencentSdkName)
+	quotas[KindPurgeUrls] = Quota{PerRequest: 1}
+	if defaultQuotas[types.TencentSdkName][KindPurgeUrls].PerRequest == 1 {
+		t.Fatal("default quotas modified")
+	}
+	if !reflect.DeepEqual(DefaultQuotas("unknown"), fallbackQuotas) {
+		t.Fatal("unknown provider should use fallback quotas")
+	}
+}
+
+func TestDailyQuota(t *testing.T) {
+	f := cdntest.New(context.Background(), nil)
+	b := New(f, &Config{Quotas: Quotas{KindPushUrls: {PerRequest: 10, PerDay: 25}}})
+	//北京时间2024-01-01 23:59:59
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 15, 59, 59, 0, time.UTC)}
+	b.now = clock.Now
+	ctx := context.Background()
+	urls := newUrls(30)
+	res, err := b.PushUrls(ctx, &types.PushUrlsCacheRequest{Urls: urls[:20]})
+	if err != nil || len(res.TaskIds) != 2 {
+		t.Fatalf("got %+v %v", res, err)
+	}
+	//超出每日配额的部分不提交
+	res, err = b.PushUrls(ctx, &types.PushUrlsCacheRequest{Urls: urls[20:]})
+	if !errors.Is(err, cdnerr.ErrQuotaExceeded) {
+		t.Fatalf("got %v, want quota exceeded", err)
+	}
+	if len(res.TaskIds) != 1 || len(res.Failed) != 1 || !reflect.DeepEqual(res.FailedUrls(), urls[25:]) {
+		t.Fatalf("got %+v", res)
+	}
+	if got, _ := f.TaskUrls(res.TaskIds[0]); !reflect.DeepEqual(got, urls[20:25]) {
+		t.Fatalf("got task urls %v", got)
+	}
+	res, err = b.PushUrls(ctx, &types.PushUrlsCacheRequest{Urls: urls[:1]})
+	if !errors.Is(err, cdnerr.ErrQuotaExceeded) || len(res.TaskIds) != 0 {
+		t.Fatalf("got %+v %v", res, err)
+	}
+	if n := f.CallCount("PushUrlsCache"); n != 3 {
+		t.Fatalf("got %d calls", n)
+	}
+	//其他任务类型不受影响
+	if _, err := b.PurgeUrls(ctx, &types.PurgeUrlsCacheRequest{Urls: urls}); err != nil {
+		t.Fatal(err)
+	}
+	//北京时间零点重置
+	clock.Set(time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC))
+	res, err = b.PushUrls(ctx, &types.PushUrlsCacheRequest{Urls: urls[:25]})
+	if err != nil || len(res.TaskIds) != 3 {
+		t.Fatalf("got %+v %v after reset", res, err)
+	}
+}
+
+func TestReleaseFailedChunk(t *testing.T) {
+	f := cdntest.New(context.Background(), nil)
+	b := New(f, &Config{Quotas: Quotas{KindPurgePaths: {PerRequest: 2, PerDay: 6}}, Concurrency: 1})
+	ctx := context.Background()
+	paths := newUrls(6)
+	//第二批提交失败
+	f.AddFault(func(op string, req interface{}) error {
+		if r, ok := req.(*types.PurgePathCacheRequest); ok && r.Paths[0] == paths[2] {
+			return errSubmit
+		}
+		return nil
+	})
+	res, err := b.PurgePaths(ctx, &types.PurgePathCacheRequest{Paths: paths[:4]})
+	if !errors.Is(err, errSubmit) {
+		t.Fatalf("got %v, want submit error", err)
+	}
+	if len(res.TaskIds) != 1 || !reflect.DeepEqual(res.FailedUrls(), paths[2:4]) {
+		t.Fatalf("got %+v", res)
+	}
+	//失败批次的配额已归还,剩余4条均可提交
+	f.ClearFaults()
+	res, err = b.PurgePaths(ctx, &types.PurgePathCacheRequest{Paths: paths[2:]})
+	if err != nil || len(res.TaskIds) != 2 {
+		t.Fatalf("got %+v %v", res, err)
+	}
+	if _, err := b.PurgePaths(ctx, &types.PurgePathCacheRequest{Paths: paths[:1]}); !errors.Is(err, cdnerr.ErrQuotaExceeded) {
+		t.Fatalf("got %v, want quota exceeded", err)
+	}
+}
+
+func TestReleaseAfterReset(t *testing.T) {
+	b := New(cdntest.New(context.Background(), nil), nil)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 15, 59, 59, 0, time.UTC)}
+	b.now = clock.Now
+	quota := Quota{PerDay: 10}
+	_, day := b.reserve(KindPurgeUrls, quota, 5)
+	clock.Set(time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC))
+	b.reserve(KindPurgeUrls, quota, 3)
+	//前一天预占的配额不从新一天中扣除
+	b.release(KindPurgeUrls, quota, day, 5)
+	if n, _ := b.reserve(KindPurgeUrls, quota, 10); n != 7 {
+		t.Fatalf("got %d, want 7", n)
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	c := &blockingCdn{Fake: cdntest.New(context.Background(), nil), release: make(chan struct{})}
+	b := New(c, &Config{Quotas: Quotas{KindPurgeUrls: {PerRequest: 1}}, Concurrency: 2})
+	done := make(chan struct{})
+	var res *Result
+	var err error
+	go func() {
+		defer close(done)
+		res, err = b.PurgeUrls(context.Background(), &types.PurgeUrlsCacheRequest{Urls: newUrls(6)})
+	}()
+	//并发名额占满后才开始放行
+	deadline := time.Now().Add(5 * time.Second)
+	for c.inflight.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("chunks not submitted concurrently")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	for i := 0; i < 6; i++ {
+		select {
+		case c.release <- struct{}{}:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("chunk %d not submitted", i)
+		}
+	}
+	<-done
+	if err != nil || len(res.TaskIds) != 6 {
+		t.Fatalf("got %+v %v", res, err)
+	}
+	if max := c.max.Load(); max != 2 {
+		t.Fatalf("got %d concurrent submits, want 2", max)
+	}
+}
+
+func TestCanceled(t *testing.T) {
+	c := &blockingCdn{Fake: cdntest.New(context.Background(), nil), release: make(chan struct{})}
+	b := New(c, &Config{Quotas: Quotas{KindPurgeUrls: {PerRequest: 1}}, Concurrency: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var res *Result
+	var err error
+	go func() {
+		defer close(done)
+		res, err = b.PurgeUrls(ctx, &types.PurgeUrlsCacheRequest{Urls: newUrls(3)})
+	}()
+	//第一批提交后取消,等待并发名额的批次不再提交
+	c.release <- struct{}{}
+	cancel()
+	<-done
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want canceled", err)
+	}
+	if len(res.TaskIds)+len(res.FailedUrls()) != 3 || len(res.Failed) == 0 {
+		t.Fatalf("got %+v", res)
+	}
+}
+
+func TestResult(t *testing.T) {
+	f := cdntest.New(context.Background(), nil)
+	b := New(f, &Config{Quotas: Quotas{KindPurgeUrls: {PerRequest: 2, PerDay: 8}}})
+	urls := newUrls(10)
+	//第一批与第三批提交失败
+	f.AddFault(func(op string, req interface{}) error {
+		if r, ok := req.(*types.PurgeUrlsCacheRequest); ok && (r.Urls[0] == urls[0] || r.Urls[0] == urls[4]) {
+			return errSubmit
+		}
+		return nil
+	})
+	res, err := b.PurgeUrls(context.Background(), &types.PurgeUrlsCacheRequest{Urls: urls})
+	if !errors.Is(err, errSubmit) || !errors.Is(err, cdnerr.ErrQuotaExceeded) {
+		t.Fatalf("got %v, want submit and quota errors", err)
+	}
+	if len(res.TaskIds) != 2 {
+		t.Fatalf("got task ids %v", res.TaskIds)
+	}
+	for i, id := range res.TaskIds {
+		if got, _ := f.TaskUrls(id); !reflect.DeepEqual(got, urls[2+i*4:4+i*4]) {
+			t.Fatalf("task %d: got %v", i, got)
+		}
+	}
+	//提交失败的批次排在超出配额的URL之前
+	want := append(append(append([]string{}, urls[0:2]...), urls[4:6]...), urls[8:]...)
+	if !reflect.DeepEqual(res.FailedUrls(), want) {
+		t.Fatalf("got failed urls %v", res.FailedUrls())
+	}
+	if len(res.Failed) != 3 || !errors.Is(res.Failed[2].Err, cdnerr.ErrQuotaExceeded) {
+		t.Fatalf("got failures %+v", res.Failed)
+	}
+}
+
+func TestInvalidRequest(t *testing.T) {
+	b := New(cdntest.New(context.Background(), nil), nil)
+	ctx := context.Background()
+	if _, err := b.PurgeUrls(ctx, nil); !errors.Is(err, cdnerr.ErrNilRequest) {
+		t.Fatalf("got %v", err)
+	}
+	if _, err := b.PurgePaths(ctx, nil); !errors.Is(err, cdnerr.ErrNilRequest) {
+		t.Fatalf("got %v", err)
+	}
+	if _, err := b.PushUrls(ctx, nil); !errors.Is(err, cdnerr.ErrNilRequest) {
+		t.Fatalf("got %v", err)
+	}
+	if _, err := b.PurgeUrls(ctx, &types.PurgeUrlsCacheRequest{Urls: []string{""}}); !errors.Is(err, cdnerr.ErrInvalidParameter) {
+		t.Fatalf("got %v, want invalid parameter", err)
+	}
+}
@@ -2,8 +2,13 @@ package cdn
 
 import (
 	"context"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/huawei"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/tencent"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu"
 	"github.com/run-bigpig/cloud-sdk/cdn/types"
 )
@@ -34,21 +39,109 @@ type Cdn interface {
 	UserAccessRegionDistribution(req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) // 用户访问区域分布
 }
 
+// CdnWithContext 支持context的Cdn接口,取消和超时会传递到各厂商的请求
+type CdnWithContext interface {
+	Cdn
+	CreateDomainWithContext(ctx context.Context, req *types.CreateDomainRequest) error                                                                               // 创建域名
+	UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error                                                                               // 更新域名
+	DisableDomainWithContext(ctx context.Context, req *types.DisableDomainRequest) error                                                                             // 停用域名
+	EnableDomainWithContext(ctx context.Context, req *types.EnableDomainRequest) error                                                                               // 启用域名
+	DeleteDomainWithContext(ctx context.Context, req *types.DeleteDomainRequest) error                                                                               // 删除域名
+	CreateVerifyRecordWithContext(ctx context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error)                              // 创建域名验证记录
+	VerifyDomainRecordWithContext(ctx context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error)                              // 验证域名
+	ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error)                                    // 获取域名详情
+	ShowDomainStatusListWithContext(ctx context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error)                        // 获取指定状态域名列表
+	PurgePathCacheWithContext(ctx context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error)                                              // 刷新目录缓存
+	PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error)                                              // 刷新URL缓存
+	PushUrlsCacheWithContext(ctx context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error)                                             // 预热URL缓存
+	ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error)                           // 获取刷新任务状态
+	ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error)                              // 获取预热任务状态
+	ShowPurgeTaskListWithContext(ctx context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error)                                 // 获取刷新任务列表
+	ShowPushTaskListWithContext(ctx context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error)                                    // 获取预热任务列表
+	DomainAccessDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error)                   // 域名访问数据统计信息
+	DomainOriginDataStaticWithContext(ctx context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error)                   // 域名回源数据统计信息
+	ListTopUrlDataStaticWithContext(ctx context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error)                      // 获取TOP URL访问数据
+	DomainAccessTotalDataWithContext(ctx context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error)                              // 域名访问总流量
+	DomainOriginTotalDataWithContext(ctx context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error)                              // 域名回源数据总流量
+	UserAccessRegionDistributionWithContext(ctx context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) // 用户访问区域分布
+}
+
+// ConfigReader 可选接口,支持将线上配置读回为更新请求的Cdn实现该接口,通过类型断言检测,读取时使用 ShowDomainConfig
+type ConfigReader interface {
+	ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) // 获取域名完整配置
+}
+
+// ConfigReaderWithContext 支持context的 ConfigReader
+type ConfigReaderWithContext interface {
+	ConfigReader
+	ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) // 获取域名完整配置
+}
+
+// ShowDomainConfig 获取域名完整配置,c未实现 ConfigReader 时返回 cdnerr.ErrUnsupported
+func ShowDomainConfig(ctx context.Context, c Cdn, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	switch r := c.(type) {
+	case ConfigReaderWithContext:
+		return r.ShowDomainConfigWithContext(ctx, req)
+	case ConfigReader:
+		return r.ShowDomainConfig(req)
+	}
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, c.GetSdkName(), "show domain config not support")
+}
+
+var (
+	_ ConfigReaderWithContext = (*huawei.Huawei)(nil)
+	_ ConfigReaderWithContext = (*tencent.Tencent)(nil)
+	_ ConfigReaderWithContext = (*wangsu.Wangsu)(nil)
+	_ ConfigReaderWithContext = (*MultiCdn)(nil)
+)
+
+var (
+	_ CdnWithContext = (*huawei.Huawei)(nil)
+	_ CdnWithContext = (*tencent.Tencent)(nil)
+	_ CdnWithContext = (*wangsu.Wangsu)(nil)
+	_ CdnWithContext = (*ksyun.KsYun)(nil)
+	_ CdnWithContext = (*aliyun.Aliyun)(nil)
+	_ CdnWithContext = (*baidu.Baidu)(nil)
+	_ CdnWithContext = (*volcengine.Volcengine)(nil)
+	_ CdnWithContext = (*MultiCdn)(nil)
+)
+
 type Config struct {
-	Huawei  huawei.Config
-	Tencent tencent.Config
-	Wangsu  wangsu.Config
+	Huawei     huawei.Config
+	Tencent    tencent.Config
+	Wangsu     wangsu.Config
+	KsYun      ksyun.Config
+	Aliyun     aliyun.Config
+	Baidu      baidu.Config
+	Volcengine volcengine.Config
 }
 
+// NewCdn 根据厂商配置类型创建客户端,创建失败或配置类型未知时返回nil
+//
+// Deprecated: 使用 New 或 NewWithContext,可以获取创建失败的原因并支持注册的其他厂商
 func NewCdn(ctx context.Context, config interface{}) Cdn {
-	switch t := config.(type) {
+	var name string
+	switch config.(type) {
 	case huawei.Config:
-		return huawei.NewHuaweiSdkClient(ctx, &t)
+		name = types.HuaWeiSdkName
 	case tencent.Config:
-		return tencent.NewTencentSdkClient(ctx, &t)
+		name = types.TencentSdkName
 	case wangsu.Config:
-		return wangsu.NewWangsuSdkClient(ctx, &t)
+		name = types.WangsuSdkName
+	case ksyun.Config:
+		name = types.KsYunSdkName
+	case aliyun.Config:
+		name = types.AliyunSdkName
+	case baidu.Config:
+		name = types.BaiduSdkName
+	case volcengine.Config:
+		name = types.VolcengineSdkName
 	default:
 		return nil
 	}
+	c, err := NewWithContext(ctx, name, config)
+	if err != nil {
+		return nil
+	}
+	return c
 }
@@ -0,0 +1,38 @@
+package cdn_test
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"testing"
+)
+
+// plainCdn 只实现核心接口的Cdn,模拟未实现 cdn.ConfigReader 的外部厂商
+type plainCdn struct {
+	cdn.Cdn
+}
+
+func TestShowDomainConfig(t *testing.T) {
+	ctx := context.Background()
+	fake := cdntest.New(ctx, nil)
+	if err := fake.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	req := &types.ShowDomainConfigRequest{Domain: "www.example.com"}
+	if _, err := cdn.ShowDomainConfig(ctx, fake, req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cdn.ShowDomainConfig(ctx, cdn.WithContext(plainCdn{fake}), req); !errors.Is(err, cdnerr.ErrUnsupported) {
+		t.Fatalf("got %v, want unsupported", err)
+	}
+	//包装后按被包装的厂商是否实现 cdn.ConfigReader 决定能否读取
+	if _, err := cdn.ShowDomainConfig(ctx, cdn.NewLoggedCdn(ctx, fake, nil), req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cdn.ShowDomainConfig(ctx, cdn.NewLoggedCdn(ctx, plainCdn{fake}, nil), req); !errors.Is(err, cdnerr.ErrUnsupported) {
+		t.Fatalf("got %v, want unsupported", err)
+	}
+}
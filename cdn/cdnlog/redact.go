@@ -0,0 +1,61 @@
+// Package cdnlog 提供基于log/slog的CDN日志工具
+//
+// Transport 记录各厂商发出的HTTP请求,通过各厂商Config.Logger启用,操作级别的日志见 cdn.NewLoggedCdn。
+// 日志中不会出现AK/SK、签名请求头、鉴权key与证书私钥
+package cdnlog
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+)
+
+// Redacted 脱敏后的取值
+const Redacted = "******"
+
+// sensitiveHeaders 包含签名或凭证的请求头
+var sensitiveHeaders = []string{
+	"Authorization",
+	"X-Security-Token",
+	"X-Tc-Token",
+	"X-Auth-Token",
+	"X-Cnc-Accesskey",
+}
+
+// RedactRequest 返回可以安全记录的请求,更新域名请求会复制一份并隐藏鉴权key与证书私钥,其余请求原样返回
+func RedactRequest(req interface{}) interface{} {
+	r, ok := req.(*types.UpdateDomainRequest)
+	if !ok || r == nil {
+		return req
+	}
+	redacted := *r
+	if r.AuthConf != nil {
+		auth := *r.AuthConf
+		auth.AuthKey = redact(auth.AuthKey)
+		auth.AuthKeyBackup = redact(auth.AuthKeyBackup)
+		redacted.AuthConf = &auth
+	}
+	if r.HttpsConf != nil {
+		https := *r.HttpsConf
+		https.CertKey = redact(https.CertKey)
+		redacted.HttpsConf = &https
+	}
+	return &redacted
+}
+
+// RedactHeader 返回隐藏签名与凭证后的请求头副本
+func RedactHeader(h http.Header) http.Header {
+	header := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if header.Get(k) != "" {
+			header.Set(k, Redacted)
+		}
+	}
+	return header
+}
+
+func redact(s string) string {
+	if s == "" {
+		return s
+	}
+	return Redacted
+}
@@ -0,0 +1,89 @@
+package cdnlog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// requestIdHeaders 各厂商返回请求ID的响应头
+var requestIdHeaders = []string{
+	"X-Request-Id",
+	"X-Cnc-Request-Id",
+	"X-Bce-Request-Id",
+	"X-Acs-Request-Id",
+	"X-Kss-Request-Id",
+}
+
+// Transport 记录每次HTTP请求的方法、接口地址、接口名称、状态码、请求ID与耗时
+// 不记录查询参数与请求体,请求头仅在Debug级别脱敏后记录
+type Transport struct {
+	Logger *slog.Logger
+	Base   http.RoundTripper // 为空时使用http.DefaultTransport
+}
+
+// NewTransport 包装base,logger为空时原样返回base
+func NewTransport(logger *slog.Logger, base http.RoundTripper) http.RoundTripper {
+	if logger == nil {
+		return base
+	}
+	return &Transport{Logger: logger, Base: base}
+}
+
+// RoundTrip 实现http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ctx := req.Context()
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("endpoint", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path),
+	}
+	if action := getAction(req); action != "" {
+		attrs = append(attrs, slog.String("action", action))
+	}
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	if t.Logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("header", RedactHeader(req.Header)))
+	}
+	if err != nil {
+		t.log(ctx, slog.LevelWarn, "cdn http request failed", append(attrs, slog.String("error", err.Error())))
+		return nil, err
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if requestId := getRequestId(resp.Header); requestId != "" {
+		attrs = append(attrs, slog.String("request_id", requestId))
+	}
+	level := slog.LevelDebug
+	if resp.StatusCode >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	t.log(ctx, level, "cdn http request", attrs)
+	return resp, nil
+}
+
+func (t *Transport) log(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr) {
+	t.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// getAction 获取接口名称,腾讯云放在X-TC-Action头中,火山引擎放在Action查询参数中
+func getAction(req *http.Request) string {
+	if action := req.Header.Get("X-TC-Action"); action != "" {
+		return action
+	}
+	return req.URL.Query().Get("Action")
+}
+
+func getRequestId(h http.Header) string {
+	for _, k := range requestIdHeaders {
+		if v := h.Get(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,169 @@
+package cdnlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// secrets 出现在请求头、查询参数与请求体中的凭证,不允许出现在日志里
+var secrets = []string{"AKIDsecret", "SKsecret", "sign-secret", "tc-token-secret", "auth-token-secret", "security-token-secret", "cert-key-secret"}
+
+// newLogger 以json格式记录Debug及以上级别的日志
+func newLogger() (*slog.Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})), buf
+}
+
+// records 解析日志,每行一条
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var list []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		m := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid log line %s: %v", line, err)
+		}
+		list = append(list, m)
+	}
+	return list
+}
+
+func newSignedRequest(t *testing.T, url string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url+"/v2/domain?Action=UpdateCdnConfig&AccessKeyId=AKIDsecret&Signature=sign-secret",
+		strings.NewReader(`{"AuthKey":"SKsecret","CertKey":"cert-key-secret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "HMAC-SHA256 Credential=AKIDsecret, Signature=sign-secret")
+	req.Header.Set("X-TC-Token", "tc-token-secret")
+	req.Header.Set("X-Auth-Token", "auth-token-secret")
+	req.Header.Set("X-Security-Token", "security-token-secret")
+	req.Header.Set("X-Cnc-Accesskey", "AKIDsecret")
+	req.Header.Set("X-Trace", "trace-1")
+	return req
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Bce-Request-Id", "req-1")
+		if r.Header.Get("X-TC-Action") != "" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	logger, buf := newLogger()
+	client := &http.Client{Transport: NewTransport(logger, nil)}
+	req := newSignedRequest(t, srv.URL)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	//腾讯云接口名称在X-TC-Action头中,失败状态码以Warn级别记录
+	req = newSignedRequest(t, srv.URL)
+	req.Header.Set("X-TC-Action", "DescribeDomainsConfig")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	//发送失败时记录错误
+	req = newSignedRequest(t, "http://127.0.0.1:1")
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("want connection error")
+	}
+
+	for _, s := range secrets {
+		if strings.Contains(buf.String(), s) {
+			t.Fatalf("log contains secret %s:\n%s", s, buf.String())
+		}
+	}
+	list := records(t, buf)
+	if len(list) != 3 {
+		t.Fatalf("got %d records:\n%s", len(list), buf.String())
+	}
+	want := []struct {
+		level    string
+		endpoint string
+		action   string
+		status   float64
+	}{
+		{"DEBUG", srv.URL + "/v2/domain", "UpdateCdnConfig", 200},
+		{"WARN", srv.URL + "/v2/domain", "DescribeDomainsConfig", 403},
+		{"WARN", "http://127.0.0.1:1/v2/domain", "UpdateCdnConfig", 0},
+	}
+	for i, w := range want {
+		r := list[i]
+		if r["level"] != w.level || r["method"] != http.MethodPost || r["endpoint"] != w.endpoint || r["action"] != w.action {
+			t.Fatalf("record %d: unexpected %v", i, r)
+		}
+		if _, ok := r["latency"]; !ok {
+			t.Fatalf("record %d: latency missing %v", i, r)
+		}
+		if w.status == 0 {
+			if r["error"] == nil || r["status"] != nil {
+				t.Fatalf("record %d: want error %v", i, r)
+			}
+			continue
+		}
+		if r["status"] != w.status || r["request_id"] != "req-1" {
+			t.Fatalf("record %d: unexpected result %v", i, r)
+		}
+		//Debug级别记录脱敏后的请求头
+		header, _ := r["header"].(map[string]interface{})
+		if got := header["Authorization"]; !reflect.DeepEqual(got, []interface{}{Redacted}) {
+			t.Fatalf("record %d: got authorization %v", i, got)
+		}
+		if got := header["X-Trace"]; !reflect.DeepEqual(got, []interface{}{"trace-1"}) {
+			t.Fatalf("record %d: got trace header %v", i, got)
+		}
+	}
+}
+
+func TestTransportInfoLevel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	resp, err := (&http.Client{Transport: NewTransport(logger, nil)}).Do(newSignedRequest(t, srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	//Info级别不记录请求头
+	list := records(t, buf)
+	if len(list) != 1 || list[0]["level"] != "WARN" || list[0]["header"] != nil {
+		t.Fatalf("unexpected records %v", list)
+	}
+}
+
+func TestNewTransportWithoutLogger(t *testing.T) {
+	base := &http.Transport{}
+	if rt := NewTransport(nil, base); rt != base {
+		t.Fatalf("got %T, want base transport", rt)
+	}
+	if rt := NewTransport(nil, nil); rt != nil {
+		t.Fatalf("got %T, want nil", rt)
+	}
+	if _, err := (&Transport{Logger: slog.Default(), Base: failingTransport{}}).RoundTrip(httptest.NewRequest(http.MethodGet, "http://example.com", nil)); !errors.Is(err, errFailed) {
+		t.Fatalf("got %v, want base error", err)
+	}
+}
+
+var errFailed = errors.New("failed")
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errFailed
+}
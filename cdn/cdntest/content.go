@@ -0,0 +1,220 @@
+package cdntest
+
+import (
+	"context"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"sort"
+	"time"
+)
+
+const (
+	taskKindPurge = "purge"
+	taskKindPush  = "push"
+)
+
+// task 模拟的刷新预热任务,创建后处于进行中,经过任务耗时后变为成功
+type task struct {
+	seq       int64
+	id        string
+	kind      string
+	purgeType int64
+	urls      []string
+	createdAt time.Time
+	// status 不为空时表示通过 SetTaskStatus 指定的状态,不再随时间变化
+	status *int64
+}
+
+func (t *task) currentStatus(now time.Time, d time.Duration) int64 {
+	if t.status != nil {
+		return *t.status
+	}
+	if now.Sub(t.createdAt) >= d {
+		return consts.ShowContentPurgeOrPushStatusSuccess
+	}
+	return consts.ShowContentPurgeOrPushStatusDoing
+}
+
+// TaskUrls 返回任务提交的url或目录
+func (f *Fake) TaskUrls(taskId string) ([]string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tasks[taskId]
+	if !ok {
+		return nil, false
+	}
+	return append([]string(nil), t.urls...), true
+}
+
+// SetTaskStatus 直接设置任务状态,用于模拟任务失败或长时间未完成
+func (f *Fake) SetTaskStatus(taskId string, status int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tasks[taskId]
+	if !ok {
+		return fmt.Errorf("task %s not found", taskId)
+	}
+	t.status = &status
+	return nil
+}
+
+// submit 创建任务
+func (f *Fake) submit(kind string, purgeType int64, urls []string) (string, error) {
+	if len(urls) == 0 {
+		return "", f.newError(cdnerr.ErrInvalidParameter, "urls is empty")
+	}
+	t := &task{
+		id:        f.nextId(kind),
+		seq:       f.seq,
+		kind:      kind,
+		purgeType: purgeType,
+		urls:      append([]string(nil), urls...),
+		createdAt: f.now,
+	}
+	f.tasks[t.id] = t
+	return t.id, nil
+}
+
+// taskStatus 获取指定类型任务的状态
+func (f *Fake) taskStatus(kind string, taskId string) (int64, error) {
+	t, ok := f.tasks[taskId]
+	if !ok || t.kind != kind {
+		return 0, fmt.Errorf("task %s not found", taskId)
+	}
+	return t.currentStatus(f.now, f.config.TaskDuration), nil
+}
+
+// listTasks 按创建时间、类型与状态筛选任务,按创建顺序分页,startTime与endTime为0时不限制
+func (f *Fake) listTasks(match func(t *task) bool, startTime int64, endTime int64, status int64, page int64, limit int64) (int64, []string) {
+	list := make([]*task, 0)
+	for _, t := range f.tasks {
+		created := t.createdAt.Unix()
+		if (startTime > 0 && created < startTime) || (endTime > 0 && created > endTime) {
+			continue
+		}
+		if match(t) && t.currentStatus(f.now, f.config.TaskDuration) == status {
+			list = append(list, t)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].seq < list[j].seq
+	})
+	ids := make([]string, 0, len(list))
+	for _, t := range list {
+		ids = append(ids, t.id)
+	}
+	return rest.Slice(ids, page, limit)
+}
+
+// PurgePathCache 刷新目录缓存
+func (f *Fake) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return f.PurgePathCacheWithContext(f.ctx, req)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (f *Fake) PurgePathCacheWithContext(ctx context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return call(ctx, f, "PurgePathCache", req, req == nil, func() (*types.PurgeCacheResponse, error) {
+		taskId, err := f.submit(taskKindPurge, consts.ShowContentPurgeTypePath, req.Paths)
+		if err != nil {
+			return nil, err
+		}
+		return &types.PurgeCacheResponse{TaskId: taskId}, nil
+	})
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (f *Fake) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return f.PurgeUrlsCacheWithContext(f.ctx, req)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (f *Fake) PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return call(ctx, f, "PurgeUrlsCache", req, req == nil, func() (*types.PurgeCacheResponse, error) {
+		taskId, err := f.submit(taskKindPurge, consts.ShowContentPurgeTypeUrl, req.Urls)
+		if err != nil {
+			return nil, err
+		}
+		return &types.PurgeCacheResponse{TaskId: taskId}, nil
+	})
+}
+
+// PushUrlsCache 预热URL缓存
+func (f *Fake) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return f.PushUrlsCacheWithContext(f.ctx, req)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (f *Fake) PushUrlsCacheWithContext(ctx context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return call(ctx, f, "PushUrlsCache", req, req == nil, func() (*types.PushUrlsCacheResponse, error) {
+		taskId, err := f.submit(taskKindPush, 0, req.Urls)
+		if err != nil {
+			return nil, err
+		}
+		return &types.PushUrlsCacheResponse{TaskId: taskId}, nil
+	})
+}
+
+// ShowPurgeTaskStatus 获取刷新任务状态
+func (f *Fake) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return f.ShowPurgeTaskStatusWithContext(f.ctx, req)
+}
+
+// ShowPurgeTaskStatusWithContext 获取刷新任务状态
+func (f *Fake) ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return call(ctx, f, "ShowPurgeTaskStatus", req, req == nil, func() (*types.ShowPurgeTaskStatusResponse, error) {
+		status, err := f.taskStatus(taskKindPurge, req.TaskId)
+		if err != nil {
+			return nil, err
+		}
+		return &types.ShowPurgeTaskStatusResponse{TaskId: req.TaskId, Status: status}, nil
+	})
+}
+
+// ShowPushTaskStatus 获取预热任务状态
+func (f *Fake) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return f.ShowPushTaskStatusWithContext(f.ctx, req)
+}
+
+// ShowPushTaskStatusWithContext 获取预热任务状态
+func (f *Fake) ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return call(ctx, f, "ShowPushTaskStatus", req, req == nil, func() (*types.ShowPushTaskStatusResponse, error) {
+		status, err := f.taskStatus(taskKindPush, req.TaskId)
+		if err != nil {
+			return nil, err
+		}
+		return &types.ShowPushTaskStatusResponse{TaskId: req.TaskId, Status: status}, nil
+	})
+}
+
+// ShowPurgeTaskList 获取刷新任务列表
+func (f *Fake) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return f.ShowPurgeTaskListWithContext(f.ctx, req)
+}
+
+// ShowPurgeTaskListWithContext 获取刷新任务列表
+func (f *Fake) ShowPurgeTaskListWithContext(ctx context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return call(ctx, f, "ShowPurgeTaskList", req, req == nil, func() (*types.ShowPurgeTaskListResponse, error) {
+		total, list := f.listTasks(func(t *task) bool {
+			return t.kind == taskKindPurge && t.purgeType == req.PurgeType
+		}, req.StartTime, req.EndTime, req.TaskStatus, req.Page, req.Limit)
+		return &types.ShowPurgeTaskListResponse{Total: total, List: list}, nil
+	})
+}
+
+// ShowPushTaskList 获取预热任务列表
+func (f *Fake) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return f.ShowPushTaskListWithContext(f.ctx, req)
+}
+
+// ShowPushTaskListWithContext 获取预热任务列表
+func (f *Fake) ShowPushTaskListWithContext(ctx context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return call(ctx, f, "ShowPushTaskList", req, req == nil, func() (*types.ShowPushTaskListResponse, error) {
+		total, list := f.listTasks(func(t *task) bool {
+			return t.kind == taskKindPush
+		}, req.StartTime, req.EndTime, req.TaskStatus, req.Page, req.Limit)
+		return &types.ShowPushTaskListResponse{Total: total, List: list}, nil
+	})
+}
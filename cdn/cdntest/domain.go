@@ -0,0 +1,382 @@
+package cdntest
+
+import (
+	"context"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"sort"
+	"time"
+)
+
+// domain 模拟的域名,status为进行中的状态时,到达readyAt后变为target
+type domain struct {
+	id          string
+	name        string
+	cname       string
+	channelType int64
+	areaCode    int64
+	createTime  time.Time
+	updateTime  time.Time
+	status      int64
+	target      int64
+	readyAt     time.Time
+	config      *types.UpdateDomainRequest
+}
+
+// currentStatus 按模拟时间推进域名状态
+func (d *domain) currentStatus(now time.Time) int64 {
+	if d.status != d.target && !now.Before(d.readyAt) {
+		d.status = d.target
+	}
+	return d.status
+}
+
+// transit 开始一次域名操作,从status经过部署耗时后变为target
+func (f *Fake) transit(d *domain, status int64, target int64) {
+	d.status = status
+	d.target = target
+	d.readyAt = f.now.Add(f.config.DeployDuration)
+	d.updateTime = f.now
+}
+
+// findDomain 按域名或域名ID查找未删除的域名
+func (f *Fake) findDomain(name string, id string) (*domain, error) {
+	for _, d := range f.domains {
+		if (name != "" && d.name == name) || (name == "" && id != "" && d.id == id) {
+			if d.currentStatus(f.now) == consts.CdnDomainStatusDeleted {
+				continue
+			}
+			return d, nil
+		}
+	}
+	target := name
+	if target == "" {
+		target = id
+	}
+	return nil, f.newError(cdnerr.ErrDomainNotFound, fmt.Sprintf("domain %s not found", target))
+}
+
+// sortedDomains 按域名排序的未删除域名
+func (f *Fake) sortedDomains() []*domain {
+	list := make([]*domain, 0, len(f.domains))
+	for _, d := range f.domains {
+		if d.currentStatus(f.now) != consts.CdnDomainStatusDeleted {
+			list = append(list, d)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].name < list[j].name
+	})
+	return list
+}
+
+// DomainConfig 返回域名当前保存的配置,配置由创建请求和历次更新请求合并而成
+func (f *Fake) DomainConfig(name string) (*types.UpdateDomainRequest, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d, err := f.findDomain(name, "")
+	if err != nil {
+		return nil, false
+	}
+	conf := *d.config
+	return &conf, true
+}
+
+// SetDomainStatus 直接设置域名状态,用于模拟部署失败等厂商侧的状态变化
+func (f *Fake) SetDomainStatus(name string, status int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d, err := f.findDomain(name, "")
+	if err != nil {
+		return err
+	}
+	d.status, d.target = status, status
+	d.updateTime = f.now
+	return nil
+}
+
+// CreateDomain 创建域名
+func (f *Fake) CreateDomain(req *types.CreateDomainRequest) error {
+	return f.CreateDomainWithContext(f.ctx, req)
+}
+
+// CreateDomainWithContext 创建域名,域名先处于部署中,经过部署耗时后变为已部署
+func (f *Fake) CreateDomainWithContext(ctx context.Context, req *types.CreateDomainRequest) error {
+	_, err := call(ctx, f, "CreateDomain", req, req == nil, func() (struct{}, error) {
+		if req.Domain == "" {
+			return struct{}{}, f.newError(cdnerr.ErrInvalidParameter, "domain is empty")
+		}
+		if _, err := f.findDomain(req.Domain, ""); err == nil {
+			return struct{}{}, f.newError(cdnerr.ErrDomainAlreadyExists, fmt.Sprintf("domain %s already exists", req.Domain))
+		}
+		d := &domain{
+			id:          f.nextId("domain"),
+			name:        req.Domain,
+			cname:       req.Domain + "." + f.config.Name + ".example.com",
+			channelType: req.ChannelType,
+			areaCode:    req.AreaCode,
+			createTime:  f.now,
+			config: &types.UpdateDomainRequest{
+				Domain:           req.Domain,
+				CdnDomain:        &entity.UpdateCdnDomainBaseConf{AreaCode: req.AreaCode},
+				OriginConf:       &entity.OriginConf{OriginProtocol: req.OriginProtocol},
+				OriginServerConf: req.Sources,
+			},
+		}
+		d.config.DomainId = d.id
+		f.transit(d, consts.CdnDomainStatusDeploying, consts.CdnDomainStatusDeployed)
+		f.domains[d.id] = d
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// UpdateDomain 更新域名
+func (f *Fake) UpdateDomain(req *types.UpdateDomainRequest) error {
+	return f.UpdateDomainWithContext(f.ctx, req)
+}
+
+// UpdateDomainWithContext 更新域名,按更新动作合并配置,已部署的域名重新进入部署中
+func (f *Fake) UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error {
+	_, err := call(ctx, f, "UpdateDomain", req, req == nil, func() (struct{}, error) {
+		d, err := f.findDomain(req.Domain, req.DomainId)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if err := applyUpdate(d.config, req); err != nil {
+			return struct{}{}, f.newError(cdnerr.ErrUnsupported, err.Error())
+		}
+		if d.config.CdnDomain != nil {
+			d.areaCode = d.config.CdnDomain.AreaCode
+		}
+		if d.currentStatus(f.now) == consts.CdnDomainStatusDeployed {
+			f.transit(d, consts.CdnDomainStatusDeploying, consts.CdnDomainStatusDeployed)
+		}
+		d.updateTime = f.now
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// applyUpdate 按更新动作将请求中的配置合并到已保存的配置
+func applyUpdate(conf *types.UpdateDomainRequest, req *types.UpdateDomainRequest) error {
+	switch req.UpdateAction {
+	case types.UpdateBaseConf, types.UpdateArea:
+		conf.CdnDomain = req.CdnDomain
+	case types.UpdateOriginConf:
+		conf.OriginConf = req.OriginConf
+	case types.UpdateOriginServerConf:
+		conf.OriginServerConf = req.OriginServerConf
+	case types.UpdateOriginAdvanceServerConf:
+		conf.OriginAdvanceServerConf = req.OriginAdvanceServerConf
+	case types.UpdateOriginRequestHeaderConf:
+		conf.OriginRequestHeaderConf = req.OriginRequestHeaderConf
+	case types.UpdateOriginUrlConf:
+		conf.OriginUrlConf = req.OriginUrlConf
+	case types.UpdateIpFilterConf:
+		conf.IpFilterConf = req.IpFilterConf
+	case types.UpdateIpFrequencyConf:
+		conf.IpFrequencyConf = req.IpFrequencyConf
+	case types.UpdateRefererConf:
+		conf.RefererConf = req.RefererConf
+	case types.UpdateUserAgentConf:
+		conf.UserAgentConf = req.UserAgentConf
+	case types.UpdateSpeedConf:
+		conf.SpeedConf = req.SpeedConf
+	case types.UpdateAuthConf:
+		conf.AuthConf = req.AuthConf
+	case types.UpdateRemoteAuthConf:
+		conf.RemoteAuthConf = req.RemoteAuthConf
+	case types.UpdateCacheListConf:
+		conf.CacheListConf = req.CacheListConf
+	case types.UpdateCacheCodeConf:
+		conf.CacheCodeConf = req.CacheCodeConf
+	case types.UpdateBrowserCacheConf:
+		conf.BrowserCacheConf = req.BrowserCacheConf
+	case types.UpdateRequestUrlRewriteConf:
+		conf.RequestUrlRewriteConf = req.RequestUrlRewriteConf
+	case types.UpdateCustomErrorPageConf:
+		conf.CustomErrorPageConf = req.CustomErrorPageConf
+	case types.UpdateIntelligentCompressionConf:
+		conf.IntelligentCompressionConf = req.IntelligentCompressionConf
+	case types.UpdateResponseHeaderConf:
+		conf.ResponseHeaderConf = req.ResponseHeaderConf
+	case types.UpdateHttpsConf:
+		conf.HttpsConf = req.HttpsConf
+	case types.UpdateRecommendConf:
+		conf.OriginConf = req.OriginConf
+		conf.CacheListConf = req.CacheListConf
+		conf.HttpsConf = req.HttpsConf
+		conf.IntelligentCompressionConf = req.IntelligentCompressionConf
+	case types.UpdateFullConf:
+		domain, domainId := conf.Domain, conf.DomainId
+		*conf = *req
+		conf.Domain, conf.DomainId = domain, domainId
+	default:
+		return fmt.Errorf("update action %s not support", req.UpdateAction)
+	}
+	conf.UpdateAction = ""
+	return nil
+}
+
+// DisableDomain 停用域名
+func (f *Fake) DisableDomain(req *types.DisableDomainRequest) error {
+	return f.DisableDomainWithContext(f.ctx, req)
+}
+
+// DisableDomainWithContext 停用域名,域名先处于停止中,经过部署耗时后变为已停止
+func (f *Fake) DisableDomainWithContext(ctx context.Context, req *types.DisableDomainRequest) error {
+	_, err := call(ctx, f, "DisableDomain", req, req == nil, func() (struct{}, error) {
+		d, err := f.findDomain(req.Domain, req.DomainId)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if d.currentStatus(f.now) != consts.CdnDomainStatusStoped {
+			f.transit(d, consts.CdnDomainStatusStoping, consts.CdnDomainStatusStoped)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// EnableDomain 启用域名
+func (f *Fake) EnableDomain(req *types.EnableDomainRequest) error {
+	return f.EnableDomainWithContext(f.ctx, req)
+}
+
+// EnableDomainWithContext 启用域名,域名先处于部署中,经过部署耗时后变为已部署
+func (f *Fake) EnableDomainWithContext(ctx context.Context, req *types.EnableDomainRequest) error {
+	_, err := call(ctx, f, "EnableDomain", req, req == nil, func() (struct{}, error) {
+		d, err := f.findDomain(req.Domain, req.DomainId)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if d.currentStatus(f.now) != consts.CdnDomainStatusDeployed {
+			f.transit(d, consts.CdnDomainStatusDeploying, consts.CdnDomainStatusDeployed)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// DeleteDomain 删除域名
+func (f *Fake) DeleteDomain(req *types.DeleteDomainRequest) error {
+	return f.DeleteDomainWithContext(f.ctx, req)
+}
+
+// DeleteDomainWithContext 删除域名,域名先处于删除中,经过部署耗时后被删除,删除后可以重新创建
+func (f *Fake) DeleteDomainWithContext(ctx context.Context, req *types.DeleteDomainRequest) error {
+	_, err := call(ctx, f, "DeleteDomain", req, req == nil, func() (struct{}, error) {
+		d, err := f.findDomain(req.Domain, req.DomainId)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if d.currentStatus(f.now) != consts.CdnDomainStatusDeleting {
+			f.transit(d, consts.CdnDomainStatusDeleting, consts.CdnDomainStatusDeleted)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// ShowDomainDetail 获取域名详情
+func (f *Fake) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return f.ShowDomainDetailWithContext(f.ctx, req)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (f *Fake) ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return call(ctx, f, "ShowDomainDetail", req, req == nil, func() (*types.ShowDomainDetailResponse, error) {
+		d, err := f.findDomain(req.Domain, req.DomainId)
+		if err != nil {
+			return nil, err
+		}
+		return &types.ShowDomainDetailResponse{
+			DomainId:    d.id,
+			Domain:      d.name,
+			AreaCode:    d.areaCode,
+			ChannelType: d.channelType,
+			Cname:       d.cname,
+			Status:      d.currentStatus(f.now),
+			CreateTime:  d.createTime.Unix(),
+			UpdateTime:  d.updateTime.Unix(),
+		}, nil
+	})
+}
+
+// ShowDomainConfig 获取域名完整配置
+func (f *Fake) ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return f.ShowDomainConfigWithContext(f.ctx, req)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置,返回创建请求和历次更新请求合并后的配置,
+// 模拟可读取全部列表的厂商,未配置的列表返回空切片
+func (f *Fake) ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return call(ctx, f, "ShowDomainConfig", req, req == nil, func() (*types.UpdateDomainRequest, error) {
+		d, err := f.findDomain(req.Domain, req.DomainId)
+		if err != nil {
+			return nil, err
+		}
+		conf := *d.config
+		conf.UpdateAction = types.UpdateFullConf
+		fillLists(&conf)
+		return &conf, nil
+	})
+}
+
+// fillLists 将为nil的列表替换为空切片
+func fillLists(conf *types.UpdateDomainRequest) {
+	if conf.OriginServerConf == nil {
+		conf.OriginServerConf = make([]*entity.OriginServerConf, 0)
+	}
+	if conf.OriginAdvanceServerConf == nil {
+		conf.OriginAdvanceServerConf = make([]*entity.OriginAdvanceServerConf, 0)
+	}
+	if conf.OriginRequestHeaderConf == nil {
+		conf.OriginRequestHeaderConf = make([]*entity.OriginRequestHeaderConf, 0)
+	}
+	if conf.OriginUrlConf == nil {
+		conf.OriginUrlConf = make([]*entity.OriginUrlConf, 0)
+	}
+	if conf.CacheListConf == nil {
+		conf.CacheListConf = make([]*entity.CacheListItem, 0)
+	}
+	if conf.CacheCodeConf == nil {
+		conf.CacheCodeConf = make([]*entity.CacheCodeListItem, 0)
+	}
+	if conf.BrowserCacheConf == nil {
+		conf.BrowserCacheConf = make([]*entity.BrowserCacheListItem, 0)
+	}
+	if conf.RequestUrlRewriteConf == nil {
+		conf.RequestUrlRewriteConf = make([]*entity.RequestUrlRewriteConf, 0)
+	}
+	if conf.CustomErrorPageConf == nil {
+		conf.CustomErrorPageConf = make([]*entity.CustomErrorPageConf, 0)
+	}
+	if conf.ResponseHeaderConf == nil {
+		conf.ResponseHeaderConf = make([]*entity.ResponseHeaderConf, 0)
+	}
+}
+
+// ShowDomainStatusList 获取指定状态域名列表
+func (f *Fake) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return f.ShowDomainStatusListWithContext(f.ctx, req)
+}
+
+// ShowDomainStatusListWithContext 获取指定状态域名列表,按域名排序分页
+func (f *Fake) ShowDomainStatusListWithContext(ctx context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return call(ctx, f, "ShowDomainStatusList", req, req == nil, func() (*types.ShowDomainStatusListResponse, error) {
+		names := make([]string, 0)
+		for _, d := range f.sortedDomains() {
+			if d.currentStatus(f.now) == req.Status {
+				names = append(names, d.name)
+			}
+		}
+		total, list := rest.Slice(names, req.Page, req.Limit)
+		return &types.ShowDomainStatusListResponse{Total: total, List: list}, nil
+	})
+}
@@ -0,0 +1,261 @@
+// Package cdntest 提供内存中的CDN厂商实现,用于在单元测试中替代真实厂商
+//
+// Fake 实现 cdn.CdnWithContext,保存域名及其配置,刷新预热任务和域名状态随模拟时间推进,
+// 统计接口返回按域名和时间生成的确定性数据,并可以通过 Fault 注入错误
+package cdntest
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SdkName 模拟厂商名称
+const SdkName = "cdntest"
+
+const (
+	defaultDeployDuration = 5 * time.Minute // 默认部署耗时
+	defaultTaskDuration   = time.Minute     // 默认刷新预热任务耗时
+)
+
+var (
+	_ cdn.CdnWithContext          = (*Fake)(nil)
+	_ cdn.ConfigReaderWithContext = (*Fake)(nil)
+)
+
+// Config 模拟厂商配置
+type Config struct {
+	Name           string        // 厂商名称,默认为 SdkName
+	Now            time.Time     // 模拟时间的起点,默认为当前时间
+	DeployDuration time.Duration // 创建、更新、启停、删除域名从进行中到完成的耗时,默认5分钟
+	TaskDuration   time.Duration // 刷新预热任务从进行中到成功的耗时,默认1分钟
+	// Stats 生成统计数据,为空时按域名、指标和时间生成确定性数据
+	Stats StatsFunc
+}
+
+// Fault 错误注入钩子,op为方法名称(如CreateDomain,不含WithContext),返回非nil时方法直接返回该错误
+// 钩子在持有Fake的锁时执行,不能再调用Fake的方法
+type Fault func(op string, req interface{}) error
+
+// Call 方法调用记录
+type Call struct {
+	Op  string      // 方法名称
+	Req interface{} // 请求
+	Err error       // 返回的错误
+}
+
+// Fake 内存中的CDN厂商实现,并发安全
+type Fake struct {
+	ctx    context.Context
+	config Config
+
+	mu      sync.Mutex
+	now     time.Time
+	seq     int64
+	domains map[string]*domain
+	tasks   map[string]*task
+	faults  []Fault
+	calls   []Call
+}
+
+// New 创建模拟厂商,conf为空时使用默认配置
+func New(ctx context.Context, conf *Config) *Fake {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c := Config{}
+	if conf != nil {
+		c = *conf
+	}
+	if c.Name == "" {
+		c.Name = SdkName
+	}
+	if c.Now.IsZero() {
+		c.Now = time.Now()
+	}
+	if c.DeployDuration <= 0 {
+		c.DeployDuration = defaultDeployDuration
+	}
+	if c.TaskDuration <= 0 {
+		c.TaskDuration = defaultTaskDuration
+	}
+	if c.Stats == nil {
+		c.Stats = DefaultStats
+	}
+	return &Fake{
+		ctx:     ctx,
+		config:  c,
+		now:     c.Now,
+		domains: make(map[string]*domain),
+		tasks:   make(map[string]*task),
+	}
+}
+
+func (f *Fake) GetSdkName() string {
+	return f.config.Name
+}
+
+// Now 当前模拟时间
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance 推进模拟时间,到期的域名状态和任务状态随之变化
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Settle 推进模拟时间直到所有进行中的域名操作和任务完成
+func (f *Fake) Settle() {
+	d := f.config.DeployDuration
+	if f.config.TaskDuration > d {
+		d = f.config.TaskDuration
+	}
+	f.Advance(d)
+}
+
+// AddFault 添加错误注入钩子,按添加顺序执行,第一个返回错误的钩子生效
+func (f *Fake) AddFault(fault Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = append(f.faults, fault)
+}
+
+// ClearFaults 移除所有错误注入钩子
+func (f *Fake) ClearFaults() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = nil
+}
+
+// FailOp 指定方法每次调用都返回err,ops为空时对所有方法生效
+func FailOp(err error, ops ...string) Fault {
+	return func(op string, req interface{}) error {
+		if len(ops) == 0 {
+			return err
+		}
+		for _, v := range ops {
+			if v == op {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// FailTimes 指定方法的前n次调用返回err,用于测试重试
+func FailTimes(n int, err error, ops ...string) Fault {
+	var mu sync.Mutex
+	fail := FailOp(err, ops...)
+	return func(op string, req interface{}) error {
+		e := fail(op, req)
+		if e == nil {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if n <= 0 {
+			return nil
+		}
+		n--
+		return e
+	}
+}
+
+// Calls 返回所有方法调用记录
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallCount 返回指定方法的调用次数
+func (f *Fake) CallCount(op string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, v := range f.calls {
+		if v.Op == op {
+			count++
+		}
+	}
+	return count
+}
+
+// call 执行方法,依次检查请求、context与错误注入钩子,调用时持有锁
+func call[T any](ctx context.Context, f *Fake, op string, req interface{}, isNil bool, fn func() (T, error)) (T, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var res T
+	err := f.check(ctx, op, req, isNil)
+	if err == nil {
+		res, err = fn()
+	}
+	f.calls = append(f.calls, Call{Op: op, Req: req, Err: err})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return res, nil
+}
+
+func (f *Fake) check(ctx context.Context, op string, req interface{}, isNil bool) error {
+	if isNil {
+		return cdnerr.ErrNilRequest
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, fault := range f.faults {
+		if err := fault(op, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newError 创建模拟厂商的统一错误
+func (f *Fake) newError(kind error, msg string) error {
+	return cdnerr.New(kind, f.config.Name, msg)
+}
+
+// nextId 生成递增的ID
+func (f *Fake) nextId(prefix string) string {
+	f.seq++
+	return prefix + "-" + strconv.FormatInt(f.seq, 10)
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (f *Fake) CreateVerifyRecord(req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return f.CreateVerifyRecordWithContext(f.ctx, req)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录,返回固定格式的验证内容
+func (f *Fake) CreateVerifyRecordWithContext(ctx context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return call(ctx, f, "CreateVerifyRecord", req, req == nil, func() (*types.CreateVerifyRecordResponse, error) {
+		return &types.CreateVerifyRecordResponse{
+			RecordCode:    "verify-" + req.Domain,
+			FileVerifyUrl: "http://" + req.Domain + "/verification.html",
+		}, nil
+	})
+}
+
+// VerifyDomainRecord 验证域名
+func (f *Fake) VerifyDomainRecord(req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return f.VerifyDomainRecordWithContext(f.ctx, req)
+}
+
+// VerifyDomainRecordWithContext 验证域名,总是验证成功
+func (f *Fake) VerifyDomainRecordWithContext(ctx context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return call(ctx, f, "VerifyDomainRecord", req, req == nil, func() (*types.VerifyDomainRecordResponse, error) {
+		return &types.VerifyDomainRecordResponse{Result: true}, nil
+	})
+}
@@ -0,0 +1,76 @@
+// Package proxy 提供测试用的HTTP代理,用于验证厂商客户端是否使用了httpclient.Options中的代理配置
+package proxy
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Server 记录经由代理访问的主机,并把所有CONNECT隧道转发到同一目标地址,只支持https请求使用的CONNECT隧道
+type Server struct {
+	*httptest.Server
+	target string
+	mu     sync.Mutex
+	hosts  []string
+}
+
+// New 创建并启动代理,target为隧道转发的目标地址,如httptest.NewTLSServer监听的地址
+func New(target string) *Server {
+	p := &Server{target: target}
+	p.Server = httptest.NewServer(http.HandlerFunc(p.serve))
+	return p
+}
+
+// Hosts 经由代理访问的主机,含端口,按请求顺序排列
+func (p *Server) Hosts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.hosts...)
+}
+
+func (p *Server) serve(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if r.Method != http.MethodConnect {
+		host = r.URL.Host
+	}
+	p.mu.Lock()
+	p.hosts = append(p.hosts, host)
+	p.mu.Unlock()
+	if r.Method != http.MethodConnect {
+		http.Error(w, "only CONNECT is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	upstream, err := net.Dial("tcp", p.target)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		_ = upstream.Close()
+		http.Error(w, "hijack not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		_ = upstream.Close()
+		return
+	}
+	_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+	done := make(chan struct{}, 2)
+	go func() {
+		//Hijack前已读入缓冲区的数据同样需要转发
+		_, _ = io.Copy(upstream, rw)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, upstream)
+		done <- struct{}{}
+	}()
+	<-done
+	_ = conn.Close()
+	_ = upstream.Close()
+}
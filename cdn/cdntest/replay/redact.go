@@ -0,0 +1,93 @@
+package replay
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted 脱敏后的取值
+const Redacted = "REDACTED"
+
+var (
+	// defaultHeaders 默认脱敏的请求与响应头,包含各厂商的签名与临时凭证
+	defaultHeaders = []string{"Authorization", "X-Security-Token", "X-Tc-Token", "X-Auth-Token"}
+	// defaultQuery 默认脱敏的查询参数,包含签名放在查询参数中的厂商使用的参数
+	defaultQuery = []string{"Signature", "AccessKeyId", "SecretId", "SecurityToken"}
+)
+
+// Redactor 脱敏规则,录制与回放时对请求做相同的处理,保证两者可以匹配
+type Redactor struct {
+	Headers []string // 整体替换为Redacted的头部
+	Query   []string // 整体替换为Redacted的查询参数
+	Secrets []string // 在URL、头部与请求响应体中出现时替换为Redacted的值,如AK、SK
+}
+
+// NewRedactor 创建包含默认头部与查询参数的脱敏规则,secrets为需要额外脱敏的值
+func NewRedactor(secrets ...string) *Redactor {
+	return &Redactor{
+		Headers: append([]string(nil), defaultHeaders...),
+		Query:   append([]string(nil), defaultQuery...),
+		Secrets: secrets,
+	}
+}
+
+// request 生成脱敏后的录制请求
+func (r *Redactor) request(req *http.Request, body []byte) *Request {
+	u := *req.URL
+	if len(r.Query) > 0 && u.RawQuery != "" {
+		query := u.Query()
+		for _, k := range r.Query {
+			if _, ok := query[k]; ok {
+				query.Set(k, Redacted)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return &Request{
+		Method: req.Method,
+		Url:    r.secret(u.String()),
+		Header: r.header(req.Header),
+		Body:   r.secret(string(body)),
+	}
+}
+
+// response 生成脱敏后的录制响应
+func (r *Redactor) response(resp *http.Response, body []byte) *Response {
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     r.header(resp.Header),
+		Body:       r.secret(string(body)),
+	}
+}
+
+func (r *Redactor) header(h http.Header) http.Header {
+	header := make(http.Header, len(h))
+	for k, v := range h {
+		values := make([]string, 0, len(v))
+		for _, s := range v {
+			values = append(values, r.secret(s))
+		}
+		header[k] = values
+	}
+	for _, k := range r.Headers {
+		if header.Get(k) != "" {
+			header.Set(k, Redacted)
+		}
+	}
+	return header
+}
+
+// secret 替换出现的敏感值,同时处理URL编码后的形式
+func (r *Redactor) secret(s string) string {
+	for _, v := range r.Secrets {
+		if v == "" {
+			continue
+		}
+		s = strings.ReplaceAll(s, v, Redacted)
+		if escaped := url.QueryEscape(v); escaped != v {
+			s = strings.ReplaceAll(s, escaped, Redacted)
+		}
+	}
+	return s
+}
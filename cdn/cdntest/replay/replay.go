@@ -0,0 +1,229 @@
+// Package replay 提供录制与回放HTTP交互的RoundTripper,用于在没有真实凭证的情况下对厂商实现做回归测试
+//
+// 录制模式下请求经由真实transport发送,请求与响应脱敏后保存到golden文件;
+// 回放模式下从golden文件中按方法、URL与请求体查找对应的响应返回,找不到时返回错误,
+// 因此mapping的改动导致请求参数变化时可以被发现
+//
+// 通过各厂商Config.Http.Transport注入Transport
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode 运行模式
+type Mode int
+
+const (
+	ModeReplay Mode = iota // 回放,从golden文件读取响应
+	ModeRecord             // 录制,发送真实请求并保存交互
+)
+
+// ErrNoInteraction 回放时没有匹配的录制交互
+var ErrNoInteraction = errors.New("replay: no recorded interaction")
+
+// Request 录制的请求
+type Request struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response 录制的响应
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction 一次请求与响应
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// Matcher 判断请求与录制的请求是否匹配,两者都已脱敏
+type Matcher func(r *Request, recorded *Request) bool
+
+// Config 录制回放配置
+type Config struct {
+	Path     string            // golden文件路径
+	Mode     Mode              // 运行模式,默认回放
+	Base     http.RoundTripper // 录制时使用的transport,默认为http.DefaultTransport
+	Redactor *Redactor         // 脱敏规则,为空时使用 NewRedactor()
+	Match    Matcher           // 匹配规则,为空时使用 DefaultMatcher
+}
+
+// Transport 录制与回放HTTP交互,并发安全
+type Transport struct {
+	config       Config
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// New 创建Transport,回放模式下加载golden文件
+func New(conf *Config) (*Transport, error) {
+	if conf == nil || conf.Path == "" {
+		return nil, errors.New("replay: path is empty")
+	}
+	c := *conf
+	if c.Base == nil {
+		c.Base = http.DefaultTransport
+	}
+	if c.Redactor == nil {
+		c.Redactor = NewRedactor()
+	}
+	if c.Match == nil {
+		c.Match = DefaultMatcher
+	}
+	t := &Transport{config: c}
+	if c.Mode == ModeReplay {
+		data, err := os.ReadFile(c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("replay: load %s: %w", c.Path, err)
+		}
+		if err := json.Unmarshal(data, &t.interactions); err != nil {
+			return nil, fmt.Errorf("replay: decode %s: %w", c.Path, err)
+		}
+		t.used = make([]bool, len(t.interactions))
+	}
+	return t, nil
+}
+
+// RoundTrip 实现http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := t.config.Redactor.request(req, body)
+	if t.config.Mode == ModeRecord {
+		return t.record(req, body, recorded)
+	}
+	return t.replay(req, recorded)
+}
+
+// record 发送真实请求并保存交互
+func (t *Transport) record(req *http.Request, body []byte, recorded *Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := t.config.Base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, &Interaction{
+		Request:  recorded,
+		Response: t.config.Redactor.response(resp, data),
+	})
+	t.used = append(t.used, true)
+	return resp, nil
+}
+
+// replay 按录制顺序查找第一个未使用的匹配交互
+func (t *Transport) replay(req *http.Request, recorded *Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, v := range t.interactions {
+		if t.used[i] || !t.config.Match(recorded, v.Request) {
+			continue
+		}
+		t.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", v.Response.StatusCode, http.StatusText(v.Response.StatusCode)),
+			StatusCode:    v.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        v.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(v.Response.Body))),
+			ContentLength: int64(len(v.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s body %s", ErrNoInteraction, recorded.Method, recorded.Url, recorded.Body)
+}
+
+// Save 将录制的交互写入golden文件,回放模式下不做任何操作
+func (t *Transport) Save() error {
+	if t.config.Mode != ModeRecord {
+		return nil
+	}
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.config.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.config.Path, append(data, '\n'), 0o644)
+}
+
+// Unused 返回回放时未被使用的交互,不为空说明实现少发了请求
+func (t *Transport) Unused() []*Interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	list := make([]*Interaction, 0)
+	for i, v := range t.interactions {
+		if !t.used[i] {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// DefaultMatcher 方法、URL与请求体相同时匹配,JSON请求体按语义比较
+func DefaultMatcher(r *Request, recorded *Request) bool {
+	return r.Method == recorded.Method && r.Url == recorded.Url && equalBody(r.Body, recorded.Body)
+}
+
+// equalBody 比较请求体,均为JSON时忽略格式与字段顺序
+func equalBody(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	var x, y interface{}
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(x)
+	jb, _ := json.Marshal(y)
+	return bytes.Equal(ja, jb)
+}
+
+// readBody 读取请求体并重置,使请求可以再次读取
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
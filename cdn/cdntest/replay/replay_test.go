@@ -0,0 +1,90 @@
+package replay
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func send(t *testing.T, rt http.RoundTripper, url string, body string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "HMAC Credential=secret-ak, Signature=abc")
+	return (&http.Client{Transport: rt}).Do(req)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write(append([]byte("echo "), body...))
+	}))
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), "golden.json")
+	recorder, err := New(&Config{Path: path, Mode: ModeRecord, Redactor: NewRedactor("secret-ak")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := send(t, recorder, s.URL+"/api?Signature=xyz&a=1", `{"ak":"secret-ak","b":2}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-ak") || strings.Contains(string(data), "xyz") || strings.Contains(string(data), "Signature=abc") {
+		t.Fatalf("golden file not redacted:\n%s", data)
+	}
+
+	player, err := New(&Config{Path: path, Redactor: NewRedactor("secret-ak")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//JSON请求体按语义比较,签名不同的请求可以匹配
+	resp, err = send(t, player, s.URL+"/api?Signature=other&a=1", `{"b": 2, "ak": "secret-ak"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Request-Id") != "req-1" || !strings.HasPrefix(string(body), "echo ") {
+		t.Fatalf("unexpected replayed response %d %v %s", resp.StatusCode, resp.Header, body)
+	}
+	if len(player.Unused()) != 0 {
+		t.Fatal("interaction not marked as used")
+	}
+	//每个交互只回放一次
+	if _, err := send(t, player, s.URL+"/api?Signature=other&a=1", `{"b":2,"ak":"secret-ak"}`); !errors.Is(err, ErrNoInteraction) {
+		t.Fatalf("got %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden.json")
+	if err := os.WriteFile(path, []byte(`[{"request":{"method":"POST","url":"https://example.com/api","body":"{\"a\":1}"},"response":{"status_code":200}}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	player, err := New(&Config{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := send(t, player, "https://example.com/api", `{"a":2}`); !errors.Is(err, ErrNoInteraction) {
+		t.Fatalf("got %v, want ErrNoInteraction", err)
+	}
+	if len(player.Unused()) != 1 {
+		t.Fatal("mismatched interaction should stay unused")
+	}
+}
@@ -0,0 +1,220 @@
+package cdntest
+
+import (
+	"context"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"hash/fnv"
+)
+
+// topUrlCount TOP URL返回条数
+const topUrlCount = 10
+
+// StatsFunc 生成统计数据,origin为true时metric为回源数据指标(consts.DataOriginMetricType*),
+// 否则为访问数据指标(consts.DataAccessMetricType*),timestamp为数据点的时间戳
+type StatsFunc func(domain string, origin bool, metric int64, timestamp int64) float64
+
+// DefaultStats 默认的统计数据,由域名、指标和时间戳决定,相同参数总是返回相同的值
+func DefaultStats(domain string, origin bool, metric int64, timestamp int64) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(domain))
+	base := float64(h.Sum32()%1000 + 1)
+	//按5分钟为周期在1到12倍之间变化
+	factor := float64((timestamp/300)%12 + 1)
+	value := base * factor * float64(metric+1)
+	if origin {
+		//回源数据取访问数据的十分之一
+		value /= 10
+	}
+	return value
+}
+
+// getIntervalSeconds 获取统计粒度,单位秒
+func getIntervalSeconds(interval int64) int64 {
+	switch interval {
+	case consts.DataIntervalTypeHour:
+		return 3600
+	case consts.DataIntervalTypeDay:
+		return 86400
+	default:
+		return 300
+	}
+}
+
+// statDomains 获取统计的域名,未指定时为全部域名,指定的域名不存在时返回错误
+func (f *Fake) statDomains(domains []string) ([]string, error) {
+	if len(domains) == 0 {
+		for _, d := range f.sortedDomains() {
+			domains = append(domains, d.name)
+		}
+		return domains, nil
+	}
+	for _, v := range domains {
+		if _, err := f.findDomain(v, ""); err != nil {
+			return nil, err
+		}
+	}
+	return domains, nil
+}
+
+// series 生成各域名在时间范围内的统计数据
+func (f *Fake) series(domains []string, origin bool, metric int64, startTime int64, endTime int64, interval int64) (types.DomainAccessDataStaticResponse, error) {
+	if endTime < startTime {
+		return nil, f.newError(cdnerr.ErrInvalidParameter, "end time is before start time")
+	}
+	domains, err := f.statDomains(domains)
+	if err != nil {
+		return nil, err
+	}
+	timestamps := utils.CalcTimeStampsWithInterval(startTime, endTime, getIntervalSeconds(interval))
+	responseData := make(types.DomainAccessDataStaticResponse, len(domains))
+	for _, domain := range domains {
+		data := make([]*types.StaticData, 0, len(timestamps))
+		for _, t := range timestamps {
+			data = append(data, &types.StaticData{Time: t, Value: f.config.Stats(domain, origin, metric, t)})
+		}
+		responseData[domain] = data
+	}
+	return responseData, nil
+}
+
+// total 汇总各域名的统计数据,带宽取峰值,其余指标求和
+func total(series types.DomainAccessDataStaticResponse, bandwidth bool) types.DataTotalDataResponse {
+	responseData := make(types.DataTotalDataResponse, len(series))
+	for domain, data := range series {
+		var value float64
+		for _, v := range data {
+			if bandwidth {
+				if v.Value > value {
+					value = v.Value
+				}
+				continue
+			}
+			value += v.Value
+		}
+		responseData[domain] = int64(value)
+	}
+	return responseData
+}
+
+// DomainAccessDataStatic 域名访问数据统计信息
+func (f *Fake) DomainAccessDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return f.DomainAccessDataStaticWithContext(f.ctx, req)
+}
+
+// DomainAccessDataStaticWithContext 域名访问数据统计信息
+func (f *Fake) DomainAccessDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return call(ctx, f, "DomainAccessDataStatic", req, req == nil, func() (types.DomainAccessDataStaticResponse, error) {
+		return f.series(req.Domains, false, req.Metric, req.StartTime, req.EndTime, req.Interval)
+	})
+}
+
+// DomainOriginDataStatic 域名回源数据统计信息
+func (f *Fake) DomainOriginDataStatic(req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return f.DomainOriginDataStaticWithContext(f.ctx, req)
+}
+
+// DomainOriginDataStaticWithContext 域名回源数据统计信息
+func (f *Fake) DomainOriginDataStaticWithContext(ctx context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return call(ctx, f, "DomainOriginDataStatic", req, req == nil, func() (types.DomainOriginDataStaticResponse, error) {
+		series, err := f.series(req.Domains, true, req.Metric, req.StartTime, req.EndTime, req.Interval)
+		if err != nil {
+			return nil, err
+		}
+		return types.DomainOriginDataStaticResponse(series), nil
+	})
+}
+
+// ListTopUrlDataStatic 获取TOP URL访问数据
+func (f *Fake) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return f.ListTopUrlDataStaticWithContext(f.ctx, req)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL访问数据,按值降序返回
+func (f *Fake) ListTopUrlDataStaticWithContext(ctx context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return call(ctx, f, "ListTopUrlDataStatic", req, req == nil, func() ([]*types.ListTopUrlDataStaticResponse, error) {
+		if _, err := f.findDomain(req.Domain, ""); err != nil {
+			return nil, err
+		}
+		metric := int64(consts.DataAccessMetricTypeFlux)
+		if req.Filter == consts.ListTopFilterRequest {
+			metric = consts.DataAccessMetricTypeRequest
+		}
+		base := f.config.Stats(req.Domain, false, metric, req.StartTime)
+		responseData := make([]*types.ListTopUrlDataStaticResponse, 0, topUrlCount)
+		for i := 0; i < topUrlCount; i++ {
+			responseData = append(responseData, &types.ListTopUrlDataStaticResponse{
+				Url:   fmt.Sprintf("http://%s/path/%d", req.Domain, i+1),
+				Value: base * float64(topUrlCount-i),
+			})
+		}
+		return responseData, nil
+	})
+}
+
+// DomainAccessTotalData 域名访问总流量
+func (f *Fake) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return f.DomainAccessTotalDataWithContext(f.ctx, req)
+}
+
+// DomainAccessTotalDataWithContext 域名访问总流量,由5分钟粒度的数据汇总
+func (f *Fake) DomainAccessTotalDataWithContext(ctx context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return call(ctx, f, "DomainAccessTotalData", req, req == nil, func() (types.DataTotalDataResponse, error) {
+		series, err := f.series(req.Domains, false, req.Metric, req.StartTime, req.EndTime, consts.DataIntervalTypeFiveMinute)
+		if err != nil {
+			return nil, err
+		}
+		return total(series, req.Metric == consts.DataAccessMetricTypeBandwidth), nil
+	})
+}
+
+// DomainOriginTotalData 域名回源数据总流量
+func (f *Fake) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return f.DomainOriginTotalDataWithContext(f.ctx, req)
+}
+
+// DomainOriginTotalDataWithContext 域名回源数据总流量,由5分钟粒度的数据汇总
+func (f *Fake) DomainOriginTotalDataWithContext(ctx context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return call(ctx, f, "DomainOriginTotalData", req, req == nil, func() (types.DataTotalDataResponse, error) {
+		series, err := f.series(req.Domains, true, req.Metric, req.StartTime, req.EndTime, consts.DataIntervalTypeFiveMinute)
+		if err != nil {
+			return nil, err
+		}
+		return total(series, req.Metric == consts.DataOriginMetricTypeBandwidth), nil
+	})
+}
+
+// UserAccessRegionDistribution 用户访问区域分布
+func (f *Fake) UserAccessRegionDistribution(req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return f.UserAccessRegionDistributionWithContext(f.ctx, req)
+}
+
+// UserAccessRegionDistributionWithContext 用户访问区域分布,按域名的加速区域划分,全球加速时境内占80%
+func (f *Fake) UserAccessRegionDistributionWithContext(ctx context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return call(ctx, f, "UserAccessRegionDistribution", req, req == nil, func() (types.UserAccessRegionDistributionResponse, error) {
+		series, err := f.series(req.Domains, false, req.Metric, req.StartTime, req.EndTime, consts.DataIntervalTypeFiveMinute)
+		if err != nil {
+			return nil, err
+		}
+		responseData := make(types.UserAccessRegionDistributionResponse, len(series))
+		for domain, value := range total(series, false) {
+			d, err := f.findDomain(domain, "")
+			if err != nil {
+				return nil, err
+			}
+			switch d.areaCode {
+			case consts.AreaCodeChinaMainland:
+				responseData[domain] = &types.RegionDistribution{MainLandValue: value}
+			case consts.AreaCodeOversea:
+				responseData[domain] = &types.RegionDistribution{OverSeaValue: value}
+			default:
+				mainland := value * 8 / 10
+				responseData[domain] = &types.RegionDistribution{MainLandValue: mainland, OverSeaValue: value - mainland}
+			}
+		}
+		return responseData, nil
+	})
+}
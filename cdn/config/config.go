@@ -0,0 +1,140 @@
+// Package config 定义带版本号的域名配置文件格式,支持YAML与JSON,提供加载、校验与JSON Schema生成
+//
+// 文件中的域名与 reconcile.DomainState 一一对应,配置项使用 types 与 entity 中的json字段名,
+// 没有json标签的字段使用下划线形式的字段名,如 ip_filter_conf。取值为consts代码的字段使用可读的名称,
+// 如 origin_protocol: https、origin_priority: backup,开关类字段同时接受 on/off 与 true/false:
+//
+//	version: v1
+//	domains:
+//	  - domain: www.example.com
+//	    create:
+//	      area_code: mainland
+//	      channel_type: web
+//	      origin_protocol: https
+//	      sources:
+//	        - origin_type: domain
+//	          origin_address_list: origin.example.com
+//	          origin_priority: primary
+//	    enabled: true
+//	    config:
+//	      https_conf:
+//	        https_status: on
+//	        tls_version: [TLSv1.2, TLSv1.3]
+//	        http_two: on
+//	      cache_list_conf:
+//	        - cache_type: file_suffix
+//	          cache_content: [jpg, png]
+//	          cache_status: on
+//	          cache_ttl: 1
+//	          cache_unit: day
+//	  - domain: old.example.com
+//	    absent: true
+//
+// 域名、更新动作与域名ID由文件结构决定,不能在create与config中填写
+// 端口的取值范围为0到65535,0表示使用默认端口
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/reconcile"
+	"gopkg.in/yaml.v3"
+	"os"
+)
+
+// Version 当前的配置文件版本
+const Version = "v1"
+
+// versions 支持加载的配置文件版本
+var versions = []string{Version}
+
+// File 配置文件
+type File struct {
+	Version string                   `json:"version"` // 配置文件版本
+	Domains []*reconcile.DomainState `json:"domains"` // 域名的期望状态
+}
+
+// Load 加载YAML或JSON格式的配置文件内容,校验失败时返回 Errors,包含全部字段错误
+func Load(data []byte) (*File, error) {
+	var tree interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	d := &decoder{errs: make(Errors, 0)}
+	//校验失败的字段被置为空,其余字段仍然参与版本号与域名的校验
+	out := d.decode(tree, fileType, nil, nil, "")
+	buf, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	f := &File{}
+	if err := json.Unmarshal(buf, f); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	if err := f.Validate(); err != nil {
+		d.errs = append(d.errs, err.(Errors)...)
+	}
+	if len(d.errs) > 0 {
+		return nil, d.errs
+	}
+	return f, nil
+}
+
+// LoadFile 读取并加载配置文件
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+// Validate 校验配置文件内容,返回全部字段错误,校验通过时返回nil
+func Validate(data []byte) error {
+	_, err := Load(data)
+	return err
+}
+
+// Validate 校验版本号与域名,返回全部字段错误,校验通过时返回nil
+func (f *File) Validate() error {
+	errs := make(Errors, 0)
+	if f.Version == "" {
+		errs = append(errs, &FieldError{Path: "version", Message: "is required"})
+	} else if !contains(versions, f.Version) {
+		errs = append(errs, &FieldError{Path: "version", Message: fmt.Sprintf("unsupported version %q, must be one of %v", f.Version, versions)})
+	}
+	seen := make(map[string]int, len(f.Domains))
+	for i, v := range f.Domains {
+		path := fmt.Sprintf("domains[%d]", i)
+		if v == nil {
+			errs = append(errs, &FieldError{Path: path, Message: "is required"})
+			continue
+		}
+		if v.Domain == "" {
+			errs = append(errs, &FieldError{Path: path + ".domain", Message: "is required"})
+		} else if j, ok := seen[v.Domain]; ok {
+			errs = append(errs, &FieldError{Path: path + ".domain", Message: fmt.Sprintf("duplicates domains[%d]", j)})
+		} else {
+			seen[v.Domain] = i
+		}
+		if v.Absent && (v.Create != nil || v.Config != nil || v.Enabled != nil) {
+			errs = append(errs, &FieldError{Path: path, Message: "absent domain must not have create, config or enabled"})
+		}
+		if v.Create != nil && len(v.Create.Sources) == 0 {
+			errs = append(errs, &FieldError{Path: path + ".create.sources", Message: "at least one source is required"})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,309 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/reconcile"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/schema.json")
+
+const exampleYaml = `
+version: v1
+domains:
+  - domain: www.example.com
+    create:
+      area_code: mainland
+      channel_type: web
+      origin_protocol: https
+      sources:
+        - origin_type: domain
+          origin_address_list: origin.example.com
+          origin_https_port: 443
+          origin_priority: primary
+    enabled: true
+    config:
+      https_conf:
+        https_status: on
+        tls_version: [TLSv1.2, TLSv1.3]
+        http_two: on
+      ip_filter_conf:
+        status: on
+        ip_filter_conf:
+          - ip_type: whitelist
+            ip_list: [10.0.0.1]
+      cache_list_conf:
+        - cache_type: file_suffix
+          cache_content: [jpg, png]
+          cache_status: on
+          cache_ttl: 1
+          cache_unit: day
+  - domain: old.example.com
+    absent: true
+`
+
+const exampleJson = `{
+  "version": "v1",
+  "domains": [
+    {
+      "domain": "www.example.com",
+      "create": {
+        "area_code": "mainland",
+        "channel_type": "web",
+        "origin_protocol": "https",
+        "sources": [
+          {"origin_type": "domain", "origin_address_list": "origin.example.com", "origin_https_port": 443, "origin_priority": "primary"}
+        ]
+      },
+      "enabled": true,
+      "config": {
+        "https_conf": {"https_status": "on", "tls_version": ["TLSv1.2", "TLSv1.3"], "http_two": true},
+        "ip_filter_conf": {"status": true, "ip_filter_conf": [{"ip_type": "whitelist", "ip_list": ["10.0.0.1"]}]},
+        "cache_list_conf": [
+          {"cache_type": "file_suffix", "cache_content": ["jpg", "png"], "cache_status": "on", "cache_ttl": 1, "cache_unit": "day"}
+        ]
+      }
+    },
+    {"domain": "old.example.com", "absent": true}
+  ]
+}`
+
+func TestLoad(t *testing.T) {
+	enabled := true
+	want := &File{
+		Version: Version,
+		Domains: []*reconcile.DomainState{
+			{
+				Domain: "www.example.com",
+				Create: &types.CreateDomainRequest{
+					AreaCode:       consts.AreaCodeChinaMainland,
+					ChannelType:    consts.ChannelTypeWeb,
+					OriginProtocol: consts.OriginProtocolHttps,
+					Sources: []*entity.OriginServerConf{
+						{OriginType: consts.OriginTypeDomain, OriginAddressList: "origin.example.com", OriginHttpsPort: 443, OriginPriority: consts.OriginPriorityPrimary},
+					},
+				},
+				Enabled: &enabled,
+				Config: &types.UpdateDomainRequest{
+					HttpsConf: &entity.HttpsConf{
+						HttpsStatus: consts.SwitchOn,
+						TlsVersion:  []int64{consts.HttpsTlsVersionSSLv2, consts.HttpsTlsVersionTLSv3},
+						HttpTwo:     consts.SwitchOn,
+					},
+					IpFilterConf: &types.IpFilterConf{
+						Status:       consts.SwitchOn,
+						IpFilterConf: []*entity.IpFilter{{IpType: consts.WhiteList, IpList: []string{"10.0.0.1"}}},
+					},
+					CacheListConf: []*entity.CacheListItem{
+						{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{"jpg", "png"}, CacheStatus: consts.CacheStatusOn, CacheTTL: 1, CacheUnit: consts.CacheUnitDay},
+					},
+				},
+			},
+			{Domain: "old.example.com", Absent: true},
+		},
+	}
+	dir := t.TempDir()
+	//YAML与JSON加载结果一致
+	for name, data := range map[string]string{"domains.yaml": exampleYaml, "domains.json": exampleJson} {
+		t.Run(name, func(t *testing.T) {
+			f, err := Load([]byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(f, want) {
+				t.Fatalf("got %s", mustJson(t, f))
+			}
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if f, err := LoadFile(path); err != nil || !reflect.DeepEqual(f, want) {
+				t.Fatalf("LoadFile: got %v", err)
+			}
+		})
+	}
+	if _, err := LoadFile(filepath.Join(dir, "none.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("got %v, want not exist", err)
+	}
+}
+
+func TestEnums(t *testing.T) {
+	cases := []struct {
+		name  string
+		yaml  string
+		check func(c *types.UpdateDomainRequest) bool
+	}{
+		{"on", "https_conf: {https_status: on}", func(c *types.UpdateDomainRequest) bool {
+			return c.HttpsConf.HttpsStatus == consts.SwitchOn
+		}},
+		{"off", "https_conf: {https_status: off}", func(c *types.UpdateDomainRequest) bool {
+			return c.HttpsConf.HttpsStatus == consts.SwitchOff
+		}},
+		//开关类字段接受布尔值
+		{"true", "https_conf: {https_status: true}", func(c *types.UpdateDomainRequest) bool {
+			return c.HttpsConf.HttpsStatus == consts.SwitchOn
+		}},
+		{"false", `https_conf: {https_status: false, http_two: "on"}`, func(c *types.UpdateDomainRequest) bool {
+			return c.HttpsConf.HttpsStatus == consts.SwitchOff && c.HttpsConf.HttpTwo == consts.SwitchOn
+		}},
+		//数字形式的名称同时接受整数与字符串
+		{"redirect code int", "request_url_rewrite_conf: [{redirect_code: 302}]", func(c *types.UpdateDomainRequest) bool {
+			return c.RequestUrlRewriteConf[0].RedirectCode == consts.RedirectCode302
+		}},
+		{"redirect code string", `https_conf: {jump_manner: "301"}`, func(c *types.UpdateDomainRequest) bool {
+			return c.HttpsConf.JumpManner == consts.RedirectCode301
+		}},
+		{"sni unchanged", "origin_conf: {origin_sni_switch: unchanged}", func(c *types.UpdateDomainRequest) bool {
+			return c.OriginConf.OriginSniSwitch == -1
+		}},
+		{"sni bool", "origin_conf: {origin_sni_switch: true}", func(c *types.UpdateDomainRequest) bool {
+			return c.OriginConf.OriginSniSwitch == consts.SwitchOn
+		}},
+		{"cache", "cache_list_conf: [{cache_type: content_type, cache_status: follow, cache_unit: hour, parameters_status: exclude}]", func(c *types.UpdateDomainRequest) bool {
+			v := c.CacheListConf[0]
+			return v.CacheType == consts.RuleTypeContentType && v.CacheStatus == consts.CacheStatusFollow && v.CacheUnit == consts.CacheUnitHour && v.ParametersStatus == consts.CacheParameterStatusExclude
+		}},
+		{"auth", "auth_conf: {auth_manner: type_c, encrypt_mannger: sha256, time_format: hex, status: on}", func(c *types.UpdateDomainRequest) bool {
+			v := c.AuthConf
+			return v.AuthManner == consts.AccessAuthMannerTypeC && v.EncryptMannger == consts.AccessAuthEncryptMannerSha256 && v.TimeFormat == consts.AccessAuthTimeFormatHex && v.Status == consts.SwitchOn
+		}},
+		{"compression", "intelligent_compression_conf: {status: on, intelligent_compression_conf: [{compress_method: brotli}]}", func(c *types.UpdateDomainRequest) bool {
+			v := c.IntelligentCompressionConf
+			return v.Status == consts.SwitchOn && v.IntelligentCompressionConf[0].CompressMethod == consts.IntelligentCompressionCompressMethodBrotli
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, err := Load([]byte(domainYaml(c.yaml)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !c.check(f.Domains[0].Config) {
+				t.Fatalf("got %s", mustJson(t, f.Domains[0].Config))
+			}
+		})
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{"unknown field", "version: v1\ndomains:\n  - domain: a.example.com\n    config:\n      https_conf: {https_status: on, http3: on}\n      ip_filter: {}",
+			[]string{"domains[0].config.https_conf.http3: unknown field", "domains[0].config.ip_filter: unknown field"}},
+		//由文件结构决定的字段不能填写
+		{"excluded field", "version: v1\ndomains:\n  - domain: a.example.com\n    config: {domain: b.example.com, update_action: UpdateFullConf}",
+			[]string{"domains[0].config.domain: unknown field", "domains[0].config.update_action: unknown field"}},
+		{"enum", "version: v1\ndomains:\n  - domain: a.example.com\n    config:\n      https_conf: {tls_version: [TLSv1.2, TLSv2], jump_manner: 307, hsts_status: 1}",
+			[]string{
+				"domains[0].config.https_conf.tls_version[1]: must be one of TLSv1.0, TLSv1.1, TLSv1.2, TLSv1.3",
+				"domains[0].config.https_conf.jump_manner: must be one of 301, 302",
+				"domains[0].config.https_conf.hsts_status: must be one of off, on",
+			}},
+		//非开关类字段不接受布尔值
+		{"enum bool", "version: v1\ndomains:\n  - domain: a.example.com\n    create: {area_code: true, sources: [{}]}",
+			[]string{"domains[0].create.area_code: must be one of mainland, overseas, global"}},
+		{"types", "version: v1\ndomains:\n  - domain: a.example.com\n    enabled: yes\n    create: {sources: {origin_type: ip}}\n    config:\n      cache_list_conf: [{cache_ttl: 1.5, cache_content: jpg}]\n      https_conf: [on]\n      auth_conf: {auth_key: 123}",
+			[]string{
+				"domains[0].create.sources: must be an array",
+				"domains[0].config.auth_conf.auth_key: must be a string",
+				"domains[0].config.cache_list_conf[0].cache_content: must be an array",
+				"domains[0].config.cache_list_conf[0].cache_ttl: must be an integer",
+				"domains[0].config.https_conf: must be an object",
+				"domains[0].enabled: must be a boolean",
+				//类型错误之后是版本号与域名的校验错误
+				"domains[0].create.sources: at least one source is required",
+			}},
+		{"port", "version: v1\ndomains:\n  - domain: a.example.com\n    create:\n      sources: [{origin_http_port: 99999999999, origin_https_port: -1}]\n    config:\n      origin_advance_server_conf: [{origin_http_port: 65536}]",
+			[]string{
+				"domains[0].create.sources[0].origin_http_port: must be between 0 and 65535",
+				"domains[0].create.sources[0].origin_https_port: must be between 0 and 65535",
+				"domains[0].config.origin_advance_server_conf[0].origin_http_port: must be between 0 and 65535",
+			}},
+		{"duplicate domain", "version: v1\ndomains:\n  - domain: a.example.com\n  - domain: b.example.com\n  - domain: a.example.com",
+			[]string{"domains[2].domain: duplicates domains[0]"}},
+		{"missing domain", "version: v1\ndomains:\n  - enabled: true\n  -",
+			[]string{"domains[0].domain: is required", "domains[1]: is required"}},
+		{"absent", "version: v1\ndomains:\n  - domain: a.example.com\n    absent: true\n    enabled: false",
+			[]string{"domains[0]: absent domain must not have create, config or enabled"}},
+		{"missing version", "domains: []", []string{"version: is required"}},
+		{"unsupported version", "version: v2\ndomains: []", []string{`version: unsupported version "v2", must be one of [v1]`}},
+		{"root", "- v1", []string{"must be an object", "version: is required"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, err := Load([]byte(c.yaml))
+			if f != nil {
+				t.Fatalf("got file %s", mustJson(t, f))
+			}
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("got %v, want Errors", err)
+			}
+			got := make([]string, 0, len(errs))
+			for _, v := range errs {
+				got = append(got, v.Error())
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got errors\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(c.want, "\n"))
+			}
+			if !errors.Is(err, cdnerr.ErrInvalidParameter) {
+				t.Fatal("want invalid parameter")
+			}
+			if Validate([]byte(c.yaml)) == nil {
+				t.Fatal("Validate: want error")
+			}
+		})
+	}
+	//语法错误不是字段错误
+	if _, err := Load([]byte("version: [v1")); err == nil || errors.As(err, new(Errors)) {
+		t.Fatalf("got %v, want parse error", err)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	data, err := Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("testdata", "schema.json")
+	if *update {
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(append(data, '\n'), want) {
+		t.Fatal("schema changed, run go test ./cdn/config -run TestSchema -update and review testdata/schema.json")
+	}
+}
+
+// domainYaml 生成只包含一个域名配置的文件
+func domainYaml(config string) string {
+	return "version: v1\ndomains:\n  - domain: www.example.com\n    config:\n      " + strings.ReplaceAll(config, "\n", "\n      ")
+}
+
+func mustJson(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
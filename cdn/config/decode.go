@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var fileType = reflect.TypeOf(File{})
+
+// field 文件中的一个字段
+type field struct {
+	name string       // 文件中的字段名
+	json string       // 结构体的json字段名
+	typ  reflect.Type // 字段类型
+	enum *enum        // 取值为consts代码时的名称,切片字段作用于每个元素
+	rng  *bound       // 整数字段的取值范围
+}
+
+// fieldsOf 按定义顺序返回结构体在文件中的字段,排除由文件结构决定的字段
+func fieldsOf(t reflect.Type) []*field {
+	list := make([]*field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		f := &field{json: sf.Name, typ: sf.Type}
+		if tag := strings.Split(sf.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			f.json = tag
+		}
+		f.name = f.json
+		if f.name == sf.Name {
+			f.name = snakeCase(sf.Name)
+		}
+		if excludedFields[t][f.name] {
+			continue
+		}
+		f.enum = enums[t][f.name]
+		f.rng = bounds[t][f.name]
+		list = append(list, f)
+	}
+	return list
+}
+
+// snakeCase 驼峰形式的字段名转换为下划线形式
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// decoder 将YAML解析出的值按结构体类型校验,可读名称转换为consts代码,字段名转换为json字段名
+type decoder struct {
+	errs Errors
+}
+
+func (d *decoder) fail(path, format string, args ...interface{}) {
+	d.errs = append(d.errs, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *decoder) decode(v interface{}, t reflect.Type, e *enum, r *bound, path string) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return d.decode(v, t.Elem(), e, r, path)
+	case reflect.Struct:
+		return d.decodeStruct(v, t, path)
+	case reflect.Slice:
+		list, ok := v.([]interface{})
+		if !ok {
+			d.fail(path, "must be an array")
+			return nil
+		}
+		out := make([]interface{}, len(list))
+		for i, item := range list {
+			out[i] = d.decode(item, t.Elem(), e, r, fmt.Sprintf("%s[%d]", path, i))
+		}
+		return out
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		if e != nil {
+			return d.decodeEnum(v, e, path)
+		}
+		n, ok := toInt(v)
+		if !ok {
+			d.fail(path, "must be an integer")
+			return nil
+		}
+		if r != nil && (n < r.min || n > r.max) {
+			d.fail(path, "must be between %d and %d", r.min, r.max)
+			return nil
+		}
+		return n
+	case reflect.String:
+		s, ok := v.(string)
+		if !ok {
+			d.fail(path, "must be a string")
+			return nil
+		}
+		return s
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			d.fail(path, "must be a boolean")
+			return nil
+		}
+		return b
+	default:
+		d.fail(path, "unsupported type %s", t)
+		return nil
+	}
+}
+
+func (d *decoder) decodeStruct(v interface{}, t reflect.Type, path string) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		d.fail(path, "must be an object")
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	known := make(map[string]bool, len(m))
+	for _, f := range fieldsOf(t) {
+		known[f.name] = true
+		if item, ok := m[f.name]; ok {
+			out[f.json] = d.decode(item, f.typ, f.enum, f.rng, joinPath(path, f.name))
+		}
+	}
+	unknown := make([]string, 0)
+	for k := range m {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		d.fail(joinPath(path, k), "unknown field")
+	}
+	return out
+}
+
+// decodeEnum 名称转换为代码,开关类字段同时接受布尔值,数字形式的名称(如跳转码301)同时接受整数
+func (d *decoder) decodeEnum(v interface{}, e *enum, path string) interface{} {
+	switch x := v.(type) {
+	case string:
+		if n, ok := e.value(x); ok {
+			return n
+		}
+	case bool:
+		if e.bool {
+			if x {
+				return int64(1)
+			}
+			return int64(0)
+		}
+	default:
+		if n, ok := toInt(v); ok {
+			if code, ok := e.value(strconv.FormatInt(n, 10)); ok {
+				return code
+			}
+		}
+	}
+	d.fail(path, "must be one of %s", strings.Join(e.names, ", "))
+	return nil
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch x := v.(type) {
+	case int:
+		return int64(x), true
+	case int64:
+		return x, true
+	case uint64:
+		if x <= math.MaxInt64 {
+			return int64(x), true
+		}
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) <= math.MaxInt64 {
+			return int64(x), true
+		}
+	}
+	return 0, false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
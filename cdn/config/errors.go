@@ -0,0 +1,42 @@
+package config
+
+import (
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"strings"
+)
+
+// FieldError 字段错误,errors.Is 可以匹配 cdnerr.ErrInvalidParameter
+type FieldError struct {
+	Path    string // 字段路径,如 domains[0].config.https_conf.tls_version[1]
+	Message string // 错误描述
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+func (e *FieldError) Unwrap() error {
+	return cdnerr.ErrInvalidParameter
+}
+
+// Errors 配置文件中的全部字段错误,类型与取值错误在前,版本号与域名的校验错误在后,各自按字段的定义顺序排列
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	list := make([]string, 0, len(e))
+	for _, v := range e {
+		list = append(list, v.Error())
+	}
+	return strings.Join(list, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	list := make([]error, 0, len(e))
+	for _, v := range e {
+		list = append(list, v)
+	}
+	return list
+}
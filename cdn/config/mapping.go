@@ -0,0 +1,202 @@
+package config
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/reconcile"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"reflect"
+)
+
+// enum consts代码的可读名称
+type enum struct {
+	names  []string
+	values []int64
+	bool   bool // 开关类字段,true与false分别对应1与0
+}
+
+// newEnum 名称按顺序对应从0开始的代码,与consts中的iota定义一致
+func newEnum(names ...string) *enum {
+	e := &enum{names: names, values: make([]int64, len(names))}
+	for i := range names {
+		e.values[i] = int64(i)
+	}
+	return e
+}
+
+func (e *enum) value(name string) (int64, bool) {
+	for i, v := range e.names {
+		if v == name {
+			return e.values[i], true
+		}
+	}
+	return 0, false
+}
+
+var (
+	switchEnum = &enum{names: []string{"off", "on"}, values: []int64{consts.SwitchOff, consts.SwitchOn}, bool: true}
+	// originSniEnum unchanged表示不修改Sni配置
+	originSniEnum        = &enum{names: []string{"unchanged", "off", "on"}, values: []int64{-1, consts.SwitchOff, consts.SwitchOn}, bool: true}
+	areaCodeEnum         = newEnum("mainland", "overseas", "global")
+	channelTypeEnum      = newEnum("web", "download", "media", "hybrid")
+	originProtocolEnum   = newEnum("http", "https", "follow")
+	originTypeEnum       = newEnum("ip", "domain", "bucket")
+	originPriorityEnum   = newEnum("primary", "backup")
+	urlMatchModeEnum     = newEnum("file", "directory")
+	headerActionEnum     = newEnum("delete", "set", "add")
+	ruleTypeEnum         = newEnum("all", "file_suffix", "directory", "path", "index", "content_type")
+	cacheStatusEnum      = newEnum("follow", "off", "on")
+	cacheUnitEnum        = newEnum("second", "minute", "hour", "day")
+	parameterStatusEnum  = newEnum("off", "all", "include", "exclude")
+	listTypeEnum         = newEnum("blacklist", "whitelist")
+	effectiveTypeEnum    = newEnum("all", "file_suffix", "directory", "path", "index")
+	authMannerEnum       = newEnum("type_a", "type_b", "type_c", "type_d")
+	authRangeEnum        = newEnum("all", "include", "exclude")
+	inheritTimeTypeEnum  = newEnum("parent", "system")
+	encryptMannerEnum    = newEnum("md5", "sha256")
+	timeFormatEnum       = newEnum("dec", "hex")
+	requestMethodEnum    = newEnum("get", "post", "head")
+	timeoutActionEnum    = newEnum("return_200", "return_403")
+	originMateMethodEnum = newEnum("all", "url", "regex", "path")
+	rewriteTypeEnum      = newEnum("directory", "full_path")
+	redirectCodeEnum     = newEnum("301", "302")
+	compressTypeEnum     = newEnum("all", "file_suffix", "content_type")
+	compressMethodEnum   = newEnum("gzip", "brotli")
+	tlsVersionEnum       = newEnum("TLSv1.0", "TLSv1.1", "TLSv1.2", "TLSv1.3")
+	jumpTypeEnum         = newEnum("http", "https")
+	certTypeEnum         = newEnum("china", "global")
+)
+
+// enums 各结构体中取值为consts代码的字段,键为文件中的字段名
+var enums = map[reflect.Type]map[string]*enum{
+	reflect.TypeOf(types.CreateDomainRequest{}): {
+		"area_code":       areaCodeEnum,
+		"channel_type":    channelTypeEnum,
+		"origin_protocol": originProtocolEnum,
+	},
+	reflect.TypeOf(types.IpFilterConf{}):               {"status": switchEnum},
+	reflect.TypeOf(types.UserAgentConf{}):              {"status": switchEnum},
+	reflect.TypeOf(types.SpeedConf{}):                  {"status": switchEnum},
+	reflect.TypeOf(types.IntelligentCompressionConf{}): {"status": switchEnum},
+	reflect.TypeOf(entity.UpdateCdnDomainBaseConf{}): {
+		"area_code":    areaCodeEnum,
+		"support_ipv6": switchEnum,
+	},
+	reflect.TypeOf(entity.OriginConf{}): {
+		"origin_protocol":   originProtocolEnum,
+		"origin_sni_switch": originSniEnum,
+		"origin_range":      switchEnum,
+		"origin_follow":     switchEnum,
+		"origin_etag":       switchEnum,
+	},
+	reflect.TypeOf(entity.OriginServerConf{}): {
+		"origin_type":     originTypeEnum,
+		"origin_priority": originPriorityEnum,
+	},
+	reflect.TypeOf(entity.OriginAdvanceServerConf{}): {
+		"url_match_mode": urlMatchModeEnum,
+		"origin_type":    originTypeEnum,
+	},
+	reflect.TypeOf(entity.OriginRequestHeaderConf{}): {"action": headerActionEnum},
+	reflect.TypeOf(entity.CacheListItem{}): {
+		"cache_type":        ruleTypeEnum,
+		"cache_unit":        cacheUnitEnum,
+		"cache_status":      cacheStatusEnum,
+		"use_regex":         switchEnum,
+		"parameters_status": parameterStatusEnum,
+		"capitalization":    switchEnum,
+	},
+	reflect.TypeOf(entity.CacheCodeListItem{}): {"cache_unit": cacheUnitEnum},
+	reflect.TypeOf(entity.BrowserCacheListItem{}): {
+		"cache_type":   ruleTypeEnum,
+		"cache_unit":   cacheUnitEnum,
+		"cache_status": cacheStatusEnum,
+	},
+	reflect.TypeOf(entity.IpFilter{}): {
+		"ip_type":        listTypeEnum,
+		"effective_type": effectiveTypeEnum,
+	},
+	reflect.TypeOf(entity.Referer{}): {
+		"referer_type":  listTypeEnum,
+		"include_empty": switchEnum,
+		"status":        switchEnum,
+	},
+	reflect.TypeOf(entity.UserAgent{}): {
+		"agent_type":     listTypeEnum,
+		"effective_type": effectiveTypeEnum,
+	},
+	reflect.TypeOf(entity.AuthConf{}): {
+		"auth_manner":        authMannerEnum,
+		"auth_range":         authRangeEnum,
+		"interit_start_time": inheritTimeTypeEnum,
+		"encrypt_mannger":    encryptMannerEnum,
+		"time_format":        timeFormatEnum,
+		"status":             switchEnum,
+	},
+	reflect.TypeOf(entity.RemoteAuthConf{}): {
+		"req_method":     requestMethodEnum,
+		"file_type":      ruleTypeEnum,
+		"timeout_action": timeoutActionEnum,
+		"status":         switchEnum,
+	},
+	reflect.TypeOf(entity.IpFrequencyConf{}): {"status": switchEnum},
+	reflect.TypeOf(entity.OriginUrlConf{}):   {"mate_method": originMateMethodEnum},
+	reflect.TypeOf(entity.RequestUrlRewriteConf{}): {
+		"mate_method":   rewriteTypeEnum,
+		"redirect_code": redirectCodeEnum,
+	},
+	reflect.TypeOf(entity.CustomErrorPageConf{}): {"redirect_code": redirectCodeEnum},
+	reflect.TypeOf(entity.SpeedConf{}):           {"rule_type": ruleTypeEnum},
+	reflect.TypeOf(entity.ResponseHeaderConf{}):  {"action": headerActionEnum},
+	reflect.TypeOf(entity.IntelligentCompressionConf{}): {
+		"compress_type":   compressTypeEnum,
+		"compress_method": compressMethodEnum,
+		"status":          switchEnum,
+	},
+	reflect.TypeOf(entity.HttpsConf{}): {
+		"https_status":      switchEnum,
+		"tls_version":       tlsVersionEnum,
+		"http_two":          switchEnum,
+		"jump_force_status": switchEnum,
+		"jump_type":         jumpTypeEnum,
+		"jump_manner":       redirectCodeEnum,
+		"hsts_status":       switchEnum,
+		"hsts_subdomain":    switchEnum,
+		"ocsp_status":       switchEnum,
+		"quic_status":       switchEnum,
+		"cert_type":         certTypeEnum,
+	},
+}
+
+// bound 整数字段的取值范围
+type bound struct {
+	min int64
+	max int64
+}
+
+// portBound 端口,0表示使用默认端口
+var portBound = &bound{min: 0, max: 65535}
+
+// bounds 各结构体中有取值范围的整数字段,键为文件中的字段名
+var bounds = map[reflect.Type]map[string]*bound{
+	reflect.TypeOf(entity.OriginServerConf{}): {
+		"origin_http_port":  portBound,
+		"origin_https_port": portBound,
+	},
+	reflect.TypeOf(entity.OriginAdvanceServerConf{}): {
+		"origin_http_port":  portBound,
+		"origin_https_port": portBound,
+	},
+}
+
+// excludedFields 由文件结构决定、不能在文件中填写的字段
+var excludedFields = map[reflect.Type]map[string]bool{
+	reflect.TypeOf(types.CreateDomainRequest{}): {"domain": true},
+	reflect.TypeOf(types.UpdateDomainRequest{}): {"update_action": true, "domain": true, "domain_id": true},
+}
+
+// required 必填字段
+var required = map[reflect.Type][]string{
+	fileType:                                {"version", "domains"},
+	reflect.TypeOf(reconcile.DomainState{}): {"domain"},
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// SchemaId 配置文件JSON Schema的标识
+const SchemaId = "https://github.com/run-bigpig/cloud-sdk/cdn/config/" + Version + "/schema.json"
+
+// Schema 根据结构体生成当前版本配置文件的JSON Schema(draft 2020-12),可用于编辑器补全与CI校验
+func Schema() ([]byte, error) {
+	schema := schemaOf(fileType, nil, nil)
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = SchemaId
+	schema["title"] = "cdn domain configuration " + Version
+	schema["properties"].(map[string]interface{})["version"] = map[string]interface{}{"enum": versions}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func schemaOf(t reflect.Type, e *enum, r *bound) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), e, r)
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for _, f := range fieldsOf(t) {
+			properties[f.name] = schemaOf(f.typ, f.enum, f.rng)
+		}
+		schema := map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		if list := required[t]; len(list) > 0 {
+			schema["required"] = list
+		}
+		return schema
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": schemaOf(t.Elem(), e, r)}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		if e != nil {
+			return enumSchema(e)
+		}
+		if r != nil {
+			return map[string]interface{}{"type": "integer", "minimum": r.min, "maximum": r.max}
+		}
+		return map[string]interface{}{"type": "integer"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// enumSchema 与 decoder.decodeEnum 接受的取值一致
+func enumSchema(e *enum) map[string]interface{} {
+	values := make([]interface{}, 0, len(e.names)+2)
+	for _, v := range e.names {
+		values = append(values, v)
+	}
+	for _, v := range e.names {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			values = append(values, n)
+		}
+	}
+	if e.bool {
+		values = append(values, true, false)
+	}
+	return map[string]interface{}{"enum": values}
+}
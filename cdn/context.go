@@ -0,0 +1,139 @@
+package cdn
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+)
+
+// WithContext 将Cdn转换为CdnWithContext,未实现CdnWithContext的Cdn会忽略传入的ctx
+func WithContext(c Cdn) CdnWithContext {
+	if cc, ok := c.(CdnWithContext); ok {
+		return cc
+	}
+	return &contextAdapter{Cdn: c}
+}
+
+// contextAdapter 为不支持context的Cdn补充WithContext方法
+type contextAdapter struct {
+	Cdn
+}
+
+// CreateDomainWithContext 创建域名
+func (a *contextAdapter) CreateDomainWithContext(_ context.Context, req *types.CreateDomainRequest) error {
+	return a.CreateDomain(req)
+}
+
+// UpdateDomainWithContext 更新域名
+func (a *contextAdapter) UpdateDomainWithContext(_ context.Context, req *types.UpdateDomainRequest) error {
+	return a.UpdateDomain(req)
+}
+
+// DisableDomainWithContext 停用域名
+func (a *contextAdapter) DisableDomainWithContext(_ context.Context, req *types.DisableDomainRequest) error {
+	return a.DisableDomain(req)
+}
+
+// EnableDomainWithContext 启用域名
+func (a *contextAdapter) EnableDomainWithContext(_ context.Context, req *types.EnableDomainRequest) error {
+	return a.EnableDomain(req)
+}
+
+// DeleteDomainWithContext 删除域名
+func (a *contextAdapter) DeleteDomainWithContext(_ context.Context, req *types.DeleteDomainRequest) error {
+	return a.DeleteDomain(req)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (a *contextAdapter) CreateVerifyRecordWithContext(_ context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return a.CreateVerifyRecord(req)
+}
+
+// VerifyDomainRecordWithContext 验证域名
+func (a *contextAdapter) VerifyDomainRecordWithContext(_ context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return a.VerifyDomainRecord(req)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (a *contextAdapter) ShowDomainDetailWithContext(_ context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return a.ShowDomainDetail(req)
+}
+
+// ShowDomainConfig 获取域名完整配置,被包装的Cdn未实现 ConfigReader 时返回 cdnerr.ErrUnsupported
+func (a *contextAdapter) ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return ShowDomainConfig(context.Background(), a.Cdn, req)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置
+func (a *contextAdapter) ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return ShowDomainConfig(ctx, a.Cdn, req)
+}
+
+// ShowDomainStatusListWithContext 获取指定状态域名列表
+func (a *contextAdapter) ShowDomainStatusListWithContext(_ context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return a.ShowDomainStatusList(req)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (a *contextAdapter) PurgePathCacheWithContext(_ context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return a.PurgePathCache(req)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (a *contextAdapter) PurgeUrlsCacheWithContext(_ context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return a.PurgeUrlsCache(req)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (a *contextAdapter) PushUrlsCacheWithContext(_ context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return a.PushUrlsCache(req)
+}
+
+// ShowPurgeTaskStatusWithContext 获取刷新任务状态
+func (a *contextAdapter) ShowPurgeTaskStatusWithContext(_ context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return a.ShowPurgeTaskStatus(req)
+}
+
+// ShowPushTaskStatusWithContext 获取预热任务状态
+func (a *contextAdapter) ShowPushTaskStatusWithContext(_ context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return a.ShowPushTaskStatus(req)
+}
+
+// ShowPurgeTaskListWithContext 获取刷新任务列表
+func (a *contextAdapter) ShowPurgeTaskListWithContext(_ context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return a.ShowPurgeTaskList(req)
+}
+
+// ShowPushTaskListWithContext 获取预热任务列表
+func (a *contextAdapter) ShowPushTaskListWithContext(_ context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return a.ShowPushTaskList(req)
+}
+
+// DomainAccessDataStaticWithContext 域名访问数据统计信息
+func (a *contextAdapter) DomainAccessDataStaticWithContext(_ context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return a.DomainAccessDataStatic(req)
+}
+
+// DomainOriginDataStaticWithContext 域名回源数据统计信息
+func (a *contextAdapter) DomainOriginDataStaticWithContext(_ context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return a.DomainOriginDataStatic(req)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL访问数据
+func (a *contextAdapter) ListTopUrlDataStaticWithContext(_ context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return a.ListTopUrlDataStatic(req)
+}
+
+// DomainAccessTotalDataWithContext 域名访问总流量
+func (a *contextAdapter) DomainAccessTotalDataWithContext(_ context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return a.DomainAccessTotalData(req)
+}
+
+// DomainOriginTotalDataWithContext 域名回源数据总流量
+func (a *contextAdapter) DomainOriginTotalDataWithContext(_ context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return a.DomainOriginTotalData(req)
+}
+
+// UserAccessRegionDistributionWithContext 用户访问区域分布
+func (a *contextAdapter) UserAccessRegionDistributionWithContext(_ context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return a.UserAccessRegionDistribution(req)
+}
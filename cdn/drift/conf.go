@@ -0,0 +1,372 @@
+package drift
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"strconv"
+	"strings"
+)
+
+func (d *differ) diffBaseConf(desired, live *types.UpdateDomainRequest) {
+	if desired.CdnDomain == nil || live.CdnDomain == nil {
+		return
+	}
+	d.value(types.UpdateArea, "CdnDomain.AreaCode", desired.CdnDomain.AreaCode, live.CdnDomain.AreaCode)
+	d.value(types.UpdateBaseConf, "CdnDomain.SupportIpv6", desired.CdnDomain.SupportIpv6, live.CdnDomain.SupportIpv6)
+}
+
+func (d *differ) diffOriginConf(desired, live *types.UpdateDomainRequest) {
+	if desired.OriginConf == nil || live.OriginConf == nil {
+		return
+	}
+	const action = types.UpdateOriginConf
+	want, got := desired.OriginConf, live.OriginConf
+	d.value(action, "OriginConf.OriginProtocol", want.OriginProtocol, got.OriginProtocol)
+	d.value(action, "OriginConf.OriginRange", want.OriginRange, got.OriginRange)
+	d.value(action, "OriginConf.OriginFollow", want.OriginFollow, got.OriginFollow)
+	d.optional(action, "OriginConf.OriginTimeOut", want.OriginTimeOut, got.OriginTimeOut)
+	d.optional(action, "OriginConf.TcpTimeout", want.TcpTimeout, got.TcpTimeout)
+	//-1表示不修改Sni配置
+	if want.OriginSniSwitch != -1 {
+		d.value(action, "OriginConf.OriginSniSwitch", want.OriginSniSwitch, got.OriginSniSwitch)
+		if want.OriginSniSwitch == consts.SwitchOn {
+			d.value(action, "OriginConf.OriginSniValue", want.OriginSniValue, got.OriginSniValue)
+		}
+	}
+}
+
+func (d *differ) diffOriginServerConf(desired, live *types.UpdateDomainRequest) {
+	if desired.OriginServerConf == nil || live.OriginServerConf == nil {
+		return
+	}
+	const action = types.UpdateOriginServerConf
+	diffList(d, action, "OriginServerConf", desired.OriginServerConf, live.OriginServerConf, func(v *entity.OriginServerConf) string {
+		return v.OriginAddressList
+	}, func(path string, want, got *entity.OriginServerConf) {
+		d.value(action, path+".OriginType", want.OriginType, got.OriginType)
+		d.value(action, path+".OriginPriority", want.OriginPriority, got.OriginPriority)
+		d.optional(action, path+".OriginHttpPort", want.OriginHttpPort, got.OriginHttpPort)
+		d.optional(action, path+".OriginHttpsPort", want.OriginHttpsPort, got.OriginHttpsPort)
+		d.optional(action, path+".OriginWeight", want.OriginWeight, got.OriginWeight)
+		if want.OriginHost != "" {
+			d.value(action, path+".OriginHost", want.OriginHost, got.OriginHost)
+		}
+	})
+}
+
+func (d *differ) diffOriginAdvanceServerConf(desired, live *types.UpdateDomainRequest) {
+	if desired.OriginAdvanceServerConf == nil || live.OriginAdvanceServerConf == nil {
+		return
+	}
+	const action = types.UpdateOriginAdvanceServerConf
+	diffList(d, action, "OriginAdvanceServerConf", desired.OriginAdvanceServerConf, live.OriginAdvanceServerConf, func(v *entity.OriginAdvanceServerConf) string {
+		return strconv.FormatInt(v.UrlMatchMode, 10) + ":" + strings.Join(getSet(v.UrlMatchRule), ";") + "@" + v.OriginAddressList
+	}, func(path string, want, got *entity.OriginAdvanceServerConf) {
+		d.value(action, path+".OriginType", want.OriginType, got.OriginType)
+		d.optional(action, path+".OriginHttpPort", want.OriginHttpPort, got.OriginHttpPort)
+		d.optional(action, path+".OriginHttpsPort", want.OriginHttpsPort, got.OriginHttpsPort)
+		d.optional(action, path+".OriginPriorityValue", want.OriginPriorityValue, got.OriginPriorityValue)
+		if want.OriginHost != "" {
+			d.value(action, path+".OriginHost", want.OriginHost, got.OriginHost)
+		}
+	})
+}
+
+func (d *differ) diffOriginRequestHeaderConf(desired, live *types.UpdateDomainRequest) {
+	if desired.OriginRequestHeaderConf == nil || live.OriginRequestHeaderConf == nil {
+		return
+	}
+	const action = types.UpdateOriginRequestHeaderConf
+	diffList(d, action, "OriginRequestHeaderConf", desired.OriginRequestHeaderConf, live.OriginRequestHeaderConf, func(v *entity.OriginRequestHeaderConf) string {
+		return strings.ToLower(v.ParameterKey)
+	}, func(path string, want, got *entity.OriginRequestHeaderConf) {
+		d.value(action, path+".Action", want.Action, got.Action)
+		d.value(action, path+".ParameterValue", want.ParameterValue, got.ParameterValue)
+	})
+}
+
+func (d *differ) diffOriginUrlConf(desired, live *types.UpdateDomainRequest) {
+	if desired.OriginUrlConf == nil || live.OriginUrlConf == nil {
+		return
+	}
+	const action = types.UpdateOriginUrlConf
+	diffList(d, action, "OriginUrlConf", desired.OriginUrlConf, live.OriginUrlConf, func(v *entity.OriginUrlConf) string {
+		return v.RewriteUrl
+	}, func(path string, want, got *entity.OriginUrlConf) {
+		d.value(action, path+".MateMethod", want.MateMethod, got.MateMethod)
+		d.value(action, path+".TargetUrl", want.TargetUrl, got.TargetUrl)
+		d.optional(action, path+".Priority", want.Priority, got.Priority)
+	})
+}
+
+func (d *differ) diffIpFilterConf(desired, live *types.UpdateDomainRequest) {
+	if desired.IpFilterConf == nil || live.IpFilterConf == nil {
+		return
+	}
+	const action = types.UpdateIpFilterConf
+	got := live.IpFilterConf
+	d.value(action, "IpFilterConf.Status", desired.IpFilterConf.Status, got.Status)
+	if desired.IpFilterConf.Status != consts.SwitchOn {
+		return
+	}
+	diffList(d, action, "IpFilterConf.IpFilterConf", desired.IpFilterConf.IpFilterConf, got.IpFilterConf, func(v *entity.IpFilter) string {
+		return getRuleKey(v.EffectiveType, v.EffectiveRules)
+	}, func(path string, want, got *entity.IpFilter) {
+		d.value(action, path+".IpType", want.IpType, got.IpType)
+		d.value(action, path+".IpList", getSet(want.IpList), getSet(got.IpList))
+	})
+}
+
+func (d *differ) diffIpFrequencyConf(desired, live *types.UpdateDomainRequest) {
+	if desired.IpFrequencyConf == nil || live.IpFrequencyConf == nil {
+		return
+	}
+	const action = types.UpdateIpFrequencyConf
+	got := live.IpFrequencyConf
+	d.value(action, "IpFrequencyConf.Status", desired.IpFrequencyConf.Status, got.Status)
+	if desired.IpFrequencyConf.Status == consts.SwitchOn {
+		d.value(action, "IpFrequencyConf.Frequency", desired.IpFrequencyConf.Frequency, got.Frequency)
+	}
+}
+
+func (d *differ) diffRefererConf(desired, live *types.UpdateDomainRequest) {
+	if desired.RefererConf == nil || live.RefererConf == nil {
+		return
+	}
+	const action = types.UpdateRefererConf
+	want, got := desired.RefererConf, live.RefererConf
+	d.value(action, "RefererConf.Status", want.Status, got.Status)
+	if want.Status != consts.SwitchOn {
+		return
+	}
+	d.value(action, "RefererConf.RefererType", want.RefererType, got.RefererType)
+	d.value(action, "RefererConf.RefererList", getSet(want.RefererList), getSet(got.RefererList))
+	d.value(action, "RefererConf.IncludeEmpty", want.IncludeEmpty, got.IncludeEmpty)
+}
+
+func (d *differ) diffUserAgentConf(desired, live *types.UpdateDomainRequest) {
+	if desired.UserAgentConf == nil || live.UserAgentConf == nil {
+		return
+	}
+	const action = types.UpdateUserAgentConf
+	got := live.UserAgentConf
+	d.value(action, "UserAgentConf.Status", desired.UserAgentConf.Status, got.Status)
+	if desired.UserAgentConf.Status != consts.SwitchOn {
+		return
+	}
+	diffList(d, action, "UserAgentConf.UserAgentConf", desired.UserAgentConf.UserAgentConf, got.UserAgentConf, func(v *entity.UserAgent) string {
+		return getRuleKey(v.EffectiveType, v.EffectiveRules)
+	}, func(path string, want, got *entity.UserAgent) {
+		d.value(action, path+".AgentType", want.AgentType, got.AgentType)
+		d.value(action, path+".AgentList", getSet(want.AgentList), getSet(got.AgentList))
+	})
+}
+
+func (d *differ) diffSpeedConf(desired, live *types.UpdateDomainRequest) {
+	if desired.SpeedConf == nil || live.SpeedConf == nil {
+		return
+	}
+	const action = types.UpdateSpeedConf
+	got := live.SpeedConf
+	d.value(action, "SpeedConf.Status", desired.SpeedConf.Status, got.Status)
+	if desired.SpeedConf.Status != consts.SwitchOn {
+		return
+	}
+	diffList(d, action, "SpeedConf.SpeedConf", desired.SpeedConf.SpeedConf, got.SpeedConf, func(v *entity.SpeedConf) string {
+		return getRuleKey(v.RuleType, v.RuleContent)
+	}, func(path string, want, got *entity.SpeedConf) {
+		d.value(action, path+".SpeedValues", want.SpeedValues, got.SpeedValues)
+	})
+}
+
+func (d *differ) diffAuthConf(desired, live *types.UpdateDomainRequest) {
+	if desired.AuthConf == nil || live.AuthConf == nil {
+		return
+	}
+	const action = types.UpdateAuthConf
+	want, got := desired.AuthConf, live.AuthConf
+	d.value(action, "AuthConf.Status", want.Status, got.Status)
+	if want.Status != consts.SwitchOn {
+		return
+	}
+	d.value(action, "AuthConf.AuthManner", want.AuthManner, got.AuthManner)
+	d.value(action, "AuthConf.AuthRange", want.AuthRange, got.AuthRange)
+	if want.AuthRange != consts.AccessAuthRangeAll {
+		d.value(action, "AuthConf.FileSuffix", getRulePaths(consts.RuleTypeFileSuffix, want.FileSuffix), getRulePaths(consts.RuleTypeFileSuffix, got.FileSuffix))
+	}
+	d.value(action, "AuthConf.AuthKey", want.AuthKey, got.AuthKey)
+	d.value(action, "AuthConf.AuthKeyBackup", want.AuthKeyBackup, got.AuthKeyBackup)
+	if want.AuthParameter != "" {
+		d.value(action, "AuthConf.AuthParameter", want.AuthParameter, got.AuthParameter)
+	}
+	d.value(action, "AuthConf.EncryptMannger", want.EncryptMannger, got.EncryptMannger)
+	d.value(action, "AuthConf.TimeFormat", want.TimeFormat, got.TimeFormat)
+	d.value(action, "AuthConf.TimeValue", want.TimeValue, got.TimeValue)
+}
+
+func (d *differ) diffRemoteAuthConf(desired, live *types.UpdateDomainRequest) {
+	if desired.RemoteAuthConf == nil || live.RemoteAuthConf == nil {
+		return
+	}
+	const action = types.UpdateRemoteAuthConf
+	want, got := desired.RemoteAuthConf, live.RemoteAuthConf
+	d.value(action, "RemoteAuthConf.Status", want.Status, got.Status)
+	if want.Status != consts.SwitchOn {
+		return
+	}
+	d.value(action, "RemoteAuthConf.AuthUrl", want.AuthUrl, got.AuthUrl)
+	d.value(action, "RemoteAuthConf.ReqMethod", want.ReqMethod, got.ReqMethod)
+	d.value(action, "RemoteAuthConf.FileType", want.FileType, got.FileType)
+	d.value(action, "RemoteAuthConf.FileContent", getRulePaths(want.FileType, want.FileContent), getRulePaths(got.FileType, got.FileContent))
+	d.optional(action, "RemoteAuthConf.TimeoutDuration", want.TimeoutDuration, got.TimeoutDuration)
+	d.value(action, "RemoteAuthConf.TimeoutAction", want.TimeoutAction, got.TimeoutAction)
+}
+
+// diffCacheListConf 缓存时间换算为秒后比较,只有缓存状态为开启时比较缓存时间
+func (d *differ) diffCacheListConf(desired, live *types.UpdateDomainRequest) {
+	if desired.CacheListConf == nil || live.CacheListConf == nil {
+		return
+	}
+	const action = types.UpdateCacheListConf
+	diffList(d, action, "CacheListConf", desired.CacheListConf, live.CacheListConf, func(v *entity.CacheListItem) string {
+		return getRuleKey(v.CacheType, v.CacheContent)
+	}, func(path string, want, got *entity.CacheListItem) {
+		d.value(action, path+".CacheStatus", want.CacheStatus, got.CacheStatus)
+		if want.CacheStatus == consts.CacheStatusOn {
+			d.value(action, path+".CacheTTL", cachettl.Seconds(want.CacheTTL, want.CacheUnit), cachettl.Seconds(got.CacheTTL, got.CacheUnit))
+		}
+		d.optional(action, path+".Priority", want.Priority, got.Priority)
+		d.value(action, path+".ParametersStatus", want.ParametersStatus, got.ParametersStatus)
+		if isCacheParameterCustom(want.ParametersStatus) {
+			d.value(action, path+".ParametersValue", getSet(want.ParametersValue), getSet(got.ParametersValue))
+		}
+		d.value(action, path+".Capitalization", want.Capitalization, got.Capitalization)
+	})
+}
+
+func (d *differ) diffCacheCodeConf(desired, live *types.UpdateDomainRequest) {
+	if desired.CacheCodeConf == nil || live.CacheCodeConf == nil {
+		return
+	}
+	const action = types.UpdateCacheCodeConf
+	diffList(d, action, "CacheCodeConf", desired.CacheCodeConf, live.CacheCodeConf, func(v *entity.CacheCodeListItem) string {
+		return strconv.FormatInt(v.HttpCode, 10)
+	}, func(path string, want, got *entity.CacheCodeListItem) {
+		d.value(action, path+".CacheTTL", cachettl.Seconds(want.CacheTTL, want.CacheUnit), cachettl.Seconds(got.CacheTTL, got.CacheUnit))
+	})
+}
+
+func (d *differ) diffBrowserCacheConf(desired, live *types.UpdateDomainRequest) {
+	if desired.BrowserCacheConf == nil || live.BrowserCacheConf == nil {
+		return
+	}
+	const action = types.UpdateBrowserCacheConf
+	diffList(d, action, "BrowserCacheConf", desired.BrowserCacheConf, live.BrowserCacheConf, func(v *entity.BrowserCacheListItem) string {
+		return getRuleKey(v.CacheType, v.CacheContent)
+	}, func(path string, want, got *entity.BrowserCacheListItem) {
+		d.value(action, path+".CacheStatus", want.CacheStatus, got.CacheStatus)
+		if want.CacheStatus == consts.CacheStatusOn {
+			d.value(action, path+".CacheTTL", cachettl.Seconds(want.CacheTTL, want.CacheUnit), cachettl.Seconds(got.CacheTTL, got.CacheUnit))
+		}
+		d.optional(action, path+".Priority", want.Priority, got.Priority)
+	})
+}
+
+func (d *differ) diffRequestUrlRewriteConf(desired, live *types.UpdateDomainRequest) {
+	if desired.RequestUrlRewriteConf == nil || live.RequestUrlRewriteConf == nil {
+		return
+	}
+	const action = types.UpdateRequestUrlRewriteConf
+	diffList(d, action, "RequestUrlRewriteConf", desired.RequestUrlRewriteConf, live.RequestUrlRewriteConf, func(v *entity.RequestUrlRewriteConf) string {
+		return v.RewriteUrl
+	}, func(path string, want, got *entity.RequestUrlRewriteConf) {
+		d.value(action, path+".MateMethod", want.MateMethod, got.MateMethod)
+		d.value(action, path+".TargetUrl", want.TargetUrl, got.TargetUrl)
+		d.value(action, path+".RedirectCode", want.RedirectCode, got.RedirectCode)
+		d.optional(action, path+".Priority", want.Priority, got.Priority)
+	})
+}
+
+func (d *differ) diffCustomErrorPageConf(desired, live *types.UpdateDomainRequest) {
+	if desired.CustomErrorPageConf == nil || live.CustomErrorPageConf == nil {
+		return
+	}
+	const action = types.UpdateCustomErrorPageConf
+	diffList(d, action, "CustomErrorPageConf", desired.CustomErrorPageConf, live.CustomErrorPageConf, func(v *entity.CustomErrorPageConf) string {
+		return strconv.FormatInt(v.StatusCode, 10)
+	}, func(path string, want, got *entity.CustomErrorPageConf) {
+		d.value(action, path+".RedirectCode", want.RedirectCode, got.RedirectCode)
+		d.value(action, path+".GoalAddress", want.GoalAddress, got.GoalAddress)
+	})
+}
+
+func (d *differ) diffIntelligentCompressionConf(desired, live *types.UpdateDomainRequest) {
+	if desired.IntelligentCompressionConf == nil || live.IntelligentCompressionConf == nil {
+		return
+	}
+	const action = types.UpdateIntelligentCompressionConf
+	got := live.IntelligentCompressionConf
+	d.value(action, "IntelligentCompressionConf.Status", desired.IntelligentCompressionConf.Status, got.Status)
+	if desired.IntelligentCompressionConf.Status != consts.SwitchOn {
+		return
+	}
+	diffList(d, action, "IntelligentCompressionConf.IntelligentCompressionConf", desired.IntelligentCompressionConf.IntelligentCompressionConf, got.IntelligentCompressionConf, func(v *entity.IntelligentCompressionConf) string {
+		paths := getCompressRulePaths(v.CompressType, v.CompressContent)
+		if len(paths) == 0 {
+			return strconv.FormatInt(v.CompressType, 10)
+		}
+		return strconv.FormatInt(v.CompressType, 10) + ":" + strings.Join(paths, ";")
+	}, func(path string, want, got *entity.IntelligentCompressionConf) {
+		d.value(action, path+".CompressMethod", want.CompressMethod, got.CompressMethod)
+	})
+}
+
+func (d *differ) diffResponseHeaderConf(desired, live *types.UpdateDomainRequest) {
+	if desired.ResponseHeaderConf == nil || live.ResponseHeaderConf == nil {
+		return
+	}
+	const action = types.UpdateResponseHeaderConf
+	diffList(d, action, "ResponseHeaderConf", desired.ResponseHeaderConf, live.ResponseHeaderConf, func(v *entity.ResponseHeaderConf) string {
+		return strings.ToLower(v.ParameterKey)
+	}, func(path string, want, got *entity.ResponseHeaderConf) {
+		d.value(action, path+".Action", want.Action, got.Action)
+		d.value(action, path+".ParameterValue", want.ParameterValue, got.ParameterValue)
+	})
+}
+
+// diffHttpsConf 证书私钥无法读取不做比较,证书名称与内容只在线上能读取到时比较
+func (d *differ) diffHttpsConf(desired, live *types.UpdateDomainRequest) {
+	if desired.HttpsConf == nil || live.HttpsConf == nil {
+		return
+	}
+	const action = types.UpdateHttpsConf
+	want, got := desired.HttpsConf, live.HttpsConf
+	d.value(action, "HttpsConf.HttpsStatus", want.HttpsStatus, got.HttpsStatus)
+	if want.HttpsStatus != consts.SwitchOn {
+		return
+	}
+	if len(want.TlsVersion) > 0 {
+		d.value(action, "HttpsConf.TlsVersion", getIntSet(want.TlsVersion), getIntSet(got.TlsVersion))
+	}
+	d.value(action, "HttpsConf.HttpTwo", want.HttpTwo, got.HttpTwo)
+	d.value(action, "HttpsConf.OcspStatus", want.OcspStatus, got.OcspStatus)
+	d.value(action, "HttpsConf.QuicStatus", want.QuicStatus, got.QuicStatus)
+	d.value(action, "HttpsConf.JumpForceStatus", want.JumpForceStatus, got.JumpForceStatus)
+	if want.JumpForceStatus == consts.SwitchOn {
+		d.value(action, "HttpsConf.JumpType", want.JumpType, got.JumpType)
+		d.value(action, "HttpsConf.JumpManner", want.JumpManner, got.JumpManner)
+	}
+	d.value(action, "HttpsConf.HstsStatus", want.HstsStatus, got.HstsStatus)
+	if want.HstsStatus == consts.SwitchOn {
+		d.optional(action, "HttpsConf.HstsExpirationTime", want.HstsExpirationTime, got.HstsExpirationTime)
+		d.value(action, "HttpsConf.HstsSubdomain", want.HstsSubdomain, got.HstsSubdomain)
+	}
+	if want.CertName != "" && got.CertName != "" {
+		d.value(action, "HttpsConf.CertName", want.CertName, got.CertName)
+	}
+	if want.CertValue != "" && got.CertValue != "" {
+		d.value(action, "HttpsConf.CertValue", strings.TrimSpace(want.CertValue), strings.TrimSpace(got.CertValue))
+	}
+}
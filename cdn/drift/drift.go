@@ -0,0 +1,192 @@
+// Package drift 比较期望配置与线上配置,生成按更新动作归类的差异列表,用于漂移告警与最小化更新
+package drift
+
+import (
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Kind 差异类型
+type Kind string
+
+const (
+	KindAdded    Kind = "added"    // 期望配置中存在,线上不存在
+	KindRemoved  Kind = "removed"  // 线上存在,期望配置中不存在
+	KindModified Kind = "modified" // 两边都存在但取值不同
+)
+
+// Change 一项差异
+type Change struct {
+	Action  string      `json:"action"`            // 所属的更新动作,如 types.UpdateHttpsConf
+	Path    string      `json:"path"`              // 字段路径,列表项以标识代替下标,如 CacheListConf[1:jpg].CacheTTL
+	Kind    Kind        `json:"kind"`              // 差异类型
+	Desired interface{} `json:"desired,omitempty"` // 归一化后的期望值,KindRemoved时为空
+	Live    interface{} `json:"live,omitempty"`    // 归一化后的线上值,KindAdded时为空
+}
+
+func (c *Change) String() string {
+	switch c.Kind {
+	case KindAdded:
+		return fmt.Sprintf("+ %s", c.Path)
+	case KindRemoved:
+		return fmt.Sprintf("- %s", c.Path)
+	default:
+		return fmt.Sprintf("~ %s: %v -> %v", c.Path, c.Live, c.Desired)
+	}
+}
+
+// Changes 差异列表
+type Changes []*Change
+
+// actionOrder 更新动作的排列顺序,与 types 中的定义顺序一致
+var actionOrder = []string{
+	types.UpdateBaseConf,
+	types.UpdateArea,
+	types.UpdateOriginConf,
+	types.UpdateOriginServerConf,
+	types.UpdateOriginAdvanceServerConf,
+	types.UpdateOriginRequestHeaderConf,
+	types.UpdateOriginUrlConf,
+	types.UpdateIpFilterConf,
+	types.UpdateIpFrequencyConf,
+	types.UpdateRefererConf,
+	types.UpdateUserAgentConf,
+	types.UpdateSpeedConf,
+	types.UpdateAuthConf,
+	types.UpdateRemoteAuthConf,
+	types.UpdateCacheListConf,
+	types.UpdateCacheCodeConf,
+	types.UpdateBrowserCacheConf,
+	types.UpdateRequestUrlRewriteConf,
+	types.UpdateCustomErrorPageConf,
+	types.UpdateIntelligentCompressionConf,
+	types.UpdateResponseHeaderConf,
+	types.UpdateHttpsConf,
+}
+
+// Actions 返回存在差异的更新动作,去重后按 types 中的定义顺序排列
+func (c Changes) Actions() []string {
+	seen := make(map[string]bool)
+	for _, v := range c {
+		seen[v.Action] = true
+	}
+	actions := make([]string, 0, len(seen))
+	for _, v := range actionOrder {
+		if seen[v] {
+			actions = append(actions, v)
+		}
+	}
+	return actions
+}
+
+// ByAction 返回属于指定更新动作的差异
+func (c Changes) ByAction(action string) Changes {
+	list := make(Changes, 0)
+	for _, v := range c {
+		if v.Action == action {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// Diff 逐项比较期望配置与线上配置,线上配置通常来自 ShowDomainConfig。
+//
+// 比较前会归一化厂商差异:缓存时间统一换算为秒,列表按规则标识匹配而不考虑顺序,
+// 名单类字段按集合比较,功能关闭时忽略其余字段,期望配置中为nil的配置项与为零值的
+// 超时、端口、权重、优先级视为使用厂商默认值不做比较。线上配置中为nil的配置项与列表表示厂商无法读取,
+// 不参与比较,厂商读取到空列表时应返回空切片,证书私钥等线上无法读取的字段同样不参与比较
+func Diff(desired, live *types.UpdateDomainRequest) Changes {
+	if desired == nil {
+		return Changes{}
+	}
+	if live == nil {
+		live = &types.UpdateDomainRequest{}
+	}
+	d := &differ{changes: make(Changes, 0)}
+	d.diffBaseConf(desired, live)
+	d.diffOriginConf(desired, live)
+	d.diffOriginServerConf(desired, live)
+	d.diffOriginAdvanceServerConf(desired, live)
+	d.diffOriginRequestHeaderConf(desired, live)
+	d.diffOriginUrlConf(desired, live)
+	d.diffIpFilterConf(desired, live)
+	d.diffIpFrequencyConf(desired, live)
+	d.diffRefererConf(desired, live)
+	d.diffUserAgentConf(desired, live)
+	d.diffSpeedConf(desired, live)
+	d.diffAuthConf(desired, live)
+	d.diffRemoteAuthConf(desired, live)
+	d.diffCacheListConf(desired, live)
+	d.diffCacheCodeConf(desired, live)
+	d.diffBrowserCacheConf(desired, live)
+	d.diffRequestUrlRewriteConf(desired, live)
+	d.diffCustomErrorPageConf(desired, live)
+	d.diffIntelligentCompressionConf(desired, live)
+	d.diffResponseHeaderConf(desired, live)
+	d.diffHttpsConf(desired, live)
+	return d.changes
+}
+
+type differ struct {
+	changes Changes
+}
+
+// value 比较归一化后的字段值
+func (d *differ) value(action, path string, desired, live interface{}) {
+	if reflect.DeepEqual(desired, live) {
+		return
+	}
+	d.changes = append(d.changes, &Change{Action: action, Path: path, Kind: KindModified, Desired: desired, Live: live})
+}
+
+// optional 期望值为零值时视为使用厂商默认值,不做比较
+func (d *differ) optional(action, path string, desired, live int64) {
+	if desired == 0 {
+		return
+	}
+	d.value(action, path, desired, live)
+}
+
+// diffList 按标识匹配列表项,标识重复时依次追加序号,匹配到的列表项交给compare逐字段比较
+func diffList[T any](d *differ, action, path string, desired, live []T, key func(T) string, compare func(path string, desired, live T)) {
+	desiredItems, desiredKeys := index(desired, key)
+	liveItems, liveKeys := index(live, key)
+	for _, k := range desiredKeys {
+		itemPath := path + "[" + k + "]"
+		if l, ok := liveItems[k]; ok {
+			compare(itemPath, desiredItems[k], l)
+			continue
+		}
+		d.changes = append(d.changes, &Change{Action: action, Path: itemPath, Kind: KindAdded, Desired: desiredItems[k]})
+	}
+	for _, k := range liveKeys {
+		if _, ok := desiredItems[k]; !ok {
+			d.changes = append(d.changes, &Change{Action: action, Path: path + "[" + k + "]", Kind: KindRemoved, Live: liveItems[k]})
+		}
+	}
+}
+
+// index 按标识建立索引,返回排序后的标识,空项忽略
+func index[T any](list []T, key func(T) string) (map[string]T, []string) {
+	items := make(map[string]T, len(list))
+	keys := make([]string, 0, len(list))
+	count := make(map[string]int)
+	for _, v := range list {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			continue
+		}
+		k := key(v)
+		count[k]++
+		if count[k] > 1 {
+			k += "#" + strconv.Itoa(count[k])
+		}
+		items[k] = v
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return items, keys
+}
@@ -0,0 +1,64 @@
+package drift
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"testing"
+)
+
+func TestDiffUnreadableList(t *testing.T) {
+	desired := &types.UpdateDomainRequest{
+		OriginServerConf: []*entity.OriginServerConf{
+			{OriginType: consts.OriginTypeIp, OriginAddressList: "1.1.1.1", OriginPriority: consts.OriginPriorityPrimary},
+		},
+		OriginAdvanceServerConf: []*entity.OriginAdvanceServerConf{
+			{UrlMatchMode: consts.RuleTypeDirectory, UrlMatchRule: []string{"/api"}, OriginType: consts.OriginTypeIp, OriginAddressList: "2.2.2.2"},
+		},
+	}
+	//网宿无法读取高级回源配置,线上为nil时不视为漂移
+	live := &types.UpdateDomainRequest{
+		OriginServerConf: []*entity.OriginServerConf{
+			{OriginType: consts.OriginTypeIp, OriginAddressList: "1.1.1.1", OriginPriority: consts.OriginPriorityPrimary},
+		},
+	}
+	if changes := Diff(desired, live); len(changes) != 0 {
+		t.Fatalf("got changes %v, want none", changes)
+	}
+	//读取到空列表时期望的列表项视为新增
+	live.OriginAdvanceServerConf = []*entity.OriginAdvanceServerConf{}
+	changes := Diff(desired, live)
+	if len(changes) != 1 || changes[0].Kind != KindAdded || changes[0].Action != types.UpdateOriginAdvanceServerConf {
+		t.Fatalf("got changes %v, want one added advance origin", changes)
+	}
+}
+
+func TestDiffList(t *testing.T) {
+	desired := &types.UpdateDomainRequest{
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{".jpg", "png"}, CacheTTL: 1, CacheUnit: consts.CacheUnitDay, CacheStatus: consts.CacheStatusOn},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"/api/"}, CacheTTL: 10, CacheUnit: consts.CacheUnitMinute, CacheStatus: consts.CacheStatusOn},
+		},
+	}
+	//顺序、后缀点号与缓存单位不同但取值相同
+	live := &types.UpdateDomainRequest{
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"/api"}, CacheTTL: 60, CacheUnit: consts.CacheUnitSecond, CacheStatus: consts.CacheStatusOn},
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{"png", "jpg"}, CacheTTL: 24, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOn},
+			{CacheType: consts.RuleTypeAll, CacheStatus: consts.CacheStatusOff},
+		},
+	}
+	changes := Diff(desired, live)
+	if len(changes) != 2 {
+		t.Fatalf("got changes %v, want 2", changes)
+	}
+	if c := changes[0]; c.Kind != KindModified || c.Path != "CacheListConf[2:/api].CacheTTL" || c.Desired != int64(600) || c.Live != int64(60) {
+		t.Errorf("unexpected change %v", c)
+	}
+	if c := changes[1]; c.Kind != KindRemoved || c.Path != "CacheListConf[0]" {
+		t.Errorf("unexpected change %v", c)
+	}
+	if actions := changes.Actions(); len(actions) != 1 || actions[0] != types.UpdateCacheListConf {
+		t.Errorf("got actions %v, want cache list", actions)
+	}
+}
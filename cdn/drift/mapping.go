@@ -0,0 +1,93 @@
+package drift
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetRuleKey 规则类型与内容转换为列表项标识,全部内容与首页不带内容
+func getRuleKey(t int64, content []string) string {
+	paths := getRulePaths(t, content)
+	if len(paths) == 0 {
+		return strconv.FormatInt(t, 10)
+	}
+	return strconv.FormatInt(t, 10) + ":" + strings.Join(paths, ";")
+}
+
+// GetRulePaths 归一化规则内容,文件后缀去掉点号,目录统一以/开头且不以/结尾
+func getRulePaths(t int64, content []string) []string {
+	switch t {
+	case consts.RuleTypeAll, consts.RuleTypeIndex:
+		return nil
+	case consts.RuleTypeFileSuffix:
+		list := make([]string, 0, len(content))
+		for _, v := range content {
+			list = append(list, strings.ToLower(strings.TrimPrefix(strings.TrimSpace(v), ".")))
+		}
+		return getSet(list)
+	case consts.RuleTypeDirectory:
+		list := make([]string, 0, len(content))
+		for _, v := range content {
+			list = append(list, "/"+strings.Trim(strings.TrimSpace(v), "/"))
+		}
+		return getSet(list)
+	default:
+		return getSet(content)
+	}
+}
+
+// GetCompressRulePaths 归一化压缩规则内容
+func getCompressRulePaths(t int64, content []string) []string {
+	switch t {
+	case consts.CompressRuleTypeAll:
+		return nil
+	case consts.CompressRuleTypeFileSuffix:
+		return getRulePaths(consts.RuleTypeFileSuffix, content)
+	default:
+		return getSet(content)
+	}
+}
+
+// GetSet 去除空值后排序去重,结果为空时返回nil
+func getSet(data []string) []string {
+	seen := make(map[string]bool, len(data))
+	list := make([]string, 0, len(data))
+	for _, v := range data {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		list = append(list, v)
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	sort.Strings(list)
+	return list
+}
+
+// GetIntSet 排序去重,结果为空时返回nil
+func getIntSet(data []int64) []int64 {
+	seen := make(map[int64]bool, len(data))
+	list := make([]int64, 0, len(data))
+	for _, v := range data {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		list = append(list, v)
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+// IsCacheParameterCustom 获取缓存参数是否为自定义保留或忽略
+func isCacheParameterCustom(t int64) bool {
+	return t == consts.CacheParameterStatusInclude || t == consts.CacheParameterStatusExclude
+}
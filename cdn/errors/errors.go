@@ -0,0 +1,94 @@
+// Package errors 定义与厂商无关的CDN错误分类,各厂商的原始错误会被包装为 *Error,
+// 调用方可通过 errors.Is(err, ErrDomainNotFound) 等方式判断错误类型
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrDomainNotFound      = errors.New("domain not found")      // 域名不存在
+	ErrDomainAlreadyExists = errors.New("domain already exists") // 域名已存在
+	ErrIcpNotFiled         = errors.New("icp not filed")         // 域名未备案
+	ErrQuotaExceeded       = errors.New("quota exceeded")        // 超出配额
+	ErrRateLimited         = errors.New("rate limited")          // 请求频率超限
+	ErrInvalidParameter    = errors.New("invalid parameter")     // 参数错误
+	ErrAuthFailed          = errors.New("auth failed")           // 鉴权失败
+	ErrUnsupported         = errors.New("unsupported")           // 厂商不支持该操作
+	ErrNotFound            = errors.New("not found")             // 任务、统计数据等资源不存在
+	ErrProvider            = errors.New("provider error")        // 厂商接口返回失败,无法进一步归类
+)
+
+// ErrNilRequest 请求参数为空
+var ErrNilRequest = &Error{Kind: ErrInvalidParameter, Message: "request is nil"}
+
+// Error 厂商错误,保留厂商返回的原始错误码、错误信息与请求ID
+type Error struct {
+	Kind       error  // 错误分类,为上面定义的哨兵错误之一,无法归类时为nil
+	Provider   string // 厂商名称
+	Code       string // 厂商错误码
+	Message    string // 厂商错误信息
+	RequestId  string // 厂商请求ID
+	StatusCode int    // HTTP状态码
+	Temporary  bool   // 临时性错误,如服务端内部错误或网络错误,可重试
+	Err        error  // 厂商原始错误
+}
+
+// New 创建错误
+func New(kind error, provider string, message string) *Error {
+	return &Error{
+		Kind:     kind,
+		Provider: provider,
+		Message:  message,
+	}
+}
+
+func (e *Error) Error() string {
+	var build strings.Builder
+	if e.Provider != "" {
+		build.WriteString(e.Provider)
+		build.WriteString(": ")
+	}
+	switch {
+	case e.Message != "":
+		build.WriteString(e.Message)
+	case e.Err != nil:
+		build.WriteString(e.Err.Error())
+	case e.Kind != nil:
+		build.WriteString(e.Kind.Error())
+	}
+	details := make([]string, 0, 2)
+	if e.Code != "" {
+		details = append(details, "code: "+e.Code)
+	}
+	if e.RequestId != "" {
+		details = append(details, "request id: "+e.RequestId)
+	}
+	if len(details) > 0 {
+		build.WriteString(fmt.Sprintf(" (%s)", strings.Join(details, ", ")))
+	}
+	return build.String()
+}
+
+// Unwrap 同时返回错误分类与原始错误,使 errors.Is/errors.As 对两者均生效
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// KindOf 获取错误分类,无法归类时返回nil
+func KindOf(err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorString(t *testing.T) {
+	origin := errors.New("origin error")
+	for _, tt := range []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{"full", &Error{Kind: ErrDomainNotFound, Provider: "wangsu", Code: "NoSuchDomain", Message: "domain not exist", RequestId: "req-1"}, "wangsu: domain not exist (code: NoSuchDomain, request id: req-1)"},
+		{"code only", &Error{Provider: "huawei", Code: "CDN.0170", Message: "not found"}, "huawei: not found (code: CDN.0170)"},
+		{"origin message", &Error{Provider: "tencent", Err: origin}, "tencent: origin error"},
+		{"kind message", &Error{Kind: ErrRateLimited}, "rate limited"},
+		{"nil request", ErrNilRequest, "request is nil"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	origin := errors.New("origin error")
+	for _, tt := range []struct {
+		name string
+		err  *Error
+		want []error
+	}{
+		{"kind and origin", &Error{Kind: ErrAuthFailed, Err: origin}, []error{ErrAuthFailed, origin}},
+		{"kind only", New(ErrUnsupported, "aliyun", "not support"), []error{ErrUnsupported}},
+		{"origin only", &Error{Err: origin}, []error{origin}},
+		{"empty", &Error{}, []error{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.err.Unwrap()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsAs(t *testing.T) {
+	sentinels := []error{
+		ErrDomainNotFound, ErrDomainAlreadyExists, ErrIcpNotFiled, ErrQuotaExceeded, ErrRateLimited,
+		ErrInvalidParameter, ErrAuthFailed, ErrUnsupported, ErrNotFound, ErrProvider,
+	}
+	type vendorError struct{ error }
+	origin := &vendorError{errors.New("vendor error")}
+	for _, kind := range sentinels {
+		t.Run(kind.Error(), func(t *testing.T) {
+			//经过多层fmt包装后依然可以判断分类并取回厂商错误
+			err := fmt.Errorf("update domain: %w", fmt.Errorf("retry: %w", &Error{Kind: kind, Provider: "ksyun", Err: origin}))
+			for _, other := range sentinels {
+				if got := errors.Is(err, other); got != (other == kind) {
+					t.Errorf("errors.Is(err, %v) = %v", other, got)
+				}
+			}
+			var e *Error
+			if !errors.As(err, &e) || e.Provider != "ksyun" {
+				t.Fatalf("errors.As *Error failed: %v", err)
+			}
+			var v *vendorError
+			if !errors.As(err, &v) || v != origin {
+				t.Fatalf("errors.As vendor error failed: %v", err)
+			}
+			if KindOf(err) != kind {
+				t.Fatalf("got kind %v, want %v", KindOf(err), kind)
+			}
+		})
+	}
+	if !errors.Is(ErrNilRequest, ErrInvalidParameter) {
+		t.Fatal("nil request is not invalid parameter")
+	}
+	if KindOf(errors.New("plain")) != nil || KindOf(nil) != nil {
+		t.Fatal("got kind for unclassified error")
+	}
+}
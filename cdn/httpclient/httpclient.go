@@ -0,0 +1,84 @@
+// Package httpclient 提供各厂商共用的HTTP客户端选项,包括自定义客户端、transport、超时与代理
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout 未指定超时时直接发送HTTP请求的厂商使用的默认超时
+const DefaultTimeout = 30 * time.Second
+
+// Options HTTP客户端选项,为nil时使用厂商默认配置
+type Options struct {
+	Client    *http.Client      // 基础客户端,为空时新建
+	Transport http.RoundTripper // 发送请求使用的transport,优先于Client中的transport,可注入replay.Transport或链路追踪
+	Timeout   time.Duration     // 请求超时,优先于Client中的超时
+	Proxy     string            // 代理地址,如http://127.0.0.1:8080,要求transport为*http.Transport
+}
+
+// RoundTripper 获取发送请求使用的transport,未指定transport与代理时返回nil,由调用方使用默认transport
+func (o *Options) RoundTripper() (http.RoundTripper, error) {
+	if o == nil {
+		return nil, nil
+	}
+	rt := o.Transport
+	if rt == nil && o.Client != nil {
+		rt = o.Client.Transport
+	}
+	if o.Proxy == "" {
+		return rt, nil
+	}
+	proxy, err := url.Parse(o.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", o.Proxy, err)
+	}
+	if proxy.Scheme == "" || proxy.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: scheme and host are required", o.Proxy)
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, errors.New("proxy requires transport to be *http.Transport")
+	}
+	transport = transport.Clone()
+	transport.Proxy = http.ProxyURL(proxy)
+	return transport, nil
+}
+
+// GetTimeout 获取请求超时,未指定时返回0
+func (o *Options) GetTimeout() time.Duration {
+	if o == nil {
+		return 0
+	}
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	if o.Client != nil {
+		return o.Client.Timeout
+	}
+	return 0
+}
+
+// NewClient 按选项创建HTTP客户端,不修改Options中的Client,未指定超时时使用 DefaultTimeout
+func (o *Options) NewClient() (*http.Client, error) {
+	client := &http.Client{}
+	if o != nil && o.Client != nil {
+		*client = *o.Client
+	}
+	rt, err := o.RoundTripper()
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = rt
+	client.Timeout = o.GetTimeout()
+	if client.Timeout <= 0 {
+		client.Timeout = DefaultTimeout
+	}
+	return client, nil
+}
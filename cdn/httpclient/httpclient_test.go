@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type stubTransport struct{}
+
+func (stubTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, http.ErrNotSupported
+}
+
+func TestNewClient(t *testing.T) {
+	base := &http.Client{Transport: stubTransport{}, Timeout: time.Second}
+	cases := []struct {
+		name      string
+		opts      *Options
+		transport http.RoundTripper
+		timeout   time.Duration
+	}{
+		{"nil", nil, nil, DefaultTimeout},
+		{"client", &Options{Client: base}, stubTransport{}, time.Second},
+		//Transport与Timeout优先于Client中的设置
+		{"override", &Options{Client: base, Transport: http.DefaultTransport, Timeout: time.Minute}, http.DefaultTransport, time.Minute},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client, err := c.opts.NewClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if client.Transport != c.transport || client.Timeout != c.timeout {
+				t.Fatalf("got transport %T timeout %s", client.Transport, client.Timeout)
+			}
+		})
+	}
+	if base.Transport != (stubTransport{}) || base.Timeout != time.Second {
+		t.Fatal("options client modified")
+	}
+}
+
+func TestRoundTripperProxy(t *testing.T) {
+	transport := &http.Transport{}
+	rt, err := (&Options{Transport: transport, Proxy: "http://127.0.0.1:8080"}).RoundTripper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxied, ok := rt.(*http.Transport)
+	if !ok || proxied == transport {
+		t.Fatalf("got %T, want cloned transport", rt)
+	}
+	if transport.Proxy != nil {
+		t.Fatal("options transport modified")
+	}
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "cdn.example.com"}}
+	if u, err := proxied.Proxy(req); err != nil || u.String() != "http://127.0.0.1:8080" {
+		t.Fatalf("got proxy %v %v", u, err)
+	}
+	for _, opts := range []*Options{
+		{Proxy: "://bad"},
+		{Proxy: "127.0.0.1:8080"},
+		{Proxy: "http://127.0.0.1:8080", Transport: stubTransport{}},
+	} {
+		if _, err := opts.RoundTripper(); err == nil {
+			t.Fatalf("proxy %q with %T: want error", opts.Proxy, opts.Transport)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Package cachettl 缓存时间换算
+package cachettl
+
+import "github.com/run-bigpig/cloud-sdk/cdn/consts"
+
+// Seconds 缓存时间按缓存单位(consts.CacheUnit*)换算为秒,未知单位按秒处理
+func Seconds(cacheTtl int64, cacheUnit int64) int64 {
+	switch cacheUnit {
+	case consts.CacheUnitMinute:
+		return cacheTtl * 60
+	case consts.CacheUnitHour:
+		return cacheTtl * 60 * 60
+	case consts.CacheUnitDay:
+		return cacheTtl * 60 * 60 * 24
+	default:
+		return cacheTtl
+	}
+}
@@ -0,0 +1,27 @@
+package cachettl
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"testing"
+)
+
+func TestSeconds(t *testing.T) {
+	cases := []struct {
+		ttl  int64
+		unit int64
+		want int64
+	}{
+		{30, consts.CacheUnitSecond, 30},
+		{30, consts.CacheUnitMinute, 1800},
+		{2, consts.CacheUnitHour, 7200},
+		{7, consts.CacheUnitDay, 604800},
+		//未知单位按秒处理
+		{30, 99, 30},
+		{0, consts.CacheUnitDay, 0},
+	}
+	for _, c := range cases {
+		if got := Seconds(c.ttl, c.unit); got != c.want {
+			t.Fatalf("Seconds(%d, %d) = %d, want %d", c.ttl, c.unit, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const ContentType = "Content-Type"
+
+// Client 通用的REST接口客户端,负责请求编码、签名、发送以及响应和错误的解析
+type Client struct {
+	Endpoint     string       // 接口地址,如https://cdn.example.com
+	Signer       Signer       // 为空时不签名
+	Codec        Codec        // 为空时使用JSON
+	ErrorDecoder ErrorDecoder // 为空时使用DecodeStatusError
+	HttpClient   *http.Client // 为空时使用http.DefaultClient
+}
+
+// Response 接口原始响应
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// HeaderUnmarshaler 需要从响应头中获取信息的响应
+type HeaderUnmarshaler interface {
+	UnmarshalHeader(statusCode int, header http.Header)
+}
+
+// NewClient 创建客户端,endpoint未指定协议时使用https
+func NewClient(endpoint string, signer Signer) *Client {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return &Client{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		Signer:   signer,
+	}
+}
+
+func (c *Client) codec() Codec {
+	if c.Codec == nil {
+		return JSON
+	}
+	return c.Codec
+}
+
+// Send 发送请求并返回原始响应,ErrorDecoder判定失败时返回错误
+func (c *Client) Send(ctx context.Context, r *Request) (*Response, error) {
+	body, err := c.encode(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	target := c.Endpoint + r.Path
+	if len(r.Query) > 0 {
+		target += "?" + r.Query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, r.Method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	if body != nil && req.Header.Get(ContentType) == "" {
+		req.Header.Set(ContentType, c.codec().ContentType())
+	}
+	if c.Signer != nil {
+		if err := c.Signer.Sign(req, body, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	client := c.HttpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	res := &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}
+	decodeError := c.ErrorDecoder
+	if decodeError == nil {
+		decodeError = DecodeStatusError
+	}
+	if err := decodeError(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Decode 解析响应体,响应实现HeaderUnmarshaler时同时解析响应头
+func (c *Client) Decode(res *Response, v interface{}) error {
+	if len(res.Body) > 0 {
+		if err := c.codec().Unmarshal(res.Body, v); err != nil {
+			return err
+		}
+	}
+	if h, ok := v.(HeaderUnmarshaler); ok {
+		h.UnmarshalHeader(res.StatusCode, res.Header)
+	}
+	return nil
+}
+
+func (c *Client) encode(body interface{}) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return b, nil
+	default:
+		return c.codec().Marshal(b)
+	}
+}
+
+// Do 发送请求并将响应解析为T
+func Do[T any](ctx context.Context, c *Client, r *Request) (*T, error) {
+	res, err := c.Send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(T)
+	if err := c.Decode(res, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
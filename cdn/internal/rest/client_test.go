@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type echo struct {
+	Method      string      `json:"method"`
+	Path        string      `json:"path"`
+	Query       string      `json:"query"`
+	ContentType string      `json:"content_type"`
+	Signed      string      `json:"signed"`
+	Body        string      `json:"body"`
+	Header      http.Header `json:"-"`
+}
+
+func (e *echo) UnmarshalHeader(statusCode int, header http.Header) {
+	e.Header = header
+}
+
+// newEchoServer 将请求信息原样返回
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set(ContentType, ApplicationJson)
+		data, _ := JSON.Marshal(&echo{
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			Query:       r.URL.RawQuery,
+			ContentType: r.Header.Get(ContentType),
+			Signed:      r.Header.Get("X-Signed"),
+			Body:        string(body),
+		})
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestNewClientEndpoint(t *testing.T) {
+	cases := map[string]string{
+		"cdn.example.com":         "https://cdn.example.com",
+		"cdn.example.com/":        "https://cdn.example.com",
+		"http://127.0.0.1:8080/":  "http://127.0.0.1:8080",
+		"https://cdn.example.com": "https://cdn.example.com",
+	}
+	for endpoint, want := range cases {
+		if got := NewClient(endpoint, nil).Endpoint; got != want {
+			t.Fatalf("%s: got %s, want %s", endpoint, got, want)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	s := newEchoServer(t)
+	signedAt := time.Time{}
+	c := NewClient(s.URL, SignerFunc(func(req *http.Request, body []byte, t time.Time) error {
+		signedAt = t
+		req.Header.Set("X-Signed", req.Method+" "+req.URL.RequestURI()+" "+string(body))
+		return nil
+	}))
+	r := NewRequest(http.MethodPost, "/v1/domains").
+		WithQuery("a", "1").
+		WithQuery("empty", "").
+		WithBody(map[string]string{"domain": "www.example.com"})
+	res, err := Do[echo](context.Background(), c, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := echo{
+		Method:      http.MethodPost,
+		Path:        "/v1/domains",
+		Query:       "a=1",
+		ContentType: ApplicationJson,
+		Signed:      `POST /v1/domains?a=1 {"domain":"www.example.com"}`,
+		Body:        `{"domain":"www.example.com"}`,
+	}
+	if res.Header.Get("X-Request-Id") != "req-1" {
+		t.Fatalf("response header not decoded %v", res.Header)
+	}
+	res.Header = nil
+	if !reflect.DeepEqual(*res, want) {
+		t.Fatalf("got %+v, want %+v", *res, want)
+	}
+	if signedAt.IsZero() || time.Since(signedAt) > time.Minute {
+		t.Fatalf("unexpected signing time %v", signedAt)
+	}
+}
+
+func TestSendRawBody(t *testing.T) {
+	s := newEchoServer(t)
+	c := NewClient(s.URL, nil)
+	//已编码的请求体原样发送,已设置的Content-Type不被覆盖
+	r := NewRequest(http.MethodPost, "/").WithHeader(ContentType, "application/x-www-form-urlencoded").WithBody([]byte("a=1&b=2"))
+	res, err := Do[echo](context.Background(), c, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Body != "a=1&b=2" || res.ContentType != "application/x-www-form-urlencoded" || res.Signed != "" {
+		t.Fatalf("unexpected request %+v", res)
+	}
+	//没有请求体时不设置Content-Type
+	res, err = Do[echo](context.Background(), c, NewRequest(http.MethodGet, "/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Body != "" || res.ContentType != "" {
+		t.Fatalf("unexpected request %+v", res)
+	}
+}
+
+func TestSendSignerError(t *testing.T) {
+	called := false
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer s.Close()
+	signErr := errors.New("sign failed")
+	c := NewClient(s.URL, SignerFunc(func(req *http.Request, body []byte, t time.Time) error {
+		return signErr
+	}))
+	if _, err := c.Send(context.Background(), NewRequest(http.MethodGet, "/")); !errors.Is(err, signErr) {
+		t.Fatalf("got %v, want sign error", err)
+	}
+	if called {
+		t.Fatal("request sent after signing failed")
+	}
+}
+
+func TestSendContextCanceled(t *testing.T) {
+	s := newEchoServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewClient(s.URL, nil).Send(ctx, NewRequest(http.MethodGet, "/")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context canceled", err)
+	}
+}
+
+func TestSendDecodeError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("denied"))
+	}))
+	defer s.Close()
+	_, err := Do[echo](context.Background(), NewClient(s.URL, nil), NewRequest(http.MethodGet, "/"))
+	var e *ServiceError
+	if !errors.As(err, &e) || e.StatusCode != http.StatusForbidden || e.ErrorMessage != "denied" {
+		t.Fatalf("got %v, want status error", err)
+	}
+}
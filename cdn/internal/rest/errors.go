@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/cast"
+	"net/http"
+)
+
+// ServiceError 厂商接口返回的错误
+type ServiceError struct {
+	StatusCode   int
+	RequestId    string
+	ErrorCode    string
+	ErrorMessage string
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("{\"status_code\": %d,\"request_id\": \"%s\",\"error_code\": \"%s\",\"error_message\": \"%s\"}",
+		e.StatusCode, e.RequestId, e.ErrorCode, e.ErrorMessage)
+}
+
+// ErrorDecoder 从响应中解析错误,响应成功时返回nil
+type ErrorDecoder func(res *Response) error
+
+// ErrorFields 错误信息在响应中的位置,json字段以路径表示,如[]string{"ResponseMetadata", "Error", "Code"}
+type ErrorFields struct {
+	RequestIdHeader string
+	RequestId       []string
+	Code            []string
+	Message         []string
+	// BodyError 状态码成功时响应体中存在错误码也视为失败
+	BodyError bool
+}
+
+// DecodeStatusError 状态码不小于400时视为失败,响应体作为错误信息
+func DecodeStatusError(res *Response) error {
+	if res.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	return &ServiceError{StatusCode: res.StatusCode, ErrorMessage: string(res.Body)}
+}
+
+// NewErrorDecoder 创建按json字段解析错误的ErrorDecoder
+func NewErrorDecoder(fields ErrorFields) ErrorDecoder {
+	return func(res *Response) error {
+		failed := res.StatusCode >= http.StatusBadRequest
+		if !failed && !fields.BodyError {
+			return nil
+		}
+		body := make(map[string]interface{})
+		if err := json.Unmarshal(res.Body, &body); err != nil {
+			if !failed {
+				return nil
+			}
+			return &ServiceError{
+				StatusCode:   res.StatusCode,
+				RequestId:    res.Header.Get(fields.RequestIdHeader),
+				ErrorMessage: string(res.Body),
+			}
+		}
+		code := lookup(body, fields.Code)
+		if !failed && code == "" {
+			return nil
+		}
+		e := &ServiceError{
+			StatusCode:   res.StatusCode,
+			RequestId:    lookup(body, fields.RequestId),
+			ErrorCode:    code,
+			ErrorMessage: lookup(body, fields.Message),
+		}
+		if fields.RequestIdHeader != "" && res.Header.Get(fields.RequestIdHeader) != "" {
+			e.RequestId = res.Header.Get(fields.RequestIdHeader)
+		}
+		return e
+	}
+}
+
+// lookup 按路径获取json字段值
+func lookup(body map[string]interface{}, path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	var v interface{} = body
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		v = m[key]
+	}
+	return cast.ToString(v)
+}
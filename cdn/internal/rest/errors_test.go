@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeStatusError(t *testing.T) {
+	if err := DecodeStatusError(&Response{StatusCode: http.StatusOK, Body: []byte(`{"code":"x"}`)}); err != nil {
+		t.Fatalf("got %v for success", err)
+	}
+	err := DecodeStatusError(&Response{StatusCode: http.StatusBadGateway, Body: []byte("bad gateway")})
+	want := &ServiceError{StatusCode: http.StatusBadGateway, ErrorMessage: "bad gateway"}
+	if !reflect.DeepEqual(err, want) {
+		t.Fatalf("got %#v, want %#v", err, want)
+	}
+}
+
+func TestNewErrorDecoder(t *testing.T) {
+	decode := NewErrorDecoder(ErrorFields{
+		RequestIdHeader: "X-Request-Id",
+		RequestId:       []string{"ResponseMetadata", "RequestId"},
+		Code:            []string{"ResponseMetadata", "Error", "Code"},
+		Message:         []string{"ResponseMetadata", "Error", "Message"},
+	})
+	bodyError := NewErrorDecoder(ErrorFields{
+		RequestId: []string{"requestId"},
+		Code:      []string{"code"},
+		Message:   []string{"message"},
+		BodyError: true,
+	})
+	cases := []struct {
+		name   string
+		decode ErrorDecoder
+		res    *Response
+		want   error
+	}{
+		{
+			name:   "success",
+			decode: decode,
+			res:    &Response{StatusCode: http.StatusOK, Body: []byte(`{"ResponseMetadata":{"Error":{"Code":"x"}}}`)},
+		},
+		{
+			name:   "nested fields",
+			decode: decode,
+			res: &Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: []byte(
+				`{"ResponseMetadata":{"RequestId":"req-1","Error":{"Code":"NotFound.Domain","Message":"domain not found"}}}`)},
+			want: &ServiceError{StatusCode: http.StatusNotFound, RequestId: "req-1", ErrorCode: "NotFound.Domain", ErrorMessage: "domain not found"},
+		},
+		{
+			name:   "request id header first",
+			decode: decode,
+			res: &Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"X-Request-Id": {"req-2"}}, Body: []byte(
+				`{"ResponseMetadata":{"RequestId":"req-1","Error":{"Code":"Throttling","Message":"slow down"}}}`)},
+			want: &ServiceError{StatusCode: http.StatusTooManyRequests, RequestId: "req-2", ErrorCode: "Throttling", ErrorMessage: "slow down"},
+		},
+		{
+			name:   "non json body",
+			decode: decode,
+			res:    &Response{StatusCode: http.StatusBadGateway, Header: http.Header{"X-Request-Id": {"req-3"}}, Body: []byte("<html>bad gateway</html>")},
+			want:   &ServiceError{StatusCode: http.StatusBadGateway, RequestId: "req-3", ErrorMessage: "<html>bad gateway</html>"},
+		},
+		{
+			name:   "missing fields",
+			decode: decode,
+			res:    &Response{StatusCode: http.StatusInternalServerError, Header: http.Header{}, Body: []byte(`{"ResponseMetadata":"broken"}`)},
+			want:   &ServiceError{StatusCode: http.StatusInternalServerError},
+		},
+		{
+			name:   "body error",
+			decode: bodyError,
+			res:    &Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: []byte(`{"requestId":"req-4","code":1001,"message":"invalid domain"}`)},
+			want:   &ServiceError{StatusCode: http.StatusOK, RequestId: "req-4", ErrorCode: "1001", ErrorMessage: "invalid domain"},
+		},
+		{
+			name:   "body without code",
+			decode: bodyError,
+			res:    &Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: []byte(`{"requestId":"req-5","data":[]}`)},
+		},
+		{
+			name:   "body not json",
+			decode: bodyError,
+			res:    &Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: []byte("ok")},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.decode(c.res)
+			if c.want == nil {
+				if err != nil {
+					t.Fatalf("got %v, want nil", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(err, c.want) {
+				t.Fatalf("got %#v, want %#v", err, c.want)
+			}
+		})
+	}
+}
+
+func TestServiceErrorString(t *testing.T) {
+	err := &ServiceError{StatusCode: 400, RequestId: "req-1", ErrorCode: "InvalidParameter", ErrorMessage: "bad domain"}
+	want := `{"status_code": 400,"request_id": "req-1","error_code": "InvalidParameter","error_message": "bad domain"}`
+	if err.Error() != want {
+		t.Fatalf("got %s, want %s", err.Error(), want)
+	}
+}
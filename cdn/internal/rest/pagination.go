@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/utils"
+)
+
+// Pages 按页码拉取全部数据,页码从1开始;total小于0表示总数未知,此时返回条数不足pageSize即结束
+func Pages[T any](ctx context.Context, pageSize int64, fetch func(pageNumber int64) ([]T, int64, error)) ([]T, error) {
+	var all []T
+	for pageNumber := int64(1); ; pageNumber++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		items, total, err := fetch(pageNumber)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) == 0 || (total >= 0 && int64(len(all)) >= total) || (total < 0 && int64(len(items)) < pageSize) {
+			return all, nil
+		}
+	}
+}
+
+// Markers 按游标拉取全部数据,从空游标开始,返回的下一页游标为空时结束
+func Markers[T any](ctx context.Context, fetch func(marker string) ([]T, string, error)) ([]T, error) {
+	var all []T
+	marker := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		items, next, err := fetch(marker)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if next == "" || next == marker {
+			return all, nil
+		}
+		marker = next
+	}
+}
+
+// Slice 对已拉取的全部数据分页,返回总数与当前页数据
+func Slice[T any](items []T, page int64, limit int64) (int64, []T) {
+	total := int64(len(items))
+	offset, limit := utils.CalcOffsetAndLimit(page, limit)
+	if offset >= total {
+		return total, []T{}
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return total, items[offset:end]
+}
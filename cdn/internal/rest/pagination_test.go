@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// numbers 生成1到n的整数
+func numbers(n int) []int {
+	items := make([]int, 0, n)
+	for i := 1; i <= n; i++ {
+		items = append(items, i)
+	}
+	return items
+}
+
+func TestPages(t *testing.T) {
+	all := numbers(25)
+	cases := []struct {
+		name  string
+		total int64
+		items []int
+		want  int
+	}{
+		{"total known", 25, all, 3},
+		//总数未知时返回条数不足一页即结束
+		{"total unknown", -1, all, 3},
+		{"total unknown full pages", -1, numbers(20), 3},
+		{"empty", 0, nil, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fetches := 0
+			got, err := Pages(context.Background(), 10, func(pageNumber int64) ([]int, int64, error) {
+				fetches++
+				_, page := Slice(c.items, pageNumber, 10)
+				return page, c.total, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(c.items) == 0 {
+				c.items = nil
+			}
+			if !reflect.DeepEqual(got, c.items) || fetches != c.want {
+				t.Fatalf("got %v after %d fetches, want %d fetches", got, fetches, c.want)
+			}
+		})
+	}
+}
+
+func TestPagesError(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	_, err := Pages(context.Background(), 10, func(pageNumber int64) ([]int, int64, error) {
+		if pageNumber == 2 {
+			return nil, 0, fetchErr
+		}
+		return numbers(10), 30, nil
+	})
+	if !errors.Is(err, fetchErr) {
+		t.Fatalf("got %v, want fetch error", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = Pages(ctx, 10, func(pageNumber int64) ([]int, int64, error) {
+		cancel()
+		return numbers(10), 30, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context canceled", err)
+	}
+}
+
+func TestMarkers(t *testing.T) {
+	pages := map[string][]int{"": {1, 2}, "b": {3, 4}, "c": {5}}
+	next := map[string]string{"": "b", "b": "c", "c": ""}
+	markers := make([]string, 0)
+	got, err := Markers(context.Background(), func(marker string) ([]int, string, error) {
+		markers = append(markers, marker)
+		return pages[marker], next[marker], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) || !reflect.DeepEqual(markers, []string{"", "b", "c"}) {
+		t.Fatalf("got %v with markers %q", got, markers)
+	}
+	//游标不变时结束,避免死循环
+	calls := 0
+	got, err = Markers(context.Background(), func(marker string) ([]int, string, error) {
+		calls++
+		return []int{calls}, "same", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want stop on repeated marker", got)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = Markers(ctx, func(marker string) ([]int, string, error) {
+		cancel()
+		return []int{1}, marker + "n", nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context canceled", err)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	items := numbers(25)
+	cases := []struct {
+		page  int64
+		limit int64
+		want  []int
+	}{
+		{1, 10, numbers(10)},
+		{3, 10, []int{21, 22, 23, 24, 25}},
+		{4, 10, []int{}},
+		//页码与条数不合法时取第1页、每页10条
+		{0, 0, numbers(10)},
+		{2, 30, []int{}},
+	}
+	for _, c := range cases {
+		t.Run(strconv.FormatInt(c.page, 10)+"/"+strconv.FormatInt(c.limit, 10), func(t *testing.T) {
+			total, got := Slice(items, c.page, c.limit)
+			if total != 25 || !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %d %v, want %v", total, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Request 待发送的接口请求
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	// Body 请求体,[]byte视为已编码的请求体直接发送,其他类型使用Client的Codec编码,为空时不发送请求体
+	Body interface{}
+}
+
+// NewRequest 创建请求
+func NewRequest(method string, path string) *Request {
+	return &Request{
+		Method: method,
+		Path:   path,
+		Query:  url.Values{},
+		Header: http.Header{},
+	}
+}
+
+// WithQuery 添加查询参数,空值忽略
+func (r *Request) WithQuery(key string, value string) *Request {
+	if value != "" {
+		r.Query.Add(key, value)
+	}
+	return r
+}
+
+// WithQueryParams 请求结构体按json字段名转为查询参数,忽略空值
+func (r *Request) WithQueryParams(params interface{}) (*Request, error) {
+	values, err := EncodeQuery(params)
+	if err != nil {
+		return r, err
+	}
+	for k, v := range values {
+		r.Query[k] = append(r.Query[k], v...)
+	}
+	return r, nil
+}
+
+// WithHeader 设置请求头
+func (r *Request) WithHeader(key string, value string) *Request {
+	r.Header.Set(key, value)
+	return r
+}
+
+// WithBody 设置请求体
+func (r *Request) WithBody(body interface{}) *Request {
+	r.Body = body
+	return r
+}
+
+// EncodeQuery 结构体按json字段名转为查询参数,忽略空值
+func EncodeQuery(params interface{}) (url.Values, error) {
+	values := url.Values{}
+	if params == nil {
+		return values, nil
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range fields {
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			s = string(v)
+		}
+		if s == "" || s == "null" {
+			continue
+		}
+		values.Set(k, s)
+	}
+	return values, nil
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type queryParams struct {
+	Domain   string   `json:"domain"`
+	Page     int64    `json:"page"`
+	Enabled  bool     `json:"enabled"`
+	Empty    string   `json:"empty"`
+	Omitted  string   `json:"omitted,omitempty"`
+	Pointer  *int64   `json:"pointer"`
+	Tags     []string `json:"tags"`
+	internal string
+}
+
+func TestEncodeQuery(t *testing.T) {
+	size := int64(0)
+	cases := []struct {
+		name   string
+		params interface{}
+		want   url.Values
+	}{
+		{"nil", nil, url.Values{}},
+		{
+			name:   "struct",
+			params: &queryParams{Domain: "www.example.com", Page: 2, Enabled: true, Tags: []string{"a", "b"}, internal: "x"},
+			want:   url.Values{"domain": {"www.example.com"}, "page": {"2"}, "enabled": {"true"}, "tags": {`["a","b"]`}},
+		},
+		//零值数字与false不是空值
+		{
+			name:   "zero values",
+			params: queryParams{Pointer: &size},
+			want:   url.Values{"page": {"0"}, "enabled": {"false"}, "pointer": {"0"}},
+		},
+		{"map", map[string]interface{}{"a": "1", "b": 2.5, "c": nil}, url.Values{"a": {"1"}, "b": {"2.5"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := EncodeQuery(c.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+	if _, err := EncodeQuery([]string{"a"}); err == nil {
+		t.Fatal("want error for non object params")
+	}
+	if _, err := EncodeQuery(make(chan int)); err == nil {
+		t.Fatal("want error for unsupported type")
+	}
+}
+
+func TestWithQueryParams(t *testing.T) {
+	r, err := NewRequest("GET", "/domains").WithQuery("page", "1").WithQueryParams(&queryParams{Domain: "www.example.com", Page: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{"page": {"1", "2"}, "domain": {"www.example.com"}, "enabled": {"false"}}
+	if !reflect.DeepEqual(r.Query, want) {
+		t.Fatalf("got %v, want %v", r.Query, want)
+	}
+}
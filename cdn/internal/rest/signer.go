@@ -0,0 +1,19 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+)
+
+// Signer 请求签名,在请求发送前写入签名相关头部或参数
+type Signer interface {
+	// Sign 对请求签名,body为已编码的请求体,t为签名时间
+	Sign(req *http.Request, body []byte, t time.Time) error
+}
+
+// SignerFunc 函数形式的签名器
+type SignerFunc func(req *http.Request, body []byte, t time.Time) error
+
+func (f SignerFunc) Sign(req *http.Request, body []byte, t time.Time) error {
+	return f(req, body, t)
+}
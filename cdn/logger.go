@@ -0,0 +1,360 @@
+package cdn
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// LoggedCdn 记录操作日志的Cdn
+type LoggedCdn struct {
+	ctx    context.Context
+	cdn    CdnWithContext
+	name   string
+	logger *slog.Logger
+}
+
+var (
+	_ CdnWithContext          = (*LoggedCdn)(nil)
+	_ ConfigReaderWithContext = (*LoggedCdn)(nil)
+)
+
+// NewLoggedCdn 包装c,记录每次操作的厂商、域名、耗时与结果,logger为空时使用slog.Default()
+// 成功的操作以Info级别记录,失败的操作以Error级别记录,Debug级别同时记录脱敏后的请求
+func NewLoggedCdn(ctx context.Context, c Cdn, logger *slog.Logger) *LoggedCdn {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LoggedCdn{
+		ctx:    ctx,
+		cdn:    WithContext(c),
+		name:   c.GetSdkName(),
+		logger: logger,
+	}
+}
+
+// GetSdkName 返回被包装的厂商名称
+func (l *LoggedCdn) GetSdkName() string {
+	return l.name
+}
+
+// Unwrap 返回被包装的Cdn
+func (l *LoggedCdn) Unwrap() CdnWithContext {
+	return l.cdn
+}
+
+// logged 执行fn并记录日志
+func logged[T any](ctx context.Context, l *LoggedCdn, op string, req interface{}, fn func(ctx context.Context) (T, error)) (T, error) {
+	if ctx == nil {
+		ctx = l.ctx
+	}
+	start := time.Now()
+	res, err := fn(ctx)
+	attrs := []slog.Attr{
+		slog.String("provider", l.name),
+		slog.String("operation", op),
+	}
+	if domains := RequestDomains(req); len(domains) > 0 {
+		attrs = append(attrs, slog.String("domain", strings.Join(domains, ",")))
+	}
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	if l.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request", cdnlog.RedactRequest(req)))
+	}
+	if err != nil {
+		l.logger.LogAttrs(ctx, slog.LevelError, "cdn operation failed", append(attrs, slog.String("error", err.Error()))...)
+		return res, err
+	}
+	l.logger.LogAttrs(ctx, slog.LevelInfo, "cdn operation", attrs...)
+	return res, nil
+}
+
+// loggedErr 执行只返回错误的fn并记录日志
+func loggedErr(ctx context.Context, l *LoggedCdn, op string, req interface{}, fn func(ctx context.Context) error) error {
+	_, err := logged(ctx, l, op, req, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// CreateDomain 创建域名
+func (l *LoggedCdn) CreateDomain(req *types.CreateDomainRequest) error {
+	return l.CreateDomainWithContext(l.ctx, req)
+}
+
+// CreateDomainWithContext 创建域名
+func (l *LoggedCdn) CreateDomainWithContext(ctx context.Context, req *types.CreateDomainRequest) error {
+	return loggedErr(ctx, l, "CreateDomain", req, func(ctx context.Context) error {
+		return l.cdn.CreateDomainWithContext(ctx, req)
+	})
+}
+
+// UpdateDomain 更新域名
+func (l *LoggedCdn) UpdateDomain(req *types.UpdateDomainRequest) error {
+	return l.UpdateDomainWithContext(l.ctx, req)
+}
+
+// UpdateDomainWithContext 更新域名
+func (l *LoggedCdn) UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error {
+	return loggedErr(ctx, l, "UpdateDomain", req, func(ctx context.Context) error {
+		return l.cdn.UpdateDomainWithContext(ctx, req)
+	})
+}
+
+// DisableDomain 停用域名
+func (l *LoggedCdn) DisableDomain(req *types.DisableDomainRequest) error {
+	return l.DisableDomainWithContext(l.ctx, req)
+}
+
+// DisableDomainWithContext 停用域名
+func (l *LoggedCdn) DisableDomainWithContext(ctx context.Context, req *types.DisableDomainRequest) error {
+	return loggedErr(ctx, l, "DisableDomain", req, func(ctx context.Context) error {
+		return l.cdn.DisableDomainWithContext(ctx, req)
+	})
+}
+
+// EnableDomain 启用域名
+func (l *LoggedCdn) EnableDomain(req *types.EnableDomainRequest) error {
+	return l.EnableDomainWithContext(l.ctx, req)
+}
+
+// EnableDomainWithContext 启用域名
+func (l *LoggedCdn) EnableDomainWithContext(ctx context.Context, req *types.EnableDomainRequest) error {
+	return loggedErr(ctx, l, "EnableDomain", req, func(ctx context.Context) error {
+		return l.cdn.EnableDomainWithContext(ctx, req)
+	})
+}
+
+// DeleteDomain 删除域名
+func (l *LoggedCdn) DeleteDomain(req *types.DeleteDomainRequest) error {
+	return l.DeleteDomainWithContext(l.ctx, req)
+}
+
+// DeleteDomainWithContext 删除域名
+func (l *LoggedCdn) DeleteDomainWithContext(ctx context.Context, req *types.DeleteDomainRequest) error {
+	return loggedErr(ctx, l, "DeleteDomain", req, func(ctx context.Context) error {
+		return l.cdn.DeleteDomainWithContext(ctx, req)
+	})
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (l *LoggedCdn) CreateVerifyRecord(req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return l.CreateVerifyRecordWithContext(l.ctx, req)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (l *LoggedCdn) CreateVerifyRecordWithContext(ctx context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return logged(ctx, l, "CreateVerifyRecord", req, func(ctx context.Context) (*types.CreateVerifyRecordResponse, error) {
+		return l.cdn.CreateVerifyRecordWithContext(ctx, req)
+	})
+}
+
+// VerifyDomainRecord 验证域名
+func (l *LoggedCdn) VerifyDomainRecord(req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return l.VerifyDomainRecordWithContext(l.ctx, req)
+}
+
+// VerifyDomainRecordWithContext 验证域名
+func (l *LoggedCdn) VerifyDomainRecordWithContext(ctx context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return logged(ctx, l, "VerifyDomainRecord", req, func(ctx context.Context) (*types.VerifyDomainRecordResponse, error) {
+		return l.cdn.VerifyDomainRecordWithContext(ctx, req)
+	})
+}
+
+// ShowDomainDetail 获取域名详情
+func (l *LoggedCdn) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return l.ShowDomainDetailWithContext(l.ctx, req)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (l *LoggedCdn) ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return logged(ctx, l, "ShowDomainDetail", req, func(ctx context.Context) (*types.ShowDomainDetailResponse, error) {
+		return l.cdn.ShowDomainDetailWithContext(ctx, req)
+	})
+}
+
+// ShowDomainConfig 获取域名完整配置
+func (l *LoggedCdn) ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return l.ShowDomainConfigWithContext(l.ctx, req)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置,被包装的Cdn未实现 ConfigReader 时返回 cdnerr.ErrUnsupported
+func (l *LoggedCdn) ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return logged(ctx, l, "ShowDomainConfig", req, func(ctx context.Context) (*types.UpdateDomainRequest, error) {
+		return ShowDomainConfig(ctx, l.cdn, req)
+	})
+}
+
+// ShowDomainStatusList 获取指定状态域名列表
+func (l *LoggedCdn) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return l.ShowDomainStatusListWithContext(l.ctx, req)
+}
+
+// ShowDomainStatusListWithContext 获取指定状态域名列表
+func (l *LoggedCdn) ShowDomainStatusListWithContext(ctx context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return logged(ctx, l, "ShowDomainStatusList", req, func(ctx context.Context) (*types.ShowDomainStatusListResponse, error) {
+		return l.cdn.ShowDomainStatusListWithContext(ctx, req)
+	})
+}
+
+// PurgePathCache 刷新目录缓存
+func (l *LoggedCdn) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return l.PurgePathCacheWithContext(l.ctx, req)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (l *LoggedCdn) PurgePathCacheWithContext(ctx context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return logged(ctx, l, "PurgePathCache", req, func(ctx context.Context) (*types.PurgeCacheResponse, error) {
+		return l.cdn.PurgePathCacheWithContext(ctx, req)
+	})
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (l *LoggedCdn) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return l.PurgeUrlsCacheWithContext(l.ctx, req)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (l *LoggedCdn) PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return logged(ctx, l, "PurgeUrlsCache", req, func(ctx context.Context) (*types.PurgeCacheResponse, error) {
+		return l.cdn.PurgeUrlsCacheWithContext(ctx, req)
+	})
+}
+
+// PushUrlsCache 预热URL缓存
+func (l *LoggedCdn) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return l.PushUrlsCacheWithContext(l.ctx, req)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (l *LoggedCdn) PushUrlsCacheWithContext(ctx context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return logged(ctx, l, "PushUrlsCache", req, func(ctx context.Context) (*types.PushUrlsCacheResponse, error) {
+		return l.cdn.PushUrlsCacheWithContext(ctx, req)
+	})
+}
+
+// ShowPurgeTaskStatus 获取刷新任务状态
+func (l *LoggedCdn) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return l.ShowPurgeTaskStatusWithContext(l.ctx, req)
+}
+
+// ShowPurgeTaskStatusWithContext 获取刷新任务状态
+func (l *LoggedCdn) ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return logged(ctx, l, "ShowPurgeTaskStatus", req, func(ctx context.Context) (*types.ShowPurgeTaskStatusResponse, error) {
+		return l.cdn.ShowPurgeTaskStatusWithContext(ctx, req)
+	})
+}
+
+// ShowPushTaskStatus 获取预热任务状态
+func (l *LoggedCdn) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return l.ShowPushTaskStatusWithContext(l.ctx, req)
+}
+
+// ShowPushTaskStatusWithContext 获取预热任务状态
+func (l *LoggedCdn) ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return logged(ctx, l, "ShowPushTaskStatus", req, func(ctx context.Context) (*types.ShowPushTaskStatusResponse, error) {
+		return l.cdn.ShowPushTaskStatusWithContext(ctx, req)
+	})
+}
+
+// ShowPurgeTaskList 获取刷新任务列表
+func (l *LoggedCdn) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return l.ShowPurgeTaskListWithContext(l.ctx, req)
+}
+
+// ShowPurgeTaskListWithContext 获取刷新任务列表
+func (l *LoggedCdn) ShowPurgeTaskListWithContext(ctx context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return logged(ctx, l, "ShowPurgeTaskList", req, func(ctx context.Context) (*types.ShowPurgeTaskListResponse, error) {
+		return l.cdn.ShowPurgeTaskListWithContext(ctx, req)
+	})
+}
+
+// ShowPushTaskList 获取预热任务列表
+func (l *LoggedCdn) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return l.ShowPushTaskListWithContext(l.ctx, req)
+}
+
+// ShowPushTaskListWithContext 获取预热任务列表
+func (l *LoggedCdn) ShowPushTaskListWithContext(ctx context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return logged(ctx, l, "ShowPushTaskList", req, func(ctx context.Context) (*types.ShowPushTaskListResponse, error) {
+		return l.cdn.ShowPushTaskListWithContext(ctx, req)
+	})
+}
+
+// DomainAccessDataStatic 域名访问数据统计信息
+func (l *LoggedCdn) DomainAccessDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return l.DomainAccessDataStaticWithContext(l.ctx, req)
+}
+
+// DomainAccessDataStaticWithContext 域名访问数据统计信息
+func (l *LoggedCdn) DomainAccessDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return logged(ctx, l, "DomainAccessDataStatic", req, func(ctx context.Context) (types.DomainAccessDataStaticResponse, error) {
+		return l.cdn.DomainAccessDataStaticWithContext(ctx, req)
+	})
+}
+
+// DomainOriginDataStatic 域名回源数据统计信息
+func (l *LoggedCdn) DomainOriginDataStatic(req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return l.DomainOriginDataStaticWithContext(l.ctx, req)
+}
+
+// DomainOriginDataStaticWithContext 域名回源数据统计信息
+func (l *LoggedCdn) DomainOriginDataStaticWithContext(ctx context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return logged(ctx, l, "DomainOriginDataStatic", req, func(ctx context.Context) (types.DomainOriginDataStaticResponse, error) {
+		return l.cdn.DomainOriginDataStaticWithContext(ctx, req)
+	})
+}
+
+// ListTopUrlDataStatic 获取TOP URL访问数据
+func (l *LoggedCdn) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return l.ListTopUrlDataStaticWithContext(l.ctx, req)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL访问数据
+func (l *LoggedCdn) ListTopUrlDataStaticWithContext(ctx context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return logged(ctx, l, "ListTopUrlDataStatic", req, func(ctx context.Context) ([]*types.ListTopUrlDataStaticResponse, error) {
+		return l.cdn.ListTopUrlDataStaticWithContext(ctx, req)
+	})
+}
+
+// DomainAccessTotalData 域名访问总流量
+func (l *LoggedCdn) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return l.DomainAccessTotalDataWithContext(l.ctx, req)
+}
+
+// DomainAccessTotalDataWithContext 域名访问总流量
+func (l *LoggedCdn) DomainAccessTotalDataWithContext(ctx context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return logged(ctx, l, "DomainAccessTotalData", req, func(ctx context.Context) (types.DataTotalDataResponse, error) {
+		return l.cdn.DomainAccessTotalDataWithContext(ctx, req)
+	})
+}
+
+// DomainOriginTotalData 域名回源数据总流量
+func (l *LoggedCdn) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return l.DomainOriginTotalDataWithContext(l.ctx, req)
+}
+
+// DomainOriginTotalDataWithContext 域名回源数据总流量
+func (l *LoggedCdn) DomainOriginTotalDataWithContext(ctx context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return logged(ctx, l, "DomainOriginTotalData", req, func(ctx context.Context) (types.DataTotalDataResponse, error) {
+		return l.cdn.DomainOriginTotalDataWithContext(ctx, req)
+	})
+}
+
+// UserAccessRegionDistribution 用户访问区域分布
+func (l *LoggedCdn) UserAccessRegionDistribution(req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return l.UserAccessRegionDistributionWithContext(l.ctx, req)
+}
+
+// UserAccessRegionDistributionWithContext 用户访问区域分布
+func (l *LoggedCdn) UserAccessRegionDistributionWithContext(ctx context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return logged(ctx, l, "UserAccessRegionDistribution", req, func(ctx context.Context) (types.UserAccessRegionDistributionResponse, error) {
+		return l.cdn.UserAccessRegionDistributionWithContext(ctx, req)
+	})
+}
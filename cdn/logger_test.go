@@ -0,0 +1,162 @@
+package cdn_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// logSecrets 凭证、鉴权key与证书私钥,不允许出现在日志里
+var logSecrets = []string{"AKIDsecret", "SKsecret", "auth-key-secret", "auth-backup-secret", "cert-key-secret"}
+
+// newDebugLogger 以json格式记录Debug及以上级别的日志
+func newDebugLogger() (*slog.Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})), buf
+}
+
+// logRecords 解析日志,每行一条
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var list []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		m := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid log line %s: %v", line, err)
+		}
+		list = append(list, m)
+	}
+	return list
+}
+
+func assertNoSecrets(t *testing.T, buf *bytes.Buffer) {
+	t.Helper()
+	for _, s := range logSecrets {
+		if strings.Contains(buf.String(), s) {
+			t.Fatalf("log contains secret %s:\n%s", s, buf.String())
+		}
+	}
+}
+
+func newSecretUpdate() *types.UpdateDomainRequest {
+	return &types.UpdateDomainRequest{
+		UpdateAction: types.UpdateFullConf,
+		Domain:       "www.example.com",
+		AuthConf:     &entity.AuthConf{Status: types.ON, AuthKey: "auth-key-secret", AuthKeyBackup: "auth-backup-secret"},
+		HttpsConf:    &entity.HttpsConf{HttpsStatus: types.ON, CertName: "example", CertValue: "cert", CertKey: "cert-key-secret"},
+	}
+}
+
+func TestLoggedCdn(t *testing.T) {
+	ctx := context.Background()
+	logger, buf := newDebugLogger()
+	l := cdn.NewLoggedCdn(ctx, cdntest.New(ctx, nil), logger)
+	if err := l.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	req := newSecretUpdate()
+	if err := l.UpdateDomain(req); err != nil {
+		t.Fatal(err)
+	}
+	if req.AuthConf.AuthKey != "auth-key-secret" || req.HttpsConf.CertKey != "cert-key-secret" {
+		t.Fatal("redaction modified the request")
+	}
+	if _, err := l.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "none.example.com"}); err == nil {
+		t.Fatal("want domain not found")
+	}
+
+	assertNoSecrets(t, buf)
+	list := logRecords(t, buf)
+	want := []struct {
+		level     string
+		msg       string
+		operation string
+		domain    string
+	}{
+		{"INFO", "cdn operation", "CreateDomain", "www.example.com"},
+		{"INFO", "cdn operation", "UpdateDomain", "www.example.com"},
+		{"ERROR", "cdn operation failed", "ShowDomainDetail", "none.example.com"},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("got %d records:\n%s", len(list), buf.String())
+	}
+	for i, w := range want {
+		r := list[i]
+		if r["level"] != w.level || r["msg"] != w.msg || r["provider"] != cdntest.SdkName || r["operation"] != w.operation || r["domain"] != w.domain {
+			t.Fatalf("record %d: unexpected %v", i, r)
+		}
+		if _, ok := r["latency"]; !ok {
+			t.Fatalf("record %d: latency missing %v", i, r)
+		}
+		if (r["error"] != nil) != (w.level == "ERROR") {
+			t.Fatalf("record %d: unexpected error %v", i, r["error"])
+		}
+	}
+	//Debug级别记录脱敏后的请求
+	request, _ := list[1]["request"].(map[string]interface{})
+	auth, _ := request["auth_conf"].(map[string]interface{})
+	if auth == nil || auth["auth_key"] != cdnlog.Redacted || auth["auth_key_backup"] != cdnlog.Redacted {
+		t.Fatalf("got request %v", request)
+	}
+}
+
+// TestLoggedProvider 操作日志与厂商HTTP日志使用同一logger时,两者均不包含凭证
+func TestLoggedProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Cnc-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"NoSuchDomain","message":"domain not found"}`))
+	}))
+	t.Cleanup(srv.Close)
+	ctx := context.Background()
+	logger, buf := newDebugLogger()
+	provider, err := wangsu.New(ctx, &wangsu.Config{Ak: "AKIDsecret", Sk: "SKsecret", Endpoint: srv.URL, Logger: logger})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := cdn.NewLoggedCdn(ctx, provider, logger)
+	if err := l.UpdateDomain(newSecretUpdate()); err == nil {
+		t.Fatal("want error")
+	}
+	if _, err := l.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); err == nil {
+		t.Fatal("want error")
+	}
+
+	assertNoSecrets(t, buf)
+	var requests, operations int
+	for _, r := range logRecords(t, buf) {
+		switch r["msg"] {
+		case "cdn http request":
+			requests++
+			if r["method"] == nil || !strings.HasPrefix(r["endpoint"].(string), srv.URL+"/") || r["status"] != float64(http.StatusNotFound) || r["request_id"] != "req-1" || r["latency"] == nil {
+				t.Fatalf("unexpected http record %v", r)
+			}
+			header, _ := r["header"].(map[string]interface{})
+			if header["X-Cnc-Accesskey"] == nil || header["Authorization"] == nil {
+				t.Fatalf("signed headers not logged %v", r)
+			}
+		case "cdn operation failed":
+			operations++
+			if r["provider"] != types.WangsuSdkName || r["domain"] != "www.example.com" || r["error"] == nil || r["latency"] == nil {
+				t.Fatalf("unexpected operation record %v", r)
+			}
+		default:
+			t.Fatalf("unexpected record %v", r)
+		}
+	}
+	if requests == 0 || operations != 2 {
+		t.Fatalf("got %d http records and %d operation records:\n%s", requests, operations, buf.String())
+	}
+}
@@ -0,0 +1,711 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ReadMode 多厂商读操作模式
+type ReadMode int
+
+const (
+	ReadFallback ReadMode = iota // 按顺序查询,失败时回退到下一个厂商
+	ReadMerge                    // 查询所有厂商并合并统计数据
+)
+
+const (
+	taskIdSeparator         = "|" // 多厂商任务ID之间的分隔符
+	taskIdProviderSeparator = ":" // 厂商名称与任务ID之间的分隔符
+)
+
+// MultiCdn 多厂商CDN
+// 写操作(创建、更新、启停、删除、刷新、预热)会并发分发到所有厂商,读操作按 ReadMode 回退或合并,
+// 部分厂商失败时返回 *MultiError
+type MultiCdn struct {
+	ctx      context.Context
+	backends []*backend
+	readMode ReadMode
+}
+
+// backend 命名的厂商实例
+type backend struct {
+	name string
+	cdn  CdnWithContext
+}
+
+// BackendError 单个厂商的调用错误
+type BackendError struct {
+	Provider string // 厂商名称
+	Err      error  // 调用错误
+}
+
+func (e *BackendError) Error() string {
+	msg := e.Err.Error()
+	if strings.HasPrefix(msg, e.Provider+": ") {
+		return msg
+	}
+	return e.Provider + ": " + msg
+}
+
+func (e *BackendError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError 多厂商调用的部分或全部失败
+type MultiError struct {
+	Op        string          // 操作名称
+	Succeeded []string        // 调用成功的厂商
+	Failed    []*BackendError // 调用失败的厂商及错误
+}
+
+func (e *MultiError) Error() string {
+	msgs := make([]string, 0, len(e.Failed))
+	for _, v := range e.Failed {
+		msgs = append(msgs, v.Error())
+	}
+	return fmt.Sprintf("multi cdn %s: %d of %d providers failed: %s",
+		e.Op, len(e.Failed), len(e.Failed)+len(e.Succeeded), strings.Join(msgs, "; "))
+}
+
+// Unwrap 返回各厂商的错误,使 errors.Is 可以匹配任一厂商的错误
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, v := range e.Failed {
+		errs = append(errs, v)
+	}
+	return errs
+}
+
+// Partial 是否部分成功
+func (e *MultiError) Partial() bool {
+	return len(e.Succeeded) > 0
+}
+
+// NewMultiCdn 创建多厂商CDN,厂商以 GetSdkName 命名,重名时追加序号
+func NewMultiCdn(ctx context.Context, backends ...Cdn) *MultiCdn {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	m := &MultiCdn{ctx: ctx}
+	names := make(map[string]int)
+	for _, v := range backends {
+		if v == nil {
+			continue
+		}
+		name := v.GetSdkName()
+		names[name]++
+		if names[name] > 1 {
+			name += strconv.Itoa(names[name])
+		}
+		m.backends = append(m.backends, &backend{name: name, cdn: WithContext(v)})
+	}
+	return m
+}
+
+// WithReadMode 设置读操作模式
+func (m *MultiCdn) WithReadMode(mode ReadMode) *MultiCdn {
+	m.readMode = mode
+	return m
+}
+
+func (m *MultiCdn) GetSdkName() string {
+	return types.MultiSdkName
+}
+
+// result 单个厂商的调用结果
+type result[T any] struct {
+	backend *backend
+	value   T
+}
+
+// fanOut 并发调用所有厂商,返回成功的结果,存在失败时返回 *MultiError
+func fanOut[T any](ctx context.Context, m *MultiCdn, op string, fn func(ctx context.Context, c CdnWithContext) (T, error)) ([]*result[T], error) {
+	if len(m.backends) == 0 {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.MultiSdkName, "no backend")
+	}
+	values := make([]T, len(m.backends))
+	errs := make([]error, len(m.backends))
+	var wg sync.WaitGroup
+	for i, b := range m.backends {
+		wg.Add(1)
+		go func(i int, b *backend) {
+			defer wg.Done()
+			values[i], errs[i] = fn(ctx, b.cdn)
+		}(i, b)
+	}
+	wg.Wait()
+	results := make([]*result[T], 0, len(m.backends))
+	multiErr := &MultiError{Op: op}
+	for i, b := range m.backends {
+		if errs[i] != nil {
+			multiErr.Failed = append(multiErr.Failed, &BackendError{Provider: b.name, Err: errs[i]})
+			continue
+		}
+		multiErr.Succeeded = append(multiErr.Succeeded, b.name)
+		results = append(results, &result[T]{backend: b, value: values[i]})
+	}
+	if len(multiErr.Failed) > 0 {
+		return results, multiErr
+	}
+	return results, nil
+}
+
+// fallback 按顺序调用厂商,返回第一个成功的结果
+func fallback[T any](ctx context.Context, m *MultiCdn, op string, fn func(ctx context.Context, c CdnWithContext) (T, error)) (T, error) {
+	var zero T
+	if len(m.backends) == 0 {
+		return zero, cdnerr.New(cdnerr.ErrInvalidParameter, types.MultiSdkName, "no backend")
+	}
+	multiErr := &MultiError{Op: op}
+	for _, b := range m.backends {
+		res, err := fn(ctx, b.cdn)
+		if err == nil {
+			return res, nil
+		}
+		multiErr.Failed = append(multiErr.Failed, &BackendError{Provider: b.name, Err: err})
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return zero, multiErr
+}
+
+// read 按读操作模式查询,合并模式下使用merge合并各厂商结果
+func read[T any](ctx context.Context, m *MultiCdn, op string, fn func(ctx context.Context, c CdnWithContext) (T, error), merge func([]T) T) (T, error) {
+	if m.readMode != ReadMerge {
+		return fallback(ctx, m, op, fn)
+	}
+	results, err := fanOut(ctx, m, op, fn)
+	if len(results) == 0 {
+		var zero T
+		return zero, err
+	}
+	values := make([]T, 0, len(results))
+	for _, v := range results {
+		values = append(values, v.value)
+	}
+	return merge(values), err
+}
+
+// writeErr 写操作只关心错误
+func writeErr(_ []*result[struct{}], err error) error {
+	return err
+}
+
+// CreateDomain 创建域名
+func (m *MultiCdn) CreateDomain(req *types.CreateDomainRequest) error {
+	return m.CreateDomainWithContext(m.ctx, req)
+}
+
+// CreateDomainWithContext 创建域名
+func (m *MultiCdn) CreateDomainWithContext(ctx context.Context, req *types.CreateDomainRequest) error {
+	return writeErr(fanOut(ctx, m, "CreateDomain", func(ctx context.Context, c CdnWithContext) (struct{}, error) {
+		return struct{}{}, c.CreateDomainWithContext(ctx, copyRequest(req))
+	}))
+}
+
+// UpdateDomain 更新域名
+func (m *MultiCdn) UpdateDomain(req *types.UpdateDomainRequest) error {
+	return m.UpdateDomainWithContext(m.ctx, req)
+}
+
+// UpdateDomainWithContext 更新域名
+func (m *MultiCdn) UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error {
+	return writeErr(fanOut(ctx, m, "UpdateDomain", func(ctx context.Context, c CdnWithContext) (struct{}, error) {
+		return struct{}{}, c.UpdateDomainWithContext(ctx, copyRequest(req))
+	}))
+}
+
+// DisableDomain 停用域名
+func (m *MultiCdn) DisableDomain(req *types.DisableDomainRequest) error {
+	return m.DisableDomainWithContext(m.ctx, req)
+}
+
+// DisableDomainWithContext 停用域名
+func (m *MultiCdn) DisableDomainWithContext(ctx context.Context, req *types.DisableDomainRequest) error {
+	return writeErr(fanOut(ctx, m, "DisableDomain", func(ctx context.Context, c CdnWithContext) (struct{}, error) {
+		return struct{}{}, c.DisableDomainWithContext(ctx, copyRequest(req))
+	}))
+}
+
+// EnableDomain 启用域名
+func (m *MultiCdn) EnableDomain(req *types.EnableDomainRequest) error {
+	return m.EnableDomainWithContext(m.ctx, req)
+}
+
+// EnableDomainWithContext 启用域名
+func (m *MultiCdn) EnableDomainWithContext(ctx context.Context, req *types.EnableDomainRequest) error {
+	return writeErr(fanOut(ctx, m, "EnableDomain", func(ctx context.Context, c CdnWithContext) (struct{}, error) {
+		return struct{}{}, c.EnableDomainWithContext(ctx, copyRequest(req))
+	}))
+}
+
+// DeleteDomain 删除域名
+func (m *MultiCdn) DeleteDomain(req *types.DeleteDomainRequest) error {
+	return m.DeleteDomainWithContext(m.ctx, req)
+}
+
+// DeleteDomainWithContext 删除域名
+func (m *MultiCdn) DeleteDomainWithContext(ctx context.Context, req *types.DeleteDomainRequest) error {
+	return writeErr(fanOut(ctx, m, "DeleteDomain", func(ctx context.Context, c CdnWithContext) (struct{}, error) {
+		return struct{}{}, c.DeleteDomainWithContext(ctx, copyRequest(req))
+	}))
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (m *MultiCdn) CreateVerifyRecord(req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return m.CreateVerifyRecordWithContext(m.ctx, req)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录,使用第一个支持该操作的厂商
+func (m *MultiCdn) CreateVerifyRecordWithContext(ctx context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return fallback(ctx, m, "CreateVerifyRecord", func(ctx context.Context, c CdnWithContext) (*types.CreateVerifyRecordResponse, error) {
+		return c.CreateVerifyRecordWithContext(ctx, copyRequest(req))
+	})
+}
+
+// VerifyDomainRecord 验证域名
+func (m *MultiCdn) VerifyDomainRecord(req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return m.VerifyDomainRecordWithContext(m.ctx, req)
+}
+
+// VerifyDomainRecordWithContext 验证域名,使用第一个支持该操作的厂商
+func (m *MultiCdn) VerifyDomainRecordWithContext(ctx context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return fallback(ctx, m, "VerifyDomainRecord", func(ctx context.Context, c CdnWithContext) (*types.VerifyDomainRecordResponse, error) {
+		return c.VerifyDomainRecordWithContext(ctx, copyRequest(req))
+	})
+}
+
+// ShowDomainDetail 获取域名详情
+func (m *MultiCdn) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return m.ShowDomainDetailWithContext(m.ctx, req)
+}
+
+// ShowDomainDetailWithContext 获取域名详情,各厂商的详情无法合并,始终按顺序回退
+func (m *MultiCdn) ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return fallback(ctx, m, "ShowDomainDetail", func(ctx context.Context, c CdnWithContext) (*types.ShowDomainDetailResponse, error) {
+		return c.ShowDomainDetailWithContext(ctx, copyRequest(req))
+	})
+}
+
+// ShowDomainConfig 获取域名完整配置
+func (m *MultiCdn) ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return m.ShowDomainConfigWithContext(m.ctx, req)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置,各厂商的配置无法合并,始终按顺序回退,未实现 ConfigReader 的厂商视为失败
+func (m *MultiCdn) ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return fallback(ctx, m, "ShowDomainConfig", func(ctx context.Context, c CdnWithContext) (*types.UpdateDomainRequest, error) {
+		return ShowDomainConfig(ctx, c, copyRequest(req))
+	})
+}
+
+// ShowDomainStatusList 获取指定状态域名列表
+func (m *MultiCdn) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return m.ShowDomainStatusListWithContext(m.ctx, req)
+}
+
+// ShowDomainStatusListWithContext 获取指定状态域名列表,分页结果无法合并,始终按顺序回退
+func (m *MultiCdn) ShowDomainStatusListWithContext(ctx context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return fallback(ctx, m, "ShowDomainStatusList", func(ctx context.Context, c CdnWithContext) (*types.ShowDomainStatusListResponse, error) {
+		return c.ShowDomainStatusListWithContext(ctx, copyRequest(req))
+	})
+}
+
+// PurgePathCache 刷新目录缓存
+func (m *MultiCdn) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return m.PurgePathCacheWithContext(m.ctx, req)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存,返回的任务ID由各厂商任务ID组合而成
+func (m *MultiCdn) PurgePathCacheWithContext(ctx context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	results, err := fanOut(ctx, m, "PurgePathCache", func(ctx context.Context, c CdnWithContext) (string, error) {
+		res, err := c.PurgePathCacheWithContext(ctx, copyRequest(req))
+		if err != nil {
+			return "", err
+		}
+		return res.TaskId, nil
+	})
+	if len(results) == 0 {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: joinTaskIds(results)}, err
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (m *MultiCdn) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return m.PurgeUrlsCacheWithContext(m.ctx, req)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存,返回的任务ID由各厂商任务ID组合而成
+func (m *MultiCdn) PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	results, err := fanOut(ctx, m, "PurgeUrlsCache", func(ctx context.Context, c CdnWithContext) (string, error) {
+		res, err := c.PurgeUrlsCacheWithContext(ctx, copyRequest(req))
+		if err != nil {
+			return "", err
+		}
+		return res.TaskId, nil
+	})
+	if len(results) == 0 {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: joinTaskIds(results)}, err
+}
+
+// PushUrlsCache 预热URL缓存
+func (m *MultiCdn) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return m.PushUrlsCacheWithContext(m.ctx, req)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存,返回的任务ID由各厂商任务ID组合而成
+func (m *MultiCdn) PushUrlsCacheWithContext(ctx context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	results, err := fanOut(ctx, m, "PushUrlsCache", func(ctx context.Context, c CdnWithContext) (string, error) {
+		res, err := c.PushUrlsCacheWithContext(ctx, copyRequest(req))
+		if err != nil {
+			return "", err
+		}
+		return res.TaskId, nil
+	})
+	if len(results) == 0 {
+		return nil, err
+	}
+	return &types.PushUrlsCacheResponse{TaskId: joinTaskIds(results)}, err
+}
+
+// ShowPurgeTaskStatus 获取刷新任务状态
+func (m *MultiCdn) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return m.ShowPurgeTaskStatusWithContext(m.ctx, req)
+}
+
+// ShowPurgeTaskStatusWithContext 获取刷新任务状态,组合任务ID会查询各厂商并汇总状态
+func (m *MultiCdn) ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	if req == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status, err := taskStatus(ctx, m, "ShowPurgeTaskStatus", req.TaskId, func(ctx context.Context, c CdnWithContext, taskId string) (int64, error) {
+		res, err := c.ShowPurgeTaskStatusWithContext(ctx, &types.ShowPurgeTaskStatusRequest{TaskId: taskId})
+		if err != nil {
+			return 0, err
+		}
+		return res.Status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPurgeTaskStatusResponse{TaskId: req.TaskId, Status: status}, nil
+}
+
+// ShowPushTaskStatus 获取预热任务状态
+func (m *MultiCdn) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return m.ShowPushTaskStatusWithContext(m.ctx, req)
+}
+
+// ShowPushTaskStatusWithContext 获取预热任务状态,组合任务ID会查询各厂商并汇总状态
+func (m *MultiCdn) ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	if req == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status, err := taskStatus(ctx, m, "ShowPushTaskStatus", req.TaskId, func(ctx context.Context, c CdnWithContext, taskId string) (int64, error) {
+		res, err := c.ShowPushTaskStatusWithContext(ctx, &types.ShowPushTaskStatusRequest{TaskId: taskId})
+		if err != nil {
+			return 0, err
+		}
+		return res.Status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPushTaskStatusResponse{TaskId: req.TaskId, Status: status}, nil
+}
+
+// ShowPurgeTaskList 获取刷新任务列表
+func (m *MultiCdn) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return m.ShowPurgeTaskListWithContext(m.ctx, req)
+}
+
+// ShowPurgeTaskListWithContext 获取刷新任务列表,任务ID带有厂商前缀
+func (m *MultiCdn) ShowPurgeTaskListWithContext(ctx context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	res, err := read(ctx, m, "ShowPurgeTaskList", func(ctx context.Context, c CdnWithContext) (*types.ShowPurgeTaskListResponse, error) {
+		res, err := c.ShowPurgeTaskListWithContext(ctx, copyRequest(req))
+		if err != nil {
+			return nil, err
+		}
+		return &types.ShowPurgeTaskListResponse{Total: res.Total, List: prefixTaskIds(m.nameOf(c), res.List)}, nil
+	}, func(list []*types.ShowPurgeTaskListResponse) *types.ShowPurgeTaskListResponse {
+		merged := &types.ShowPurgeTaskListResponse{}
+		for _, v := range list {
+			merged.Total += v.Total
+			merged.List = append(merged.List, v.List...)
+		}
+		return merged
+	})
+	return res, err
+}
+
+// ShowPushTaskList 获取预热任务列表
+func (m *MultiCdn) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return m.ShowPushTaskListWithContext(m.ctx, req)
+}
+
+// ShowPushTaskListWithContext 获取预热任务列表,任务ID带有厂商前缀
+func (m *MultiCdn) ShowPushTaskListWithContext(ctx context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	res, err := read(ctx, m, "ShowPushTaskList", func(ctx context.Context, c CdnWithContext) (*types.ShowPushTaskListResponse, error) {
+		res, err := c.ShowPushTaskListWithContext(ctx, copyRequest(req))
+		if err != nil {
+			return nil, err
+		}
+		return &types.ShowPushTaskListResponse{Total: res.Total, List: prefixTaskIds(m.nameOf(c), res.List)}, nil
+	}, func(list []*types.ShowPushTaskListResponse) *types.ShowPushTaskListResponse {
+		merged := &types.ShowPushTaskListResponse{}
+		for _, v := range list {
+			merged.Total += v.Total
+			merged.List = append(merged.List, v.List...)
+		}
+		return merged
+	})
+	return res, err
+}
+
+// DomainAccessDataStatic 域名访问数据统计信息
+func (m *MultiCdn) DomainAccessDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return m.DomainAccessDataStaticWithContext(m.ctx, req)
+}
+
+// DomainAccessDataStaticWithContext 域名访问数据统计信息,合并模式下按时间点累加
+func (m *MultiCdn) DomainAccessDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return read(ctx, m, "DomainAccessDataStatic", func(ctx context.Context, c CdnWithContext) (types.DomainAccessDataStaticResponse, error) {
+		return c.DomainAccessDataStaticWithContext(ctx, copyRequest(req))
+	}, func(list []types.DomainAccessDataStaticResponse) types.DomainAccessDataStaticResponse {
+		data := make([]map[string][]*types.StaticData, 0, len(list))
+		for _, v := range list {
+			data = append(data, v)
+		}
+		return mergeStaticData(data)
+	})
+}
+
+// DomainOriginDataStatic 域名回源数据统计信息
+func (m *MultiCdn) DomainOriginDataStatic(req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return m.DomainOriginDataStaticWithContext(m.ctx, req)
+}
+
+// DomainOriginDataStaticWithContext 域名回源数据统计信息,合并模式下按时间点累加
+func (m *MultiCdn) DomainOriginDataStaticWithContext(ctx context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return read(ctx, m, "DomainOriginDataStatic", func(ctx context.Context, c CdnWithContext) (types.DomainOriginDataStaticResponse, error) {
+		return c.DomainOriginDataStaticWithContext(ctx, copyRequest(req))
+	}, func(list []types.DomainOriginDataStaticResponse) types.DomainOriginDataStaticResponse {
+		data := make([]map[string][]*types.StaticData, 0, len(list))
+		for _, v := range list {
+			data = append(data, v)
+		}
+		return mergeStaticData(data)
+	})
+}
+
+// ListTopUrlDataStatic 获取TOP URL访问数据
+func (m *MultiCdn) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return m.ListTopUrlDataStaticWithContext(m.ctx, req)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL访问数据,合并模式下按URL累加后倒序排列
+func (m *MultiCdn) ListTopUrlDataStaticWithContext(ctx context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return read(ctx, m, "ListTopUrlDataStatic", func(ctx context.Context, c CdnWithContext) ([]*types.ListTopUrlDataStaticResponse, error) {
+		return c.ListTopUrlDataStaticWithContext(ctx, copyRequest(req))
+	}, func(list [][]*types.ListTopUrlDataStaticResponse) []*types.ListTopUrlDataStaticResponse {
+		values := make(map[string]float64)
+		for _, v := range list {
+			for _, item := range v {
+				values[item.Url] += item.Value
+			}
+		}
+		merged := make([]*types.ListTopUrlDataStaticResponse, 0, len(values))
+		for url, value := range values {
+			merged = append(merged, &types.ListTopUrlDataStaticResponse{Url: url, Value: value})
+		}
+		sort.Slice(merged, func(i, j int) bool {
+			if merged[i].Value == merged[j].Value {
+				return merged[i].Url < merged[j].Url
+			}
+			return merged[i].Value > merged[j].Value
+		})
+		return merged
+	})
+}
+
+// DomainAccessTotalData 域名访问总流量
+func (m *MultiCdn) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return m.DomainAccessTotalDataWithContext(m.ctx, req)
+}
+
+// DomainAccessTotalDataWithContext 域名访问总流量,合并模式下按域名累加
+func (m *MultiCdn) DomainAccessTotalDataWithContext(ctx context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return read(ctx, m, "DomainAccessTotalData", func(ctx context.Context, c CdnWithContext) (types.DataTotalDataResponse, error) {
+		return c.DomainAccessTotalDataWithContext(ctx, copyRequest(req))
+	}, mergeTotalData)
+}
+
+// DomainOriginTotalData 域名回源数据总流量
+func (m *MultiCdn) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return m.DomainOriginTotalDataWithContext(m.ctx, req)
+}
+
+// DomainOriginTotalDataWithContext 域名回源数据总流量,合并模式下按域名累加
+func (m *MultiCdn) DomainOriginTotalDataWithContext(ctx context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return read(ctx, m, "DomainOriginTotalData", func(ctx context.Context, c CdnWithContext) (types.DataTotalDataResponse, error) {
+		return c.DomainOriginTotalDataWithContext(ctx, copyRequest(req))
+	}, mergeTotalData)
+}
+
+// UserAccessRegionDistribution 用户访问区域分布
+func (m *MultiCdn) UserAccessRegionDistribution(req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return m.UserAccessRegionDistributionWithContext(m.ctx, req)
+}
+
+// UserAccessRegionDistributionWithContext 用户访问区域分布,合并模式下按域名累加
+func (m *MultiCdn) UserAccessRegionDistributionWithContext(ctx context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return read(ctx, m, "UserAccessRegionDistribution", func(ctx context.Context, c CdnWithContext) (types.UserAccessRegionDistributionResponse, error) {
+		return c.UserAccessRegionDistributionWithContext(ctx, copyRequest(req))
+	}, func(list []types.UserAccessRegionDistributionResponse) types.UserAccessRegionDistributionResponse {
+		merged := make(types.UserAccessRegionDistributionResponse)
+		for _, v := range list {
+			for domain, item := range v {
+				if item == nil {
+					continue
+				}
+				if merged[domain] == nil {
+					merged[domain] = &types.RegionDistribution{}
+				}
+				merged[domain].MainLandValue += item.MainLandValue
+				merged[domain].OverSeaValue += item.OverSeaValue
+			}
+		}
+		return merged
+	})
+}
+
+// nameOf 获取厂商名称
+func (m *MultiCdn) nameOf(c CdnWithContext) string {
+	for _, b := range m.backends {
+		if b.cdn == c {
+			return b.name
+		}
+	}
+	return c.GetSdkName()
+}
+
+// backendOf 按名称获取厂商
+func (m *MultiCdn) backendOf(name string) *backend {
+	for _, b := range m.backends {
+		if b.name == name {
+			return b
+		}
+	}
+	return nil
+}
+
+// copyRequest 浅拷贝请求,厂商会向请求写入默认值(如统计接口的时区),每个厂商使用独立的副本以免并发写入
+func copyRequest[T any](req *T) *T {
+	if req == nil {
+		return nil
+	}
+	v := *req
+	return &v
+}
+
+// joinTaskIds 组合各厂商任务ID,格式为 厂商:任务ID|厂商:任务ID
+func joinTaskIds(results []*result[string]) string {
+	ids := make([]string, 0, len(results))
+	for _, v := range results {
+		ids = append(ids, v.backend.name+taskIdProviderSeparator+v.value)
+	}
+	return strings.Join(ids, taskIdSeparator)
+}
+
+// prefixTaskIds 为任务ID添加厂商前缀
+func prefixTaskIds(name string, ids []string) []string {
+	list := make([]string, 0, len(ids))
+	for _, v := range ids {
+		list = append(list, name+taskIdProviderSeparator+v)
+	}
+	return list
+}
+
+// taskStatus 查询组合任务状态,任一失败则失败,否则任一进行中则进行中,全部成功才成功
+// 不带厂商前缀的任务ID会按顺序回退查询
+func taskStatus(ctx context.Context, m *MultiCdn, op string, taskId string, fn func(ctx context.Context, c CdnWithContext, taskId string) (int64, error)) (int64, error) {
+	if !strings.Contains(taskId, taskIdProviderSeparator) {
+		return fallback(ctx, m, op, func(ctx context.Context, c CdnWithContext) (int64, error) {
+			return fn(ctx, c, taskId)
+		})
+	}
+	multiErr := &MultiError{Op: op}
+	status := int64(consts.ShowContentPurgeOrPushStatusSuccess)
+	for _, part := range strings.Split(taskId, taskIdSeparator) {
+		name, id, _ := strings.Cut(part, taskIdProviderSeparator)
+		b := m.backendOf(name)
+		if b == nil {
+			multiErr.Failed = append(multiErr.Failed, &BackendError{Provider: name,
+				Err: cdnerr.New(cdnerr.ErrInvalidParameter, types.MultiSdkName, "unknown provider in task id")})
+			continue
+		}
+		s, err := fn(ctx, b.cdn, id)
+		if err != nil {
+			multiErr.Failed = append(multiErr.Failed, &BackendError{Provider: name, Err: err})
+			continue
+		}
+		multiErr.Succeeded = append(multiErr.Succeeded, name)
+		switch {
+		case s == consts.ShowContentPurgeOrPushStatusFail:
+			status = consts.ShowContentPurgeOrPushStatusFail
+		case s == consts.ShowContentPurgeOrPushStatusDoing && status != consts.ShowContentPurgeOrPushStatusFail:
+			status = consts.ShowContentPurgeOrPushStatusDoing
+		}
+	}
+	if len(multiErr.Failed) > 0 {
+		return 0, multiErr
+	}
+	return status, nil
+}
+
+// mergeStaticData 按域名和时间点累加统计数据
+func mergeStaticData(list []map[string][]*types.StaticData) map[string][]*types.StaticData {
+	merged := make(map[string][]*types.StaticData)
+	for _, v := range list {
+		for domain, items := range v {
+			values := make(map[int64]float64)
+			for _, item := range merged[domain] {
+				values[item.Time] = item.Value
+			}
+			for _, item := range items {
+				values[item.Time] += item.Value
+			}
+			data := make([]*types.StaticData, 0, len(values))
+			for t, value := range values {
+				data = append(data, &types.StaticData{Time: t, Value: value})
+			}
+			sort.Slice(data, func(i, j int) bool {
+				return data[i].Time < data[j].Time
+			})
+			merged[domain] = data
+		}
+	}
+	return merged
+}
+
+// mergeTotalData 按域名累加总量数据
+func mergeTotalData(list []types.DataTotalDataResponse) types.DataTotalDataResponse {
+	merged := make(types.DataTotalDataResponse)
+	for _, v := range list {
+		for domain, value := range v {
+			merged[domain] += value
+		}
+	}
+	return merged
+}
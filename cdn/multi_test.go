@@ -0,0 +1,56 @@
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newWangsuStats 返回固定统计数据的网宿接口替身
+func newWangsuStats(t *testing.T, total float64) *wangsu.Wangsu {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &model.DomainStatisticsRequest{}
+		_ = json.NewDecoder(r.Body).Decode(req)
+		res := &model.DomainStatisticsResponse{Code: "0"}
+		for _, v := range req.DomainList {
+			res.Data = append(res.Data, &model.DomainStatisticsData{Domain: v, Total: total})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(s.Close)
+	client, err := wangsu.New(context.Background(), &wangsu.Config{Ak: "ak", Sk: "sk", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// TestMultiReadMergeCopiesRequest 厂商会向请求写入默认时区,合并读取时各厂商需使用独立的请求副本,需配合 -race 运行
+func TestMultiReadMergeCopiesRequest(t *testing.T) {
+	m := NewMultiCdn(context.Background(), newWangsuStats(t, 100), newWangsuStats(t, 200)).WithReadMode(ReadMerge)
+	for i := 0; i < 20; i++ {
+		req := &types.DomainAccessTotalDataRequest{
+			Domains:   []string{"www.example.com"},
+			StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			EndTime:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Unix(),
+		}
+		res, err := m.DomainAccessTotalData(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res["www.example.com"] != 300 {
+			t.Fatalf("got merged total %d, want 300", res["www.example.com"])
+		}
+		if req.TimeZone != nil {
+			t.Fatalf("caller request was modified: time zone %q", *req.TimeZone)
+		}
+	}
+}
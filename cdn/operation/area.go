@@ -0,0 +1,343 @@
+// Package operation 提供可等待、可持久化、可恢复的长时间运行的域名操作
+//
+// 操作的状态可以序列化为JSON保存,进程退出后通过 ResumeAreaMigration 从保存的阶段继续执行
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/cdn/waiter"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultInterval   = 10 * time.Second // 默认轮询域名状态的间隔
+	DefaultAttempts   = 3                // 默认切换到目标区域的最大尝试次数
+	DefaultRetryDelay = time.Minute      // 默认切换到目标区域失败后的重试间隔
+)
+
+var (
+	ErrRunning    = errors.New("operation is running")    // 操作正在执行
+	ErrNotStarted = errors.New("operation not started")   // 操作尚未开始执行
+	ErrMismatch   = errors.New("operation not resumable") // 保存的状态与厂商不匹配,无法恢复
+)
+
+// Client 执行区域切换所需的厂商接口,各厂商实现均满足该接口
+type Client interface {
+	GetSdkName() string
+	UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error
+	ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error)
+}
+
+// Status 操作状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 未开始
+	StatusRunning   Status = "running"   // 执行中
+	StatusSucceeded Status = "succeeded" // 已完成
+	StatusFailed    Status = "failed"    // 已失败,可以恢复后从失败的阶段继续执行
+)
+
+// Phase 区域切换的阶段
+type Phase string
+
+const (
+	PhaseSwitchGlobal Phase = "switch_global" // 切换到全球
+	PhaseWaitGlobal   Phase = "wait_global"   // 等待全球配置部署完成
+	PhaseSwitchTarget Phase = "switch_target" // 切换到目标区域
+	PhaseWaitTarget   Phase = "wait_target"   // 等待目标区域配置部署完成
+	PhaseDone         Phase = "done"          // 完成
+)
+
+// phases 阶段顺序,用于计算进度
+var phases = []Phase{PhaseSwitchGlobal, PhaseWaitGlobal, PhaseSwitchTarget, PhaseWaitTarget, PhaseDone}
+
+// AreaMigrationState 区域切换的状态,可序列化后持久化
+type AreaMigrationState struct {
+	Provider  string    `json:"provider"`        // 厂商名称
+	Domain    string    `json:"domain"`          // 域名
+	From      int64     `json:"from"`            // 原加速区域代码
+	To        int64     `json:"to"`              // 目标加速区域代码
+	Phase     Phase     `json:"phase"`           // 当前阶段
+	Status    Status    `json:"status"`          // 操作状态
+	Attempts  int       `json:"attempts"`        // 当前阶段已失败的次数
+	Error     string    `json:"error,omitempty"` // 最终错误
+	StartedAt time.Time `json:"started_at"`      // 开始时间
+	UpdatedAt time.Time `json:"updated_at"`      // 最后更新时间
+}
+
+// Progress 进度,取值范围[0, 1]
+func (s AreaMigrationState) Progress() float64 {
+	for i, v := range phases {
+		if v == s.Phase {
+			return float64(i) / float64(len(phases)-1)
+		}
+	}
+	return 0
+}
+
+// Options 区域切换配置,为空时使用默认值
+type Options struct {
+	Interval   time.Duration            // 轮询域名状态的间隔,默认10s
+	Attempts   int                      // 切换到目标区域的最大尝试次数,默认3
+	RetryDelay time.Duration            // 切换到目标区域失败后的重试间隔,默认1min
+	OnProgress func(AreaMigrationState) // 状态变化回调,可用于持久化,在执行操作的goroutine中同步执行
+}
+
+// AreaMigration 加速区域切换操作
+// 中国大陆与境外之间不能直接切换,需要先切换到全球,部署完成后再切换到目标区域
+type AreaMigration struct {
+	client Client
+	opts   Options
+
+	mu      sync.Mutex
+	state   AreaMigrationState
+	running bool
+	done    chan struct{}
+	err     error
+}
+
+// NewAreaMigration 创建区域切换操作,调用 Run 或 Start 后开始执行
+func NewAreaMigration(c Client, domain string, from, to int64, opts *Options) *AreaMigration {
+	phase := PhaseSwitchGlobal
+	switch {
+	case from == to:
+		phase = PhaseDone
+	case from == consts.AreaCodeGlobal:
+		phase = PhaseSwitchTarget
+	}
+	return newAreaMigration(c, AreaMigrationState{
+		Provider: c.GetSdkName(),
+		Domain:   domain,
+		From:     from,
+		To:       to,
+		Phase:    phase,
+		Status:   StatusPending,
+	}, opts)
+}
+
+// ResumeAreaMigration 从保存的状态恢复区域切换操作,已失败的操作会从失败的阶段重新执行
+func ResumeAreaMigration(c Client, state AreaMigrationState, opts *Options) (*AreaMigration, error) {
+	if state.Provider != c.GetSdkName() || state.Domain == "" {
+		return nil, fmt.Errorf("%w: provider %s, domain %s", ErrMismatch, state.Provider, state.Domain)
+	}
+	if state.Status != StatusSucceeded {
+		state.Status = StatusPending
+		state.Attempts = 0
+		state.Error = ""
+	}
+	return newAreaMigration(c, state, opts), nil
+}
+
+func newAreaMigration(c Client, state AreaMigrationState, opts *Options) *AreaMigration {
+	m := &AreaMigration{client: c, state: state}
+	if opts != nil {
+		m.opts = *opts
+	}
+	if m.opts.Interval <= 0 {
+		m.opts.Interval = DefaultInterval
+	}
+	if m.opts.Attempts <= 0 {
+		m.opts.Attempts = DefaultAttempts
+	}
+	if m.opts.RetryDelay <= 0 {
+		m.opts.RetryDelay = DefaultRetryDelay
+	}
+	return m
+}
+
+// State 返回当前状态的副本
+func (m *AreaMigration) State() AreaMigrationState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// Run 执行操作直到完成、失败或ctx结束,ctx结束时状态停留在当前阶段,可以再次Run或持久化后恢复
+func (m *AreaMigration) Run(ctx context.Context) error {
+	if err := m.begin(); err != nil {
+		return err
+	}
+	return m.run(ctx)
+}
+
+// Start 在后台执行操作,通过 Wait 等待结果
+func (m *AreaMigration) Start(ctx context.Context) error {
+	if err := m.begin(); err != nil {
+		return err
+	}
+	go m.run(ctx)
+	return nil
+}
+
+// Wait 等待后台执行的操作结束,返回操作的最终错误
+func (m *AreaMigration) Wait(ctx context.Context) error {
+	m.mu.Lock()
+	done := m.done
+	m.mu.Unlock()
+	if done == nil {
+		return ErrNotStarted
+	}
+	select {
+	case <-done:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *AreaMigration) begin() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.running {
+		return ErrRunning
+	}
+	m.running = true
+	m.done = make(chan struct{})
+	m.err = nil
+	return nil
+}
+
+func (m *AreaMigration) run(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err := m.execute(ctx)
+	m.mu.Lock()
+	m.running = false
+	m.err = err
+	close(m.done)
+	m.mu.Unlock()
+	return err
+}
+
+// execute 从当前阶段开始依次执行
+func (m *AreaMigration) execute(ctx context.Context) error {
+	m.update(func(s *AreaMigrationState) {
+		if s.StartedAt.IsZero() {
+			s.StartedAt = time.Now()
+		}
+		if s.Phase == PhaseDone {
+			s.Status = StatusSucceeded
+			return
+		}
+		s.Status = StatusRunning
+	})
+	for {
+		state := m.State()
+		var err error
+		next := state.Phase
+		switch state.Phase {
+		case PhaseDone:
+			return nil
+		case PhaseSwitchGlobal:
+			err = m.switchArea(ctx, consts.AreaCodeGlobal)
+			next = PhaseWaitGlobal
+		case PhaseWaitGlobal:
+			err = m.waitDeployed(ctx)
+			next = PhaseSwitchTarget
+			if state.To == consts.AreaCodeGlobal {
+				next = PhaseDone
+			}
+		case PhaseSwitchTarget:
+			err = m.switchTarget(ctx, state.To)
+			next = PhaseWaitTarget
+		case PhaseWaitTarget:
+			err = m.waitDeployed(ctx)
+			next = PhaseDone
+		default:
+			err = fmt.Errorf("unknown phase %s", state.Phase)
+		}
+		if err != nil {
+			//ctx结束时不记为失败,状态回到未开始,可以再次执行
+			if ctx.Err() != nil {
+				m.update(func(s *AreaMigrationState) { s.Status = StatusPending })
+				return err
+			}
+			m.update(func(s *AreaMigrationState) {
+				s.Status = StatusFailed
+				s.Error = err.Error()
+			})
+			return err
+		}
+		m.update(func(s *AreaMigrationState) {
+			s.Phase = next
+			s.Attempts = 0
+			if next == PhaseDone {
+				s.Status = StatusSucceeded
+			}
+		})
+	}
+}
+
+// switchTarget 切换到目标区域,全球配置刚部署完成时厂商可能仍拒绝切换,失败后按间隔重试
+func (m *AreaMigration) switchTarget(ctx context.Context, area int64) error {
+	for {
+		err := m.switchArea(ctx, area)
+		if err == nil {
+			return nil
+		}
+		attempts := 0
+		m.update(func(s *AreaMigrationState) {
+			s.Attempts++
+			attempts = s.Attempts
+		})
+		if attempts >= m.opts.Attempts || ctx.Err() != nil {
+			return err
+		}
+		if err := sleep(ctx, m.opts.RetryDelay); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *AreaMigration) switchArea(ctx context.Context, area int64) error {
+	return m.client.UpdateDomainWithContext(ctx, &types.UpdateDomainRequest{
+		Domain:       m.state.Domain,
+		UpdateAction: types.UpdateArea,
+		CdnDomain: &entity.UpdateCdnDomainBaseConf{
+			AreaCode: area,
+		},
+	})
+}
+
+// waitDeployed 按固定间隔等待域名部署完成,部署失败时返回 waiter.ErrDeployFailed
+func (m *AreaMigration) waitDeployed(ctx context.Context) error {
+	_, err := waiter.WaitForDomainStatus(ctx, m.client, m.state.Domain, consts.CdnDomainStatusDeployed, &waiter.Options{
+		Interval:    m.opts.Interval,
+		MaxInterval: m.opts.Interval,
+		Multiplier:  1,
+	})
+	return err
+}
+
+// update 修改状态并调用OnProgress
+func (m *AreaMigration) update(fn func(s *AreaMigrationState)) {
+	m.mu.Lock()
+	fn(&m.state)
+	m.state.UpdatedAt = time.Now()
+	state := m.state
+	m.mu.Unlock()
+	if m.opts.OnProgress != nil {
+		m.opts.OnProgress(state)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package operation_test
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/operation"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/cdn/waiter"
+	"testing"
+	"time"
+)
+
+// newTestFake 创建已部署www.example.com的模拟厂商
+func newTestFake(t *testing.T) *cdntest.Fake {
+	t.Helper()
+	fake := cdntest.New(context.Background(), nil)
+	if err := fake.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(time.Hour)
+	return fake
+}
+
+func TestAreaMigration(t *testing.T) {
+	fake := newTestFake(t)
+	m := operation.NewAreaMigration(fake, "www.example.com", consts.AreaCodeChinaMainland, consts.AreaCodeOversea, &operation.Options{
+		Interval: time.Millisecond,
+		OnProgress: func(s operation.AreaMigrationState) {
+			//切换区域后推进模拟时间,使配置部署完成
+			if s.Phase == operation.PhaseWaitGlobal || s.Phase == operation.PhaseWaitTarget {
+				fake.Advance(time.Hour)
+			}
+		},
+	})
+	if err := m.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := m.State(); s.Status != operation.StatusSucceeded || s.Phase != operation.PhaseDone {
+		t.Fatalf("got status %s phase %s, want succeeded done", s.Status, s.Phase)
+	}
+	conf, _ := fake.DomainConfig("www.example.com")
+	if conf.CdnDomain == nil || conf.CdnDomain.AreaCode != consts.AreaCodeOversea {
+		t.Fatalf("got area %+v, want oversea", conf.CdnDomain)
+	}
+}
+
+func TestAreaMigrationDeployFailed(t *testing.T) {
+	fake := newTestFake(t)
+	m := operation.NewAreaMigration(fake, "www.example.com", consts.AreaCodeChinaMainland, consts.AreaCodeOversea, &operation.Options{
+		Interval: time.Millisecond,
+		OnProgress: func(s operation.AreaMigrationState) {
+			if s.Phase == operation.PhaseWaitGlobal && s.Status == operation.StatusRunning {
+				_ = fake.SetDomainStatus("www.example.com", consts.CdnDomainStatusFaild)
+			}
+		},
+	})
+	if err := m.Run(context.Background()); !errors.Is(err, waiter.ErrDeployFailed) {
+		t.Fatalf("got %v, want waiter.ErrDeployFailed", err)
+	}
+	if s := m.State(); s.Status != operation.StatusFailed || s.Phase != operation.PhaseWaitGlobal {
+		t.Fatalf("got status %s phase %s, want failed wait_global", s.Status, s.Phase)
+	}
+}
@@ -0,0 +1,304 @@
+package otelcdn
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"go.opentelemetry.io/otel/attribute"
+	"strings"
+)
+
+// requestAttrs 获取请求中记录到span的属性
+func requestAttrs(req interface{}) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 1)
+	if domains := cdn.RequestDomains(req); len(domains) > 0 {
+		attrs = append(attrs, AttrDomain.String(strings.Join(domains, ",")))
+	}
+	switch r := req.(type) {
+	case *types.ShowPurgeTaskStatusRequest:
+		if r != nil && r.TaskId != "" {
+			attrs = append(attrs, AttrTaskId.String(r.TaskId))
+		}
+	case *types.ShowPushTaskStatusRequest:
+		if r != nil && r.TaskId != "" {
+			attrs = append(attrs, AttrTaskId.String(r.TaskId))
+		}
+	}
+	return attrs
+}
+
+// CreateDomain 创建域名
+func (c *Cdn) CreateDomain(req *types.CreateDomainRequest) error {
+	return c.CreateDomainWithContext(c.ctx, req)
+}
+
+// CreateDomainWithContext 创建域名
+func (c *Cdn) CreateDomainWithContext(ctx context.Context, req *types.CreateDomainRequest) error {
+	return instrumentErr(ctx, c, "CreateDomain", requestAttrs(req), func(ctx context.Context) error {
+		return c.cdn.CreateDomainWithContext(ctx, req)
+	})
+}
+
+// UpdateDomain 更新域名
+func (c *Cdn) UpdateDomain(req *types.UpdateDomainRequest) error {
+	return c.UpdateDomainWithContext(c.ctx, req)
+}
+
+// UpdateDomainWithContext 更新域名
+func (c *Cdn) UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error {
+	return instrumentErr(ctx, c, "UpdateDomain", requestAttrs(req), func(ctx context.Context) error {
+		return c.cdn.UpdateDomainWithContext(ctx, req)
+	})
+}
+
+// DisableDomain 停用域名
+func (c *Cdn) DisableDomain(req *types.DisableDomainRequest) error {
+	return c.DisableDomainWithContext(c.ctx, req)
+}
+
+// DisableDomainWithContext 停用域名
+func (c *Cdn) DisableDomainWithContext(ctx context.Context, req *types.DisableDomainRequest) error {
+	return instrumentErr(ctx, c, "DisableDomain", requestAttrs(req), func(ctx context.Context) error {
+		return c.cdn.DisableDomainWithContext(ctx, req)
+	})
+}
+
+// EnableDomain 启用域名
+func (c *Cdn) EnableDomain(req *types.EnableDomainRequest) error {
+	return c.EnableDomainWithContext(c.ctx, req)
+}
+
+// EnableDomainWithContext 启用域名
+func (c *Cdn) EnableDomainWithContext(ctx context.Context, req *types.EnableDomainRequest) error {
+	return instrumentErr(ctx, c, "EnableDomain", requestAttrs(req), func(ctx context.Context) error {
+		return c.cdn.EnableDomainWithContext(ctx, req)
+	})
+}
+
+// DeleteDomain 删除域名
+func (c *Cdn) DeleteDomain(req *types.DeleteDomainRequest) error {
+	return c.DeleteDomainWithContext(c.ctx, req)
+}
+
+// DeleteDomainWithContext 删除域名
+func (c *Cdn) DeleteDomainWithContext(ctx context.Context, req *types.DeleteDomainRequest) error {
+	return instrumentErr(ctx, c, "DeleteDomain", requestAttrs(req), func(ctx context.Context) error {
+		return c.cdn.DeleteDomainWithContext(ctx, req)
+	})
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (c *Cdn) CreateVerifyRecord(req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return c.CreateVerifyRecordWithContext(c.ctx, req)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (c *Cdn) CreateVerifyRecordWithContext(ctx context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return instrument(ctx, c, "CreateVerifyRecord", requestAttrs(req), func(ctx context.Context) (*types.CreateVerifyRecordResponse, error) {
+		return c.cdn.CreateVerifyRecordWithContext(ctx, req)
+	})
+}
+
+// VerifyDomainRecord 验证域名
+func (c *Cdn) VerifyDomainRecord(req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return c.VerifyDomainRecordWithContext(c.ctx, req)
+}
+
+// VerifyDomainRecordWithContext 验证域名
+func (c *Cdn) VerifyDomainRecordWithContext(ctx context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return instrument(ctx, c, "VerifyDomainRecord", requestAttrs(req), func(ctx context.Context) (*types.VerifyDomainRecordResponse, error) {
+		return c.cdn.VerifyDomainRecordWithContext(ctx, req)
+	})
+}
+
+// ShowDomainDetail 获取域名详情
+func (c *Cdn) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return c.ShowDomainDetailWithContext(c.ctx, req)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (c *Cdn) ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return instrument(ctx, c, "ShowDomainDetail", requestAttrs(req), func(ctx context.Context) (*types.ShowDomainDetailResponse, error) {
+		return c.cdn.ShowDomainDetailWithContext(ctx, req)
+	})
+}
+
+// ShowDomainConfig 获取域名完整配置
+func (c *Cdn) ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return c.ShowDomainConfigWithContext(c.ctx, req)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置,被包装的Cdn未实现 cdn.ConfigReader 时返回 cdnerr.ErrUnsupported
+func (c *Cdn) ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return instrument(ctx, c, "ShowDomainConfig", requestAttrs(req), func(ctx context.Context) (*types.UpdateDomainRequest, error) {
+		return cdn.ShowDomainConfig(ctx, c.cdn, req)
+	})
+}
+
+// ShowDomainStatusList 获取指定状态域名列表
+func (c *Cdn) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return c.ShowDomainStatusListWithContext(c.ctx, req)
+}
+
+// ShowDomainStatusListWithContext 获取指定状态域名列表
+func (c *Cdn) ShowDomainStatusListWithContext(ctx context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return instrument(ctx, c, "ShowDomainStatusList", requestAttrs(req), func(ctx context.Context) (*types.ShowDomainStatusListResponse, error) {
+		return c.cdn.ShowDomainStatusListWithContext(ctx, req)
+	})
+}
+
+// PurgePathCache 刷新目录缓存
+func (c *Cdn) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return c.PurgePathCacheWithContext(c.ctx, req)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (c *Cdn) PurgePathCacheWithContext(ctx context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return instrument(ctx, c, "PurgePathCache", requestAttrs(req), func(ctx context.Context) (*types.PurgeCacheResponse, error) {
+		return c.cdn.PurgePathCacheWithContext(ctx, req)
+	})
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (c *Cdn) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return c.PurgeUrlsCacheWithContext(c.ctx, req)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (c *Cdn) PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return instrument(ctx, c, "PurgeUrlsCache", requestAttrs(req), func(ctx context.Context) (*types.PurgeCacheResponse, error) {
+		return c.cdn.PurgeUrlsCacheWithContext(ctx, req)
+	})
+}
+
+// PushUrlsCache 预热URL缓存
+func (c *Cdn) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return c.PushUrlsCacheWithContext(c.ctx, req)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (c *Cdn) PushUrlsCacheWithContext(ctx context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return instrument(ctx, c, "PushUrlsCache", requestAttrs(req), func(ctx context.Context) (*types.PushUrlsCacheResponse, error) {
+		return c.cdn.PushUrlsCacheWithContext(ctx, req)
+	})
+}
+
+// ShowPurgeTaskStatus 获取刷新任务状态
+func (c *Cdn) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return c.ShowPurgeTaskStatusWithContext(c.ctx, req)
+}
+
+// ShowPurgeTaskStatusWithContext 获取刷新任务状态
+func (c *Cdn) ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return instrument(ctx, c, "ShowPurgeTaskStatus", requestAttrs(req), func(ctx context.Context) (*types.ShowPurgeTaskStatusResponse, error) {
+		return c.cdn.ShowPurgeTaskStatusWithContext(ctx, req)
+	})
+}
+
+// ShowPushTaskStatus 获取预热任务状态
+func (c *Cdn) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return c.ShowPushTaskStatusWithContext(c.ctx, req)
+}
+
+// ShowPushTaskStatusWithContext 获取预热任务状态
+func (c *Cdn) ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return instrument(ctx, c, "ShowPushTaskStatus", requestAttrs(req), func(ctx context.Context) (*types.ShowPushTaskStatusResponse, error) {
+		return c.cdn.ShowPushTaskStatusWithContext(ctx, req)
+	})
+}
+
+// ShowPurgeTaskList 获取刷新任务列表
+func (c *Cdn) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return c.ShowPurgeTaskListWithContext(c.ctx, req)
+}
+
+// ShowPurgeTaskListWithContext 获取刷新任务列表
+func (c *Cdn) ShowPurgeTaskListWithContext(ctx context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return instrument(ctx, c, "ShowPurgeTaskList", requestAttrs(req), func(ctx context.Context) (*types.ShowPurgeTaskListResponse, error) {
+		return c.cdn.ShowPurgeTaskListWithContext(ctx, req)
+	})
+}
+
+// ShowPushTaskList 获取预热任务列表
+func (c *Cdn) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return c.ShowPushTaskListWithContext(c.ctx, req)
+}
+
+// ShowPushTaskListWithContext 获取预热任务列表
+func (c *Cdn) ShowPushTaskListWithContext(ctx context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return instrument(ctx, c, "ShowPushTaskList", requestAttrs(req), func(ctx context.Context) (*types.ShowPushTaskListResponse, error) {
+		return c.cdn.ShowPushTaskListWithContext(ctx, req)
+	})
+}
+
+// DomainAccessDataStatic 域名访问数据统计信息
+func (c *Cdn) DomainAccessDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return c.DomainAccessDataStaticWithContext(c.ctx, req)
+}
+
+// DomainAccessDataStaticWithContext 域名访问数据统计信息
+func (c *Cdn) DomainAccessDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return instrument(ctx, c, "DomainAccessDataStatic", requestAttrs(req), func(ctx context.Context) (types.DomainAccessDataStaticResponse, error) {
+		return c.cdn.DomainAccessDataStaticWithContext(ctx, req)
+	})
+}
+
+// DomainOriginDataStatic 域名回源数据统计信息
+func (c *Cdn) DomainOriginDataStatic(req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return c.DomainOriginDataStaticWithContext(c.ctx, req)
+}
+
+// DomainOriginDataStaticWithContext 域名回源数据统计信息
+func (c *Cdn) DomainOriginDataStaticWithContext(ctx context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return instrument(ctx, c, "DomainOriginDataStatic", requestAttrs(req), func(ctx context.Context) (types.DomainOriginDataStaticResponse, error) {
+		return c.cdn.DomainOriginDataStaticWithContext(ctx, req)
+	})
+}
+
+// ListTopUrlDataStatic 获取TOP URL访问数据
+func (c *Cdn) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return c.ListTopUrlDataStaticWithContext(c.ctx, req)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL访问数据
+func (c *Cdn) ListTopUrlDataStaticWithContext(ctx context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return instrument(ctx, c, "ListTopUrlDataStatic", requestAttrs(req), func(ctx context.Context) ([]*types.ListTopUrlDataStaticResponse, error) {
+		return c.cdn.ListTopUrlDataStaticWithContext(ctx, req)
+	})
+}
+
+// DomainAccessTotalData 域名访问总流量
+func (c *Cdn) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return c.DomainAccessTotalDataWithContext(c.ctx, req)
+}
+
+// DomainAccessTotalDataWithContext 域名访问总流量
+func (c *Cdn) DomainAccessTotalDataWithContext(ctx context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return instrument(ctx, c, "DomainAccessTotalData", requestAttrs(req), func(ctx context.Context) (types.DataTotalDataResponse, error) {
+		return c.cdn.DomainAccessTotalDataWithContext(ctx, req)
+	})
+}
+
+// DomainOriginTotalData 域名回源数据总流量
+func (c *Cdn) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return c.DomainOriginTotalDataWithContext(c.ctx, req)
+}
+
+// DomainOriginTotalDataWithContext 域名回源数据总流量
+func (c *Cdn) DomainOriginTotalDataWithContext(ctx context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return instrument(ctx, c, "DomainOriginTotalData", requestAttrs(req), func(ctx context.Context) (types.DataTotalDataResponse, error) {
+		return c.cdn.DomainOriginTotalDataWithContext(ctx, req)
+	})
+}
+
+// UserAccessRegionDistribution 用户访问区域分布
+func (c *Cdn) UserAccessRegionDistribution(req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return c.UserAccessRegionDistributionWithContext(c.ctx, req)
+}
+
+// UserAccessRegionDistributionWithContext 用户访问区域分布
+func (c *Cdn) UserAccessRegionDistributionWithContext(ctx context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return instrument(ctx, c, "UserAccessRegionDistribution", requestAttrs(req), func(ctx context.Context) (types.UserAccessRegionDistributionResponse, error) {
+		return c.cdn.UserAccessRegionDistributionWithContext(ctx, req)
+	})
+}
@@ -0,0 +1,168 @@
+// Package otelcdn 以装饰器的形式为任意 cdn.Cdn 提供OpenTelemetry链路追踪与指标
+//
+// 每次调用生成一个span,包含厂商名称、操作、域名与调用结果,
+// 同时按厂商与操作记录请求数、错误数与耗时(RED指标)
+package otelcdn
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"time"
+)
+
+// ScopeName 链路追踪与指标的instrumentation scope名称
+const ScopeName = "github.com/run-bigpig/cloud-sdk/cdn/otelcdn"
+
+const (
+	AttrProvider  = attribute.Key("cdn.provider")   // 厂商名称,取自GetSdkName
+	AttrOperation = attribute.Key("cdn.operation")  // 操作名称,如CreateDomain
+	AttrDomain    = attribute.Key("cdn.domain")     // 域名,多个域名以逗号分隔,仅记录在span中
+	AttrTaskId    = attribute.Key("cdn.task_id")    // 刷新预热任务ID,仅记录在span中
+	AttrOutcome   = attribute.Key("cdn.outcome")    // 调用结果,success或error
+	AttrErrorType = attribute.Key("error.type")     // 错误分类,取自cdnerr的错误分类
+	AttrRequestId = attribute.Key("cdn.request_id") // 厂商请求ID,仅记录在span中
+)
+
+const (
+	OutcomeSuccess = "success" // 调用成功
+	OutcomeError   = "error"   // 调用失败
+)
+
+const (
+	MetricRequests = "cdn.client.requests" // 请求数
+	MetricErrors   = "cdn.client.errors"   // 错误数
+	MetricDuration = "cdn.client.duration" // 耗时,单位秒
+)
+
+// Config 链路追踪与指标配置
+type Config struct {
+	TracerProvider trace.TracerProvider // 为空时使用otel.GetTracerProvider()
+	MeterProvider  metric.MeterProvider // 为空时使用otel.GetMeterProvider()
+}
+
+// Cdn 带链路追踪与指标的Cdn
+type Cdn struct {
+	ctx      context.Context
+	cdn      cdn.CdnWithContext
+	name     string
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+var (
+	_ cdn.CdnWithContext          = (*Cdn)(nil)
+	_ cdn.ConfigReaderWithContext = (*Cdn)(nil)
+)
+
+// New 包装c,conf为空时使用全局的TracerProvider与MeterProvider
+func New(ctx context.Context, c cdn.Cdn, conf *Config) (*Cdn, error) {
+	if c == nil {
+		return nil, errors.New("otelcdn: cdn is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tp, mp := otel.GetTracerProvider(), otel.GetMeterProvider()
+	if conf != nil && conf.TracerProvider != nil {
+		tp = conf.TracerProvider
+	}
+	if conf != nil && conf.MeterProvider != nil {
+		mp = conf.MeterProvider
+	}
+	meter := mp.Meter(ScopeName)
+	requests, err := meter.Int64Counter(MetricRequests, metric.WithUnit("{request}"), metric.WithDescription("CDN操作请求数"))
+	if err != nil {
+		return nil, err
+	}
+	errs, err := meter.Int64Counter(MetricErrors, metric.WithUnit("{request}"), metric.WithDescription("CDN操作错误数"))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram(MetricDuration, metric.WithUnit("s"), metric.WithDescription("CDN操作耗时"))
+	if err != nil {
+		return nil, err
+	}
+	return &Cdn{
+		ctx:      ctx,
+		cdn:      cdn.WithContext(c),
+		name:     c.GetSdkName(),
+		tracer:   tp.Tracer(ScopeName),
+		requests: requests,
+		errors:   errs,
+		duration: duration,
+	}, nil
+}
+
+// GetSdkName 返回被包装的厂商名称
+func (c *Cdn) GetSdkName() string {
+	return c.name
+}
+
+// Unwrap 返回被包装的Cdn
+func (c *Cdn) Unwrap() cdn.CdnWithContext {
+	return c.cdn
+}
+
+// instrument 在span中执行fn并记录指标
+func instrument[T any](ctx context.Context, c *Cdn, op string, attrs []attribute.KeyValue, fn func(ctx context.Context) (T, error)) (T, error) {
+	if ctx == nil {
+		ctx = c.ctx
+	}
+	base := []attribute.KeyValue{AttrProvider.String(c.name), AttrOperation.String(op)}
+	spanAttrs := append(append(make([]attribute.KeyValue, 0, len(base)+len(attrs)), base...), attrs...)
+	ctx, span := c.tracer.Start(ctx, "cdn."+op, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(spanAttrs...))
+	defer span.End()
+	start := time.Now()
+	res, err := fn(ctx)
+	elapsed := time.Since(start).Seconds()
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeError
+		errorType := getErrorType(err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(AttrErrorType.String(errorType))
+		var e *cdnerr.Error
+		if errors.As(err, &e) && e.RequestId != "" {
+			span.SetAttributes(AttrRequestId.String(e.RequestId))
+		}
+		c.errors.Add(ctx, 1, metric.WithAttributes(append(base, AttrErrorType.String(errorType))...))
+	}
+	span.SetAttributes(AttrOutcome.String(outcome))
+	set := metric.WithAttributes(append(base, AttrOutcome.String(outcome))...)
+	c.requests.Add(ctx, 1, set)
+	c.duration.Record(ctx, elapsed, set)
+	return res, err
+}
+
+// instrumentErr 在span中执行只返回错误的fn并记录指标
+func instrumentErr(ctx context.Context, c *Cdn, op string, attrs []attribute.KeyValue, fn func(ctx context.Context) error) error {
+	_, err := instrument(ctx, c, op, attrs, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// getErrorType 获取错误分类,无法归类时返回_OTHER
+func getErrorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	}
+	if kind := cdnerr.KindOf(err); kind != nil {
+		return strings.ReplaceAll(kind.Error(), " ", "_")
+	}
+	return "_OTHER"
+}
@@ -0,0 +1,212 @@
+package otelcdn
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+// newTestCdn 使用内存中的span记录器与指标读取器包装模拟厂商
+func newTestCdn(t *testing.T) (*Cdn, *cdntest.Fake, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	fake := cdntest.New(context.Background(), nil)
+	c, err := New(context.Background(), fake, &Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, fake, spans, reader
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+	return attrs
+}
+
+// collect 读取指标,返回指标名称到数据的映射
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope.Name != ScopeName {
+			continue
+		}
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+// sumOf 返回属性包含want的计数器数据点之和
+func sumOf(t *testing.T, data metricdata.Aggregation, want ...attribute.KeyValue) int64 {
+	t.Helper()
+	sum, ok := data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("got %T, want int64 sum", data)
+	}
+	var total int64
+	for _, dp := range sum.DataPoints {
+		matched := true
+		for _, kv := range want {
+			if v, ok := dp.Attributes.Value(kv.Key); !ok || v != kv.Value {
+				matched = false
+			}
+		}
+		if matched {
+			total += dp.Value
+		}
+	}
+	return total
+}
+
+func TestSpanOnSuccess(t *testing.T) {
+	c, _, spans, _ := newTestCdn(t)
+	if err := c.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "cdn.CreateDomain" || span.SpanKind() != trace.SpanKindClient || span.Status().Code == codes.Error {
+		t.Fatalf("unexpected span %s kind %v status %v", span.Name(), span.SpanKind(), span.Status())
+	}
+	attrs := spanAttrs(span)
+	for k, want := range map[attribute.Key]string{
+		AttrProvider:  cdntest.SdkName,
+		AttrOperation: "CreateDomain",
+		AttrDomain:    "www.example.com",
+		AttrOutcome:   OutcomeSuccess,
+	} {
+		if attrs[k] != want {
+			t.Errorf("%s = %q, want %q", k, attrs[k], want)
+		}
+	}
+	if _, ok := attrs[AttrErrorType]; ok {
+		t.Error("error.type set on successful span")
+	}
+}
+
+func TestSpanOnError(t *testing.T) {
+	c, fake, spans, _ := newTestCdn(t)
+	fake.AddFault(cdntest.FailOp(&cdnerr.Error{Kind: cdnerr.ErrRateLimited, Provider: cdntest.SdkName, RequestId: "req-1"}, "PurgeUrlsCache"))
+	_, err := c.PurgeUrlsCache(&types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a"}})
+	if !errors.Is(err, cdnerr.ErrRateLimited) {
+		t.Fatalf("got %v, want rate limited", err)
+	}
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1", len(ended))
+	}
+	span := ended[0]
+	if span.Status().Code != codes.Error {
+		t.Fatalf("got status %v, want error", span.Status())
+	}
+	attrs := spanAttrs(span)
+	for k, want := range map[attribute.Key]string{
+		AttrOperation: "PurgeUrlsCache",
+		AttrDomain:    "www.example.com",
+		AttrOutcome:   OutcomeError,
+		AttrErrorType: "rate_limited",
+		AttrRequestId: "req-1",
+	} {
+		if attrs[k] != want {
+			t.Errorf("%s = %q, want %q", k, attrs[k], want)
+		}
+	}
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("got events %v, want one exception", events)
+	}
+}
+
+func TestTaskIdAttribute(t *testing.T) {
+	c, _, spans, _ := newTestCdn(t)
+	res, err := c.PurgeUrlsCache(&types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ShowPurgeTaskStatus(&types.ShowPurgeTaskStatusRequest{TaskId: res.TaskId}); err != nil {
+		t.Fatal(err)
+	}
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("got %d spans, want 2", len(ended))
+	}
+	if got := spanAttrs(ended[1])[AttrTaskId]; got != res.TaskId {
+		t.Fatalf("task id = %q, want %q", got, res.TaskId)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	c, fake, _, reader := newTestCdn(t)
+	if err := c.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "missing.example.com"}); !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+	fake.AddFault(cdntest.FailOp(cdnerr.New(cdnerr.ErrRateLimited, cdntest.SdkName, "throttled"), "ShowDomainDetail"))
+	if _, err := c.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); err == nil {
+		t.Fatal("expected error")
+	}
+	metrics := collect(t, reader)
+	provider := AttrProvider.String(cdntest.SdkName)
+	detail := AttrOperation.String("ShowDomainDetail")
+	requests := metrics[MetricRequests]
+	if n := sumOf(t, requests, provider, detail); n != 3 {
+		t.Errorf("got %d ShowDomainDetail requests, want 3", n)
+	}
+	if n := sumOf(t, requests, detail, AttrOutcome.String(OutcomeError)); n != 2 {
+		t.Errorf("got %d failed ShowDomainDetail requests, want 2", n)
+	}
+	if n := sumOf(t, requests, AttrOperation.String("CreateDomain"), AttrOutcome.String(OutcomeSuccess)); n != 1 {
+		t.Errorf("got %d CreateDomain requests, want 1", n)
+	}
+	errs := metrics[MetricErrors]
+	if n := sumOf(t, errs, detail, AttrErrorType.String("domain_not_found")); n != 1 {
+		t.Errorf("got %d domain_not_found errors, want 1", n)
+	}
+	if n := sumOf(t, errs, detail, AttrErrorType.String("rate_limited")); n != 1 {
+		t.Errorf("got %d rate_limited errors, want 1", n)
+	}
+	hist, ok := metrics[MetricDuration].(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("got %T, want float64 histogram", metrics[MetricDuration])
+	}
+	var count uint64
+	for _, dp := range hist.DataPoints {
+		if _, ok := dp.Attributes.Value(AttrDomain); ok {
+			t.Error("domain recorded as metric attribute")
+		}
+		count += dp.Count
+	}
+	if count != 4 {
+		t.Errorf("got %d duration samples, want 4", count)
+	}
+}
@@ -0,0 +1,196 @@
+// Package ratelimit 提供按操作类别限制调用频率的令牌桶,超出频率时等待而不是失败
+package ratelimit
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"math"
+	"sync"
+	"time"
+)
+
+// Class 操作类别,同一类别的操作共享一个令牌桶
+type Class int
+
+const (
+	ClassConfig  Class = iota // 域名配置写操作,如创建、更新、启停、删除域名
+	ClassContent              // 刷新预热任务提交
+	ClassQuery                // 域名与刷新预热任务查询
+	ClassStats                // 统计数据查询
+)
+
+// Rate 令牌桶速率
+type Rate struct {
+	QPS   float64 // 每秒生成的令牌数,小于等于0时不限制
+	Burst int     // 桶容量,小于等于0时为QPS向上取整且不小于1
+}
+
+// Limits 各操作类别的速率,未配置的类别不限制
+type Limits map[Class]Rate
+
+// defaultLimits 各厂商的默认速率,低于厂商公开的单接口QPS上限,留出余量给同账号的其他调用方
+var defaultLimits = map[string]Limits{
+	types.TencentSdkName: {
+		ClassConfig:  {QPS: 10},
+		ClassContent: {QPS: 10},
+		ClassQuery:   {QPS: 20},
+		ClassStats:   {QPS: 20},
+	},
+	types.HuaWeiSdkName: {
+		ClassConfig:  {QPS: 5},
+		ClassContent: {QPS: 5},
+		ClassQuery:   {QPS: 10},
+		ClassStats:   {QPS: 10},
+	},
+	types.WangsuSdkName: {
+		ClassConfig:  {QPS: 5},
+		ClassContent: {QPS: 5},
+		ClassQuery:   {QPS: 10},
+		ClassStats:   {QPS: 10},
+	},
+	types.AliyunSdkName: {
+		ClassConfig:  {QPS: 10},
+		ClassContent: {QPS: 10},
+		ClassQuery:   {QPS: 20},
+		ClassStats:   {QPS: 20},
+	},
+	types.BaiduSdkName: {
+		ClassConfig:  {QPS: 5},
+		ClassContent: {QPS: 5},
+		ClassQuery:   {QPS: 10},
+		ClassStats:   {QPS: 10},
+	},
+	types.KsYunSdkName: {
+		ClassConfig:  {QPS: 5},
+		ClassContent: {QPS: 5},
+		ClassQuery:   {QPS: 10},
+		ClassStats:   {QPS: 5},
+	},
+	types.VolcengineSdkName: {
+		ClassConfig:  {QPS: 5},
+		ClassContent: {QPS: 10},
+		ClassQuery:   {QPS: 10},
+		ClassStats:   {QPS: 10},
+	},
+}
+
+// fallbackLimits 未单独配置默认速率的厂商使用的速率
+var fallbackLimits = Limits{
+	ClassConfig:  {QPS: 5},
+	ClassContent: {QPS: 5},
+	ClassQuery:   {QPS: 10},
+	ClassStats:   {QPS: 10},
+}
+
+// DefaultLimits 获取厂商的默认速率,返回副本,可修改后传给 New
+func DefaultLimits(provider string) Limits {
+	limits, ok := defaultLimits[provider]
+	if !ok {
+		limits = fallbackLimits
+	}
+	res := make(Limits, len(limits))
+	for k, v := range limits {
+		res[k] = v
+	}
+	return res
+}
+
+// Limiter 按操作类别限制调用频率,并发安全
+// 通过各厂商Config.RateLimit传入,每次调用厂商接口前获取令牌,同一账号的多个客户端应共享同一个Limiter,
+// 未传入时每个客户端使用 DefaultLimits 创建各自的Limiter
+type Limiter struct {
+	buckets map[Class]*bucket
+}
+
+// New 创建限流器,limits为空时不限制
+func New(limits Limits) *Limiter {
+	l := &Limiter{buckets: make(map[Class]*bucket, len(limits))}
+	start := time.Now()
+	for class, rate := range limits {
+		if rate.QPS <= 0 {
+			continue
+		}
+		burst := float64(rate.Burst)
+		if burst <= 0 {
+			burst = math.Max(1, math.Ceil(rate.QPS))
+		}
+		l.buckets[class] = &bucket{qps: rate.QPS, burst: burst, tokens: burst, last: start}
+	}
+	return l
+}
+
+// Unlimited 返回不限制调用频率的限流器,传给厂商Config.RateLimit以关闭默认限流
+func Unlimited() *Limiter {
+	return New(nil)
+}
+
+// OrDefault l不为空时返回l,否则返回使用provider默认速率的新限流器
+func OrDefault(l *Limiter, provider string) *Limiter {
+	if l != nil {
+		return l
+	}
+	return New(DefaultLimits(provider))
+}
+
+// Wait 等待获取一个令牌,ctx取消或等待时间超过ctx的截止时间时返回错误且不消耗令牌
+func (l *Limiter) Wait(ctx context.Context, class Class) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	b, ok := l.buckets[class]
+	if !ok {
+		return ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	delay := b.reserve(now)
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		b.cancel()
+		return context.DeadlineExceeded
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// bucket 令牌桶,令牌可以为负数,表示已被预订的未来令牌
+type bucket struct {
+	mu     sync.Mutex
+	qps    float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// reserve 预订一个令牌,返回需要等待的时间
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.qps)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.qps * float64(time.Second))
+}
+
+// cancel 归还预订的令牌
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
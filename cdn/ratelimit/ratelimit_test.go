@@ -0,0 +1,187 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReserveAndCancel(t *testing.T) {
+	start := time.Now()
+	b := &bucket{qps: 10, burst: 2, tokens: 2, last: start}
+	for i, want := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if got := b.reserve(start); got != want {
+			t.Fatalf("reserve %d: got %s, want %s", i, got, want)
+		}
+	}
+	//归还预订的令牌后,下一次预订的等待时间相应缩短
+	b.cancel()
+	if got := b.reserve(start); got != 200*time.Millisecond {
+		t.Fatalf("got %s after cancel", got)
+	}
+	//令牌按速率补充,且不超过桶容量
+	if got := b.reserve(start.Add(300 * time.Millisecond)); got != 0 {
+		t.Fatalf("got %s after refill", got)
+	}
+	b.reserve(start.Add(time.Hour))
+	if b.tokens != 1 {
+		t.Fatalf("got %v tokens, want burst minus one", b.tokens)
+	}
+	//归还令牌同样不超过桶容量
+	b.cancel()
+	b.cancel()
+	if b.tokens != 2 {
+		t.Fatalf("got %v tokens after cancel, want burst", b.tokens)
+	}
+	//时间回拨时不补充令牌
+	if got := b.reserve(start); got != 0 || b.tokens != 1 {
+		t.Fatalf("got %s with %v tokens", got, b.tokens)
+	}
+}
+
+func TestNewBurst(t *testing.T) {
+	l := New(Limits{
+		ClassConfig:  {QPS: 2.5},
+		ClassContent: {QPS: 0.5},
+		ClassQuery:   {QPS: 1, Burst: 3},
+		ClassStats:   {QPS: 0, Burst: 5},
+	})
+	want := map[Class]float64{ClassConfig: 3, ClassContent: 1, ClassQuery: 3}
+	if len(l.buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(l.buckets), len(want))
+	}
+	for class, burst := range want {
+		if b := l.buckets[class]; b == nil || b.burst != burst || b.tokens != burst {
+			t.Fatalf("class %d: got bucket %+v, want burst %v", class, b, burst)
+		}
+	}
+	//桶容量内的请求不等待
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx, ClassQuery); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("burst waited %s", elapsed)
+	}
+}
+
+func TestWaitInsteadOfFail(t *testing.T) {
+	l := New(Limits{ClassContent: {QPS: 20, Burst: 1}})
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(ctx, ClassContent); err != nil {
+			t.Fatalf("wait %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 140*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("4 waits at 20 qps took %s, want about 150ms", elapsed)
+	}
+	//未配置的类别不限制
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(ctx, ClassStats); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWaitCanceled(t *testing.T) {
+	l := New(Limits{ClassConfig: {QPS: 1, Burst: 1}})
+	ctx := context.Background()
+	if err := l.Wait(ctx, ClassConfig); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	if err := l.Wait(ctx, ClassConfig); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("canceled wait returned after %s", elapsed)
+	}
+	//取消的等待不消耗令牌
+	if b := l.buckets[ClassConfig]; b.tokens < -0.1 {
+		t.Fatalf("got %v tokens after cancel", b.tokens)
+	}
+	//已取消的ctx直接返回,不论是否限流
+	if err := l.Wait(ctx, ClassStats); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v for unlimited class", err)
+	}
+	if err := (*Limiter)(nil).Wait(ctx, ClassConfig); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v for nil limiter", err)
+	}
+}
+
+func TestWaitDeadline(t *testing.T) {
+	l := New(Limits{ClassStats: {QPS: 1, Burst: 1}})
+	if err := l.Wait(context.Background(), ClassStats); err != nil {
+		t.Fatal(err)
+	}
+	//等待时间超过截止时间时立即返回且不消耗令牌
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(ctx, ClassStats); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("refused wait returned after %s", elapsed)
+	}
+	if b := l.buckets[ClassStats]; b.tokens < -0.1 {
+		t.Fatalf("got %v tokens after refusal", b.tokens)
+	}
+	//截止时间足够时等待
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := l.Wait(ctx, ClassStats); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaultLimits(t *testing.T) {
+	limits := DefaultLimits(types.TencentSdkName)
+	if !reflect.DeepEqual(limits, defaultLimits[types.TencentSdkName]) {
+		t.Fatalf("got %v", limits)
+	}
+	//返回副本,修改不影响默认速率
+	limits[ClassConfig] = Rate{QPS: 1000}
+	if defaultLimits[types.TencentSdkName][ClassConfig].QPS == 1000 {
+		t.Fatal("default limits modified")
+	}
+	for _, name := range []string{types.HuaWeiSdkName, types.WangsuSdkName, types.AliyunSdkName, types.BaiduSdkName, types.KsYunSdkName, types.VolcengineSdkName} {
+		if len(DefaultLimits(name)) != 4 {
+			t.Fatalf("%s: missing default limits", name)
+		}
+	}
+	if !reflect.DeepEqual(DefaultLimits("unknown"), fallbackLimits) {
+		t.Fatal("unknown provider should use fallback limits")
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	l := New(nil)
+	if OrDefault(l, types.TencentSdkName) != l {
+		t.Fatal("want given limiter")
+	}
+	def := OrDefault(nil, types.HuaWeiSdkName)
+	if def == nil || len(def.buckets) != len(DefaultLimits(types.HuaWeiSdkName)) {
+		t.Fatalf("got %+v, want default limiter", def)
+	}
+	if def.buckets[ClassConfig].qps != defaultLimits[types.HuaWeiSdkName][ClassConfig].QPS {
+		t.Fatalf("got config qps %v", def.buckets[ClassConfig].qps)
+	}
+	//每次创建独立的限流器
+	if OrDefault(nil, types.HuaWeiSdkName) == def {
+		t.Fatal("default limiter shared")
+	}
+	if u := Unlimited(); u == nil || len(u.buckets) != 0 {
+		t.Fatalf("got %+v, want unlimited", u)
+	}
+}
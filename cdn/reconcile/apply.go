@@ -0,0 +1,164 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+)
+
+// DomainResult 单个域名的执行结果
+type DomainResult struct {
+	Domain      string
+	Applied     []*Step // 执行成功的步骤,按执行顺序排列
+	Failed      *Step   // 执行失败的步骤,全部成功时为空
+	Err         error   // 执行失败的原因
+	RolledBack  []*Step // 回滚成功的步骤,按回滚顺序排列
+	RollbackErr error   // 回滚失败的原因,回滚在第一个失败的步骤处停止
+}
+
+// Result 执行结果
+type Result struct {
+	Domains []*DomainResult // 与计划中的域名顺序一致
+}
+
+// Failed 返回执行失败的域名
+func (r *Result) Failed() []*DomainResult {
+	list := make([]*DomainResult, 0)
+	for _, v := range r.Domains {
+		if v.Err != nil {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// Apply 执行计划,不删除域名的计划先并发执行,全部完成后再并发执行删除域名的计划。
+//
+// 同一域名的步骤依次执行,某一步失败时按相反顺序回滚该域名已完成的步骤,不影响其他域名,
+// 回滚不受ctx取消的影响。部分域名失败时同时返回结果与错误
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) (*Result, error) {
+	if plan == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result := &Result{Domains: make([]*DomainResult, len(plan.Domains))}
+	for _, deletes := range []bool{false, true} {
+		list := make([]int, 0, len(plan.Domains))
+		for i, v := range plan.Domains {
+			if v.deletes() == deletes {
+				list = append(list, i)
+			}
+		}
+		errs := r.each(ctx, len(list), func(ctx context.Context, i int) error {
+			result.Domains[list[i]] = r.applyDomain(ctx, plan.Domains[list[i]])
+			return nil
+		})
+		//ctx取消后未开始执行的域名
+		for i, err := range errs {
+			if err != nil {
+				result.Domains[list[i]] = &DomainResult{Domain: plan.Domains[list[i]].Domain, Err: err}
+			}
+		}
+	}
+	list := make([]error, 0)
+	for _, v := range result.Domains {
+		if v.Err != nil {
+			list = append(list, v.Err)
+		}
+		if v.RollbackErr != nil {
+			list = append(list, v.RollbackErr)
+		}
+	}
+	return result, errors.Join(list...)
+}
+
+func (r *Reconciler) applyDomain(ctx context.Context, plan *DomainPlan) *DomainResult {
+	res := &DomainResult{Domain: plan.Domain}
+	for _, step := range plan.Steps {
+		if err := r.exec(ctx, step); err != nil {
+			res.Failed, res.Err = step, fmt.Errorf("%s: %s: %w", plan.Domain, step, err)
+			if r.rollback {
+				r.rollbackDomain(context.WithoutCancel(ctx), res)
+			}
+			return res
+		}
+		res.Applied = append(res.Applied, step)
+	}
+	return res
+}
+
+// rollbackDomain 按相反顺序撤销已完成的步骤,本次新建的域名直接删除
+func (r *Reconciler) rollbackDomain(ctx context.Context, res *DomainResult) {
+	last := 0
+	if len(res.Applied) > 0 && res.Applied[0].Type == StepCreate {
+		last = len(res.Applied) - 1
+	}
+	for i := len(res.Applied) - 1 - last; i >= 0; i-- {
+		step := res.Applied[i]
+		if err := r.undo(ctx, step); err != nil {
+			res.RollbackErr = fmt.Errorf("%s: rollback %s: %w", res.Domain, step, err)
+			return
+		}
+		res.RolledBack = append(res.RolledBack, step)
+	}
+}
+
+func (r *Reconciler) exec(ctx context.Context, step *Step) error {
+	var err error
+	switch step.Type {
+	case StepCreate:
+		err = r.cdn.CreateDomainWithContext(ctx, step.create)
+	case StepUpdate:
+		err = r.cdn.UpdateDomainWithContext(ctx, step.update)
+	case StepEnable:
+		err = r.cdn.EnableDomainWithContext(ctx, &types.EnableDomainRequest{Domain: step.Domain})
+	case StepDisable:
+		err = r.cdn.DisableDomainWithContext(ctx, &types.DisableDomainRequest{Domain: step.Domain})
+	case StepDelete:
+		err = r.cdn.DeleteDomainWithContext(ctx, &types.DeleteDomainRequest{Domain: step.Domain})
+	default:
+		err = cdnerr.New(cdnerr.ErrInvalidParameter, r.cdn.GetSdkName(), fmt.Sprintf("unknown step type %s", step.Type))
+	}
+	if err != nil {
+		return err
+	}
+	return r.waitFor(ctx, step.Domain, step.wait)
+}
+
+// undo 撤销一个步骤,新建的域名停用后删除,更新恢复为更新前的线上配置,已删除的域名无法恢复
+func (r *Reconciler) undo(ctx context.Context, step *Step) error {
+	switch step.Type {
+	case StepCreate:
+		if err := r.exec(ctx, newStep(StepDisable, step.Domain)); err != nil {
+			return err
+		}
+		return r.exec(ctx, newStep(StepDelete, step.Domain))
+	case StepUpdate:
+		if step.rollback == nil {
+			return cdnerr.New(cdnerr.ErrUnsupported, r.cdn.GetSdkName(), fmt.Sprintf("live config of %s unavailable", step.Action))
+		}
+		if err := r.cdn.UpdateDomainWithContext(ctx, step.rollback); err != nil {
+			return err
+		}
+		return r.waitFor(ctx, step.Domain, step.wait)
+	case StepEnable:
+		return r.exec(ctx, newStep(StepDisable, step.Domain))
+	case StepDisable:
+		return r.exec(ctx, newStep(StepEnable, step.Domain))
+	default:
+		return cdnerr.New(cdnerr.ErrUnsupported, r.cdn.GetSdkName(), fmt.Sprintf("%s can not be rolled back", step.Type))
+	}
+}
+
+// waitFor 配置了等待时等待域名变为目标状态
+func (r *Reconciler) waitFor(ctx context.Context, domain string, status int64) error {
+	if r.waiter == nil || status == noWait {
+		return nil
+	}
+	_, err := r.waiter.WaitForDomainStatus(ctx, domain, status, r.wait)
+	return err
+}
@@ -0,0 +1,113 @@
+package reconcile
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+)
+
+// sections 更新动作与其对应的配置项,按 types 中的定义顺序排列,present返回请求中是否包含该配置项
+var sections = []struct {
+	action  string
+	present func(req *types.UpdateDomainRequest) bool
+}{
+	{types.UpdateBaseConf, func(req *types.UpdateDomainRequest) bool { return req.CdnDomain != nil }},
+	{types.UpdateArea, func(req *types.UpdateDomainRequest) bool { return req.CdnDomain != nil }},
+	{types.UpdateOriginConf, func(req *types.UpdateDomainRequest) bool { return req.OriginConf != nil }},
+	{types.UpdateOriginServerConf, func(req *types.UpdateDomainRequest) bool { return req.OriginServerConf != nil }},
+	{types.UpdateOriginAdvanceServerConf, func(req *types.UpdateDomainRequest) bool { return req.OriginAdvanceServerConf != nil }},
+	{types.UpdateOriginRequestHeaderConf, func(req *types.UpdateDomainRequest) bool { return req.OriginRequestHeaderConf != nil }},
+	{types.UpdateOriginUrlConf, func(req *types.UpdateDomainRequest) bool { return req.OriginUrlConf != nil }},
+	{types.UpdateIpFilterConf, func(req *types.UpdateDomainRequest) bool { return req.IpFilterConf != nil }},
+	{types.UpdateIpFrequencyConf, func(req *types.UpdateDomainRequest) bool { return req.IpFrequencyConf != nil }},
+	{types.UpdateRefererConf, func(req *types.UpdateDomainRequest) bool { return req.RefererConf != nil }},
+	{types.UpdateUserAgentConf, func(req *types.UpdateDomainRequest) bool { return req.UserAgentConf != nil }},
+	{types.UpdateSpeedConf, func(req *types.UpdateDomainRequest) bool { return req.SpeedConf != nil }},
+	{types.UpdateAuthConf, func(req *types.UpdateDomainRequest) bool { return req.AuthConf != nil }},
+	{types.UpdateRemoteAuthConf, func(req *types.UpdateDomainRequest) bool { return req.RemoteAuthConf != nil }},
+	{types.UpdateCacheListConf, func(req *types.UpdateDomainRequest) bool { return req.CacheListConf != nil }},
+	{types.UpdateCacheCodeConf, func(req *types.UpdateDomainRequest) bool { return req.CacheCodeConf != nil }},
+	{types.UpdateBrowserCacheConf, func(req *types.UpdateDomainRequest) bool { return req.BrowserCacheConf != nil }},
+	{types.UpdateRequestUrlRewriteConf, func(req *types.UpdateDomainRequest) bool { return req.RequestUrlRewriteConf != nil }},
+	{types.UpdateCustomErrorPageConf, func(req *types.UpdateDomainRequest) bool { return req.CustomErrorPageConf != nil }},
+	{types.UpdateIntelligentCompressionConf, func(req *types.UpdateDomainRequest) bool { return req.IntelligentCompressionConf != nil }},
+	{types.UpdateResponseHeaderConf, func(req *types.UpdateDomainRequest) bool { return req.ResponseHeaderConf != nil }},
+	{types.UpdateHttpsConf, func(req *types.UpdateDomainRequest) bool { return req.HttpsConf != nil }},
+}
+
+// overlay 以线上配置为基础覆盖期望配置中不为nil的配置项,
+// 部分厂商的更新动作会同时读取关联的配置项,如腾讯云回源URL改写依赖回源配置
+func overlay(desired, live *types.UpdateDomainRequest) *types.UpdateDomainRequest {
+	req := &types.UpdateDomainRequest{}
+	if live != nil {
+		*req = *live
+	}
+	if desired.CdnDomain != nil {
+		req.CdnDomain = desired.CdnDomain
+	}
+	if desired.OriginConf != nil {
+		req.OriginConf = desired.OriginConf
+	}
+	if desired.OriginServerConf != nil {
+		req.OriginServerConf = desired.OriginServerConf
+	}
+	if desired.OriginAdvanceServerConf != nil {
+		req.OriginAdvanceServerConf = desired.OriginAdvanceServerConf
+	}
+	if desired.OriginRequestHeaderConf != nil {
+		req.OriginRequestHeaderConf = desired.OriginRequestHeaderConf
+	}
+	if desired.OriginUrlConf != nil {
+		req.OriginUrlConf = desired.OriginUrlConf
+	}
+	if desired.IpFilterConf != nil {
+		req.IpFilterConf = desired.IpFilterConf
+	}
+	if desired.IpFrequencyConf != nil {
+		req.IpFrequencyConf = desired.IpFrequencyConf
+	}
+	if desired.RefererConf != nil {
+		req.RefererConf = desired.RefererConf
+	}
+	if desired.UserAgentConf != nil {
+		req.UserAgentConf = desired.UserAgentConf
+	}
+	if desired.SpeedConf != nil {
+		req.SpeedConf = desired.SpeedConf
+	}
+	if desired.AuthConf != nil {
+		req.AuthConf = desired.AuthConf
+	}
+	if desired.RemoteAuthConf != nil {
+		req.RemoteAuthConf = desired.RemoteAuthConf
+	}
+	if desired.CacheListConf != nil {
+		req.CacheListConf = desired.CacheListConf
+	}
+	if desired.CacheCodeConf != nil {
+		req.CacheCodeConf = desired.CacheCodeConf
+	}
+	if desired.BrowserCacheConf != nil {
+		req.BrowserCacheConf = desired.BrowserCacheConf
+	}
+	if desired.RequestUrlRewriteConf != nil {
+		req.RequestUrlRewriteConf = desired.RequestUrlRewriteConf
+	}
+	if desired.CustomErrorPageConf != nil {
+		req.CustomErrorPageConf = desired.CustomErrorPageConf
+	}
+	if desired.IntelligentCompressionConf != nil {
+		req.IntelligentCompressionConf = desired.IntelligentCompressionConf
+	}
+	if desired.ResponseHeaderConf != nil {
+		req.ResponseHeaderConf = desired.ResponseHeaderConf
+	}
+	if desired.HttpsConf != nil {
+		req.HttpsConf = desired.HttpsConf
+	}
+	return req
+}
+
+// isStopped 域名是否已停用或正在停用
+func isStopped(status int64) bool {
+	return status == consts.CdnDomainStatusStoping || status == consts.CdnDomainStatusStoped
+}
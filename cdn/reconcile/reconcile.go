@@ -0,0 +1,376 @@
+// Package reconcile 根据多个域名的期望状态生成执行计划(创建、按配置项更新、启停、删除),
+// 审阅后按顺序并发执行,执行失败时回滚该域名已完成的步骤
+//
+// 与直接使用 types.UpdateFullConf 不同,已存在的域名通过 ShowDomainConfig 读取线上配置,
+// 只更新存在差异的配置项
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/drift"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/cdn/validate"
+	"github.com/run-bigpig/cloud-sdk/cdn/waiter"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency 默认同时处理的域名数
+const DefaultConcurrency = 4
+
+// noWait 步骤执行后不等待域名状态
+const noWait int64 = -1
+
+// DomainState 域名的期望状态
+type DomainState struct {
+	Domain  string                     `json:"domain"`            // 域名
+	Create  *types.CreateDomainRequest `json:"create,omitempty"`  // 域名不存在时的创建参数,为空时域名不存在视为错误
+	Config  *types.UpdateDomainRequest `json:"config,omitempty"`  // 期望配置,为nil的配置项不做管理,UpdateAction被忽略
+	Enabled *bool                      `json:"enabled,omitempty"` // 期望的启用状态,为空时不做管理
+	Absent  bool                       `json:"absent,omitempty"`  // 为true时删除域名,其余字段被忽略
+}
+
+// StepType 步骤类型
+type StepType string
+
+const (
+	StepCreate  StepType = "create"  // 创建域名
+	StepUpdate  StepType = "update"  // 更新一个配置项
+	StepEnable  StepType = "enable"  // 启用域名
+	StepDisable StepType = "disable" // 停用域名
+	StepDelete  StepType = "delete"  // 删除域名
+)
+
+// Step 执行计划中的一个步骤
+type Step struct {
+	Type    StepType      // 步骤类型
+	Domain  string        // 域名
+	Action  string        // 更新动作,仅StepUpdate有值
+	Changes drift.Changes // 该更新动作的差异,线上配置无法读取时为nil
+
+	create   *types.CreateDomainRequest
+	update   *types.UpdateDomainRequest
+	rollback *types.UpdateDomainRequest // 更新前的线上配置,无法读取时为nil
+	wait     int64                      // 执行后等待的域名状态,为noWait时不等待
+}
+
+func (s *Step) String() string {
+	switch s.Type {
+	case StepCreate:
+		return "+ create"
+	case StepDelete:
+		return "- delete"
+	case StepUpdate:
+		if s.Changes == nil {
+			return "~ update " + s.Action + " (live config unavailable)"
+		}
+		return "~ update " + s.Action
+	default:
+		return "* " + string(s.Type)
+	}
+}
+
+// DomainPlan 单个域名的执行计划,步骤按执行顺序排列
+type DomainPlan struct {
+	Domain string
+	Steps  []*Step
+}
+
+// deletes 是否删除域名,删除在其余域名处理完成后执行
+func (p *DomainPlan) deletes() bool {
+	for _, v := range p.Steps {
+		if v.Type == StepDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan 执行计划,只包含需要变更的域名
+type Plan struct {
+	Domains []*DomainPlan
+}
+
+// Empty 是否没有需要执行的步骤
+func (p *Plan) Empty() bool {
+	return len(p.Domains) == 0
+}
+
+// Count 统计各类型的步骤数
+func (p *Plan) Count() map[StepType]int {
+	count := make(map[StepType]int)
+	for _, d := range p.Domains {
+		for _, s := range d.Steps {
+			count[s.Type]++
+		}
+	}
+	return count
+}
+
+// String 输出供审阅的执行计划
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, d := range p.Domains {
+		b.WriteString(d.Domain + "\n")
+		for _, s := range d.Steps {
+			b.WriteString("  " + s.String() + "\n")
+			for _, c := range s.Changes {
+				b.WriteString("      " + c.String() + "\n")
+			}
+		}
+	}
+	count := p.Count()
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to enable, %d to disable, %d to delete.\n",
+		count[StepCreate], count[StepUpdate], count[StepEnable], count[StepDisable], count[StepDelete])
+	return b.String()
+}
+
+// Options 执行配置
+type Options struct {
+	Concurrency int             // 同时处理的域名数,小于等于0时为 DefaultConcurrency
+	Wait        *waiter.Options // 每个步骤执行后等待域名部署完成或停止的轮询配置,为空时不等待
+	NoRollback  bool            // 为true时执行失败不回滚
+}
+
+// Reconciler 生成并执行域名配置的变更计划
+type Reconciler struct {
+	cdn         cdn.CdnWithContext
+	capability  validate.Capability
+	waiter      *waiter.Waiter
+	wait        *waiter.Options
+	concurrency int
+	rollback    bool
+}
+
+// New 创建协调器,opts为空时使用默认配置
+func New(c cdn.Cdn, opts *Options) *Reconciler {
+	r := &Reconciler{
+		cdn:         cdn.WithContext(c),
+		capability:  validate.DefaultCapability(c.GetSdkName()),
+		concurrency: DefaultConcurrency,
+		rollback:    true,
+	}
+	if opts != nil {
+		if opts.Concurrency > 0 {
+			r.concurrency = opts.Concurrency
+		}
+		if opts.Wait != nil {
+			r.waiter = waiter.New(r.cdn)
+			r.wait = opts.Wait
+		}
+		r.rollback = !opts.NoRollback
+	}
+	return r
+}
+
+// Plan 读取各域名的线上状态并生成执行计划,部分域名读取失败时同时返回其余域名的计划与错误。
+//
+// 同一域名内的步骤依次为:创建、启用、按 types 中的定义顺序更新存在差异的配置项、停用、删除,
+// 删除前域名未停用时先停用。线上配置无法读取的厂商或配置项,期望配置中的配置项全部更新,
+// 厂商不支持的更新动作不生成步骤,如网宿的cdn_domain只更新加速区域。
+// 读取线上状态前先按厂商能力校验全部期望配置,存在违规项时不调用任何接口
+func (r *Reconciler) Plan(ctx context.Context, states []*DomainState) (*Plan, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	seen := make(map[string]bool, len(states))
+	for _, v := range states {
+		if v == nil {
+			return nil, cdnerr.ErrNilRequest
+		}
+		if v.Domain == "" {
+			return nil, cdnerr.New(cdnerr.ErrInvalidParameter, r.cdn.GetSdkName(), "domain is empty")
+		}
+		if seen[v.Domain] {
+			return nil, cdnerr.New(cdnerr.ErrInvalidParameter, r.cdn.GetSdkName(), fmt.Sprintf("domain %s is duplicated", v.Domain))
+		}
+		seen[v.Domain] = true
+	}
+	invalid := make([]error, 0)
+	for _, v := range states {
+		if v.Config == nil {
+			continue
+		}
+		if err := r.capability.Validate(v.Config); err != nil {
+			invalid = append(invalid, fmt.Errorf("%s: %w", v.Domain, err))
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, errors.Join(invalid...)
+	}
+	plans := make([]*DomainPlan, len(states))
+	errs := r.each(ctx, len(states), func(ctx context.Context, i int) error {
+		var err error
+		plans[i], err = r.planDomain(ctx, states[i])
+		return err
+	})
+	plan := &Plan{Domains: make([]*DomainPlan, 0)}
+	for _, v := range plans {
+		if v != nil && len(v.Steps) > 0 {
+			plan.Domains = append(plan.Domains, v)
+		}
+	}
+	return plan, errors.Join(errs...)
+}
+
+func (r *Reconciler) planDomain(ctx context.Context, state *DomainState) (*DomainPlan, error) {
+	plan := &DomainPlan{Domain: state.Domain}
+	detail, err := r.cdn.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: state.Domain})
+	if errors.Is(err, cdnerr.ErrDomainNotFound) || (err == nil && detail.Status == consts.CdnDomainStatusDeleted) {
+		detail, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", state.Domain, err)
+	}
+	if detail != nil && detail.Status == consts.CdnDomainStatusDeleting {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, r.cdn.GetSdkName(), fmt.Sprintf("domain %s is deleting", state.Domain))
+	}
+	if state.Absent {
+		if detail == nil {
+			return plan, nil
+		}
+		if !isStopped(detail.Status) {
+			plan.Steps = append(plan.Steps, newStep(StepDisable, state.Domain))
+		}
+		plan.Steps = append(plan.Steps, newStep(StepDelete, state.Domain))
+		return plan, nil
+	}
+	if detail == nil {
+		if state.Create == nil {
+			return nil, cdnerr.New(cdnerr.ErrDomainNotFound, r.cdn.GetSdkName(), fmt.Sprintf("domain %s not found and no create request", state.Domain))
+		}
+		create := *state.Create
+		create.Domain = state.Domain
+		step := newStep(StepCreate, state.Domain)
+		step.create = &create
+		plan.Steps = append(plan.Steps, step)
+		plan.Steps = append(plan.Steps, r.planCreatedUpdates(state, &create)...)
+		if state.Enabled != nil && !*state.Enabled {
+			plan.Steps = append(plan.Steps, newStep(StepDisable, state.Domain))
+		}
+		return plan, nil
+	}
+	if state.Enabled != nil && *state.Enabled && isStopped(detail.Status) {
+		plan.Steps = append(plan.Steps, newStep(StepEnable, state.Domain))
+	}
+	updates, err := r.planUpdates(ctx, state, detail)
+	if err != nil {
+		return nil, err
+	}
+	//已停用且不启用的域名更新后仍为停止状态
+	if isStopped(detail.Status) && (state.Enabled == nil || !*state.Enabled) {
+		for _, v := range updates {
+			v.wait = consts.CdnDomainStatusStoped
+		}
+	}
+	plan.Steps = append(plan.Steps, updates...)
+	if state.Enabled != nil && !*state.Enabled && !isStopped(detail.Status) {
+		plan.Steps = append(plan.Steps, newStep(StepDisable, state.Domain))
+	}
+	return plan, nil
+}
+
+// planCreatedUpdates 新建域名没有线上配置,期望配置中厂商支持的配置项全部更新,加速区域与创建参数一致时不再更新
+func (r *Reconciler) planCreatedUpdates(state *DomainState, create *types.CreateDomainRequest) []*Step {
+	if state.Config == nil {
+		return nil
+	}
+	req := overlay(state.Config, nil)
+	steps := make([]*Step, 0)
+	for _, v := range sections {
+		if !v.present(state.Config) || !r.capability.Supports(v.action) {
+			continue
+		}
+		if v.action == types.UpdateArea && state.Config.CdnDomain.AreaCode == create.AreaCode {
+			continue
+		}
+		steps = append(steps, newUpdateStep(state.Domain, "", v.action, nil, req, nil))
+	}
+	return steps
+}
+
+// planUpdates 比较期望配置与线上配置,为厂商支持且存在差异或线上无法读取的配置项生成更新步骤
+func (r *Reconciler) planUpdates(ctx context.Context, state *DomainState, detail *types.ShowDomainDetailResponse) ([]*Step, error) {
+	if state.Config == nil {
+		return nil, nil
+	}
+	live, err := cdn.ShowDomainConfig(ctx, r.cdn, &types.ShowDomainConfigRequest{Domain: state.Domain, DomainId: detail.DomainId})
+	if err != nil && !errors.Is(err, cdnerr.ErrUnsupported) {
+		return nil, fmt.Errorf("%s: %w", state.Domain, err)
+	}
+	req := overlay(state.Config, live)
+	changes := drift.Diff(state.Config, live)
+	changed := make(map[string]bool)
+	for _, v := range changes.Actions() {
+		changed[v] = true
+	}
+	steps := make([]*Step, 0)
+	for _, v := range sections {
+		if !v.present(state.Config) || !r.capability.Supports(v.action) {
+			continue
+		}
+		switch {
+		case live == nil || !v.present(live):
+			steps = append(steps, newUpdateStep(state.Domain, detail.DomainId, v.action, nil, req, nil))
+		case changed[v.action]:
+			steps = append(steps, newUpdateStep(state.Domain, detail.DomainId, v.action, changes.ByAction(v.action), req, live))
+		}
+	}
+	return steps, nil
+}
+
+// newStep 创建步骤,停用后等待停止,删除后不等待,其余等待部署完成
+func newStep(t StepType, domain string) *Step {
+	step := &Step{Type: t, Domain: domain, wait: consts.CdnDomainStatusDeployed}
+	switch t {
+	case StepDisable:
+		step.wait = consts.CdnDomainStatusStoped
+	case StepDelete:
+		step.wait = noWait
+	}
+	return step
+}
+
+// newUpdateStep 创建更新步骤,期望配置与回滚配置复制后设置更新动作
+func newUpdateStep(domain, domainId, action string, changes drift.Changes, req, live *types.UpdateDomainRequest) *Step {
+	step := newStep(StepUpdate, domain)
+	step.Action, step.Changes = action, changes
+	update := *req
+	update.UpdateAction, update.Domain, update.DomainId = action, domain, domainId
+	step.update = &update
+	if live != nil {
+		rollback := *live
+		rollback.UpdateAction, rollback.Domain, rollback.DomainId = action, domain, domainId
+		step.rollback = &rollback
+	}
+	return step
+}
+
+// each 并发执行fn,同时执行的数量不超过concurrency,返回各项的错误
+func (r *Reconciler) each(ctx context.Context, n int, fn func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			errs[i] = fn(ctx, i)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,163 @@
+package reconcile_test
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/reconcile"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"testing"
+	"time"
+)
+
+// newWangsuFake 创建以网宿能力校验的模拟厂商,与网宿一致拒绝基础配置更新
+func newWangsuFake() *cdntest.Fake {
+	fake := cdntest.New(context.Background(), &cdntest.Config{Name: types.WangsuSdkName})
+	fake.AddFault(func(op string, req interface{}) error {
+		if r, ok := req.(*types.UpdateDomainRequest); ok && r.UpdateAction == types.UpdateBaseConf {
+			return cdnerr.New(cdnerr.ErrUnsupported, types.WangsuSdkName, "update base conf not support")
+		}
+		return nil
+	})
+	return fake
+}
+
+// failAction 指定更新动作的更新请求返回err
+func failAction(action string, err error) cdntest.Fault {
+	return func(op string, req interface{}) error {
+		if r, ok := req.(*types.UpdateDomainRequest); ok && r.UpdateAction == action {
+			return err
+		}
+		return nil
+	}
+}
+
+func stepNames(steps []*reconcile.Step) []string {
+	names := make([]string, 0, len(steps))
+	for _, v := range steps {
+		names = append(names, string(v.Type)+" "+v.Action)
+	}
+	return names
+}
+
+func equal(got []string, want ...string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPlanSkipsUnsupportedActions(t *testing.T) {
+	ctx := context.Background()
+	fake := newWangsuFake()
+	r := reconcile.New(fake, nil)
+	plan, err := r.Plan(ctx, []*reconcile.DomainState{{
+		Domain: "www.example.com",
+		Create: &types.CreateDomainRequest{AreaCode: consts.AreaCodeChinaMainland},
+		Config: &types.UpdateDomainRequest{
+			CdnDomain:  &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeGlobal},
+			OriginConf: &entity.OriginConf{OriginProtocol: consts.OriginProtocolHttps},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Domains) != 1 {
+		t.Fatalf("got %d domain plans, want 1", len(plan.Domains))
+	}
+	if got := stepNames(plan.Domains[0].Steps); !equal(got, "create ", "update "+types.UpdateArea, "update "+types.UpdateOriginConf) {
+		t.Fatalf("got steps %v", got)
+	}
+	if _, err := r.Apply(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+	conf, _ := fake.DomainConfig("www.example.com")
+	if conf.CdnDomain.AreaCode != consts.AreaCodeGlobal || conf.OriginConf.OriginProtocol != consts.OriginProtocolHttps {
+		t.Fatalf("unexpected config %+v %+v", conf.CdnDomain, conf.OriginConf)
+	}
+
+	//已存在的域名同样跳过基础配置
+	plan, err = r.Plan(ctx, []*reconcile.DomainState{{
+		Domain: "www.example.com",
+		Config: &types.UpdateDomainRequest{CdnDomain: &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeGlobal, SupportIpv6: consts.SwitchOn}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("got plan %s, want empty", plan)
+	}
+}
+
+func TestApplyRollback(t *testing.T) {
+	ctx := context.Background()
+	fake := newWangsuFake()
+	if err := fake.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com", AreaCode: consts.AreaCodeChinaMainland}); err != nil {
+		t.Fatal(err)
+	}
+	fake.AddFault(failAction(types.UpdateOriginConf, cdnerr.New(cdnerr.ErrInvalidParameter, types.WangsuSdkName, "invalid origin protocol")))
+	r := reconcile.New(fake, nil)
+	plan, err := r.Plan(ctx, []*reconcile.DomainState{{
+		Domain: "www.example.com",
+		Config: &types.UpdateDomainRequest{
+			CdnDomain:  &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeGlobal},
+			OriginConf: &entity.OriginConf{OriginProtocol: consts.OriginProtocolHttps},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := r.Apply(ctx, plan)
+	if !errors.Is(err, cdnerr.ErrInvalidParameter) {
+		t.Fatalf("got %v, want invalid parameter", err)
+	}
+	d := res.Domains[0]
+	if d.Failed == nil || d.Failed.Action != types.UpdateOriginConf || d.RollbackErr != nil {
+		t.Fatalf("unexpected result failed %v rollback error %v", d.Failed, d.RollbackErr)
+	}
+	if got := stepNames(d.RolledBack); !equal(got, "update "+types.UpdateArea) {
+		t.Fatalf("got rolled back steps %v", got)
+	}
+	conf, _ := fake.DomainConfig("www.example.com")
+	if conf.CdnDomain.AreaCode != consts.AreaCodeChinaMainland {
+		t.Fatalf("got area %d, want rolled back to china mainland", conf.CdnDomain.AreaCode)
+	}
+}
+
+func TestApplyRollbackCreated(t *testing.T) {
+	ctx := context.Background()
+	fake := newWangsuFake()
+	fake.AddFault(failAction(types.UpdateOriginConf, cdnerr.New(cdnerr.ErrInvalidParameter, types.WangsuSdkName, "invalid origin protocol")))
+	r := reconcile.New(fake, nil)
+	plan, err := r.Plan(ctx, []*reconcile.DomainState{{
+		Domain: "www.example.com",
+		Create: &types.CreateDomainRequest{AreaCode: consts.AreaCodeChinaMainland},
+		Config: &types.UpdateDomainRequest{
+			CdnDomain:  &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeChinaMainland},
+			OriginConf: &entity.OriginConf{OriginProtocol: consts.OriginProtocolHttps},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := r.Apply(ctx, plan)
+	if !errors.Is(err, cdnerr.ErrInvalidParameter) {
+		t.Fatalf("got %v, want invalid parameter", err)
+	}
+	//新建的域名回滚时停用后删除
+	if d := res.Domains[0]; d.RollbackErr != nil || len(d.RolledBack) != 1 || d.RolledBack[0].Type != reconcile.StepCreate {
+		t.Fatalf("unexpected rollback %v %v", stepNames(d.RolledBack), d.RollbackErr)
+	}
+	fake.Advance(time.Hour)
+	if _, ok := fake.DomainConfig("www.example.com"); ok {
+		t.Fatal("created domain not deleted")
+	}
+}
@@ -0,0 +1,112 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/huawei"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/tencent"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"sort"
+	"sync"
+)
+
+// Factory 厂商构造函数,config为厂商自己的配置类型
+type Factory func(ctx context.Context, config interface{}) (Cdn, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+func init() {
+	register(types.HuaWeiSdkName, huawei.New)
+	register(types.TencentSdkName, tencent.New)
+	register(types.WangsuSdkName, wangsu.New)
+	register(types.KsYunSdkName, ksyun.New)
+	register(types.AliyunSdkName, aliyun.New)
+	register(types.BaiduSdkName, baidu.New)
+	register(types.VolcengineSdkName, volcengine.New)
+}
+
+// register 使用厂商自己的构造函数注册厂商,构造失败时不返回持有nil指针的Cdn
+func register[T any, C Cdn](name string, newFn func(ctx context.Context, conf *T) (C, error)) {
+	Register(name, func(ctx context.Context, config interface{}) (Cdn, error) {
+		conf, err := configOf[T](name, config)
+		if err != nil {
+			return nil, err
+		}
+		c, err := newFn(ctx, conf)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+}
+
+// Register 注册厂商,name重复或factory为空时panic
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if factory == nil {
+		panic("cdn: Register factory is nil for " + name)
+	}
+	if _, ok := factories[name]; ok {
+		panic("cdn: Register called twice for " + name)
+	}
+	factories[name] = factory
+}
+
+// Providers 获取已注册的厂商名称
+func Providers() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New 按名称创建厂商客户端
+func New(name string, config interface{}) (Cdn, error) {
+	return NewWithContext(context.Background(), name, config)
+}
+
+// NewWithContext 按名称创建厂商客户端,ctx为客户端不带context方法使用的默认context
+func NewWithContext(ctx context.Context, name string, config interface{}) (Cdn, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[name]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, name, "provider not registered")
+	}
+	c, err := factory(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, name, "factory returned nil")
+	}
+	return c, nil
+}
+
+// configOf 获取厂商配置,支持值与指针两种形式
+func configOf[T any](name string, config interface{}) (*T, error) {
+	switch t := config.(type) {
+	case T:
+		return &t, nil
+	case *T:
+		if t != nil {
+			return t, nil
+		}
+	}
+	var zero T
+	return nil, cdnerr.New(cdnerr.ErrInvalidParameter, name, fmt.Sprintf("config must be %T, got %T", zero, config))
+}
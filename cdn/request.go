@@ -0,0 +1,115 @@
+package cdn
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/url"
+)
+
+// RequestDomains 获取请求涉及的域名,刷新预热请求从url或目录中解析去重后的域名,
+// 请求为空或不涉及域名时返回nil,用于日志与链路追踪
+func RequestDomains(req interface{}) []string {
+	switch r := req.(type) {
+	case *types.CreateDomainRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.UpdateDomainRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.DisableDomainRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.EnableDomainRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.DeleteDomainRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.CreateVerifyRecordRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.VerifyDomainRecordRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.ShowDomainDetailRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.ShowDomainConfigRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.ListTopUrlDataStaticRequest:
+		if r != nil {
+			return compactDomains(r.Domain)
+		}
+	case *types.DomainAccessDataStaticRequest:
+		if r != nil {
+			return compactDomains(r.Domains...)
+		}
+	case *types.DomainOriginDataStaticRequest:
+		if r != nil {
+			return compactDomains(r.Domains...)
+		}
+	case *types.DomainAccessTotalDataRequest:
+		if r != nil {
+			return compactDomains(r.Domains...)
+		}
+	case *types.DomainOriginTotalDataRequest:
+		if r != nil {
+			return compactDomains(r.Domains...)
+		}
+	case *types.UserAccessRegionDistributionRequest:
+		if r != nil {
+			return compactDomains(r.Domains...)
+		}
+	case *types.PurgePathCacheRequest:
+		if r != nil {
+			return urlDomains(r.Paths)
+		}
+	case *types.PurgeUrlsCacheRequest:
+		if r != nil {
+			return urlDomains(r.Urls)
+		}
+	case *types.PushUrlsCacheRequest:
+		if r != nil {
+			return urlDomains(r.Urls)
+		}
+	}
+	return nil
+}
+
+// compactDomains 去除空域名
+func compactDomains(domains ...string) []string {
+	list := make([]string, 0, len(domains))
+	for _, v := range domains {
+		if v != "" {
+			list = append(list, v)
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+// urlDomains 从url或目录中解析去重后的域名
+func urlDomains(urls []string) []string {
+	seen := make(map[string]bool)
+	hosts := make([]string, 0)
+	for _, v := range urls {
+		u, err := url.Parse(v)
+		if err != nil || u.Host == "" || seen[u.Host] {
+			continue
+		}
+		seen[u.Host] = true
+		hosts = append(hosts, u.Host)
+	}
+	return compactDomains(hosts...)
+}
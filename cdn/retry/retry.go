@@ -0,0 +1,106 @@
+// Package retry 提供厂商接口调用的重试与退避策略
+package retry
+
+import (
+	"context"
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"io"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultBaseDelay = 200 * time.Millisecond // 默认初始退避时间
+	DefaultMaxDelay  = 5 * time.Second        // 默认最大退避时间
+)
+
+const (
+	Idempotent    = true  // 幂等操作,按策略重试
+	NonIdempotent = false // 非幂等操作,仅在被限流时重试
+)
+
+// Policy 重试策略,为nil时不重试
+type Policy struct {
+	MaxAttempts int                  // 最大尝试次数(含首次调用),小于等于1时不重试
+	BaseDelay   time.Duration        // 初始退避时间,每次重试翻倍,默认200ms
+	MaxDelay    time.Duration        // 最大退避时间,默认5s
+	Retryable   func(err error) bool // 可重试错误判断,默认使用 IsRetryable
+}
+
+// Do 按重试策略执行fn
+// 非幂等操作(如创建域名、提交刷新预热任务)只在请求被限流时重试,因为此时可以确定请求未被处理
+func Do[T any](ctx context.Context, p *Policy, idempotent bool, fn func() (T, error)) (T, error) {
+	res, err := fn()
+	if p == nil {
+		return res, err
+	}
+	for attempt := 1; attempt < p.MaxAttempts && err != nil; attempt++ {
+		if !p.shouldRetry(err, idempotent) {
+			return res, err
+		}
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, err
+		case <-timer.C:
+		}
+		res, err = fn()
+	}
+	return res, err
+}
+
+func (p *Policy) shouldRetry(err error, idempotent bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if !idempotent && !errors.Is(err, cdnerr.ErrRateLimited) {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// Backoff 第attempt次重试前的等待时间,指数退避并在[d/2, d]范围内随机抖动
+func (p *Policy) Backoff(attempt int) time.Duration {
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = DefaultBaseDelay
+	}
+	if max <= 0 {
+		max = DefaultMaxDelay
+	}
+	d := base
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// IsRetryable 默认的可重试错误判断:限流、服务端临时错误以及网络错误
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, cdnerr.ErrRateLimited) {
+		return true
+	}
+	var e *cdnerr.Error
+	if errors.As(err, &e) && (e.Temporary || e.StatusCode >= 500) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,44 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	p := &Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		for i := 0; i < 20; i++ {
+			if d := p.Backoff(attempt); d < want/2 || d > want {
+				t.Fatalf("attempt %d: backoff %s out of [%s, %s]", attempt, d, want/2, want)
+			}
+		}
+	}
+}
+
+func TestDoStopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := Do(ctx, &Policy{MaxAttempts: 5, BaseDelay: time.Hour}, Idempotent, func() (int, error) {
+		calls++
+		cancel()
+		return 0, cdnerr.New(cdnerr.ErrRateLimited, "test", "throttled")
+	})
+	if !errors.Is(err, cdnerr.ErrRateLimited) || calls != 1 {
+		t.Fatalf("got %v after %d calls", err, calls)
+	}
+}
+
+func TestDoCustomRetryable(t *testing.T) {
+	calls := 0
+	_, err := Do(context.Background(), &Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, Retryable: func(err error) bool { return false }}, Idempotent, func() (int, error) {
+		calls++
+		return 0, &cdnerr.Error{StatusCode: 503}
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("got %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,1287 @@
+package aliyun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listPageSize 域名列表每页条数
+const listPageSize = 500
+
+type Aliyun struct {
+	ctx     context.Context
+	config  *Config
+	limiter *ratelimit.Limiter
+	client  *common.Client
+}
+
+type Config struct {
+	Ak        string
+	Sk        string
+	Endpoint  string              // 接口地址,默认https://cdn.aliyuncs.com
+	Retry     *retry.Policy       // 重试策略,为空时不重试
+	RateLimit *ratelimit.Limiter  // 限流器,每次调用厂商接口(含重试)前获取令牌,为空时使用ratelimit.DefaultLimits,不限制时传入ratelimit.Unlimited()
+	Http      *httpclient.Options // HTTP客户端选项,为空时使用默认客户端,超时为httpclient.DefaultTimeout
+	Logger    *slog.Logger        // 记录HTTP请求的日志,为空时不记录
+}
+
+// NewAliyunSdkClient 创建阿里云CDN客户端,创建失败时返回nil,需要错误信息时使用 New
+func NewAliyunSdkClient(ctx context.Context, conf *Config) *Aliyun {
+	client, err := New(ctx, conf)
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// New 创建阿里云CDN客户端
+func New(ctx context.Context, conf *Config) (*Aliyun, error) {
+	if conf == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.AliyunSdkName, "config is nil")
+	}
+	if conf.Ak == "" || conf.Sk == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.AliyunSdkName, "ak or sk is empty")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	httpClient, err := conf.Http.NewClient()
+	if err != nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.AliyunSdkName, "invalid http options: "+err.Error())
+	}
+	httpClient.Transport = cdnlog.NewTransport(conf.Logger, httpClient.Transport)
+	return &Aliyun{
+		ctx:     ctx,
+		config:  conf,
+		limiter: ratelimit.OrDefault(conf.RateLimit, types.AliyunSdkName),
+		client:  common.NewClient(common.NewSigner(conf.Ak, conf.Sk), conf.Endpoint).WithHttpClient(httpClient),
+	}, nil
+}
+
+func (a *Aliyun) GetSdkName() string {
+	return types.AliyunSdkName
+}
+
+// invoke 调用厂商接口,每次尝试前按class获取限流令牌,转换错误并按重试策略重试
+func invoke[T any](ctx context.Context, a *Aliyun, class ratelimit.Class, idempotent bool, fn func() (T, error)) (T, error) {
+	return retry.Do(ctx, a.config.Retry, idempotent, func() (T, error) {
+		if err := a.limiter.Wait(ctx, class); err != nil {
+			var zero T
+			return zero, err
+		}
+		res, err := fn()
+		return res, wrapError(err)
+	})
+}
+
+// getDomainName 阿里云以域名作为域名ID,未传入域名时使用域名ID
+func getDomainName(domain string, domainId string) string {
+	if domain != "" {
+		return domain
+	}
+	return domainId
+}
+
+// listDomains 分页获取全部域名
+func (a *Aliyun) listDomains(ctx context.Context) ([]string, error) {
+	domains := make([]string, 0)
+	for page := int64(1); ; page++ {
+		res, err := invoke(ctx, a, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeUserDomainsResponse, error) {
+			return a.client.DescribeUserDomains(ctx, &model.DescribeUserDomainsRequest{PageNumber: page, PageSize: listPageSize})
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range res.Domains.PageData {
+			domains = append(domains, v.DomainName)
+		}
+		if len(res.Domains.PageData) == 0 || int64(len(domains)) >= res.TotalCount {
+			return domains, nil
+		}
+	}
+}
+
+// CreateDomain 创建域名
+func (a *Aliyun) CreateDomain(data *types.CreateDomainRequest) error {
+	return a.CreateDomainWithContext(a.ctx, data)
+}
+
+// CreateDomainWithContext 创建域名
+func (a *Aliyun) CreateDomainWithContext(ctx context.Context, data *types.CreateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	sources, err := json.Marshal(getSources(data.OriginProtocol, data.Sources))
+	if err != nil {
+		return err
+	}
+	_, err = invoke(ctx, a, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.CommonResponse, error) {
+		return a.client.AddCdnDomain(ctx, &model.AddCdnDomainRequest{
+			CdnType:    getCdnType(data.ChannelType),
+			DomainName: data.Domain,
+			Sources:    string(sources),
+			Scope:      getScope(data.AreaCode),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	//回源协议与回源host需在域名创建后单独配置
+	updateDomain := a.newUpdateDomainConfigModel(&types.UpdateDomainRequest{
+		Domain:           data.Domain,
+		OriginServerConf: data.Sources,
+	})
+	if data.OriginProtocol != consts.OriginProtocolHttp {
+		updateDomain.withForwardScheme(data.OriginProtocol)
+	}
+	updateDomain.withOriginHost()
+	if len(updateDomain.functions) == 0 {
+		return nil
+	}
+	return updateDomain.send(ctx)
+}
+
+// getOrigins 获取指定优先级的源站
+func getOrigins(sources []*entity.OriginServerConf, priority int64) []*entity.OriginServerConf {
+	origins := make([]*entity.OriginServerConf, 0, len(sources))
+	for _, v := range sources {
+		if v.OriginPriority == priority {
+			origins = append(origins, v)
+		}
+	}
+	return origins
+}
+
+// UpdateDomain 更新域名
+func (a *Aliyun) UpdateDomain(data *types.UpdateDomainRequest) error {
+	return a.UpdateDomainWithContext(a.ctx, data)
+}
+
+// UpdateDomainWithContext 更新域名
+func (a *Aliyun) UpdateDomainWithContext(ctx context.Context, data *types.UpdateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	updateDomain := a.newUpdateDomainConfigModel(data)
+	switch data.UpdateAction {
+	case types.UpdateBaseConf:
+		updateDomain.WithBaseConf()
+	case types.UpdateArea:
+		updateDomain.WithArea()
+	case types.UpdateOriginConf:
+		updateDomain.WithOriginConf()
+	case types.UpdateOriginServerConf:
+		updateDomain.WithOriginServerConf()
+	case types.UpdateOriginRequestHeaderConf:
+		updateDomain.WithOriginRequestHeaderConf()
+	case types.UpdateOriginUrlConf:
+		updateDomain.WithOriginUrlConf()
+	case types.UpdateIpFilterConf:
+		updateDomain.WithIpFilterConf()
+	case types.UpdateRefererConf:
+		updateDomain.WithRefererConf()
+	case types.UpdateUserAgentConf:
+		updateDomain.WithUserAgentConf()
+	case types.UpdateSpeedConf:
+		updateDomain.WithSpeedConf()
+	case types.UpdateAuthConf:
+		updateDomain.WithAuthConf()
+	case types.UpdateRemoteAuthConf:
+		updateDomain.WithRemoteAuthConf()
+	case types.UpdateCacheListConf:
+		updateDomain.WithCacheListConf()
+	case types.UpdateCacheCodeConf:
+		updateDomain.WithCacheCodeConf()
+	case types.UpdateRequestUrlRewriteConf:
+		updateDomain.WithRequestUrlRewriteConf()
+	case types.UpdateCustomErrorPageConf:
+		updateDomain.WithCustomErrorPageConf()
+	case types.UpdateIntelligentCompressionConf:
+		updateDomain.WithIntelligentCompressionConf()
+	case types.UpdateResponseHeaderConf:
+		updateDomain.WithResponseHeaderConf()
+	case types.UpdateHttpsConf:
+		updateDomain.WithHttpsConf()
+	case types.UpdateRecommendConf:
+		updateDomain.WithOriginConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithHttpsConf()
+		updateDomain.WithIntelligentCompressionConf()
+	case types.UpdateFullConf:
+		updateDomain.WithBaseConf()
+		updateDomain.WithOriginConf()
+		updateDomain.WithOriginServerConf()
+		updateDomain.WithOriginRequestHeaderConf()
+		updateDomain.WithOriginUrlConf()
+		updateDomain.WithIpFilterConf()
+		updateDomain.WithRefererConf()
+		updateDomain.WithUserAgentConf()
+		updateDomain.WithSpeedConf()
+		updateDomain.WithAuthConf()
+		updateDomain.WithRemoteAuthConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithCacheCodeConf()
+		updateDomain.WithRequestUrlRewriteConf()
+		updateDomain.WithCustomErrorPageConf()
+		updateDomain.WithIntelligentCompressionConf()
+		updateDomain.WithResponseHeaderConf()
+		updateDomain.WithHttpsConf()
+	case types.UpdateOriginAdvanceServerConf, types.UpdateIpFrequencyConf, types.UpdateBrowserCacheConf:
+		//阿里云域名配置功能中没有对应项:按url选择源站需要规则引擎条件,ip访问频率属于边缘安全防护配置,
+		//浏览器缓存时间只能通过节点响应头Cache-Control设置,请使用ResponseHeaderConf
+		return cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("update action %s not support", data.UpdateAction))
+	default:
+		return cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("update action %s not support", data.UpdateAction))
+	}
+	return updateDomain.send(ctx)
+}
+
+// DisableDomain 停用域名
+func (a *Aliyun) DisableDomain(data *types.DisableDomainRequest) error {
+	return a.DisableDomainWithContext(a.ctx, data)
+}
+
+// DisableDomainWithContext 停用域名
+func (a *Aliyun) DisableDomainWithContext(ctx context.Context, data *types.DisableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, a, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return a.client.StopCdnDomain(ctx, &model.DomainNameRequest{DomainName: getDomainName(data.Domain, data.DomainId)})
+	})
+	return err
+}
+
+// EnableDomain 启用域名
+func (a *Aliyun) EnableDomain(data *types.EnableDomainRequest) error {
+	return a.EnableDomainWithContext(a.ctx, data)
+}
+
+// EnableDomainWithContext 启用域名
+func (a *Aliyun) EnableDomainWithContext(ctx context.Context, data *types.EnableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, a, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return a.client.StartCdnDomain(ctx, &model.DomainNameRequest{DomainName: getDomainName(data.Domain, data.DomainId)})
+	})
+	return err
+}
+
+// DeleteDomain 删除域名
+func (a *Aliyun) DeleteDomain(data *types.DeleteDomainRequest) error {
+	return a.DeleteDomainWithContext(a.ctx, data)
+}
+
+// DeleteDomainWithContext 删除域名,域名不存在时视为删除成功
+func (a *Aliyun) DeleteDomainWithContext(ctx context.Context, data *types.DeleteDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, a, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return a.client.DeleteCdnDomain(ctx, &model.DomainNameRequest{DomainName: getDomainName(data.Domain, data.DomainId)})
+	})
+	if errors.Is(err, cdnerr.ErrDomainNotFound) {
+		return nil
+	}
+	return err
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (a *Aliyun) CreateVerifyRecord(data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return a.CreateVerifyRecordWithContext(a.ctx, data)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (a *Aliyun) CreateVerifyRecordWithContext(ctx context.Context, data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, a, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeVerifyContentResponse, error) {
+		return a.client.DescribeVerifyContent(ctx, &model.DomainNameRequest{DomainName: getDomainName(data.Domain, data.DomainId)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.CreateVerifyRecordResponse{RecordCode: res.Content}, nil
+}
+
+// VerifyDomainRecord 验证域名解析记录
+func (a *Aliyun) VerifyDomainRecord(data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return a.VerifyDomainRecordWithContext(a.ctx, data)
+}
+
+// VerifyDomainRecordWithContext 验证域名解析记录,校验未通过时阿里云返回错误
+func (a *Aliyun) VerifyDomainRecordWithContext(ctx context.Context, data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, a, ratelimit.ClassConfig, retry.Idempotent, func() (*model.VerifyDomainOwnerResponse, error) {
+		return a.client.VerifyDomainOwner(ctx, &model.VerifyDomainOwnerRequest{
+			DomainName: getDomainName(data.Domain, data.DomainId),
+			VerifyType: getVerifyType(data.VerifyType),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.VerifyDomainRecordResponse{Result: true}, nil
+}
+
+// ShowDomainDetail 获取域名详情
+func (a *Aliyun) ShowDomainDetail(data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return a.ShowDomainDetailWithContext(a.ctx, data)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (a *Aliyun) ShowDomainDetailWithContext(ctx context.Context, data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, a, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeCdnDomainDetailResponse, error) {
+		return a.client.DescribeCdnDomainDetail(ctx, &model.DescribeCdnDomainDetailRequest{DomainName: getDomainName(data.Domain, data.DomainId)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	detail := res.GetDomainDetailModel
+	if detail == nil {
+		return nil, cdnerr.New(cdnerr.ErrDomainNotFound, types.AliyunSdkName, fmt.Sprintf("domain %s not found", getDomainName(data.Domain, data.DomainId)))
+	}
+	return &types.ShowDomainDetailResponse{
+		DomainId:    detail.DomainName,
+		Domain:      detail.DomainName,
+		Cname:       detail.Cname,
+		ChannelType: mapCdnType(detail.CdnType),
+		AreaCode:    mapScope(detail.Scope),
+		Status:      getDomainStatus(detail.DomainStatus),
+		CreateTime:  parseTime(detail.GmtCreated),
+		UpdateTime:  parseTime(detail.GmtModified),
+	}, nil
+}
+
+// ShowDomainStatusList 展示域名状态列表
+func (a *Aliyun) ShowDomainStatusList(data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return a.ShowDomainStatusListWithContext(a.ctx, data)
+}
+
+// ShowDomainStatusListWithContext 展示域名状态列表
+func (a *Aliyun) ShowDomainStatusListWithContext(ctx context.Context, data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status := setDomainStatus(data.Status)
+	if status == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.AliyunSdkName, fmt.Sprintf("domain status %d not support", data.Status))
+	}
+	offset, limit := utils.CalcOffsetAndLimit(data.Page, data.Limit)
+	res, err := invoke(ctx, a, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeUserDomainsResponse, error) {
+		return a.client.DescribeUserDomains(ctx, &model.DescribeUserDomainsRequest{
+			PageNumber:   offset/limit + 1,
+			PageSize:     limit,
+			DomainStatus: status,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	domains := make([]string, 0, len(res.Domains.PageData))
+	for _, v := range res.Domains.PageData {
+		domains = append(domains, v.DomainName)
+	}
+	return &types.ShowDomainStatusListResponse{Total: res.TotalCount, List: domains}, nil
+}
+
+// PurgePathCache 刷新目录缓存
+func (a *Aliyun) PurgePathCache(data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return a.PurgePathCacheWithContext(a.ctx, data)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (a *Aliyun) PurgePathCacheWithContext(ctx context.Context, data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	force := strconv.FormatBool(data.Mode != consts.ContentPurgePathModeFile)
+	res, err := invoke(ctx, a, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.RefreshObjectCachesResponse, error) {
+		return a.client.RefreshObjectCaches(ctx, &model.RefreshObjectCachesRequest{
+			ObjectPath: strings.Join(data.Paths, "\n"),
+			ObjectType: objectTypeDirectory,
+			Force:      force,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: res.RefreshTaskId}, nil
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (a *Aliyun) PurgeUrlsCache(data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return a.PurgeUrlsCacheWithContext(a.ctx, data)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (a *Aliyun) PurgeUrlsCacheWithContext(ctx context.Context, data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, a, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.RefreshObjectCachesResponse, error) {
+		return a.client.RefreshObjectCaches(ctx, &model.RefreshObjectCachesRequest{
+			ObjectPath: strings.Join(data.Urls, "\n"),
+			ObjectType: objectTypeFile,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: res.RefreshTaskId}, nil
+}
+
+// PushUrlsCache 预热URL缓存
+func (a *Aliyun) PushUrlsCache(data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return a.PushUrlsCacheWithContext(a.ctx, data)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (a *Aliyun) PushUrlsCacheWithContext(ctx context.Context, data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, a, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.PushObjectCacheResponse, error) {
+		return a.client.PushObjectCache(ctx, &model.PushObjectCacheRequest{ObjectPath: strings.Join(data.Urls, "\n")})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PushUrlsCacheResponse{TaskId: res.PushTaskId}, nil
+}
+
+// ShowPurgeTaskStatus 展示刷新任务状态
+func (a *Aliyun) ShowPurgeTaskStatus(data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return a.ShowPurgeTaskStatusWithContext(a.ctx, data)
+}
+
+// ShowPurgeTaskStatusWithContext 展示刷新任务状态
+func (a *Aliyun) ShowPurgeTaskStatusWithContext(ctx context.Context, data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status, err := a.getTaskStatus(ctx, data.TaskId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPurgeTaskStatusResponse{TaskId: data.TaskId, Status: status}, nil
+}
+
+// ShowPushTaskStatus 展示预热任务状态
+func (a *Aliyun) ShowPushTaskStatus(data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return a.ShowPushTaskStatusWithContext(a.ctx, data)
+}
+
+// ShowPushTaskStatusWithContext 展示预热任务状态
+func (a *Aliyun) ShowPushTaskStatusWithContext(ctx context.Context, data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status, err := a.getTaskStatus(ctx, data.TaskId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPushTaskStatusResponse{TaskId: data.TaskId, Status: status}, nil
+}
+
+// getTaskStatus 刷新与预热任务共用任务ID查询接口
+func (a *Aliyun) getTaskStatus(ctx context.Context, taskId string) (int64, error) {
+	res, err := invoke(ctx, a, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeRefreshTaskByIdResponse, error) {
+		return a.client.DescribeRefreshTaskById(ctx, &model.DescribeRefreshTaskByIdRequest{TaskId: taskId})
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(res.Tasks) == 0 {
+		return 0, newNotFoundError("task not found", res.RequestId)
+	}
+	return mergeTaskStatus(res.Tasks), nil
+}
+
+// mergeTaskStatus 汇总任务下所有url的状态,存在进行中则为进行中,存在失败则为失败
+func mergeTaskStatus(tasks []*model.Task) int64 {
+	taskStatus := int64(consts.ShowContentPurgeOrPushStatusSuccess)
+	for _, v := range tasks {
+		status := getShowContentPurgeOrPushStatus(v.Status)
+		if status == consts.ShowContentPurgeOrPushStatusDoing {
+			return status
+		}
+		if status == consts.ShowContentPurgeOrPushStatusFail {
+			taskStatus = status
+		}
+	}
+	return taskStatus
+}
+
+// ShowPurgeTaskList 展示刷新任务列表
+func (a *Aliyun) ShowPurgeTaskList(data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return a.ShowPurgeTaskListWithContext(a.ctx, data)
+}
+
+// ShowPurgeTaskListWithContext 展示刷新任务列表
+func (a *Aliyun) ShowPurgeTaskListWithContext(ctx context.Context, data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	total, list, err := a.listTaskIds(ctx, getTaskType(data.PurgeType), data.TaskStatus, data.StartTime, data.EndTime, data.Page, data.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPurgeTaskListResponse{Total: total, List: list}, nil
+}
+
+// ShowPushTaskList 展示预热任务列表
+func (a *Aliyun) ShowPushTaskList(data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return a.ShowPushTaskListWithContext(a.ctx, data)
+}
+
+// ShowPushTaskListWithContext 展示预热任务列表
+func (a *Aliyun) ShowPushTaskListWithContext(ctx context.Context, data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	total, list, err := a.listTaskIds(ctx, taskTypePreload, data.TaskStatus, data.StartTime, data.EndTime, data.Page, data.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPushTaskListResponse{Total: total, List: list}, nil
+}
+
+// listTaskIds 阿里云支持按任务类型与状态筛选,时间参数为UTC时间,无需处理时区
+func (a *Aliyun) listTaskIds(ctx context.Context, taskType string, taskStatus int64, startTime int64, endTime int64, page int64, limit int64) (int64, []string, error) {
+	status := setShowContentPurgeOrPushStatus(taskStatus)
+	if status == "" {
+		return 0, nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.AliyunSdkName, fmt.Sprintf("task status %d not support", taskStatus))
+	}
+	offset, limit := utils.CalcOffsetAndLimit(page, limit)
+	res, err := invoke(ctx, a, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeRefreshTasksResponse, error) {
+		return a.client.DescribeRefreshTasks(ctx, &model.DescribeRefreshTasksRequest{
+			ObjectType: taskType,
+			Status:     status,
+			StartTime:  formatTime(startTime),
+			EndTime:    formatTime(endTime),
+			PageNumber: offset/limit + 1,
+			PageSize:   limit,
+		})
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	list := make([]string, 0, len(res.Tasks.CDNTask))
+	for _, v := range res.Tasks.CDNTask {
+		list = append(list, v.TaskId)
+	}
+	return res.TotalCount, list, nil
+}
+
+// DomainAccessDataStatic 获取域名访问数据统计
+func (a *Aliyun) DomainAccessDataStatic(data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return a.DomainAccessDataStaticWithContext(a.ctx, data)
+}
+
+// DomainAccessDataStaticWithContext 获取域名访问数据统计
+func (a *Aliyun) DomainAccessDataStaticWithContext(ctx context.Context, data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.District != nil || data.Isp != nil {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, "district and isp not support")
+	}
+	action := getDataAccessMetricAction(data.Metric)
+	if action == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := a.getDomainData(ctx, action, data.Domains, data.StartTime, data.EndTime, getInterval(data.Interval))
+	if err != nil {
+		return nil, err
+	}
+	responseData := getStaticData(action, data.Area, datas)
+	if len(responseData) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return responseData, nil
+}
+
+// DomainOriginDataStatic 获取域名回源数据统计
+func (a *Aliyun) DomainOriginDataStatic(data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return a.DomainOriginDataStaticWithContext(a.ctx, data)
+}
+
+// DomainOriginDataStaticWithContext 获取域名回源数据统计
+func (a *Aliyun) DomainOriginDataStaticWithContext(ctx context.Context, data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	action := getDataOriginMetricAction(data.Metric)
+	if action == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := a.getDomainData(ctx, action, data.Domains, data.StartTime, data.EndTime, getInterval(data.Interval))
+	if err != nil {
+		return nil, err
+	}
+	return types.DomainOriginDataStaticResponse(getStaticData(action, data.Area, datas)), nil
+}
+
+// getDomainData 阿里云传入多个域名时返回汇总数据,按域名逐个查询,未指定域名时查询全部域名
+func (a *Aliyun) getDomainData(ctx context.Context, action string, domains []string, startTime int64, endTime int64, interval string) (map[string][]*model.DataModule, error) {
+	if len(domains) == 0 {
+		all, err := a.listDomains(ctx)
+		if err != nil {
+			return nil, err
+		}
+		domains = all
+	}
+	datas := make(map[string][]*model.DataModule, len(domains))
+	for _, domain := range domains {
+		res, err := invoke(ctx, a, ratelimit.ClassStats, retry.Idempotent, func() (*model.DomainDataResponse, error) {
+			return a.client.DescribeDomainData(ctx, action, &model.DomainDataRequest{
+				DomainName: domain,
+				StartTime:  formatTime(startTime),
+				EndTime:    formatTime(endTime),
+				Interval:   interval,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		datas[domain] = res.Data()
+	}
+	return datas, nil
+}
+
+func getStaticData(action string, area int64, datas map[string][]*model.DataModule) types.DomainAccessDataStaticResponse {
+	responseData := make(types.DomainAccessDataStaticResponse)
+	for domain, modules := range datas {
+		for _, v := range modules {
+			responseData[domain] = append(responseData[domain], &types.StaticData{
+				Value: getStatisticsValue(action, area, v),
+				Time:  parseTime(v.TimeStamp),
+			})
+		}
+	}
+	return responseData
+}
+
+// getTotalData 汇总各时间点数据,带宽取峰值,其余指标求和
+func getTotalData(action string, area int64, datas map[string][]*model.DataModule) types.DataTotalDataResponse {
+	responseData := make(types.DataTotalDataResponse)
+	for domain, modules := range datas {
+		for _, v := range modules {
+			value := int64(getStatisticsValue(action, area, v))
+			if action == "DescribeDomainBpsData" || action == "DescribeDomainSrcBpsData" {
+				if value > responseData[domain] {
+					responseData[domain] = value
+				}
+				continue
+			}
+			responseData[domain] += value
+		}
+	}
+	return responseData
+}
+
+// ListTopUrlDataStatic 获取TOP URL数据统计
+func (a *Aliyun) ListTopUrlDataStatic(data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return a.ListTopUrlDataStaticWithContext(a.ctx, data)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL数据统计
+func (a *Aliyun) ListTopUrlDataStaticWithContext(ctx context.Context, data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, a, ratelimit.ClassStats, retry.Idempotent, func() (*model.DescribeDomainTopUrlVisitResponse, error) {
+		return a.client.DescribeDomainTopUrlVisit(ctx, &model.DescribeDomainTopUrlVisitRequest{
+			DomainName: data.Domain,
+			StartTime:  formatTime(data.StartTime),
+			EndTime:    formatTime(data.EndTime),
+			SortBy:     getTopUrlSortBy(data.Filter),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := make([]*types.ListTopUrlDataStaticResponse, 0, len(res.AllUrlList.UrlList))
+	for _, v := range res.AllUrlList.UrlList {
+		responseData = append(responseData, &types.ListTopUrlDataStaticResponse{
+			Url:   v.UrlDetail,
+			Value: getTopUrlValue(data.Filter, v),
+		})
+	}
+	sort.SliceStable(responseData, func(i, j int) bool {
+		return responseData[i].Value > responseData[j].Value
+	})
+	return responseData, nil
+}
+
+// DomainAccessTotalData 获取域名总流量
+func (a *Aliyun) DomainAccessTotalData(data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return a.DomainAccessTotalDataWithContext(a.ctx, data)
+}
+
+// DomainAccessTotalDataWithContext 获取域名总流量
+func (a *Aliyun) DomainAccessTotalDataWithContext(ctx context.Context, data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	action := getDataAccessMetricAction(data.Metric)
+	if action == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := a.getDomainData(ctx, action, data.Domains, data.StartTime, data.EndTime, getInterval(consts.DataIntervalTypeDay))
+	if err != nil {
+		return nil, err
+	}
+	responseData := getTotalData(action, data.Area, datas)
+	if len(responseData) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return responseData, nil
+}
+
+// DomainOriginTotalData 获取域名回源总流量
+func (a *Aliyun) DomainOriginTotalData(data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return a.DomainOriginTotalDataWithContext(a.ctx, data)
+}
+
+// DomainOriginTotalDataWithContext 获取域名回源总流量
+func (a *Aliyun) DomainOriginTotalDataWithContext(ctx context.Context, data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	action := getDataOriginMetricAction(data.Metric)
+	if action == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := a.getDomainData(ctx, action, data.Domains, data.StartTime, data.EndTime, getInterval(consts.DataIntervalTypeDay))
+	if err != nil {
+		return nil, err
+	}
+	return getTotalData(action, data.Area, datas), nil
+}
+
+// UserAccessRegionDistribution 获取用户访问区域分布
+func (a *Aliyun) UserAccessRegionDistribution(data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return a.UserAccessRegionDistributionWithContext(a.ctx, data)
+}
+
+// UserAccessRegionDistributionWithContext 获取用户访问区域分布,阿里云统计数据同时返回境内与境外的值
+func (a *Aliyun) UserAccessRegionDistributionWithContext(ctx context.Context, data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	responseData := make(types.UserAccessRegionDistributionResponse)
+	if data == nil {
+		return responseData, cdnerr.ErrNilRequest
+	}
+	action := getDataAccessMetricAction(data.Metric)
+	if action != "DescribeDomainTrafficData" && action != "DescribeDomainQpsData" {
+		return responseData, cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := a.getDomainData(ctx, action, data.Domains, data.StartTime, data.EndTime, getInterval(consts.DataIntervalTypeDay))
+	if err != nil {
+		return responseData, err
+	}
+	mainland := getTotalData(action, consts.AreaCodeChinaMainland, datas)
+	oversea := getTotalData(action, consts.AreaCodeOversea, datas)
+	for domain := range datas {
+		responseData[domain] = &types.RegionDistribution{
+			MainLandValue: mainland[domain],
+			OverSeaValue:  oversea[domain],
+		}
+	}
+	return responseData, nil
+}
+
+//设置更新的各项信息
+
+// UpdateDomainConfigModel 阿里云的域名配置以配置功能提交,先收集各项功能,再按加速区域、源站、证书、配置功能的顺序提交
+type UpdateDomainConfigModel struct {
+	a           *Aliyun
+	req         *types.UpdateDomainRequest
+	err         error
+	scope       string
+	sources     []*model.Source
+	certificate *model.SetCdnDomainSSLCertificateRequest
+	functions   []*model.DomainFunction
+	// clears 可配置多条的功能,提交前先删除已有配置
+	clears []string
+}
+
+func (a *Aliyun) newUpdateDomainConfigModel(req *types.UpdateDomainRequest) *UpdateDomainConfigModel {
+	return &UpdateDomainConfigModel{
+		a:   a,
+		req: req,
+	}
+}
+
+// addFunction 添加配置功能,args为参数名与参数值交替排列
+func (u *UpdateDomainConfigModel) addFunction(name string, args ...string) {
+	function := &model.DomainFunction{
+		FunctionName: name,
+		FunctionArgs: make([]*model.FunctionArg, 0, len(args)/2),
+	}
+	for i := 0; i+1 < len(args); i += 2 {
+		function.FunctionArgs = append(function.FunctionArgs, &model.FunctionArg{ArgName: args[i], ArgValue: args[i+1]})
+	}
+	u.functions = append(u.functions, function)
+}
+
+// clear 提交前删除配置功能的已有配置
+func (u *UpdateDomainConfigModel) clear(names ...string) {
+	for _, name := range names {
+		exists := false
+		for _, v := range u.clears {
+			if v == name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			u.clears = append(u.clears, name)
+		}
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithBaseConf() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	u.WithArea()
+	u.addFunction("ipv6", "switch", getSwitch(u.req.CdnDomain.SupportIpv6), "region", "*")
+}
+
+func (u *UpdateDomainConfigModel) WithArea() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	u.scope = getScope(u.req.CdnDomain.AreaCode)
+}
+
+func (u *UpdateDomainConfigModel) WithOriginConf() {
+	if u.req.OriginConf == nil {
+		return
+	}
+	conf := u.req.OriginConf
+	u.withForwardScheme(conf.OriginProtocol)
+	if conf.OriginSniSwitch == consts.SwitchOn {
+		u.addFunction("https_origin_sni", "enabled", consts.ON, "https_origin_sni", conf.OriginSniValue)
+	} else {
+		u.addFunction("https_origin_sni", "enabled", consts.OFF)
+	}
+	u.addFunction("range", "enable", getSwitch(conf.OriginRange))
+	u.addFunction("follow_302", "enable", getSwitch(conf.OriginFollow))
+	if conf.OriginTimeOut > 0 {
+		u.addFunction("forward_timeout", "forward_timeout", strconv.FormatInt(conf.OriginTimeOut, 10))
+	}
+}
+
+// withForwardScheme 设置回源协议
+func (u *UpdateDomainConfigModel) withForwardScheme(protocol int64) {
+	u.addFunction("forward_scheme", "enable", consts.ON, "scheme_origin", getOriginProtocol(protocol))
+}
+
+func (u *UpdateDomainConfigModel) WithOriginServerConf() {
+	if len(u.req.OriginServerConf) == 0 {
+		return
+	}
+	protocol := int64(consts.OriginProtocolHttp)
+	if u.req.OriginConf != nil {
+		protocol = u.req.OriginConf.OriginProtocol
+	}
+	u.sources = getSources(protocol, u.req.OriginServerConf)
+	u.withOriginHost()
+}
+
+// withOriginHost 设置回源host,阿里云回源host对全部源站生效,以主源站为准
+func (u *UpdateDomainConfigModel) withOriginHost() {
+	primary := getOrigins(u.req.OriginServerConf, consts.OriginPriorityPrimary)
+	if len(primary) == 0 || primary[0].OriginHost == "" {
+		return
+	}
+	u.addFunction("set_req_host_header", "domain_name", primary[0].OriginHost)
+}
+
+func (u *UpdateDomainConfigModel) WithOriginRequestHeaderConf() {
+	u.clear("origin_request_header")
+	for _, v := range u.req.OriginRequestHeaderConf {
+		u.addFunction("origin_request_header",
+			"header_operation_type", getHeaderOperationType(v.Action),
+			"header_name", v.ParameterKey,
+			"header_value", v.ParameterValue,
+			"duplicate", consts.OFF,
+		)
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithOriginUrlConf() {
+	u.clear("back_to_origin_url_rewrite")
+	for _, v := range u.req.OriginUrlConf {
+		u.addFunction("back_to_origin_url_rewrite", "source_url", v.RewriteUrl, "target_url", v.TargetUrl, "flag", "break")
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithIpFilterConf() {
+	if u.req.IpFilterConf == nil {
+		return
+	}
+	u.clear("ip_black_list_set", "ip_allow_list_set")
+	if u.req.IpFilterConf.Status != types.ON {
+		return
+	}
+	//阿里云黑名单与白名单互斥,以第一条有效规则的类型为准
+	ips := make([]string, 0)
+	ipType := int64(-1)
+	for _, v := range u.req.IpFilterConf.IpFilterConf {
+		if len(v.IpList) == 0 {
+			continue
+		}
+		if ipType == -1 {
+			ipType = v.IpType
+		}
+		if v.IpType == ipType {
+			ips = append(ips, v.IpList...)
+		}
+	}
+	if len(ips) == 0 {
+		return
+	}
+	u.addFunction(getIpListFunction(ipType), "ip_list", strings.Join(ips, ","))
+}
+
+func (u *UpdateDomainConfigModel) WithRefererConf() {
+	if u.req.RefererConf == nil {
+		return
+	}
+	u.clear("referer_white_list_set", "referer_black_list_set")
+	if u.req.RefererConf.Status != types.ON {
+		return
+	}
+	name, argName := getRefererFunction(u.req.RefererConf.RefererType)
+	u.addFunction(name,
+		argName, strings.Join(u.req.RefererConf.RefererList, ","),
+		"allow_empty", getSwitch(u.req.RefererConf.IncludeEmpty),
+	)
+}
+
+func (u *UpdateDomainConfigModel) WithUserAgentConf() {
+	if u.req.UserAgentConf == nil {
+		return
+	}
+	u.clear("ali_ua")
+	if u.req.UserAgentConf.Status != types.ON {
+		return
+	}
+	//阿里云UA黑白名单对全部内容生效,以第一条有效规则的类型为准
+	agents := make([]string, 0)
+	agentType := int64(-1)
+	for _, v := range u.req.UserAgentConf.UserAgentConf {
+		if len(v.AgentList) == 0 {
+			continue
+		}
+		if agentType == -1 {
+			agentType = v.AgentType
+		}
+		if v.AgentType == agentType {
+			agents = append(agents, v.AgentList...)
+		}
+	}
+	if len(agents) == 0 {
+		return
+	}
+	u.addFunction("ali_ua", "ua", strings.Join(agents, "|"), "type", getListType(agentType))
+}
+
+func (u *UpdateDomainConfigModel) WithSpeedConf() {
+	if u.req.SpeedConf == nil {
+		return
+	}
+	u.clear("limit_rate")
+	if u.req.SpeedConf.Status != types.ON {
+		return
+	}
+	//阿里云单请求限速对全部内容生效,取第一条有效规则
+	for _, v := range u.req.SpeedConf.SpeedConf {
+		if v.SpeedValues <= 0 {
+			continue
+		}
+		u.addFunction("limit_rate", "ali_limit_rate", fmt.Sprintf("%dk", v.SpeedValues))
+		return
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithAuthConf() {
+	if u.req.AuthConf == nil {
+		return
+	}
+	if u.req.AuthConf.Status != types.ON {
+		u.addFunction("aliauth", "auth_type", "no_auth")
+		return
+	}
+	authType := getAuthType(u.req.AuthConf.AuthManner)
+	if authType == "" {
+		u.err = cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("auth manner %d not support", u.req.AuthConf.AuthManner))
+		return
+	}
+	u.addFunction("aliauth",
+		"auth_type", authType,
+		"auth_key1", u.req.AuthConf.AuthKey,
+		"auth_key2", u.req.AuthConf.AuthKeyBackup,
+		"ali_auth_delta", strconv.FormatInt(u.req.AuthConf.TimeValue, 10),
+	)
+}
+
+func (u *UpdateDomainConfigModel) WithRemoteAuthConf() {
+	if u.req.RemoteAuthConf == nil {
+		return
+	}
+	conf := u.req.RemoteAuthConf
+	if conf.Status != types.ON {
+		u.addFunction("cdn_remote_auth", "enable", consts.OFF)
+		return
+	}
+	args := []string{
+		"enable", consts.ON,
+		"auth_addr", conf.AuthUrl,
+		"auth_method", getRemoteAuthMethod(conf.ReqMethod),
+		"timeout_action", getRemoteAuthTimeoutAction(conf.TimeoutAction),
+	}
+	if conf.TimeoutDuration > 0 {
+		args = append(args, "auth_timeout", strconv.FormatInt(conf.TimeoutDuration, 10))
+	}
+	if conf.FileType == consts.FileTypeFile && len(conf.FileContent) > 0 {
+		args = append(args, "file_type", strings.Join(conf.FileContent, ","))
+	}
+	u.addFunction("cdn_remote_auth", args...)
+}
+
+func (u *UpdateDomainConfigModel) WithCacheListConf() {
+	u.clear("filetype_based_ttl_set", "path_based_ttl_set")
+	for _, v := range u.req.CacheListConf {
+		name, argName, value := getCacheFunction(v.CacheType, v.CacheContent)
+		ttl := cachettl.Seconds(v.CacheTTL, v.CacheUnit)
+		if v.CacheStatus == consts.CacheStatusOff {
+			ttl = 0
+		}
+		//权重取值1-99,越大优先级越高
+		weight := v.Priority
+		if weight < 1 {
+			weight = 1
+		}
+		if weight > 99 {
+			weight = 99
+		}
+		u.addFunction(name, "ttl", strconv.FormatInt(ttl, 10), argName, value, "weight", strconv.FormatInt(weight, 10))
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithCacheCodeConf() {
+	u.clear("default_ttl_code")
+	if len(u.req.CacheCodeConf) == 0 {
+		return
+	}
+	u.addFunction("default_ttl_code", "default_ttl_code", getCacheCodeTtl(u.req.CacheCodeConf))
+}
+
+func (u *UpdateDomainConfigModel) WithRequestUrlRewriteConf() {
+	u.clear("host_redirect")
+	for _, v := range u.req.RequestUrlRewriteConf {
+		flag := getRedirectFlag(v.RedirectCode)
+		if flag == "" {
+			u.err = cdnerr.New(cdnerr.ErrUnsupported, types.AliyunSdkName, fmt.Sprintf("redirect code %d not support", v.RedirectCode))
+			return
+		}
+		u.addFunction("host_redirect", "regex", v.RewriteUrl, "replacement", v.TargetUrl, "flag", flag)
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithCustomErrorPageConf() {
+	u.clear("error_page")
+	for _, v := range u.req.CustomErrorPageConf {
+		u.addFunction("error_page", "error_code", strconv.FormatInt(v.StatusCode, 10), "rewrite_page", v.GoalAddress)
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithIntelligentCompressionConf() {
+	if u.req.IntelligentCompressionConf == nil {
+		return
+	}
+	//阿里云gzip与brotli压缩对全部内容生效,只取规则中的压缩方式
+	enabled := make(map[string]bool)
+	if u.req.IntelligentCompressionConf.Status == types.ON {
+		for _, v := range u.req.IntelligentCompressionConf.IntelligentCompressionConf {
+			enabled[getCompressFunction(v.CompressMethod)] = true
+		}
+	}
+	u.addFunction("gzip", "enable", getBoolSwitch(enabled["gzip"]))
+	if enabled["brotli"] {
+		u.addFunction("brotli", "enable", consts.ON, "brotli_level", "1")
+	} else {
+		u.addFunction("brotli", "enable", consts.OFF)
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithResponseHeaderConf() {
+	u.clear("set_resp_header")
+	for _, v := range u.req.ResponseHeaderConf {
+		u.addFunction("set_resp_header",
+			"key", v.ParameterKey,
+			"value", v.ParameterValue,
+			"header_operation_type", getHeaderOperationType(v.Action),
+		)
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithHttpsConf() {
+	if u.req.HttpsConf == nil {
+		return
+	}
+	conf := u.req.HttpsConf
+	u.certificate = &model.SetCdnDomainSSLCertificateRequest{SSLProtocol: getSwitch(conf.HttpsStatus)}
+	if conf.HttpsStatus != types.ON {
+		return
+	}
+	u.certificate.CertName = conf.CertName
+	if u.certificate.CertName == "" {
+		u.certificate.CertName = u.req.Domain
+	}
+	u.certificate.CertType = "upload"
+	u.certificate.SSLPub = conf.CertValue
+	u.certificate.SSLPri = conf.CertKey
+	if conf.JumpForceStatus == consts.SwitchOn {
+		u.addFunction(getForceFunction(conf.JumpType), "enable", consts.ON)
+	} else {
+		u.addFunction("https_force", "enable", consts.OFF)
+		u.addFunction("http_force", "enable", consts.OFF)
+	}
+	u.addFunction("https_option", "http2", getSwitch(conf.HttpTwo), "ocsp_stapling", getSwitch(conf.OcspStatus))
+	if len(conf.TlsVersion) > 0 {
+		versions := make(map[string]bool, len(conf.TlsVersion))
+		for _, v := range conf.TlsVersion {
+			versions[getTlsVersionArg(v)] = true
+		}
+		args := make([]string, 0, 8)
+		for _, v := range []string{"tls10", "tls11", "tls12", "tls13"} {
+			args = append(args, v, getBoolSwitch(versions[v]))
+		}
+		u.addFunction("https_tls_version", args...)
+	}
+	if conf.HstsStatus == consts.SwitchOn {
+		u.addFunction("HSTS",
+			"enabled", consts.ON,
+			"https_hsts_max_age", strconv.FormatInt(conf.HstsExpirationTime, 10),
+			"https_hsts_include_subdomains", getSwitch(conf.HstsSubdomain),
+		)
+	} else {
+		u.addFunction("HSTS", "enabled", consts.OFF)
+	}
+}
+
+// send 依次提交收集到的配置,证书需先于https相关配置功能提交
+func (u *UpdateDomainConfigModel) send(ctx context.Context) error {
+	if u.err != nil {
+		return u.err
+	}
+	domain := getDomainName(u.req.Domain, u.req.DomainId)
+	client := u.a.client
+	calls := make([]func() (*model.CommonResponse, error), 0)
+	if u.scope != "" {
+		property, err := json.Marshal(map[string]string{"coverage": u.scope})
+		if err != nil {
+			return err
+		}
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.ModifyCdnDomainSchdmByProperty(ctx, &model.ModifyCdnDomainSchdmByPropertyRequest{
+				DomainName: domain,
+				Property:   string(property),
+			})
+		})
+	}
+	if len(u.sources) > 0 {
+		sources, err := json.Marshal(u.sources)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.ModifyCdnDomain(ctx, &model.ModifyCdnDomainRequest{DomainName: domain, Sources: string(sources)})
+		})
+	}
+	if len(u.clears) > 0 {
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return u.deleteConfigs(ctx, domain)
+		})
+	}
+	if u.certificate != nil {
+		u.certificate.DomainName = domain
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetCdnDomainSSLCertificate(ctx, u.certificate)
+		})
+	}
+	if len(u.functions) > 0 {
+		functions, err := json.Marshal(u.functions)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.BatchSetCdnDomainConfig(ctx, &model.BatchSetCdnDomainConfigRequest{
+				DomainNames: domain,
+				Functions:   string(functions),
+			})
+		})
+	}
+	for _, call := range calls {
+		if _, err := invoke(ctx, u.a, ratelimit.ClassConfig, retry.Idempotent, call); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteConfigs 删除需要重新设置的配置功能的已有配置
+func (u *UpdateDomainConfigModel) deleteConfigs(ctx context.Context, domain string) (*model.CommonResponse, error) {
+	res, err := u.a.client.DescribeCdnDomainConfigs(ctx, &model.DescribeCdnDomainConfigsRequest{
+		DomainName:    domain,
+		FunctionNames: strings.Join(u.clears, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(res.DomainConfigs.DomainConfig))
+	for _, v := range res.DomainConfigs.DomainConfig {
+		ids = append(ids, v.ConfigId)
+	}
+	if len(ids) == 0 {
+		return &model.CommonResponse{RequestId: res.RequestId}, nil
+	}
+	//查询与删除是两次接口调用,invoke只获取了一个令牌
+	if err := u.a.limiter.Wait(ctx, ratelimit.ClassConfig); err != nil {
+		return nil, err
+	}
+	return u.a.client.DeleteSpecificConfig(ctx, &model.DeleteSpecificConfigRequest{
+		DomainName: domain,
+		ConfigId:   strings.Join(ids, ","),
+	})
+}
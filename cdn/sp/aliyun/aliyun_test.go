@@ -0,0 +1,356 @@
+package aliyun
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDomainLifecycle(t *testing.T) {
+	s, a := newStandIn(t)
+	err := a.CreateDomain(&types.CreateDomainRequest{
+		Domain:         "www.example.com",
+		AreaCode:       consts.AreaCodeGlobal,
+		ChannelType:    consts.ChannelTypeDownload,
+		OriginProtocol: consts.OriginProtocolHttps,
+		Sources: []*entity.OriginServerConf{
+			{OriginAddressList: "1.1.1.1", OriginHttpsPort: 8443, OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityPrimary},
+			{OriginAddressList: "backup.example.com", OriginType: consts.OriginTypeDomain, OriginPriority: consts.OriginPriorityBackup},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := s.domain("www.example.com")
+	if d.detail.CdnType != "download" || d.detail.Scope != "global" || len(d.sources) != 2 {
+		t.Fatalf("unexpected created domain %+v %d sources", d.detail, len(d.sources))
+	}
+	if v := d.sources[0]; v.Content != "1.1.1.1" || v.Type != "ipaddr" || v.Port != 8443 || v.Priority != sourcePriorityMain {
+		t.Fatalf("unexpected primary source %+v", v)
+	}
+	if v := d.sources[1]; v.Content != "backup.example.com" || v.Type != "domain" || v.Priority != sourcePriorityBack {
+		t.Fatalf("unexpected backup source %+v", v)
+	}
+	//回源协议与回源host在创建后以配置功能提交
+	if got := s.functions("www.example.com", "forward_scheme"); !reflect.DeepEqual(got, []map[string]string{{"enable": "on", "scheme_origin": "https"}}) {
+		t.Fatalf("unexpected forward scheme %v", got)
+	}
+	if got := s.functions("www.example.com", "set_req_host_header"); !reflect.DeepEqual(got, []map[string]string{{"domain_name": "origin.example.com"}}) {
+		t.Fatalf("unexpected origin host %v", got)
+	}
+	if err := a.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrDomainAlreadyExists) {
+		t.Fatalf("got %v, want domain already exists", err)
+	}
+
+	detail, err := a.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.DomainId != "www.example.com" || detail.Cname != d.detail.Cname || detail.AreaCode != consts.AreaCodeGlobal || detail.ChannelType != consts.ChannelTypeDownload || detail.Status != consts.CdnDomainStatusDeploying {
+		t.Fatalf("unexpected detail %+v", detail)
+	}
+	if detail.CreateTime != time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix() {
+		t.Fatalf("unexpected create time %d", detail.CreateTime)
+	}
+
+	if err := a.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := a.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusStoped, Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 1 || !reflect.DeepEqual(list.List, []string{"www.example.com"}) {
+		t.Fatalf("unexpected stopped domains %+v", list)
+	}
+	if err := a.EnableDomain(&types.EnableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if status := s.domain("www.example.com").detail.DomainStatus; status != "online" {
+		t.Fatalf("got status %s after enable", status)
+	}
+
+	if err := a.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if s.domain("www.example.com") != nil {
+		t.Fatal("domain not deleted")
+	}
+	//域名不存在时删除视为成功
+	if err := a.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+}
+
+func TestUpdateDomainConfig(t *testing.T) {
+	s, a := newStandIn(t)
+	s.addDomain("www.example.com")
+	//已有配置在提交前删除
+	if err := a.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateIpFilterConf,
+		Domain:       "www.example.com",
+		IpFilterConf: &types.IpFilterConf{Status: types.ON, IpFilterConf: []*entity.IpFilter{{IpType: consts.BlackList, IpList: []string{"10.0.0.9"}}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	s.calls = nil
+	s.mu.Unlock()
+
+	err := a.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateFullConf,
+		Domain:       "www.example.com",
+		CdnDomain:    &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeOversea},
+		IpFilterConf: &types.IpFilterConf{Status: types.ON, IpFilterConf: []*entity.IpFilter{
+			{IpType: consts.WhiteList, IpList: []string{"10.0.0.1"}},
+			{IpType: consts.BlackList, IpList: []string{"10.0.0.2"}},
+			{IpType: consts.WhiteList, IpList: []string{"10.0.0.3"}},
+		}},
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{".jpg", "png"}, CacheTTL: 2, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOn, Priority: 120},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"api"}, CacheTTL: 10, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOff},
+		},
+		ResponseHeaderConf: []*entity.ResponseHeaderConf{
+			{ParameterKey: "X-Frame-Options", ParameterValue: "DENY", Action: consts.OriginHeaderActionSet},
+		},
+		HttpsConf: &entity.HttpsConf{
+			HttpsStatus:     types.ON,
+			HttpTwo:         types.ON,
+			JumpForceStatus: types.ON,
+			JumpType:        consts.HttpsJumpTypeHttps,
+			CertValue:       "cert",
+			CertKey:         "key",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ModifyCdnDomainSchdmByProperty", "DescribeCdnDomainConfigs", "DeleteSpecificConfig", "SetCdnDomainSSLCertificate", "BatchSetCdnDomainConfig"}
+	if calls := s.callList(); !reflect.DeepEqual(calls, want) {
+		t.Fatalf("got calls %v, want %v", calls, want)
+	}
+	d := s.domain("www.example.com")
+	if d.detail.Scope != "overseas" {
+		t.Fatalf("got scope %s", d.detail.Scope)
+	}
+	//阿里云黑白名单互斥,以第一条有效规则的类型为准
+	if got := s.functions("www.example.com", "ip_black_list_set"); len(got) != 0 {
+		t.Fatalf("old black list not deleted %v", got)
+	}
+	if got := s.functions("www.example.com", "ip_allow_list_set"); !reflect.DeepEqual(got, []map[string]string{{"ip_list": "10.0.0.1,10.0.0.3"}}) {
+		t.Fatalf("unexpected allow list %v", got)
+	}
+	if got := s.functions("www.example.com", "filetype_based_ttl_set"); !reflect.DeepEqual(got, []map[string]string{{"ttl": "7200", "file_type": "jpg,png", "weight": "99"}}) {
+		t.Fatalf("unexpected file type ttl %v", got)
+	}
+	if got := s.functions("www.example.com", "path_based_ttl_set"); !reflect.DeepEqual(got, []map[string]string{{"ttl": "0", "path": "/api/", "weight": "1"}}) {
+		t.Fatalf("unexpected path ttl %v", got)
+	}
+	if got := s.functions("www.example.com", "set_resp_header"); len(got) != 1 || got[0]["key"] != "X-Frame-Options" || got[0]["value"] != "DENY" {
+		t.Fatalf("unexpected response header %v", got)
+	}
+	if d.cert == nil || d.cert.SSLProtocol != "on" || d.cert.CertName != "www.example.com" || d.cert.CertType != "upload" || d.cert.SSLPub != "cert" || d.cert.SSLPri != "key" {
+		t.Fatalf("unexpected certificate %+v", d.cert)
+	}
+	if got := s.functions("www.example.com", "https_force"); !reflect.DeepEqual(got, []map[string]string{{"enable": "on"}}) {
+		t.Fatalf("unexpected https force %v", got)
+	}
+	if got := s.functions("www.example.com", "https_option"); len(got) != 1 || got[0]["http2"] != "on" {
+		t.Fatalf("unexpected https option %v", got)
+	}
+}
+
+func TestUpdateDomainUnsupported(t *testing.T) {
+	s, a := newStandIn(t)
+	s.addDomain("www.example.com")
+	for _, action := range []string{types.UpdateOriginAdvanceServerConf, types.UpdateIpFrequencyConf, types.UpdateBrowserCacheConf} {
+		err := a.UpdateDomain(&types.UpdateDomainRequest{UpdateAction: action, Domain: "www.example.com"})
+		if !errors.Is(err, cdnerr.ErrUnsupported) {
+			t.Fatalf("%s: got %v, want unsupported", action, err)
+		}
+	}
+	if calls := s.callList(); len(calls) != 0 {
+		t.Fatalf("unsupported actions called %v", calls)
+	}
+}
+
+func TestUpdateDomainNotFound(t *testing.T) {
+	s, a := newStandIn(t)
+	err := a.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateArea,
+		Domain:       "www.example.com",
+		CdnDomain:    &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeOversea},
+	})
+	if !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+	if calls := s.callList(); len(calls) != 1 || calls[0] != "ModifyCdnDomainSchdmByProperty" {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestPurgeAndTaskStatus(t *testing.T) {
+	s, a := newStandIn(t)
+	purge, err := a.PurgePathCache(&types.PurgePathCacheRequest{Paths: []string{"https://www.example.com/static/"}, Mode: consts.ContentPurgePathModeAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := a.ShowPurgeTaskStatus(&types.ShowPurgeTaskStatusRequest{TaskId: purge.TaskId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != consts.ShowContentPurgeOrPushStatusSuccess {
+		t.Fatalf("got purge status %d", status.Status)
+	}
+	push, err := a.PushUrlsCache(&types.PushUrlsCacheRequest{Urls: []string{"https://www.example.com/a.jpg", "https://www.example.com/b.jpg"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//任务下存在进行中的url时任务进行中,存在失败的url时任务失败
+	for _, v := range []struct {
+		status string
+		want   int64
+	}{
+		{"Pending", consts.ShowContentPurgeOrPushStatusDoing},
+		{"Refreshing", consts.ShowContentPurgeOrPushStatusDoing},
+		{"Failed", consts.ShowContentPurgeOrPushStatusFail},
+		{"Complete", consts.ShowContentPurgeOrPushStatusSuccess},
+	} {
+		s.mu.Lock()
+		s.tasks[push.TaskId][1].Status = v.status
+		s.mu.Unlock()
+		status, err := a.ShowPushTaskStatus(&types.ShowPushTaskStatusRequest{TaskId: push.TaskId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Status != v.want {
+			t.Fatalf("%s: got push status %d, want %d", v.status, status.Status, v.want)
+		}
+	}
+	if _, err := a.ShowPushTaskStatus(&types.ShowPushTaskStatusRequest{TaskId: "404"}); !errors.Is(err, cdnerr.ErrNotFound) {
+		t.Fatalf("got %v, want not found", err)
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	s, a := newStandIn(t)
+	s.addDomain("a.example.com")
+	s.addDomain("b.example.com")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := start + 3600
+	static, err := a.DomainAccessDataStatic(&types.DomainAccessDataStaticRequest{
+		Domains:   []string{"a.example.com"},
+		StartTime: start,
+		EndTime:   end,
+		Metric:    consts.DataAccessMetricTypeFlux,
+		Interval:  consts.DataIntervalTypeHour,
+		Area:      consts.AreaCodeOversea,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := static["a.example.com"]
+	if len(static) != 1 || len(points) != 2 || points[0].Time != start || points[0].Value != 10 || points[1].Time != end || points[1].Value != 20 {
+		t.Fatalf("unexpected oversea flux %v", static)
+	}
+	cases := []struct {
+		name   string
+		metric int64
+		area   int64
+		want   int64
+	}{
+		{"flux", consts.DataAccessMetricTypeFlux, consts.AreaCodeChinaMainland, 300},
+		//带宽取峰值
+		{"bandwidth", consts.DataAccessMetricTypeBandwidth, consts.AreaCodeChinaMainland, 200},
+		//请求数取各时间段的请求总数
+		{"request", consts.DataAccessMetricTypeRequest, consts.AreaCodeChinaMainland, 3},
+		{"oversea request", consts.DataAccessMetricTypeRequest, consts.AreaCodeOversea, 7},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			total, err := a.DomainAccessTotalData(&types.DomainAccessTotalDataRequest{StartTime: start, EndTime: end, Metric: c.metric, Area: c.area})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(total, types.DataTotalDataResponse{"a.example.com": c.want, "b.example.com": c.want}) {
+				t.Fatalf("got %v, want %d per domain", total, c.want)
+			}
+		})
+	}
+	//回源数据不区分区域
+	origin, err := a.DomainOriginTotalData(&types.DomainOriginTotalDataRequest{Domains: []string{"b.example.com"}, StartTime: start, EndTime: end, Metric: consts.DataOriginMetricTypeFlux, Area: consts.AreaCodeOversea})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(origin, types.DataTotalDataResponse{"b.example.com": 4000}) {
+		t.Fatalf("unexpected origin flux %v", origin)
+	}
+
+	s.mu.Lock()
+	s.calls = nil
+	s.mu.Unlock()
+	if _, err := a.DomainAccessTotalData(&types.DomainAccessTotalDataRequest{StartTime: start, EndTime: end, Metric: consts.DataAccessMetricTypeHitFlux}); !errors.Is(err, cdnerr.ErrUnsupported) {
+		t.Fatalf("got %v, want unsupported metric", err)
+	}
+	district := int64(1)
+	if _, err := a.DomainAccessDataStatic(&types.DomainAccessDataStaticRequest{StartTime: start, EndTime: end, District: &district}); !errors.Is(err, cdnerr.ErrUnsupported) {
+		t.Fatalf("got %v, want unsupported district", err)
+	}
+	if calls := s.callList(); len(calls) != 0 {
+		t.Fatalf("unsupported queries called %v", calls)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	cases := []struct {
+		status    int
+		code      string
+		kind      error
+		temporary bool
+	}{
+		{http.StatusBadRequest, "Throttling.User", cdnerr.ErrRateLimited, false},
+		{http.StatusNotFound, "InvalidAccessKeyId.NotFound", cdnerr.ErrAuthFailed, false},
+		{http.StatusBadRequest, "InvalidDomain.NotFound", cdnerr.ErrDomainNotFound, false},
+		{http.StatusBadRequest, "DomainNotRegistration", cdnerr.ErrIcpNotFiled, false},
+		{http.StatusBadRequest, "Exceed.MaxDomainCount", cdnerr.ErrQuotaExceeded, false},
+		{http.StatusBadRequest, "MissingParameter", cdnerr.ErrInvalidParameter, false},
+		{http.StatusForbidden, "UnknownError", cdnerr.ErrAuthFailed, false},
+		{http.StatusServiceUnavailable, "ServiceUnavailable", nil, true},
+	}
+	for _, c := range cases {
+		t.Run(c.code, func(t *testing.T) {
+			s, a := newStandIn(t)
+			s.fail("DescribeUserDomains", c.status, c.code)
+			_, err := a.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusDeployed, Page: 1, Limit: 10})
+			var e *cdnerr.Error
+			if !errors.As(err, &e) {
+				t.Fatalf("got %v, want *errors.Error", err)
+			}
+			if e.Kind != c.kind || e.Code != c.code || e.StatusCode != c.status || e.Temporary != c.temporary || e.RequestId != "req-1" || e.Provider != types.AliyunSdkName {
+				t.Fatalf("unexpected error %+v", e)
+			}
+		})
+	}
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	s, _ := newStandIn(t)
+	a, err := New(context.Background(), &Config{Ak: "ak", Sk: "wrong", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = a.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusDeployed, Page: 1, Limit: 10})
+	if !errors.Is(err, cdnerr.ErrAuthFailed) {
+		t.Fatalf("got %v, want auth failed", err)
+	}
+}
@@ -0,0 +1,167 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common/model"
+	"net/http"
+	"time"
+)
+
+const (
+	ApiVersion      = "2018-05-10"
+	DefaultEndpoint = "https://cdn.aliyuncs.com"
+	FormatJson      = "JSON"
+	FormUrlencoded  = "application/x-www-form-urlencoded"
+)
+
+type Client struct {
+	rest *rest.Client
+}
+
+func NewClient(signer *Signer, endpoint string) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	client := rest.NewClient(endpoint, &formSigner{signer: signer})
+	client.ErrorDecoder = rest.NewErrorDecoder(rest.ErrorFields{
+		RequestId: []string{"RequestId"},
+		Code:      []string{"Code"},
+		Message:   []string{"Message"},
+	})
+	return &Client{
+		rest: client,
+	}
+}
+
+// WithHttpClient 设置发送请求使用的HTTP客户端,为空时使用http.DefaultClient
+func (c *Client) WithHttpClient(client *http.Client) *Client {
+	if client != nil {
+		c.rest.HttpClient = client
+	}
+	return c
+}
+
+// send 发送签名请求,请求参数与公共参数以表单形式放在请求体中
+func send[T any](ctx context.Context, c *Client, action string, params interface{}) (*T, error) {
+	form, err := rest.EncodeQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	form.Set("Action", action)
+	form.Set("Format", FormatJson)
+	form.Set("Version", ApiVersion)
+	r := rest.NewRequest(http.MethodPost, "/").WithHeader(rest.ContentType, FormUrlencoded).WithBody([]byte(form.Encode()))
+	return rest.Do[T](ctx, c.rest, r)
+}
+
+// nonce 生成唯一随机数,防止重放攻击
+func nonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// AddCdnDomain 新增加速域名
+func (c *Client) AddCdnDomain(ctx context.Context, req *model.AddCdnDomainRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "AddCdnDomain", req)
+}
+
+// DescribeUserDomains 查询域名列表
+func (c *Client) DescribeUserDomains(ctx context.Context, req *model.DescribeUserDomainsRequest) (*model.DescribeUserDomainsResponse, error) {
+	return send[model.DescribeUserDomainsResponse](ctx, c, "DescribeUserDomains", req)
+}
+
+// DescribeCdnDomainDetail 查询域名基本配置
+func (c *Client) DescribeCdnDomainDetail(ctx context.Context, req *model.DescribeCdnDomainDetailRequest) (*model.DescribeCdnDomainDetailResponse, error) {
+	return send[model.DescribeCdnDomainDetailResponse](ctx, c, "DescribeCdnDomainDetail", req)
+}
+
+// ModifyCdnDomain 修改域名源站
+func (c *Client) ModifyCdnDomain(ctx context.Context, req *model.ModifyCdnDomainRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "ModifyCdnDomain", req)
+}
+
+// ModifyCdnDomainSchdmByProperty 修改域名加速区域
+func (c *Client) ModifyCdnDomainSchdmByProperty(ctx context.Context, req *model.ModifyCdnDomainSchdmByPropertyRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "ModifyCdnDomainSchdmByProperty", req)
+}
+
+// StartCdnDomain 启用域名
+func (c *Client) StartCdnDomain(ctx context.Context, req *model.DomainNameRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "StartCdnDomain", req)
+}
+
+// StopCdnDomain 停用域名
+func (c *Client) StopCdnDomain(ctx context.Context, req *model.DomainNameRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "StopCdnDomain", req)
+}
+
+// DeleteCdnDomain 删除域名
+func (c *Client) DeleteCdnDomain(ctx context.Context, req *model.DomainNameRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "DeleteCdnDomain", req)
+}
+
+// DescribeVerifyContent 获取域名归属校验内容
+func (c *Client) DescribeVerifyContent(ctx context.Context, req *model.DomainNameRequest) (*model.DescribeVerifyContentResponse, error) {
+	return send[model.DescribeVerifyContentResponse](ctx, c, "DescribeVerifyContent", req)
+}
+
+// VerifyDomainOwner 校验域名归属
+func (c *Client) VerifyDomainOwner(ctx context.Context, req *model.VerifyDomainOwnerRequest) (*model.VerifyDomainOwnerResponse, error) {
+	return send[model.VerifyDomainOwnerResponse](ctx, c, "VerifyDomainOwner", req)
+}
+
+// BatchSetCdnDomainConfig 批量配置域名
+func (c *Client) BatchSetCdnDomainConfig(ctx context.Context, req *model.BatchSetCdnDomainConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "BatchSetCdnDomainConfig", req)
+}
+
+// DescribeCdnDomainConfigs 查询域名配置
+func (c *Client) DescribeCdnDomainConfigs(ctx context.Context, req *model.DescribeCdnDomainConfigsRequest) (*model.DescribeCdnDomainConfigsResponse, error) {
+	return send[model.DescribeCdnDomainConfigsResponse](ctx, c, "DescribeCdnDomainConfigs", req)
+}
+
+// DeleteSpecificConfig 删除域名配置
+func (c *Client) DeleteSpecificConfig(ctx context.Context, req *model.DeleteSpecificConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "DeleteSpecificConfig", req)
+}
+
+// SetCdnDomainSSLCertificate 设置域名证书
+func (c *Client) SetCdnDomainSSLCertificate(ctx context.Context, req *model.SetCdnDomainSSLCertificateRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, "SetCdnDomainSSLCertificate", req)
+}
+
+// RefreshObjectCaches 刷新缓存
+func (c *Client) RefreshObjectCaches(ctx context.Context, req *model.RefreshObjectCachesRequest) (*model.RefreshObjectCachesResponse, error) {
+	return send[model.RefreshObjectCachesResponse](ctx, c, "RefreshObjectCaches", req)
+}
+
+// PushObjectCache 预热缓存
+func (c *Client) PushObjectCache(ctx context.Context, req *model.PushObjectCacheRequest) (*model.PushObjectCacheResponse, error) {
+	return send[model.PushObjectCacheResponse](ctx, c, "PushObjectCache", req)
+}
+
+// DescribeRefreshTaskById 按任务ID查询刷新预热任务
+func (c *Client) DescribeRefreshTaskById(ctx context.Context, req *model.DescribeRefreshTaskByIdRequest) (*model.DescribeRefreshTaskByIdResponse, error) {
+	return send[model.DescribeRefreshTaskByIdResponse](ctx, c, "DescribeRefreshTaskById", req)
+}
+
+// DescribeRefreshTasks 查询刷新预热任务列表
+func (c *Client) DescribeRefreshTasks(ctx context.Context, req *model.DescribeRefreshTasksRequest) (*model.DescribeRefreshTasksResponse, error) {
+	return send[model.DescribeRefreshTasksResponse](ctx, c, "DescribeRefreshTasks", req)
+}
+
+// DescribeDomainData 查询域名统计数据,action为DescribeDomain*Data系列接口
+func (c *Client) DescribeDomainData(ctx context.Context, action string, req *model.DomainDataRequest) (*model.DomainDataResponse, error) {
+	return send[model.DomainDataResponse](ctx, c, action, req)
+}
+
+// DescribeDomainTopUrlVisit 查询热门url
+func (c *Client) DescribeDomainTopUrlVisit(ctx context.Context, req *model.DescribeDomainTopUrlVisitRequest) (*model.DescribeDomainTopUrlVisitResponse, error) {
+	return send[model.DescribeDomainTopUrlVisitResponse](ctx, c, "DescribeDomainTopUrlVisit", req)
+}
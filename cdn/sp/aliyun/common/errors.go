@@ -0,0 +1,6 @@
+package common
+
+import "github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+
+// ServiceResponseError 阿里云接口返回的错误
+type ServiceResponseError = rest.ServiceError
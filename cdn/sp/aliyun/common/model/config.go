@@ -0,0 +1,63 @@
+package model
+
+type FunctionArg struct {
+	ArgName  string `json:"argName"`
+	ArgValue string `json:"argValue"`
+}
+
+// DomainFunction 域名配置功能,序列化为json后作为Functions参数
+type DomainFunction struct {
+	FunctionName string         `json:"functionName"`
+	FunctionArgs []*FunctionArg `json:"functionArgs"`
+}
+
+type BatchSetCdnDomainConfigRequest struct {
+	//域名,多个以逗号分隔
+	DomainNames string `json:"DomainNames"`
+	//配置功能列表,json格式
+	Functions string `json:"Functions"`
+}
+
+type DescribeCdnDomainConfigsRequest struct {
+	DomainName string `json:"DomainName"`
+	//功能名称,多个以逗号分隔
+	FunctionNames string `json:"FunctionNames,omitempty"`
+}
+
+type DomainConfig struct {
+	ConfigId     string `json:"ConfigId"`
+	FunctionName string `json:"FunctionName"`
+	Status       string `json:"Status"`
+	FunctionArgs struct {
+		FunctionArg []*struct {
+			ArgName  string `json:"ArgName"`
+			ArgValue string `json:"ArgValue"`
+		} `json:"FunctionArg"`
+	} `json:"FunctionArgs"`
+}
+
+type DescribeCdnDomainConfigsResponse struct {
+	RequestId     string `json:"RequestId"`
+	DomainConfigs struct {
+		DomainConfig []*DomainConfig `json:"DomainConfig"`
+	} `json:"DomainConfigs"`
+}
+
+type DeleteSpecificConfigRequest struct {
+	DomainName string `json:"DomainName"`
+	//配置ID,多个以逗号分隔
+	ConfigId string `json:"ConfigId"`
+}
+
+type SetCdnDomainSSLCertificateRequest struct {
+	DomainName string `json:"DomainName"`
+	//是否开启https：on、off
+	SSLProtocol string `json:"SSLProtocol"`
+	CertName    string `json:"CertName,omitempty"`
+	//证书类型：upload上传证书
+	CertType string `json:"CertType,omitempty"`
+	//证书公钥
+	SSLPub string `json:"SSLPub,omitempty"`
+	//证书私钥
+	SSLPri string `json:"SSLPri,omitempty"`
+}
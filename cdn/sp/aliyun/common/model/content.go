@@ -0,0 +1,72 @@
+package model
+
+type RefreshObjectCachesRequest struct {
+	//刷新的url或目录,多个以换行符分隔
+	ObjectPath string `json:"ObjectPath"`
+	//刷新类型：File、Directory
+	ObjectType string `json:"ObjectType"`
+	//目录刷新时是否刷新全部资源：true全部、false仅刷新有变化的资源
+	Force string `json:"Force,omitempty"`
+}
+
+type RefreshObjectCachesResponse struct {
+	RequestId string `json:"RequestId"`
+	//刷新任务ID,多个以逗号分隔
+	RefreshTaskId string `json:"RefreshTaskId"`
+}
+
+type PushObjectCacheRequest struct {
+	//预热的url,多个以换行符分隔
+	ObjectPath string `json:"ObjectPath"`
+}
+
+type PushObjectCacheResponse struct {
+	RequestId string `json:"RequestId"`
+	//预热任务ID,多个以逗号分隔
+	PushTaskId string `json:"PushTaskId"`
+}
+
+type Task struct {
+	TaskId     string `json:"TaskId"`
+	ObjectPath string `json:"ObjectPath"`
+	//任务类型：file、directory、preload
+	ObjectType string `json:"ObjectType"`
+	//任务状态：Complete、Refreshing、Failed、Pending
+	Status       string `json:"Status"`
+	Process      string `json:"Process"`
+	CreationTime string `json:"CreationTime"`
+}
+
+type DescribeRefreshTaskByIdRequest struct {
+	//任务ID,多个以逗号分隔,最多10个
+	TaskId string `json:"TaskId"`
+}
+
+type DescribeRefreshTaskByIdResponse struct {
+	RequestId  string  `json:"RequestId"`
+	TotalCount int64   `json:"TotalCount"`
+	Tasks      []*Task `json:"Tasks"`
+}
+
+type DescribeRefreshTasksRequest struct {
+	//任务类型：file、directory、preload
+	ObjectType string `json:"ObjectType,omitempty"`
+	//任务状态：Complete、Refreshing、Failed
+	Status string `json:"Status,omitempty"`
+	//开始时间,UTC时间,格式如：2006-01-02T15:04:05Z
+	StartTime  string `json:"StartTime,omitempty"`
+	EndTime    string `json:"EndTime,omitempty"`
+	PageNumber int64  `json:"PageNumber,omitempty"`
+	//每页条数,最大100
+	PageSize int64 `json:"PageSize,omitempty"`
+}
+
+type DescribeRefreshTasksResponse struct {
+	RequestId  string `json:"RequestId"`
+	PageNumber int64  `json:"PageNumber"`
+	PageSize   int64  `json:"PageSize"`
+	TotalCount int64  `json:"TotalCount"`
+	Tasks      struct {
+		CDNTask []*Task `json:"CDNTask"`
+	} `json:"Tasks"`
+}
@@ -0,0 +1,114 @@
+package model
+
+// CommonResponse 只返回请求ID的接口响应
+type CommonResponse struct {
+	RequestId string `json:"RequestId"`
+}
+
+// Source 源站信息,序列化为json后作为Sources参数
+type Source struct {
+	//源站地址
+	Content string `json:"content"`
+	//源站类型：ipaddr、domain、oss
+	Type string `json:"type"`
+	//优先级：20主源站、30备源站
+	Priority string `json:"priority"`
+	//端口
+	Port int64 `json:"port"`
+	//权重
+	Weight string `json:"weight,omitempty"`
+}
+
+type AddCdnDomainRequest struct {
+	//业务类型：web、download、video
+	CdnType string `json:"CdnType"`
+	//加速域名
+	DomainName string `json:"DomainName"`
+	//源站信息,json格式
+	Sources string `json:"Sources"`
+	//加速区域：domestic、overseas、global
+	Scope string `json:"Scope,omitempty"`
+}
+
+type DescribeUserDomainsRequest struct {
+	PageNumber int64 `json:"PageNumber,omitempty"`
+	//每页条数,最大500
+	PageSize   int64  `json:"PageSize,omitempty"`
+	DomainName string `json:"DomainName,omitempty"`
+	//域名搜索类型：fuzzy_match、pre_match、suf_match、full_match
+	DomainSearchType string `json:"DomainSearchType,omitempty"`
+	//域名状态：online、offline、configuring、configure_failed、checking、check_failed
+	DomainStatus string `json:"DomainStatus,omitempty"`
+}
+
+type PageData struct {
+	DomainName   string `json:"DomainName"`
+	Cname        string `json:"Cname"`
+	CdnType      string `json:"CdnType"`
+	DomainStatus string `json:"DomainStatus"`
+	Coverage     string `json:"Coverage"`
+	GmtCreated   string `json:"GmtCreated"`
+	GmtModified  string `json:"GmtModified"`
+}
+
+type DescribeUserDomainsResponse struct {
+	RequestId  string `json:"RequestId"`
+	PageNumber int64  `json:"PageNumber"`
+	PageSize   int64  `json:"PageSize"`
+	TotalCount int64  `json:"TotalCount"`
+	Domains    struct {
+		PageData []*PageData `json:"PageData"`
+	} `json:"Domains"`
+}
+
+type DescribeCdnDomainDetailRequest struct {
+	DomainName string `json:"DomainName"`
+}
+
+type DomainDetail struct {
+	DomainName   string `json:"DomainName"`
+	Cname        string `json:"Cname"`
+	CdnType      string `json:"CdnType"`
+	DomainStatus string `json:"DomainStatus"`
+	Scope        string `json:"Scope"`
+	GmtCreated   string `json:"GmtCreated"`
+	GmtModified  string `json:"GmtModified"`
+}
+
+type DescribeCdnDomainDetailResponse struct {
+	RequestId            string        `json:"RequestId"`
+	GetDomainDetailModel *DomainDetail `json:"GetDomainDetailModel"`
+}
+
+type DomainNameRequest struct {
+	DomainName string `json:"DomainName"`
+}
+
+type ModifyCdnDomainRequest struct {
+	DomainName string `json:"DomainName"`
+	//源站信息,json格式
+	Sources string `json:"Sources"`
+}
+
+type ModifyCdnDomainSchdmByPropertyRequest struct {
+	DomainName string `json:"DomainName"`
+	//调度属性,json格式,如{"coverage":"overseas"}
+	Property string `json:"Property"`
+}
+
+type DescribeVerifyContentResponse struct {
+	RequestId string `json:"RequestId"`
+	//需配置的TXT记录值
+	Content string `json:"Content"`
+}
+
+type VerifyDomainOwnerRequest struct {
+	DomainName string `json:"DomainName"`
+	//验证方式：dnsCheck、fileCheck
+	VerifyType string `json:"VerifyType"`
+}
+
+type VerifyDomainOwnerResponse struct {
+	RequestId string `json:"RequestId"`
+	Content   string `json:"Content"`
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Number 阿里云统计数据中的数值可能以字符串返回
+type Number float64
+
+func (n *Number) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(v)
+	return nil
+}
+
+type DomainDataRequest struct {
+	DomainName string `json:"DomainName"`
+	//开始时间,UTC时间,格式如：2006-01-02T15:04:05Z
+	StartTime string `json:"StartTime"`
+	EndTime   string `json:"EndTime"`
+	//时间粒度,单位秒：300、3600、86400
+	Interval string `json:"Interval,omitempty"`
+	//运营商英文名
+	IspNameEn string `json:"IspNameEn,omitempty"`
+	//地区英文名
+	LocationNameEn string `json:"LocationNameEn,omitempty"`
+}
+
+type DataModule struct {
+	TimeStamp     string `json:"TimeStamp"`
+	Value         Number `json:"Value"`
+	DomesticValue Number `json:"DomesticValue"`
+	OverseasValue Number `json:"OverseasValue"`
+	//请求数,仅DescribeDomainQpsData返回
+	AccValue         Number `json:"AccValue"`
+	AccDomesticValue Number `json:"AccDomesticValue"`
+	AccOverseasValue Number `json:"AccOverseasValue"`
+}
+
+type DataPerInterval struct {
+	DataModule []*DataModule `json:"DataModule"`
+}
+
+// DomainDataResponse DescribeDomain*Data接口的响应,不同接口的数据字段名不同
+type DomainDataResponse struct {
+	RequestId                 string           `json:"RequestId"`
+	DomainName                string           `json:"DomainName"`
+	DataInterval              string           `json:"DataInterval"`
+	TrafficDataPerInterval    *DataPerInterval `json:"TrafficDataPerInterval"`
+	BpsDataPerInterval        *DataPerInterval `json:"BpsDataPerInterval"`
+	QpsDataPerInterval        *DataPerInterval `json:"QpsDataPerInterval"`
+	SrcTrafficDataPerInterval *DataPerInterval `json:"SrcTrafficDataPerInterval"`
+	SrcBpsDataPerInterval     *DataPerInterval `json:"SrcBpsDataPerInterval"`
+}
+
+// Data 获取响应中的数据
+func (r *DomainDataResponse) Data() []*DataModule {
+	for _, v := range []*DataPerInterval{
+		r.TrafficDataPerInterval,
+		r.BpsDataPerInterval,
+		r.QpsDataPerInterval,
+		r.SrcTrafficDataPerInterval,
+		r.SrcBpsDataPerInterval,
+	} {
+		if v != nil {
+			return v.DataModule
+		}
+	}
+	return nil
+}
+
+type DescribeDomainTopUrlVisitRequest struct {
+	DomainName string `json:"DomainName"`
+	StartTime  string `json:"StartTime"`
+	EndTime    string `json:"EndTime,omitempty"`
+	//排序方式：traf流量、pv请求数
+	SortBy string `json:"SortBy,omitempty"`
+}
+
+type UrlList struct {
+	UrlDetail string `json:"UrlDetail"`
+	Flow      Number `json:"Flow"`
+	VisitData Number `json:"VisitData"`
+}
+
+type DescribeDomainTopUrlVisitResponse struct {
+	RequestId  string `json:"RequestId"`
+	AllUrlList struct {
+		UrlList []*UrlList `json:"UrlList"`
+	} `json:"AllUrlList"`
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common/model"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestSignDocumentedExample 阿里云RPC签名文档中的示例
+func TestSignDocumentedExample(t *testing.T) {
+	params := url.Values{}
+	params.Set("Format", "XML")
+	params.Set("Action", "DescribeRegions")
+	params.Set("Version", "2014-05-26")
+	signed := NewSigner("testid", "testsecret").Sign(http.MethodGet, params, time.Date(2016, 2, 23, 12, 46, 24, 0, time.UTC), "3ee8c1b8-83d3-44af-a94f-4e0ad82fd6cf")
+	if got, want := signed.Get("Signature"), "OLeaidS1JvxuMvnyHOwuJ+uX5qY="; got != want {
+		t.Fatalf("got signature %s, want %s", got, want)
+	}
+}
+
+func TestSignGolden(t *testing.T) {
+	params := url.Values{}
+	params.Set("Action", "AddCdnDomain")
+	params.Set("Format", FormatJson)
+	params.Set("Version", ApiVersion)
+	params.Set("DomainName", "www.example.com")
+	params.Set("CdnType", "web")
+	params.Set("Scope", "global")
+	params.Set("Sources", `[{"content":"1.1.1.1","type":"ipaddr","priority":"20","port":80,"weight":"10"}]`)
+	params.Set("Remark", "a b*c~d")
+	//非UTC时间需转换为UTC
+	ts := time.Date(2024, 1, 1, 8, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	signer := NewSigner("ak", "sk")
+	signed := signer.Sign(http.MethodPost, params, ts, "c4ca4238a0b923820dcc509a6f75849b")
+	if got := signed.Get("Timestamp"); got != "2024-01-01T00:00:00Z" {
+		t.Fatalf("got timestamp %s", got)
+	}
+	wantCanonical := "AccessKeyId=ak&Action=AddCdnDomain&CdnType=web&DomainName=www.example.com&Format=JSON&Remark=a%20b%2Ac~d&Scope=global" +
+		"&SignatureMethod=HMAC-SHA1&SignatureNonce=c4ca4238a0b923820dcc509a6f75849b&SignatureVersion=1.0" +
+		"&Sources=%5B%7B%22content%22%3A%221.1.1.1%22%2C%22type%22%3A%22ipaddr%22%2C%22priority%22%3A%2220%22%2C%22port%22%3A80%2C%22weight%22%3A%2210%22%7D%5D" +
+		"&Timestamp=2024-01-01T00%3A00%3A00Z&Version=2018-05-10"
+	if got := canonicalize(signed); got != wantCanonical {
+		t.Fatalf("got canonical query\n%s\nwant\n%s", got, wantCanonical)
+	}
+	if got, want := signed.Get("Signature"), "YBznYdTIC4g5vISkX9zk+7DPzmA="; got != want {
+		t.Fatalf("got signature %s, want %s", got, want)
+	}
+	if params.Get("Signature") != "" || params.Get("Timestamp") != "" {
+		t.Fatal("sign modified the input params")
+	}
+	//签名只与时间和随机数有关,相同输入结果相同
+	if again := signer.Sign(http.MethodPost, params, ts, "c4ca4238a0b923820dcc509a6f75849b"); again.Get("Signature") != signed.Get("Signature") {
+		t.Fatal("signature is not deterministic")
+	}
+}
+
+func TestPercentEncode(t *testing.T) {
+	for in, want := range map[string]string{
+		"a b":     "a%20b",
+		"a*b":     "a%2Ab",
+		"a~b":     "a~b",
+		"a+b":     "a%2Bb",
+		"/path?x": "%2Fpath%3Fx",
+		"中":       "%E4%B8%AD",
+	} {
+		if got := percentEncode(in); got != want {
+			t.Errorf("percentEncode(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+// TestClientRequestSigned 客户端请求按收到的时间戳与随机数重新签名后应一致
+func TestClientRequestSigned(t *testing.T) {
+	signer := NewSigner("ak", "sk")
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		form := r.PostForm
+		ts, err := time.Parse(TimestampFormat, form.Get("Timestamp"))
+		if err != nil || time.Since(ts) > time.Minute {
+			http.Error(w, `{"Code":"InvalidTimeStamp.Expired"}`, http.StatusBadRequest)
+			return
+		}
+		params := url.Values{}
+		for k, v := range form {
+			switch k {
+			case "AccessKeyId", "SignatureMethod", "SignatureVersion", "SignatureNonce", "Timestamp", "Signature":
+			default:
+				params[k] = v
+			}
+		}
+		want := signer.Sign(r.Method, params, ts, form.Get("SignatureNonce"))
+		if want.Get("Signature") != form.Get("Signature") || form.Get("DomainName") != "www.example.com" || form.Get("Action") != "AddCdnDomain" {
+			http.Error(w, `{"Code":"SignatureDoesNotMatch"}`, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&model.CommonResponse{RequestId: "req-1"})
+	}))
+	defer s.Close()
+	res, err := NewClient(signer, s.URL).AddCdnDomain(context.Background(), &model.AddCdnDomainRequest{
+		CdnType:    "web",
+		DomainName: "www.example.com",
+		Sources:    `[{"content":"1.1.1.1","type":"ipaddr"}]`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequestId != "req-1" {
+		t.Fatalf("got request id %s", res.RequestId)
+	}
+}
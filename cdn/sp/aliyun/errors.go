@@ -0,0 +1,73 @@
+package aliyun
+
+import (
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"strings"
+)
+
+// wrapError 将阿里云接口错误转换为统一错误
+func wrapError(err error) error {
+	var e *common.ServiceResponseError
+	if !errors.As(err, &e) {
+		return err
+	}
+	return &cdnerr.Error{
+		Kind:       getErrorKind(e.ErrorCode, e.StatusCode),
+		Provider:   types.AliyunSdkName,
+		Code:       e.ErrorCode,
+		Message:    e.ErrorMessage,
+		RequestId:  e.RequestId,
+		StatusCode: e.StatusCode,
+		Temporary:  e.StatusCode >= http.StatusInternalServerError,
+		Err:        err,
+	}
+}
+
+// getErrorKind 错误码转错误分类,阿里云鉴权错误码如InvalidAccessKeyId.NotFound包含NotFound,需先于域名不存在判断
+func getErrorKind(code string, statusCode int) error {
+	c := strings.ToLower(code)
+	switch {
+	case strings.Contains(c, "accesskey"), strings.Contains(c, "signature"), strings.Contains(c, "forbidden"), strings.Contains(c, "unauthorized"):
+		return cdnerr.ErrAuthFailed
+	case strings.Contains(c, "domain") && (strings.Contains(c, "notfound") || strings.Contains(c, "notexist")):
+		return cdnerr.ErrDomainNotFound
+	case strings.Contains(c, "domainalreadyexist"), strings.Contains(c, "domainexist"):
+		return cdnerr.ErrDomainAlreadyExists
+	case strings.Contains(c, "icp"), strings.Contains(c, "notregistration"), strings.Contains(c, "beian"):
+		return cdnerr.ErrIcpNotFiled
+	case strings.Contains(c, "throttl"), strings.Contains(c, "flowlimit"):
+		return cdnerr.ErrRateLimited
+	case strings.Contains(c, "quota"), strings.Contains(c, "exceed"):
+		return cdnerr.ErrQuotaExceeded
+	case strings.Contains(c, "invalid"), strings.Contains(c, "missing"), strings.Contains(c, "malformed"):
+		return cdnerr.ErrInvalidParameter
+	}
+	switch statusCode {
+	case http.StatusNotFound:
+		return cdnerr.ErrDomainNotFound
+	case http.StatusConflict:
+		return cdnerr.ErrDomainAlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return cdnerr.ErrAuthFailed
+	case http.StatusTooManyRequests:
+		return cdnerr.ErrRateLimited
+	case http.StatusBadRequest:
+		return cdnerr.ErrInvalidParameter
+	default:
+		return nil
+	}
+}
+
+// newNotFoundError 查询的任务或统计数据不存在
+func newNotFoundError(message string, requestId string) error {
+	return &cdnerr.Error{
+		Kind:      cdnerr.ErrNotFound,
+		Provider:  types.AliyunSdkName,
+		Message:   message,
+		RequestId: requestId,
+	}
+}
@@ -0,0 +1,516 @@
+package aliyun
+
+import (
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	objectTypeFile      = "File"
+	objectTypeDirectory = "Directory"
+	taskTypeFile        = "file"
+	taskTypeDirectory   = "directory"
+	taskTypePreload     = "preload"
+	sourcePriorityMain  = "20"
+	sourcePriorityBack  = "30"
+)
+
+// GetCdnType 业务类型转阿里云业务类型
+func getCdnType(channelType int64) string {
+	switch channelType {
+	case consts.ChannelTypeWeb:
+		return "web"
+	case consts.ChannelTypeDownload:
+		return "download"
+	case consts.ChannelTypeMedia:
+		return "video"
+	default:
+		return "web"
+	}
+}
+
+// MapCdnType 阿里云业务类型转业务类型
+func mapCdnType(cdnType string) int64 {
+	switch cdnType {
+	case "web":
+		return consts.ChannelTypeWeb
+	case "download":
+		return consts.ChannelTypeDownload
+	case "video":
+		return consts.ChannelTypeMedia
+	default:
+		return consts.ChannelTypeWeb
+	}
+}
+
+// GetScope 区域代码转加速区域
+func getScope(area int64) string {
+	switch area {
+	case consts.AreaCodeChinaMainland:
+		return "domestic"
+	case consts.AreaCodeOversea:
+		return "overseas"
+	case consts.AreaCodeGlobal:
+		return "global"
+	default:
+		return "domestic"
+	}
+}
+
+// MapScope 加速区域转区域代码
+func mapScope(scope string) int64 {
+	switch scope {
+	case "domestic":
+		return consts.AreaCodeChinaMainland
+	case "overseas":
+		return consts.AreaCodeOversea
+	case "global":
+		return consts.AreaCodeGlobal
+	default:
+		return consts.AreaCodeChinaMainland
+	}
+}
+
+// GetDomainStatus 获取域名状态
+func getDomainStatus(status string) int64 {
+	switch status {
+	case "online":
+		return consts.CdnDomainStatusDeployed
+	case "offline":
+		return consts.CdnDomainStatusStoped
+	case "configuring", "checking":
+		return consts.CdnDomainStatusDeploying
+	case "configure_failed", "check_failed":
+		return consts.CdnDomainStatusFaild
+	default:
+		return consts.CdnDomainStatusDeploying
+	}
+}
+
+// SetDomainStatus 域名状态转阿里云域名状态
+func setDomainStatus(status int64) string {
+	switch status {
+	case consts.CdnDomainStatusDeployed:
+		return "online"
+	case consts.CdnDomainStatusStoped:
+		return "offline"
+	case consts.CdnDomainStatusDeploying:
+		return "configuring"
+	case consts.CdnDomainStatusFaild:
+		return "configure_failed"
+	default:
+		return ""
+	}
+}
+
+// GetSourceType 获取源站类型
+func getSourceType(originType int64) string {
+	switch originType {
+	case consts.OriginTypeIp:
+		return "ipaddr"
+	case consts.OriginTypeDomain:
+		return "domain"
+	case consts.OriginTypeBucket:
+		return "oss"
+	default:
+		return "ipaddr"
+	}
+}
+
+// GetSourcePriority 获取源站优先级
+func getSourcePriority(priority int64) string {
+	switch priority {
+	case consts.OriginPriorityPrimary:
+		return sourcePriorityMain
+	case consts.OriginPriorityBackup:
+		return sourcePriorityBack
+	default:
+		return sourcePriorityMain
+	}
+}
+
+// GetSourcePort 按回源协议获取源站端口
+func getSourcePort(protocol int64, source *entity.OriginServerConf) int64 {
+	if protocol == consts.OriginProtocolHttps && source.OriginHttpsPort != 0 {
+		return source.OriginHttpsPort
+	}
+	if source.OriginHttpPort != 0 {
+		return source.OriginHttpPort
+	}
+	if source.OriginHttpsPort != 0 {
+		return source.OriginHttpsPort
+	}
+	if protocol == consts.OriginProtocolHttps {
+		return 443
+	}
+	return 80
+}
+
+// GetSources 源站信息转阿里云Sources参数
+func getSources(protocol int64, sources []*entity.OriginServerConf) []*model.Source {
+	items := make([]*model.Source, 0, len(sources))
+	for _, v := range sources {
+		for _, address := range strings.Split(v.OriginAddressList, ",") {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				continue
+			}
+			source := &model.Source{
+				Content:  address,
+				Type:     getSourceType(v.OriginType),
+				Priority: getSourcePriority(v.OriginPriority),
+				Port:     getSourcePort(protocol, v),
+			}
+			if v.OriginWeight > 0 {
+				source.Weight = strconv.FormatInt(v.OriginWeight, 10)
+			}
+			items = append(items, source)
+		}
+	}
+	return items
+}
+
+// GetOriginProtocol 获取回源协议
+func getOriginProtocol(protocol int64) string {
+	switch protocol {
+	case consts.OriginProtocolHttp:
+		return "http"
+	case consts.OriginProtocolHttps:
+		return "https"
+	case consts.OriginProtocolFollow:
+		return "follow"
+	default:
+		return "http"
+	}
+}
+
+// GetSwitch 数字开关转阿里云开关
+func getSwitch(status int64) string {
+	if status == consts.SwitchOn {
+		return consts.ON
+	}
+	return consts.OFF
+}
+
+// GetBoolSwitch 布尔值转阿里云开关
+func getBoolSwitch(b bool) string {
+	if b {
+		return consts.ON
+	}
+	return consts.OFF
+}
+
+// GetHeaderOperationType 获取请求头操作类型
+func getHeaderOperationType(action int64) string {
+	switch action {
+	case consts.OriginHeaderActionDelete:
+		return "delete"
+	case consts.OriginHeaderActionSet:
+		return "modify"
+	case consts.OriginHeaderActionAdd:
+		return "add"
+	default:
+		return "add"
+	}
+}
+
+// GetIpListFunction 获取ip黑白名单配置功能
+func getIpListFunction(t int64) string {
+	switch t {
+	case consts.BlackList:
+		return "ip_black_list_set"
+	case consts.WhiteList:
+		return "ip_allow_list_set"
+	default:
+		return "ip_black_list_set"
+	}
+}
+
+// GetRefererFunction 获取referer黑白名单配置功能与名单参数
+func getRefererFunction(t int64) (string, string) {
+	switch t {
+	case consts.BlackList:
+		return "referer_black_list_set", "refer_domain_deny_list"
+	case consts.WhiteList:
+		return "referer_white_list_set", "refer_domain_allow_list"
+	default:
+		return "referer_black_list_set", "refer_domain_deny_list"
+	}
+}
+
+// GetListType 获取黑白名单类型
+func getListType(t int64) string {
+	switch t {
+	case consts.BlackList:
+		return "black"
+	case consts.WhiteList:
+		return "white"
+	default:
+		return "black"
+	}
+}
+
+// GetAuthType 获取鉴权类型,阿里云TypeD签名方式不同,不支持
+func getAuthType(manner int64) string {
+	switch manner {
+	case consts.AccessAuthMannerTypeA:
+		return "type_a"
+	case consts.AccessAuthMannerTypeB:
+		return "type_b"
+	case consts.AccessAuthMannerTypeC:
+		return "type_c"
+	default:
+		return ""
+	}
+}
+
+// GetRemoteAuthMethod 获取远程鉴权请求方式
+func getRemoteAuthMethod(method int64) string {
+	switch method {
+	case consts.RequestMethodGet:
+		return "get"
+	case consts.RequestMethodPost:
+		return "post"
+	case consts.RequestMethodHead:
+		return "head"
+	default:
+		return "get"
+	}
+}
+
+// GetRemoteAuthTimeoutAction 获取远程鉴权超时动作
+func getRemoteAuthTimeoutAction(action int64) string {
+	switch action {
+	case consts.AccessRemoteAuthTimeOutActionReturn200:
+		return "pass"
+	case consts.AccessRemoteAuthTimeOutActionReturn403:
+		return "reject"
+	default:
+		return "pass"
+	}
+}
+
+// GetCacheFunction 规则类型转阿里云缓存配置功能与规则参数
+func getCacheFunction(t int64, content []string) (string, string, string) {
+	items := make([]string, 0, len(content))
+	switch t {
+	case consts.RuleTypeFileSuffix:
+		for _, v := range content {
+			items = append(items, strings.TrimPrefix(v, "."))
+		}
+		return "filetype_based_ttl_set", "file_type", strings.Join(items, ",")
+	case consts.RuleTypeDirectory:
+		for _, v := range content {
+			items = append(items, "/"+strings.Trim(v, "/")+"/")
+		}
+		return "path_based_ttl_set", "path", strings.Join(items, ",")
+	case consts.RuleTypePath:
+		return "path_based_ttl_set", "path", strings.Join(content, ",")
+	case consts.RuleTypeIndex:
+		return "path_based_ttl_set", "path", "/"
+	default:
+		return "path_based_ttl_set", "path", "/"
+	}
+}
+
+// GetRedirectFlag 获取url重写跳转方式,阿里云重写跳转为302,不支持301
+func getRedirectFlag(code int64) string {
+	switch code {
+	case consts.RedirectCode302:
+		return "redirect"
+	default:
+		return ""
+	}
+}
+
+// GetCompressFunction 获取智能压缩配置功能
+func getCompressFunction(method int64) string {
+	switch method {
+	case consts.IntelligentCompressionCompressMethodGzip:
+		return "gzip"
+	case consts.IntelligentCompressionCompressMethodBrotli:
+		return "brotli"
+	default:
+		return "gzip"
+	}
+}
+
+// GetTlsVersionArg 获取TLS版本参数名
+func getTlsVersionArg(version int64) string {
+	switch version {
+	case consts.HttpsTlsVersionSSLv0:
+		return "tls10"
+	case consts.HttpsTlsVersionSSLv1:
+		return "tls11"
+	case consts.HttpsTlsVersionSSLv2:
+		return "tls12"
+	case consts.HttpsTlsVersionTLSv3:
+		return "tls13"
+	default:
+		return ""
+	}
+}
+
+// GetForceFunction 获取强制跳转配置功能
+func getForceFunction(jumpType int64) string {
+	switch jumpType {
+	case consts.HttpsJumpTypeHttp:
+		return "http_force"
+	case consts.HttpsJumpTypeHttps:
+		return "https_force"
+	default:
+		return "https_force"
+	}
+}
+
+// GetShowContentPurgeOrPushStatus 获取刷新或预热任务状态
+func getShowContentPurgeOrPushStatus(status string) int64 {
+	switch status {
+	case "Complete":
+		return consts.ShowContentPurgeOrPushStatusSuccess
+	case "Refreshing", "Pending":
+		return consts.ShowContentPurgeOrPushStatusDoing
+	case "Failed":
+		return consts.ShowContentPurgeOrPushStatusFail
+	default:
+		return consts.ShowContentPurgeOrPushStatusFail
+	}
+}
+
+// SetShowContentPurgeOrPushStatus 任务状态转阿里云任务状态
+func setShowContentPurgeOrPushStatus(status int64) string {
+	switch status {
+	case consts.ShowContentPurgeOrPushStatusSuccess:
+		return "Complete"
+	case consts.ShowContentPurgeOrPushStatusDoing:
+		return "Refreshing"
+	case consts.ShowContentPurgeOrPushStatusFail:
+		return "Failed"
+	default:
+		return ""
+	}
+}
+
+// GetTaskType 获取刷新任务类型
+func getTaskType(t int64) string {
+	switch t {
+	case consts.ShowContentPurgeTypeUrl:
+		return taskTypeFile
+	case consts.ShowContentPurgeTypePath:
+		return taskTypeDirectory
+	default:
+		return taskTypeFile
+	}
+}
+
+// GetInterval 获取统计粒度,单位秒
+func getInterval(t int64) string {
+	switch t {
+	case consts.DataIntervalTypeFiveMinute:
+		return "300"
+	case consts.DataIntervalTypeHour:
+		return "3600"
+	case consts.DataIntervalTypeDay:
+		return "86400"
+	default:
+		return "300"
+	}
+}
+
+// GetDataAccessMetricAction 获取访问数据指标对应的统计接口,不支持的指标返回空
+func getDataAccessMetricAction(t int64) string {
+	switch t {
+	case consts.DataAccessMetricTypeFlux:
+		return "DescribeDomainTrafficData"
+	case consts.DataAccessMetricTypeBandwidth:
+		return "DescribeDomainBpsData"
+	case consts.DataAccessMetricTypeRequest:
+		return "DescribeDomainQpsData"
+	default:
+		return ""
+	}
+}
+
+// GetDataOriginMetricAction 获取回源数据指标对应的统计接口,不支持的指标返回空
+func getDataOriginMetricAction(t int64) string {
+	switch t {
+	case consts.DataOriginMetricTypeFlux:
+		return "DescribeDomainSrcTrafficData"
+	case consts.DataOriginMetricTypeBandwidth:
+		return "DescribeDomainSrcBpsData"
+	default:
+		return ""
+	}
+}
+
+// GetStatisticsValue 按统计接口与区域获取指标值,请求数取各时间段的请求总数,回源数据不区分区域
+func getStatisticsValue(action string, area int64, data *model.DataModule) float64 {
+	switch action {
+	case "DescribeDomainSrcTrafficData", "DescribeDomainSrcBpsData":
+		return float64(data.Value)
+	case "DescribeDomainQpsData":
+		if area == consts.AreaCodeOversea {
+			return float64(data.AccOverseasValue)
+		}
+		return float64(data.AccDomesticValue)
+	default:
+		if area == consts.AreaCodeOversea {
+			return float64(data.OverseasValue)
+		}
+		return float64(data.DomesticValue)
+	}
+}
+
+// GetTopUrlSortBy 获取TOP URL排序方式
+func getTopUrlSortBy(filter int64) string {
+	if filter == consts.ListTopFilterRequest {
+		return "pv"
+	}
+	return "traf"
+}
+
+// GetTopUrlValue 按排序条件获取TOP URL的值
+func getTopUrlValue(filter int64, data *model.UrlList) float64 {
+	if filter == consts.ListTopFilterRequest {
+		return float64(data.VisitData)
+	}
+	return float64(data.Flow)
+}
+
+// GetVerifyType 获取域名归属校验方式
+func getVerifyType(verifyType string) string {
+	if verifyType == "file" {
+		return "fileCheck"
+	}
+	return "dnsCheck"
+}
+
+// GetCacheCodeTtl 拼接状态码缓存时间,如404=10,5xx=3
+func getCacheCodeTtl(items []*entity.CacheCodeListItem) string {
+	codes := make([]string, 0, len(items))
+	for _, v := range items {
+		codes = append(codes, fmt.Sprintf("%d=%d", v.HttpCode, cachettl.Seconds(v.CacheTTL, v.CacheUnit)))
+	}
+	return strings.Join(codes, ",")
+}
+
+// FormatTime 时间戳格式化为阿里云UTC时间
+func formatTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(common.TimestampFormat)
+}
+
+// ParseTime 阿里云时间转时间戳
+func parseTime(t string) int64 {
+	tm, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return 0
+	}
+	return tm.Unix()
+}
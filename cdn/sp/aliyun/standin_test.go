@@ -0,0 +1,352 @@
+package aliyun
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/aliyun/common/model"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// standIn 阿里云接口的本地替身,校验请求签名并在内存中保存域名配置
+type standIn struct {
+	*httptest.Server
+	signer   *common.Signer
+	mu       sync.Mutex
+	domains  map[string]*domainState
+	calls    []string
+	tasks    map[string][]*model.Task
+	configId int
+	// faults 指定接口返回的错误码与状态码
+	faults map[string]fault
+}
+
+type fault struct {
+	status int
+	code   string
+}
+
+// domainState 域名在阿里云侧的各项配置
+type domainState struct {
+	detail  model.DomainDetail
+	sources []*model.Source
+	configs []*model.DomainConfig
+	cert    *model.SetCdnDomainSSLCertificateRequest
+}
+
+func newStandIn(t *testing.T) (*standIn, *Aliyun) {
+	t.Helper()
+	s := &standIn{
+		signer:  common.NewSigner("ak", "sk"),
+		domains: make(map[string]*domainState),
+		tasks:   make(map[string][]*model.Task),
+		faults:  make(map[string]fault),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	a, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, a
+}
+
+// addDomain 添加线上域名
+func (s *standIn) addDomain(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.create(&model.AddCdnDomainRequest{DomainName: name, CdnType: "web", Scope: "domestic"}, "online")
+}
+
+func (s *standIn) create(req *model.AddCdnDomainRequest, status string) {
+	d := &domainState{
+		detail: model.DomainDetail{
+			DomainName:   req.DomainName,
+			Cname:        req.DomainName + ".w.kunlunsl.com",
+			CdnType:      req.CdnType,
+			DomainStatus: status,
+			Scope:        req.Scope,
+			GmtCreated:   "2024-01-01T00:00:00Z",
+			GmtModified:  "2024-01-01T00:00:00Z",
+		},
+	}
+	_ = json.Unmarshal([]byte(req.Sources), &d.sources)
+	s.domains[req.DomainName] = d
+}
+
+// domain 获取域名配置
+func (s *standIn) domain(name string) *domainState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.domains[name]
+}
+
+// functions 获取域名指定配置功能的参数,每条配置的参数以参数名为键
+func (s *standIn) functions(domain string, name string) []map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]map[string]string, 0)
+	for _, v := range s.domains[domain].configs {
+		if v.FunctionName != name {
+			continue
+		}
+		args := make(map[string]string)
+		for _, arg := range v.FunctionArgs.FunctionArg {
+			args[arg.ArgName] = arg.ArgValue
+		}
+		list = append(list, args)
+	}
+	return list
+}
+
+func (s *standIn) callList() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func (s *standIn) fail(action string, status int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[action] = fault{status: status, code: code}
+}
+
+// verify 按请求中的时间戳与随机数重新签名
+func (s *standIn) verify(r *http.Request) bool {
+	form := r.PostForm
+	ts, err := time.Parse(common.TimestampFormat, form.Get("Timestamp"))
+	if err != nil || time.Since(ts) > 5*time.Minute || form.Get("AccessKeyId") != "ak" {
+		return false
+	}
+	params := url.Values{}
+	for k, v := range form {
+		switch k {
+		case "AccessKeyId", "SignatureMethod", "SignatureVersion", "SignatureNonce", "Timestamp", "Signature":
+		default:
+			params[k] = v
+		}
+	}
+	return s.signer.Sign(r.Method, params, ts, form.Get("SignatureNonce")).Get("Signature") == form.Get("Signature")
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := r.ParseForm(); err != nil {
+		fail(w, http.StatusBadRequest, "MalformedRequest")
+		return
+	}
+	form := r.PostForm
+	action := form.Get("Action")
+	s.calls = append(s.calls, action)
+	if !s.verify(r) {
+		fail(w, http.StatusBadRequest, "SignatureDoesNotMatch")
+		return
+	}
+	if f, ok := s.faults[action]; ok {
+		fail(w, f.status, f.code)
+		return
+	}
+	switch action {
+	case "AddCdnDomain":
+		if _, ok := s.domains[form.Get("DomainName")]; ok {
+			fail(w, http.StatusBadRequest, "DomainAlreadyExist")
+			return
+		}
+		s.create(&model.AddCdnDomainRequest{
+			CdnType:    form.Get("CdnType"),
+			DomainName: form.Get("DomainName"),
+			Sources:    form.Get("Sources"),
+			Scope:      form.Get("Scope"),
+		}, "configuring")
+		reply(w, &model.CommonResponse{RequestId: "req-1"})
+	case "DescribeUserDomains":
+		reply(w, s.listDomains(form))
+	case "RefreshObjectCaches", "PushObjectCache":
+		taskType := strings.ToLower(form.Get("ObjectType"))
+		if action == "PushObjectCache" {
+			taskType = taskTypePreload
+		}
+		id := strconv.Itoa(len(s.tasks) + 1)
+		for _, v := range strings.Split(form.Get("ObjectPath"), "\n") {
+			s.tasks[id] = append(s.tasks[id], &model.Task{TaskId: id, ObjectPath: v, ObjectType: taskType, Status: "Complete"})
+		}
+		if action == "PushObjectCache" {
+			reply(w, &model.PushObjectCacheResponse{RequestId: "req-1", PushTaskId: id})
+			return
+		}
+		reply(w, &model.RefreshObjectCachesResponse{RequestId: "req-1", RefreshTaskId: id})
+	case "DescribeRefreshTaskById":
+		tasks := s.tasks[form.Get("TaskId")]
+		reply(w, &model.DescribeRefreshTaskByIdResponse{RequestId: "req-1", TotalCount: int64(len(tasks)), Tasks: tasks})
+	case "DescribeDomainTrafficData", "DescribeDomainBpsData", "DescribeDomainQpsData", "DescribeDomainSrcTrafficData", "DescribeDomainSrcBpsData":
+		reply(w, statistics(action, form))
+	default:
+		s.config(w, action, form)
+	}
+}
+
+// config 域名配置类接口,均以DomainName或DomainNames指定域名
+func (s *standIn) config(w http.ResponseWriter, action string, form url.Values) {
+	name := form.Get("DomainName")
+	if action == "BatchSetCdnDomainConfig" {
+		name = form.Get("DomainNames")
+	}
+	d, ok := s.domains[name]
+	if !ok {
+		fail(w, http.StatusNotFound, "InvalidDomain.NotFound")
+		return
+	}
+	switch action {
+	case "DescribeCdnDomainDetail":
+		detail := d.detail
+		reply(w, &model.DescribeCdnDomainDetailResponse{RequestId: "req-1", GetDomainDetailModel: &detail})
+		return
+	case "ModifyCdnDomainSchdmByProperty":
+		property := map[string]string{}
+		_ = json.Unmarshal([]byte(form.Get("Property")), &property)
+		d.detail.Scope = property["coverage"]
+	case "ModifyCdnDomain":
+		d.sources = nil
+		_ = json.Unmarshal([]byte(form.Get("Sources")), &d.sources)
+	case "StartCdnDomain":
+		d.detail.DomainStatus = "online"
+	case "StopCdnDomain":
+		d.detail.DomainStatus = "offline"
+	case "DeleteCdnDomain":
+		delete(s.domains, name)
+	case "SetCdnDomainSSLCertificate":
+		d.cert = &model.SetCdnDomainSSLCertificateRequest{
+			DomainName:  name,
+			SSLProtocol: form.Get("SSLProtocol"),
+			CertName:    form.Get("CertName"),
+			CertType:    form.Get("CertType"),
+			SSLPub:      form.Get("SSLPub"),
+			SSLPri:      form.Get("SSLPri"),
+		}
+	case "BatchSetCdnDomainConfig":
+		functions := make([]*model.DomainFunction, 0)
+		if err := json.Unmarshal([]byte(form.Get("Functions")), &functions); err != nil {
+			fail(w, http.StatusBadRequest, "InvalidFunctions.Malformed")
+			return
+		}
+		for _, f := range functions {
+			s.configId++
+			config := &model.DomainConfig{ConfigId: strconv.Itoa(s.configId), FunctionName: f.FunctionName, Status: "success"}
+			for _, arg := range f.FunctionArgs {
+				config.FunctionArgs.FunctionArg = append(config.FunctionArgs.FunctionArg, &struct {
+					ArgName  string `json:"ArgName"`
+					ArgValue string `json:"ArgValue"`
+				}{ArgName: arg.ArgName, ArgValue: arg.ArgValue})
+			}
+			d.configs = append(d.configs, config)
+		}
+	case "DescribeCdnDomainConfigs":
+		names := strings.Split(form.Get("FunctionNames"), ",")
+		res := &model.DescribeCdnDomainConfigsResponse{RequestId: "req-1"}
+		for _, v := range d.configs {
+			for _, n := range names {
+				if v.FunctionName == n {
+					res.DomainConfigs.DomainConfig = append(res.DomainConfigs.DomainConfig, v)
+				}
+			}
+		}
+		reply(w, res)
+		return
+	case "DeleteSpecificConfig":
+		ids := strings.Split(form.Get("ConfigId"), ",")
+		configs := d.configs[:0]
+		for _, v := range d.configs {
+			deleted := false
+			for _, id := range ids {
+				deleted = deleted || v.ConfigId == id
+			}
+			if !deleted {
+				configs = append(configs, v)
+			}
+		}
+		d.configs = configs
+	default:
+		fail(w, http.StatusBadRequest, "InvalidAction.NotFound")
+		return
+	}
+	reply(w, &model.CommonResponse{RequestId: "req-1"})
+}
+
+func (s *standIn) listDomains(form url.Values) *model.DescribeUserDomainsResponse {
+	all := make([]*model.PageData, 0, len(s.domains))
+	for _, v := range s.domains {
+		if status := form.Get("DomainStatus"); status != "" && v.detail.DomainStatus != status {
+			continue
+		}
+		all = append(all, &model.PageData{
+			DomainName:   v.detail.DomainName,
+			Cname:        v.detail.Cname,
+			CdnType:      v.detail.CdnType,
+			DomainStatus: v.detail.DomainStatus,
+			Coverage:     v.detail.Scope,
+		})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].DomainName < all[j].DomainName })
+	page, _ := strconv.ParseInt(form.Get("PageNumber"), 10, 64)
+	size, _ := strconv.ParseInt(form.Get("PageSize"), 10, 64)
+	res := &model.DescribeUserDomainsResponse{RequestId: "req-1", PageNumber: page, PageSize: size, TotalCount: int64(len(all))}
+	start, end := (page-1)*size, page*size
+	if start < int64(len(all)) {
+		if end > int64(len(all)) {
+			end = int64(len(all))
+		}
+		res.Domains.PageData = all[start:end]
+	}
+	return res
+}
+
+// statistics 在开始与结束时间各返回一个数据点,境内值为100与200,境外值为10与20,
+// 请求数境内为1与2、境外为3与4,回源数据为1000与3000
+func statistics(action string, form url.Values) *model.DomainDataResponse {
+	data := &model.DataPerInterval{}
+	for i, t := range []string{form.Get("StartTime"), form.Get("EndTime")} {
+		n := model.Number(i + 1)
+		data.DataModule = append(data.DataModule, &model.DataModule{
+			TimeStamp:        t,
+			Value:            1000 + 2000*model.Number(i),
+			DomesticValue:    100 * n,
+			OverseasValue:    10 * n,
+			AccDomesticValue: n,
+			AccOverseasValue: 2 + n,
+		})
+	}
+	res := &model.DomainDataResponse{RequestId: "req-1", DomainName: form.Get("DomainName"), DataInterval: form.Get("Interval")}
+	switch action {
+	case "DescribeDomainTrafficData":
+		res.TrafficDataPerInterval = data
+	case "DescribeDomainBpsData":
+		res.BpsDataPerInterval = data
+	case "DescribeDomainQpsData":
+		res.QpsDataPerInterval = data
+	case "DescribeDomainSrcTrafficData":
+		res.SrcTrafficDataPerInterval = data
+	case "DescribeDomainSrcBpsData":
+		res.SrcBpsDataPerInterval = data
+	}
+	return res
+}
+
+func fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"RequestId": "req-1", "Code": code, "Message": code})
+}
+
+func reply(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,1214 @@
+package baidu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"log/slog"
+	"sort"
+	"strconv"
+)
+
+// topUrlLimit TOP URL查询条数
+const topUrlLimit = 100
+
+type Baidu struct {
+	ctx     context.Context
+	config  *Config
+	limiter *ratelimit.Limiter
+	client  *common.Client
+}
+
+type Config struct {
+	Ak        string
+	Sk        string
+	Endpoint  string              // 接口地址,默认https://cdn.baidubce.com
+	Retry     *retry.Policy       // 重试策略,为空时不重试
+	RateLimit *ratelimit.Limiter  // 限流器,每次调用厂商接口(含重试)前获取令牌,为空时使用ratelimit.DefaultLimits,不限制时传入ratelimit.Unlimited()
+	Http      *httpclient.Options // HTTP客户端选项,为空时使用默认客户端,超时为httpclient.DefaultTimeout
+	Logger    *slog.Logger        // 记录HTTP请求的日志,为空时不记录
+}
+
+// NewBaiduSdkClient 创建百度云CDN客户端,创建失败时返回nil,需要错误信息时使用 New
+func NewBaiduSdkClient(ctx context.Context, conf *Config) *Baidu {
+	client, err := New(ctx, conf)
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// New 创建百度云CDN客户端
+func New(ctx context.Context, conf *Config) (*Baidu, error) {
+	if conf == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.BaiduSdkName, "config is nil")
+	}
+	if conf.Ak == "" || conf.Sk == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.BaiduSdkName, "ak or sk is empty")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	httpClient, err := conf.Http.NewClient()
+	if err != nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.BaiduSdkName, "invalid http options: "+err.Error())
+	}
+	httpClient.Transport = cdnlog.NewTransport(conf.Logger, httpClient.Transport)
+	return &Baidu{
+		ctx:     ctx,
+		config:  conf,
+		limiter: ratelimit.OrDefault(conf.RateLimit, types.BaiduSdkName),
+		client:  common.NewClient(common.NewSigner(conf.Ak, conf.Sk), conf.Endpoint).WithHttpClient(httpClient),
+	}, nil
+}
+
+func (b *Baidu) GetSdkName() string {
+	return types.BaiduSdkName
+}
+
+// invoke 调用厂商接口,每次尝试前按class获取限流令牌,转换错误并按重试策略重试
+func invoke[T any](ctx context.Context, b *Baidu, class ratelimit.Class, idempotent bool, fn func() (T, error)) (T, error) {
+	return retry.Do(ctx, b.config.Retry, idempotent, func() (T, error) {
+		if err := b.limiter.Wait(ctx, class); err != nil {
+			var zero T
+			return zero, err
+		}
+		res, err := fn()
+		return res, wrapError(err)
+	})
+}
+
+// getDomainName 百度云以域名作为域名ID,未传入域名时使用域名ID
+func getDomainName(domain string, domainId string) string {
+	if domain != "" {
+		return domain
+	}
+	return domainId
+}
+
+// listDomains 按游标获取指定状态的全部域名
+func (b *Baidu) listDomains(ctx context.Context, status string) ([]*model.UserDomain, error) {
+	return rest.Markers(ctx, func(marker string) ([]*model.UserDomain, string, error) {
+		res, err := invoke(ctx, b, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ListUserDomainsResponse, error) {
+			return b.client.ListUserDomains(ctx, &model.ListUserDomainsRequest{Status: status, Marker: marker})
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if !res.IsTruncated {
+			return res.Domains, "", nil
+		}
+		return res.Domains, res.NextMarker, nil
+	})
+}
+
+// getPrimaryHost 获取主源站的回源host
+func getPrimaryHost(sources []*entity.OriginServerConf) string {
+	for _, v := range sources {
+		if v.OriginPriority == consts.OriginPriorityPrimary && v.OriginHost != "" {
+			return v.OriginHost
+		}
+	}
+	return ""
+}
+
+// CreateDomain 创建域名
+func (b *Baidu) CreateDomain(data *types.CreateDomainRequest) error {
+	return b.CreateDomainWithContext(b.ctx, data)
+}
+
+// CreateDomainWithContext 创建域名
+func (b *Baidu) CreateDomainWithContext(ctx context.Context, data *types.CreateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	if data.AreaCode != consts.AreaCodeChinaMainland {
+		return cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("area %d not support", data.AreaCode))
+	}
+	_, err := invoke(ctx, b, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.CreateDomainResponse, error) {
+		return b.client.CreateDomain(ctx, data.Domain, &model.CreateDomainRequest{
+			Origin:      getOrigins(data.OriginProtocol, data.Sources),
+			Form:        getForm(data.ChannelType),
+			DefaultHost: getPrimaryHost(data.Sources),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	//回源协议需在域名创建后单独配置
+	if data.OriginProtocol == consts.OriginProtocolHttp {
+		return nil
+	}
+	updateDomain := b.newUpdateDomainConfigModel(&types.UpdateDomainRequest{Domain: data.Domain})
+	updateDomain.withOriginProtocol(data.OriginProtocol)
+	return updateDomain.send(ctx)
+}
+
+// UpdateDomain 更新域名
+func (b *Baidu) UpdateDomain(data *types.UpdateDomainRequest) error {
+	return b.UpdateDomainWithContext(b.ctx, data)
+}
+
+// UpdateDomainWithContext 更新域名
+func (b *Baidu) UpdateDomainWithContext(ctx context.Context, data *types.UpdateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	updateDomain := b.newUpdateDomainConfigModel(data)
+	switch data.UpdateAction {
+	case types.UpdateBaseConf:
+		updateDomain.WithBaseConf()
+	case types.UpdateArea:
+		updateDomain.WithArea()
+	case types.UpdateOriginConf:
+		updateDomain.WithOriginConf()
+	case types.UpdateOriginServerConf:
+		updateDomain.WithOriginServerConf()
+	case types.UpdateOriginRequestHeaderConf:
+		updateDomain.WithOriginRequestHeaderConf()
+	case types.UpdateIpFilterConf:
+		updateDomain.WithIpFilterConf()
+	case types.UpdateIpFrequencyConf:
+		updateDomain.WithIpFrequencyConf()
+	case types.UpdateRefererConf:
+		updateDomain.WithRefererConf()
+	case types.UpdateUserAgentConf:
+		updateDomain.WithUserAgentConf()
+	case types.UpdateSpeedConf:
+		updateDomain.WithSpeedConf()
+	case types.UpdateAuthConf:
+		updateDomain.WithAuthConf()
+	case types.UpdateCacheListConf:
+		updateDomain.WithCacheListConf()
+	case types.UpdateCacheCodeConf:
+		updateDomain.WithCacheCodeConf()
+	case types.UpdateCustomErrorPageConf:
+		updateDomain.WithCustomErrorPageConf()
+	case types.UpdateIntelligentCompressionConf:
+		updateDomain.WithIntelligentCompressionConf()
+	case types.UpdateResponseHeaderConf:
+		updateDomain.WithResponseHeaderConf()
+	case types.UpdateHttpsConf:
+		updateDomain.WithHttpsConf()
+	case types.UpdateRecommendConf:
+		updateDomain.WithOriginConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithHttpsConf()
+		updateDomain.WithIntelligentCompressionConf()
+	case types.UpdateFullConf:
+		updateDomain.WithBaseConf()
+		updateDomain.WithOriginConf()
+		updateDomain.WithOriginServerConf()
+		updateDomain.WithOriginRequestHeaderConf()
+		updateDomain.WithIpFilterConf()
+		updateDomain.WithIpFrequencyConf()
+		updateDomain.WithRefererConf()
+		updateDomain.WithUserAgentConf()
+		updateDomain.WithSpeedConf()
+		updateDomain.WithAuthConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithCacheCodeConf()
+		updateDomain.WithCustomErrorPageConf()
+		updateDomain.WithIntelligentCompressionConf()
+		updateDomain.WithResponseHeaderConf()
+		updateDomain.WithHttpsConf()
+	default:
+		//回源高级配置、回源url改写、远程鉴权、浏览器缓存与访问url重写百度云没有对应的配置项
+		return cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("update action %s not support", data.UpdateAction))
+	}
+	return updateDomain.send(ctx)
+}
+
+// DisableDomain 停用域名
+func (b *Baidu) DisableDomain(data *types.DisableDomainRequest) error {
+	return b.DisableDomainWithContext(b.ctx, data)
+}
+
+// DisableDomainWithContext 停用域名
+func (b *Baidu) DisableDomainWithContext(ctx context.Context, data *types.DisableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, b, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return b.client.DisableDomain(ctx, getDomainName(data.Domain, data.DomainId))
+	})
+	return err
+}
+
+// EnableDomain 启用域名
+func (b *Baidu) EnableDomain(data *types.EnableDomainRequest) error {
+	return b.EnableDomainWithContext(b.ctx, data)
+}
+
+// EnableDomainWithContext 启用域名
+func (b *Baidu) EnableDomainWithContext(ctx context.Context, data *types.EnableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, b, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return b.client.EnableDomain(ctx, getDomainName(data.Domain, data.DomainId))
+	})
+	return err
+}
+
+// DeleteDomain 删除域名
+func (b *Baidu) DeleteDomain(data *types.DeleteDomainRequest) error {
+	return b.DeleteDomainWithContext(b.ctx, data)
+}
+
+// DeleteDomainWithContext 删除域名,域名不存在时视为删除成功
+func (b *Baidu) DeleteDomainWithContext(ctx context.Context, data *types.DeleteDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, b, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return b.client.DeleteDomain(ctx, getDomainName(data.Domain, data.DomainId))
+	})
+	if errors.Is(err, cdnerr.ErrDomainNotFound) {
+		return nil
+	}
+	return err
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (b *Baidu) CreateVerifyRecord(data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return b.CreateVerifyRecordWithContext(b.ctx, data)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录,百度云没有域名归属校验接口
+func (b *Baidu) CreateVerifyRecordWithContext(ctx context.Context, data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, "create verify record not support")
+}
+
+// VerifyDomainRecord 验证域名解析记录
+func (b *Baidu) VerifyDomainRecord(data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return b.VerifyDomainRecordWithContext(b.ctx, data)
+}
+
+// VerifyDomainRecordWithContext 验证域名解析记录,百度云没有域名归属校验接口
+func (b *Baidu) VerifyDomainRecordWithContext(ctx context.Context, data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, "verify domain record not support")
+}
+
+// ShowDomainDetail 获取域名详情
+func (b *Baidu) ShowDomainDetail(data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return b.ShowDomainDetailWithContext(b.ctx, data)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (b *Baidu) ShowDomainDetailWithContext(ctx context.Context, data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	domain := getDomainName(data.Domain, data.DomainId)
+	res, err := invoke(ctx, b, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DomainConfig, error) {
+		return b.client.GetDomainConfig(ctx, domain)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowDomainDetailResponse{
+		DomainId:    domain,
+		Domain:      domain,
+		Cname:       res.Cname,
+		ChannelType: mapForm(res.Form),
+		AreaCode:    consts.AreaCodeChinaMainland,
+		Status:      getDomainStatus(res.Status),
+		CreateTime:  parseTime(res.CreateTime),
+		UpdateTime:  parseTime(res.LastModifyTime),
+	}, nil
+}
+
+// ShowDomainStatusList 展示域名状态列表
+func (b *Baidu) ShowDomainStatusList(data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return b.ShowDomainStatusListWithContext(b.ctx, data)
+}
+
+// ShowDomainStatusListWithContext 展示域名状态列表,百度云按游标分页,拉取全部后分页
+func (b *Baidu) ShowDomainStatusListWithContext(ctx context.Context, data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status := setDomainStatus(data.Status)
+	if status == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.BaiduSdkName, fmt.Sprintf("domain status %d not support", data.Status))
+	}
+	all, err := b.listDomains(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	domains := make([]string, 0, len(all))
+	for _, v := range all {
+		domains = append(domains, v.Domain)
+	}
+	total, list := rest.Slice(domains, data.Page, data.Limit)
+	return &types.ShowDomainStatusListResponse{Total: total, List: list}, nil
+}
+
+// PurgePathCache 刷新目录缓存
+func (b *Baidu) PurgePathCache(data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return b.PurgePathCacheWithContext(b.ctx, data)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存,百度云目录刷新不区分刷新模式
+func (b *Baidu) PurgePathCacheWithContext(ctx context.Context, data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	return b.purge(ctx, purgeTypeDirectory, data.Paths)
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (b *Baidu) PurgeUrlsCache(data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return b.PurgeUrlsCacheWithContext(b.ctx, data)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (b *Baidu) PurgeUrlsCacheWithContext(ctx context.Context, data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	return b.purge(ctx, purgeTypeFile, data.Urls)
+}
+
+func (b *Baidu) purge(ctx context.Context, purgeType string, urls []string) (*types.PurgeCacheResponse, error) {
+	tasks := make([]*model.PurgeTask, 0, len(urls))
+	for _, v := range urls {
+		tasks = append(tasks, &model.PurgeTask{Url: v, Type: purgeType})
+	}
+	res, err := invoke(ctx, b, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.CacheResponse, error) {
+		return b.client.Purge(ctx, &model.CacheRequest{Tasks: tasks})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: res.Id}, nil
+}
+
+// PushUrlsCache 预热URL缓存
+func (b *Baidu) PushUrlsCache(data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return b.PushUrlsCacheWithContext(b.ctx, data)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (b *Baidu) PushUrlsCacheWithContext(ctx context.Context, data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	tasks := make([]*model.PurgeTask, 0, len(data.Urls))
+	for _, v := range data.Urls {
+		tasks = append(tasks, &model.PurgeTask{Url: v})
+	}
+	res, err := invoke(ctx, b, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.CacheResponse, error) {
+		return b.client.Prefetch(ctx, &model.CacheRequest{Tasks: tasks})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PushUrlsCacheResponse{TaskId: res.Id}, nil
+}
+
+// ShowPurgeTaskStatus 展示刷新任务状态
+func (b *Baidu) ShowPurgeTaskStatus(data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return b.ShowPurgeTaskStatusWithContext(b.ctx, data)
+}
+
+// ShowPurgeTaskStatusWithContext 展示刷新任务状态
+func (b *Baidu) ShowPurgeTaskStatusWithContext(ctx context.Context, data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	details, err := b.listCacheRecords(ctx, b.client.GetPurgedStatus, &model.CacheRecordsRequest{Id: data.TaskId})
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, newNotFoundError("task not found", "")
+	}
+	return &types.ShowPurgeTaskStatusResponse{TaskId: data.TaskId, Status: mergeTaskStatus(details)}, nil
+}
+
+// ShowPushTaskStatus 展示预热任务状态
+func (b *Baidu) ShowPushTaskStatus(data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return b.ShowPushTaskStatusWithContext(b.ctx, data)
+}
+
+// ShowPushTaskStatusWithContext 展示预热任务状态
+func (b *Baidu) ShowPushTaskStatusWithContext(ctx context.Context, data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	details, err := b.listCacheRecords(ctx, b.client.GetPrefetchStatus, &model.CacheRecordsRequest{Id: data.TaskId})
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, newNotFoundError("task not found", "")
+	}
+	return &types.ShowPushTaskStatusResponse{TaskId: data.TaskId, Status: mergeTaskStatus(details)}, nil
+}
+
+// listCacheRecords 按游标获取全部刷新或预热记录
+func (b *Baidu) listCacheRecords(ctx context.Context, query func(context.Context, *model.CacheRecordsRequest) (*model.CacheRecordsResponse, error), req *model.CacheRecordsRequest) ([]*model.CacheDetail, error) {
+	return rest.Markers(ctx, func(marker string) ([]*model.CacheDetail, string, error) {
+		r := *req
+		r.Marker = marker
+		res, err := invoke(ctx, b, ratelimit.ClassQuery, retry.Idempotent, func() (*model.CacheRecordsResponse, error) {
+			return query(ctx, &r)
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if !res.IsTruncated {
+			return res.Details, "", nil
+		}
+		return res.Details, res.NextMarker, nil
+	})
+}
+
+// mergeTaskStatus 汇总任务下所有url的状态,存在进行中则为进行中,存在失败则为失败
+func mergeTaskStatus(details []*model.CacheDetail) int64 {
+	taskStatus := int64(consts.ShowContentPurgeOrPushStatusSuccess)
+	for _, v := range details {
+		status := getShowContentPurgeOrPushStatus(v.Status)
+		if status == consts.ShowContentPurgeOrPushStatusDoing {
+			return status
+		}
+		if status == consts.ShowContentPurgeOrPushStatusFail {
+			taskStatus = status
+		}
+	}
+	return taskStatus
+}
+
+// ShowPurgeTaskList 展示刷新任务列表
+func (b *Baidu) ShowPurgeTaskList(data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return b.ShowPurgeTaskListWithContext(b.ctx, data)
+}
+
+// ShowPurgeTaskListWithContext 展示刷新任务列表
+func (b *Baidu) ShowPurgeTaskListWithContext(ctx context.Context, data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	details, err := b.listCacheRecords(ctx, b.client.GetPurgedStatus, &model.CacheRecordsRequest{
+		StartTime: formatTime(data.StartTime),
+		EndTime:   formatTime(data.EndTime),
+	})
+	if err != nil {
+		return nil, err
+	}
+	purgeType := getPurgeType(data.PurgeType)
+	filtered := make([]*model.CacheDetail, 0, len(details))
+	for _, v := range details {
+		if v.Task != nil && v.Task.Type == purgeType {
+			filtered = append(filtered, v)
+		}
+	}
+	total, list := rest.Slice(getTaskIds(filtered, data.TaskStatus), data.Page, data.Limit)
+	return &types.ShowPurgeTaskListResponse{Total: total, List: list}, nil
+}
+
+// ShowPushTaskList 展示预热任务列表
+func (b *Baidu) ShowPushTaskList(data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return b.ShowPushTaskListWithContext(b.ctx, data)
+}
+
+// ShowPushTaskListWithContext 展示预热任务列表
+func (b *Baidu) ShowPushTaskListWithContext(ctx context.Context, data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	details, err := b.listCacheRecords(ctx, b.client.GetPrefetchStatus, &model.CacheRecordsRequest{
+		StartTime: formatTime(data.StartTime),
+		EndTime:   formatTime(data.EndTime),
+	})
+	if err != nil {
+		return nil, err
+	}
+	total, list := rest.Slice(getTaskIds(details, data.TaskStatus), data.Page, data.Limit)
+	return &types.ShowPushTaskListResponse{Total: total, List: list}, nil
+}
+
+// getTaskIds 按任务ID汇总各url的状态,返回指定状态的任务ID,百度云不支持按状态筛选
+func getTaskIds(details []*model.CacheDetail, taskStatus int64) []string {
+	ids := make([]string, 0)
+	tasks := make(map[string][]*model.CacheDetail)
+	for _, v := range details {
+		if _, ok := tasks[v.Id]; !ok {
+			ids = append(ids, v.Id)
+		}
+		tasks[v.Id] = append(tasks[v.Id], v)
+	}
+	list := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if mergeTaskStatus(tasks[id]) == taskStatus {
+			list = append(list, id)
+		}
+	}
+	return list
+}
+
+// DomainAccessDataStatic 获取域名访问数据统计
+func (b *Baidu) DomainAccessDataStatic(data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return b.DomainAccessDataStaticWithContext(b.ctx, data)
+}
+
+// DomainAccessDataStaticWithContext 获取域名访问数据统计
+func (b *Baidu) DomainAccessDataStaticWithContext(ctx context.Context, data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.District != nil || data.Isp != nil {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, "district and isp not support")
+	}
+	metric := getDataAccessMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	if data.Area != consts.AreaCodeChinaMainland {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("area %d not support", data.Area))
+	}
+	datas, err := b.getStatData(ctx, metric, data.Domains, data.StartTime, data.EndTime, getPeriod(data.Interval))
+	if err != nil {
+		return nil, err
+	}
+	responseData := getStaticData(metric, data.Metric == consts.DataAccessMetricTypeBandwidth, datas)
+	if len(responseData) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return responseData, nil
+}
+
+// DomainOriginDataStatic 获取域名回源数据统计
+func (b *Baidu) DomainOriginDataStatic(data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return b.DomainOriginDataStaticWithContext(b.ctx, data)
+}
+
+// DomainOriginDataStaticWithContext 获取域名回源数据统计
+func (b *Baidu) DomainOriginDataStaticWithContext(ctx context.Context, data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	metric := getDataOriginMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := b.getStatData(ctx, metric, data.Domains, data.StartTime, data.EndTime, getPeriod(data.Interval))
+	if err != nil {
+		return nil, err
+	}
+	return types.DomainOriginDataStaticResponse(getStaticData(metric, data.Metric == consts.DataOriginMetricTypeBandwidth, datas)), nil
+}
+
+// getStatData 按域名分组查询统计数据,未指定域名时查询全部域名
+func (b *Baidu) getStatData(ctx context.Context, metric string, domains []string, startTime int64, endTime int64, period int64) (map[string][]*model.StatDetail, error) {
+	if len(domains) == 0 {
+		all, err := b.listDomains(ctx, "ALL")
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range all {
+			domains = append(domains, v.Domain)
+		}
+	}
+	res, err := invoke(ctx, b, ratelimit.ClassStats, retry.Idempotent, func() (*model.StatResponse, error) {
+		return b.client.GetStat(ctx, &model.StatRequest{
+			Metric:    metric,
+			StartTime: formatTime(startTime),
+			EndTime:   formatTime(endTime),
+			Period:    period,
+			Key:       domains,
+			GroupBy:   "key",
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	datas := make(map[string][]*model.StatDetail, len(domains))
+	for _, v := range res.Details {
+		datas[v.Key] = append(datas[v.Key], v)
+	}
+	return datas, nil
+}
+
+func getStaticData(metric string, bandwidth bool, datas map[string][]*model.StatDetail) types.DomainAccessDataStaticResponse {
+	responseData := make(types.DomainAccessDataStaticResponse)
+	for domain, details := range datas {
+		for _, v := range details {
+			responseData[domain] = append(responseData[domain], &types.StaticData{
+				Value: getStatisticsValue(metric, bandwidth, v),
+				Time:  parseTime(v.Timestamp),
+			})
+		}
+	}
+	return responseData
+}
+
+// getTotalData 汇总各时间点数据,带宽取峰值,其余指标求和
+func getTotalData(metric string, bandwidth bool, datas map[string][]*model.StatDetail) types.DataTotalDataResponse {
+	responseData := make(types.DataTotalDataResponse)
+	for domain, details := range datas {
+		for _, v := range details {
+			value := int64(getStatisticsValue(metric, bandwidth, v))
+			if bandwidth {
+				if value > responseData[domain] {
+					responseData[domain] = value
+				}
+				continue
+			}
+			responseData[domain] += value
+		}
+	}
+	return responseData
+}
+
+// ListTopUrlDataStatic 获取TOP URL数据统计
+func (b *Baidu) ListTopUrlDataStatic(data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return b.ListTopUrlDataStaticWithContext(b.ctx, data)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL数据统计,百度云TOP URL只按请求数统计
+func (b *Baidu) ListTopUrlDataStaticWithContext(ctx context.Context, data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.Filter != consts.ListTopFilterRequest {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("filter %d not support", data.Filter))
+	}
+	res, err := invoke(ctx, b, ratelimit.ClassStats, retry.Idempotent, func() (*model.StatResponse, error) {
+		return b.client.GetStat(ctx, &model.StatRequest{
+			Metric:    metricTopUrls,
+			StartTime: formatTime(data.StartTime),
+			EndTime:   formatTime(data.EndTime),
+			Key:       []string{data.Domain},
+			Extra:     topUrlLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	//各时间段的TOP URL按url汇总
+	values := make(map[string]float64)
+	urls := make([]string, 0)
+	for _, detail := range res.Details {
+		for _, v := range detail.Counters {
+			if _, ok := values[v.Name]; !ok {
+				urls = append(urls, v.Name)
+			}
+			values[v.Name] += v.Value
+		}
+	}
+	responseData := make([]*types.ListTopUrlDataStaticResponse, 0, len(urls))
+	for _, url := range urls {
+		responseData = append(responseData, &types.ListTopUrlDataStaticResponse{Url: url, Value: values[url]})
+	}
+	sort.SliceStable(responseData, func(i, j int) bool {
+		return responseData[i].Value > responseData[j].Value
+	})
+	return responseData, nil
+}
+
+// DomainAccessTotalData 获取域名总流量
+func (b *Baidu) DomainAccessTotalData(data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return b.DomainAccessTotalDataWithContext(b.ctx, data)
+}
+
+// DomainAccessTotalDataWithContext 获取域名总流量
+func (b *Baidu) DomainAccessTotalDataWithContext(ctx context.Context, data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	metric := getDataAccessMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	if data.Area != consts.AreaCodeChinaMainland {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("area %d not support", data.Area))
+	}
+	datas, err := b.getStatData(ctx, metric, data.Domains, data.StartTime, data.EndTime, getPeriod(consts.DataIntervalTypeDay))
+	if err != nil {
+		return nil, err
+	}
+	responseData := getTotalData(metric, data.Metric == consts.DataAccessMetricTypeBandwidth, datas)
+	if len(responseData) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return responseData, nil
+}
+
+// DomainOriginTotalData 获取域名回源总流量
+func (b *Baidu) DomainOriginTotalData(data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return b.DomainOriginTotalDataWithContext(b.ctx, data)
+}
+
+// DomainOriginTotalDataWithContext 获取域名回源总流量
+func (b *Baidu) DomainOriginTotalDataWithContext(ctx context.Context, data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	metric := getDataOriginMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := b.getStatData(ctx, metric, data.Domains, data.StartTime, data.EndTime, getPeriod(consts.DataIntervalTypeDay))
+	if err != nil {
+		return nil, err
+	}
+	return getTotalData(metric, data.Metric == consts.DataOriginMetricTypeBandwidth, datas), nil
+}
+
+// UserAccessRegionDistribution 获取用户访问区域分布
+func (b *Baidu) UserAccessRegionDistribution(data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return b.UserAccessRegionDistributionWithContext(b.ctx, data)
+}
+
+// UserAccessRegionDistributionWithContext 获取用户访问区域分布,百度云加速区域为中国大陆,境外值为0
+func (b *Baidu) UserAccessRegionDistributionWithContext(ctx context.Context, data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	responseData := make(types.UserAccessRegionDistributionResponse)
+	if data == nil {
+		return responseData, cdnerr.ErrNilRequest
+	}
+	metric := getDataAccessMetric(data.Metric)
+	if metric == "" || data.Metric == consts.DataAccessMetricTypeBandwidth {
+		return responseData, cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	datas, err := b.getStatData(ctx, metric, data.Domains, data.StartTime, data.EndTime, getPeriod(consts.DataIntervalTypeDay))
+	if err != nil {
+		return responseData, err
+	}
+	for domain, value := range getTotalData(metric, false, datas) {
+		responseData[domain] = &types.RegionDistribution{MainLandValue: value}
+	}
+	return responseData, nil
+}
+
+//设置更新的各项信息
+
+// UpdateDomainConfigModel 百度云的域名配置按配置项逐个提交,先收集各配置项,再按证书、配置项的顺序提交
+type UpdateDomainConfigModel struct {
+	b           *Baidu
+	req         *types.UpdateDomainRequest
+	err         error
+	keys        []string
+	bodies      map[string]interface{}
+	https       *model.Https
+	certificate *model.Certificate
+	// cacheTTLs 缓存规则与状态码缓存共用cacheTTL配置项,按规则类型分组,提交时保留未更新类型的已有规则
+	cacheTTLs map[string][]*model.CacheTTL
+	// headers 回源请求头与响应头共用httpHeader配置项,按生效类型分组,提交时保留未更新类型的已有配置
+	headers map[string][]*model.HttpHeader
+}
+
+func (b *Baidu) newUpdateDomainConfigModel(req *types.UpdateDomainRequest) *UpdateDomainConfigModel {
+	return &UpdateDomainConfigModel{
+		b:      b,
+		req:    req,
+		bodies: make(map[string]interface{}),
+	}
+}
+
+// setConfig 设置配置项,同一配置项多次设置时以最后一次为准
+func (u *UpdateDomainConfigModel) setConfig(key string, value interface{}) {
+	u.setConfigBody(key, map[string]interface{}{key: value})
+}
+
+// setConfigBody 设置配置项的完整请求体,用于需同时提交多个字段的配置项
+func (u *UpdateDomainConfigModel) setConfigBody(key string, body interface{}) {
+	if _, ok := u.bodies[key]; !ok {
+		u.keys = append(u.keys, key)
+	}
+	u.bodies[key] = body
+}
+
+func (u *UpdateDomainConfigModel) WithBaseConf() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	u.WithArea()
+	u.setConfig("ipv6Dispatch", &model.Switch{Enable: u.req.CdnDomain.SupportIpv6 == consts.SwitchOn})
+}
+
+// WithArea 百度云只支持中国大陆加速区域
+func (u *UpdateDomainConfigModel) WithArea() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	if u.req.CdnDomain.AreaCode != consts.AreaCodeChinaMainland {
+		u.err = cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("area %d not support", u.req.CdnDomain.AreaCode))
+	}
+}
+
+// WithOriginConf 回源SNI、跟随跳转与Etag校验百度云没有对应的配置项,忽略
+func (u *UpdateDomainConfigModel) WithOriginConf() {
+	if u.req.OriginConf == nil {
+		return
+	}
+	conf := u.req.OriginConf
+	u.withOriginProtocol(conf.OriginProtocol)
+	u.setConfig("rangeSwitch", getSwitch(conf.OriginRange))
+	if conf.OriginTimeOut > 0 {
+		connectTimeout := conf.TcpTimeout
+		if connectTimeout <= 0 {
+			connectTimeout = conf.OriginTimeOut
+		}
+		u.setConfig("originTimeout", &model.OriginTimeout{ConnectTimeout: connectTimeout, LoadTimeout: conf.OriginTimeOut})
+	}
+}
+
+// withOriginProtocol 设置回源协议
+func (u *UpdateDomainConfigModel) withOriginProtocol(protocol int64) {
+	u.setConfig("originProtocol", &model.OriginProtocol{Value: getOriginProtocol(protocol)})
+}
+
+func (u *UpdateDomainConfigModel) WithOriginServerConf() {
+	if len(u.req.OriginServerConf) == 0 {
+		return
+	}
+	protocol := int64(consts.OriginProtocolHttp)
+	if u.req.OriginConf != nil {
+		protocol = u.req.OriginConf.OriginProtocol
+	}
+	//源站与默认回源host需同时提交
+	u.setConfigBody("origin", &model.DomainConfig{
+		Origin:      getOrigins(protocol, u.req.OriginServerConf),
+		DefaultHost: getPrimaryHost(u.req.OriginServerConf),
+	})
+}
+
+func (u *UpdateDomainConfigModel) WithOriginRequestHeaderConf() {
+	headers := make([]*model.HttpHeader, 0, len(u.req.OriginRequestHeaderConf))
+	for _, v := range u.req.OriginRequestHeaderConf {
+		headers = append(headers, &model.HttpHeader{
+			Type:   headerTypeOrigin,
+			Header: v.ParameterKey,
+			Value:  v.ParameterValue,
+			Action: getHeaderAction(v.Action),
+		})
+	}
+	u.withHeaders(headerTypeOrigin, headers)
+}
+
+func (u *UpdateDomainConfigModel) WithResponseHeaderConf() {
+	headers := make([]*model.HttpHeader, 0, len(u.req.ResponseHeaderConf))
+	for _, v := range u.req.ResponseHeaderConf {
+		headers = append(headers, &model.HttpHeader{
+			Type:   headerTypeResponse,
+			Header: v.ParameterKey,
+			Value:  v.ParameterValue,
+			Action: getHeaderAction(v.Action),
+		})
+	}
+	u.withHeaders(headerTypeResponse, headers)
+}
+
+func (u *UpdateDomainConfigModel) withHeaders(headerType string, headers []*model.HttpHeader) {
+	if u.headers == nil {
+		u.headers = make(map[string][]*model.HttpHeader)
+	}
+	u.headers[headerType] = headers
+	u.setConfig("httpHeader", nil)
+}
+
+// getAcl 获取黑白名单配置,百度云黑名单与白名单互斥,以第一条有效规则的类型为准
+func getAcl(listType int64, list []string) *model.ACL {
+	if listType == consts.WhiteList {
+		return &model.ACL{WhiteList: list}
+	}
+	return &model.ACL{BlackList: list}
+}
+
+func (u *UpdateDomainConfigModel) WithIpFilterConf() {
+	if u.req.IpFilterConf == nil {
+		return
+	}
+	ips := make([]string, 0)
+	ipType := int64(-1)
+	if u.req.IpFilterConf.Status == types.ON {
+		for _, v := range u.req.IpFilterConf.IpFilterConf {
+			if len(v.IpList) == 0 {
+				continue
+			}
+			if ipType == -1 {
+				ipType = v.IpType
+			}
+			if v.IpType == ipType {
+				ips = append(ips, v.IpList...)
+			}
+		}
+	}
+	u.setConfig("ipACL", getAcl(ipType, ips))
+}
+
+func (u *UpdateDomainConfigModel) WithIpFrequencyConf() {
+	if u.req.IpFrequencyConf == nil {
+		return
+	}
+	limit := &model.AccessLimit{Enabled: u.req.IpFrequencyConf.Status == types.ON}
+	if limit.Enabled {
+		limit.Limit = u.req.IpFrequencyConf.Frequency
+	}
+	u.setConfig("accessLimit", limit)
+}
+
+func (u *UpdateDomainConfigModel) WithRefererConf() {
+	if u.req.RefererConf == nil {
+		return
+	}
+	if u.req.RefererConf.Status != types.ON {
+		u.setConfig("refererACL", &model.ACL{})
+		return
+	}
+	acl := getAcl(u.req.RefererConf.RefererType, u.req.RefererConf.RefererList)
+	allowEmpty := u.req.RefererConf.IncludeEmpty == consts.SwitchOn
+	acl.AllowEmpty = &allowEmpty
+	u.setConfig("refererACL", acl)
+}
+
+func (u *UpdateDomainConfigModel) WithUserAgentConf() {
+	if u.req.UserAgentConf == nil {
+		return
+	}
+	agents := make([]string, 0)
+	agentType := int64(-1)
+	if u.req.UserAgentConf.Status == types.ON {
+		for _, v := range u.req.UserAgentConf.UserAgentConf {
+			if len(v.AgentList) == 0 {
+				continue
+			}
+			if agentType == -1 {
+				agentType = v.AgentType
+			}
+			if v.AgentType == agentType {
+				agents = append(agents, v.AgentList...)
+			}
+		}
+	}
+	u.setConfig("uaAcl", getAcl(agentType, agents))
+}
+
+// WithSpeedConf 百度云单请求限速对全部内容生效,取第一条有效规则
+func (u *UpdateDomainConfigModel) WithSpeedConf() {
+	if u.req.SpeedConf == nil {
+		return
+	}
+	limit := &model.TrafficLimit{}
+	if u.req.SpeedConf.Status == types.ON {
+		for _, v := range u.req.SpeedConf.SpeedConf {
+			if v.SpeedValues <= 0 {
+				continue
+			}
+			limit.Enable = true
+			limit.LimitRate = v.SpeedValues * 1024
+			break
+		}
+	}
+	u.setConfig("trafficLimit", limit)
+}
+
+// WithAuthConf 关闭鉴权时提交空的鉴权配置
+func (u *UpdateDomainConfigModel) WithAuthConf() {
+	if u.req.AuthConf == nil {
+		return
+	}
+	if u.req.AuthConf.Status != types.ON {
+		u.setConfig("requestAuth", &model.RequestAuth{})
+		return
+	}
+	authType := getAuthType(u.req.AuthConf.AuthManner)
+	if authType == "" {
+		u.err = cdnerr.New(cdnerr.ErrUnsupported, types.BaiduSdkName, fmt.Sprintf("auth manner %d not support", u.req.AuthConf.AuthManner))
+		return
+	}
+	u.setConfig("requestAuth", &model.RequestAuth{
+		Type:    authType,
+		Key1:    u.req.AuthConf.AuthKey,
+		Key2:    u.req.AuthConf.AuthKeyBackup,
+		Timeout: u.req.AuthConf.TimeValue,
+		SignArg: u.req.AuthConf.AuthParameter,
+	})
+}
+
+func (u *UpdateDomainConfigModel) WithCacheListConf() {
+	items := make([]*model.CacheTTL, 0)
+	for _, v := range u.req.CacheListConf {
+		items = append(items, getCacheTTLs(v)...)
+	}
+	u.withCacheTTLs("", items)
+}
+
+func (u *UpdateDomainConfigModel) WithCacheCodeConf() {
+	items := make([]*model.CacheTTL, 0, len(u.req.CacheCodeConf))
+	for _, v := range u.req.CacheCodeConf {
+		items = append(items, &model.CacheTTL{
+			Type:  cacheTypeCode,
+			Value: strconv.FormatInt(v.HttpCode, 10),
+			TTL:   cachettl.Seconds(v.CacheTTL, v.CacheUnit),
+		})
+	}
+	u.withCacheTTLs(cacheTypeCode, items)
+}
+
+// withCacheTTLs 设置缓存规则,group为空表示状态码以外的缓存规则
+func (u *UpdateDomainConfigModel) withCacheTTLs(group string, items []*model.CacheTTL) {
+	if u.cacheTTLs == nil {
+		u.cacheTTLs = make(map[string][]*model.CacheTTL)
+	}
+	u.cacheTTLs[group] = items
+	u.setConfig("cacheTTL", nil)
+}
+
+func (u *UpdateDomainConfigModel) WithCustomErrorPageConf() {
+	pages := make([]*model.ErrorPage, 0, len(u.req.CustomErrorPageConf))
+	for _, v := range u.req.CustomErrorPageConf {
+		pages = append(pages, &model.ErrorPage{
+			Code:         v.StatusCode,
+			RedirectCode: getRedirectCode(v.RedirectCode),
+			Url:          v.GoalAddress,
+		})
+	}
+	u.setConfig("errorPage", pages)
+}
+
+// WithIntelligentCompressionConf 百度云压缩对全部内容生效,只取规则中的压缩方式
+func (u *UpdateDomainConfigModel) WithIntelligentCompressionConf() {
+	if u.req.IntelligentCompressionConf == nil {
+		return
+	}
+	gzip, brotli := false, false
+	if u.req.IntelligentCompressionConf.Status == types.ON {
+		for _, v := range u.req.IntelligentCompressionConf.IntelligentCompressionConf {
+			if v.CompressMethod == consts.IntelligentCompressionCompressMethodBrotli {
+				brotli = true
+			} else {
+				gzip = true
+			}
+		}
+	}
+	compress := &model.Compress{Allow: gzip || brotli}
+	if compress.Allow {
+		compress.Type = getCompressType(gzip, brotli)
+	}
+	u.setConfig("compress", compress)
+}
+
+// WithHttpsConf HSTS百度云没有对应的配置项,忽略
+func (u *UpdateDomainConfigModel) WithHttpsConf() {
+	if u.req.HttpsConf == nil {
+		return
+	}
+	conf := u.req.HttpsConf
+	if conf.HttpsStatus != types.ON {
+		u.setConfig("https", &model.Https{Enabled: false})
+		return
+	}
+	u.certificate = &model.Certificate{
+		CertName:        conf.CertName,
+		CertServerData:  conf.CertValue,
+		CertPrivateData: conf.CertKey,
+	}
+	if u.certificate.CertName == "" {
+		u.certificate.CertName = u.req.Domain
+	}
+	https := &model.Https{
+		Enabled:      true,
+		Http2Enabled: conf.HttpTwo == consts.SwitchOn,
+		SslVersion:   getSslVersion(conf.TlsVersion),
+	}
+	if conf.JumpForceStatus == consts.SwitchOn {
+		if conf.JumpType == consts.HttpsJumpTypeHttp {
+			https.HttpsRedirect = true
+		} else {
+			https.HttpRedirect = true
+			https.HttpRedirectCode = 301
+		}
+	}
+	u.https = https
+	u.setConfig("https", https)
+	ocsp := conf.OcspStatus == consts.SwitchOn
+	u.setConfig("ocsp", &ocsp)
+}
+
+// send 依次提交收集到的配置,证书需先于https配置提交
+func (u *UpdateDomainConfigModel) send(ctx context.Context) error {
+	if u.err != nil {
+		return u.err
+	}
+	domain := getDomainName(u.req.Domain, u.req.DomainId)
+	client := u.b.client
+	if u.certificate != nil {
+		res, err := invoke(ctx, u.b, ratelimit.ClassConfig, retry.Idempotent, func() (*model.PutCertificateResponse, error) {
+			return client.PutCertificate(ctx, domain, &model.PutCertificateRequest{Certificate: u.certificate})
+		})
+		if err != nil {
+			return err
+		}
+		u.https.CertId = res.CertId
+	}
+	if err := u.mergeConfigs(ctx, domain); err != nil {
+		return err
+	}
+	for _, key := range u.keys {
+		key, body := key, u.bodies[key]
+		if _, err := invoke(ctx, u.b, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+			return client.SetDomainConfig(ctx, domain, key, body)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeConfigs 缓存规则与头部配置整体提交,保留未更新类型的已有配置
+func (u *UpdateDomainConfigModel) mergeConfigs(ctx context.Context, domain string) error {
+	if u.cacheTTLs == nil && u.headers == nil {
+		return nil
+	}
+	current, err := invoke(ctx, u.b, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DomainConfig, error) {
+		return u.b.client.GetDomainConfig(ctx, domain)
+	})
+	if err != nil {
+		return err
+	}
+	if u.cacheTTLs != nil {
+		items := make([]*model.CacheTTL, 0)
+		for _, v := range current.CacheTTL {
+			group := ""
+			if v.Type == cacheTypeCode {
+				group = cacheTypeCode
+			}
+			if _, ok := u.cacheTTLs[group]; !ok {
+				items = append(items, v)
+			}
+		}
+		for _, group := range []string{"", cacheTypeCode} {
+			items = append(items, u.cacheTTLs[group]...)
+		}
+		u.setConfig("cacheTTL", items)
+	}
+	if u.headers != nil {
+		items := make([]*model.HttpHeader, 0)
+		for _, v := range current.HttpHeader {
+			if _, ok := u.headers[v.Type]; !ok {
+				items = append(items, v)
+			}
+		}
+		for _, headerType := range []string{headerTypeOrigin, headerTypeResponse} {
+			items = append(items, u.headers[headerType]...)
+		}
+		u.setConfig("httpHeader", items)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package baidu
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDomainLifecycle(t *testing.T) {
+	s, b := newStandIn(t)
+	err := b.CreateDomain(&types.CreateDomainRequest{
+		Domain:         "www.example.com",
+		AreaCode:       consts.AreaCodeChinaMainland,
+		ChannelType:    consts.ChannelTypeDownload,
+		OriginProtocol: consts.OriginProtocolHttps,
+		Sources: []*entity.OriginServerConf{
+			{OriginAddressList: "1.1.1.1, 2.2.2.2", OriginHttpsPort: 8443, OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityPrimary, OriginWeight: 10},
+			{OriginAddressList: "backup.example.com", OriginType: consts.OriginTypeDomain, OriginPriority: consts.OriginPriorityBackup},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//回源协议在创建后单独配置
+	want := []string{"PUT /v2/domain/www.example.com", "PUT /v2/domain/www.example.com/config?originProtocol="}
+	if calls := s.callList(); !reflect.DeepEqual(calls, want) {
+		t.Fatalf("got calls %v, want %v", calls, want)
+	}
+	d := s.domain("www.example.com")
+	origins := []*model.Origin{
+		{Peer: "https://1.1.1.1:8443", Host: "origin.example.com", Weight: 10},
+		{Peer: "https://2.2.2.2:8443", Host: "origin.example.com", Weight: 10},
+		{Peer: "https://backup.example.com:443", Backup: true},
+	}
+	if d.Form != "download" || d.DefaultHost != "origin.example.com" || !reflect.DeepEqual(d.Origin, origins) {
+		t.Fatalf("unexpected created domain %+v", d)
+	}
+	if d.OriginProtocol == nil || d.OriginProtocol.Value != "https" {
+		t.Fatalf("unexpected origin protocol %+v", d.OriginProtocol)
+	}
+	if err := b.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com", OriginProtocol: consts.OriginProtocolHttp}); !errors.Is(err, cdnerr.ErrDomainAlreadyExists) {
+		t.Fatalf("got %v, want domain already exists", err)
+	}
+	//百度云只支持中国大陆加速区域
+	s.resetCalls()
+	if err := b.CreateDomain(&types.CreateDomainRequest{Domain: "oversea.example.com", AreaCode: consts.AreaCodeGlobal}); !errors.Is(err, cdnerr.ErrUnsupported) {
+		t.Fatalf("got %v, want unsupported area", err)
+	}
+	if calls := s.callList(); len(calls) != 0 {
+		t.Fatalf("unsupported area called %v", calls)
+	}
+
+	detail, err := b.ShowDomainDetail(&types.ShowDomainDetailRequest{DomainId: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDetail := &types.ShowDomainDetailResponse{
+		DomainId:    "www.example.com",
+		Domain:      "www.example.com",
+		Cname:       "www.example.com.a.bdydns.com",
+		ChannelType: consts.ChannelTypeDownload,
+		AreaCode:    consts.AreaCodeChinaMainland,
+		Status:      consts.CdnDomainStatusDeploying,
+		CreateTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		UpdateTime:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+	if !reflect.DeepEqual(detail, wantDetail) {
+		t.Fatalf("got detail %+v, want %+v", detail, wantDetail)
+	}
+
+	if err := b.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	s.addDomain("a.example.com")
+	list, err := b.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusStoped, Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 1 || !reflect.DeepEqual(list.List, []string{"www.example.com"}) {
+		t.Fatalf("unexpected stopped domains %+v", list)
+	}
+	if err := b.EnableDomain(&types.EnableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if status := s.domain("www.example.com").Status; status != "RUNNING" {
+		t.Fatalf("got status %s after enable", status)
+	}
+
+	if err := b.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if s.domain("www.example.com") != nil {
+		t.Fatal("domain not deleted")
+	}
+	//域名不存在时删除视为成功
+	if err := b.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	var e *cdnerr.Error
+	if !errors.Is(err, cdnerr.ErrDomainNotFound) || !errors.As(err, &e) || e.Code != "NoSuchDomain" || e.RequestId != "req-1" {
+		t.Fatalf("got %v, want domain not found with request id from header", err)
+	}
+}
+
+func TestUpdateDomainConfig(t *testing.T) {
+	s, b := newStandIn(t)
+	d := s.addDomain("www.example.com")
+	d.CacheTTL = []*model.CacheTTL{
+		{Type: "suffix", Value: ".png", TTL: 60},
+		{Type: cacheTypeCode, Value: "404", TTL: 10},
+	}
+	d.HttpHeader = []*model.HttpHeader{
+		{Type: headerTypeOrigin, Header: "X-From", Value: "cdn", Action: "add"},
+		{Type: headerTypeResponse, Header: "X-Old", Value: "1", Action: "add"},
+	}
+	err := b.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateRecommendConf,
+		Domain:       "www.example.com",
+		OriginConf:   &entity.OriginConf{OriginProtocol: consts.OriginProtocolFollow, OriginRange: consts.SwitchOn, OriginTimeOut: 30},
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{"jpg", ".gif"}, CacheTTL: 2, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOn, Priority: 120},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"api/"}, CacheTTL: 10, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOff},
+		},
+		HttpsConf: &entity.HttpsConf{
+			HttpsStatus:     types.ON,
+			HttpTwo:         types.ON,
+			JumpForceStatus: types.ON,
+			JumpType:        consts.HttpsJumpTypeHttps,
+			TlsVersion:      []int64{consts.HttpsTlsVersionSSLv2, consts.HttpsTlsVersionSSLv1},
+			CertValue:       "cert",
+			CertKey:         "key",
+		},
+		IntelligentCompressionConf: &types.IntelligentCompressionConf{Status: types.ON, IntelligentCompressionConf: []*entity.IntelligentCompressionConf{
+			{CompressMethod: consts.IntelligentCompressionCompressMethodGzip},
+			{CompressMethod: consts.IntelligentCompressionCompressMethodBrotli},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//证书先于https配置提交,缓存规则提交前查询已有配置
+	want := []string{
+		"PUT /v2/www.example.com/certificates",
+		"GET /v2/domain/www.example.com/config",
+		"PUT /v2/domain/www.example.com/config?originProtocol=",
+		"PUT /v2/domain/www.example.com/config?rangeSwitch=",
+		"PUT /v2/domain/www.example.com/config?originTimeout=",
+		"PUT /v2/domain/www.example.com/config?cacheTTL=",
+		"PUT /v2/domain/www.example.com/config?https=",
+		"PUT /v2/domain/www.example.com/config?ocsp=",
+		"PUT /v2/domain/www.example.com/config?compress=",
+	}
+	if calls := s.callList(); !reflect.DeepEqual(calls, want) {
+		t.Fatalf("got calls\n%v\nwant\n%v", calls, want)
+	}
+	d = s.domain("www.example.com")
+	if d.OriginProtocol.Value != "*" || d.RangeSwitch != "on" || !reflect.DeepEqual(d.OriginTimeout, &model.OriginTimeout{ConnectTimeout: 30, LoadTimeout: 30}) {
+		t.Fatalf("unexpected origin config %+v %s %+v", d.OriginProtocol, d.RangeSwitch, d.OriginTimeout)
+	}
+	//状态码缓存规则不在本次更新范围内,保留已有配置
+	cacheTTL := []*model.CacheTTL{
+		{Type: cacheTypeCode, Value: "404", TTL: 10},
+		{Type: "suffix", Value: ".jpg", TTL: 7200, Weight: 100},
+		{Type: "suffix", Value: ".gif", TTL: 7200, Weight: 100},
+		{Type: "path", Value: "/api", TTL: 0},
+	}
+	if !reflect.DeepEqual(d.CacheTTL, cacheTTL) {
+		t.Fatalf("got cache ttl %+v", d.CacheTTL)
+	}
+	if cert := s.certs["www.example.com"]; cert == nil || cert.CertName != "www.example.com" || cert.CertServerData != "cert" || cert.CertPrivateData != "key" {
+		t.Fatalf("unexpected certificate %+v", cert)
+	}
+	https := &model.Https{Enabled: true, CertId: "cert-www.example.com", HttpRedirect: true, HttpRedirectCode: 301, Http2Enabled: true, SslVersion: "TLSV11"}
+	if !reflect.DeepEqual(d.Https, https) || d.Ocsp == nil || *d.Ocsp {
+		t.Fatalf("unexpected https config %+v %v", d.Https, d.Ocsp)
+	}
+	if !reflect.DeepEqual(d.Compress, &model.Compress{Allow: true, Type: "all"}) {
+		t.Fatalf("unexpected compress %+v", d.Compress)
+	}
+
+	//回源请求头不在本次更新范围内,保留已有配置
+	err = b.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateResponseHeaderConf,
+		Domain:       "www.example.com",
+		ResponseHeaderConf: []*entity.ResponseHeaderConf{
+			{ParameterKey: "X-Frame-Options", ParameterValue: "DENY", Action: consts.OriginHeaderActionSet},
+			{ParameterKey: "X-Powered-By", Action: consts.OriginHeaderActionDelete},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers := []*model.HttpHeader{
+		{Type: headerTypeOrigin, Header: "X-From", Value: "cdn", Action: "add"},
+		{Type: headerTypeResponse, Header: "X-Frame-Options", Value: "DENY", Action: "add"},
+		{Type: headerTypeResponse, Header: "X-Powered-By", Action: "remove"},
+	}
+	if got := s.domain("www.example.com").HttpHeader; !reflect.DeepEqual(got, headers) {
+		t.Fatalf("got headers %+v", got)
+	}
+}
+
+func TestUpdateDomainUnsupported(t *testing.T) {
+	s, b := newStandIn(t)
+	s.addDomain("www.example.com")
+	s.resetCalls()
+	cases := []*types.UpdateDomainRequest{
+		{UpdateAction: types.UpdateOriginUrlConf, Domain: "www.example.com"},
+		{UpdateAction: types.UpdateArea, Domain: "www.example.com", CdnDomain: &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeOversea}},
+		{UpdateAction: types.UpdateAuthConf, Domain: "www.example.com", AuthConf: &entity.AuthConf{Status: types.ON, AuthManner: consts.AccessAuthMannerTypeD}},
+	}
+	for _, c := range cases {
+		if err := b.UpdateDomain(c); !errors.Is(err, cdnerr.ErrUnsupported) {
+			t.Fatalf("%s: got %v, want unsupported", c.UpdateAction, err)
+		}
+	}
+	if calls := s.callList(); len(calls) != 0 {
+		t.Fatalf("unsupported updates called %v", calls)
+	}
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	s, _ := newStandIn(t)
+	b, err := New(context.Background(), &Config{Ak: "ak", Sk: "wrong", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusDeployed, Page: 1, Limit: 10})
+	if !errors.Is(err, cdnerr.ErrAuthFailed) {
+		t.Fatalf("got %v, want auth failed", err)
+	}
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common/model"
+	"net/http"
+)
+
+const (
+	DefaultEndpoint = "https://cdn.baidubce.com"
+	XBceRequestId   = "x-bce-request-id"
+)
+
+type Client struct {
+	rest *rest.Client
+}
+
+func NewClient(signer *Signer, endpoint string) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	client := rest.NewClient(endpoint, signer)
+	client.ErrorDecoder = rest.NewErrorDecoder(rest.ErrorFields{
+		RequestIdHeader: XBceRequestId,
+		RequestId:       []string{"requestId"},
+		Code:            []string{"code"},
+		Message:         []string{"message"},
+	})
+	return &Client{
+		rest: client,
+	}
+}
+
+// WithHttpClient 设置发送请求使用的HTTP客户端,为空时使用http.DefaultClient
+func (c *Client) WithHttpClient(client *http.Client) *Client {
+	c.rest.HttpClient = client
+	return c
+}
+
+// CreateDomain 创建加速域名
+func (c *Client) CreateDomain(ctx context.Context, domain string, req *model.CreateDomainRequest) (*model.CreateDomainResponse, error) {
+	return rest.Do[model.CreateDomainResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/v2/domain/"+domain).WithBody(req))
+}
+
+// ListUserDomains 查询用户域名列表
+func (c *Client) ListUserDomains(ctx context.Context, req *model.ListUserDomainsRequest) (*model.ListUserDomainsResponse, error) {
+	r, err := rest.NewRequest(http.MethodGet, "/v2/user/domains").WithQueryParams(req)
+	if err != nil {
+		return nil, err
+	}
+	return rest.Do[model.ListUserDomainsResponse](ctx, c.rest, r)
+}
+
+// EnableDomain 启用加速域名
+func (c *Client) EnableDomain(ctx context.Context, domain string) (*model.CommonResponse, error) {
+	r := rest.NewRequest(http.MethodPost, "/v2/domain/"+domain)
+	r.Query.Set("enable", "")
+	return rest.Do[model.CommonResponse](ctx, c.rest, r)
+}
+
+// DisableDomain 停用加速域名
+func (c *Client) DisableDomain(ctx context.Context, domain string) (*model.CommonResponse, error) {
+	r := rest.NewRequest(http.MethodPost, "/v2/domain/"+domain)
+	r.Query.Set("disable", "")
+	return rest.Do[model.CommonResponse](ctx, c.rest, r)
+}
+
+// DeleteDomain 删除加速域名
+func (c *Client) DeleteDomain(ctx context.Context, domain string) (*model.CommonResponse, error) {
+	return rest.Do[model.CommonResponse](ctx, c.rest, rest.NewRequest(http.MethodDelete, "/v2/domain/"+domain))
+}
+
+// GetDomainConfig 查询域名配置
+func (c *Client) GetDomainConfig(ctx context.Context, domain string) (*model.DomainConfig, error) {
+	return rest.Do[model.DomainConfig](ctx, c.rest, rest.NewRequest(http.MethodGet, "/v2/domain/"+domain+"/config"))
+}
+
+// SetDomainConfig 修改域名配置,key为配置项名称,body为请求体
+func (c *Client) SetDomainConfig(ctx context.Context, domain string, key string, body interface{}) (*model.CommonResponse, error) {
+	r := rest.NewRequest(http.MethodPut, "/v2/domain/"+domain+"/config").WithBody(body)
+	r.Query.Set(key, "")
+	return rest.Do[model.CommonResponse](ctx, c.rest, r)
+}
+
+// PutCertificate 上传域名证书
+func (c *Client) PutCertificate(ctx context.Context, domain string, req *model.PutCertificateRequest) (*model.PutCertificateResponse, error) {
+	return rest.Do[model.PutCertificateResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/v2/"+domain+"/certificates").WithBody(req))
+}
+
+// Purge 刷新缓存
+func (c *Client) Purge(ctx context.Context, req *model.CacheRequest) (*model.CacheResponse, error) {
+	return rest.Do[model.CacheResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/v2/cache/purge").WithBody(req))
+}
+
+// Prefetch 预热缓存
+func (c *Client) Prefetch(ctx context.Context, req *model.CacheRequest) (*model.CacheResponse, error) {
+	return rest.Do[model.CacheResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/v2/cache/prefetch").WithBody(req))
+}
+
+// GetPurgedStatus 查询刷新任务
+func (c *Client) GetPurgedStatus(ctx context.Context, req *model.CacheRecordsRequest) (*model.CacheRecordsResponse, error) {
+	r, err := rest.NewRequest(http.MethodGet, "/v2/cache/purge").WithQueryParams(req)
+	if err != nil {
+		return nil, err
+	}
+	return rest.Do[model.CacheRecordsResponse](ctx, c.rest, r)
+}
+
+// GetPrefetchStatus 查询预热任务
+func (c *Client) GetPrefetchStatus(ctx context.Context, req *model.CacheRecordsRequest) (*model.CacheRecordsResponse, error) {
+	r, err := rest.NewRequest(http.MethodGet, "/v2/cache/prefetch").WithQueryParams(req)
+	if err != nil {
+		return nil, err
+	}
+	return rest.Do[model.CacheRecordsResponse](ctx, c.rest, r)
+}
+
+// GetStat 查询统计数据
+func (c *Client) GetStat(ctx context.Context, req *model.StatRequest) (*model.StatResponse, error) {
+	return rest.Do[model.StatResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/v2/stat/query").WithBody(req))
+}
@@ -0,0 +1,129 @@
+package model
+
+type CacheTTL struct {
+	//规则类型：suffix、path、origin、code、exactPath
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	//缓存时间,单位秒,0表示不缓存
+	TTL int64 `json:"ttl"`
+	//权重,取值0-100,越大优先级越高
+	Weight int64 `json:"weight,omitempty"`
+}
+
+type ACL struct {
+	BlackList  []string `json:"blackList,omitempty"`
+	WhiteList  []string `json:"whiteList,omitempty"`
+	AllowEmpty *bool    `json:"allowEmpty,omitempty"`
+}
+
+type RequestAuth struct {
+	//鉴权类型：A、B、C
+	Type    string `json:"type,omitempty"`
+	Key1    string `json:"key1,omitempty"`
+	Key2    string `json:"key2,omitempty"`
+	Timeout int64  `json:"timeout,omitempty"`
+	SignArg string `json:"signArg,omitempty"`
+}
+
+type HttpHeader struct {
+	//生效类型：origin回源请求头、response响应头
+	Type   string `json:"type"`
+	Header string `json:"header"`
+	Value  string `json:"value,omitempty"`
+	//操作：add、remove
+	Action string `json:"action"`
+}
+
+type ErrorPage struct {
+	Code         int64  `json:"code"`
+	RedirectCode int64  `json:"redirectCode,omitempty"`
+	Url          string `json:"url"`
+}
+
+type Compress struct {
+	Allow bool `json:"allow"`
+	//压缩方式：gzip、br、all
+	Type string `json:"type,omitempty"`
+}
+
+type TrafficLimit struct {
+	Enable bool `json:"enable"`
+	//限速值,单位Byte/s
+	LimitRate int64 `json:"limitRate,omitempty"`
+}
+
+type AccessLimit struct {
+	Enabled bool `json:"enabled"`
+	//单ip每秒请求数
+	Limit int64 `json:"limit,omitempty"`
+}
+
+type OriginProtocol struct {
+	//回源协议：http、https、*跟随
+	Value string `json:"value"`
+}
+
+type OriginTimeout struct {
+	//回源连接超时,单位秒
+	ConnectTimeout int64 `json:"connectTimeout"`
+	//回源读超时,单位秒
+	LoadTimeout int64 `json:"loadTimeout"`
+}
+
+type Switch struct {
+	Enable bool `json:"enable"`
+}
+
+type Https struct {
+	Enabled          bool   `json:"enabled"`
+	CertId           string `json:"certId,omitempty"`
+	HttpRedirect     bool   `json:"httpRedirect"`
+	HttpRedirectCode int64  `json:"httpRedirectCode,omitempty"`
+	HttpsRedirect    bool   `json:"httpsRedirect"`
+	Http2Enabled     bool   `json:"http2Enabled"`
+	//最低TLS版本：SSLV3、TLSV1、TLSV11、TLSV12
+	SslVersion string `json:"sslVersion,omitempty"`
+}
+
+// DomainConfig 域名配置
+type DomainConfig struct {
+	Domain         string          `json:"domain,omitempty"`
+	Cname          string          `json:"cname,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	CreateTime     string          `json:"createTime,omitempty"`
+	LastModifyTime string          `json:"lastModifyTime,omitempty"`
+	Form           string          `json:"form,omitempty"`
+	Origin         []*Origin       `json:"origin,omitempty"`
+	DefaultHost    string          `json:"defaultHost,omitempty"`
+	CacheTTL       []*CacheTTL     `json:"cacheTTL,omitempty"`
+	RefererACL     *ACL            `json:"refererACL,omitempty"`
+	IpACL          *ACL            `json:"ipACL,omitempty"`
+	UaAcl          *ACL            `json:"uaAcl,omitempty"`
+	RequestAuth    *RequestAuth    `json:"requestAuth,omitempty"`
+	HttpHeader     []*HttpHeader   `json:"httpHeader,omitempty"`
+	ErrorPage      []*ErrorPage    `json:"errorPage,omitempty"`
+	Compress       *Compress       `json:"compress,omitempty"`
+	TrafficLimit   *TrafficLimit   `json:"trafficLimit,omitempty"`
+	AccessLimit    *AccessLimit    `json:"accessLimit,omitempty"`
+	OriginProtocol *OriginProtocol `json:"originProtocol,omitempty"`
+	OriginTimeout  *OriginTimeout  `json:"originTimeout,omitempty"`
+	RangeSwitch    string          `json:"rangeSwitch,omitempty"`
+	Ipv6Dispatch   *Switch         `json:"ipv6Dispatch,omitempty"`
+	Https          *Https          `json:"https,omitempty"`
+	Ocsp           *bool           `json:"ocsp,omitempty"`
+}
+
+type Certificate struct {
+	CertName        string `json:"certName"`
+	CertServerData  string `json:"certServerData"`
+	CertPrivateData string `json:"certPrivateData"`
+	CertLinkData    string `json:"certLinkData,omitempty"`
+}
+
+type PutCertificateRequest struct {
+	Certificate *Certificate `json:"certificate"`
+}
+
+type PutCertificateResponse struct {
+	CertId string `json:"certId"`
+}
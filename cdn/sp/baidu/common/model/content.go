@@ -0,0 +1,41 @@
+package model
+
+type PurgeTask struct {
+	Url string `json:"url"`
+	//刷新类型：file、directory
+	Type string `json:"type,omitempty"`
+}
+
+type CacheRequest struct {
+	Tasks []*PurgeTask `json:"tasks"`
+}
+
+type CacheResponse struct {
+	Id string `json:"id"`
+}
+
+type CacheRecordsRequest struct {
+	Id string `json:"id,omitempty"`
+	//开始时间,UTC时间,格式如：2006-01-02T15:04:05Z
+	StartTime string `json:"startTime,omitempty"`
+	EndTime   string `json:"endTime,omitempty"`
+	Url       string `json:"url,omitempty"`
+	Marker    string `json:"marker,omitempty"`
+}
+
+type CacheDetail struct {
+	Id string `json:"id"`
+	//任务状态：in-progress、completed、failed
+	Status     string     `json:"status"`
+	Task       *PurgeTask `json:"task"`
+	CreatedAt  string     `json:"createdAt"`
+	StartedAt  string     `json:"startedAt"`
+	FinishedAt string     `json:"finishedAt"`
+	Progress   int64      `json:"progress"`
+}
+
+type CacheRecordsResponse struct {
+	Details     []*CacheDetail `json:"details"`
+	IsTruncated bool           `json:"isTruncated"`
+	NextMarker  string         `json:"nextMarker"`
+}
@@ -0,0 +1,52 @@
+package model
+
+type Origin struct {
+	//源站地址,如http://1.2.3.4:80
+	Peer string `json:"peer"`
+	//回源host
+	Host string `json:"host,omitempty"`
+	//是否为备源站
+	Backup bool `json:"backup"`
+	//权重,取值1-100
+	Weight int64 `json:"weight,omitempty"`
+}
+
+type CreateDomainRequest struct {
+	Origin []*Origin `json:"origin"`
+	//业务类型：default、image、download、media、dynamic
+	Form string `json:"form,omitempty"`
+	//默认回源host
+	DefaultHost string `json:"defaultHost,omitempty"`
+}
+
+type CreateDomainResponse struct {
+	Domain string `json:"domain"`
+	Cname  string `json:"cname"`
+	Status string `json:"status"`
+}
+
+type ListUserDomainsRequest struct {
+	//域名状态：ALL、RUNNING、STOPPED、OPERATING
+	Status string `json:"status,omitempty"`
+	Marker string `json:"marker,omitempty"`
+}
+
+type UserDomain struct {
+	Domain string `json:"domain"`
+	//域名状态：RUNNING、STOPPED、OPERATING
+	Status         string `json:"status"`
+	Cname          string `json:"cname"`
+	CreateTime     string `json:"createTime"`
+	LastModifyTime string `json:"lastModifyTime"`
+	IsBan          string `json:"isBan"`
+}
+
+type ListUserDomainsResponse struct {
+	Domains     []*UserDomain `json:"domains"`
+	IsTruncated bool          `json:"isTruncated"`
+	NextMarker  string        `json:"nextMarker"`
+}
+
+type CommonResponse struct {
+	RequestId string `json:"requestId"`
+}
@@ -0,0 +1,39 @@
+package model
+
+type StatRequest struct {
+	//统计指标：flow、pv、src_flow、pv_src、top_urls
+	Metric string `json:"metric"`
+	//开始时间,UTC时间,格式如：2006-01-02T15:04:05Z
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	//统计粒度,单位秒：300、3600、86400
+	Period int64 `json:"period,omitempty"`
+	//查询类型：0按域名查询
+	KeyType int64    `json:"key_type"`
+	Key     []string `json:"key,omitempty"`
+	//分组方式：key按域名分组
+	GroupBy string `json:"groupBy,omitempty"`
+	//top_urls查询的条数
+	Extra int64 `json:"extra,omitempty"`
+}
+
+type Counter struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+type StatDetail struct {
+	Timestamp string     `json:"timestamp"`
+	Key       string     `json:"key"`
+	Flow      float64    `json:"flow"`
+	Bps       float64    `json:"bps"`
+	Pv        float64    `json:"pv"`
+	Qps       float64    `json:"qps"`
+	Counters  []*Counter `json:"counters"`
+}
+
+type StatResponse struct {
+	Status  string        `json:"status"`
+	Count   int64         `json:"count"`
+	Details []*StatDetail `json:"details"`
+}
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AuthVersion       = "bce-auth-v1"
+	ExpirationSeconds = 1800
+	TimestampFormat   = "2006-01-02T15:04:05Z"
+	BceDate           = "x-bce-date"
+	BcePrefix         = "x-bce-"
+	Authorization     = "Authorization"
+)
+
+// Signer 百度智能云BCE签名,实现rest.Signer
+type Signer struct {
+	AccessKey string
+	SecretKey string
+}
+
+func NewSigner(accessKey string, secretKey string) *Signer {
+	return &Signer{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+	}
+}
+
+// Sign 生成bce-auth-v1认证字符串,签名头部为host、content-*与x-bce-*头部
+func (s *Signer) Sign(req *http.Request, body []byte, t time.Time) error {
+	timestamp := t.UTC().Format(TimestampFormat)
+	req.Header.Set(BceDate, timestamp)
+	authPrefix := fmt.Sprintf("%s/%s/%s/%d", AuthVersion, s.AccessKey, timestamp, ExpirationSeconds)
+	signingKey := hmacSha256Hex(s.SecretKey, authPrefix)
+	headers, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := req.Method + "\n" +
+		uriEncode(req.URL.Path, false) + "\n" +
+		canonicalQuery(req.URL.Query()) + "\n" +
+		headers
+	signature := hmacSha256Hex(signingKey, canonicalRequest)
+	req.Header.Set(Authorization, authPrefix+"/"+signedHeaders+"/"+signature)
+	return nil
+}
+
+// canonicalQuery 查询参数编码后按字典序排列,不包含authorization参数
+func canonicalQuery(query url.Values) string {
+	items := make([]string, 0, len(query))
+	for k, values := range query {
+		if strings.ToLower(k) == "authorization" {
+			continue
+		}
+		for _, v := range values {
+			items = append(items, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	sort.Strings(items)
+	return strings.Join(items, "&")
+}
+
+// canonicalHeaders 获取参与签名的头部及头部名称列表
+func canonicalHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.Host}
+	if req.ContentLength > 0 {
+		headers["content-length"] = strconv.FormatInt(req.ContentLength, 10)
+	}
+	for k := range req.Header {
+		name := strings.ToLower(k)
+		if name == "content-type" || name == "content-md5" || strings.HasPrefix(name, BcePrefix) {
+			headers[name] = strings.TrimSpace(req.Header.Get(k))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	items := make([]string, 0, len(headers))
+	for k, v := range headers {
+		if v == "" {
+			continue
+		}
+		names = append(names, k)
+		items = append(items, uriEncode(k, true)+":"+uriEncode(v, true))
+	}
+	sort.Strings(names)
+	sort.Strings(items)
+	return strings.Join(items, "\n"), strings.Join(names, ";")
+}
+
+// uriEncode 除字母、数字与-_.~外全部编码,encodeSlash为false时保留/
+func uriEncode(s string, encodeSlash bool) string {
+	var build strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			build.WriteByte(c)
+			continue
+		}
+		build.WriteString(fmt.Sprintf("%%%02X", c))
+	}
+	return build.String()
+}
+
+func hmacSha256Hex(key string, data string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(data))
+	return hex.EncodeToString(h.Sum(nil))
+}
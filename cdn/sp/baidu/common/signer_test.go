@@ -0,0 +1,78 @@
+package common
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSignDocumentedExample 百度智能云生成认证字符串文档中的示例
+func TestSignDocumentedExample(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "http://bj.bcebos.com/v1/test/myfolder/readme.txt?partNumber=9&uploadId=a44cc9bab11cbd156984767aad637851", strings.NewReader("Example\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Content-Md5", "NFzcPqhviddjRNnSOGo4rw==")
+	signer := NewSigner("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	if err := signer.Sign(req, nil, time.Date(2015, 4, 27, 8, 23, 49, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	want := "bce-auth-v1/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/2015-04-27T08:23:49Z/1800/content-length;content-md5;content-type;host;x-bce-date/" +
+		"d74a04362e6a848f5b39b15421cb449427f419c95a480fd6b8cf9fc783e2999e"
+	if got := req.Header.Get(Authorization); got != want {
+		t.Fatalf("got authorization\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSignGolden(t *testing.T) {
+	body := `{"origin":[{"peer":"1.1.1.1"}]}`
+	req, err := http.NewRequest(http.MethodPut, "https://cdn.baidubce.com/v2/domain/www.example.com/config?origin&note=a%20b%2Fc~d", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Bce-Request-Id", " req-1 ")
+	req.Header.Set("Accept", "application/json")
+	//非UTC时间需转换为UTC
+	ts := time.Date(2024, 1, 1, 8, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	if err := NewSigner("ak", "sk").Sign(req, []byte(body), ts); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get(BceDate); got != "2024-01-01T00:00:00Z" {
+		t.Fatalf("got %s %s", BceDate, got)
+	}
+	//空值参数保留等号,/与空格均需编码
+	if got, want := canonicalQuery(req.URL.Query()), "note=a%20b%2Fc~d&origin="; got != want {
+		t.Fatalf("got canonical query %s, want %s", got, want)
+	}
+	//只签名host、content-*与x-bce-*头部,头部值去除首尾空白后编码
+	headers, signedHeaders := canonicalHeaders(req)
+	wantHeaders := "content-length:31\ncontent-type:application%2Fjson\nhost:cdn.baidubce.com\nx-bce-date:2024-01-01T00%3A00%3A00Z\nx-bce-request-id:req-1"
+	if headers != wantHeaders || signedHeaders != "content-length;content-type;host;x-bce-date;x-bce-request-id" {
+		t.Fatalf("got canonical headers\n%s\n%s", headers, signedHeaders)
+	}
+	want := "bce-auth-v1/ak/2024-01-01T00:00:00Z/1800/content-length;content-type;host;x-bce-date;x-bce-request-id/ddc5bae5ba47b62401bfc5084ed3245aebf6feb314ff0f986a6dae1e9b515c50"
+	if got := req.Header.Get(Authorization); got != want {
+		t.Fatalf("got authorization\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUriEncode(t *testing.T) {
+	cases := []struct {
+		s           string
+		encodeSlash bool
+		want        string
+	}{
+		{"/v2/domain/www.example.com", false, "/v2/domain/www.example.com"},
+		{"/v2/domain/www.example.com", true, "%2Fv2%2Fdomain%2Fwww.example.com"},
+		{"a b+c*d~e_f-g", true, "a%20b%2Bc%2Ad~e_f-g"},
+		{"中", true, "%E4%B8%AD"},
+	}
+	for _, c := range cases {
+		if got := uriEncode(c.s, c.encodeSlash); got != c.want {
+			t.Fatalf("uriEncode(%q, %v) = %s, want %s", c.s, c.encodeSlash, got, c.want)
+		}
+	}
+}
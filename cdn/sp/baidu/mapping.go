@@ -0,0 +1,341 @@
+package baidu
+
+import (
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common/model"
+	"strings"
+	"time"
+)
+
+const (
+	purgeTypeFile      = "file"
+	purgeTypeDirectory = "directory"
+	headerTypeOrigin   = "origin"
+	headerTypeResponse = "response"
+	cacheTypeCode      = "code"
+	metricFlow         = "flow"
+	metricPv           = "pv"
+	metricSrcFlow      = "src_flow"
+	metricSrcPv        = "pv_src"
+	metricTopUrls      = "top_urls"
+)
+
+// GetForm 业务类型转百度云业务类型
+func getForm(channelType int64) string {
+	switch channelType {
+	case consts.ChannelTypeWeb:
+		return "default"
+	case consts.ChannelTypeDownload:
+		return "download"
+	case consts.ChannelTypeMedia:
+		return "media"
+	case consts.ChannelTypeHybrid:
+		return "dynamic"
+	default:
+		return "default"
+	}
+}
+
+// MapForm 百度云业务类型转业务类型
+func mapForm(form string) int64 {
+	switch form {
+	case "default", "image":
+		return consts.ChannelTypeWeb
+	case "download":
+		return consts.ChannelTypeDownload
+	case "media":
+		return consts.ChannelTypeMedia
+	case "dynamic":
+		return consts.ChannelTypeHybrid
+	default:
+		return consts.ChannelTypeWeb
+	}
+}
+
+// GetDomainStatus 获取域名状态
+func getDomainStatus(status string) int64 {
+	switch status {
+	case "RUNNING":
+		return consts.CdnDomainStatusDeployed
+	case "STOPPED":
+		return consts.CdnDomainStatusStoped
+	case "OPERATING":
+		return consts.CdnDomainStatusDeploying
+	default:
+		return consts.CdnDomainStatusDeploying
+	}
+}
+
+// SetDomainStatus 域名状态转百度云域名状态
+func setDomainStatus(status int64) string {
+	switch status {
+	case consts.CdnDomainStatusDeployed:
+		return "RUNNING"
+	case consts.CdnDomainStatusStoped:
+		return "STOPPED"
+	case consts.CdnDomainStatusDeploying:
+		return "OPERATING"
+	default:
+		return ""
+	}
+}
+
+// GetOriginScheme 获取源站地址协议
+func getOriginScheme(protocol int64) string {
+	if protocol == consts.OriginProtocolHttps {
+		return "https"
+	}
+	return "http"
+}
+
+// GetOriginPort 按回源协议获取源站端口
+func getOriginPort(protocol int64, source *entity.OriginServerConf) int64 {
+	if protocol == consts.OriginProtocolHttps {
+		if source.OriginHttpsPort != 0 {
+			return source.OriginHttpsPort
+		}
+		return 443
+	}
+	if source.OriginHttpPort != 0 {
+		return source.OriginHttpPort
+	}
+	return 80
+}
+
+// GetOrigins 源站信息转百度云源站,地址以peer形式指定协议与端口
+func getOrigins(protocol int64, sources []*entity.OriginServerConf) []*model.Origin {
+	items := make([]*model.Origin, 0, len(sources))
+	for _, v := range sources {
+		for _, address := range strings.Split(v.OriginAddressList, ",") {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				continue
+			}
+			items = append(items, &model.Origin{
+				Peer:   fmt.Sprintf("%s://%s:%d", getOriginScheme(protocol), address, getOriginPort(protocol, v)),
+				Host:   v.OriginHost,
+				Backup: v.OriginPriority == consts.OriginPriorityBackup,
+				Weight: v.OriginWeight,
+			})
+		}
+	}
+	return items
+}
+
+// GetOriginProtocol 获取回源协议
+func getOriginProtocol(protocol int64) string {
+	switch protocol {
+	case consts.OriginProtocolHttp:
+		return "http"
+	case consts.OriginProtocolHttps:
+		return "https"
+	case consts.OriginProtocolFollow:
+		return "*"
+	default:
+		return "http"
+	}
+}
+
+// GetSwitch 数字开关转百度云开关
+func getSwitch(status int64) string {
+	if status == consts.SwitchOn {
+		return consts.ON
+	}
+	return consts.OFF
+}
+
+// GetHeaderAction 获取头部操作类型,百度云设置与添加均为add
+func getHeaderAction(action int64) string {
+	if action == consts.OriginHeaderActionDelete {
+		return "remove"
+	}
+	return "add"
+}
+
+// GetAuthType 获取鉴权类型,百度云不支持TypeD
+func getAuthType(manner int64) string {
+	switch manner {
+	case consts.AccessAuthMannerTypeA:
+		return "A"
+	case consts.AccessAuthMannerTypeB:
+		return "B"
+	case consts.AccessAuthMannerTypeC:
+		return "C"
+	default:
+		return ""
+	}
+}
+
+// GetCacheTTLs 缓存规则转百度云缓存规则,每项规则内容对应一条缓存规则
+func getCacheTTLs(item *entity.CacheListItem) []*model.CacheTTL {
+	ttl := cachettl.Seconds(item.CacheTTL, item.CacheUnit)
+	if item.CacheStatus == consts.CacheStatusOff {
+		ttl = 0
+	}
+	//权重取值0-100,越大优先级越高
+	weight := item.Priority
+	if weight < 0 {
+		weight = 0
+	}
+	if weight > 100 {
+		weight = 100
+	}
+	newCacheTTL := func(t string, value string) *model.CacheTTL {
+		return &model.CacheTTL{Type: t, Value: value, TTL: ttl, Weight: weight}
+	}
+	items := make([]*model.CacheTTL, 0, len(item.CacheContent))
+	switch item.CacheType {
+	case consts.RuleTypeFileSuffix:
+		for _, v := range item.CacheContent {
+			items = append(items, newCacheTTL("suffix", "."+strings.TrimPrefix(v, ".")))
+		}
+	case consts.RuleTypeDirectory:
+		for _, v := range item.CacheContent {
+			items = append(items, newCacheTTL("path", "/"+strings.Trim(v, "/")))
+		}
+	case consts.RuleTypePath:
+		for _, v := range item.CacheContent {
+			items = append(items, newCacheTTL("exactPath", v))
+		}
+	default:
+		items = append(items, newCacheTTL("path", "/"))
+	}
+	return items
+}
+
+// GetCompressType 获取压缩方式,同时开启gzip与brotli时为all
+func getCompressType(gzip bool, brotli bool) string {
+	switch {
+	case gzip && brotli:
+		return "all"
+	case brotli:
+		return "br"
+	default:
+		return "gzip"
+	}
+}
+
+// GetRedirectCode 获取跳转状态码
+func getRedirectCode(code int64) int64 {
+	if code == consts.RedirectCode301 {
+		return 301
+	}
+	return 302
+}
+
+// GetSslVersion 获取最低TLS版本
+func getSslVersion(versions []int64) string {
+	if len(versions) == 0 {
+		return ""
+	}
+	min := versions[0]
+	for _, v := range versions {
+		if v < min {
+			min = v
+		}
+	}
+	switch min {
+	case consts.HttpsTlsVersionSSLv0:
+		return "TLSV1"
+	case consts.HttpsTlsVersionSSLv1:
+		return "TLSV11"
+	case consts.HttpsTlsVersionSSLv2, consts.HttpsTlsVersionTLSv3:
+		return "TLSV12"
+	default:
+		return ""
+	}
+}
+
+// GetShowContentPurgeOrPushStatus 获取刷新或预热任务状态
+func getShowContentPurgeOrPushStatus(status string) int64 {
+	switch status {
+	case "completed":
+		return consts.ShowContentPurgeOrPushStatusSuccess
+	case "in-progress", "waiting":
+		return consts.ShowContentPurgeOrPushStatusDoing
+	case "failed":
+		return consts.ShowContentPurgeOrPushStatusFail
+	default:
+		return consts.ShowContentPurgeOrPushStatusFail
+	}
+}
+
+// GetPurgeType 获取刷新任务类型
+func getPurgeType(t int64) string {
+	switch t {
+	case consts.ShowContentPurgeTypeUrl:
+		return purgeTypeFile
+	case consts.ShowContentPurgeTypePath:
+		return purgeTypeDirectory
+	default:
+		return purgeTypeFile
+	}
+}
+
+// GetPeriod 获取统计粒度,单位秒
+func getPeriod(t int64) int64 {
+	switch t {
+	case consts.DataIntervalTypeFiveMinute:
+		return 300
+	case consts.DataIntervalTypeHour:
+		return 3600
+	case consts.DataIntervalTypeDay:
+		return 86400
+	default:
+		return 300
+	}
+}
+
+// GetDataAccessMetric 获取访问数据指标对应的统计指标,不支持的指标返回空
+func getDataAccessMetric(t int64) string {
+	switch t {
+	case consts.DataAccessMetricTypeFlux, consts.DataAccessMetricTypeBandwidth:
+		return metricFlow
+	case consts.DataAccessMetricTypeRequest:
+		return metricPv
+	default:
+		return ""
+	}
+}
+
+// GetDataOriginMetric 获取回源数据指标对应的统计指标,不支持的指标返回空
+func getDataOriginMetric(t int64) string {
+	switch t {
+	case consts.DataOriginMetricTypeFlux, consts.DataOriginMetricTypeBandwidth:
+		return metricSrcFlow
+	case consts.DataOriginMetricTypeRequest:
+		return metricSrcPv
+	default:
+		return ""
+	}
+}
+
+// GetStatisticsValue 获取指标值,bandwidth为true时取带宽,流量与带宽同一统计指标返回
+func getStatisticsValue(metric string, bandwidth bool, data *model.StatDetail) float64 {
+	switch {
+	case metric == metricPv || metric == metricSrcPv:
+		return data.Pv
+	case bandwidth:
+		return data.Bps
+	default:
+		return data.Flow
+	}
+}
+
+// FormatTime 时间戳格式化为百度云UTC时间
+func formatTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(common.TimestampFormat)
+}
+
+// ParseTime 百度云时间转时间戳
+func parseTime(t string) int64 {
+	tm, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return 0
+	}
+	return tm.Unix()
+}
@@ -0,0 +1,235 @@
+package baidu
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/baidu/common/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// standIn 百度云CDN接口的本地替身,校验请求签名并在内存中保存域名配置
+type standIn struct {
+	*httptest.Server
+	signer  *common.Signer
+	mu      sync.Mutex
+	domains map[string]*model.DomainConfig
+	certs   map[string]*model.Certificate
+	calls   []string
+}
+
+func newStandIn(t *testing.T) (*standIn, *Baidu) {
+	t.Helper()
+	s := &standIn{
+		signer:  common.NewSigner("ak", "sk"),
+		domains: make(map[string]*model.DomainConfig),
+		certs:   make(map[string]*model.Certificate),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	b, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, b
+}
+
+// addDomain 添加运行中的域名
+func (s *standIn) addDomain(name string) *model.DomainConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := s.create(name, &model.CreateDomainRequest{Form: "default"})
+	d.Status = "RUNNING"
+	return d
+}
+
+func (s *standIn) create(name string, req *model.CreateDomainRequest) *model.DomainConfig {
+	d := &model.DomainConfig{
+		Domain:         name,
+		Cname:          name + ".a.bdydns.com",
+		Status:         "OPERATING",
+		CreateTime:     "2024-01-01T00:00:00Z",
+		LastModifyTime: "2024-01-02T00:00:00Z",
+		Form:           req.Form,
+		Origin:         req.Origin,
+		DefaultHost:    req.DefaultHost,
+	}
+	s.domains[name] = d
+	return d
+}
+
+// domain 获取域名配置
+func (s *standIn) domain(name string) *model.DomainConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.domains[name]
+}
+
+func (s *standIn) callList() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func (s *standIn) resetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+}
+
+// verify 以请求中的x-bce-date重新签名
+func (s *standIn) verify(r *http.Request, body []byte) bool {
+	ts, err := time.Parse(common.TimestampFormat, r.Header.Get(common.BceDate))
+	if err != nil || time.Since(ts) > 5*time.Minute {
+		return false
+	}
+	req := r.Clone(context.Background())
+	req.Header.Del(common.Authorization)
+	if err := s.signer.Sign(req, body, ts); err != nil {
+		return false
+	}
+	return req.Header.Get(common.Authorization) == r.Header.Get(common.Authorization)
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	call := r.Method + " " + r.URL.Path
+	if r.URL.RawQuery != "" && r.URL.Path != "/v2/user/domains" {
+		call += "?" + r.URL.RawQuery
+	}
+	s.calls = append(s.calls, call)
+	if !s.verify(r, body) {
+		fail(w, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+	if r.URL.Path == "/v2/user/domains" {
+		s.listDomains(w, r.URL.Query().Get("status"))
+		return
+	}
+	//证书接口路径为/v2/{domain}/certificates
+	if strings.HasSuffix(r.URL.Path, "/certificates") {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/"), "/certificates")
+		req := &model.PutCertificateRequest{}
+		if _, ok := s.domains[name]; !ok || json.Unmarshal(body, req) != nil {
+			fail(w, http.StatusBadRequest, "InvalidCertificate")
+			return
+		}
+		s.certs[name] = req.Certificate
+		reply(w, &model.PutCertificateResponse{CertId: "cert-" + name})
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/v2/domain/")
+	config := strings.HasSuffix(name, "/config")
+	name = strings.TrimSuffix(name, "/config")
+	if r.Method == http.MethodPut && !config {
+		if _, ok := s.domains[name]; ok {
+			fail(w, http.StatusConflict, "DomainAlreadyExists")
+			return
+		}
+		req := &model.CreateDomainRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			fail(w, http.StatusBadRequest, "MalformedJSON")
+			return
+		}
+		d := s.create(name, req)
+		reply(w, &model.CreateDomainResponse{Domain: name, Cname: d.Cname, Status: d.Status})
+		return
+	}
+	d, ok := s.domains[name]
+	if !ok {
+		fail(w, http.StatusNotFound, "NoSuchDomain")
+		return
+	}
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && config:
+		reply(w, d)
+	case r.Method == http.MethodPut && config:
+		if err := setConfig(d, query, body); err != nil {
+			fail(w, http.StatusBadRequest, "InvalidConfig")
+			return
+		}
+		reply(w, &model.CommonResponse{})
+	case r.Method == http.MethodPost && query.Has("enable"):
+		d.Status = "RUNNING"
+		reply(w, &model.CommonResponse{})
+	case r.Method == http.MethodPost && query.Has("disable"):
+		d.Status = "STOPPED"
+		reply(w, &model.CommonResponse{})
+	case r.Method == http.MethodDelete:
+		delete(s.domains, name)
+		reply(w, &model.CommonResponse{})
+	default:
+		fail(w, http.StatusBadRequest, "InvalidRequest")
+	}
+}
+
+// setConfig 按查询参数中的配置项名称替换域名配置,源站与默认回源host同时提交
+func setConfig(d *model.DomainConfig, query map[string][]string, body []byte) error {
+	if len(query) != 1 {
+		return io.ErrUnexpectedEOF
+	}
+	update := &model.DomainConfig{}
+	if err := json.Unmarshal(body, update); err != nil {
+		return err
+	}
+	for key := range query {
+		keys := []string{key}
+		if key == "origin" {
+			keys = append(keys, "defaultHost")
+		}
+		for _, k := range keys {
+			if !copyField(d, update, k) {
+				return io.ErrUnexpectedEOF
+			}
+		}
+	}
+	return nil
+}
+
+// copyField 复制json字段名为key的字段
+func copyField(dst *model.DomainConfig, src *model.DomainConfig, key string) bool {
+	dv, sv := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := 0; i < dv.NumField(); i++ {
+		if strings.Split(dv.Type().Field(i).Tag.Get("json"), ",")[0] == key {
+			dv.Field(i).Set(sv.Field(i))
+			return true
+		}
+	}
+	return false
+}
+
+func (s *standIn) listDomains(w http.ResponseWriter, status string) {
+	res := &model.ListUserDomainsResponse{Domains: make([]*model.UserDomain, 0)}
+	for _, v := range s.domains {
+		if status != "" && status != "ALL" && v.Status != status {
+			continue
+		}
+		res.Domains = append(res.Domains, &model.UserDomain{Domain: v.Domain, Status: v.Status, Cname: v.Cname})
+	}
+	sort.Slice(res.Domains, func(i, j int) bool { return res.Domains[i].Domain < res.Domains[j].Domain })
+	reply(w, res)
+}
+
+func fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(common.XBceRequestId, "req-1")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"requestId": "req-body", "code": code, "message": code})
+}
+
+func reply(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(common.XBceRequestId, "req-1")
+	_ = json.NewEncoder(w).Encode(v)
+}
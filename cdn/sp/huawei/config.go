@@ -0,0 +1,400 @@
+package huawei
+
+import (
+	"context"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+)
+
+// ShowDomainConfig 获取域名完整配置
+func (h *Huawei) ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return h.ShowDomainConfigWithContext(h.ctx, req)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置,华为云不返回证书私钥,HttpsConf.CertKey始终为空
+func (h *Huawei) ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	if req == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	request := &model.ShowDomainFullConfigRequest{}
+	request.DomainName = req.Domain
+	res, err := invoke(ctx, h, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowDomainFullConfigResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainFullConfig(request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Configs == nil {
+		return nil, cdnerr.New(cdnerr.ErrDomainNotFound, types.HuaWeiSdkName, "domain config not found")
+	}
+	c := &DomainConfigModel{configs: res.Configs, req: &types.UpdateDomainRequest{
+		UpdateAction: types.UpdateFullConf,
+		Domain:       req.Domain,
+		DomainId:     req.DomainId,
+	}}
+	c.ReadBaseConf()
+	c.ReadOriginConf()
+	c.ReadOriginServerConf()
+	c.ReadOriginAdvanceConf()
+	c.ReadOriginRequestHeaderConf()
+	c.ReadOriginUrlConf()
+	c.ReadResponseHeaderConf()
+	c.ReadIntelligentCompressionConf()
+	c.ReadCustomErrorPageConf()
+	c.ReadCacheListConf()
+	c.ReadCacheBrowserConf()
+	c.ReadCacheCodeConf()
+	c.ReadRequestUrlRewriteConf()
+	c.ReadIpFilterConf()
+	c.ReadRefererConf()
+	c.ReadUserAgentConf()
+	c.ReadAuthConf()
+	c.ReadRemoteAuthConf()
+	c.ReadIpFrequencyConf()
+	c.ReadHttpsConf()
+	return c.req, nil
+}
+
+// DomainConfigModel 将华为云域名配置反向映射为通用的更新请求,与 UpdateDomainModel 的With*方法一一对应
+type DomainConfigModel struct {
+	configs *model.ConfigsGetBody
+	req     *types.UpdateDomainRequest
+}
+
+func (c *DomainConfigModel) ReadBaseConf() {
+	c.req.CdnDomain = &entity.UpdateCdnDomainBaseConf{
+		AreaCode:    mapAreaCode(utils.StringValue(c.configs.ServiceArea)),
+		SupportIpv6: int64(utils.Int32Value(c.configs.Ipv6Accelerate)),
+	}
+}
+
+func (c *DomainConfigModel) ReadOriginConf() {
+	c.req.OriginConf = &entity.OriginConf{
+		OriginProtocol: mapOriginProtocol(utils.StringValue(c.configs.OriginProtocol)),
+		OriginFollow:   mapSwitch(utils.StringValue(c.configs.OriginFollow302Status)),
+		OriginRange:    mapSwitch(utils.StringValue(c.configs.OriginRangeStatus)),
+		OriginTimeOut:  int64(utils.Int32Value(c.configs.OriginReceiveTimeout)),
+	}
+	if c.configs.Sni != nil {
+		c.req.OriginConf.OriginSniSwitch = mapSwitch(c.configs.Sni.Status)
+		c.req.OriginConf.OriginSniValue = utils.StringValue(c.configs.Sni.ServerName)
+	}
+}
+
+func (c *DomainConfigModel) ReadOriginServerConf() {
+	c.req.OriginServerConf = make([]*entity.OriginServerConf, 0)
+	if c.configs.Sources == nil {
+		return
+	}
+	for _, v := range *c.configs.Sources {
+		c.req.OriginServerConf = append(c.req.OriginServerConf, &entity.OriginServerConf{
+			OriginType:        mapOriginType(v.OriginType),
+			OriginAddressList: v.OriginAddr,
+			OriginHttpPort:    int64(utils.Int32Value(v.HttpPort)),
+			OriginHttpsPort:   int64(utils.Int32Value(v.HttpsPort)),
+			OriginHost:        utils.StringValue(v.HostName),
+			OriginWeight:      int64(utils.Int32Value(v.Weight)),
+			OriginPriority:    mapPrimaryOrBack(v.Priority),
+		})
+	}
+}
+
+// ReadOriginAdvanceConf 每个回源源站对应一条高级回源配置
+func (c *DomainConfigModel) ReadOriginAdvanceConf() {
+	c.req.OriginAdvanceServerConf = make([]*entity.OriginAdvanceServerConf, 0)
+	if c.configs.FlexibleOrigin == nil {
+		return
+	}
+	for _, v := range *c.configs.FlexibleOrigin {
+		mode := mapOriginAdvanceUrlMatchMode(v.MatchType)
+		for _, source := range v.BackSources {
+			c.req.OriginAdvanceServerConf = append(c.req.OriginAdvanceServerConf, &entity.OriginAdvanceServerConf{
+				UrlMatchMode:        mode,
+				UrlMatchRule:        mapOriginAdvanceUrlMatchRule(mode, v.MatchPattern),
+				OriginType:          mapOriginType(source.SourcesType),
+				OriginAddressList:   source.IpOrDomain,
+				OriginHttpPort:      int64(utils.Int32Value(source.HttpPort)),
+				OriginHttpsPort:     int64(utils.Int32Value(source.HttpsPort)),
+				OriginPriorityValue: int64(v.Priority),
+			})
+		}
+	}
+}
+
+func (c *DomainConfigModel) ReadOriginRequestHeaderConf() {
+	c.req.OriginRequestHeaderConf = make([]*entity.OriginRequestHeaderConf, 0)
+	if c.configs.OriginRequestHeader == nil {
+		return
+	}
+	for _, v := range *c.configs.OriginRequestHeader {
+		c.req.OriginRequestHeaderConf = append(c.req.OriginRequestHeaderConf, &entity.OriginRequestHeaderConf{
+			Action:         mapOriginHeaderAction(v.Action),
+			ParameterKey:   v.Name,
+			ParameterValue: utils.StringValue(v.Value),
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadOriginUrlConf() {
+	c.req.OriginUrlConf = make([]*entity.OriginUrlConf, 0)
+	if c.configs.OriginRequestUrlRewrite == nil {
+		return
+	}
+	for _, v := range *c.configs.OriginRequestUrlRewrite {
+		c.req.OriginUrlConf = append(c.req.OriginUrlConf, &entity.OriginUrlConf{
+			MateMethod: mapOriginUrlMateMethod(v.MatchType),
+			RewriteUrl: utils.StringValue(v.SourceUrl),
+			TargetUrl:  v.TargetUrl,
+			Priority:   int64(v.Priority),
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadResponseHeaderConf() {
+	c.req.ResponseHeaderConf = make([]*entity.ResponseHeaderConf, 0)
+	if c.configs.HttpResponseHeader == nil {
+		return
+	}
+	for _, v := range *c.configs.HttpResponseHeader {
+		c.req.ResponseHeaderConf = append(c.req.ResponseHeaderConf, &entity.ResponseHeaderConf{
+			ParameterKey:   v.Name,
+			ParameterValue: utils.StringValue(v.Value),
+			Action:         mapOriginHeaderAction(v.Action),
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadIntelligentCompressionConf() {
+	c.req.IntelligentCompressionConf = &types.IntelligentCompressionConf{Status: consts.SwitchOff}
+	if c.configs.Compress == nil {
+		return
+	}
+	c.req.IntelligentCompressionConf.Status = mapSwitch(c.configs.Compress.Status)
+	if c.configs.Compress.FileType == nil {
+		return
+	}
+	c.req.IntelligentCompressionConf.IntelligentCompressionConf = []*entity.IntelligentCompressionConf{{
+		CompressType:    consts.CompressRuleTypeFileSuffix,
+		CompressContent: mapFileType(*c.configs.Compress.FileType),
+		CompressMethod:  mapIntelligentCompressionCompressMethod(utils.StringValue(c.configs.Compress.Type)),
+		Status:          c.req.IntelligentCompressionConf.Status,
+	}}
+}
+
+func (c *DomainConfigModel) ReadCustomErrorPageConf() {
+	c.req.CustomErrorPageConf = make([]*entity.CustomErrorPageConf, 0)
+	if c.configs.ErrorCodeRedirectRules == nil {
+		return
+	}
+	for _, v := range *c.configs.ErrorCodeRedirectRules {
+		c.req.CustomErrorPageConf = append(c.req.CustomErrorPageConf, &entity.CustomErrorPageConf{
+			StatusCode:   int64(v.ErrorCode),
+			RedirectCode: mapRedirectCode(v.TargetCode),
+			GoalAddress:  v.TargetLink,
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadCacheListConf() {
+	c.req.CacheListConf = make([]*entity.CacheListItem, 0)
+	if c.configs.CacheRules == nil {
+		return
+	}
+	for _, v := range *c.configs.CacheRules {
+		cacheType := mapRuleType(utils.StringValue(v.MatchType))
+		parametersStatus := mapCacheParameterStatus(utils.StringValue(v.UrlParameterType))
+		item := &entity.CacheListItem{
+			CacheType:        cacheType,
+			CacheContent:     mapRulePaths(cacheType, utils.StringValue(v.MatchValue)),
+			CacheTTL:         int64(utils.Int32Value(v.Ttl)),
+			CacheUnit:        mapCacheUnit(v.TtlUnit),
+			CacheStatus:      mapCacheStatus(utils.StringValue(v.FollowOrigin), utils.Int32Value(v.Ttl)),
+			Priority:         int64(v.Priority),
+			ParametersStatus: parametersStatus,
+		}
+		if parametersStatus == consts.CacheParameterStatusInclude || parametersStatus == consts.CacheParameterStatusExclude {
+			item.ParametersValue = utils.SplitString(utils.StringValue(v.UrlParameterValue), ",")
+		}
+		c.req.CacheListConf = append(c.req.CacheListConf, item)
+	}
+}
+
+func (c *DomainConfigModel) ReadCacheBrowserConf() {
+	c.req.BrowserCacheConf = make([]*entity.BrowserCacheListItem, 0)
+	if c.configs.BrowserCacheRules == nil {
+		return
+	}
+	for _, v := range *c.configs.BrowserCacheRules {
+		item := &entity.BrowserCacheListItem{
+			CacheStatus: mapCacheBrowserCacheStatus(v.CacheType),
+			CacheTTL:    int64(utils.Int32Value(v.Ttl)),
+			CacheUnit:   mapCacheUnit(utils.StringValue(v.TtlUnit)),
+		}
+		if v.Condition != nil {
+			item.CacheType = mapRuleType(v.Condition.MatchType)
+			item.CacheContent = mapRulePaths(item.CacheType, utils.StringValue(v.Condition.MatchValue))
+			item.Priority = int64(v.Condition.Priority)
+		}
+		c.req.BrowserCacheConf = append(c.req.BrowserCacheConf, item)
+	}
+}
+
+// ReadCacheCodeConf 华为云状态码缓存时间的单位为秒
+func (c *DomainConfigModel) ReadCacheCodeConf() {
+	c.req.CacheCodeConf = make([]*entity.CacheCodeListItem, 0)
+	if c.configs.ErrorCodeCache == nil {
+		return
+	}
+	for _, v := range *c.configs.ErrorCodeCache {
+		c.req.CacheCodeConf = append(c.req.CacheCodeConf, &entity.CacheCodeListItem{
+			HttpCode:  int64(utils.Int32Value(v.Code)),
+			CacheTTL:  int64(utils.Int32Value(v.Ttl)),
+			CacheUnit: consts.CacheUnitSecond,
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadRequestUrlRewriteConf() {
+	c.req.RequestUrlRewriteConf = make([]*entity.RequestUrlRewriteConf, 0)
+	if c.configs.RequestUrlRewrite == nil {
+		return
+	}
+	for _, v := range *c.configs.RequestUrlRewrite {
+		item := &entity.RequestUrlRewriteConf{
+			TargetUrl:    v.RedirectUrl,
+			RedirectCode: mapRedirectCode(utils.Int32Value(v.RedirectStatusCode)),
+		}
+		if v.Condition != nil {
+			item.MateMethod = mapRequestUrlRewriteType(v.Condition.MatchType)
+			item.RewriteUrl = v.Condition.MatchValue
+			item.Priority = int64(v.Condition.Priority)
+		}
+		c.req.RequestUrlRewriteConf = append(c.req.RequestUrlRewriteConf, item)
+	}
+}
+
+func (c *DomainConfigModel) ReadIpFilterConf() {
+	c.req.IpFilterConf = &types.IpFilterConf{Status: consts.SwitchOff}
+	if c.configs.IpFilter == nil || c.configs.IpFilter.Type == consts.OFF {
+		return
+	}
+	c.req.IpFilterConf.Status = consts.SwitchOn
+	c.req.IpFilterConf.IpFilterConf = []*entity.IpFilter{{
+		IpType: mapWhiteOrBlackList(c.configs.IpFilter.Type),
+		IpList: utils.SplitString(utils.StringValue(c.configs.IpFilter.Value), ","),
+	}}
+}
+
+func (c *DomainConfigModel) ReadRefererConf() {
+	c.req.RefererConf = &entity.Referer{Status: consts.SwitchOff}
+	if c.configs.Referer == nil {
+		return
+	}
+	if utils.BoolValue(c.configs.Referer.IncludeEmpty) {
+		c.req.RefererConf.IncludeEmpty = consts.SwitchOn
+	}
+	c.req.RefererConf.RefererList = utils.SplitString(utils.StringValue(c.configs.Referer.Value), ",")
+	if c.configs.Referer.Type != consts.OFF {
+		c.req.RefererConf.Status = consts.SwitchOn
+		c.req.RefererConf.RefererType = mapWhiteOrBlackList(c.configs.Referer.Type)
+	}
+}
+
+func (c *DomainConfigModel) ReadUserAgentConf() {
+	c.req.UserAgentConf = &types.UserAgentConf{Status: consts.SwitchOff}
+	if c.configs.UserAgentFilter == nil || c.configs.UserAgentFilter.Type == consts.OFF {
+		return
+	}
+	agents := utils.SplitString(utils.StringValue(c.configs.UserAgentFilter.Value), ",")
+	if c.configs.UserAgentFilter.UaList != nil {
+		agents = *c.configs.UserAgentFilter.UaList
+	}
+	c.req.UserAgentConf.Status = consts.SwitchOn
+	c.req.UserAgentConf.UserAgentConf = []*entity.UserAgent{{
+		AgentType: mapWhiteOrBlackList(c.configs.UserAgentFilter.Type),
+		AgentList: agents,
+	}}
+}
+
+func (c *DomainConfigModel) ReadAuthConf() {
+	c.req.AuthConf = &entity.AuthConf{Status: consts.SwitchOff}
+	auth := c.configs.UrlAuth
+	if auth == nil {
+		return
+	}
+	c.req.AuthConf = &entity.AuthConf{
+		AuthManner:     mapAccessAuthMannerType(utils.StringValue(auth.Type)),
+		AuthRange:      consts.AccessAuthRangeAll,
+		AuthKey:        utils.StringValue(auth.Key),
+		AuthKeyBackup:  utils.StringValue(auth.BackupKey),
+		AuthParameter:  utils.StringValue(auth.SignArg),
+		EncryptMannger: mapAccessAuthEncryptManner(utils.StringValue(auth.SignMethod)),
+		TimeFormat:     mapAccessAuthTimeFormat(utils.StringValue(auth.TimeFormat)),
+		TimeValue:      int64(utils.Int32Value(auth.ExpireTime)),
+		Status:         mapSwitch(auth.Status),
+	}
+	if auth.InheritConfig != nil && auth.InheritConfig.InheritType != nil {
+		c.req.AuthConf.InheritConf = *auth.InheritConfig.InheritType
+		c.req.AuthConf.InteritStartTime = mapAccessAuthInheritTimeType(utils.StringValue(auth.InheritConfig.InheritTimeType))
+	}
+}
+
+func (c *DomainConfigModel) ReadRemoteAuthConf() {
+	c.req.RemoteAuthConf = &entity.RemoteAuthConf{Status: consts.SwitchOff}
+	if c.configs.RemoteAuth == nil {
+		return
+	}
+	c.req.RemoteAuthConf.Status = mapSwitch(c.configs.RemoteAuth.RemoteAuthentication)
+	rule := c.configs.RemoteAuth.RemoteAuthRules
+	if rule == nil {
+		return
+	}
+	c.req.RemoteAuthConf.AuthUrl = rule.AuthServer
+	c.req.RemoteAuthConf.ReqMethod = mapRemoteAuthRequestMethod(rule.RequestMethod)
+	c.req.RemoteAuthConf.FileType = mapAccessRemoteAuthFileType(rule.FileTypeSetting)
+	c.req.RemoteAuthConf.TimeoutDuration = int64(rule.Timeout)
+	c.req.RemoteAuthConf.TimeoutAction = mapAccessRemoteAuthTimeOutAction(rule.TimeoutAction)
+	if c.req.RemoteAuthConf.FileType == consts.FileTypeFile {
+		c.req.RemoteAuthConf.FileContent = utils.SplitString(utils.StringValue(rule.SpecifiedFileType), "|")
+	}
+}
+
+func (c *DomainConfigModel) ReadIpFrequencyConf() {
+	c.req.IpFrequencyConf = &entity.IpFrequencyConf{Status: consts.SwitchOff}
+	if c.configs.IpFrequencyLimit == nil {
+		return
+	}
+	c.req.IpFrequencyConf.Status = mapSwitch(c.configs.IpFrequencyLimit.Status)
+	c.req.IpFrequencyConf.Frequency = int64(utils.Int32Value(c.configs.IpFrequencyLimit.Qps))
+}
+
+func (c *DomainConfigModel) ReadHttpsConf() {
+	c.req.HttpsConf = &entity.HttpsConf{HttpsStatus: consts.SwitchOff}
+	if https := c.configs.Https; https != nil {
+		c.req.HttpsConf.HttpsStatus = mapSwitch(utils.StringValue(https.HttpsStatus))
+		c.req.HttpsConf.CertName = utils.StringValue(https.CertificateName)
+		c.req.HttpsConf.CertValue = utils.StringValue(https.CertificateValue)
+		c.req.HttpsConf.HttpTwo = mapSwitch(utils.StringValue(https.Http2Status))
+		c.req.HttpsConf.TlsVersion = mapTlsVersions(utils.StringValue(https.TlsVersion))
+		c.req.HttpsConf.OcspStatus = mapSwitch(utils.StringValue(https.OcspStaplingStatus))
+	}
+	if redirect := c.configs.ForceRedirect; redirect != nil {
+		c.req.HttpsConf.JumpForceStatus = mapSwitch(redirect.Status)
+		c.req.HttpsConf.JumpType = mapHttpsJumpType(utils.StringValue(redirect.Type))
+		c.req.HttpsConf.JumpManner = mapRedirectCode(utils.Int32Value(redirect.RedirectCode))
+	}
+	if hsts := c.configs.Hsts; hsts != nil {
+		c.req.HttpsConf.HstsStatus = mapSwitch(hsts.Status)
+		c.req.HttpsConf.HstsExpirationTime = int64(utils.Int32Value(hsts.MaxAge))
+		c.req.HttpsConf.HstsSubdomain = mapSwitch(utils.StringValue(hsts.IncludeSubdomains))
+	}
+	if c.configs.Quic != nil {
+		c.req.HttpsConf.QuicStatus = mapSwitch(c.configs.Quic.Status)
+	}
+}
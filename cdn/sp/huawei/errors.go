@@ -0,0 +1,78 @@
+package huawei
+
+import (
+	"errors"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"net/http"
+)
+
+// wrapError 将华为云SDK错误转换为统一错误
+func wrapError(err error) error {
+	var e *sdkerr.ServiceResponseError
+	if !errors.As(err, &e) {
+		return err
+	}
+	return &cdnerr.Error{
+		Kind:       getErrorKind(e.ErrorCode, e.StatusCode),
+		Provider:   types.HuaWeiSdkName,
+		Code:       e.ErrorCode,
+		Message:    e.ErrorMessage,
+		RequestId:  e.RequestId,
+		StatusCode: e.StatusCode,
+		Temporary:  e.StatusCode >= http.StatusInternalServerError,
+		Err:        err,
+	}
+}
+
+// GetErrorKind 错误码转错误分类
+func getErrorKind(code string, statusCode int) error {
+	switch code {
+	case "CDN.0170":
+		return cdnerr.ErrDomainNotFound
+	case "APIGW.0301", "APIGW.0303":
+		return cdnerr.ErrAuthFailed
+	case "APIGW.0308":
+		return cdnerr.ErrRateLimited
+	}
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return cdnerr.ErrAuthFailed
+	case http.StatusTooManyRequests:
+		return cdnerr.ErrRateLimited
+	case http.StatusBadRequest:
+		return cdnerr.ErrInvalidParameter
+	case http.StatusNotFound:
+		return cdnerr.ErrNotFound
+	default:
+		return nil
+	}
+}
+
+// newConfigError 客户端配置错误
+func newConfigError(message string, err error) error {
+	return &cdnerr.Error{
+		Kind:     cdnerr.ErrInvalidParameter,
+		Provider: types.HuaWeiSdkName,
+		Message:  message + ": " + err.Error(),
+		Err:      err,
+	}
+}
+
+// newStatusError 接口返回非成功状态码时的错误,按状态码归类,无法归类时为 cdnerr.ErrProvider
+func newStatusError(message string, statusCode int, requestId *string) error {
+	kind := getErrorKind("", statusCode)
+	if kind == nil {
+		kind = cdnerr.ErrProvider
+	}
+	return &cdnerr.Error{
+		Kind:       kind,
+		Provider:   types.HuaWeiSdkName,
+		Message:    message,
+		RequestId:  utils.StringValue(requestId),
+		StatusCode: statusCode,
+		Temporary:  statusCode >= http.StatusInternalServerError,
+	}
+}
@@ -0,0 +1,65 @@
+package huawei
+
+import (
+	"errors"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"net/http"
+	"testing"
+)
+
+func TestWrapError(t *testing.T) {
+	for _, tt := range []struct {
+		code      string
+		status    int
+		kind      error
+		temporary bool
+	}{
+		{"CDN.0170", http.StatusBadRequest, cdnerr.ErrDomainNotFound, false},
+		{"APIGW.0301", http.StatusUnauthorized, cdnerr.ErrAuthFailed, false},
+		{"APIGW.0303", http.StatusUnauthorized, cdnerr.ErrAuthFailed, false},
+		{"APIGW.0308", http.StatusTooManyRequests, cdnerr.ErrRateLimited, false},
+		{"", http.StatusForbidden, cdnerr.ErrAuthFailed, false},
+		{"", http.StatusTooManyRequests, cdnerr.ErrRateLimited, false},
+		{"CDN.0001", http.StatusBadRequest, cdnerr.ErrInvalidParameter, false},
+		{"", http.StatusNotFound, cdnerr.ErrNotFound, false},
+		{"CDN.0000", http.StatusInternalServerError, nil, true},
+		{"", http.StatusServiceUnavailable, nil, true},
+	} {
+		origin := &sdkerr.ServiceResponseError{StatusCode: tt.status, RequestId: "req-1", ErrorCode: tt.code, ErrorMessage: "message"}
+		err := wrapError(origin)
+		var e *cdnerr.Error
+		if !errors.As(err, &e) {
+			t.Fatalf("%s %d: got %T", tt.code, tt.status, err)
+		}
+		if e.Kind != tt.kind || e.Temporary != tt.temporary || e.Code != tt.code || e.RequestId != "req-1" || e.StatusCode != tt.status {
+			t.Errorf("%s %d: got %+v", tt.code, tt.status, e)
+		}
+		var sdkErr *sdkerr.ServiceResponseError
+		if !errors.As(err, &sdkErr) || sdkErr != origin {
+			t.Errorf("%s %d: sdk error not kept", tt.code, tt.status)
+		}
+	}
+	other := errors.New("network error")
+	if wrapError(other) != other || wrapError(nil) != nil {
+		t.Fatal("non sdk error changed")
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	requestId := "req-1"
+	for _, tt := range []struct {
+		status    int
+		kind      error
+		temporary bool
+	}{
+		{http.StatusNotFound, cdnerr.ErrNotFound, false},
+		{http.StatusConflict, cdnerr.ErrProvider, false},
+		{http.StatusBadGateway, cdnerr.ErrProvider, true},
+	} {
+		var e *cdnerr.Error
+		if !errors.As(newStatusError("failed", tt.status, &requestId), &e) || e.Kind != tt.kind || e.Temporary != tt.temporary || e.RequestId != requestId {
+			t.Errorf("%d: got %+v", tt.status, e)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package huawei
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest/proxy"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newApiServer IAM查询正常返回,block为true时CDN接口阻塞到请求被取消,否则返回域名不存在
+func newApiServer(t *testing.T, block bool) *httptest.Server {
+	t.Helper()
+	release := make(chan struct{})
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v3/auth/domains" {
+			_, _ = w.Write([]byte(`{"domains":[{"id":"test-domain-id","name":"test"}]}`))
+			return
+		}
+		if block {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"error_code":"CDN.0170","error_msg":"domain not exist"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	return srv
+}
+
+// countingTransport 统计经由的请求数,并把请求转发到本地服务
+type countingTransport struct {
+	srv   *httptest.Server
+	count atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count.Add(1)
+	target, _ := url.Parse(c.srv.URL)
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+	return c.srv.Client().Transport.RoundTrip(req)
+}
+
+func TestHttpProxy(t *testing.T) {
+	srv := newApiServer(t, false)
+	p := proxy.New(srv.Listener.Addr().String())
+	t.Cleanup(p.Close)
+	h, err := New(context.Background(), &Config{
+		Region: "cn-north-1",
+		Ak:     "test-ak-http-proxy",
+		Sk:     "test-sk",
+		Http: &httpclient.Options{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+			Proxy:     p.URL,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = h.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	if !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+	//构建客户端时的IAM查询与CDN接口均经由代理,SDK按AK缓存账号ID,同一进程内重复运行时不再查询IAM
+	want := []string{"iam.myhuaweicloud.com:443", "cdn.myhuaweicloud.com:443"}
+	if hosts := p.Hosts(); !reflect.DeepEqual(hosts, want) && !reflect.DeepEqual(hosts, want[1:]) {
+		t.Fatalf("got proxied hosts %v, want %v", hosts, want)
+	}
+}
+
+func TestHttpTransportAndTimeout(t *testing.T) {
+	srv := newApiServer(t, true)
+	for _, tt := range []struct {
+		name string
+		http func(rt http.RoundTripper) *httpclient.Options
+	}{
+		{"options", func(rt http.RoundTripper) *httpclient.Options {
+			return &httpclient.Options{Transport: rt, Timeout: 100 * time.Millisecond}
+		}},
+		//未指定Transport与Timeout时使用Client中的设置
+		{"client", func(rt http.RoundTripper) *httpclient.Options {
+			return &httpclient.Options{Client: &http.Client{Transport: rt, Timeout: 100 * time.Millisecond}}
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &countingTransport{srv: srv}
+			h, err := New(context.Background(), &Config{
+				Region: "cn-north-1",
+				Ak:     "test-ak-http-" + tt.name,
+				Sk:     "test-sk",
+				Http:   tt.http(rt),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := time.Now()
+			if _, err := h.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); err == nil {
+				t.Fatal("want timeout error")
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Fatalf("call returned after %s", elapsed)
+			}
+			if rt.count.Load() == 0 {
+				t.Fatal("custom transport not used")
+			}
+		})
+	}
+}
+
+func TestHttpInvalidProxy(t *testing.T) {
+	for _, opts := range []*httpclient.Options{
+		{Proxy: "://bad"},
+		{Proxy: "127.0.0.1:8080"},
+		{Proxy: "http://127.0.0.1:8080", Transport: &redirectTransport{}},
+	} {
+		_, err := New(context.Background(), &Config{Region: "cn-north-1", Ak: "ak", Sk: "sk", Http: opts})
+		if !errors.Is(err, cdnerr.ErrInvalidParameter) {
+			t.Fatalf("proxy %q: got %v, want invalid parameter", opts.Proxy, err)
+		}
+	}
+}
@@ -2,79 +2,149 @@ package huawei
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/global"
-	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
 	huaweisdk "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/region"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
 	"github.com/run-bigpig/cloud-sdk/cdn/consts"
-	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/operation"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
 	"github.com/run-bigpig/cloud-sdk/cdn/types"
 	"github.com/run-bigpig/cloud-sdk/utils"
 	"github.com/spf13/cast"
+	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 )
 
 type Huawei struct {
-	config *Config
-	client *huaweisdk.CdnClient
-	ctx    context.Context
+	config     *Config
+	limiter    *ratelimit.Limiter
+	credential auth.ICredential
+	endpoints  []string
+	transport  http.RoundTripper
+	timeout    time.Duration
+	ctx        context.Context
 }
 
 type Config struct {
-	Region string
-	Ak     string
-	Sk     string
+	Region    string
+	Ak        string
+	Sk        string
+	Retry     *retry.Policy      // 重试策略,为空时不重试
+	RateLimit *ratelimit.Limiter // 限流器,每次调用厂商接口(含重试)前获取令牌,为空时使用ratelimit.DefaultLimits,不限制时传入ratelimit.Unlimited()
+	// Http HTTP客户端选项,为空时使用SDK默认配置,指定Client时使用其transport与超时
+	Http   *httpclient.Options
+	Logger *slog.Logger // 记录HTTP请求的日志,为空时不记录
 }
 
 // NewHuaweiSdkClient creates a new Huawei client using the provided config.
+// It returns nil when the client cannot be built, use New to get the error.
 func NewHuaweiSdkClient(ctx context.Context, conf *Config) *Huawei {
-	auth, err := global.NewCredentialsBuilder().WithAk(conf.Ak).WithSk(conf.Sk).SafeBuild()
+	client, err := New(ctx, conf)
 	if err != nil {
 		return nil
 	}
+	return client
+}
+
+// New 创建华为云CDN客户端
+func New(ctx context.Context, conf *Config) (*Huawei, error) {
+	if conf == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.HuaWeiSdkName, "config is nil")
+	}
+	auth, err := global.NewCredentialsBuilder().WithAk(conf.Ak).WithSk(conf.Sk).SafeBuild()
+	if err != nil {
+		return nil, newConfigError("build credentials fail", err)
+	}
 	rg, err := region.SafeValueOf(conf.Region)
 	if err != nil {
-		return nil
+		return nil, newConfigError("invalid region", err)
 	}
-	huaweiClient, err := huaweisdk.CdnClientBuilder().WithRegion(rg).WithCredential(auth).SafeBuild()
+	rt, err := conf.Http.RoundTripper()
 	if err != nil {
-		return nil
+		return nil, newConfigError("invalid http options", err)
 	}
-	client := huaweisdk.NewCdnClient(huaweiClient)
-	return &Huawei{
-		config: conf,
-		client: client,
-		ctx:    ctx,
+	rt = cdnlog.NewTransport(conf.Logger, rt)
+	timeout := conf.Http.GetTimeout()
+	builder := huaweisdk.CdnClientBuilder().WithRegion(rg).WithCredential(auth)
+	if rt != nil || timeout > 0 {
+		//构建客户端时会调用IAM接口获取账号ID,同样经由指定的transport发送
+		builder.WithHttpConfig(newHttpConfig(rt, timeout))
 	}
+	huaweiClient, err := builder.SafeBuild()
+	if err != nil {
+		return nil, newConfigError("build client fail", err)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if rt == nil {
+		rt = &http.Transport{
+			TLSClientConfig: &tls.Config{},
+		}
+	}
+	return &Huawei{
+		config:     conf,
+		limiter:    ratelimit.OrDefault(conf.RateLimit, types.HuaWeiSdkName),
+		credential: huaweiClient.GetCredential(),
+		endpoints:  getEndpoints(rg.Endpoints),
+		transport:  rt,
+		timeout:    timeout,
+		ctx:        ctx,
+	}, nil
 }
 
 func (h *Huawei) GetSdkName() string {
 	return types.HuaWeiSdkName
 }
 
+// invoke 调用厂商接口,每次尝试前按class获取限流令牌,转换错误并按重试策略重试
+func invoke[T any](ctx context.Context, h *Huawei, class ratelimit.Class, idempotent bool, fn func() (T, error)) (T, error) {
+	return retry.Do(ctx, h.config.Retry, idempotent, func() (T, error) {
+		if err := h.limiter.Wait(ctx, class); err != nil {
+			var zero T
+			return zero, err
+		}
+		res, err := fn()
+		return res, wrapError(err)
+	})
+}
+
 // CreateDomain 创建域名
 func (h *Huawei) CreateDomain(req *types.CreateDomainRequest) error {
+	return h.CreateDomainWithContext(h.ctx, req)
+}
+
+// CreateDomainWithContext 创建域名
+func (h *Huawei) CreateDomainWithContext(ctx context.Context, req *types.CreateDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
-	detail, err := h.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: req.Domain})
+	detail, err := h.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: req.Domain})
 	if err == nil {
 		if detail.Status == consts.CdnDomainStatusStoped {
-			err = h.DeleteDomain(&types.DeleteDomainRequest{Domain: req.Domain})
+			err = h.DeleteDomainWithContext(ctx, &types.DeleteDomainRequest{Domain: req.Domain})
 			if err != nil {
 				return err
 			}
 		} else if detail.ChannelType != req.ChannelType || detail.AreaCode != req.AreaCode {
-			err = h.DisableDomain(&types.DisableDomainRequest{Domain: req.Domain})
+			err = h.DisableDomainWithContext(ctx, &types.DisableDomainRequest{Domain: req.Domain})
 			if err != nil {
 				return err
 			}
-			err = h.DeleteDomain(&types.DeleteDomainRequest{Domain: req.Domain})
+			err = h.DeleteDomainWithContext(ctx, &types.DeleteDomainRequest{Domain: req.Domain})
 			if err != nil {
 				return err
 			}
@@ -85,7 +155,7 @@ func (h *Huawei) CreateDomain(req *types.CreateDomainRequest) error {
 	request := &model.CreateDomainRequest{}
 	listSource := make([]model.Sources, 0)
 	if req.Sources == nil {
-		return errors.New("sources is nil")
+		return cdnerr.New(cdnerr.ErrInvalidParameter, types.HuaWeiSdkName, "sources is nil")
 	}
 	for _, v := range req.Sources {
 		activeStandby := int32(0)
@@ -107,60 +177,31 @@ func (h *Huawei) CreateDomain(req *types.CreateDomainRequest) error {
 	request.Body = &model.CreateDomainRequestBody{
 		Domain: domainBody,
 	}
-	res, err := h.client.CreateDomain(request)
+	res, err := invoke(ctx, h, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.CreateDomainResponse, error) {
+		return h.clientWithContext(ctx).CreateDomain(request)
+	})
 	if err != nil {
 		return err
 	}
 	if res.HttpStatusCode < 200 || res.HttpStatusCode > 299 {
-		return errors.New("create domain error")
+		return newStatusError("create domain error", res.HttpStatusCode, res.XRequestId)
 	}
 	return nil
 }
 
-// changeDomainArea 切换区域
-func (h *Huawei) changeDomainArea(domain string, lastAreaCode, nowAreaCode int64) error {
-	if lastAreaCode == nowAreaCode {
-		return nil
-	}
-	//更新到全球
-	if lastAreaCode != consts.AreaCodeGlobal {
-		err := h.UpdateDomain(&types.UpdateDomainRequest{
-			Domain:       domain,
-			UpdateAction: types.UpdateArea,
-			CdnDomain: &entity.UpdateCdnDomainBaseConf{
-				AreaCode:    consts.AreaCodeGlobal,
-				SupportIpv6: 0,
-			},
-		})
-		if err != nil {
-			return err
-		}
-	}
-	if nowAreaCode == consts.AreaCodeGlobal {
-		return nil
-	}
-	//更新到指定区域
-	go func() {
-		for i := 0; i < 3; i++ {
-			time.Sleep(time.Second * 60)
-			err := h.UpdateDomain(&types.UpdateDomainRequest{
-				Domain:       domain,
-				UpdateAction: types.UpdateArea,
-				CdnDomain: &entity.UpdateCdnDomainBaseConf{
-					AreaCode: nowAreaCode,
-				},
-			})
-			if err == nil {
-				return
-			}
-		}
-	}()
-	return nil
+// ChangeDomainArea 切换加速区域,中国大陆与境外之间需要先切换到全球,部署完成后再切换到目标区域
+// 返回的操作需要调用Run或Start执行,状态可通过OnProgress持久化,进程退出后通过operation.ResumeAreaMigration恢复
+func (h *Huawei) ChangeDomainArea(domain string, lastAreaCode, nowAreaCode int64, opts *operation.Options) *operation.AreaMigration {
+	return operation.NewAreaMigration(h, domain, lastAreaCode, nowAreaCode, opts)
 }
 
 func (h *Huawei) UpdateDomain(req *types.UpdateDomainRequest) error {
+	return h.UpdateDomainWithContext(h.ctx, req)
+}
+
+func (h *Huawei) UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
 	request := &model.UpdateDomainFullConfigRequest{}
 	modityConfigBody := &model.ModifyDomainConfigRequestBody{}
@@ -241,89 +282,114 @@ func (h *Huawei) UpdateDomain(req *types.UpdateDomainRequest) error {
 	modityConfigBody.Configs = configs
 	request.Body = modityConfigBody
 	request.DomainName = req.Domain
-	response, err := h.client.UpdateDomainFullConfig(request)
+	response, err := invoke(ctx, h, ratelimit.ClassConfig, retry.Idempotent, func() (*model.UpdateDomainFullConfigResponse, error) {
+		return h.clientWithContext(ctx).UpdateDomainFullConfig(request)
+	})
 	if err != nil {
 		return err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return errors.New("update domain error")
+		return newStatusError("update domain error", response.HttpStatusCode, response.XRequestId)
 	}
 	return nil
 }
 
 // DisableDomain 禁用域名
 func (h *Huawei) DisableDomain(req *types.DisableDomainRequest) error {
+	return h.DisableDomainWithContext(h.ctx, req)
+}
+
+// DisableDomainWithContext 禁用域名
+func (h *Huawei) DisableDomainWithContext(ctx context.Context, req *types.DisableDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
-	domain, err := h.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: req.Domain})
+	domain, err := h.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: req.Domain})
 	if err != nil {
 		return err
 	}
 	request := &model.DisableDomainRequest{}
 	request.DomainId = domain.DomainId
-	response, err := h.client.DisableDomain(request)
+	response, err := invoke(ctx, h, ratelimit.ClassConfig, retry.Idempotent, func() (*model.DisableDomainResponse, error) {
+		return h.clientWithContext(ctx).DisableDomain(request)
+	})
 	if err != nil {
 		return err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return errors.New("disable domain error")
+		return newStatusError("disable domain error", response.HttpStatusCode, response.XRequestId)
 	}
 	return nil
 }
 
 // EnableDomain 启用域名
 func (h *Huawei) EnableDomain(req *types.EnableDomainRequest) error {
+	return h.EnableDomainWithContext(h.ctx, req)
+}
+
+// EnableDomainWithContext 启用域名
+func (h *Huawei) EnableDomainWithContext(ctx context.Context, req *types.EnableDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
-	domain, err := h.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: req.Domain})
+	domain, err := h.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: req.Domain})
 	if err != nil {
 		return err
 	}
 	request := &model.EnableDomainRequest{}
 	request.DomainId = domain.DomainId
-	response, err := h.client.EnableDomain(request)
+	response, err := invoke(ctx, h, ratelimit.ClassConfig, retry.Idempotent, func() (*model.EnableDomainResponse, error) {
+		return h.clientWithContext(ctx).EnableDomain(request)
+	})
 	if err != nil {
 		return err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return errors.New("enable domain error")
+		return newStatusError("enable domain error", response.HttpStatusCode, response.XRequestId)
 	}
 	return nil
 }
 
 // DeleteDomain 删除域名
 func (h *Huawei) DeleteDomain(req *types.DeleteDomainRequest) error {
+	return h.DeleteDomainWithContext(h.ctx, req)
+}
+
+// DeleteDomainWithContext 删除域名
+func (h *Huawei) DeleteDomainWithContext(ctx context.Context, req *types.DeleteDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
-	domain, err := h.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: req.Domain})
+	domain, err := h.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: req.Domain})
 	if err != nil {
-		var e *sdkerr.ServiceResponseError
-		if errors.As(err, &e) {
-			if e.ErrorCode == "CDN.0170" {
-				return nil
-			}
+		if errors.Is(err, cdnerr.ErrDomainNotFound) {
+			return nil
 		}
 		return err
 	}
 	request := &model.DeleteDomainRequest{}
 	request.DomainId = domain.DomainId
-	response, err := h.client.DeleteDomain(request)
+	response, err := invoke(ctx, h, ratelimit.ClassConfig, retry.Idempotent, func() (*model.DeleteDomainResponse, error) {
+		return h.clientWithContext(ctx).DeleteDomain(request)
+	})
 	if err != nil {
 		return err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return errors.New("delete domain error")
+		return newStatusError("delete domain error", response.HttpStatusCode, response.XRequestId)
 	}
 	return nil
 }
 
 // PurgePathCache 刷新目录缓存
 func (h *Huawei) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return h.PurgePathCacheWithContext(h.ctx, req)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (h *Huawei) PurgePathCacheWithContext(ctx context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	typeRefreshTask := model.GetRefreshTaskRequestBodyTypeEnum().DIRECTORY
 	request := &model.CreateRefreshTasksRequest{}
@@ -337,7 +403,9 @@ func (h *Huawei) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeC
 	request.Body = &model.RefreshTaskRequest{
 		RefreshTask: refreshTaskbody,
 	}
-	response, err := h.client.CreateRefreshTasks(request)
+	response, err := invoke(ctx, h, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.CreateRefreshTasksResponse, error) {
+		return h.clientWithContext(ctx).CreateRefreshTasks(request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -346,8 +414,13 @@ func (h *Huawei) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeC
 
 // PurgeUrlsCache 刷新URL缓存
 func (h *Huawei) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return h.PurgeUrlsCacheWithContext(h.ctx, req)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (h *Huawei) PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	typeRefreshTask := model.GetRefreshTaskRequestBodyTypeEnum().FILE
 	request := &model.CreateRefreshTasksRequest{}
@@ -359,7 +432,9 @@ func (h *Huawei) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeC
 	request.Body = &model.RefreshTaskRequest{
 		RefreshTask: refreshTaskbody,
 	}
-	response, err := h.client.CreateRefreshTasks(request)
+	response, err := invoke(ctx, h, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.CreateRefreshTasksResponse, error) {
+		return h.clientWithContext(ctx).CreateRefreshTasks(request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -368,8 +443,13 @@ func (h *Huawei) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeC
 
 // PushUrlsCache 推送URL预热
 func (h *Huawei) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return h.PushUrlsCacheWithContext(h.ctx, req)
+}
+
+// PushUrlsCacheWithContext 推送URL预热
+func (h *Huawei) PushUrlsCacheWithContext(ctx context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := &model.CreatePreheatingTasksRequest{}
 	preheatingTaskbody := &model.PreheatingTaskRequestBody{
@@ -379,7 +459,9 @@ func (h *Huawei) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrls
 	request.Body = &model.PreheatingTaskRequest{
 		PreheatingTask: preheatingTaskbody,
 	}
-	response, err := h.client.CreatePreheatingTasks(request)
+	response, err := invoke(ctx, h, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.CreatePreheatingTasksResponse, error) {
+		return h.clientWithContext(ctx).CreatePreheatingTasks(request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -388,19 +470,26 @@ func (h *Huawei) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrls
 
 // ShowPurgeTaskStatus 展示刷新任务状态
 func (h *Huawei) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return h.ShowPurgeTaskStatusWithContext(h.ctx, req)
+}
+
+// ShowPurgeTaskStatusWithContext 展示刷新任务状态
+func (h *Huawei) ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := &model.ShowHistoryTaskDetailsRequest{}
 	request.HistoryTasksId = req.TaskId
 	request.PageSize = utils.Int32Ptr(1)
 	request.PageNumber = utils.Int32Ptr(1)
-	response, err := h.client.ShowHistoryTaskDetails(request)
+	response, err := invoke(ctx, h, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowHistoryTaskDetailsResponse, error) {
+		return h.clientWithContext(ctx).ShowHistoryTaskDetails(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return nil, errors.New("show purge task status error")
+		return nil, newStatusError("show purge task status error", response.HttpStatusCode, response.XRequestId)
 	}
 	return &types.ShowPurgeTaskStatusResponse{
 		TaskId: *response.Id,
@@ -410,8 +499,13 @@ func (h *Huawei) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*ty
 
 // ShowPurgeTaskList 展示刷新任务列表
 func (h *Huawei) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return h.ShowPurgeTaskListWithContext(h.ctx, req)
+}
+
+// ShowPurgeTaskListWithContext 展示刷新任务列表
+func (h *Huawei) ShowPurgeTaskListWithContext(ctx context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := &model.ShowHistoryTasksRequest{}
 	taskType := model.GetShowHistoryTasksRequestTaskTypeEnum().REFRESH
@@ -428,12 +522,14 @@ func (h *Huawei) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.
 	}
 	status := setContentPurgeOrPushStatus(req.TaskStatus)
 	request.Status = &status
-	response, err := h.client.ShowHistoryTasks(request)
+	response, err := invoke(ctx, h, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowHistoryTasksResponse, error) {
+		return h.clientWithContext(ctx).ShowHistoryTasks(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return nil, errors.New("show purge task list error")
+		return nil, newStatusError("show purge task list error", response.HttpStatusCode, response.XRequestId)
 	}
 	if *response.Total == 0 || *response.Tasks == nil || len(*response.Tasks) == 0 {
 		return &types.ShowPurgeTaskListResponse{Total: int64(*response.Total), List: []string{}}, nil
@@ -447,19 +543,26 @@ func (h *Huawei) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.
 
 // ShowPushTaskStatus 展示预热任务状态
 func (h *Huawei) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return h.ShowPushTaskStatusWithContext(h.ctx, req)
+}
+
+// ShowPushTaskStatusWithContext 展示预热任务状态
+func (h *Huawei) ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := &model.ShowHistoryTaskDetailsRequest{}
 	request.HistoryTasksId = req.TaskId
 	request.PageSize = utils.Int32Ptr(1)
 	request.PageNumber = utils.Int32Ptr(1)
-	response, err := h.client.ShowHistoryTaskDetails(request)
+	response, err := invoke(ctx, h, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowHistoryTaskDetailsResponse, error) {
+		return h.clientWithContext(ctx).ShowHistoryTaskDetails(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return nil, errors.New("show push task status error")
+		return nil, newStatusError("show push task status error", response.HttpStatusCode, response.XRequestId)
 	}
 	return &types.ShowPushTaskStatusResponse{
 		TaskId: *response.Id,
@@ -469,8 +572,13 @@ func (h *Huawei) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*type
 
 // ShowPushTaskList 展示预热任务列表
 func (h *Huawei) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return h.ShowPushTaskListWithContext(h.ctx, req)
+}
+
+// ShowPushTaskListWithContext 展示预热任务列表
+func (h *Huawei) ShowPushTaskListWithContext(ctx context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := &model.ShowHistoryTasksRequest{}
 	taskType := model.GetShowHistoryTasksRequestTaskTypeEnum().PREHEATING
@@ -485,12 +593,14 @@ func (h *Huawei) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.Sh
 	}
 	status := setContentPurgeOrPushStatus(req.TaskStatus)
 	request.Status = &status
-	response, err := h.client.ShowHistoryTasks(request)
+	response, err := invoke(ctx, h, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowHistoryTasksResponse, error) {
+		return h.clientWithContext(ctx).ShowHistoryTasks(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return nil, errors.New("show push task list error")
+		return nil, newStatusError("show push task list error", response.HttpStatusCode, response.XRequestId)
 	}
 	if *response.Total == 0 || *response.Tasks == nil || len(*response.Tasks) == 0 {
 		return &types.ShowPushTaskListResponse{Total: int64(*response.Total), List: []string{}}, nil
@@ -504,17 +614,24 @@ func (h *Huawei) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.Sh
 
 // ShowDomainDetail 展示域名详情
 func (h *Huawei) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return h.ShowDomainDetailWithContext(h.ctx, req)
+}
+
+// ShowDomainDetailWithContext 展示域名详情
+func (h *Huawei) ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := &model.ShowDomainDetailByNameRequest{}
 	request.DomainName = req.Domain
-	res, err := h.client.ShowDomainDetailByName(request)
+	res, err := invoke(ctx, h, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowDomainDetailByNameResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainDetailByName(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if res.HttpStatusCode < 200 || res.HttpStatusCode > 299 {
-		return nil, errors.New("show domain detail error")
+		return nil, newStatusError("show domain detail error", res.HttpStatusCode, res.XRequestId)
 	}
 	return &types.ShowDomainDetailResponse{
 		DomainId:    *res.Domain.Id,
@@ -530,19 +647,26 @@ func (h *Huawei) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.Sh
 
 // ShowDomainStatusList 展示域名状态列表
 func (h *Huawei) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return h.ShowDomainStatusListWithContext(h.ctx, req)
+}
+
+// ShowDomainStatusListWithContext 展示域名状态列表
+func (h *Huawei) ShowDomainStatusListWithContext(ctx context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := &model.ListDomainsRequest{}
 	request.DomainStatus = utils.StringPtr(setDomainStatus(req.Status))
 	request.PageNumber = utils.Int32Ptr(int32(req.Page))
 	request.PageSize = utils.Int32Ptr(int32(req.Limit))
-	response, err := h.client.ListDomains(request)
+	response, err := invoke(ctx, h, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ListDomainsResponse, error) {
+		return h.clientWithContext(ctx).ListDomains(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode < 200 || response.HttpStatusCode > 299 {
-		return nil, errors.New("show domain status list error")
+		return nil, newStatusError("show domain status list error", response.HttpStatusCode, response.XRequestId)
 	}
 	if *response.Total == 0 || *response.Domains == nil || len(*response.Domains) == 0 {
 		return &types.ShowDomainStatusListResponse{Total: int64(*response.Total), List: []string{}}, nil
@@ -556,11 +680,21 @@ func (h *Huawei) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (*
 
 // CreateVerifyRecord 创建域名验证记录
 func (h *Huawei) CreateVerifyRecord(req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return h.CreateVerifyRecordWithContext(h.ctx, req)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (h *Huawei) CreateVerifyRecordWithContext(ctx context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
 	return nil, nil
 }
 
 // VerifyDomainRecord 验证域名记录
 func (h *Huawei) VerifyDomainRecord(req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return h.VerifyDomainRecordWithContext(h.ctx, req)
+}
+
+// VerifyDomainRecordWithContext 验证域名记录
+func (h *Huawei) VerifyDomainRecordWithContext(ctx context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
 	return nil, nil
 }
 
@@ -767,7 +901,7 @@ func (u *UpdateDomainModel) WithCacheCodeConf() {
 		for _, v := range u.req.CacheCodeConf {
 			cacheCodeRules = append(cacheCodeRules, model.ErrorCodeCache{
 				Code: utils.Int32Ptr(int32(v.HttpCode)),
-				Ttl:  utils.Int32Ptr(getCacheTtl(int32(v.CacheTTL), int32(v.CacheUnit))),
+				Ttl:  utils.Int32Ptr(int32(cachettl.Seconds(v.CacheTTL, v.CacheUnit))),
 			})
 		}
 	}
@@ -929,17 +1063,27 @@ func (u *UpdateDomainModel) WithHttpsConf() {
 
 // DomainAccessDataStatic 域名访问数据统计信息
 func (h *Huawei) DomainAccessDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return h.DomainAccessDataStaticWithContext(h.ctx, req)
+}
+
+// DomainAccessDataStaticWithContext 域名访问数据统计信息
+func (h *Huawei) DomainAccessDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
 	if err := h.verifyMutualExclusion(req); err != nil {
 		return nil, err
 	}
 	if req.Metric == consts.DataAccessMetricTypeHitFlux || req.Metric == consts.DataAccessMetricTypeHitRequest || (req.Isp == nil && req.District == nil && req.IpProtocol == nil && req.Protocol == nil) {
-		return h.DomainServiceAreaDataStatic(req)
+		return h.DomainServiceAreaDataStaticWithContext(ctx, req)
 	}
-	return h.DomainAccessLocationDataStatic(req)
+	return h.DomainAccessLocationDataStaticWithContext(ctx, req)
 }
 
 // DomainAccessLocationDataStatic 域名地区运营商访问数据统计信息
 func (h *Huawei) DomainAccessLocationDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return h.DomainAccessLocationDataStaticWithContext(h.ctx, req)
+}
+
+// DomainAccessLocationDataStaticWithContext 域名地区运营商访问数据统计信息
+func (h *Huawei) DomainAccessLocationDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
 	request := &model.ShowDomainLocationStatsRequest{
 		Action:     getAccessDataStaticType(consts.DataStaticTypeDetail),
 		StartTime:  req.StartTime * 1000,
@@ -975,12 +1119,14 @@ func (h *Huawei) DomainAccessLocationDataStatic(req *types.DomainAccessDataStati
 	if req.Protocol != nil {
 		request.Protocol = utils.StringPtr(getHttpProtocol(*req.Protocol))
 	}
-	response, err := h.client.ShowDomainLocationStats(request)
+	response, err := invoke(ctx, h, ratelimit.ClassStats, retry.Idempotent, func() (*model.ShowDomainLocationStatsResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainLocationStats(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode != 200 {
-		return nil, errors.New("show domain location stats error")
+		return nil, newStatusError("show domain location stats error", response.HttpStatusCode, nil)
 	}
 	responseData := make(types.DomainAccessDataStaticResponse)
 	timeStamps := utils.CalcTimeStampsWithInterval(req.StartTime, req.EndTime, *request.Interval) //获取所有时间戳
@@ -1033,6 +1179,11 @@ func (h *Huawei) DomainAccessLocationDataStatic(req *types.DomainAccessDataStati
 
 // DomainServiceAreaDataStatic 加速域名服务区域访问数据统计信息
 func (h *Huawei) DomainServiceAreaDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return h.DomainServiceAreaDataStaticWithContext(h.ctx, req)
+}
+
+// DomainServiceAreaDataStaticWithContext 加速域名服务区域访问数据统计信息
+func (h *Huawei) DomainServiceAreaDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
 	stateRequest := &model.ShowDomainStatsRequest{
 		Action:      getOriginDataStaticType(consts.DataStaticTypeDetail),
 		StartTime:   req.StartTime * 1000,
@@ -1044,12 +1195,14 @@ func (h *Huawei) DomainServiceAreaDataStatic(req *types.DomainAccessDataStaticRe
 		ServiceArea: utils.StringPtr(getAreaCode(req.Area).Value()),
 	}
 	isStatusCode := strings.Contains(stateRequest.StatType, "status_code")
-	response, err := h.client.ShowDomainStats(stateRequest)
+	response, err := invoke(ctx, h, ratelimit.ClassStats, retry.Idempotent, func() (*model.ShowDomainStatsResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainStats(stateRequest)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode != 200 {
-		return nil, errors.New("show domain stats error")
+		return nil, newStatusError("show domain stats error", response.HttpStatusCode, nil)
 	}
 	responseData := make(types.DomainAccessDataStaticResponse)
 	timeStamps := utils.CalcTimeStampsWithInterval(req.StartTime, req.EndTime, *stateRequest.Interval) //获取所有时间戳
@@ -1102,6 +1255,11 @@ func (h *Huawei) DomainServiceAreaDataStatic(req *types.DomainAccessDataStaticRe
 
 // DomainOriginDataStatic 域名回源数据统计信息
 func (h *Huawei) DomainOriginDataStatic(req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return h.DomainOriginDataStaticWithContext(h.ctx, req)
+}
+
+// DomainOriginDataStaticWithContext 域名回源数据统计信息
+func (h *Huawei) DomainOriginDataStaticWithContext(ctx context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
 	request := &model.ShowDomainStatsRequest{
 		Action:     getOriginDataStaticType(consts.DataStaticTypeDetail),
 		StartTime:  req.StartTime * 1000,
@@ -1112,12 +1270,14 @@ func (h *Huawei) DomainOriginDataStatic(req *types.DomainOriginDataStaticRequest
 		GroupBy:    utils.StringPtr("domain"),
 	}
 	isStatusCode := strings.Contains(request.StatType, "bs_status_code")
-	response, err := h.client.ShowDomainStats(request)
+	response, err := invoke(ctx, h, ratelimit.ClassStats, retry.Idempotent, func() (*model.ShowDomainStatsResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainStats(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode != 200 {
-		return nil, errors.New("show domain location stats error")
+		return nil, newStatusError("show domain location stats error", response.HttpStatusCode, nil)
 	}
 	responseData := make(types.DomainOriginDataStaticResponse)
 	timeStamps := utils.CalcTimeStampsWithInterval(req.StartTime, req.EndTime, *request.Interval) //获取所有时间戳
@@ -1173,11 +1333,11 @@ func (h *Huawei) verifyMutualExclusion(req *types.DomainAccessDataStaticRequest)
 	switch {
 	case req.IpProtocol == nil:
 		if req.District != nil && req.Isp != nil {
-			return errors.New("when the ip protocol is empty, district and isp cannot have parameter mutual exclusion at the same time")
+			return cdnerr.New(cdnerr.ErrInvalidParameter, types.HuaWeiSdkName, "when the ip protocol is empty, district and isp cannot have parameter mutual exclusion at the same time")
 		}
 	case req.IpProtocol != nil:
 		if req.District != nil || req.Isp != nil {
-			return errors.New("when the ip protocol is not empty, district and isp cannot have parameter mutual exclusion at the same time")
+			return cdnerr.New(cdnerr.ErrInvalidParameter, types.HuaWeiSdkName, "when the ip protocol is not empty, district and isp cannot have parameter mutual exclusion at the same time")
 		}
 	}
 	return nil
@@ -1197,6 +1357,11 @@ func (h *Huawei) makeDefaultData(timeStamps []int64) []*types.StaticData {
 
 // ListTopUrlDataStatic 获取域名TOP URL访问数据
 func (h *Huawei) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return h.ListTopUrlDataStaticWithContext(h.ctx, req)
+}
+
+// ListTopUrlDataStaticWithContext 获取域名TOP URL访问数据
+func (h *Huawei) ListTopUrlDataStaticWithContext(ctx context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
 	request := &model.ShowTopUrlRequest{
 		StartTime:   req.StartTime * 1000,
 		EndTime:     req.EndTime * 1000,
@@ -1204,12 +1369,14 @@ func (h *Huawei) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([
 		StatType:    getTopUrlFilter(req.Filter),
 		ServiceArea: utils.StringPtr(getAreaCode(req.Area).Value()),
 	}
-	response, err := h.client.ShowTopUrl(request)
+	response, err := invoke(ctx, h, ratelimit.ClassStats, retry.Idempotent, func() (*model.ShowTopUrlResponse, error) {
+		return h.clientWithContext(ctx).ShowTopUrl(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode != 200 {
-		return nil, errors.New("show domain top url  error")
+		return nil, newStatusError("show domain top url error", response.HttpStatusCode, nil)
 	}
 	responseData := make([]*types.ListTopUrlDataStaticResponse, 0)
 	if response.TopUrlSummary != nil {
@@ -1225,6 +1392,11 @@ func (h *Huawei) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([
 
 // DomainAccessTotalData 访问数据总数据
 func (h *Huawei) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return h.DomainAccessTotalDataWithContext(h.ctx, req)
+}
+
+// DomainAccessTotalDataWithContext 访问数据总数据
+func (h *Huawei) DomainAccessTotalDataWithContext(ctx context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
 	stateRequest := &model.ShowDomainStatsRequest{
 		Action:      "summary",
 		StartTime:   req.StartTime * 1000,
@@ -1236,14 +1408,16 @@ func (h *Huawei) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest)
 		ServiceArea: utils.StringPtr(getAreaCode(req.Area).Value()),
 	}
 	if strings.Contains(stateRequest.StatType, "status_code") {
-		return nil, errors.New("status_code is not support")
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.HuaWeiSdkName, "status_code is not support")
 	}
-	response, err := h.client.ShowDomainStats(stateRequest)
+	response, err := invoke(ctx, h, ratelimit.ClassStats, retry.Idempotent, func() (*model.ShowDomainStatsResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainStats(stateRequest)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode != 200 {
-		return nil, errors.New("show domain stats error")
+		return nil, newStatusError("show domain stats error", response.HttpStatusCode, nil)
 	}
 	responseData := make(types.DataTotalDataResponse)
 	if response.Result != nil {
@@ -1265,6 +1439,11 @@ func (h *Huawei) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest)
 
 // DomainOriginTotalData 回源总流量
 func (h *Huawei) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return h.DomainOriginTotalDataWithContext(h.ctx, req)
+}
+
+// DomainOriginTotalDataWithContext 回源总流量
+func (h *Huawei) DomainOriginTotalDataWithContext(ctx context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
 	request := &model.ShowDomainStatsRequest{
 		Action:     "summary",
 		StartTime:  req.StartTime * 1000,
@@ -1275,14 +1454,16 @@ func (h *Huawei) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest)
 		GroupBy:    utils.StringPtr("domain"),
 	}
 	if strings.Contains(request.StatType, "bs_status_code") {
-		return nil, errors.New("bs_status_code is not support")
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.HuaWeiSdkName, "bs_status_code is not support")
 	}
-	response, err := h.client.ShowDomainStats(request)
+	response, err := invoke(ctx, h, ratelimit.ClassStats, retry.Idempotent, func() (*model.ShowDomainStatsResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainStats(request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if response.HttpStatusCode != 200 {
-		return nil, errors.New("show domain location stats error")
+		return nil, newStatusError("show domain location stats error", response.HttpStatusCode, nil)
 	}
 	responseData := make(types.DataTotalDataResponse)
 	if response.Result != nil {
@@ -1303,6 +1484,10 @@ func (h *Huawei) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest)
 }
 
 func (h *Huawei) UserAccessRegionDistribution(req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return h.UserAccessRegionDistributionWithContext(h.ctx, req)
+}
+
+func (h *Huawei) UserAccessRegionDistributionWithContext(ctx context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
 	request := &model.ShowDomainLocationStatsRequest{
 		Action:     getAccessDataStaticType(consts.DataStaticTypeSum),
 		StartTime:  req.StartTime * 1000,
@@ -1313,13 +1498,15 @@ func (h *Huawei) UserAccessRegionDistribution(req *types.UserAccessRegionDistrib
 		GroupBy:    utils.StringPtr("domain,country"),
 		Country:    utils.StringPtr("all"),
 	}
-	response, err := h.client.ShowDomainLocationStats(request)
+	response, err := invoke(ctx, h, ratelimit.ClassStats, retry.Idempotent, func() (*model.ShowDomainLocationStatsResponse, error) {
+		return h.clientWithContext(ctx).ShowDomainLocationStats(request)
+	})
 	responseData := make(types.UserAccessRegionDistributionResponse)
 	if err != nil {
 		return responseData, err
 	}
 	if response.HttpStatusCode != 200 {
-		return responseData, errors.New("show domain location stats error")
+		return responseData, newStatusError("show domain location stats error", response.HttpStatusCode, nil)
 	}
 	if response.Result != nil {
 		for domain, v := range response.Result {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
 	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/utils"
 	"strings"
 )
 
@@ -369,21 +370,6 @@ func getCacheUnit(t int64) string {
 	}
 }
 
-func getCacheTtl(cacheTtl int32, cacheUnit int32) int32 {
-	switch cacheUnit {
-	case consts.CacheUnitSecond:
-		return cacheTtl
-	case consts.CacheUnitMinute:
-		return cacheTtl * 60
-	case consts.CacheUnitHour:
-		return cacheTtl * 60 * 60
-	case consts.CacheUnitDay:
-		return cacheTtl * 60 * 60 * 24
-	default:
-		return cacheTtl
-	}
-}
-
 // GetCacheBrowserCacheStatus 获取浏览器缓存状态
 func getCacheBrowserCacheStatus(t int64) string {
 	switch t {
@@ -509,22 +495,6 @@ func getRemoteAuthRequestMethod(t int64) string {
 	}
 }
 
-// 判断切片是否是递增的
-func isIncreasingSlice(slice []int64) bool {
-	if len(slice) < 2 {
-		return false
-	}
-	if len(slice) == 2 {
-		return slice[1] == slice[0]+1
-	}
-	for i := 1; i < len(slice); i++ {
-		if slice[i] != slice[i-1]+1 {
-			return false
-		}
-	}
-	return true
-}
-
 func getFileType(data []string) string {
 	newData := make([]string, len(data))
 	copy(newData, data)
@@ -934,3 +904,306 @@ func getTopUrlFilter(t int64) string {
 		return "flux"
 	}
 }
+
+// mapSwitch 开关状态转换为开关
+func mapSwitch(s string) int64 {
+	if s == consts.ON {
+		return consts.SwitchOn
+	}
+	return consts.SwitchOff
+}
+
+// mapWhiteOrBlackList 黑白名单类型转换为黑白名单
+func mapWhiteOrBlackList(s string) int64 {
+	if s == "white" {
+		return consts.WhiteList
+	}
+	return consts.BlackList
+}
+
+// mapOriginProtocol 回源协议转换为回源协议代码
+func mapOriginProtocol(s string) int64 {
+	switch s {
+	case "https":
+		return consts.OriginProtocolHttps
+	case "follow":
+		return consts.OriginProtocolFollow
+	default:
+		return consts.OriginProtocolHttp
+	}
+}
+
+// mapOriginType 源站类型转换为源站类型代码
+func mapOriginType(s string) int64 {
+	switch s {
+	case "domain":
+		return consts.OriginTypeDomain
+	case "obs_bucket":
+		return consts.OriginTypeBucket
+	default:
+		return consts.OriginTypeIp
+	}
+}
+
+// mapPrimaryOrBack 源站优先级转换为主备,70为主源站,30为备源站
+func mapPrimaryOrBack(priority int32) int64 {
+	if priority >= 70 {
+		return consts.OriginPriorityPrimary
+	}
+	return consts.OriginPriorityBackup
+}
+
+// mapOriginAdvanceUrlMatchMode 高级回源匹配类型转换为匹配模式
+func mapOriginAdvanceUrlMatchMode(s string) int64 {
+	if s == "file_path" {
+		return consts.OriginUrlMatchModeDirectory
+	}
+	return consts.OriginUrlMatchModeFile
+}
+
+// mapOriginAdvanceUrlMatchRule 高级回源匹配规则转换为文件后缀或目录列表
+func mapOriginAdvanceUrlMatchRule(t int64, s string) []string {
+	data := utils.SplitString(s, ";")
+	for index, item := range data {
+		switch t {
+		case consts.OriginUrlMatchModeFile:
+			data[index] = strings.TrimPrefix(item, ".")
+		case consts.OriginUrlMatchModeDirectory:
+			data[index] = strings.TrimLeft(item, "/")
+		}
+	}
+	return data
+}
+
+// mapRuleType 匹配类型转换为规则类型
+func mapRuleType(s string) int64 {
+	switch s {
+	case "file_extension":
+		return consts.RuleTypeFileSuffix
+	case "catalog":
+		return consts.RuleTypeDirectory
+	case "full_path":
+		return consts.RuleTypePath
+	case "home_page":
+		return consts.RuleTypeIndex
+	case "contentType":
+		return consts.RuleTypeContentType
+	default:
+		return consts.RuleTypeAll
+	}
+}
+
+// mapRulePaths 匹配值转换为缓存内容
+func mapRulePaths(t int64, s string) []string {
+	switch t {
+	case consts.RuleTypeAll, consts.RuleTypeIndex:
+		return nil
+	case consts.RuleTypeFileSuffix:
+		return mapFileType(s)
+	default:
+		return utils.SplitString(strings.ReplaceAll(s, "\\*", "*"), ",")
+	}
+}
+
+// mapFileType 文件后缀列表转换为不带点的后缀
+func mapFileType(s string) []string {
+	data := utils.SplitString(s, ",")
+	for index, item := range data {
+		data[index] = strings.TrimPrefix(item, ".")
+	}
+	return data
+}
+
+// mapCacheUnit 缓存时间单位转换为缓存单位
+func mapCacheUnit(s string) int64 {
+	switch s {
+	case "m":
+		return consts.CacheUnitMinute
+	case "h":
+		return consts.CacheUnitHour
+	case "d":
+		return consts.CacheUnitDay
+	default:
+		return consts.CacheUnitSecond
+	}
+}
+
+// mapCacheParameterStatus URL参数类型转换为缓存参数状态
+func mapCacheParameterStatus(s string) int64 {
+	switch s {
+	case "full_url":
+		return consts.CacheParameterStatusOff
+	case "reserve_params":
+		return consts.CacheParameterStatusInclude
+	case "del_params":
+		return consts.CacheParameterStatusExclude
+	default:
+		return consts.CacheParameterStatusAll
+	}
+}
+
+// mapCacheStatus 是否遵循源站与缓存时间转换为缓存状态
+func mapCacheStatus(followOrigin string, ttl int32) int64 {
+	switch {
+	case followOrigin == consts.ON:
+		return consts.CacheStatusFollow
+	case ttl > 0:
+		return consts.CacheStatusOn
+	default:
+		return consts.CacheStatusOff
+	}
+}
+
+// mapCacheBrowserCacheStatus 浏览器缓存类型转换为缓存状态
+func mapCacheBrowserCacheStatus(s string) int64 {
+	switch s {
+	case "follow_origin":
+		return consts.CacheStatusFollow
+	case "never":
+		return consts.CacheStatusOff
+	default:
+		return consts.CacheStatusOn
+	}
+}
+
+// mapOriginHeaderAction 请求头操作转换为操作代码
+func mapOriginHeaderAction(s string) int64 {
+	switch s {
+	case "delete":
+		return consts.OriginHeaderActionDelete
+	case "set":
+		return consts.OriginHeaderActionSet
+	default:
+		return consts.OriginHeaderActionAdd
+	}
+}
+
+// mapOriginUrlMateMethod 回源URL改写匹配类型转换为匹配方法
+func mapOriginUrlMateMethod(s string) int64 {
+	switch s {
+	case "file_path":
+		return consts.OriginMateMethodUrl
+	case "wildcard":
+		return consts.OriginMateMethodRegx
+	case "full_path":
+		return consts.OriginMateMethodPath
+	default:
+		return consts.OriginMateMethodAll
+	}
+}
+
+// mapAccessAuthMannerType 鉴权类型转换为鉴权方式
+func mapAccessAuthMannerType(s string) int64 {
+	switch s {
+	case "type_b":
+		return consts.AccessAuthMannerTypeB
+	case "type_c1":
+		return consts.AccessAuthMannerTypeC
+	case "type_c2":
+		return consts.AccessAuthMannerTypeD
+	default:
+		return consts.AccessAuthMannerTypeA
+	}
+}
+
+// mapAccessAuthEncryptManner 签名方法转换为加密方式
+func mapAccessAuthEncryptManner(s string) int64 {
+	if s == "sha256" {
+		return consts.AccessAuthEncryptMannerSha256
+	}
+	return consts.AccessAuthEncryptMannerMd5
+}
+
+// mapAccessAuthInheritTimeType 继承时间类型转换为继承时间代码
+func mapAccessAuthInheritTimeType(s string) int64 {
+	if s == "sys_time" {
+		return consts.AccessAuthInheritTimeTypeSystem
+	}
+	return consts.AccessAuthInheritTimeTypeParent
+}
+
+// mapAccessAuthTimeFormat 时间格式转换为时间格式代码
+func mapAccessAuthTimeFormat(s string) int64 {
+	if s == "hex" {
+		return consts.AccessAuthTimeFormatHex
+	}
+	return consts.AccessAuthTimeFormatDec
+}
+
+// mapAccessRemoteAuthFileType 远程鉴权文件类型转换为文件类型代码
+func mapAccessRemoteAuthFileType(s string) int64 {
+	if s == "specific_file" {
+		return consts.FileTypeFile
+	}
+	return consts.FileTypeAll
+}
+
+// mapAccessRemoteAuthTimeOutAction 远程鉴权超时动作转换为超时动作代码
+func mapAccessRemoteAuthTimeOutAction(s string) int64 {
+	if s == "forbid" {
+		return consts.AccessRemoteAuthTimeOutActionReturn403
+	}
+	return consts.AccessRemoteAuthTimeOutActionReturn200
+}
+
+// mapRemoteAuthRequestMethod 请求方法转换为请求方式代码
+func mapRemoteAuthRequestMethod(s string) int64 {
+	switch s {
+	case "POST":
+		return consts.RequestMethodPost
+	case "HEAD":
+		return consts.RequestMethodHead
+	default:
+		return consts.RequestMethodGet
+	}
+}
+
+// mapRequestUrlRewriteType 访问URL重写匹配类型转换为重写类型
+func mapRequestUrlRewriteType(s string) int64 {
+	if s == "full_path" {
+		return consts.RequestUrlRewriteTypeFullPath
+	}
+	return consts.RequestUrlRewriteTypeDirectory
+}
+
+// mapRedirectCode 重定向状态码转换为跳转码
+func mapRedirectCode(code int32) int64 {
+	if code == 302 {
+		return consts.RedirectCode302
+	}
+	return consts.RedirectCode301
+}
+
+// mapTlsVersions TLS版本列表转换为TLS版本代码
+func mapTlsVersions(s string) []int64 {
+	versions := make([]int64, 0)
+	for _, v := range utils.SplitString(s, ",") {
+		switch v {
+		case "TLSv1.0":
+			versions = append(versions, consts.HttpsTlsVersionSSLv0)
+		case "TLSv1.1":
+			versions = append(versions, consts.HttpsTlsVersionSSLv1)
+		case "TLSv1.2":
+			versions = append(versions, consts.HttpsTlsVersionSSLv2)
+		case "TLSv1.3":
+			versions = append(versions, consts.HttpsTlsVersionTLSv3)
+		}
+	}
+	return versions
+}
+
+// mapHttpsJumpType 强制跳转类型转换为跳转类型代码
+func mapHttpsJumpType(s string) int64 {
+	if s == "https" {
+		return consts.HttpsJumpTypeHttps
+	}
+	return consts.HttpsJumpTypeHttp
+}
+
+// mapIntelligentCompressionCompressMethod 压缩方式转换为压缩方法代码
+func mapIntelligentCompressionCompressMethod(s string) int64 {
+	if s == "br" {
+		return consts.IntelligentCompressionCompressMethodBrotli
+	}
+	return consts.IntelligentCompressionCompressMethodGzip
+}
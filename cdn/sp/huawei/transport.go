@@ -0,0 +1,106 @@
+package huawei
+
+import (
+	"context"
+	"crypto/tls"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/config"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/impl"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+	huaweisdk "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 华为云SDK不支持按请求传入context,这里为每次调用构造一个绑定context的客户端,
+// 通过注册替代协议的方式把请求转交给共享的底层transport,并在发送前合并调用方的context
+
+// clientWithContext 获取绑定context的CDN客户端
+func (h *Huawei) clientWithContext(ctx context.Context) *huaweisdk.CdnClient {
+	if ctx == nil {
+		ctx = h.ctx
+	}
+	httpClient := impl.NewDefaultHttpClient(newHttpConfig(&contextRoundTripper{ctx: ctx, base: h.transport}, h.timeout))
+	hcClient := core.NewHcHttpClient(httpClient).
+		WithEndpoints(h.endpoints).
+		WithCredential(h.credential).
+		WithErrorHandler(sdkerr.DefaultErrorHandler{})
+	return huaweisdk.NewCdnClient(hcClient)
+}
+
+// newHttpConfig 创建把请求转交给rt的SDK配置,SDK只接受*http.Transport,因此通过注册替代协议实现
+// rt为空时使用默认transport,timeout为0时使用SDK默认超时
+func newHttpConfig(rt http.RoundTripper, timeout time.Duration) *config.HttpConfig {
+	if rt == nil {
+		rt = &http.Transport{
+			TLSClientConfig: &tls.Config{},
+		}
+	}
+	shell := &http.Transport{
+		TLSClientConfig: &tls.Config{},
+		TLSNextProto:    map[string]func(string, *tls.Conn) http.RoundTripper{},
+	}
+	shell.RegisterProtocol("http", rt)
+	shell.RegisterProtocol("https", rt)
+	httpConfig := config.DefaultHttpConfig().WithHttpTransport(shell)
+	if timeout > 0 {
+		httpConfig.WithTimeout(timeout)
+	}
+	return httpConfig
+}
+
+// getEndpoints 补全endpoint协议
+func getEndpoints(endpoints []string) []string {
+	list := make([]string, 0, len(endpoints))
+	for _, v := range endpoints {
+		if !strings.HasPrefix(v, "http") {
+			v = "https://" + v
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+// contextRoundTripper 将调用方context合并到请求中
+type contextRoundTripper struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (c *contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := context.Cause(c.ctx); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancelCause(req.Context())
+	stop := context.AfterFunc(c.ctx, func() {
+		cancel(context.Cause(c.ctx))
+	})
+	resp, err := c.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		stop()
+		cancel(nil)
+		if cause := context.Cause(c.ctx); cause != nil {
+			return nil, cause
+		}
+		return nil, err
+	}
+	resp.Body = &contextBody{ReadCloser: resp.Body, release: func() {
+		stop()
+		cancel(nil)
+	}}
+	return resp, nil
+}
+
+// contextBody 响应体关闭时释放context
+type contextBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *contextBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
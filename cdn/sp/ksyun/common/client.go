@@ -0,0 +1,175 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun/common/model"
+	"net/http"
+	"strings"
+)
+
+const (
+	ApiVersion      = "2016-09-01"
+	DefaultEndpoint = "https://cdn.api.ksyun.com"
+	DefaultRegion   = "cn-shanghai-1"
+	ApplicationJson = "application/json"
+)
+
+type Client struct {
+	rest *rest.Client
+}
+
+func NewClient(signer *Signer, endpoint string) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	client := rest.NewClient(endpoint, signer)
+	client.ErrorDecoder = rest.NewErrorDecoder(rest.ErrorFields{
+		RequestIdHeader: HeaderRequestId,
+		RequestId:       []string{"RequestId"},
+		Code:            []string{"Error", "Code"},
+		Message:         []string{"Error", "Message"},
+	})
+	return &Client{
+		rest: client,
+	}
+}
+
+// WithHttpClient 设置发送请求使用的HTTP客户端,为空时使用http.DefaultClient
+func (c *Client) WithHttpClient(client *http.Client) *Client {
+	if client != nil {
+		c.rest.HttpClient = client
+	}
+	return c
+}
+
+// send 发送签名请求,GET请求参数放在查询串中,其余请求参数以json放在请求体中
+func send[T any](ctx context.Context, c *Client, method string, api string, params interface{}) (*T, error) {
+	r := rest.NewRequest(method, "/"+ApiVersion+"/"+api).WithHeader("Accept", ApplicationJson)
+	if method != http.MethodGet {
+		return rest.Do[T](ctx, c.rest, r.WithBody(params))
+	}
+	if _, err := r.WithQueryParams(params); err != nil {
+		return nil, err
+	}
+	return rest.Do[T](ctx, c.rest, r)
+}
+
+// AddCdnDomain 新增加速域名
+func (c *Client) AddCdnDomain(ctx context.Context, req *model.AddCdnDomainRequest) (*model.AddCdnDomainResponse, error) {
+	return send[model.AddCdnDomainResponse](ctx, c, http.MethodPost, "domain/AddCdnDomain", req)
+}
+
+// GetCdnDomains 查询域名列表
+func (c *Client) GetCdnDomains(ctx context.Context, req *model.GetCdnDomainsRequest) (*model.GetCdnDomainsResponse, error) {
+	return send[model.GetCdnDomainsResponse](ctx, c, http.MethodGet, "domain/GetCdnDomains", req)
+}
+
+// GetCdnDomainBasicInfo 查询域名基础信息
+func (c *Client) GetCdnDomainBasicInfo(ctx context.Context, req *model.GetCdnDomainBasicInfoRequest) (*model.GetCdnDomainBasicInfoResponse, error) {
+	return send[model.GetCdnDomainBasicInfoResponse](ctx, c, http.MethodGet, "domain/GetCdnDomainBasicInfo", req)
+}
+
+// ModifyCdnDomainBasicInfo 修改域名基础信息
+func (c *Client) ModifyCdnDomainBasicInfo(ctx context.Context, req *model.ModifyCdnDomainBasicInfoRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/ModifyCdnDomainBasicInfo", req)
+}
+
+// StartStopCdnDomain 启用或停用域名
+func (c *Client) StartStopCdnDomain(ctx context.Context, req *model.StartStopCdnDomainRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/StartStopCdnDomain", req)
+}
+
+// DeleteCdnDomain 删除域名
+func (c *Client) DeleteCdnDomain(ctx context.Context, req *model.DeleteCdnDomainRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/DeleteCdnDomain", req)
+}
+
+// SetBackOriginHostConfig 设置回源host
+func (c *Client) SetBackOriginHostConfig(ctx context.Context, req *model.SetBackOriginHostConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetBackOriginHostConfig", req)
+}
+
+// SetOriginAdvancedConfig 设置高级回源策略
+func (c *Client) SetOriginAdvancedConfig(ctx context.Context, req *model.SetOriginAdvancedConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetOriginAdvancedConfig", req)
+}
+
+// SetCacheRuleConfig 设置缓存规则
+func (c *Client) SetCacheRuleConfig(ctx context.Context, req *model.SetCacheRuleConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetCacheRuleConfig", req)
+}
+
+// SetReferProtectionConfig 设置referer防盗链
+func (c *Client) SetReferProtectionConfig(ctx context.Context, req *model.SetReferProtectionConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetReferProtectionConfig", req)
+}
+
+// SetIpProtectionConfig 设置ip黑白名单
+func (c *Client) SetIpProtectionConfig(ctx context.Context, req *model.SetIpProtectionConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetIpProtectionConfig", req)
+}
+
+// SetRequestAuthConfig 设置url鉴权
+func (c *Client) SetRequestAuthConfig(ctx context.Context, req *model.SetRequestAuthConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetRequestAuthConfig", req)
+}
+
+// SetErrorPageConfig 设置自定义错误页面
+func (c *Client) SetErrorPageConfig(ctx context.Context, req *model.SetErrorPageConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetErrorPageConfig", req)
+}
+
+// SetHttpHeadersConfig 设置http响应头
+func (c *Client) SetHttpHeadersConfig(ctx context.Context, req *model.SetHttpHeadersConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetHttpHeadersConfig", req)
+}
+
+// DeleteHttpHeadersConfig 删除http响应头
+func (c *Client) DeleteHttpHeadersConfig(ctx context.Context, req *model.DeleteHttpHeadersConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/DeleteHttpHeadersConfig", req)
+}
+
+// SetForceRedirectConfig 设置强制跳转
+func (c *Client) SetForceRedirectConfig(ctx context.Context, req *model.SetForceRedirectConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetForceRedirectConfig", req)
+}
+
+// SetHttp2OptionConfig 设置http2
+func (c *Client) SetHttp2OptionConfig(ctx context.Context, req *model.SetHttp2OptionConfigRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "domain/SetHttp2OptionConfig", req)
+}
+
+// ConfigCertificate 为域名配置证书
+func (c *Client) ConfigCertificate(ctx context.Context, req *model.ConfigCertificateRequest) (*model.CommonResponse, error) {
+	return send[model.CommonResponse](ctx, c, http.MethodPost, "cert/ConfigCertificate", req)
+}
+
+// RefreshCaches 刷新缓存
+func (c *Client) RefreshCaches(ctx context.Context, req *model.RefreshCachesRequest) (*model.RefreshCachesResponse, error) {
+	return send[model.RefreshCachesResponse](ctx, c, http.MethodPost, "content/RefreshCaches", req)
+}
+
+// PreloadCaches 预热缓存
+func (c *Client) PreloadCaches(ctx context.Context, req *model.PreloadCachesRequest) (*model.PreloadCachesResponse, error) {
+	return send[model.PreloadCachesResponse](ctx, c, http.MethodPost, "content/PreloadCaches", req)
+}
+
+// GetRefreshOrPreloadTask 查询刷新预热任务
+func (c *Client) GetRefreshOrPreloadTask(ctx context.Context, req *model.GetRefreshOrPreloadTaskRequest) (*model.GetRefreshOrPreloadTaskResponse, error) {
+	return send[model.GetRefreshOrPreloadTaskResponse](ctx, c, http.MethodPost, "content/GetRefreshOrPreloadTask", req)
+}
+
+// GetStatisticsData 查询统计数据,api为统计接口名,如GetFlowData、GetBandwidthData、GetPvData
+func (c *Client) GetStatisticsData(ctx context.Context, api string, req *model.StatisticsRequest) (*model.StatisticsResponse, error) {
+	if strings.ContainsAny(api, "/?") {
+		return nil, fmt.Errorf("invalid statistics api %q", api)
+	}
+	return send[model.StatisticsResponse](ctx, c, http.MethodGet, "statistics/"+api, req)
+}
+
+// GetTopUrlData 查询TOP URL
+func (c *Client) GetTopUrlData(ctx context.Context, req *model.TopUrlRequest) (*model.TopUrlResponse, error) {
+	return send[model.TopUrlResponse](ctx, c, http.MethodGet, "statistics/GetTopUrlData", req)
+}
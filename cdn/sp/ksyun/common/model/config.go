@@ -0,0 +1,121 @@
+package model
+
+type SetBackOriginHostConfigRequest struct {
+	DomainId string `json:"DomainId"`
+	//回源host,为空时使用加速域名
+	BackOriginHost string `json:"BackOriginHost"`
+}
+
+type SetOriginAdvancedConfigRequest struct {
+	DomainId string `json:"DomainId"`
+	//是否开启高级回源：on、off
+	Enable string `json:"Enable"`
+	//回源策略：rr轮询、quality按质量
+	OriginPolicy string `json:"OriginPolicy,omitempty"`
+	//备源类型：ipaddr、domain
+	BackupOriginType string `json:"BackupOriginType,omitempty"`
+	//备源地址,多个以逗号分隔
+	BackupOrigin string `json:"BackupOrigin,omitempty"`
+}
+
+type CacheRule struct {
+	//规则类型：file_suffix文件后缀、directory目录、exact全路径
+	CacheRuleType string `json:"CacheRuleType"`
+	//规则内容,多个以逗号分隔
+	Value string `json:"Value"`
+	//缓存时间,单位秒,0表示不缓存
+	CacheTime int64 `json:"CacheTime"`
+	//是否遵循源站缓存策略：on、off
+	RespectOrigin string `json:"RespectOrigin"`
+}
+
+type SetCacheRuleConfigRequest struct {
+	DomainId   string       `json:"DomainId"`
+	CacheRules []*CacheRule `json:"CacheRules"`
+}
+
+type SetReferProtectionConfigRequest struct {
+	DomainId string `json:"DomainId"`
+	//是否开启：on、off
+	Enable string `json:"Enable"`
+	//名单类型：block黑名单、allow白名单
+	ReferType string `json:"ReferType,omitempty"`
+	//referer列表,多个以逗号分隔
+	ReferList string `json:"ReferList,omitempty"`
+	//是否允许空referer：on、off
+	AllowEmpty string `json:"AllowEmpty,omitempty"`
+}
+
+type SetIpProtectionConfigRequest struct {
+	DomainId string `json:"DomainId"`
+	//是否开启：on、off
+	Enable string `json:"Enable"`
+	//名单类型：block黑名单、allow白名单
+	IpType string `json:"IpType,omitempty"`
+	//ip列表,多个以逗号分隔
+	IpList string `json:"IpList,omitempty"`
+}
+
+type SetRequestAuthConfigRequest struct {
+	DomainId string `json:"DomainId"`
+	//是否开启：on、off
+	Enable string `json:"Enable"`
+	//鉴权类型：typeA、typeB、typeC
+	AuthType string `json:"AuthType,omitempty"`
+	//主鉴权key
+	Key1 string `json:"Key1,omitempty"`
+	//备鉴权key
+	Key2 string `json:"Key2,omitempty"`
+	//有效时间,单位秒
+	ExpirationTime int64 `json:"ExpirationTime,omitempty"`
+	//鉴权参数名,仅typeA有效
+	SignParam string `json:"SignParam,omitempty"`
+}
+
+type ErrorPage struct {
+	//错误状态码
+	ErrorHttpCode int64 `json:"ErrorHttpCode"`
+	//自定义页面地址
+	CustomPageUrl string `json:"CustomPageUrl"`
+}
+
+type SetErrorPageConfigRequest struct {
+	DomainId   string       `json:"DomainId"`
+	ErrorPages []*ErrorPage `json:"ErrorPages"`
+}
+
+type SetHttpHeadersConfigRequest struct {
+	DomainId    string `json:"DomainId"`
+	HeaderKey   string `json:"HeaderKey"`
+	HeaderValue string `json:"HeaderValue"`
+}
+
+type DeleteHttpHeadersConfigRequest struct {
+	DomainId  string `json:"DomainId"`
+	HeaderKey string `json:"HeaderKey"`
+}
+
+type ConfigCertificateRequest struct {
+	//是否开启https：on、off
+	Enable string `json:"Enable"`
+	//域名ID,多个以逗号分隔
+	DomainIds string `json:"DomainIds"`
+	//证书名称
+	CertificateName string `json:"CertificateName,omitempty"`
+	//证书内容
+	ServerCertificate string `json:"ServerCertificate,omitempty"`
+	//证书私钥
+	PrivateKey string `json:"PrivateKey,omitempty"`
+}
+
+type SetForceRedirectConfigRequest struct {
+	DomainId string `json:"DomainId"`
+	//跳转类型：off不跳转、https跳转https、http跳转http
+	RedirectType string `json:"RedirectType"`
+}
+
+type SetHttp2OptionConfigRequest struct {
+	DomainId string `json:"DomainId"`
+	//是否开启：on、off
+	Enable string `json:"Enable"`
+}
@@ -0,0 +1,61 @@
+package model
+
+type UrlItem struct {
+	Url string `json:"Url"`
+}
+
+type RefreshCachesRequest struct {
+	//刷新的url列表,单次最多1000条
+	Files []*UrlItem `json:"Files,omitempty"`
+	//刷新的目录列表,单次最多50条
+	Dirs []*UrlItem `json:"Dirs,omitempty"`
+}
+
+type RefreshCachesResponse struct {
+	RequestId     string `json:"RequestId"`
+	RefreshTaskId string `json:"RefreshTaskId"`
+}
+
+type PreloadCachesRequest struct {
+	//预热的url列表,单次最多1000条
+	Urls []*UrlItem `json:"Urls"`
+}
+
+type PreloadCachesResponse struct {
+	RequestId     string `json:"RequestId"`
+	PreloadTaskId string `json:"PreloadTaskId"`
+}
+
+type GetRefreshOrPreloadTaskRequest struct {
+	//任务ID,与时间范围二选一
+	TaskId string `json:"TaskId,omitempty"`
+	//查询开始时间,格式如：2016-09-01T08:00+0800
+	StartTime string `json:"StartTime,omitempty"`
+	//查询结束时间
+	EndTime string `json:"EndTime,omitempty"`
+	//任务类型：refresh、preload
+	Type string `json:"Type"`
+	//页码,从1开始
+	PageNumber int64 `json:"PageNumber,omitempty"`
+	//每页条数
+	PageSize int64 `json:"PageSize,omitempty"`
+}
+
+type RefreshOrPreloadTask struct {
+	TaskId string `json:"TaskId"`
+	Url    string `json:"Url"`
+	//任务类型：refresh、preload
+	Type string `json:"Type"`
+	//刷新类型：file、dir,仅刷新任务有效
+	UrlType string `json:"UrlType"`
+	//任务状态：success、progressing、failed
+	Status      string `json:"Status"`
+	Progress    string `json:"Progress"`
+	CreatedTime string `json:"CreatedTime"`
+}
+
+type GetRefreshOrPreloadTaskResponse struct {
+	RequestId  string                  `json:"RequestId"`
+	TotalCount int64                   `json:"TotalCount"`
+	Datas      []*RefreshOrPreloadTask `json:"Datas"`
+}
@@ -0,0 +1,94 @@
+package model
+
+// CommonResponse 只返回请求ID的接口响应
+type CommonResponse struct {
+	RequestId string `json:"RequestId"`
+}
+
+type AddCdnDomainRequest struct {
+	//加速域名
+	DomainName string `json:"DomainName"`
+	//产品类型：file小文件、download大文件下载、video音视频点播
+	CdnType string `json:"CdnType"`
+	//加速区域：CN中国大陆、AS亚洲、EU欧洲、NA北美,多个以逗号分隔
+	Regions string `json:"Regions,omitempty"`
+	//源站类型：ipaddr、domain、KS3
+	OriginType string `json:"OriginType"`
+	//回源协议：http、https、follow
+	OriginProtocol string `json:"OriginProtocol"`
+	//源站地址,多个以逗号分隔
+	Origin string `json:"Origin"`
+	//源站端口
+	OriginPort int64 `json:"OriginPort,omitempty"`
+}
+
+type AddCdnDomainResponse struct {
+	RequestId string `json:"RequestId"`
+	DomainId  string `json:"DomainId"`
+}
+
+type GetCdnDomainsRequest struct {
+	//页码,从1开始
+	PageNumber int64 `json:"PageNumber,omitempty"`
+	//每页条数,最大500
+	PageSize int64 `json:"PageSize,omitempty"`
+	//按域名过滤
+	DomainName string `json:"DomainName,omitempty"`
+	//域名是否模糊匹配：on、off
+	FuzzyMatch string `json:"FuzzyMatch,omitempty"`
+	//按域名状态过滤：online、offline、configuring、configure_failed、icp_checking、icp_check_failed
+	DomainStatus string `json:"DomainStatus,omitempty"`
+	//按产品类型过滤
+	CdnType string `json:"CdnType,omitempty"`
+}
+
+type Domain struct {
+	DomainId     string `json:"DomainId"`
+	DomainName   string `json:"DomainName"`
+	Cname        string `json:"Cname"`
+	CdnType      string `json:"CdnType"`
+	Regions      string `json:"Regions"`
+	DomainStatus string `json:"DomainStatus"`
+	CreatedTime  string `json:"CreatedTime"`
+	ModifiedTime string `json:"ModifiedTime"`
+}
+
+type GetCdnDomainsResponse struct {
+	RequestId  string    `json:"RequestId"`
+	PageNumber int64     `json:"PageNumber"`
+	PageSize   int64     `json:"PageSize"`
+	TotalCount int64     `json:"TotalCount"`
+	Domains    []*Domain `json:"Domains"`
+}
+
+type GetCdnDomainBasicInfoRequest struct {
+	DomainId string `json:"DomainId"`
+}
+
+type GetCdnDomainBasicInfoResponse struct {
+	RequestId string `json:"RequestId"`
+	Domain
+	OriginType     string `json:"OriginType"`
+	OriginProtocol string `json:"OriginProtocol"`
+	Origin         string `json:"Origin"`
+	OriginPort     int64  `json:"OriginPort"`
+}
+
+type ModifyCdnDomainBasicInfoRequest struct {
+	DomainId       string `json:"DomainId"`
+	Regions        string `json:"Regions,omitempty"`
+	OriginType     string `json:"OriginType,omitempty"`
+	OriginProtocol string `json:"OriginProtocol,omitempty"`
+	Origin         string `json:"Origin,omitempty"`
+	OriginPort     int64  `json:"OriginPort,omitempty"`
+}
+
+type StartStopCdnDomainRequest struct {
+	DomainId string `json:"DomainId"`
+	//操作类型：start启用、stop停用
+	ActionType string `json:"ActionType"`
+}
+
+type DeleteCdnDomainRequest struct {
+	DomainId string `json:"DomainId"`
+}
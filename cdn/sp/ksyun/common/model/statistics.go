@@ -0,0 +1,68 @@
+package model
+
+type StatisticsRequest struct {
+	//开始时间,格式如：2016-09-01T08:00+0800
+	StartTime string `json:"StartTime"`
+	//结束时间
+	EndTime string `json:"EndTime"`
+	//产品类型
+	CdnType string `json:"CdnType"`
+	//域名ID,多个以逗号分隔
+	DomainIds string `json:"DomainIds,omitempty"`
+	//区域：CN、AS、EU、NA,多个以逗号分隔
+	Regions string `json:"Regions,omitempty"`
+	//结果类型：0合并返回、1按域名返回
+	ResultType int64 `json:"ResultType"`
+	//统计粒度,单位分钟：5、60、1440
+	Granularity int64 `json:"Granularity,omitempty"`
+	//数据类型：edge边缘、origin回源
+	DataType string `json:"DataType,omitempty"`
+}
+
+type DomainStatisticsData struct {
+	DomainId string  `json:"DomainId"`
+	Bw       float64 `json:"Bw"`
+	Flow     float64 `json:"Flow"`
+	Pv       float64 `json:"Pv"`
+}
+
+type StatisticsData struct {
+	//时间点,格式如：2016-09-01T08:00+0800
+	DataTime string                  `json:"DataTime"`
+	Bw       float64                 `json:"Bw"`
+	Flow     float64                 `json:"Flow"`
+	Pv       float64                 `json:"Pv"`
+	Domains  []*DomainStatisticsData `json:"Domains"`
+}
+
+type StatisticsResponse struct {
+	RequestId   string            `json:"RequestId"`
+	StartTime   string            `json:"StartTime"`
+	EndTime     string            `json:"EndTime"`
+	CdnType     string            `json:"CdnType"`
+	ResultType  int64             `json:"ResultType"`
+	Granularity int64             `json:"Granularity"`
+	DataType    string            `json:"DataType"`
+	Datas       []*StatisticsData `json:"Datas"`
+}
+
+type TopUrlRequest struct {
+	StartTime string `json:"StartTime"`
+	EndTime   string `json:"EndTime"`
+	CdnType   string `json:"CdnType"`
+	//域名ID,多个以逗号分隔
+	DomainIds string `json:"DomainIds"`
+	//返回条数,最大200
+	LimitN int64 `json:"LimitN,omitempty"`
+}
+
+type TopUrlData struct {
+	Url  string  `json:"Url"`
+	Pv   float64 `json:"Pv"`
+	Flow float64 `json:"Flow"`
+}
+
+type TopUrlResponse struct {
+	RequestId string        `json:"RequestId"`
+	Datas     []*TopUrlData `json:"Datas"`
+}
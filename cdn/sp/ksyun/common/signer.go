@@ -0,0 +1,142 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	SignAlgorithm   = "AWS4-HMAC-SHA256"
+	SignService     = "cdn"
+	SignTerminator  = "aws4_request"
+	HeaderDate      = "X-Amz-Date"
+	HeaderRequestId = "X-Kss-Request-Id"
+	DateFormat      = "20060102T150405Z"
+	ShortDateFormat = "20060102"
+)
+
+// Signer 金山云接口签名,兼容AWS Signature V4,只保存不可变的鉴权信息,可在多个goroutine间共享
+type Signer struct {
+	AccessKey string
+	SecretKey string
+	Region    string
+}
+
+func NewSigner(accessKey string, secretKey string, region string) *Signer {
+	return &Signer{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Region:    region,
+	}
+}
+
+// Sign 实现rest.Signer,使用指定时间对请求签名,body为请求体原文
+func (s *Signer) Sign(req *http.Request, body []byte, t time.Time) error {
+	t = t.UTC()
+	req.Header.Set(HeaderDate, t.Format(DateFormat))
+	if req.Header.Get("Host") == "" {
+		req.Header.Set("Host", req.URL.Host)
+	}
+	signedHeaders, canonicalHeaders := getCanonicalHeaders(req.Header)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		getCanonicalUri(req.URL),
+		getCanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+	scope := strings.Join([]string{t.Format(ShortDateFormat), s.Region, SignService, SignTerminator}, "/")
+	stringToSign := strings.Join([]string{
+		SignAlgorithm,
+		t.Format(DateFormat),
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+	signature := hex.EncodeToString(hmacSha256(s.signingKey(t), stringToSign))
+	req.Header.Set("Authorization", SignAlgorithm+" Credential="+s.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+	return nil
+}
+
+// signingKey 逐级派生签名密钥
+func (s *Signer) signingKey(t time.Time) []byte {
+	key := hmacSha256([]byte("AWS4"+s.SecretKey), t.Format(ShortDateFormat))
+	key = hmacSha256(key, s.Region)
+	key = hmacSha256(key, SignService)
+	return hmacSha256(key, SignTerminator)
+}
+
+// getCanonicalHeaders 参与签名的头部按小写名称排序,返回签名头部列表与规范化头部
+func getCanonicalHeaders(header http.Header) (string, string) {
+	names := make([]string, 0, len(header))
+	values := make(map[string]string, len(header))
+	for k, v := range header {
+		name := strings.ToLower(k)
+		if name == "authorization" || name == "user-agent" {
+			continue
+		}
+		names = append(names, name)
+		items := make([]string, 0, len(v))
+		for _, vv := range v {
+			items = append(items, strings.Join(strings.Fields(vv), " "))
+		}
+		values[name] = strings.Join(items, ",")
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(values[name])
+		b.WriteByte('\n')
+	}
+	return strings.Join(names, ";"), b.String()
+}
+
+func getCanonicalUri(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// getCanonicalQuery 查询参数按名称和值排序,空格编码为%20
+func getCanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]string, 0, len(query))
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			items = append(items, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(items, "&")
+}
+
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hashHex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
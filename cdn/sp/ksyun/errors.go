@@ -0,0 +1,73 @@
+package ksyun
+
+import (
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"strings"
+)
+
+// wrapError 将金山云接口错误转换为统一错误
+func wrapError(err error) error {
+	var e *common.ServiceResponseError
+	if !errors.As(err, &e) {
+		return err
+	}
+	return &cdnerr.Error{
+		Kind:       getErrorKind(e.ErrorCode, e.StatusCode),
+		Provider:   types.KsYunSdkName,
+		Code:       e.ErrorCode,
+		Message:    e.ErrorMessage,
+		RequestId:  e.RequestId,
+		StatusCode: e.StatusCode,
+		Temporary:  e.StatusCode >= http.StatusInternalServerError,
+		Err:        err,
+	}
+}
+
+// getErrorKind 错误码转错误分类
+func getErrorKind(code string, statusCode int) error {
+	c := strings.ToLower(code)
+	switch {
+	case strings.Contains(c, "domainnotfound"), strings.Contains(c, "domainnotexist"), strings.Contains(c, "nosuchdomain"):
+		return cdnerr.ErrDomainNotFound
+	case strings.Contains(c, "domainalreadyexist"), strings.Contains(c, "domainexist"), strings.Contains(c, "domainduplicate"):
+		return cdnerr.ErrDomainAlreadyExists
+	case strings.Contains(c, "icp"):
+		return cdnerr.ErrIcpNotFiled
+	case strings.Contains(c, "throttl"), strings.Contains(c, "ratelimit"), strings.Contains(c, "requestlimit"), strings.Contains(c, "toomanyrequests"):
+		return cdnerr.ErrRateLimited
+	case strings.Contains(c, "quota"), strings.Contains(c, "exceed"):
+		return cdnerr.ErrQuotaExceeded
+	case strings.Contains(c, "signature"), strings.Contains(c, "accesskey"), strings.Contains(c, "auth"), strings.Contains(c, "forbidden"), strings.Contains(c, "accessdenied"):
+		return cdnerr.ErrAuthFailed
+	case strings.Contains(c, "invalid"), strings.Contains(c, "missing"):
+		return cdnerr.ErrInvalidParameter
+	}
+	switch statusCode {
+	case http.StatusNotFound:
+		return cdnerr.ErrDomainNotFound
+	case http.StatusConflict:
+		return cdnerr.ErrDomainAlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return cdnerr.ErrAuthFailed
+	case http.StatusTooManyRequests:
+		return cdnerr.ErrRateLimited
+	case http.StatusBadRequest:
+		return cdnerr.ErrInvalidParameter
+	default:
+		return nil
+	}
+}
+
+// newNotFoundError 查询的任务或统计数据不存在
+func newNotFoundError(message string, requestId string) error {
+	return &cdnerr.Error{
+		Kind:      cdnerr.ErrNotFound,
+		Provider:  types.KsYunSdkName,
+		Message:   message,
+		RequestId: requestId,
+	}
+}
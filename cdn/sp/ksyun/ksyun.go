@@ -0,0 +1,1253 @@
+package ksyun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// listPageSize 域名列表与任务列表每页条数
+const listPageSize = 500
+
+type KsYun struct {
+	ctx     context.Context
+	config  *Config
+	limiter *ratelimit.Limiter
+	client  *common.Client
+}
+
+type Config struct {
+	Ak        string
+	Sk        string
+	Region    string              // 签名区域,默认cn-shanghai-1
+	Endpoint  string              // 接口地址,默认https://cdn.api.ksyun.com
+	Retry     *retry.Policy       // 重试策略,为空时不重试
+	RateLimit *ratelimit.Limiter  // 限流器,每次调用厂商接口(含重试)前获取令牌,为空时使用ratelimit.DefaultLimits,不限制时传入ratelimit.Unlimited()
+	Http      *httpclient.Options // HTTP客户端选项,为空时使用默认客户端,超时为httpclient.DefaultTimeout
+	Logger    *slog.Logger        // 记录HTTP请求的日志,为空时不记录
+}
+
+// NewKsYunSdkClient 创建金山云CDN客户端,创建失败时返回nil,需要错误信息时使用 New
+func NewKsYunSdkClient(ctx context.Context, conf *Config) *KsYun {
+	client, err := New(ctx, conf)
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// New 创建金山云CDN客户端
+func New(ctx context.Context, conf *Config) (*KsYun, error) {
+	if conf == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.KsYunSdkName, "config is nil")
+	}
+	if conf.Ak == "" || conf.Sk == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.KsYunSdkName, "ak or sk is empty")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	region := conf.Region
+	if region == "" {
+		region = common.DefaultRegion
+	}
+	httpClient, err := conf.Http.NewClient()
+	if err != nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.KsYunSdkName, "invalid http options: "+err.Error())
+	}
+	httpClient.Transport = cdnlog.NewTransport(conf.Logger, httpClient.Transport)
+	return &KsYun{
+		ctx:     ctx,
+		config:  conf,
+		limiter: ratelimit.OrDefault(conf.RateLimit, types.KsYunSdkName),
+		client:  common.NewClient(common.NewSigner(conf.Ak, conf.Sk, region), conf.Endpoint).WithHttpClient(httpClient),
+	}, nil
+}
+
+func (k *KsYun) GetSdkName() string {
+	return types.KsYunSdkName
+}
+
+// invoke 调用厂商接口,每次尝试前按class获取限流令牌,转换错误并按重试策略重试
+func invoke[T any](ctx context.Context, k *KsYun, class ratelimit.Class, idempotent bool, fn func() (T, error)) (T, error) {
+	return retry.Do(ctx, k.config.Retry, idempotent, func() (T, error) {
+		if err := k.limiter.Wait(ctx, class); err != nil {
+			var zero T
+			return zero, err
+		}
+		res, err := fn()
+		return res, wrapError(err)
+	})
+}
+
+// getDomainId 获取域名ID,金山云配置接口均以域名ID操作,未传入ID时按域名查询
+func (k *KsYun) getDomainId(ctx context.Context, domain string, domainId string) (string, error) {
+	if domainId != "" {
+		return domainId, nil
+	}
+	res, err := invoke(ctx, k, ratelimit.ClassQuery, retry.Idempotent, func() (*model.GetCdnDomainsResponse, error) {
+		return k.client.GetCdnDomains(ctx, &model.GetCdnDomainsRequest{
+			PageNumber: 1,
+			PageSize:   listPageSize,
+			DomainName: domain,
+			FuzzyMatch: consts.OFF,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, v := range res.Domains {
+		if v.DomainName == domain {
+			return v.DomainId, nil
+		}
+	}
+	return "", cdnerr.New(cdnerr.ErrDomainNotFound, types.KsYunSdkName, fmt.Sprintf("domain %s not found", domain))
+}
+
+// listDomains 分页获取全部域名
+func (k *KsYun) listDomains(ctx context.Context) ([]*model.Domain, error) {
+	domains := make([]*model.Domain, 0)
+	for page := int64(1); ; page++ {
+		res, err := invoke(ctx, k, ratelimit.ClassQuery, retry.Idempotent, func() (*model.GetCdnDomainsResponse, error) {
+			return k.client.GetCdnDomains(ctx, &model.GetCdnDomainsRequest{PageNumber: page, PageSize: listPageSize})
+		})
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, res.Domains...)
+		if len(res.Domains) == 0 || int64(len(domains)) >= res.TotalCount {
+			return domains, nil
+		}
+	}
+}
+
+// getDomainNames 获取统计域名的ID与域名的对应关系,未指定域名时返回全部域名
+func (k *KsYun) getDomainNames(ctx context.Context, domains []string) (map[string]string, error) {
+	all, err := k.listDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(all))
+	if len(domains) == 0 {
+		for _, v := range all {
+			names[v.DomainId] = v.DomainName
+		}
+		return names, nil
+	}
+	ids := make(map[string]string, len(all))
+	for _, v := range all {
+		ids[v.DomainName] = v.DomainId
+	}
+	for _, v := range domains {
+		id, ok := ids[v]
+		if !ok {
+			return nil, cdnerr.New(cdnerr.ErrDomainNotFound, types.KsYunSdkName, fmt.Sprintf("domain %s not found", v))
+		}
+		names[id] = v
+	}
+	return names, nil
+}
+
+// getDomainIds 拼接统计接口的域名ID参数
+func getDomainIds(names map[string]string) string {
+	ids := make([]string, 0, len(names))
+	for id := range names {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// CreateDomain 创建域名
+func (k *KsYun) CreateDomain(data *types.CreateDomainRequest) error {
+	return k.CreateDomainWithContext(k.ctx, data)
+}
+
+// CreateDomainWithContext 创建域名
+func (k *KsYun) CreateDomainWithContext(ctx context.Context, data *types.CreateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	req := &model.AddCdnDomainRequest{
+		DomainName:     data.Domain,
+		CdnType:        getCdnType(data.ChannelType),
+		Regions:        getRegions(data.AreaCode),
+		OriginProtocol: getOriginProtocol(data.OriginProtocol),
+	}
+	primary := getOrigins(data.Sources, consts.OriginPriorityPrimary)
+	if len(primary) > 0 {
+		req.OriginType = getOriginType(primary[0].OriginType)
+		req.Origin = joinOrigins(primary)
+		req.OriginPort = getOriginPort(data.OriginProtocol, primary[0])
+	}
+	res, err := invoke(ctx, k, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.AddCdnDomainResponse, error) {
+		return k.client.AddCdnDomain(ctx, req)
+	})
+	if err != nil {
+		return err
+	}
+	//回源host与备源需在域名创建后单独设置
+	updateDomain := k.newUpdateDomainConfigModel(&types.UpdateDomainRequest{
+		Domain:           data.Domain,
+		DomainId:         res.DomainId,
+		OriginServerConf: data.Sources,
+	})
+	updateDomain.withOriginExtraConf()
+	//新建域名未开启高级回源,没有备源时无需关闭
+	if updateDomain.originAdvanced != nil && updateDomain.originAdvanced.Enable == consts.OFF {
+		updateDomain.originAdvanced = nil
+	}
+	if updateDomain.backOriginHost == nil && updateDomain.originAdvanced == nil {
+		return nil
+	}
+	return updateDomain.send(ctx)
+}
+
+// getOrigins 获取指定优先级的源站
+func getOrigins(sources []*entity.OriginServerConf, priority int64) []*entity.OriginServerConf {
+	origins := make([]*entity.OriginServerConf, 0, len(sources))
+	for _, v := range sources {
+		if v.OriginPriority == priority {
+			origins = append(origins, v)
+		}
+	}
+	return origins
+}
+
+// joinOrigins 金山云源站地址以逗号分隔
+func joinOrigins(sources []*entity.OriginServerConf) string {
+	addresses := make([]string, 0, len(sources))
+	for _, v := range sources {
+		addresses = append(addresses, v.OriginAddressList)
+	}
+	return strings.Join(addresses, ",")
+}
+
+// UpdateDomain 更新域名
+func (k *KsYun) UpdateDomain(data *types.UpdateDomainRequest) error {
+	return k.UpdateDomainWithContext(k.ctx, data)
+}
+
+// UpdateDomainWithContext 更新域名
+func (k *KsYun) UpdateDomainWithContext(ctx context.Context, data *types.UpdateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	updateDomain := k.newUpdateDomainConfigModel(data)
+	switch data.UpdateAction {
+	case types.UpdateBaseConf, types.UpdateArea:
+		updateDomain.WithArea()
+	case types.UpdateOriginConf:
+		updateDomain.WithOriginConf()
+	case types.UpdateOriginServerConf:
+		updateDomain.WithOriginServerConf()
+	case types.UpdateIpFilterConf:
+		updateDomain.WithIpFilterConf()
+	case types.UpdateRefererConf:
+		updateDomain.WithRefererConf()
+	case types.UpdateAuthConf:
+		updateDomain.WithAuthConf()
+	case types.UpdateCacheListConf:
+		updateDomain.WithCacheListConf()
+	case types.UpdateCustomErrorPageConf:
+		updateDomain.WithCustomErrorPageConf()
+	case types.UpdateResponseHeaderConf:
+		updateDomain.WithResponseHeaderConf()
+	case types.UpdateHttpsConf:
+		updateDomain.WithHttpsConf()
+	case types.UpdateRecommendConf:
+		updateDomain.WithOriginConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithHttpsConf()
+	case types.UpdateFullConf:
+		updateDomain.WithArea()
+		updateDomain.WithOriginConf()
+		updateDomain.WithOriginServerConf()
+		updateDomain.WithIpFilterConf()
+		updateDomain.WithRefererConf()
+		updateDomain.WithAuthConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithCustomErrorPageConf()
+		updateDomain.WithResponseHeaderConf()
+		updateDomain.WithHttpsConf()
+	default:
+		return cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, fmt.Sprintf("update action %s not support", data.UpdateAction))
+	}
+	return updateDomain.send(ctx)
+}
+
+// DisableDomain 停用域名
+func (k *KsYun) DisableDomain(data *types.DisableDomainRequest) error {
+	return k.DisableDomainWithContext(k.ctx, data)
+}
+
+// DisableDomainWithContext 停用域名
+func (k *KsYun) DisableDomainWithContext(ctx context.Context, data *types.DisableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	return k.startStopDomain(ctx, data.Domain, data.DomainId, "stop")
+}
+
+// EnableDomain 启用域名
+func (k *KsYun) EnableDomain(data *types.EnableDomainRequest) error {
+	return k.EnableDomainWithContext(k.ctx, data)
+}
+
+// EnableDomainWithContext 启用域名
+func (k *KsYun) EnableDomainWithContext(ctx context.Context, data *types.EnableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	return k.startStopDomain(ctx, data.Domain, data.DomainId, "start")
+}
+
+func (k *KsYun) startStopDomain(ctx context.Context, domain string, domainId string, actionType string) error {
+	domainId, err := k.getDomainId(ctx, domain, domainId)
+	if err != nil {
+		return err
+	}
+	_, err = invoke(ctx, k, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return k.client.StartStopCdnDomain(ctx, &model.StartStopCdnDomainRequest{DomainId: domainId, ActionType: actionType})
+	})
+	return err
+}
+
+// DeleteDomain 删除域名
+func (k *KsYun) DeleteDomain(data *types.DeleteDomainRequest) error {
+	return k.DeleteDomainWithContext(k.ctx, data)
+}
+
+// DeleteDomainWithContext 删除域名
+func (k *KsYun) DeleteDomainWithContext(ctx context.Context, data *types.DeleteDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	//域名不存在时视为删除成功
+	domainId, err := k.getDomainId(ctx, data.Domain, data.DomainId)
+	if err != nil {
+		if errors.Is(err, cdnerr.ErrDomainNotFound) {
+			return nil
+		}
+		return err
+	}
+	_, err = invoke(ctx, k, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResponse, error) {
+		return k.client.DeleteCdnDomain(ctx, &model.DeleteCdnDomainRequest{DomainId: domainId})
+	})
+	if errors.Is(err, cdnerr.ErrDomainNotFound) {
+		return nil
+	}
+	return err
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (k *KsYun) CreateVerifyRecord(data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return k.CreateVerifyRecordWithContext(k.ctx, data)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (k *KsYun) CreateVerifyRecordWithContext(ctx context.Context, data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, "create verify record not support")
+}
+
+// VerifyDomainRecord 验证域名解析记录
+func (k *KsYun) VerifyDomainRecord(data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return k.VerifyDomainRecordWithContext(k.ctx, data)
+}
+
+// VerifyDomainRecordWithContext 验证域名解析记录
+func (k *KsYun) VerifyDomainRecordWithContext(ctx context.Context, data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, "verify domain record not support")
+}
+
+// ShowDomainDetail 获取域名详情
+func (k *KsYun) ShowDomainDetail(data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return k.ShowDomainDetailWithContext(k.ctx, data)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (k *KsYun) ShowDomainDetailWithContext(ctx context.Context, data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	domainId, err := k.getDomainId(ctx, data.Domain, data.DomainId)
+	if err != nil {
+		return nil, err
+	}
+	res, err := invoke(ctx, k, ratelimit.ClassQuery, retry.Idempotent, func() (*model.GetCdnDomainBasicInfoResponse, error) {
+		return k.client.GetCdnDomainBasicInfo(ctx, &model.GetCdnDomainBasicInfoRequest{DomainId: domainId})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowDomainDetailResponse{
+		DomainId:    res.DomainId,
+		Domain:      res.DomainName,
+		Cname:       res.Cname,
+		ChannelType: mapCdnType(res.CdnType),
+		AreaCode:    mapRegions(res.Regions),
+		Status:      getDomainStatus(res.DomainStatus),
+		CreateTime:  parseTime(res.CreatedTime),
+		UpdateTime:  parseTime(res.ModifiedTime),
+	}, nil
+}
+
+// ShowDomainStatusList 展示域名状态列表
+func (k *KsYun) ShowDomainStatusList(data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return k.ShowDomainStatusListWithContext(k.ctx, data)
+}
+
+// ShowDomainStatusListWithContext 展示域名状态列表
+func (k *KsYun) ShowDomainStatusListWithContext(ctx context.Context, data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status := setDomainStatus(data.Status)
+	if status == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.KsYunSdkName, fmt.Sprintf("domain status %d not support", data.Status))
+	}
+	offset, limit := utils.CalcOffsetAndLimit(data.Page, data.Limit)
+	res, err := invoke(ctx, k, ratelimit.ClassQuery, retry.Idempotent, func() (*model.GetCdnDomainsResponse, error) {
+		return k.client.GetCdnDomains(ctx, &model.GetCdnDomainsRequest{
+			PageNumber:   offset/limit + 1,
+			PageSize:     limit,
+			DomainStatus: status,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	domains := make([]string, 0, len(res.Domains))
+	for _, v := range res.Domains {
+		domains = append(domains, v.DomainName)
+	}
+	return &types.ShowDomainStatusListResponse{Total: res.TotalCount, List: domains}, nil
+}
+
+// PurgePathCache 刷新目录缓存
+func (k *KsYun) PurgePathCache(data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return k.PurgePathCacheWithContext(k.ctx, data)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (k *KsYun) PurgePathCacheWithContext(ctx context.Context, data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.Mode == consts.ContentPurgePathModeFile {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, "purge path mode file not support")
+	}
+	res, err := invoke(ctx, k, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.RefreshCachesResponse, error) {
+		return k.client.RefreshCaches(ctx, &model.RefreshCachesRequest{Dirs: getUrlItems(data.Paths)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: res.RefreshTaskId}, nil
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (k *KsYun) PurgeUrlsCache(data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return k.PurgeUrlsCacheWithContext(k.ctx, data)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (k *KsYun) PurgeUrlsCacheWithContext(ctx context.Context, data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, k, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.RefreshCachesResponse, error) {
+		return k.client.RefreshCaches(ctx, &model.RefreshCachesRequest{Files: getUrlItems(data.Urls)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: res.RefreshTaskId}, nil
+}
+
+// PushUrlsCache 预热URL缓存
+func (k *KsYun) PushUrlsCache(data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return k.PushUrlsCacheWithContext(k.ctx, data)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (k *KsYun) PushUrlsCacheWithContext(ctx context.Context, data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, k, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.PreloadCachesResponse, error) {
+		return k.client.PreloadCaches(ctx, &model.PreloadCachesRequest{Urls: getUrlItems(data.Urls)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PushUrlsCacheResponse{TaskId: res.PreloadTaskId}, nil
+}
+
+func getUrlItems(urls []string) []*model.UrlItem {
+	items := make([]*model.UrlItem, 0, len(urls))
+	for _, v := range urls {
+		items = append(items, &model.UrlItem{Url: v})
+	}
+	return items
+}
+
+// ShowPurgeTaskStatus 展示刷新任务状态
+func (k *KsYun) ShowPurgeTaskStatus(data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return k.ShowPurgeTaskStatusWithContext(k.ctx, data)
+}
+
+// ShowPurgeTaskStatusWithContext 展示刷新任务状态
+func (k *KsYun) ShowPurgeTaskStatusWithContext(ctx context.Context, data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status, err := k.getTaskStatus(ctx, data.TaskId, taskTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPurgeTaskStatusResponse{TaskId: data.TaskId, Status: status}, nil
+}
+
+// ShowPushTaskStatus 展示预热任务状态
+func (k *KsYun) ShowPushTaskStatus(data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return k.ShowPushTaskStatusWithContext(k.ctx, data)
+}
+
+// ShowPushTaskStatusWithContext 展示预热任务状态
+func (k *KsYun) ShowPushTaskStatusWithContext(ctx context.Context, data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status, err := k.getTaskStatus(ctx, data.TaskId, taskTypePreload)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowPushTaskStatusResponse{TaskId: data.TaskId, Status: status}, nil
+}
+
+func (k *KsYun) getTaskStatus(ctx context.Context, taskId string, taskType string) (int64, error) {
+	res, err := invoke(ctx, k, ratelimit.ClassQuery, retry.Idempotent, func() (*model.GetRefreshOrPreloadTaskResponse, error) {
+		return k.client.GetRefreshOrPreloadTask(ctx, &model.GetRefreshOrPreloadTaskRequest{
+			TaskId:     taskId,
+			Type:       taskType,
+			PageNumber: 1,
+			PageSize:   listPageSize,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(res.Datas) == 0 {
+		return 0, newNotFoundError("task not found", res.RequestId)
+	}
+	return mergeTaskStatus(res.Datas), nil
+}
+
+// mergeTaskStatus 汇总任务下所有url的状态,存在进行中则为进行中,存在失败则为失败
+func mergeTaskStatus(tasks []*model.RefreshOrPreloadTask) int64 {
+	taskStatus := int64(consts.ShowContentPurgeOrPushStatusSuccess)
+	for _, v := range tasks {
+		status := getShowContentPurgeOrPushStatus(v.Status)
+		if status == consts.ShowContentPurgeOrPushStatusDoing {
+			return status
+		}
+		if status == consts.ShowContentPurgeOrPushStatusFail {
+			taskStatus = status
+		}
+	}
+	return taskStatus
+}
+
+// ShowPurgeTaskList 展示刷新任务列表
+func (k *KsYun) ShowPurgeTaskList(data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return k.ShowPurgeTaskListWithContext(k.ctx, data)
+}
+
+// ShowPurgeTaskListWithContext 展示刷新任务列表
+func (k *KsYun) ShowPurgeTaskListWithContext(ctx context.Context, data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.TimeZone == "" {
+		data.TimeZone = "Asia/Shanghai"
+	}
+	tasks, err := k.listTasks(ctx, taskTypeRefresh, data.StartTime, data.EndTime, data.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	urlType := getUrlType(data.PurgeType)
+	filtered := make([]*model.RefreshOrPreloadTask, 0, len(tasks))
+	for _, v := range tasks {
+		if v.UrlType == "" || v.UrlType == urlType {
+			filtered = append(filtered, v)
+		}
+	}
+	total, list := getTaskIds(filtered, data.TaskStatus, data.Page, data.Limit)
+	return &types.ShowPurgeTaskListResponse{Total: total, List: list}, nil
+}
+
+// ShowPushTaskList 展示预热任务列表
+func (k *KsYun) ShowPushTaskList(data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return k.ShowPushTaskListWithContext(k.ctx, data)
+}
+
+// ShowPushTaskListWithContext 展示预热任务列表
+func (k *KsYun) ShowPushTaskListWithContext(ctx context.Context, data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.TimeZone == "" {
+		data.TimeZone = "Asia/Shanghai"
+	}
+	tasks, err := k.listTasks(ctx, taskTypePreload, data.StartTime, data.EndTime, data.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	total, list := getTaskIds(tasks, data.TaskStatus, data.Page, data.Limit)
+	return &types.ShowPushTaskListResponse{Total: total, List: list}, nil
+}
+
+// listTasks 分页获取时间范围内的全部任务明细
+func (k *KsYun) listTasks(ctx context.Context, taskType string, startTime int64, endTime int64, timezone string) ([]*model.RefreshOrPreloadTask, error) {
+	tasks := make([]*model.RefreshOrPreloadTask, 0)
+	for page := int64(1); ; page++ {
+		res, err := invoke(ctx, k, ratelimit.ClassQuery, retry.Idempotent, func() (*model.GetRefreshOrPreloadTaskResponse, error) {
+			return k.client.GetRefreshOrPreloadTask(ctx, &model.GetRefreshOrPreloadTaskRequest{
+				StartTime:  formatTime(startTime, timezone),
+				EndTime:    formatTime(endTime, timezone),
+				Type:       taskType,
+				PageNumber: page,
+				PageSize:   listPageSize,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, res.Datas...)
+		if len(res.Datas) == 0 || int64(len(tasks)) >= res.TotalCount {
+			return tasks, nil
+		}
+	}
+}
+
+// getTaskIds 任务明细按url维度返回,金山云不支持按状态筛选,按任务汇总状态后在本地筛选与分页
+func getTaskIds(tasks []*model.RefreshOrPreloadTask, taskStatus int64, page int64, limit int64) (int64, []string) {
+	ids := make([]string, 0)
+	details := make(map[string][]*model.RefreshOrPreloadTask)
+	for _, v := range tasks {
+		if _, ok := details[v.TaskId]; !ok {
+			ids = append(ids, v.TaskId)
+		}
+		details[v.TaskId] = append(details[v.TaskId], v)
+	}
+	list := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if mergeTaskStatus(details[id]) == taskStatus {
+			list = append(list, id)
+		}
+	}
+	total := int64(len(list))
+	offset, limit := utils.CalcOffsetAndLimit(page, limit)
+	if offset >= total {
+		return total, make([]string, 0)
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return total, list[offset:end]
+}
+
+// DomainAccessDataStatic 获取域名访问数据统计
+func (k *KsYun) DomainAccessDataStatic(data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return k.DomainAccessDataStaticWithContext(k.ctx, data)
+}
+
+// DomainAccessDataStaticWithContext 获取域名访问数据统计
+func (k *KsYun) DomainAccessDataStaticWithContext(ctx context.Context, data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.District != nil || data.Isp != nil {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, "district and isp not support")
+	}
+	api := getDataAccessMetricApi(data.Metric)
+	if api == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	res, names, err := k.getStatisticsData(ctx, api, data.Domains, &model.StatisticsRequest{
+		StartTime:   formatTime(data.StartTime, *data.TimeZone),
+		EndTime:     formatTime(data.EndTime, *data.TimeZone),
+		CdnType:     getCdnType(data.ChannelType),
+		Regions:     getStatisticsRegions(data.Area),
+		Granularity: getGranularity(data.Interval),
+		DataType:    dataTypeEdge,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Datas) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return getStaticData(api, res.Datas, names), nil
+}
+
+// DomainOriginDataStatic 获取域名回源数据统计
+func (k *KsYun) DomainOriginDataStatic(data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return k.DomainOriginDataStaticWithContext(k.ctx, data)
+}
+
+// DomainOriginDataStaticWithContext 获取域名回源数据统计
+func (k *KsYun) DomainOriginDataStaticWithContext(ctx context.Context, data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	api := getDataOriginMetricApi(data.Metric)
+	if api == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	res, names, err := k.getStatisticsData(ctx, api, data.Domains, &model.StatisticsRequest{
+		StartTime:   formatTime(data.StartTime, *data.TimeZone),
+		EndTime:     formatTime(data.EndTime, *data.TimeZone),
+		CdnType:     getCdnType(data.ChannelType),
+		Regions:     getStatisticsRegions(data.Area),
+		Granularity: getGranularity(data.Interval),
+		DataType:    dataTypeOrigin,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types.DomainOriginDataStaticResponse(getStaticData(api, res.Datas, names)), nil
+}
+
+// getStatisticsData 按域名维度查询统计数据,返回域名ID与域名的对应关系
+func (k *KsYun) getStatisticsData(ctx context.Context, api string, domains []string, req *model.StatisticsRequest) (*model.StatisticsResponse, map[string]string, error) {
+	names, err := k.getDomainNames(ctx, domains)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.DomainIds = getDomainIds(names)
+	req.ResultType = 1
+	res, err := invoke(ctx, k, ratelimit.ClassStats, retry.Idempotent, func() (*model.StatisticsResponse, error) {
+		return k.client.GetStatisticsData(ctx, api, req)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, names, nil
+}
+
+func getStaticData(api string, datas []*model.StatisticsData, names map[string]string) types.DomainAccessDataStaticResponse {
+	responseData := make(types.DomainAccessDataStaticResponse)
+	for _, v := range datas {
+		t := parseTime(v.DataTime)
+		for _, d := range v.Domains {
+			name, ok := names[d.DomainId]
+			if !ok {
+				continue
+			}
+			responseData[name] = append(responseData[name], &types.StaticData{
+				Value: getStatisticsValue(api, d),
+				Time:  t,
+			})
+		}
+	}
+	return responseData
+}
+
+// getTotalData 汇总各时间点数据,带宽取峰值,其余指标求和
+func getTotalData(api string, datas []*model.StatisticsData, names map[string]string) types.DataTotalDataResponse {
+	responseData := make(types.DataTotalDataResponse)
+	for _, v := range datas {
+		for _, d := range v.Domains {
+			name, ok := names[d.DomainId]
+			if !ok {
+				continue
+			}
+			value := int64(getStatisticsValue(api, d))
+			if api == "GetBandwidthData" {
+				if value > responseData[name] {
+					responseData[name] = value
+				}
+				continue
+			}
+			responseData[name] += value
+		}
+	}
+	return responseData
+}
+
+// ListTopUrlDataStatic 获取TOP URL数据统计
+func (k *KsYun) ListTopUrlDataStatic(data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return k.ListTopUrlDataStaticWithContext(k.ctx, data)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL数据统计,金山云TOP URL接口不区分区域
+func (k *KsYun) ListTopUrlDataStaticWithContext(ctx context.Context, data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	domainId, err := k.getDomainId(ctx, data.Domain, "")
+	if err != nil {
+		return nil, err
+	}
+	res, err := invoke(ctx, k, ratelimit.ClassStats, retry.Idempotent, func() (*model.TopUrlResponse, error) {
+		return k.client.GetTopUrlData(ctx, &model.TopUrlRequest{
+			StartTime: formatTime(data.StartTime, "Asia/Shanghai"),
+			EndTime:   formatTime(data.EndTime, "Asia/Shanghai"),
+			CdnType:   getCdnType(data.ChannelType),
+			DomainIds: domainId,
+			LimitN:    100,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := make([]*types.ListTopUrlDataStaticResponse, 0, len(res.Datas))
+	for _, v := range res.Datas {
+		responseData = append(responseData, &types.ListTopUrlDataStaticResponse{
+			Url:   v.Url,
+			Value: getTopUrlValue(data.Filter, v),
+		})
+	}
+	sort.SliceStable(responseData, func(i, j int) bool {
+		return responseData[i].Value > responseData[j].Value
+	})
+	return responseData, nil
+}
+
+// DomainAccessTotalData 获取域名总流量
+func (k *KsYun) DomainAccessTotalData(data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return k.DomainAccessTotalDataWithContext(k.ctx, data)
+}
+
+// DomainAccessTotalDataWithContext 获取域名总流量
+func (k *KsYun) DomainAccessTotalDataWithContext(ctx context.Context, data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	api := getDataAccessMetricApi(data.Metric)
+	if api == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	res, names, err := k.getStatisticsData(ctx, api, data.Domains, &model.StatisticsRequest{
+		StartTime:   formatTime(data.StartTime, *data.TimeZone),
+		EndTime:     formatTime(data.EndTime, *data.TimeZone),
+		CdnType:     getCdnType(data.ChannelType),
+		Regions:     getStatisticsRegions(data.Area),
+		Granularity: getGranularity(consts.DataIntervalTypeDay),
+		DataType:    dataTypeEdge,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Datas) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return getTotalData(api, res.Datas, names), nil
+}
+
+// DomainOriginTotalData 获取域名回源总流量
+func (k *KsYun) DomainOriginTotalData(data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return k.DomainOriginTotalDataWithContext(k.ctx, data)
+}
+
+// DomainOriginTotalDataWithContext 获取域名回源总流量
+func (k *KsYun) DomainOriginTotalDataWithContext(ctx context.Context, data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	api := getDataOriginMetricApi(data.Metric)
+	if api == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	res, names, err := k.getStatisticsData(ctx, api, data.Domains, &model.StatisticsRequest{
+		StartTime:   formatTime(data.StartTime, *data.TimeZone),
+		EndTime:     formatTime(data.EndTime, *data.TimeZone),
+		CdnType:     getCdnType(data.ChannelType),
+		Regions:     getStatisticsRegions(data.Area),
+		Granularity: getGranularity(consts.DataIntervalTypeDay),
+		DataType:    dataTypeOrigin,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getTotalData(api, res.Datas, names), nil
+}
+
+// UserAccessRegionDistribution 获取用户访问区域分布
+func (k *KsYun) UserAccessRegionDistribution(data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return k.UserAccessRegionDistributionWithContext(k.ctx, data)
+}
+
+// UserAccessRegionDistributionWithContext 获取用户访问区域分布,分别查询境内与境外的汇总数据
+func (k *KsYun) UserAccessRegionDistributionWithContext(ctx context.Context, data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	responseData := make(types.UserAccessRegionDistributionResponse)
+	if data == nil {
+		return responseData, cdnerr.ErrNilRequest
+	}
+	api := getDataAccessMetricApi(data.Metric)
+	if api != "GetFlowData" && api != "GetPvData" {
+		return responseData, cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	for _, area := range []int64{consts.AreaCodeChinaMainland, consts.AreaCodeOversea} {
+		res, names, err := k.getStatisticsData(ctx, api, data.Domains, &model.StatisticsRequest{
+			StartTime:   formatTime(data.StartTime, "Asia/Shanghai"),
+			EndTime:     formatTime(data.EndTime, "Asia/Shanghai"),
+			CdnType:     getCdnType(data.ChannelType),
+			Regions:     getStatisticsRegions(area),
+			Granularity: getGranularity(consts.DataIntervalTypeDay),
+			DataType:    dataTypeEdge,
+		})
+		if err != nil {
+			return responseData, err
+		}
+		for domain, value := range getTotalData(api, res.Datas, names) {
+			if responseData[domain] == nil {
+				responseData[domain] = &types.RegionDistribution{}
+			}
+			if area == consts.AreaCodeChinaMainland {
+				responseData[domain].MainLandValue += value
+				continue
+			}
+			responseData[domain].OverSeaValue += value
+		}
+	}
+	return responseData, nil
+}
+
+//设置更新的各项信息
+
+// UpdateDomainConfigModel 金山云的各项配置分属不同接口,先收集各接口的请求,查询到域名ID后再依次提交
+type UpdateDomainConfigModel struct {
+	k               *KsYun
+	req             *types.UpdateDomainRequest
+	err             error
+	basicInfo       *model.ModifyCdnDomainBasicInfoRequest
+	backOriginHost  *model.SetBackOriginHostConfigRequest
+	originAdvanced  *model.SetOriginAdvancedConfigRequest
+	cacheRule       *model.SetCacheRuleConfigRequest
+	referProtection *model.SetReferProtectionConfigRequest
+	ipProtection    *model.SetIpProtectionConfigRequest
+	requestAuth     *model.SetRequestAuthConfigRequest
+	errorPage       *model.SetErrorPageConfigRequest
+	setHeaders      []*model.SetHttpHeadersConfigRequest
+	deleteHeaders   []*model.DeleteHttpHeadersConfigRequest
+	certificate     *model.ConfigCertificateRequest
+	forceRedirect   *model.SetForceRedirectConfigRequest
+	http2           *model.SetHttp2OptionConfigRequest
+}
+
+func (k *KsYun) newUpdateDomainConfigModel(req *types.UpdateDomainRequest) *UpdateDomainConfigModel {
+	return &UpdateDomainConfigModel{
+		k:   k,
+		req: req,
+	}
+}
+
+func (u *UpdateDomainConfigModel) getBasicInfo() *model.ModifyCdnDomainBasicInfoRequest {
+	if u.basicInfo == nil {
+		u.basicInfo = &model.ModifyCdnDomainBasicInfoRequest{}
+	}
+	return u.basicInfo
+}
+
+func (u *UpdateDomainConfigModel) WithArea() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	u.getBasicInfo().Regions = getRegions(u.req.CdnDomain.AreaCode)
+}
+
+func (u *UpdateDomainConfigModel) WithOriginConf() {
+	if u.req.OriginConf == nil {
+		return
+	}
+	u.getBasicInfo().OriginProtocol = getOriginProtocol(u.req.OriginConf.OriginProtocol)
+}
+
+func (u *UpdateDomainConfigModel) WithOriginServerConf() {
+	primary := getOrigins(u.req.OriginServerConf, consts.OriginPriorityPrimary)
+	if len(primary) == 0 {
+		return
+	}
+	protocol := int64(consts.OriginProtocolHttp)
+	if u.req.OriginConf != nil {
+		protocol = u.req.OriginConf.OriginProtocol
+	}
+	basicInfo := u.getBasicInfo()
+	basicInfo.OriginType = getOriginType(primary[0].OriginType)
+	basicInfo.Origin = joinOrigins(primary)
+	basicInfo.OriginPort = getOriginPort(protocol, primary[0])
+	u.withOriginExtraConf()
+}
+
+// withOriginExtraConf 设置回源host与备源
+func (u *UpdateDomainConfigModel) withOriginExtraConf() {
+	primary := getOrigins(u.req.OriginServerConf, consts.OriginPriorityPrimary)
+	if len(primary) == 0 {
+		return
+	}
+	if primary[0].OriginHost != "" {
+		u.backOriginHost = &model.SetBackOriginHostConfigRequest{BackOriginHost: primary[0].OriginHost}
+	}
+	backup := getOrigins(u.req.OriginServerConf, consts.OriginPriorityBackup)
+	u.originAdvanced = &model.SetOriginAdvancedConfigRequest{Enable: consts.OFF}
+	if len(backup) > 0 {
+		u.originAdvanced.Enable = consts.ON
+		u.originAdvanced.OriginPolicy = "rr"
+		u.originAdvanced.BackupOriginType = getOriginType(backup[0].OriginType)
+		u.originAdvanced.BackupOrigin = joinOrigins(backup)
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithIpFilterConf() {
+	if u.req.IpFilterConf == nil {
+		return
+	}
+	u.ipProtection = &model.SetIpProtectionConfigRequest{Enable: getSwitch(u.req.IpFilterConf.Status)}
+	if u.req.IpFilterConf.Status != types.ON {
+		return
+	}
+	//金山云只支持一组全局生效的黑名单或白名单,以第一条有效规则的类型为准
+	ips := make([]string, 0)
+	ipType := int64(-1)
+	for _, v := range u.req.IpFilterConf.IpFilterConf {
+		if len(v.IpList) == 0 {
+			continue
+		}
+		if ipType == -1 {
+			ipType = v.IpType
+		}
+		if v.IpType == ipType {
+			ips = append(ips, v.IpList...)
+		}
+	}
+	if len(ips) == 0 {
+		u.ipProtection.Enable = consts.OFF
+		return
+	}
+	u.ipProtection.IpType = getListType(ipType)
+	u.ipProtection.IpList = strings.Join(ips, ",")
+}
+
+func (u *UpdateDomainConfigModel) WithRefererConf() {
+	if u.req.RefererConf == nil {
+		return
+	}
+	u.referProtection = &model.SetReferProtectionConfigRequest{Enable: getSwitch(u.req.RefererConf.Status)}
+	if u.req.RefererConf.Status != types.ON {
+		return
+	}
+	u.referProtection.ReferType = getListType(u.req.RefererConf.RefererType)
+	u.referProtection.ReferList = strings.Join(u.req.RefererConf.RefererList, ",")
+	u.referProtection.AllowEmpty = getSwitch(u.req.RefererConf.IncludeEmpty)
+}
+
+func (u *UpdateDomainConfigModel) WithAuthConf() {
+	if u.req.AuthConf == nil {
+		return
+	}
+	u.requestAuth = &model.SetRequestAuthConfigRequest{Enable: getSwitch(u.req.AuthConf.Status)}
+	if u.req.AuthConf.Status != types.ON {
+		return
+	}
+	authType := getAuthType(u.req.AuthConf.AuthManner)
+	if authType == "" {
+		u.err = cdnerr.New(cdnerr.ErrUnsupported, types.KsYunSdkName, fmt.Sprintf("auth manner %d not support", u.req.AuthConf.AuthManner))
+		return
+	}
+	u.requestAuth.AuthType = authType
+	u.requestAuth.Key1 = u.req.AuthConf.AuthKey
+	u.requestAuth.Key2 = u.req.AuthConf.AuthKeyBackup
+	u.requestAuth.ExpirationTime = u.req.AuthConf.TimeValue
+	if authType == "typeA" {
+		u.requestAuth.SignParam = u.req.AuthConf.AuthParameter
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithCacheListConf() {
+	rules := make([]*model.CacheRule, 0, len(u.req.CacheListConf))
+	for _, v := range u.req.CacheListConf {
+		ruleType, value := getCacheRule(v.CacheType, v.CacheContent)
+		rule := &model.CacheRule{
+			CacheRuleType: ruleType,
+			Value:         value,
+			CacheTime:     cachettl.Seconds(v.CacheTTL, v.CacheUnit),
+			RespectOrigin: consts.OFF,
+		}
+		switch v.CacheStatus {
+		case consts.CacheStatusFollow:
+			rule.RespectOrigin = consts.ON
+		case consts.CacheStatusOff:
+			rule.CacheTime = 0
+		}
+		rules = append(rules, rule)
+	}
+	u.cacheRule = &model.SetCacheRuleConfigRequest{CacheRules: rules}
+}
+
+func (u *UpdateDomainConfigModel) WithCustomErrorPageConf() {
+	pages := make([]*model.ErrorPage, 0, len(u.req.CustomErrorPageConf))
+	for _, v := range u.req.CustomErrorPageConf {
+		pages = append(pages, &model.ErrorPage{ErrorHttpCode: v.StatusCode, CustomPageUrl: v.GoalAddress})
+	}
+	u.errorPage = &model.SetErrorPageConfigRequest{ErrorPages: pages}
+}
+
+func (u *UpdateDomainConfigModel) WithResponseHeaderConf() {
+	for _, v := range u.req.ResponseHeaderConf {
+		if v.Action == consts.OriginHeaderActionDelete {
+			u.deleteHeaders = append(u.deleteHeaders, &model.DeleteHttpHeadersConfigRequest{HeaderKey: v.ParameterKey})
+			continue
+		}
+		u.setHeaders = append(u.setHeaders, &model.SetHttpHeadersConfigRequest{HeaderKey: v.ParameterKey, HeaderValue: v.ParameterValue})
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithHttpsConf() {
+	if u.req.HttpsConf == nil {
+		return
+	}
+	u.certificate = &model.ConfigCertificateRequest{Enable: getSwitch(u.req.HttpsConf.HttpsStatus)}
+	if u.req.HttpsConf.HttpsStatus != types.ON {
+		return
+	}
+	u.certificate.CertificateName = u.req.HttpsConf.CertName
+	if u.certificate.CertificateName == "" {
+		u.certificate.CertificateName = u.req.Domain
+	}
+	u.certificate.ServerCertificate = u.req.HttpsConf.CertValue
+	u.certificate.PrivateKey = u.req.HttpsConf.CertKey
+	u.forceRedirect = &model.SetForceRedirectConfigRequest{
+		RedirectType: getRedirectType(u.req.HttpsConf.JumpForceStatus, u.req.HttpsConf.JumpType),
+	}
+	u.http2 = &model.SetHttp2OptionConfigRequest{Enable: getSwitch(u.req.HttpsConf.HttpTwo)}
+}
+
+// send 依次提交收集到的配置,证书需先于强制跳转与http2配置提交
+func (u *UpdateDomainConfigModel) send(ctx context.Context) error {
+	if u.err != nil {
+		return u.err
+	}
+	domainId, err := u.k.getDomainId(ctx, u.req.Domain, u.req.DomainId)
+	if err != nil {
+		return err
+	}
+	client := u.k.client
+	calls := make([]func() (*model.CommonResponse, error), 0)
+	if u.basicInfo != nil {
+		u.basicInfo.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.ModifyCdnDomainBasicInfo(ctx, u.basicInfo)
+		})
+	}
+	if u.backOriginHost != nil {
+		u.backOriginHost.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetBackOriginHostConfig(ctx, u.backOriginHost)
+		})
+	}
+	if u.originAdvanced != nil {
+		u.originAdvanced.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetOriginAdvancedConfig(ctx, u.originAdvanced)
+		})
+	}
+	if u.cacheRule != nil {
+		u.cacheRule.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetCacheRuleConfig(ctx, u.cacheRule)
+		})
+	}
+	if u.referProtection != nil {
+		u.referProtection.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetReferProtectionConfig(ctx, u.referProtection)
+		})
+	}
+	if u.ipProtection != nil {
+		u.ipProtection.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetIpProtectionConfig(ctx, u.ipProtection)
+		})
+	}
+	if u.requestAuth != nil {
+		u.requestAuth.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetRequestAuthConfig(ctx, u.requestAuth)
+		})
+	}
+	if u.errorPage != nil {
+		u.errorPage.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetErrorPageConfig(ctx, u.errorPage)
+		})
+	}
+	for _, v := range u.deleteHeaders {
+		v := v
+		v.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.DeleteHttpHeadersConfig(ctx, v)
+		})
+	}
+	for _, v := range u.setHeaders {
+		v := v
+		v.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetHttpHeadersConfig(ctx, v)
+		})
+	}
+	if u.certificate != nil {
+		u.certificate.DomainIds = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.ConfigCertificate(ctx, u.certificate)
+		})
+	}
+	if u.forceRedirect != nil {
+		u.forceRedirect.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetForceRedirectConfig(ctx, u.forceRedirect)
+		})
+	}
+	if u.http2 != nil {
+		u.http2.DomainId = domainId
+		calls = append(calls, func() (*model.CommonResponse, error) {
+			return client.SetHttp2OptionConfig(ctx, u.http2)
+		})
+	}
+	for _, call := range calls {
+		if _, err := invoke(ctx, u.k, ratelimit.ClassConfig, retry.Idempotent, call); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package ksyun
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDomainLifecycle(t *testing.T) {
+	s, k := newStandIn(t)
+	err := k.CreateDomain(&types.CreateDomainRequest{
+		Domain:         "www.example.com",
+		AreaCode:       consts.AreaCodeGlobal,
+		ChannelType:    consts.ChannelTypeDownload,
+		OriginProtocol: consts.OriginProtocolHttps,
+		Sources: []*entity.OriginServerConf{
+			{OriginAddressList: "1.1.1.1", OriginHttpsPort: 8443, OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityPrimary},
+			{OriginAddressList: "backup.example.com", OriginType: consts.OriginTypeDomain, OriginPriority: consts.OriginPriorityBackup},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := s.domain("www.example.com")
+	if d.basic.CdnType != "download" || d.basic.Regions != "CN,AS,EU,NA" || d.basic.Origin != "1.1.1.1" || d.basic.OriginPort != 8443 || d.basic.OriginProtocol != "https" {
+		t.Fatalf("unexpected created domain %+v", d.basic)
+	}
+	if d.host != "origin.example.com" || d.advanced == nil || d.advanced.Enable != "on" || d.advanced.BackupOriginType != "domain" || d.advanced.BackupOrigin != "backup.example.com" {
+		t.Fatalf("origin host or backup origin not set: %q %+v", d.host, d.advanced)
+	}
+	if err := k.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrDomainAlreadyExists) {
+		t.Fatalf("got %v, want domain already exists", err)
+	}
+
+	detail, err := k.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.DomainId != d.basic.DomainId || detail.AreaCode != consts.AreaCodeGlobal || detail.ChannelType != consts.ChannelTypeDownload || detail.Status != consts.CdnDomainStatusDeploying {
+		t.Fatalf("unexpected detail %+v", detail)
+	}
+	if detail.CreateTime != time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix() {
+		t.Fatalf("unexpected create time %d", detail.CreateTime)
+	}
+
+	if err := k.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := k.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusStoped, Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 1 || !reflect.DeepEqual(list.List, []string{"www.example.com"}) {
+		t.Fatalf("unexpected stopped domains %+v", list)
+	}
+	if err := k.EnableDomain(&types.EnableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if status := s.domain("www.example.com").basic.DomainStatus; status != "online" {
+		t.Fatalf("got status %s after enable", status)
+	}
+
+	if err := k.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if s.domain("www.example.com") != nil {
+		t.Fatal("domain not deleted")
+	}
+	//域名不存在时删除视为成功
+	if err := k.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+}
+
+func TestUpdateDomainConfig(t *testing.T) {
+	s, k := newStandIn(t)
+	s.addDomain("www.example.com")
+	err := k.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateFullConf,
+		Domain:       "www.example.com",
+		CdnDomain:    &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeOversea},
+		IpFilterConf: &types.IpFilterConf{Status: types.ON, IpFilterConf: []*entity.IpFilter{
+			{IpType: consts.WhiteList, IpList: []string{"10.0.0.1"}},
+			{IpType: consts.BlackList, IpList: []string{"10.0.0.2"}},
+			{IpType: consts.WhiteList, IpList: []string{"10.0.0.3"}},
+		}},
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{".jpg", "png"}, CacheTTL: 2, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOn},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"api"}, CacheTTL: 10, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOff},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"static/"}, CacheStatus: consts.CacheStatusFollow},
+		},
+		ResponseHeaderConf: []*entity.ResponseHeaderConf{
+			{ParameterKey: "X-Frame-Options", ParameterValue: "DENY", Action: consts.OriginHeaderActionSet},
+			{ParameterKey: "X-Powered-By", Action: consts.OriginHeaderActionDelete},
+		},
+		HttpsConf: &entity.HttpsConf{
+			HttpsStatus:     types.ON,
+			HttpTwo:         types.ON,
+			JumpForceStatus: types.ON,
+			JumpType:        consts.HttpsJumpTypeHttps,
+			CertValue:       "cert",
+			CertKey:         "key",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := s.domain("www.example.com")
+	if d.basic.Regions != "AS,EU,NA" {
+		t.Fatalf("got regions %s", d.basic.Regions)
+	}
+	//金山云只支持一组名单,以第一条有效规则的类型为准
+	if d.ip.Enable != "on" || d.ip.IpType != "allow" || d.ip.IpList != "10.0.0.1,10.0.0.3" {
+		t.Fatalf("unexpected ip protection %+v", d.ip)
+	}
+	rules := make([][4]interface{}, 0, len(d.cache.CacheRules))
+	for _, v := range d.cache.CacheRules {
+		rules = append(rules, [4]interface{}{v.CacheRuleType, v.Value, v.CacheTime, v.RespectOrigin})
+	}
+	want := [][4]interface{}{
+		{"file_suffix", "jpg,png", int64(7200), "off"},
+		{"directory", "/api/", int64(0), "off"},
+		{"directory", "/static/", int64(0), "on"},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("got cache rules %v, want %v", rules, want)
+	}
+	if !reflect.DeepEqual(d.headers, map[string]string{"X-Frame-Options": "DENY"}) {
+		t.Fatalf("unexpected headers %v", d.headers)
+	}
+	if d.cert.Enable != "on" || d.cert.CertificateName != "www.example.com" || d.cert.ServerCertificate != "cert" || d.redirect != "https" || d.http2 != "on" {
+		t.Fatalf("unexpected https config %+v %s %s", d.cert, d.redirect, d.http2)
+	}
+}
+
+func TestUpdateDomainNotFound(t *testing.T) {
+	s, k := newStandIn(t)
+	err := k.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateArea,
+		Domain:       "www.example.com",
+		CdnDomain:    &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeOversea},
+	})
+	if !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+	if calls := s.callList(); len(calls) != 1 || calls[0] != "domain/GetCdnDomains" {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestPurgeAndTaskStatus(t *testing.T) {
+	_, k := newStandIn(t)
+	purge, err := k.PurgePathCache(&types.PurgePathCacheRequest{Paths: []string{"https://www.example.com/static/"}, Mode: consts.ContentPurgePathModeAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := k.ShowPurgeTaskStatus(&types.ShowPurgeTaskStatusRequest{TaskId: purge.TaskId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != consts.ShowContentPurgeOrPushStatusSuccess {
+		t.Fatalf("got purge status %d", status.Status)
+	}
+	push, err := k.PushUrlsCache(&types.PushUrlsCacheRequest{Urls: []string{"https://www.example.com/a.jpg"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.ShowPushTaskStatus(&types.ShowPushTaskStatusRequest{TaskId: push.TaskId}); err != nil {
+		t.Fatal(err)
+	}
+	//刷新任务ID不能查询预热任务
+	if _, err := k.ShowPushTaskStatus(&types.ShowPushTaskStatusRequest{TaskId: purge.TaskId}); !errors.Is(err, cdnerr.ErrNotFound) {
+		t.Fatalf("got %v, want not found", err)
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	s, k := newStandIn(t)
+	s.addDomain("a.example.com")
+	s.addDomain("b.example.com")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := start + 3600
+	static, err := k.DomainAccessDataStatic(&types.DomainAccessDataStaticRequest{
+		Domains:   []string{"a.example.com"},
+		StartTime: start,
+		EndTime:   end,
+		Metric:    consts.DataAccessMetricTypeFlux,
+		Interval:  consts.DataIntervalTypeHour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := static["a.example.com"]
+	if len(static) != 1 || len(points) != 2 || points[0].Time != start || points[0].Value != 100 || points[1].Time != end || points[1].Value != 200 {
+		t.Fatalf("unexpected static data %v", static)
+	}
+	flow, err := k.DomainAccessTotalData(&types.DomainAccessTotalDataRequest{StartTime: start, EndTime: end, Metric: consts.DataAccessMetricTypeFlux})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(flow, types.DataTotalDataResponse{"a.example.com": 300, "b.example.com": 300}) {
+		t.Fatalf("unexpected total flow %v", flow)
+	}
+	//带宽取峰值
+	bandwidth, err := k.DomainAccessTotalData(&types.DomainAccessTotalDataRequest{Domains: []string{"b.example.com"}, StartTime: start, EndTime: end, Metric: consts.DataAccessMetricTypeBandwidth})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bandwidth, types.DataTotalDataResponse{"b.example.com": 30}) {
+		t.Fatalf("unexpected peak bandwidth %v", bandwidth)
+	}
+	if _, err := k.DomainAccessTotalData(&types.DomainAccessTotalDataRequest{Domains: []string{"c.example.com"}, StartTime: start, EndTime: end}); !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	s, _ := newStandIn(t)
+	k, err := New(context.Background(), &Config{Ak: "ak", Sk: "wrong", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = k.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusDeployed, Page: 1, Limit: 10})
+	var e *cdnerr.Error
+	if !errors.Is(err, cdnerr.ErrAuthFailed) || !errors.As(err, &e) || e.RequestId != "req-1" {
+		t.Fatalf("got %v, want auth failed with request id", err)
+	}
+}
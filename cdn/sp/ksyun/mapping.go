@@ -0,0 +1,343 @@
+package ksyun
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun/common/model"
+	"strings"
+	"time"
+)
+
+const (
+	taskTypeRefresh = "refresh"
+	taskTypePreload = "preload"
+	dataTypeEdge    = "edge"
+	dataTypeOrigin  = "origin"
+	regionMainland  = "CN"
+	regionOversea   = "AS,EU,NA"
+)
+
+// GetCdnType 业务类型转产品类型
+func getCdnType(channelType int64) string {
+	switch channelType {
+	case consts.ChannelTypeWeb:
+		return "file"
+	case consts.ChannelTypeDownload:
+		return "download"
+	case consts.ChannelTypeMedia:
+		return "video"
+	default:
+		return "file"
+	}
+}
+
+// MapCdnType 产品类型转业务类型
+func mapCdnType(cdnType string) int64 {
+	switch cdnType {
+	case "file":
+		return consts.ChannelTypeWeb
+	case "download":
+		return consts.ChannelTypeDownload
+	case "video":
+		return consts.ChannelTypeMedia
+	default:
+		return consts.ChannelTypeWeb
+	}
+}
+
+// GetRegions 区域代码转加速区域
+func getRegions(area int64) string {
+	switch area {
+	case consts.AreaCodeChinaMainland:
+		return regionMainland
+	case consts.AreaCodeOversea:
+		return regionOversea
+	case consts.AreaCodeGlobal:
+		return regionMainland + "," + regionOversea
+	default:
+		return regionMainland
+	}
+}
+
+// MapRegions 加速区域转区域代码
+func mapRegions(regions string) int64 {
+	if regions == "" {
+		return consts.AreaCodeChinaMainland
+	}
+	items := strings.Split(regions, ",")
+	hasMainland := false
+	for _, v := range items {
+		if strings.TrimSpace(v) == regionMainland {
+			hasMainland = true
+		}
+	}
+	switch {
+	case hasMainland && len(items) == 1:
+		return consts.AreaCodeChinaMainland
+	case hasMainland:
+		return consts.AreaCodeGlobal
+	default:
+		return consts.AreaCodeOversea
+	}
+}
+
+// GetStatisticsRegions 获取统计区域,为空时统计全部区域
+func getStatisticsRegions(area int64) string {
+	switch area {
+	case consts.AreaCodeChinaMainland:
+		return regionMainland
+	case consts.AreaCodeOversea:
+		return regionOversea
+	default:
+		return ""
+	}
+}
+
+// GetDomainStatus 获取域名状态
+func getDomainStatus(status string) int64 {
+	switch status {
+	case "online":
+		return consts.CdnDomainStatusDeployed
+	case "offline":
+		return consts.CdnDomainStatusStoped
+	case "configuring", "icp_checking":
+		return consts.CdnDomainStatusDeploying
+	case "configure_failed", "icp_check_failed":
+		return consts.CdnDomainStatusFaild
+	default:
+		return consts.CdnDomainStatusDeploying
+	}
+}
+
+// SetDomainStatus 域名状态转金山云域名状态
+func setDomainStatus(status int64) string {
+	switch status {
+	case consts.CdnDomainStatusDeployed:
+		return "online"
+	case consts.CdnDomainStatusStoped:
+		return "offline"
+	case consts.CdnDomainStatusDeploying:
+		return "configuring"
+	case consts.CdnDomainStatusFaild:
+		return "configure_failed"
+	default:
+		return ""
+	}
+}
+
+// GetOriginType 获取源站类型
+func getOriginType(originType int64) string {
+	switch originType {
+	case consts.OriginTypeIp:
+		return "ipaddr"
+	case consts.OriginTypeDomain:
+		return "domain"
+	case consts.OriginTypeBucket:
+		return "KS3"
+	default:
+		return "ipaddr"
+	}
+}
+
+// GetOriginProtocol 获取回源协议
+func getOriginProtocol(protocol int64) string {
+	switch protocol {
+	case consts.OriginProtocolHttp:
+		return "http"
+	case consts.OriginProtocolHttps:
+		return "https"
+	case consts.OriginProtocolFollow:
+		return "follow"
+	default:
+		return "http"
+	}
+}
+
+// GetOriginPort 按回源协议获取源站端口
+func getOriginPort(protocol int64, source *entity.OriginServerConf) int64 {
+	if protocol == consts.OriginProtocolHttps && source.OriginHttpsPort != 0 {
+		return source.OriginHttpsPort
+	}
+	if source.OriginHttpPort != 0 {
+		return source.OriginHttpPort
+	}
+	return source.OriginHttpsPort
+}
+
+// GetSwitch 数字开关转金山云开关
+func getSwitch(status int64) string {
+	if status == consts.SwitchOn {
+		return consts.ON
+	}
+	return consts.OFF
+}
+
+// GetListType 获取黑白名单类型
+func getListType(t int64) string {
+	switch t {
+	case consts.BlackList:
+		return "block"
+	case consts.WhiteList:
+		return "allow"
+	default:
+		return "block"
+	}
+}
+
+// GetCacheRule 规则类型转金山云缓存规则类型与规则内容
+func getCacheRule(t int64, content []string) (string, string) {
+	items := make([]string, 0, len(content))
+	switch t {
+	case consts.RuleTypeFileSuffix:
+		for _, v := range content {
+			items = append(items, strings.TrimPrefix(v, "."))
+		}
+		return "file_suffix", strings.Join(items, ",")
+	case consts.RuleTypeDirectory:
+		for _, v := range content {
+			items = append(items, "/"+strings.Trim(v, "/")+"/")
+		}
+		return "directory", strings.Join(items, ",")
+	case consts.RuleTypePath:
+		return "exact", strings.Join(content, ",")
+	case consts.RuleTypeIndex:
+		return "exact", "/"
+	default:
+		return "directory", "/"
+	}
+}
+
+// GetAuthType 获取鉴权类型,金山云不支持TypeD
+func getAuthType(manner int64) string {
+	switch manner {
+	case consts.AccessAuthMannerTypeA:
+		return "typeA"
+	case consts.AccessAuthMannerTypeB:
+		return "typeB"
+	case consts.AccessAuthMannerTypeC:
+		return "typeC"
+	default:
+		return ""
+	}
+}
+
+// GetRedirectType 获取强制跳转类型
+func getRedirectType(jumpForceStatus int64, jumpType int64) string {
+	if jumpForceStatus != consts.SwitchOn {
+		return consts.OFF
+	}
+	switch jumpType {
+	case consts.HttpsJumpTypeHttp:
+		return "http"
+	case consts.HttpsJumpTypeHttps:
+		return "https"
+	default:
+		return "https"
+	}
+}
+
+// GetShowContentPurgeOrPushStatus 获取刷新或预热任务状态
+func getShowContentPurgeOrPushStatus(status string) int64 {
+	switch status {
+	case "success":
+		return consts.ShowContentPurgeOrPushStatusSuccess
+	case "progressing":
+		return consts.ShowContentPurgeOrPushStatusDoing
+	case "failed":
+		return consts.ShowContentPurgeOrPushStatusFail
+	default:
+		return consts.ShowContentPurgeOrPushStatusFail
+	}
+}
+
+// GetUrlType 获取刷新类型
+func getUrlType(t int64) string {
+	switch t {
+	case consts.ShowContentPurgeTypeUrl:
+		return "file"
+	case consts.ShowContentPurgeTypePath:
+		return "dir"
+	default:
+		return "file"
+	}
+}
+
+// GetGranularity 获取统计粒度,单位分钟
+func getGranularity(t int64) int64 {
+	switch t {
+	case consts.DataIntervalTypeFiveMinute:
+		return 5
+	case consts.DataIntervalTypeHour:
+		return 60
+	case consts.DataIntervalTypeDay:
+		return 1440
+	default:
+		return 5
+	}
+}
+
+// GetDataAccessMetricApi 获取访问数据指标对应的统计接口,不支持的指标返回空
+func getDataAccessMetricApi(t int64) string {
+	switch t {
+	case consts.DataAccessMetricTypeFlux:
+		return "GetFlowData"
+	case consts.DataAccessMetricTypeBandwidth:
+		return "GetBandwidthData"
+	case consts.DataAccessMetricTypeRequest:
+		return "GetPvData"
+	default:
+		return ""
+	}
+}
+
+// GetDataOriginMetricApi 获取回源数据指标对应的统计接口,不支持的指标返回空
+func getDataOriginMetricApi(t int64) string {
+	switch t {
+	case consts.DataOriginMetricTypeFlux:
+		return "GetFlowData"
+	case consts.DataOriginMetricTypeBandwidth:
+		return "GetBandwidthData"
+	case consts.DataOriginMetricTypeRequest:
+		return "GetPvData"
+	default:
+		return ""
+	}
+}
+
+// GetStatisticsValue 按统计接口获取对应的指标值
+func getStatisticsValue(api string, data *model.DomainStatisticsData) float64 {
+	switch api {
+	case "GetBandwidthData":
+		return data.Bw
+	case "GetPvData":
+		return data.Pv
+	default:
+		return data.Flow
+	}
+}
+
+// GetTopUrlValue 按排序条件获取TOP URL的值
+func getTopUrlValue(filter int64, data *model.TopUrlData) float64 {
+	if filter == consts.ListTopFilterRequest {
+		return data.Pv
+	}
+	return data.Flow
+}
+
+// FormatTime 时间戳格式化为金山云时间
+func formatTime(timestamp int64, timezone string) string {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.Local
+	}
+	return time.Unix(timestamp, 0).In(location).Format(consts.KsYunTimeFormat)
+}
+
+// ParseTime 金山云时间转时间戳
+func parseTime(t string) int64 {
+	tm, err := time.Parse(consts.KsYunTimeFormat, t)
+	if err != nil {
+		return 0
+	}
+	return tm.Unix()
+}
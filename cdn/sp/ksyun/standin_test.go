@@ -0,0 +1,347 @@
+package ksyun
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/ksyun/common/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// standIn 金山云接口的本地替身,校验请求签名并在内存中保存域名配置
+type standIn struct {
+	*httptest.Server
+	signer  *common.Signer
+	mu      sync.Mutex
+	domains map[string]*domainState // 按域名ID保存
+	calls   []string
+	tasks   map[string][]*model.RefreshOrPreloadTask
+}
+
+// domainState 域名在金山云侧的各项配置
+type domainState struct {
+	basic    model.GetCdnDomainBasicInfoResponse
+	host     string
+	advanced *model.SetOriginAdvancedConfigRequest
+	cache    *model.SetCacheRuleConfigRequest
+	ip       *model.SetIpProtectionConfigRequest
+	headers  map[string]string
+	cert     *model.ConfigCertificateRequest
+	redirect string
+	http2    string
+}
+
+func newStandIn(t *testing.T) (*standIn, *KsYun) {
+	t.Helper()
+	s := &standIn{
+		signer:  common.NewSigner("ak", "sk", common.DefaultRegion),
+		domains: make(map[string]*domainState),
+		tasks:   make(map[string][]*model.RefreshOrPreloadTask),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	k, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, k
+}
+
+// addDomain 添加线上域名,返回域名ID
+func (s *standIn) addDomain(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.create(&model.AddCdnDomainRequest{DomainName: name, CdnType: "file", Regions: "CN", OriginType: "ipaddr", Origin: "1.1.1.1"}, "online")
+}
+
+func (s *standIn) create(req *model.AddCdnDomainRequest, status string) string {
+	id := "id-" + strconv.Itoa(len(s.domains)+1)
+	s.domains[id] = &domainState{
+		basic: model.GetCdnDomainBasicInfoResponse{
+			Domain: model.Domain{
+				DomainId:     id,
+				DomainName:   req.DomainName,
+				Cname:        req.DomainName + ".download.ks-cdn.com",
+				CdnType:      req.CdnType,
+				Regions:      req.Regions,
+				DomainStatus: status,
+				CreatedTime:  "2024-01-01T08:00+0800",
+				ModifiedTime: "2024-01-01T08:00+0800",
+			},
+			OriginType:     req.OriginType,
+			OriginProtocol: req.OriginProtocol,
+			Origin:         req.Origin,
+			OriginPort:     req.OriginPort,
+		},
+		headers: make(map[string]string),
+	}
+	return id
+}
+
+// domain 按域名获取配置
+func (s *standIn) domain(name string) *domainState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.domains {
+		if v.basic.DomainName == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *standIn) callList() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+// verify 按请求中的签名头部与时间重新计算签名
+func (s *standIn) verify(r *http.Request, body []byte) bool {
+	auth := r.Header.Get("Authorization")
+	i := strings.Index(auth, "SignedHeaders=")
+	if !strings.HasPrefix(auth, common.SignAlgorithm+" Credential=ak/") || i < 0 {
+		return false
+	}
+	t, err := time.Parse(common.DateFormat, r.Header.Get(common.HeaderDate))
+	if err != nil || time.Since(t) > 5*time.Minute || time.Until(t) > 5*time.Minute {
+		return false
+	}
+	req, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		return false
+	}
+	signed := strings.SplitN(auth[i+len("SignedHeaders="):], ",", 2)[0]
+	for _, name := range strings.Split(signed, ";") {
+		if name == "host" {
+			req.Header.Set("Host", r.Host)
+			continue
+		}
+		req.Header[http.CanonicalHeaderKey(name)] = r.Header.Values(name)
+	}
+	_ = s.signer.Sign(req, body, t)
+	return req.Header.Get("Authorization") == auth
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	api := strings.TrimPrefix(r.URL.Path, "/"+common.ApiVersion+"/")
+	s.calls = append(s.calls, api)
+	w.Header().Set(common.HeaderRequestId, "req-1")
+	if !s.verify(r, body) {
+		fail(w, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+	query := r.URL.Query()
+	switch api {
+	case "domain/AddCdnDomain":
+		req := &model.AddCdnDomainRequest{}
+		_ = json.Unmarshal(body, req)
+		for _, v := range s.domains {
+			if v.basic.DomainName == req.DomainName {
+				fail(w, http.StatusConflict, "DomainAlreadyExist")
+				return
+			}
+		}
+		reply(w, &model.AddCdnDomainResponse{RequestId: "req-1", DomainId: s.create(req, "configuring")})
+	case "domain/GetCdnDomains":
+		reply(w, s.listDomains(query))
+	case "domain/GetCdnDomainBasicInfo":
+		d, ok := s.domains[query.Get("DomainId")]
+		if !ok {
+			fail(w, http.StatusNotFound, "DomainNotFound")
+			return
+		}
+		res := d.basic
+		res.RequestId = "req-1"
+		reply(w, &res)
+	case "content/RefreshCaches":
+		req := &model.RefreshCachesRequest{}
+		_ = json.Unmarshal(body, req)
+		id := s.addTask(taskTypeRefresh, append(req.Files, req.Dirs...))
+		reply(w, &model.RefreshCachesResponse{RequestId: "req-1", RefreshTaskId: id})
+	case "content/PreloadCaches":
+		req := &model.PreloadCachesRequest{}
+		_ = json.Unmarshal(body, req)
+		id := s.addTask(taskTypePreload, req.Urls)
+		reply(w, &model.PreloadCachesResponse{RequestId: "req-1", PreloadTaskId: id})
+	case "content/GetRefreshOrPreloadTask":
+		req := &model.GetRefreshOrPreloadTaskRequest{}
+		_ = json.Unmarshal(body, req)
+		res := &model.GetRefreshOrPreloadTaskResponse{RequestId: "req-1", Datas: make([]*model.RefreshOrPreloadTask, 0)}
+		for _, v := range s.tasks[req.TaskId] {
+			if v.Type == req.Type {
+				res.Datas = append(res.Datas, v)
+			}
+		}
+		res.TotalCount = int64(len(res.Datas))
+		reply(w, res)
+	case "statistics/GetFlowData", "statistics/GetBandwidthData", "statistics/GetPvData":
+		reply(w, s.statistics(query))
+	default:
+		if strings.HasPrefix(api, "domain/") || strings.HasPrefix(api, "cert/") {
+			s.config(w, api, body)
+			return
+		}
+		fail(w, http.StatusNotFound, "InvalidAction")
+	}
+}
+
+// config 域名配置类接口,请求体均以DomainId指定域名
+func (s *standIn) config(w http.ResponseWriter, api string, body []byte) {
+	target := struct {
+		DomainId  string `json:"DomainId"`
+		DomainIds string `json:"DomainIds"`
+	}{}
+	_ = json.Unmarshal(body, &target)
+	id := target.DomainId
+	if api == "cert/ConfigCertificate" {
+		id = target.DomainIds
+	}
+	d, ok := s.domains[id]
+	if !ok {
+		fail(w, http.StatusNotFound, "DomainNotFound")
+		return
+	}
+	switch api {
+	case "domain/ModifyCdnDomainBasicInfo":
+		req := &model.ModifyCdnDomainBasicInfoRequest{}
+		_ = json.Unmarshal(body, req)
+		if req.Regions != "" {
+			d.basic.Regions = req.Regions
+		}
+		if req.Origin != "" {
+			d.basic.OriginType, d.basic.Origin, d.basic.OriginPort = req.OriginType, req.Origin, req.OriginPort
+		}
+		if req.OriginProtocol != "" {
+			d.basic.OriginProtocol = req.OriginProtocol
+		}
+	case "domain/StartStopCdnDomain":
+		req := &model.StartStopCdnDomainRequest{}
+		_ = json.Unmarshal(body, req)
+		d.basic.DomainStatus = map[string]string{"start": "online", "stop": "offline"}[req.ActionType]
+	case "domain/DeleteCdnDomain":
+		delete(s.domains, id)
+	case "domain/SetBackOriginHostConfig":
+		req := &model.SetBackOriginHostConfigRequest{}
+		_ = json.Unmarshal(body, req)
+		d.host = req.BackOriginHost
+	case "domain/SetOriginAdvancedConfig":
+		d.advanced = &model.SetOriginAdvancedConfigRequest{}
+		_ = json.Unmarshal(body, d.advanced)
+	case "domain/SetCacheRuleConfig":
+		d.cache = &model.SetCacheRuleConfigRequest{}
+		_ = json.Unmarshal(body, d.cache)
+	case "domain/SetIpProtectionConfig":
+		d.ip = &model.SetIpProtectionConfigRequest{}
+		_ = json.Unmarshal(body, d.ip)
+	case "domain/SetHttpHeadersConfig":
+		req := &model.SetHttpHeadersConfigRequest{}
+		_ = json.Unmarshal(body, req)
+		d.headers[req.HeaderKey] = req.HeaderValue
+	case "domain/DeleteHttpHeadersConfig":
+		req := &model.DeleteHttpHeadersConfigRequest{}
+		_ = json.Unmarshal(body, req)
+		delete(d.headers, req.HeaderKey)
+	case "cert/ConfigCertificate":
+		d.cert = &model.ConfigCertificateRequest{}
+		_ = json.Unmarshal(body, d.cert)
+	case "domain/SetForceRedirectConfig":
+		//强制跳转需先开启https
+		if d.cert == nil || d.cert.Enable != "on" {
+			fail(w, http.StatusBadRequest, "InvalidHttpsStatus")
+			return
+		}
+		req := &model.SetForceRedirectConfigRequest{}
+		_ = json.Unmarshal(body, req)
+		d.redirect = req.RedirectType
+	case "domain/SetHttp2OptionConfig":
+		req := &model.SetHttp2OptionConfigRequest{}
+		_ = json.Unmarshal(body, req)
+		d.http2 = req.Enable
+	}
+	reply(w, &model.CommonResponse{RequestId: "req-1"})
+}
+
+func (s *standIn) listDomains(query map[string][]string) *model.GetCdnDomainsResponse {
+	get := func(k string) string {
+		if v := query[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	all := make([]*model.Domain, 0, len(s.domains))
+	for _, v := range s.domains {
+		if name := get("DomainName"); name != "" && v.basic.DomainName != name {
+			continue
+		}
+		if status := get("DomainStatus"); status != "" && v.basic.DomainStatus != status {
+			continue
+		}
+		d := v.basic.Domain
+		all = append(all, &d)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].DomainName < all[j].DomainName })
+	page, _ := strconv.ParseInt(get("PageNumber"), 10, 64)
+	size, _ := strconv.ParseInt(get("PageSize"), 10, 64)
+	res := &model.GetCdnDomainsResponse{RequestId: "req-1", PageNumber: page, PageSize: size, TotalCount: int64(len(all))}
+	start, end := (page-1)*size, page*size
+	if start < int64(len(all)) {
+		if end > int64(len(all)) {
+			end = int64(len(all))
+		}
+		res.Domains = all[start:end]
+	}
+	return res
+}
+
+func (s *standIn) addTask(taskType string, urls []*model.UrlItem) string {
+	id := taskType + "-" + strconv.Itoa(len(s.tasks)+1)
+	for _, v := range urls {
+		s.tasks[id] = append(s.tasks[id], &model.RefreshOrPreloadTask{TaskId: id, Url: v.Url, Type: taskType, Status: "success"})
+	}
+	return id
+}
+
+// statistics 在开始与结束时间各返回一个数据点,每个域名的流量为100与200,带宽为10与30
+func (s *standIn) statistics(query map[string][]string) *model.StatisticsResponse {
+	res := &model.StatisticsResponse{RequestId: "req-1", ResultType: 1, Datas: make([]*model.StatisticsData, 0)}
+	ids := strings.Split(query["DomainIds"][0], ",")
+	for i, t := range []string{query["StartTime"][0], query["EndTime"][0]} {
+		data := &model.StatisticsData{DataTime: t}
+		for _, id := range ids {
+			data.Domains = append(data.Domains, &model.DomainStatisticsData{
+				DomainId: id,
+				Flow:     float64(100 * (i + 1)),
+				Bw:       float64(10 + 20*i),
+				Pv:       float64(i + 1),
+			})
+		}
+		res.Datas = append(res.Datas, data)
+	}
+	return res
+}
+
+func fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", common.ApplicationJson)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"RequestId": "req-1",
+		"Error":     map[string]string{"Code": code, "Message": code},
+	})
+}
+
+func reply(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", common.ApplicationJson)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,572 @@
+package tencent
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	tencentsdk "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cdn/v20180606"
+
+	"github.com/spf13/cast"
+)
+
+// ShowDomainConfig 获取域名完整配置
+func (t *Tencent) ShowDomainConfig(req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return t.ShowDomainConfigWithContext(t.ctx, req)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置,腾讯云不返回证书私钥,HttpsConf.CertKey始终为空
+func (t *Tencent) ShowDomainConfigWithContext(ctx context.Context, req *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	if req == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	request := tencentsdk.NewDescribeDomainsConfigRequest()
+	request.Offset = utils.Int64Ptr(0)
+	request.Limit = utils.Int64Ptr(1)
+	request.Filters = []*tencentsdk.DomainFilter{
+		{
+			Name:  utils.StringPtr("domain"),
+			Value: utils.StringPtrs([]string{req.Domain}),
+			Fuzzy: utils.BoolPtr(false),
+		},
+	}
+	res, err := invoke(ctx, t, ratelimit.ClassQuery, retry.Idempotent, func() (*tencentsdk.DescribeDomainsConfigResponse, error) {
+		return t.client.DescribeDomainsConfigWithContext(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Response.Domains) == 0 {
+		return nil, cdnerr.New(cdnerr.ErrDomainNotFound, types.TencentSdkName, "domain not found")
+	}
+	domain := res.Response.Domains[0]
+	c := &DomainConfigModel{domain: domain, req: &types.UpdateDomainRequest{
+		UpdateAction: types.UpdateFullConf,
+		Domain:       req.Domain,
+		DomainId:     utils.StringValue(domain.ResourceId),
+	}}
+	c.ReadBaseConf()
+	c.ReadOriginConf()
+	c.ReadOrigins()
+	c.ReadOriginUrlConf()
+	c.ReadOriginAdvanceConf()
+	c.ReadOriginRequestHeaderConf()
+	c.ReadIpFilterConf()
+	c.ReadIpFrequencyConf()
+	c.ReadRefererConf()
+	c.ReadUserAgentConf()
+	c.ReadSpeedConf()
+	c.ReadAuthConf()
+	c.ReadRemoteAuthConf()
+	c.ReadCacheListConf()
+	c.ReadCacheCodeConf()
+	c.ReadRequestUrlRewriteConf()
+	c.ReadBrowserCacheConf()
+	c.ReadCustomErrorPageConf()
+	c.ReadHttpsConf()
+	c.ReadIntelligentCompressionConf()
+	c.ReadResponseHeaderConf()
+	return c.req, nil
+}
+
+// DomainConfigModel 将腾讯云域名配置反向映射为通用的更新请求,与 UpdateDomainConfigModel 的With*方法一一对应
+type DomainConfigModel struct {
+	domain *tencentsdk.DetailDomain
+	req    *types.UpdateDomainRequest
+}
+
+func (c *DomainConfigModel) ReadBaseConf() {
+	c.req.CdnDomain = &entity.UpdateCdnDomainBaseConf{
+		AreaCode:    mapAreaCode(utils.StringValue(c.domain.Area)),
+		SupportIpv6: consts.SwitchOff,
+	}
+	if c.domain.Ipv6Access != nil {
+		c.req.CdnDomain.SupportIpv6 = mapSwitch(utils.StringValue(c.domain.Ipv6Access.Switch))
+	}
+}
+
+func (c *DomainConfigModel) ReadOriginConf() {
+	c.req.OriginConf = &entity.OriginConf{OriginSniSwitch: -1}
+	if origin := c.domain.Origin; origin != nil {
+		c.req.OriginConf.OriginProtocol = mapOriginProtocol(utils.StringValue(origin.OriginPullProtocol))
+		if origin.Sni != nil {
+			c.req.OriginConf.OriginSniSwitch = mapSwitch(utils.StringValue(origin.Sni.Switch))
+			c.req.OriginConf.OriginSniValue = utils.StringValue(origin.Sni.ServerName)
+		}
+	}
+	if timeout := c.domain.OriginPullTimeout; timeout != nil {
+		c.req.OriginConf.TcpTimeout = int64(utils.Uint64Value(timeout.ConnectTimeout))
+		c.req.OriginConf.OriginTimeOut = int64(utils.Uint64Value(timeout.ReceiveTimeout))
+	}
+	if c.domain.FollowRedirect != nil {
+		c.req.OriginConf.OriginFollow = mapSwitch(utils.StringValue(c.domain.FollowRedirect.Switch))
+	}
+	if c.domain.RangeOriginPull != nil {
+		c.req.OriginConf.OriginRange = mapSwitch(utils.StringValue(c.domain.RangeOriginPull.Switch))
+	}
+}
+
+// ReadOrigins 主源站与备源站共用回源协议,端口按回源协议写入http或https端口
+func (c *DomainConfigModel) ReadOrigins() {
+	c.req.OriginServerConf = make([]*entity.OriginServerConf, 0)
+	origin := c.domain.Origin
+	if origin == nil {
+		return
+	}
+	protocol := mapOriginProtocol(utils.StringValue(origin.OriginPullProtocol))
+	c.req.OriginServerConf = append(c.req.OriginServerConf, readOrigins(protocol, consts.OriginPriorityPrimary, utils.StringValue(origin.OriginType), utils.StringValue(origin.ServerName), origin.Origins)...)
+	c.req.OriginServerConf = append(c.req.OriginServerConf, readOrigins(protocol, consts.OriginPriorityBackup, utils.StringValue(origin.BackupOriginType), utils.StringValue(origin.BackupServerName), origin.BackupOrigins)...)
+}
+
+func readOrigins(protocol int64, priority int64, originType string, originHost string, origins []*string) []*entity.OriginServerConf {
+	list := make([]*entity.OriginServerConf, 0, len(origins))
+	for _, v := range origins {
+		address, port, weight := mapOriginAddress(utils.StringValue(v), protocol)
+		item := &entity.OriginServerConf{
+			OriginType:        mapOriginType(originType, address),
+			OriginAddressList: address,
+			OriginHost:        originHost,
+			OriginWeight:      weight,
+			OriginPriority:    priority,
+		}
+		switch protocol {
+		case consts.OriginProtocolHttp:
+			item.OriginHttpPort = port
+		case consts.OriginProtocolHttps:
+			item.OriginHttpsPort = port
+		}
+		list = append(list, item)
+	}
+	return list
+}
+
+func (c *DomainConfigModel) ReadOriginUrlConf() {
+	c.req.OriginUrlConf = make([]*entity.OriginUrlConf, 0)
+	if c.domain.Origin == nil {
+		return
+	}
+	for i, v := range c.domain.Origin.PathRules {
+		c.req.OriginUrlConf = append(c.req.OriginUrlConf, &entity.OriginUrlConf{
+			MateMethod: mapOriginMateMethod(utils.BoolValue(v.Regex), utils.BoolValue(v.FullMatch)),
+			RewriteUrl: utils.StringValue(v.Path),
+			TargetUrl:  utils.StringValue(v.ForwardUri),
+			Priority:   int64(i),
+		})
+	}
+}
+
+// ReadOriginAdvanceConf 每个回源源站对应一条高级回源配置
+func (c *DomainConfigModel) ReadOriginAdvanceConf() {
+	c.req.OriginAdvanceServerConf = make([]*entity.OriginAdvanceServerConf, 0)
+	origin := c.domain.Origin
+	if origin == nil {
+		return
+	}
+	protocol := mapOriginProtocol(utils.StringValue(origin.OriginPullProtocol))
+	for _, v := range origin.PathBasedOrigin {
+		mode := mapRuleType(utils.StringValue(v.RuleType))
+		for _, source := range v.Origin {
+			address, port, _ := mapOriginAddress(utils.StringValue(source), protocol)
+			item := &entity.OriginAdvanceServerConf{
+				UrlMatchMode:      mode,
+				UrlMatchRule:      utils.StringValues(v.RulePaths),
+				OriginType:        mapOriginType(utils.StringValue(origin.OriginType), address),
+				OriginAddressList: address,
+			}
+			switch protocol {
+			case consts.OriginProtocolHttp:
+				item.OriginHttpPort = port
+			case consts.OriginProtocolHttps:
+				item.OriginHttpsPort = port
+			}
+			c.req.OriginAdvanceServerConf = append(c.req.OriginAdvanceServerConf, item)
+		}
+	}
+}
+
+func (c *DomainConfigModel) ReadOriginRequestHeaderConf() {
+	c.req.OriginRequestHeaderConf = make([]*entity.OriginRequestHeaderConf, 0)
+	header := c.domain.RequestHeader
+	if header == nil || utils.StringValue(header.Switch) != consts.ON {
+		return
+	}
+	for _, v := range header.HeaderRules {
+		c.req.OriginRequestHeaderConf = append(c.req.OriginRequestHeaderConf, &entity.OriginRequestHeaderConf{
+			Action:         mapOriginHeaderAction(utils.StringValue(v.HeaderMode)),
+			ParameterKey:   utils.StringValue(v.HeaderName),
+			ParameterValue: utils.StringValue(v.HeaderValue),
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadIpFilterConf() {
+	c.req.IpFilterConf = &types.IpFilterConf{Status: consts.SwitchOff}
+	filter := c.domain.IpFilter
+	if filter == nil {
+		return
+	}
+	c.req.IpFilterConf.Status = mapSwitch(utils.StringValue(filter.Switch))
+	for _, v := range filter.FilterRules {
+		effectiveType := mapAccessEffectiveType(utils.StringValue(v.RuleType))
+		c.req.IpFilterConf.IpFilterConf = append(c.req.IpFilterConf.IpFilterConf, &entity.IpFilter{
+			IpType:         mapWhiteOrBlackList(utils.StringValue(v.FilterType)),
+			IpList:         utils.StringValues(v.Filters),
+			EffectiveType:  effectiveType,
+			EffectiveRules: mapAccessEffectiveContent(effectiveType, v.RulePaths),
+		})
+	}
+	//未配置分路径规则时使用全局黑白名单
+	if len(filter.FilterRules) == 0 && len(filter.Filters) > 0 {
+		c.req.IpFilterConf.IpFilterConf = []*entity.IpFilter{{
+			IpType:        mapWhiteOrBlackList(utils.StringValue(filter.FilterType)),
+			IpList:        utils.StringValues(filter.Filters),
+			EffectiveType: consts.AccessEffectiveTypeAll,
+		}}
+	}
+}
+
+func (c *DomainConfigModel) ReadIpFrequencyConf() {
+	c.req.IpFrequencyConf = &entity.IpFrequencyConf{Status: consts.SwitchOff}
+	if c.domain.IpFreqLimit == nil {
+		return
+	}
+	c.req.IpFrequencyConf.Status = mapSwitch(utils.StringValue(c.domain.IpFreqLimit.Switch))
+	c.req.IpFrequencyConf.Frequency = utils.Int64Value(c.domain.IpFreqLimit.Qps)
+}
+
+func (c *DomainConfigModel) ReadRefererConf() {
+	c.req.RefererConf = &entity.Referer{Status: consts.SwitchOff}
+	referer := c.domain.Referer
+	if referer == nil {
+		return
+	}
+	c.req.RefererConf.Status = mapSwitch(utils.StringValue(referer.Switch))
+	if len(referer.RefererRules) == 0 {
+		return
+	}
+	rule := referer.RefererRules[0]
+	c.req.RefererConf.RefererType = mapWhiteOrBlackList(utils.StringValue(rule.RefererType))
+	c.req.RefererConf.RefererList = utils.StringValues(rule.Referers)
+	if utils.BoolValue(rule.AllowEmpty) {
+		c.req.RefererConf.IncludeEmpty = consts.SwitchOn
+	}
+}
+
+func (c *DomainConfigModel) ReadUserAgentConf() {
+	c.req.UserAgentConf = &types.UserAgentConf{Status: consts.SwitchOff}
+	filter := c.domain.UserAgentFilter
+	if filter == nil {
+		return
+	}
+	c.req.UserAgentConf.Status = mapSwitch(utils.StringValue(filter.Switch))
+	for _, v := range filter.FilterRules {
+		effectiveType := mapAccessEffectiveType(utils.StringValue(v.RuleType))
+		c.req.UserAgentConf.UserAgentConf = append(c.req.UserAgentConf.UserAgentConf, &entity.UserAgent{
+			AgentType:      mapWhiteOrBlackList(utils.StringValue(v.FilterType)),
+			AgentList:      utils.StringValues(v.UserAgents),
+			EffectiveType:  effectiveType,
+			EffectiveRules: mapAccessEffectiveContent(effectiveType, v.RulePaths),
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadSpeedConf() {
+	c.req.SpeedConf = &types.SpeedConf{Status: consts.SwitchOff}
+	capping := c.domain.DownstreamCapping
+	if capping == nil {
+		return
+	}
+	c.req.SpeedConf.Status = mapSwitch(utils.StringValue(capping.Switch))
+	for _, v := range capping.CappingRules {
+		ruleType := mapRuleType(utils.StringValue(v.RuleType))
+		c.req.SpeedConf.SpeedConf = append(c.req.SpeedConf.SpeedConf, &entity.SpeedConf{
+			RuleType:    ruleType,
+			RuleContent: mapRulePaths(ruleType, v.RulePaths),
+			SpeedValues: utils.Int64Value(v.KBpsThreshold),
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadAuthConf() {
+	c.req.AuthConf = &entity.AuthConf{Status: consts.SwitchOff}
+	auth := c.domain.Authentication
+	if auth == nil {
+		return
+	}
+	c.req.AuthConf.Status = mapSwitch(utils.StringValue(auth.Switch))
+	c.req.AuthConf.EncryptMannger = mapAccessAuthEncryptManner(utils.StringValue(auth.AuthAlgorithm))
+	switch {
+	case auth.TypeA != nil:
+		c.req.AuthConf.AuthManner = consts.AccessAuthMannerTypeA
+		c.req.AuthConf.AuthKey = utils.StringValue(auth.TypeA.SecretKey)
+		c.req.AuthConf.AuthKeyBackup = utils.StringValue(auth.TypeA.BackupSecretKey)
+		c.req.AuthConf.AuthParameter = utils.StringValue(auth.TypeA.SignParam)
+		c.req.AuthConf.TimeValue = utils.Int64Value(auth.TypeA.ExpireTime)
+		c.readAuthRange(auth.TypeA.FilterType, auth.TypeA.FileExtensions)
+	case auth.TypeB != nil:
+		c.req.AuthConf.AuthManner = consts.AccessAuthMannerTypeB
+		c.req.AuthConf.AuthKey = utils.StringValue(auth.TypeB.SecretKey)
+		c.req.AuthConf.AuthKeyBackup = utils.StringValue(auth.TypeB.BackupSecretKey)
+		c.req.AuthConf.TimeValue = utils.Int64Value(auth.TypeB.ExpireTime)
+		c.readAuthRange(auth.TypeB.FilterType, auth.TypeB.FileExtensions)
+	case auth.TypeC != nil:
+		c.req.AuthConf.AuthManner = consts.AccessAuthMannerTypeC
+		c.req.AuthConf.AuthKey = utils.StringValue(auth.TypeC.SecretKey)
+		c.req.AuthConf.AuthKeyBackup = utils.StringValue(auth.TypeC.BackupSecretKey)
+		c.req.AuthConf.TimeValue = utils.Int64Value(auth.TypeC.ExpireTime)
+		c.req.AuthConf.TimeFormat = mapAccessAuthTimeFormat(utils.StringValue(auth.TypeC.TimeFormat))
+		c.readAuthRange(auth.TypeC.FilterType, auth.TypeC.FileExtensions)
+	case auth.TypeD != nil:
+		c.req.AuthConf.AuthManner = consts.AccessAuthMannerTypeD
+		c.req.AuthConf.AuthKey = utils.StringValue(auth.TypeD.SecretKey)
+		c.req.AuthConf.AuthKeyBackup = utils.StringValue(auth.TypeD.BackupSecretKey)
+		c.req.AuthConf.AuthParameter = utils.StringValue(auth.TypeD.SignParam)
+		c.req.AuthConf.TimeValue = utils.Int64Value(auth.TypeD.ExpireTime)
+		c.req.AuthConf.TimeFormat = mapAccessAuthTimeFormat(utils.StringValue(auth.TypeD.TimeFormat))
+		c.readAuthRange(auth.TypeD.FilterType, auth.TypeD.FileExtensions)
+	}
+}
+
+func (c *DomainConfigModel) readAuthRange(filterType *string, extensions []*string) {
+	c.req.AuthConf.FileSuffix = mapAccessAuthRangeFileExtensions(extensions)
+	c.req.AuthConf.AuthRange = mapAccessAuthRange(utils.StringValue(filterType), c.req.AuthConf.FileSuffix)
+}
+
+func (c *DomainConfigModel) ReadRemoteAuthConf() {
+	c.req.RemoteAuthConf = &entity.RemoteAuthConf{Status: consts.SwitchOff}
+	auth := c.domain.RemoteAuthentication
+	if auth == nil {
+		return
+	}
+	c.req.RemoteAuthConf.Status = mapSwitch(utils.StringValue(auth.Switch))
+	if len(auth.RemoteAuthenticationRules) == 0 {
+		return
+	}
+	rule := auth.RemoteAuthenticationRules[0]
+	c.req.RemoteAuthConf.AuthUrl = utils.StringValue(rule.Server)
+	c.req.RemoteAuthConf.ReqMethod = mapRemoteAuthRequestMethod(utils.StringValue(rule.AuthMethod))
+	c.req.RemoteAuthConf.FileType = mapRuleType(utils.StringValue(rule.RuleType))
+	c.req.RemoteAuthConf.FileContent = mapRulePaths(c.req.RemoteAuthConf.FileType, rule.RulePaths)
+	c.req.RemoteAuthConf.TimeoutDuration = utils.Int64Value(rule.AuthTimeout)
+	c.req.RemoteAuthConf.TimeoutAction = mapAccessRemoteAuthTimeOutAction(utils.StringValue(rule.AuthTimeoutAction))
+}
+
+// ReadCacheListConf 缓存规则与缓存键规则按规则类型和内容对应,全部内容的缓存键取全局配置
+func (c *DomainConfigModel) ReadCacheListConf() {
+	c.req.CacheListConf = make([]*entity.CacheListItem, 0)
+	if c.domain.Cache == nil {
+		return
+	}
+	for i, v := range c.domain.Cache.RuleCache {
+		cacheType := mapRuleType(utils.StringValue(v.RuleType))
+		item := &entity.CacheListItem{
+			CacheType:        cacheType,
+			CacheContent:     mapRulePaths(cacheType, v.RulePaths),
+			CacheStatus:      consts.CacheStatusOff,
+			CacheUnit:        consts.CacheUnitSecond,
+			Priority:         int64(i),
+			ParametersStatus: consts.CacheParameterStatusAll,
+		}
+		if config := v.CacheConfig; config != nil {
+			switch {
+			case config.FollowOrigin != nil && utils.StringValue(config.FollowOrigin.Switch) == consts.ON:
+				item.CacheStatus = consts.CacheStatusFollow
+			case config.Cache != nil && utils.StringValue(config.Cache.Switch) == consts.ON:
+				item.CacheStatus = consts.CacheStatusOn
+				item.CacheTTL, item.CacheUnit = mapCacheTime(utils.Int64Value(config.Cache.CacheTime))
+			}
+		}
+		c.readCacheKey(item, v.RuleType, v.RulePaths)
+		c.req.CacheListConf = append(c.req.CacheListConf, item)
+	}
+}
+
+func (c *DomainConfigModel) readCacheKey(item *entity.CacheListItem, ruleType *string, rulePaths []*string) {
+	cacheKey := c.domain.CacheKey
+	if cacheKey == nil {
+		return
+	}
+	var fullUrlCache, ignoreCase, querySwitch, action, value string
+	if item.CacheType == consts.RuleTypeAll {
+		fullUrlCache = utils.StringValue(cacheKey.FullUrlCache)
+		ignoreCase = utils.StringValue(cacheKey.IgnoreCase)
+		if cacheKey.QueryString != nil {
+			querySwitch = utils.StringValue(cacheKey.QueryString.Switch)
+			action = utils.StringValue(cacheKey.QueryString.Action)
+			value = utils.StringValue(cacheKey.QueryString.Value)
+		}
+	} else {
+		keyRule := findKeyRule(cacheKey.KeyRules, utils.StringValue(ruleType), utils.StringValues(rulePaths))
+		if keyRule == nil {
+			return
+		}
+		fullUrlCache = utils.StringValue(keyRule.FullUrlCache)
+		ignoreCase = utils.StringValue(keyRule.IgnoreCase)
+		if keyRule.QueryString != nil {
+			querySwitch = utils.StringValue(keyRule.QueryString.Switch)
+			action = utils.StringValue(keyRule.QueryString.Action)
+			value = utils.StringValue(keyRule.QueryString.Value)
+		}
+	}
+	item.Capitalization = mapSwitch(ignoreCase)
+	item.ParametersStatus = mapCacheParameterStatus(fullUrlCache, querySwitch, action)
+	if item.ParametersStatus == consts.CacheParameterStatusInclude || item.ParametersStatus == consts.CacheParameterStatusExclude {
+		item.ParametersValue = utils.SplitString(value, ";")
+	}
+}
+
+// findKeyRule 查找规则类型与内容一致的缓存键规则
+func findKeyRule(keyRules []*tencentsdk.KeyRule, ruleType string, rulePaths []string) *tencentsdk.KeyRule {
+	for _, v := range keyRules {
+		if utils.StringValue(v.RuleType) != ruleType || len(v.RulePaths) != len(rulePaths) {
+			continue
+		}
+		match := true
+		for i, path := range utils.StringValues(v.RulePaths) {
+			if path != rulePaths[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return v
+		}
+	}
+	return nil
+}
+
+func (c *DomainConfigModel) ReadCacheCodeConf() {
+	c.req.CacheCodeConf = make([]*entity.CacheCodeListItem, 0)
+	cache := c.domain.StatusCodeCache
+	if cache == nil || utils.StringValue(cache.Switch) != consts.ON {
+		return
+	}
+	for _, v := range cache.CacheRules {
+		item := &entity.CacheCodeListItem{HttpCode: cast.ToInt64(utils.StringValue(v.StatusCode))}
+		item.CacheTTL, item.CacheUnit = mapCacheTime(utils.Int64Value(v.CacheTime))
+		c.req.CacheCodeConf = append(c.req.CacheCodeConf, item)
+	}
+}
+
+func (c *DomainConfigModel) ReadRequestUrlRewriteConf() {
+	c.req.RequestUrlRewriteConf = make([]*entity.RequestUrlRewriteConf, 0)
+	redirect := c.domain.UrlRedirect
+	if redirect == nil || utils.StringValue(redirect.Switch) != consts.ON {
+		return
+	}
+	for i, v := range redirect.PathRules {
+		item := &entity.RequestUrlRewriteConf{
+			MateMethod:   consts.RequestUrlRewriteTypeDirectory,
+			RewriteUrl:   utils.StringValue(v.Pattern),
+			TargetUrl:    utils.StringValue(v.RedirectUrl),
+			RedirectCode: mapRedirectCode(utils.Int64Value(v.RedirectStatusCode)),
+			Priority:     int64(i),
+		}
+		if utils.BoolValue(v.FullMatch) {
+			item.MateMethod = consts.RequestUrlRewriteTypeFullPath
+		}
+		c.req.RequestUrlRewriteConf = append(c.req.RequestUrlRewriteConf, item)
+	}
+}
+
+func (c *DomainConfigModel) ReadBrowserCacheConf() {
+	c.req.BrowserCacheConf = make([]*entity.BrowserCacheListItem, 0)
+	maxAge := c.domain.MaxAge
+	if maxAge == nil || utils.StringValue(maxAge.Switch) != consts.ON {
+		return
+	}
+	for i, v := range maxAge.MaxAgeRules {
+		cacheType := mapRuleType(utils.StringValue(v.MaxAgeType))
+		item := &entity.BrowserCacheListItem{
+			CacheType:    cacheType,
+			CacheContent: mapRulePaths(cacheType, v.MaxAgeContents),
+			CacheStatus:  mapMaxAgeCacheStatus(utils.StringValue(v.FollowOrigin), utils.Int64Value(v.MaxAgeTime)),
+			Priority:     int64(i),
+		}
+		item.CacheTTL, item.CacheUnit = mapCacheTime(utils.Int64Value(v.MaxAgeTime))
+		c.req.BrowserCacheConf = append(c.req.BrowserCacheConf, item)
+	}
+}
+
+func (c *DomainConfigModel) ReadCustomErrorPageConf() {
+	c.req.CustomErrorPageConf = make([]*entity.CustomErrorPageConf, 0)
+	errorPage := c.domain.ErrorPage
+	if errorPage == nil || utils.StringValue(errorPage.Switch) != consts.ON {
+		return
+	}
+	for _, v := range errorPage.PageRules {
+		c.req.CustomErrorPageConf = append(c.req.CustomErrorPageConf, &entity.CustomErrorPageConf{
+			StatusCode:   utils.Int64Value(v.StatusCode),
+			RedirectCode: mapRedirectCode(utils.Int64Value(v.RedirectCode)),
+			GoalAddress:  utils.StringValue(v.RedirectUrl),
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadHttpsConf() {
+	c.req.HttpsConf = &entity.HttpsConf{HttpsStatus: consts.SwitchOff}
+	if https := c.domain.Https; https != nil {
+		c.req.HttpsConf.HttpsStatus = mapSwitch(utils.StringValue(https.Switch))
+		c.req.HttpsConf.HttpTwo = mapSwitch(utils.StringValue(https.Http2))
+		c.req.HttpsConf.OcspStatus = mapSwitch(utils.StringValue(https.OcspStapling))
+		c.req.HttpsConf.TlsVersion = mapTlsVersions(https.TlsVersion)
+		if https.CertInfo != nil {
+			c.req.HttpsConf.CertName = utils.StringValue(https.CertInfo.CertName)
+			c.req.HttpsConf.CertValue = utils.StringValue(https.CertInfo.Certificate)
+		}
+		if https.Hsts != nil {
+			c.req.HttpsConf.HstsStatus = mapSwitch(utils.StringValue(https.Hsts.Switch))
+			c.req.HttpsConf.HstsExpirationTime = utils.Int64Value(https.Hsts.MaxAge)
+			c.req.HttpsConf.HstsSubdomain = mapSwitch(utils.StringValue(https.Hsts.IncludeSubDomains))
+		}
+	}
+	if redirect := c.domain.ForceRedirect; redirect != nil {
+		c.req.HttpsConf.JumpForceStatus = mapSwitch(utils.StringValue(redirect.Switch))
+		c.req.HttpsConf.JumpType = mapHttpsJumpType(utils.StringValue(redirect.RedirectType))
+		c.req.HttpsConf.JumpManner = mapRedirectCode(utils.Int64Value(redirect.RedirectStatusCode))
+	}
+	if c.domain.Quic != nil {
+		c.req.HttpsConf.QuicStatus = mapSwitch(utils.StringValue(c.domain.Quic.Switch))
+	}
+}
+
+func (c *DomainConfigModel) ReadIntelligentCompressionConf() {
+	c.req.IntelligentCompressionConf = &types.IntelligentCompressionConf{Status: consts.SwitchOff}
+	compression := c.domain.Compression
+	if compression == nil {
+		return
+	}
+	c.req.IntelligentCompressionConf.Status = mapSwitch(utils.StringValue(compression.Switch))
+	for i, v := range compression.CompressionRules {
+		compressType := mapCompressRuleType(utils.StringValue(v.RuleType))
+		item := &entity.IntelligentCompressionConf{
+			CompressType:   compressType,
+			CompressMethod: mapIntelligentCompressionCompressMethod(v.Algorithms),
+			Priority:       int64(i),
+			Status:         c.req.IntelligentCompressionConf.Status,
+		}
+		if compressType != consts.CompressRuleTypeAll {
+			item.CompressContent = utils.StringValues(v.RulePaths)
+		}
+		c.req.IntelligentCompressionConf.IntelligentCompressionConf = append(c.req.IntelligentCompressionConf.IntelligentCompressionConf, item)
+	}
+}
+
+func (c *DomainConfigModel) ReadResponseHeaderConf() {
+	c.req.ResponseHeaderConf = make([]*entity.ResponseHeaderConf, 0)
+	header := c.domain.ResponseHeader
+	if header == nil || utils.StringValue(header.Switch) != consts.ON {
+		return
+	}
+	for _, v := range header.HeaderRules {
+		c.req.ResponseHeaderConf = append(c.req.ResponseHeaderConf, &entity.ResponseHeaderConf{
+			Action:         mapOriginHeaderAction(utils.StringValue(v.HeaderMode)),
+			ParameterKey:   utils.StringValue(v.HeaderName),
+			ParameterValue: utils.StringValue(v.HeaderValue),
+		})
+	}
+}
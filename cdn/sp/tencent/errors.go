@@ -0,0 +1,66 @@
+package tencent
+
+import (
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	tencentsdk "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cdn/v20180606"
+	errors2 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"strings"
+)
+
+// wrapError 将腾讯云SDK错误转换为统一错误
+func wrapError(err error) error {
+	var e *errors2.TencentCloudSDKError
+	if !errors.As(err, &e) {
+		return err
+	}
+	return &cdnerr.Error{
+		Kind:      getErrorKind(e.GetCode()),
+		Provider:  types.TencentSdkName,
+		Code:      e.GetCode(),
+		Message:   e.GetMessage(),
+		RequestId: e.GetRequestId(),
+		Temporary: strings.HasPrefix(e.GetCode(), "InternalError") || e.GetCode() == "ClientError.NetworkError",
+		Err:       err,
+	}
+}
+
+// GetErrorKind 错误码转错误分类
+func getErrorKind(code string) error {
+	switch code {
+	case tencentsdk.RESOURCENOTFOUND, tencentsdk.RESOURCENOTFOUND_CDNHOSTNOTEXISTS, tencentsdk.RESOURCENOTFOUND_ECDNDOMAINNOTEXISTS:
+		return cdnerr.ErrDomainNotFound
+	case tencentsdk.RESOURCEINUSE_CDNHOSTEXISTS, tencentsdk.RESOURCEINUSE_CDNCONFLICTHOSTEXISTS, tencentsdk.RESOURCEINUSE_TCBHOSTEXISTS,
+		tencentsdk.RESOURCEUNAVAILABLE_CDNHOSTEXISTSINDSA, tencentsdk.RESOURCEUNAVAILABLE_CDNHOSTEXISTSINTCB, tencentsdk.RESOURCEUNAVAILABLE_HOSTEXISTINVOD:
+		return cdnerr.ErrDomainAlreadyExists
+	case tencentsdk.RESOURCEUNAVAILABLE_CDNHOSTNOICP, tencentsdk.UNAUTHORIZEDOPERATION_CDNHOSTINICPBLACKLIST:
+		return cdnerr.ErrIcpNotFiled
+	case tencentsdk.REQUESTLIMITEXCEEDED, tencentsdk.LIMITEXCEEDED_CDNHOSTOPTOOOFTEN, tencentsdk.LIMITEXCEEDED_CDNCALLINGQUERYIPTOOOFTEN:
+		return cdnerr.ErrRateLimited
+	}
+	switch {
+	case strings.HasPrefix(code, "RequestLimitExceeded"):
+		return cdnerr.ErrRateLimited
+	case strings.HasPrefix(code, tencentsdk.LIMITEXCEEDED):
+		return cdnerr.ErrQuotaExceeded
+	case strings.HasPrefix(code, tencentsdk.AUTHFAILURE), strings.HasPrefix(code, tencentsdk.UNAUTHORIZEDOPERATION):
+		return cdnerr.ErrAuthFailed
+	case strings.HasPrefix(code, tencentsdk.INVALIDPARAMETER), strings.HasPrefix(code, "MissingParameter"):
+		return cdnerr.ErrInvalidParameter
+	case strings.HasPrefix(code, tencentsdk.UNSUPPORTEDOPERATION):
+		return cdnerr.ErrUnsupported
+	default:
+		return nil
+	}
+}
+
+// newNotFoundError 查询的任务或统计数据不存在
+func newNotFoundError(message string, requestId string) error {
+	return &cdnerr.Error{
+		Kind:      cdnerr.ErrNotFound,
+		Provider:  types.TencentSdkName,
+		Message:   message,
+		RequestId: requestId,
+	}
+}
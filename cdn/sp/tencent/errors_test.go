@@ -0,0 +1,51 @@
+package tencent
+
+import (
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	tencentsdk "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cdn/v20180606"
+	errors2 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"testing"
+)
+
+func TestWrapError(t *testing.T) {
+	for _, tt := range []struct {
+		code      string
+		kind      error
+		temporary bool
+	}{
+		{tencentsdk.RESOURCENOTFOUND_CDNHOSTNOTEXISTS, cdnerr.ErrDomainNotFound, false},
+		{tencentsdk.RESOURCEINUSE_CDNHOSTEXISTS, cdnerr.ErrDomainAlreadyExists, false},
+		{tencentsdk.RESOURCEUNAVAILABLE_CDNHOSTNOICP, cdnerr.ErrIcpNotFiled, false},
+		{tencentsdk.REQUESTLIMITEXCEEDED, cdnerr.ErrRateLimited, false},
+		{"RequestLimitExceeded.UinLimitExceeded", cdnerr.ErrRateLimited, false},
+		{tencentsdk.LIMITEXCEEDED_CDNHOSTOPTOOOFTEN, cdnerr.ErrRateLimited, false},
+		{tencentsdk.LIMITEXCEEDED_CDNPURGEEXCEEDDAYLIMIT, cdnerr.ErrQuotaExceeded, false},
+		{"AuthFailure.SignatureFailure", cdnerr.ErrAuthFailed, false},
+		{"UnauthorizedOperation.CdnAccountUnauthorized", cdnerr.ErrAuthFailed, false},
+		{tencentsdk.INVALIDPARAMETER_CDNPARAMERROR, cdnerr.ErrInvalidParameter, false},
+		{"MissingParameter", cdnerr.ErrInvalidParameter, false},
+		{tencentsdk.UNSUPPORTEDOPERATION, cdnerr.ErrUnsupported, false},
+		{tencentsdk.INTERNALERROR_CDNSYSTEMERROR, nil, true},
+		{"ClientError.NetworkError", nil, true},
+		{"FailedOperation", nil, false},
+	} {
+		origin := errors2.NewTencentCloudSDKError(tt.code, "message", "req-1")
+		err := wrapError(origin)
+		var e *cdnerr.Error
+		if !errors.As(err, &e) {
+			t.Fatalf("%s: got %T", tt.code, err)
+		}
+		if e.Kind != tt.kind || e.Temporary != tt.temporary || e.Code != tt.code || e.RequestId != "req-1" {
+			t.Errorf("%s: got %+v", tt.code, e)
+		}
+		var sdkErr *errors2.TencentCloudSDKError
+		if !errors.As(err, &sdkErr) || sdkErr.GetCode() != tt.code {
+			t.Errorf("%s: sdk error not kept", tt.code)
+		}
+	}
+	other := errors.New("network error")
+	if wrapError(other) != other || wrapError(nil) != nil {
+		t.Fatal("non sdk error changed")
+	}
+}
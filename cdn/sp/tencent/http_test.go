@@ -0,0 +1,126 @@
+package tencent
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest/proxy"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newApiServer block为true时阻塞到请求被取消,否则返回域名不存在
+func newApiServer(t *testing.T, block bool) *httptest.Server {
+	t.Helper()
+	release := make(chan struct{})
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if block {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Response":{"Error":{"Code":"ResourceNotFound.CdnHostNotExists","Message":"domain not exist"},"RequestId":"req-1"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	return srv
+}
+
+// countingTransport 统计经由的请求数后交给base发送
+type countingTransport struct {
+	base  http.RoundTripper
+	count atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count.Add(1)
+	return c.base.RoundTrip(req)
+}
+
+func TestHttpProxy(t *testing.T) {
+	srv := newApiServer(t, false)
+	p := proxy.New(srv.Listener.Addr().String())
+	t.Cleanup(p.Close)
+	client, err := New(context.Background(), &Config{
+		Ak: "ak",
+		Sk: "sk",
+		Http: &httpclient.Options{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+			Proxy:     p.URL,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	if !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+	if hosts := p.Hosts(); !reflect.DeepEqual(hosts, []string{"cdn.tencentcloudapi.com:443"}) {
+		t.Fatalf("got proxied hosts %v", hosts)
+	}
+}
+
+func TestHttpTransportAndTimeout(t *testing.T) {
+	srv := newApiServer(t, true)
+	for _, tt := range []struct {
+		name string
+		http func(rt http.RoundTripper) *httpclient.Options
+	}{
+		{"options", func(rt http.RoundTripper) *httpclient.Options {
+			return &httpclient.Options{Transport: rt, Timeout: 100 * time.Millisecond}
+		}},
+		//未指定Transport与Timeout时使用Client中的设置
+		{"client", func(rt http.RoundTripper) *httpclient.Options {
+			return &httpclient.Options{Client: &http.Client{Transport: rt, Timeout: 100 * time.Millisecond}}
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &countingTransport{base: srv.Client().Transport}
+			client, err := New(context.Background(), &Config{
+				Endpoint: strings.TrimPrefix(srv.URL, "https://"),
+				Ak:       "ak",
+				Sk:       "sk",
+				Http:     tt.http(rt),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			//腾讯云SDK超时以秒为单位,100ms向上取整为1s
+			start := time.Now()
+			if _, err := client.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); err == nil {
+				t.Fatal("want timeout error")
+			}
+			if elapsed := time.Since(start); elapsed < time.Second || elapsed > 3*time.Second {
+				t.Fatalf("call returned after %s, want about 1s", elapsed)
+			}
+			if rt.count.Load() == 0 {
+				t.Fatal("custom transport not used")
+			}
+		})
+	}
+}
+
+func TestHttpInvalidProxy(t *testing.T) {
+	for _, opts := range []*httpclient.Options{
+		{Proxy: "://bad"},
+		{Proxy: "127.0.0.1:8080"},
+		{Proxy: "http://127.0.0.1:8080", Transport: &countingTransport{}},
+	} {
+		_, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Http: opts})
+		if !errors.Is(err, cdnerr.ErrInvalidParameter) {
+			t.Fatalf("proxy %q: got %v, want invalid parameter", opts.Proxy, err)
+		}
+	}
+}
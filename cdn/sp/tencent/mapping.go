@@ -3,6 +3,7 @@ package tencent
 import (
 	"fmt"
 	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/utils"
 	"net"
 	"strconv"
 	"strings"
@@ -67,11 +68,10 @@ func serviceTypeToChannelType(st string) int64 {
 	}
 }
 
-// ConvertTimeZone 转换时区
+// convertTimeZone 转换时区,无法识别的时区按东八区处理
 func convertTimeZone(timezone string) string {
 	location, err := time.LoadLocation(timezone)
 	if err != nil {
-		fmt.Println(err)
 		return "UTC+08:00"
 	}
 	_, offset := time.Now().In(location).Zone()
@@ -525,22 +525,6 @@ func getIntelligentCompressionCompressMethod(t int64) string {
 	}
 }
 
-// 判断切片是否是递增的
-func isIncreasingSlice(slice []int64) bool {
-	if len(slice) < 2 {
-		return false
-	}
-	if len(slice) == 2 {
-		return slice[1] == slice[0]+1
-	}
-	for i := 1; i < len(slice); i++ {
-		if slice[i] != slice[i-1]+1 {
-			return false
-		}
-	}
-	return true
-}
-
 // GetShowContentPurgeOrPushStatus 获取显示内容刷新或预热状态
 func getShowContentPurgeOrPushStatus(status string) int64 {
 	switch status {
@@ -868,3 +852,291 @@ func getTopUrlFilter(t int64) string {
 		return "flux"
 	}
 }
+
+// mapSwitch 开关状态转换为开关
+func mapSwitch(s string) int64 {
+	if s == consts.ON {
+		return consts.SwitchOn
+	}
+	return consts.SwitchOff
+}
+
+// mapWhiteOrBlackList 黑白名单类型转换为黑白名单
+func mapWhiteOrBlackList(s string) int64 {
+	if s == "whitelist" {
+		return consts.WhiteList
+	}
+	return consts.BlackList
+}
+
+// mapOriginProtocol 回源协议转换为回源协议代码
+func mapOriginProtocol(s string) int64 {
+	switch s {
+	case "https":
+		return consts.OriginProtocolHttps
+	case "follow":
+		return consts.OriginProtocolFollow
+	default:
+		return consts.OriginProtocolHttp
+	}
+}
+
+// mapOriginType 根据源站类型与地址获取源站类型代码
+func mapOriginType(t string, address string) int64 {
+	switch {
+	case t == "cos" || strings.HasPrefix(t, "third_party"):
+		return consts.OriginTypeBucket
+	case net.ParseIP(address) != nil:
+		return consts.OriginTypeIp
+	default:
+		return consts.OriginTypeDomain
+	}
+}
+
+// mapOriginAddress 拆分源站地址,格式为 地址[:端口][:权重],回源协议为follow时不带端口
+func mapOriginAddress(origin string, protocol int64) (address string, port int64, weight int64) {
+	if net.ParseIP(origin) != nil {
+		return origin, 0, 0
+	}
+	parts := strings.Split(origin, ":")
+	address, parts = parts[0], parts[1:]
+	if protocol != consts.OriginProtocolFollow && len(parts) > 0 {
+		port, _ = strconv.ParseInt(parts[0], 10, 64)
+		parts = parts[1:]
+	}
+	if len(parts) > 0 {
+		weight, _ = strconv.ParseInt(parts[0], 10, 64)
+	}
+	return
+}
+
+// mapRuleType 规则类型转换为规则类型代码
+func mapRuleType(s string) int64 {
+	switch s {
+	case "file":
+		return consts.RuleTypeFileSuffix
+	case "directory":
+		return consts.RuleTypeDirectory
+	case "path":
+		return consts.RuleTypePath
+	case "index":
+		return consts.RuleTypeIndex
+	case "contentType":
+		return consts.RuleTypeContentType
+	default:
+		return consts.RuleTypeAll
+	}
+}
+
+// mapCompressRuleType 压缩规则类型转换为压缩规则类型代码
+func mapCompressRuleType(s string) int64 {
+	switch s {
+	case "file":
+		return consts.CompressRuleTypeFileSuffix
+	case "contentType":
+		return consts.CompressRuleTypeContentType
+	default:
+		return consts.CompressRuleTypeAll
+	}
+}
+
+// mapRulePaths 规则内容转换为缓存内容,全部内容与首页不返回内容
+func mapRulePaths(t int64, paths []*string) []string {
+	if t == consts.RuleTypeAll || t == consts.RuleTypeIndex {
+		return nil
+	}
+	return utils.StringValues(paths)
+}
+
+// mapAccessEffectiveType 生效类型转换为访问限制生效类型
+func mapAccessEffectiveType(s string) int64 {
+	switch s {
+	case "file":
+		return consts.AccessEffectiveTypeFileSuffix
+	case "directory":
+		return consts.AccessEffectiveTypeDirectory
+	case "path":
+		return consts.AccessEffectiveTypePath
+	case "index":
+		return consts.AccessEffectiveTypeIndex
+	default:
+		return consts.AccessEffectiveTypeAll
+	}
+}
+
+// mapAccessEffectiveContent 生效内容转换为访问限制生效内容
+func mapAccessEffectiveContent(t int64, paths []*string) []string {
+	if t == consts.AccessEffectiveTypeAll || t == consts.AccessEffectiveTypeIndex {
+		return nil
+	}
+	return utils.StringValues(paths)
+}
+
+// mapOriginHeaderAction 头部操作转换为回源头操作
+func mapOriginHeaderAction(s string) int64 {
+	switch s {
+	case "del":
+		return consts.OriginHeaderActionDelete
+	case "set":
+		return consts.OriginHeaderActionSet
+	default:
+		return consts.OriginHeaderActionAdd
+	}
+}
+
+// mapOriginMateMethod 回源路径规则转换为匹配方式
+func mapOriginMateMethod(regex bool, fullMatch bool) int64 {
+	switch {
+	case fullMatch:
+		return consts.OriginMateMethodAll
+	case regex:
+		return consts.OriginMateMethodRegx
+	default:
+		return consts.OriginMateMethodPath
+	}
+}
+
+// mapCacheTime 秒数转换为缓存时间与缓存单位,取能整除的最大单位
+func mapCacheTime(seconds int64) (ttl int64, unit int64) {
+	switch {
+	case seconds == 0:
+		return 0, consts.CacheUnitSecond
+	case seconds%86400 == 0:
+		return seconds / 86400, consts.CacheUnitDay
+	case seconds%3600 == 0:
+		return seconds / 3600, consts.CacheUnitHour
+	case seconds%60 == 0:
+		return seconds / 60, consts.CacheUnitMinute
+	default:
+		return seconds, consts.CacheUnitSecond
+	}
+}
+
+// mapMaxAgeCacheStatus 浏览器缓存规则转换为缓存状态
+func mapMaxAgeCacheStatus(followOrigin string, maxAgeTime int64) int64 {
+	switch {
+	case followOrigin == consts.ON:
+		return consts.CacheStatusFollow
+	case maxAgeTime == 0:
+		return consts.CacheStatusOff
+	default:
+		return consts.CacheStatusOn
+	}
+}
+
+// mapCacheParameterStatus 缓存键配置转换为缓存参数状态
+func mapCacheParameterStatus(fullUrlCache string, querySwitch string, action string) int64 {
+	switch {
+	case querySwitch == consts.ON && action == "includeCustom":
+		return consts.CacheParameterStatusInclude
+	case querySwitch == consts.ON && action == "excludeCustom":
+		return consts.CacheParameterStatusExclude
+	case fullUrlCache == consts.ON:
+		return consts.CacheParameterStatusOff
+	default:
+		return consts.CacheParameterStatusAll
+	}
+}
+
+// mapRedirectCode 重定向状态码转换为跳转码
+func mapRedirectCode(code int64) int64 {
+	if code == 302 {
+		return consts.RedirectCode302
+	}
+	return consts.RedirectCode301
+}
+
+// mapAccessAuthRange 鉴权文件类型转换为访问鉴权范围
+func mapAccessAuthRange(filterType string, extensions []string) int64 {
+	switch {
+	case filterType == "whitelist":
+		return consts.AccessAuthRangeExclude
+	case len(extensions) == 0 || extensions[0] == "*":
+		return consts.AccessAuthRangeAll
+	default:
+		return consts.AccessAuthRangeInclude
+	}
+}
+
+// mapAccessAuthRangeFileExtensions 鉴权文件后缀转换为文件后缀,全部文件不返回内容
+func mapAccessAuthRangeFileExtensions(extensions []*string) []string {
+	data := utils.StringValues(extensions)
+	if len(data) == 0 || data[0] == "*" {
+		return nil
+	}
+	return data
+}
+
+// mapAccessAuthEncryptManner 鉴权算法转换为访问鉴权加密方式
+func mapAccessAuthEncryptManner(s string) int64 {
+	if s == "sha256" {
+		return consts.AccessAuthEncryptMannerSha256
+	}
+	return consts.AccessAuthEncryptMannerMd5
+}
+
+// mapAccessAuthTimeFormat 时间格式转换为访问鉴权时间格式
+func mapAccessAuthTimeFormat(s string) int64 {
+	if s == "hex" {
+		return consts.AccessAuthTimeFormatHex
+	}
+	return consts.AccessAuthTimeFormatDec
+}
+
+// mapAccessRemoteAuthTimeOutAction 超时动作转换为访问鉴权超时动作
+func mapAccessRemoteAuthTimeOutAction(s string) int64 {
+	if s == "RETURN_403" {
+		return consts.AccessRemoteAuthTimeOutActionReturn403
+	}
+	return consts.AccessRemoteAuthTimeOutActionReturn200
+}
+
+// mapRemoteAuthRequestMethod 请求方式转换为请求方式代码,all按get处理
+func mapRemoteAuthRequestMethod(s string) int64 {
+	switch s {
+	case "post":
+		return consts.RequestMethodPost
+	case "head":
+		return consts.RequestMethodHead
+	default:
+		return consts.RequestMethodGet
+	}
+}
+
+// mapTlsVersions TLS版本转换为TLS版本代码,兼容逗号拼接的版本
+func mapTlsVersions(versions []*string) []int64 {
+	list := make([]int64, 0, len(versions))
+	for _, v := range versions {
+		for _, item := range utils.SplitString(utils.StringValue(v), ",") {
+			switch item {
+			case "TLSv1", "TLSv1.0":
+				list = append(list, consts.HttpsTlsVersionSSLv0)
+			case "TLSv1.1":
+				list = append(list, consts.HttpsTlsVersionSSLv1)
+			case "TLSv1.2":
+				list = append(list, consts.HttpsTlsVersionSSLv2)
+			case "TLSv1.3":
+				list = append(list, consts.HttpsTlsVersionTLSv3)
+			}
+		}
+	}
+	return list
+}
+
+// mapHttpsJumpType 跳转类型转换为https跳转类型
+func mapHttpsJumpType(s string) int64 {
+	if s == "https" {
+		return consts.HttpsJumpTypeHttps
+	}
+	return consts.HttpsJumpTypeHttp
+}
+
+// mapIntelligentCompressionCompressMethod 压缩算法转换为智能压缩压缩方法
+func mapIntelligentCompressionCompressMethod(algorithms []*string) int64 {
+	for _, v := range algorithms {
+		if utils.StringValue(v) == "brotli" {
+			return consts.IntelligentCompressionCompressMethodBrotli
+		}
+	}
+	return consts.IntelligentCompressionCompressMethodGzip
+}
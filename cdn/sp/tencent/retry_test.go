@@ -0,0 +1,131 @@
+package tencent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// throttleServer 腾讯云接口的本地替身,按接口名依次返回预设的错误码,用尽后返回成功
+type throttleServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	errors map[string][]string
+	calls  map[string]int
+}
+
+func newThrottleServer(t *testing.T, policy *retry.Policy, errs map[string][]string) (*throttleServer, *Tencent) {
+	t.Helper()
+	s := &throttleServer{errors: errs, calls: make(map[string]int)}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	client, err := New(context.Background(), &Config{
+		Endpoint: strings.TrimPrefix(s.URL, "https://"),
+		Ak:       "ak",
+		Sk:       "sk",
+		Retry:    policy,
+		Http:     &httpclient.Options{Client: s.Client()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, client
+}
+
+func (s *throttleServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	action := r.Header.Get("X-TC-Action")
+	s.calls[action]++
+	res := map[string]interface{}{"RequestId": "req-" + action}
+	if queue := s.errors[action]; len(queue) > 0 {
+		s.errors[action] = queue[1:]
+		res["Error"] = map[string]string{"Code": queue[0], "Message": queue[0]}
+	} else {
+		res["TaskId"] = "task-1"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"Response": res})
+}
+
+func (s *throttleServer) count(action string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[action]
+}
+
+func testPolicy() *retry.Policy {
+	return &retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+}
+
+func TestRetryIdempotentCall(t *testing.T) {
+	s, client := newThrottleServer(t, testPolicy(), map[string][]string{
+		"StopCdnDomain": {"RequestLimitExceeded", "InternalError"},
+	})
+	if err := client.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.count("StopCdnDomain"); n != 3 {
+		t.Fatalf("got %d calls, want 3", n)
+	}
+}
+
+func TestRetryStopsAtMaxAttempts(t *testing.T) {
+	s, client := newThrottleServer(t, testPolicy(), map[string][]string{
+		"StopCdnDomain": {"RequestLimitExceeded", "RequestLimitExceeded", "RequestLimitExceeded", "RequestLimitExceeded"},
+	})
+	err := client.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"})
+	if !errors.Is(err, cdnerr.ErrRateLimited) {
+		t.Fatalf("got %v, want rate limited", err)
+	}
+	var e *cdnerr.Error
+	if !errors.As(err, &e) || e.RequestId != "req-StopCdnDomain" {
+		t.Fatalf("request id not kept: %v", err)
+	}
+	if n := s.count("StopCdnDomain"); n != 3 {
+		t.Fatalf("got %d calls, want 3", n)
+	}
+}
+
+func TestRetryNonIdempotentOnlyWhenThrottled(t *testing.T) {
+	s, client := newThrottleServer(t, testPolicy(), map[string][]string{
+		"PurgeUrlsCache": {"RequestLimitExceeded"},
+		"PushUrlsCache":  {"InternalError"},
+	})
+	res, err := client.PurgeUrlsCache(&types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TaskId != "task-1" || s.count("PurgeUrlsCache") != 2 {
+		t.Fatalf("throttled purge: task %q after %d calls", res.TaskId, s.count("PurgeUrlsCache"))
+	}
+	//服务端内部错误时无法确定任务是否已提交,非幂等操作不重试
+	if _, err := client.PushUrlsCache(&types.PushUrlsCacheRequest{Urls: []string{"https://www.example.com/a"}}); err == nil {
+		t.Fatal("expected internal error")
+	}
+	if n := s.count("PushUrlsCache"); n != 1 {
+		t.Fatalf("got %d push calls, want 1", n)
+	}
+}
+
+func TestNoRetryWithoutPolicy(t *testing.T) {
+	s, client := newThrottleServer(t, nil, map[string][]string{
+		"StopCdnDomain": {"RequestLimitExceeded"},
+	})
+	if err := client.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrRateLimited) {
+		t.Fatalf("got %v, want rate limited", err)
+	}
+	if n := s.count("StopCdnDomain"); n != 1 {
+		t.Fatalf("got %d calls, want 1", n)
+	}
+}
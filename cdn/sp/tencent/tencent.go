@@ -4,14 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
 	"github.com/run-bigpig/cloud-sdk/cdn/consts"
 	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/operation"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
 	"github.com/run-bigpig/cloud-sdk/cdn/types"
 	"github.com/run-bigpig/cloud-sdk/utils"
 	tencentsdk "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cdn/v20180606"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
-	errors2 "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -19,36 +25,97 @@ import (
 )
 
 type Tencent struct {
-	config *Config
-	client *tencentsdk.Client
-	ctx    context.Context
+	config  *Config
+	limiter *ratelimit.Limiter
+	client  *tencentsdk.Client
+	ctx     context.Context
 }
 
 type Config struct {
-	Region   string
-	Endpoint string
-	Ak       string
-	Sk       string
+	Region    string
+	Endpoint  string
+	Ak        string
+	Sk        string
+	Retry     *retry.Policy      // 重试策略,为空时不重试
+	RateLimit *ratelimit.Limiter // 限流器,每次调用厂商接口(含重试)前获取令牌,为空时使用ratelimit.DefaultLimits,不限制时传入ratelimit.Unlimited()
+	// Http HTTP客户端选项,为空时使用SDK默认配置,指定Client时使用其transport与超时,超时按秒向上取整
+	Http   *httpclient.Options
+	Logger *slog.Logger // 记录HTTP请求的日志,为空时不记录
 }
 
-// NewTencentSdkClient 创建腾讯云CDN客户端
+// NewTencentSdkClient 创建腾讯云CDN客户端,创建失败时返回nil,需要错误信息时使用 New
 func NewTencentSdkClient(ctx context.Context, conf *Config) *Tencent {
+	client, err := New(ctx, conf)
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// New 创建腾讯云CDN客户端
+func New(ctx context.Context, conf *Config) (*Tencent, error) {
+	if conf == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.TencentSdkName, "config is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	auth := common.NewCredential(conf.Ak, conf.Sk)
 	cfp := profile.NewClientProfile()
 	cfp.HttpProfile.Endpoint = conf.Endpoint
-	client, _ := tencentsdk.NewClient(auth, "", cfp)
-	return &Tencent{
-		config: conf,
-		client: client,
-		ctx:    ctx,
+	if timeout := conf.Http.GetTimeout(); timeout > 0 {
+		cfp.HttpProfile.ReqTimeout = int((timeout + time.Second - 1) / time.Second)
+	}
+	rt, err := conf.Http.RoundTripper()
+	if err != nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.TencentSdkName, "invalid http options: "+err.Error())
 	}
+	rt = cdnlog.NewTransport(conf.Logger, rt)
+	client, err := tencentsdk.NewClient(auth, "", cfp)
+	if err != nil {
+		return nil, &cdnerr.Error{
+			Kind:     cdnerr.ErrInvalidParameter,
+			Provider: types.TencentSdkName,
+			Message:  "build client fail: " + err.Error(),
+			Err:      err,
+		}
+	}
+	if rt != nil {
+		client.WithHttpTransport(rt)
+	}
+	return &Tencent{
+		config:  conf,
+		limiter: ratelimit.OrDefault(conf.RateLimit, types.TencentSdkName),
+		client:  client,
+		ctx:     ctx,
+	}, nil
 }
 
 func (t *Tencent) GetSdkName() string {
 	return types.TencentSdkName
 }
 
+// invoke 调用厂商接口,每次尝试前按class获取限流令牌,转换错误并按重试策略重试
+func invoke[T any](ctx context.Context, t *Tencent, class ratelimit.Class, idempotent bool, fn func() (T, error)) (T, error) {
+	return retry.Do(ctx, t.config.Retry, idempotent, func() (T, error) {
+		if err := t.limiter.Wait(ctx, class); err != nil {
+			var zero T
+			return zero, err
+		}
+		res, err := fn()
+		//SDK将传输错误转为字符串,上下文结束时返回上下文错误
+		if err != nil && ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+		return res, wrapError(err)
+	})
+}
+
 func (t *Tencent) IcpVerify(req *types.IcpVerifyRequest) bool {
+	return t.IcpVerifyWithContext(t.ctx, req)
+}
+
+func (t *Tencent) IcpVerifyWithContext(ctx context.Context, req *types.IcpVerifyRequest) bool {
 	request := tencentsdk.NewAddCdnDomainRequest()
 
 	request.Domain = utils.StringPtr(req.Domain)
@@ -57,36 +124,40 @@ func (t *Tencent) IcpVerify(req *types.IcpVerifyRequest) bool {
 		OriginType: utils.StringPtr("domain"),
 	}
 	request.Area = utils.StringPtr("mainland")
-	_, err := t.client.AddCdnDomain(request)
+	_, err := invoke(ctx, t, ratelimit.ClassConfig, retry.NonIdempotent, func() (*tencentsdk.AddCdnDomainResponse, error) {
+		return t.client.AddCdnDomainWithContext(ctx, request)
+	})
 	if err != nil {
-		var e *errors2.TencentCloudSDKError
-		if errors.As(err, &e) {
-			return !(e.GetCode() == tencentsdk.RESOURCEUNAVAILABLE_CDNHOSTNOICP)
-		}
+		return !errors.Is(err, cdnerr.ErrIcpNotFiled)
 	}
 	return true
 }
 
 // CreateDomain 创建域名
 func (t *Tencent) CreateDomain(req *types.CreateDomainRequest) error {
+	return t.CreateDomainWithContext(t.ctx, req)
+}
+
+// CreateDomainWithContext 创建域名
+func (t *Tencent) CreateDomainWithContext(ctx context.Context, req *types.CreateDomainRequest) error {
 	var originHost string
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
 	//检测域名是否已存在
-	detail, err := t.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: req.Domain})
+	detail, err := t.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: req.Domain})
 	if err == nil {
 		if detail.Status == consts.CdnDomainStatusStoped {
-			err = t.DeleteDomain(&types.DeleteDomainRequest{Domain: req.Domain})
+			err = t.DeleteDomainWithContext(ctx, &types.DeleteDomainRequest{Domain: req.Domain})
 			if err != nil {
 				return err
 			}
 		} else if detail.ChannelType != req.ChannelType || detail.AreaCode != req.AreaCode {
-			err = t.DisableDomain(&types.DisableDomainRequest{Domain: req.Domain})
+			err = t.DisableDomainWithContext(ctx, &types.DisableDomainRequest{Domain: req.Domain})
 			if err != nil {
 				return err
 			}
-			err = t.DeleteDomain(&types.DeleteDomainRequest{Domain: req.Domain})
+			err = t.DeleteDomainWithContext(ctx, &types.DeleteDomainRequest{Domain: req.Domain})
 			if err != nil {
 				return err
 			}
@@ -96,7 +167,7 @@ func (t *Tencent) CreateDomain(req *types.CreateDomainRequest) error {
 	}
 	request := tencentsdk.NewAddCdnDomainRequest()
 	if req.Sources == nil || len(req.Sources) == 0 {
-		return errors.New("sources is nil")
+		return cdnerr.New(cdnerr.ErrInvalidParameter, types.TencentSdkName, "sources is nil")
 	}
 	request.Domain = utils.StringPtr(req.Domain)
 	request.ServiceType = utils.StringPtr(getChannelType(req.ChannelType))
@@ -140,51 +211,19 @@ func (t *Tencent) CreateDomain(req *types.CreateDomainRequest) error {
 		origin.BackupServerName = utils.StringPtr(originHost)
 	}
 	request.Origin = origin
-	_, err = t.client.AddCdnDomain(request)
+	_, err = invoke(ctx, t, ratelimit.ClassConfig, retry.NonIdempotent, func() (*tencentsdk.AddCdnDomainResponse, error) {
+		return t.client.AddCdnDomainWithContext(ctx, request)
+	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// changeDomainArea 切换区域
-func (t *Tencent) changeDomainArea(domain string, lastAreaCode, nowAreaCode int64) error {
-	if lastAreaCode == nowAreaCode {
-		return nil
-	}
-	//更新到全球
-	if lastAreaCode != consts.AreaCodeGlobal {
-		err := t.UpdateDomain(&types.UpdateDomainRequest{
-			Domain:       domain,
-			UpdateAction: types.UpdateArea,
-			CdnDomain: &entity.UpdateCdnDomainBaseConf{
-				AreaCode: consts.AreaCodeGlobal,
-			},
-		})
-		if err != nil {
-			return err
-		}
-	}
-	if nowAreaCode == consts.AreaCodeGlobal {
-		return nil
-	}
-	//更新到指定区域
-	go func() {
-		for i := 0; i < 3; i++ {
-			time.Sleep(time.Second * 60)
-			err := t.UpdateDomain(&types.UpdateDomainRequest{
-				Domain:       domain,
-				UpdateAction: types.UpdateArea,
-				CdnDomain: &entity.UpdateCdnDomainBaseConf{
-					AreaCode: nowAreaCode,
-				},
-			})
-			if err == nil {
-				return
-			}
-		}
-	}()
-	return nil
+// ChangeDomainArea 切换加速区域,中国大陆与境外之间需要先切换到全球,部署完成后再切换到目标区域
+// 返回的操作需要调用Run或Start执行,状态可通过OnProgress持久化,进程退出后通过operation.ResumeAreaMigration恢复
+func (t *Tencent) ChangeDomainArea(domain string, lastAreaCode, nowAreaCode int64, opts *operation.Options) *operation.AreaMigration {
+	return operation.NewAreaMigration(t, domain, lastAreaCode, nowAreaCode, opts)
 }
 
 // CacalOriginPrimaryOrBackNumber 计算主备个数
@@ -202,8 +241,13 @@ func (t *Tencent) cacalOriginPrimaryOrBackNumber(resource []*entity.OriginServer
 
 // UpdateDomain 更新域名
 func (t *Tencent) UpdateDomain(req *types.UpdateDomainRequest) error {
+	return t.UpdateDomainWithContext(t.ctx, req)
+}
+
+// UpdateDomainWithContext 更新域名
+func (t *Tencent) UpdateDomainWithContext(ctx context.Context, req *types.UpdateDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewUpdateDomainConfigRequest()
 	updateDomain := t.newUpdateDomainConfigModel(req, request)
@@ -286,7 +330,9 @@ func (t *Tencent) UpdateDomain(req *types.UpdateDomainRequest) error {
 		updateDomain.WithResponseHeaderConf()
 		updateDomain.WithHttpsConf()
 	}
-	_, err := t.client.UpdateDomainConfig(request)
+	_, err := invoke(ctx, t, ratelimit.ClassConfig, retry.Idempotent, func() (*tencentsdk.UpdateDomainConfigResponse, error) {
+		return t.client.UpdateDomainConfigWithContext(ctx, request)
+	})
 	if err != nil {
 		return err
 	}
@@ -295,15 +341,22 @@ func (t *Tencent) UpdateDomain(req *types.UpdateDomainRequest) error {
 
 // DisableDomain 停用域名
 func (t *Tencent) DisableDomain(req *types.DisableDomainRequest) error {
+	return t.DisableDomainWithContext(t.ctx, req)
+}
+
+// DisableDomainWithContext 停用域名
+func (t *Tencent) DisableDomainWithContext(ctx context.Context, req *types.DisableDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewStopCdnDomainRequest()
 
 	request.Domain = utils.StringPtr(req.Domain)
 
 	// 返回的resp是一个StopCdnDomainResponse的实例，与请求对象对应
-	_, err := t.client.StopCdnDomain(request)
+	_, err := invoke(ctx, t, ratelimit.ClassConfig, retry.Idempotent, func() (*tencentsdk.StopCdnDomainResponse, error) {
+		return t.client.StopCdnDomainWithContext(ctx, request)
+	})
 	if err != nil {
 		return err
 	}
@@ -312,12 +365,19 @@ func (t *Tencent) DisableDomain(req *types.DisableDomainRequest) error {
 
 // EnableDomain 启用域名
 func (t *Tencent) EnableDomain(req *types.EnableDomainRequest) error {
+	return t.EnableDomainWithContext(t.ctx, req)
+}
+
+// EnableDomainWithContext 启用域名
+func (t *Tencent) EnableDomainWithContext(ctx context.Context, req *types.EnableDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewStartCdnDomainRequest()
 	request.Domain = utils.StringPtr(req.Domain)
-	_, err := t.client.StartCdnDomain(request)
+	_, err := invoke(ctx, t, ratelimit.ClassConfig, retry.Idempotent, func() (*tencentsdk.StartCdnDomainResponse, error) {
+		return t.client.StartCdnDomainWithContext(ctx, request)
+	})
 	if err != nil {
 		return err
 	}
@@ -326,23 +386,27 @@ func (t *Tencent) EnableDomain(req *types.EnableDomainRequest) error {
 
 // DeleteDomain 删除域名
 func (t *Tencent) DeleteDomain(req *types.DeleteDomainRequest) error {
+	return t.DeleteDomainWithContext(t.ctx, req)
+}
+
+// DeleteDomainWithContext 删除域名
+func (t *Tencent) DeleteDomainWithContext(ctx context.Context, req *types.DeleteDomainRequest) error {
 	if req == nil {
-		return errors.New("request is nil")
+		return cdnerr.ErrNilRequest
 	}
 	//检测域名是否已存在
-	_, err := t.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: req.Domain})
+	_, err := t.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: req.Domain})
 	if err != nil {
-		var e *errors2.TencentCloudSDKError
-		if errors.As(err, &e) {
-			if e.GetCode() == tencentsdk.RESOURCENOTFOUND {
-				return nil
-			}
+		if errors.Is(err, cdnerr.ErrDomainNotFound) {
+			return nil
 		}
 		return err
 	}
 	request := tencentsdk.NewDeleteCdnDomainRequest()
 	request.Domain = utils.StringPtr(req.Domain)
-	_, err = t.client.DeleteCdnDomain(request)
+	_, err = invoke(ctx, t, ratelimit.ClassConfig, retry.Idempotent, func() (*tencentsdk.DeleteCdnDomainResponse, error) {
+		return t.client.DeleteCdnDomainWithContext(ctx, request)
+	})
 	if err != nil {
 		return err
 	}
@@ -351,15 +415,22 @@ func (t *Tencent) DeleteDomain(req *types.DeleteDomainRequest) error {
 
 // PurgePathCache 刷新目录缓存
 func (t *Tencent) PurgePathCache(req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return t.PurgePathCacheWithContext(t.ctx, req)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (t *Tencent) PurgePathCacheWithContext(ctx context.Context, req *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	flushTypeSlice := []string{"delete", "flush"}
 	request := tencentsdk.NewPurgePathCacheRequest()
 	request.Paths = utils.StringPtrs(req.Paths)
 	request.FlushType = utils.StringPtr(flushTypeSlice[req.Mode])
 	request.UrlEncode = utils.BoolPtr(req.UrlEncode)
-	response, err := t.client.PurgePathCache(request)
+	response, err := invoke(ctx, t, ratelimit.ClassContent, retry.NonIdempotent, func() (*tencentsdk.PurgePathCacheResponse, error) {
+		return t.client.PurgePathCacheWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -368,13 +439,20 @@ func (t *Tencent) PurgePathCache(req *types.PurgePathCacheRequest) (*types.Purge
 
 // PurgeUrlsCache 刷新URL缓存
 func (t *Tencent) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return t.PurgeUrlsCacheWithContext(t.ctx, req)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (t *Tencent) PurgeUrlsCacheWithContext(ctx context.Context, req *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewPurgeUrlsCacheRequest()
 	request.Urls = utils.StringPtrs(req.Urls)
 	request.UrlEncode = utils.BoolPtr(req.UrlEncode)
-	response, err := t.client.PurgeUrlsCache(request)
+	response, err := invoke(ctx, t, ratelimit.ClassContent, retry.NonIdempotent, func() (*tencentsdk.PurgeUrlsCacheResponse, error) {
+		return t.client.PurgeUrlsCacheWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -383,15 +461,22 @@ func (t *Tencent) PurgeUrlsCache(req *types.PurgeUrlsCacheRequest) (*types.Purge
 
 // PushUrlsCache 预热URL缓存
 func (t *Tencent) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return t.PushUrlsCacheWithContext(t.ctx, req)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (t *Tencent) PushUrlsCacheWithContext(ctx context.Context, req *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewPushUrlsCacheRequest()
 
 	request.Urls = utils.StringPtrs(req.Urls)
 	request.UrlEncode = utils.BoolPtr(req.UrlEncode)
 	request.Area = utils.StringPtr("global")
-	response, err := t.client.PushUrlsCache(request)
+	response, err := invoke(ctx, t, ratelimit.ClassContent, retry.NonIdempotent, func() (*tencentsdk.PushUrlsCacheResponse, error) {
+		return t.client.PushUrlsCacheWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -400,19 +485,26 @@ func (t *Tencent) PushUrlsCache(req *types.PushUrlsCacheRequest) (*types.PushUrl
 
 // ShowPurgeTaskStatus 展示刷新任务状态
 func (t *Tencent) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return t.ShowPurgeTaskStatusWithContext(t.ctx, req)
+}
+
+// ShowPurgeTaskStatusWithContext 展示刷新任务状态
+func (t *Tencent) ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewDescribePurgeTasksRequest()
 	request.TaskId = utils.StringPtr(req.TaskId)
 	request.Offset = utils.Int64Ptr(0)
 	request.Limit = utils.Int64Ptr(1)
-	response, err := t.client.DescribePurgeTasks(request)
+	response, err := invoke(ctx, t, ratelimit.ClassQuery, retry.Idempotent, func() (*tencentsdk.DescribePurgeTasksResponse, error) {
+		return t.client.DescribePurgeTasksWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if len(response.Response.PurgeLogs) == 0 {
-		return nil, errors.New("task not found")
+		return nil, newNotFoundError("task not found", utils.StringValue(response.Response.RequestId))
 	}
 	var taskStatus int64
 	for _, v := range response.Response.PurgeLogs {
@@ -429,8 +521,13 @@ func (t *Tencent) ShowPurgeTaskStatus(req *types.ShowPurgeTaskStatusRequest) (*t
 
 // ShowPurgeTaskList 展示刷新任务列表
 func (t *Tencent) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return t.ShowPurgeTaskListWithContext(t.ctx, req)
+}
+
+// ShowPurgeTaskListWithContext 展示刷新任务列表
+func (t *Tencent) ShowPurgeTaskListWithContext(ctx context.Context, req *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewDescribePurgeTasksRequest()
 	//计算翻页
@@ -441,7 +538,9 @@ func (t *Tencent) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types
 	request.StartTime = utils.StringPtr(utils.FormatTimeWithTimezone(req.StartTime, req.TimeZone))
 	request.EndTime = utils.StringPtr(utils.FormatTimeWithTimezone(req.EndTime, req.TimeZone))
 	request.Status = utils.StringPtr(setShowContentPurgeOrPushStatus(req.TaskStatus))
-	response, err := t.client.DescribePurgeTasks(request)
+	response, err := invoke(ctx, t, ratelimit.ClassQuery, retry.Idempotent, func() (*tencentsdk.DescribePurgeTasksResponse, error) {
+		return t.client.DescribePurgeTasksWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -459,19 +558,26 @@ func (t *Tencent) ShowPurgeTaskList(req *types.ShowPurgeTaskListRequest) (*types
 
 // ShowPushTaskStatus 展示预热任务状态
 func (t *Tencent) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return t.ShowPushTaskStatusWithContext(t.ctx, req)
+}
+
+// ShowPushTaskStatusWithContext 展示预热任务状态
+func (t *Tencent) ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewDescribePushTasksRequest()
 	request.TaskId = utils.StringPtr(req.TaskId)
 	request.Offset = utils.Int64Ptr(0)
 	request.Limit = utils.Int64Ptr(1)
-	response, err := t.client.DescribePushTasks(request)
+	response, err := invoke(ctx, t, ratelimit.ClassQuery, retry.Idempotent, func() (*tencentsdk.DescribePushTasksResponse, error) {
+		return t.client.DescribePushTasksWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if len(response.Response.PushLogs) == 0 {
-		return nil, errors.New("task not found")
+		return nil, newNotFoundError("task not found", utils.StringValue(response.Response.RequestId))
 	}
 	var taskStatus int64
 	for _, v := range response.Response.PushLogs {
@@ -488,8 +594,13 @@ func (t *Tencent) ShowPushTaskStatus(req *types.ShowPushTaskStatusRequest) (*typ
 
 // ShowPushTaskList 展示预热任务列表
 func (t *Tencent) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return t.ShowPushTaskListWithContext(t.ctx, req)
+}
+
+// ShowPushTaskListWithContext 展示预热任务列表
+func (t *Tencent) ShowPushTaskListWithContext(ctx context.Context, req *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewDescribePushTasksRequest()
 	//计算翻页
@@ -499,7 +610,9 @@ func (t *Tencent) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.S
 	request.StartTime = utils.StringPtr(utils.FormatTimeWithTimezone(req.StartTime, req.TimeZone))
 	request.EndTime = utils.StringPtr(utils.FormatTimeWithTimezone(req.EndTime, req.TimeZone))
 	request.Status = utils.StringPtr(setShowContentPurgeOrPushStatus(req.TaskStatus))
-	response, err := t.client.DescribePushTasks(request)
+	response, err := invoke(ctx, t, ratelimit.ClassQuery, retry.Idempotent, func() (*tencentsdk.DescribePushTasksResponse, error) {
+		return t.client.DescribePushTasksWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -517,8 +630,13 @@ func (t *Tencent) ShowPushTaskList(req *types.ShowPushTaskListRequest) (*types.S
 
 // ShowDomainDetail 获取域名详情
 func (t *Tencent) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return t.ShowDomainDetailWithContext(t.ctx, req)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (t *Tencent) ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewDescribeDomainsRequest()
 	request.Offset = utils.Int64Ptr(0)
@@ -530,12 +648,14 @@ func (t *Tencent) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.S
 			Fuzzy: utils.BoolPtr(false),
 		},
 	}
-	res, err := t.client.DescribeDomains(request)
+	res, err := invoke(ctx, t, ratelimit.ClassQuery, retry.Idempotent, func() (*tencentsdk.DescribeDomainsResponse, error) {
+		return t.client.DescribeDomainsWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if len(res.Response.Domains) == 0 {
-		return nil, errors2.NewTencentCloudSDKError(tencentsdk.RESOURCENOTFOUND, "domain not found", "")
+		return nil, cdnerr.New(cdnerr.ErrDomainNotFound, types.TencentSdkName, "domain not found")
 	}
 	domain := res.Response.Domains[0]
 	return &types.ShowDomainDetailResponse{
@@ -552,8 +672,13 @@ func (t *Tencent) ShowDomainDetail(req *types.ShowDomainDetailRequest) (*types.S
 
 // ShowDomainStatusList 展示域名状态列表
 func (t *Tencent) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return t.ShowDomainStatusListWithContext(t.ctx, req)
+}
+
+// ShowDomainStatusListWithContext 展示域名状态列表
+func (t *Tencent) ShowDomainStatusListWithContext(ctx context.Context, req *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewDescribeDomainsRequest()
 	//计算翻页
@@ -567,7 +692,9 @@ func (t *Tencent) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (
 			Fuzzy: utils.BoolPtr(false),
 		},
 	}
-	res, err := t.client.DescribeDomains(request)
+	res, err := invoke(ctx, t, ratelimit.ClassQuery, retry.Idempotent, func() (*tencentsdk.DescribeDomainsResponse, error) {
+		return t.client.DescribeDomainsWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -584,13 +711,20 @@ func (t *Tencent) ShowDomainStatusList(req *types.ShowDomainStatusListRequest) (
 
 // CreateVerifyRecord 创建域名验证记录
 func (t *Tencent) CreateVerifyRecord(req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return t.CreateVerifyRecordWithContext(t.ctx, req)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (t *Tencent) CreateVerifyRecordWithContext(ctx context.Context, req *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewCreateVerifyRecordRequest()
 
 	request.Domain = utils.StringPtr(req.Domain)
-	response, err := t.client.CreateVerifyRecord(request)
+	response, err := invoke(ctx, t, ratelimit.ClassConfig, retry.Idempotent, func() (*tencentsdk.CreateVerifyRecordResponse, error) {
+		return t.client.CreateVerifyRecordWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -599,13 +733,20 @@ func (t *Tencent) CreateVerifyRecord(req *types.CreateVerifyRecordRequest) (*typ
 
 // VerifyDomainRecord 验证域名解析记录
 func (t *Tencent) VerifyDomainRecord(req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return t.VerifyDomainRecordWithContext(t.ctx, req)
+}
+
+// VerifyDomainRecordWithContext 验证域名解析记录
+func (t *Tencent) VerifyDomainRecordWithContext(ctx context.Context, req *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, cdnerr.ErrNilRequest
 	}
 	request := tencentsdk.NewVerifyDomainRecordRequest()
 	request.Domain = utils.StringPtr(req.Domain)
 	request.VerifyType = utils.StringPtr(req.VerifyType)
-	response, err := t.client.VerifyDomainRecord(request)
+	response, err := invoke(ctx, t, ratelimit.ClassConfig, retry.Idempotent, func() (*tencentsdk.VerifyDomainRecordResponse, error) {
+		return t.client.VerifyDomainRecordWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1147,6 +1288,11 @@ func (u *UpdateDomainConfigModel) WithCustomErrorPageConf() {
 
 // DomainAccessDataStatic 获取域名访问数据统计
 func (t *Tencent) DomainAccessDataStatic(req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return t.DomainAccessDataStaticWithContext(t.ctx, req)
+}
+
+// DomainAccessDataStaticWithContext 获取域名访问数据统计
+func (t *Tencent) DomainAccessDataStaticWithContext(ctx context.Context, req *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
 	if err := t.verifyMutualExclusion(req); err != nil {
 		return nil, err
 	}
@@ -1189,12 +1335,14 @@ func (t *Tencent) DomainAccessDataStatic(req *types.DomainAccessDataStaticReques
 	if req.Isp != nil {
 		request.Isp = common.Int64Ptr(getIspCode(*req.Isp))
 	}
-	response, err := t.client.DescribeCdnData(request)
+	response, err := invoke(ctx, t, ratelimit.ClassStats, retry.Idempotent, func() (*tencentsdk.DescribeCdnDataResponse, error) {
+		return t.client.DescribeCdnDataWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if len(response.Response.Data) == 0 {
-		return nil, errors.New("data not found")
+		return nil, newNotFoundError("data not found", utils.StringValue(response.Response.RequestId))
 	}
 	responseData := make(types.DomainAccessDataStaticResponse)
 	for _, v := range response.Response.Data {
@@ -1232,6 +1380,11 @@ func (t *Tencent) DomainAccessDataStatic(req *types.DomainAccessDataStaticReques
 
 // DomainOriginDataStatic 获取域名回源数据统计
 func (t *Tencent) DomainOriginDataStatic(req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return t.DomainOriginDataStaticWithContext(t.ctx, req)
+}
+
+// DomainOriginDataStaticWithContext 获取域名回源数据统计
+func (t *Tencent) DomainOriginDataStaticWithContext(ctx context.Context, req *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
 	request := tencentsdk.NewDescribeOriginDataRequest()
 	if req.TimeZone == nil {
 		req.TimeZone = common.StringPtr("Asia/Shanghai")
@@ -1245,7 +1398,9 @@ func (t *Tencent) DomainOriginDataStatic(req *types.DomainOriginDataStaticReques
 	request.TimeZone = common.StringPtr(convertTimeZone(*req.TimeZone))
 	request.Detail = common.BoolPtr(true)
 
-	response, err := t.client.DescribeOriginData(request)
+	response, err := invoke(ctx, t, ratelimit.ClassStats, retry.Idempotent, func() (*tencentsdk.DescribeOriginDataResponse, error) {
+		return t.client.DescribeOriginDataWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1289,11 +1444,11 @@ func (t *Tencent) verifyMutualExclusion(req *types.DomainAccessDataStaticRequest
 	switch {
 	case req.IpProtocol == nil:
 		if req.District != nil && req.Isp != nil {
-			return errors.New("when the ip protocol is empty, district and isp cannot have parameter mutual exclusion at the same time")
+			return cdnerr.New(cdnerr.ErrInvalidParameter, types.TencentSdkName, "when the ip protocol is empty, district and isp cannot have parameter mutual exclusion at the same time")
 		}
 	case req.IpProtocol != nil:
 		if req.District != nil || req.Isp != nil {
-			return errors.New("when the ip protocol is not empty, district and isp cannot have parameter mutual exclusion at the same time")
+			return cdnerr.New(cdnerr.ErrInvalidParameter, types.TencentSdkName, "when the ip protocol is not empty, district and isp cannot have parameter mutual exclusion at the same time")
 		}
 	}
 	return nil
@@ -1301,6 +1456,11 @@ func (t *Tencent) verifyMutualExclusion(req *types.DomainAccessDataStaticRequest
 
 // ListTopUrlDataStatic 获取TOP URL数据统计
 func (t *Tencent) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return t.ListTopUrlDataStaticWithContext(t.ctx, req)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL数据统计
+func (t *Tencent) ListTopUrlDataStaticWithContext(ctx context.Context, req *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
 	request := tencentsdk.NewListTopDataRequest()
 	request.StartTime = common.StringPtr(utils.FormatTimeWithTimezone(req.StartTime, "Asia/Shanghai"))
 	request.EndTime = common.StringPtr(utils.FormatTimeWithTimezone(req.EndTime, "Asia/Shanghai"))
@@ -1310,7 +1470,9 @@ func (t *Tencent) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) (
 	request.Filter = common.StringPtr(getTopUrlFilter(req.Filter))
 	request.Detail = common.BoolPtr(false)
 	request.Product = common.StringPtr(getProductType(req.Product))
-	response, err := t.client.ListTopData(request)
+	response, err := invoke(ctx, t, ratelimit.ClassStats, retry.Idempotent, func() (*tencentsdk.ListTopDataResponse, error) {
+		return t.client.ListTopDataWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1328,6 +1490,11 @@ func (t *Tencent) ListTopUrlDataStatic(req *types.ListTopUrlDataStaticRequest) (
 
 // DomainAccessTotalData 获取域名总流量
 func (t *Tencent) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return t.DomainAccessTotalDataWithContext(t.ctx, req)
+}
+
+// DomainAccessTotalDataWithContext 获取域名总流量
+func (t *Tencent) DomainAccessTotalDataWithContext(ctx context.Context, req *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
 	request := tencentsdk.NewDescribeCdnDataRequest()
 	if req.TimeZone == nil {
 		req.TimeZone = common.StringPtr("Asia/Shanghai")
@@ -1348,14 +1515,16 @@ func (t *Tencent) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest)
 		request.DataSource = nil
 	}
 	if strings.Contains(*request.Metric, "xx") {
-		return nil, errors.New("status code not support")
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.TencentSdkName, "status code not support")
 	}
-	response, err := t.client.DescribeCdnData(request)
+	response, err := invoke(ctx, t, ratelimit.ClassStats, retry.Idempotent, func() (*tencentsdk.DescribeCdnDataResponse, error) {
+		return t.client.DescribeCdnDataWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if len(response.Response.Data) == 0 {
-		return nil, errors.New("data not found")
+		return nil, newNotFoundError("data not found", utils.StringValue(response.Response.RequestId))
 	}
 	responseData := make(types.DataTotalDataResponse)
 	for _, v := range response.Response.Data {
@@ -1370,6 +1539,11 @@ func (t *Tencent) DomainAccessTotalData(req *types.DomainAccessTotalDataRequest)
 
 // DomainOriginTotalData 获取域名回源总流量
 func (t *Tencent) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return t.DomainOriginTotalDataWithContext(t.ctx, req)
+}
+
+// DomainOriginTotalDataWithContext 获取域名回源总流量
+func (t *Tencent) DomainOriginTotalDataWithContext(ctx context.Context, req *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
 	request := tencentsdk.NewDescribeOriginDataRequest()
 	if req.TimeZone == nil {
 		req.TimeZone = common.StringPtr("Asia/Shanghai")
@@ -1383,9 +1557,11 @@ func (t *Tencent) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest)
 	request.TimeZone = common.StringPtr(convertTimeZone(*req.TimeZone))
 	request.Detail = common.BoolPtr(true)
 	if strings.Contains(*request.Metric, "xx") {
-		return nil, errors.New("status code not support")
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.TencentSdkName, "status code not support")
 	}
-	response, err := t.client.DescribeOriginData(request)
+	response, err := invoke(ctx, t, ratelimit.ClassStats, retry.Idempotent, func() (*tencentsdk.DescribeOriginDataResponse, error) {
+		return t.client.DescribeOriginDataWithContext(ctx, request)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1402,6 +1578,11 @@ func (t *Tencent) DomainOriginTotalData(req *types.DomainOriginTotalDataRequest)
 
 // UserAccessRegionDistribution 获取用户访问区域分布
 func (t *Tencent) UserAccessRegionDistribution(req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return t.UserAccessRegionDistributionWithContext(t.ctx, req)
+}
+
+// UserAccessRegionDistributionWithContext 获取用户访问区域分布
+func (t *Tencent) UserAccessRegionDistributionWithContext(ctx context.Context, req *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
 	request := tencentsdk.NewListTopDataRequest()
 	request.StartTime = common.StringPtr(utils.FormatTimeWithTimezone(req.StartTime, "Asia/Shanghai"))
 	request.EndTime = common.StringPtr(utils.FormatTimeWithTimezone(req.EndTime, "Asia/Shanghai"))
@@ -1411,7 +1592,9 @@ func (t *Tencent) UserAccessRegionDistribution(req *types.UserAccessRegionDistri
 	request.Filter = common.StringPtr(getDataAccessMetricType(req.Metric))
 	request.Detail = common.BoolPtr(true)
 	request.Product = common.StringPtr(getProductType(req.Product))
-	response, err := t.client.ListTopData(request)
+	response, err := invoke(ctx, t, ratelimit.ClassStats, retry.Idempotent, func() (*tencentsdk.ListTopDataResponse, error) {
+		return t.client.ListTopDataWithContext(ctx, request)
+	})
 	responseData := make(types.UserAccessRegionDistributionResponse)
 	if err != nil {
 		return responseData, err
@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine/common/model"
+	"net/http"
+)
+
+const (
+	DefaultEndpoint = "https://cdn.volcengineapi.com"
+	Version         = "2021-03-01"
+)
+
+type Client struct {
+	rest *rest.Client
+}
+
+func NewClient(signer *Signer, endpoint string) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	client := rest.NewClient(endpoint, signer)
+	//火山引擎业务错误同样以200状态码返回,错误信息在ResponseMetadata.Error中
+	client.ErrorDecoder = rest.NewErrorDecoder(rest.ErrorFields{
+		RequestId: []string{"ResponseMetadata", "RequestId"},
+		Code:      []string{"ResponseMetadata", "Error", "Code"},
+		Message:   []string{"ResponseMetadata", "Error", "Message"},
+		BodyError: true,
+	})
+	return &Client{
+		rest: client,
+	}
+}
+
+// WithHttpClient 设置发送请求使用的HTTP客户端,为空时使用http.DefaultClient
+func (c *Client) WithHttpClient(client *http.Client) *Client {
+	c.rest.HttpClient = client
+	return c
+}
+
+// call 调用OpenAPI接口,所有接口均以POST提交json请求体
+func call[T any](ctx context.Context, c *Client, action string, req interface{}) (*T, error) {
+	r := rest.NewRequest(http.MethodPost, "/").
+		WithQuery("Action", action).
+		WithQuery("Version", Version).
+		WithBody(req)
+	res, err := rest.Do[model.Response[T]](ctx, c.rest, r)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Result, nil
+}
+
+// AddCdnDomain 添加加速域名
+func (c *Client) AddCdnDomain(ctx context.Context, req *model.AddCdnDomainRequest) (*model.CommonResult, error) {
+	return call[model.CommonResult](ctx, c, "AddCdnDomain", req)
+}
+
+// ListCdnDomains 查询加速域名列表
+func (c *Client) ListCdnDomains(ctx context.Context, req *model.ListCdnDomainsRequest) (*model.ListCdnDomainsResult, error) {
+	return call[model.ListCdnDomainsResult](ctx, c, "ListCdnDomains", req)
+}
+
+// DescribeCdnConfig 查询域名配置
+func (c *Client) DescribeCdnConfig(ctx context.Context, domain string) (*model.DescribeCdnConfigResult, error) {
+	return call[model.DescribeCdnConfigResult](ctx, c, "DescribeCdnConfig", &model.DomainRequest{Domain: domain})
+}
+
+// UpdateCdnConfig 修改域名配置,只提交的配置项会被修改
+func (c *Client) UpdateCdnConfig(ctx context.Context, req *model.DomainConfig) (*model.CommonResult, error) {
+	return call[model.CommonResult](ctx, c, "UpdateCdnConfig", req)
+}
+
+// StartCdnDomain 启用加速域名
+func (c *Client) StartCdnDomain(ctx context.Context, domain string) (*model.CommonResult, error) {
+	return call[model.CommonResult](ctx, c, "StartCdnDomain", &model.DomainRequest{Domain: domain})
+}
+
+// StopCdnDomain 停用加速域名
+func (c *Client) StopCdnDomain(ctx context.Context, domain string) (*model.CommonResult, error) {
+	return call[model.CommonResult](ctx, c, "StopCdnDomain", &model.DomainRequest{Domain: domain})
+}
+
+// DeleteCdnDomain 删除加速域名
+func (c *Client) DeleteCdnDomain(ctx context.Context, domain string) (*model.CommonResult, error) {
+	return call[model.CommonResult](ctx, c, "DeleteCdnDomain", &model.DomainRequest{Domain: domain})
+}
+
+// AddCertificate 上传证书
+func (c *Client) AddCertificate(ctx context.Context, req *model.AddCertificateRequest) (*model.AddCertificateResult, error) {
+	return call[model.AddCertificateResult](ctx, c, "AddCertificate", req)
+}
+
+// SubmitRefreshTask 提交刷新任务
+func (c *Client) SubmitRefreshTask(ctx context.Context, req *model.SubmitRefreshTaskRequest) (*model.SubmitTaskResult, error) {
+	return call[model.SubmitTaskResult](ctx, c, "SubmitRefreshTask", req)
+}
+
+// SubmitPreloadTask 提交预热任务
+func (c *Client) SubmitPreloadTask(ctx context.Context, req *model.SubmitPreloadTaskRequest) (*model.SubmitTaskResult, error) {
+	return call[model.SubmitTaskResult](ctx, c, "SubmitPreloadTask", req)
+}
+
+// DescribeContentTasks 查询刷新预热任务
+func (c *Client) DescribeContentTasks(ctx context.Context, req *model.DescribeContentTasksRequest) (*model.DescribeContentTasksResult, error) {
+	return call[model.DescribeContentTasksResult](ctx, c, "DescribeContentTasks", req)
+}
+
+// DescribeCdnData 查询访问统计数据
+func (c *Client) DescribeCdnData(ctx context.Context, req *model.DescribeCdnDataRequest) (*model.DescribeCdnDataResult, error) {
+	return call[model.DescribeCdnDataResult](ctx, c, "DescribeCdnData", req)
+}
+
+// DescribeCdnOriginData 查询回源统计数据
+func (c *Client) DescribeCdnOriginData(ctx context.Context, req *model.DescribeCdnDataRequest) (*model.DescribeCdnDataResult, error) {
+	return call[model.DescribeCdnDataResult](ctx, c, "DescribeCdnOriginData", req)
+}
+
+// DescribeEdgeTopStatisticalData 查询TOP排行数据
+func (c *Client) DescribeEdgeTopStatisticalData(ctx context.Context, req *model.DescribeEdgeTopStatisticalDataRequest) (*model.DescribeEdgeTopStatisticalDataResult, error) {
+	return call[model.DescribeEdgeTopStatisticalDataResult](ctx, c, "DescribeEdgeTopStatisticalData", req)
+}
@@ -0,0 +1,23 @@
+package model
+
+type ResponseMetadata struct {
+	RequestId string `json:"RequestId"`
+	Action    string `json:"Action"`
+	Version   string `json:"Version"`
+	Service   string `json:"Service"`
+	Region    string `json:"Region"`
+	Error     *Error `json:"Error,omitempty"`
+}
+
+type Error struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+// Response 火山引擎OpenAPI统一响应,业务数据在Result中
+type Response[T any] struct {
+	ResponseMetadata ResponseMetadata `json:"ResponseMetadata"`
+	Result           T                `json:"Result"`
+}
+
+type CommonResult struct{}
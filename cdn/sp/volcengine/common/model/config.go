@@ -0,0 +1,191 @@
+package model
+
+type Switch struct {
+	Switch bool `json:"Switch"`
+}
+
+type AccessRule struct {
+	Switch bool `json:"Switch"`
+	//规则类型：allow、deny
+	RuleType string `json:"RuleType,omitempty"`
+	//是否允许空值,用于Referer与UA
+	AllowEmpty *bool    `json:"AllowEmpty,omitempty"`
+	Values     []string `json:"Values,omitempty"`
+}
+
+type IpFreqLimit struct {
+	Switch bool `json:"Switch"`
+	//每秒请求数上限
+	Frequency int64 `json:"Frequency,omitempty"`
+}
+
+type DownloadSpeedLimit struct {
+	Switch bool `json:"Switch"`
+	//限速值,单位KB/s
+	SpeedLimitRate int64 `json:"SpeedLimitRate,omitempty"`
+}
+
+type SignedUrlAuthAction struct {
+	//鉴权类型：typea、typeb、typec、typed
+	AuthType        string `json:"AuthType"`
+	MasterSecretKey string `json:"MasterSecretKey"`
+	BackupSecretKey string `json:"BackupSecretKey,omitempty"`
+	SignatureParam  string `json:"SignatureParam,omitempty"`
+	TimeParam       string `json:"TimeParam,omitempty"`
+	//有效时长,单位秒
+	Duration int64 `json:"Duration,omitempty"`
+}
+
+type SignedUrlAuth struct {
+	Switch              bool                 `json:"Switch"`
+	SignedUrlAuthAction *SignedUrlAuthAction `json:"SignedUrlAuthAction,omitempty"`
+}
+
+type ConditionRule struct {
+	//匹配类型：url、path、filetype、directory
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+type Condition struct {
+	ConditionRule []*ConditionRule `json:"ConditionRule"`
+	//条件关系：and、or
+	Connective string `json:"Connective,omitempty"`
+}
+
+type CacheAction struct {
+	//缓存动作：cache、no-cache
+	Action string `json:"Action"`
+	//缓存时间,单位秒
+	Ttl int64 `json:"Ttl"`
+	//默认策略：force_cache、follow_origin
+	DefaultPolicy string `json:"DefaultPolicy,omitempty"`
+}
+
+type CacheRule struct {
+	CacheAction *CacheAction `json:"CacheAction"`
+	Condition   *Condition   `json:"Condition,omitempty"`
+}
+
+type NegativeCacheRule struct {
+	StatusCode string `json:"StatusCode"`
+	Action     string `json:"Action"`
+	Ttl        int64  `json:"Ttl"`
+}
+
+type NegativeCache struct {
+	NegativeCacheRule *NegativeCacheRule `json:"NegativeCacheRule"`
+}
+
+type ErrorPageAction struct {
+	StatusCode string `json:"StatusCode"`
+	//跳转状态码：301、302
+	RedirectCode string `json:"RedirectCode"`
+	RedirectUrl  string `json:"RedirectUrl"`
+	Action       string `json:"Action"`
+}
+
+type CustomErrorPage struct {
+	Switch        bool               `json:"Switch"`
+	ErrorPageRule []*ErrorPageAction `json:"ErrorPageRule"`
+}
+
+type Compression struct {
+	Switch bool `json:"Switch"`
+	//压缩方式：gzip、br
+	CompressionType []string `json:"CompressionType,omitempty"`
+}
+
+type HeaderInstance struct {
+	//操作类型：set、delete
+	Action    string `json:"Action"`
+	Key       string `json:"Key"`
+	Value     string `json:"Value"`
+	ValueType string `json:"ValueType"`
+}
+
+type ForcedRedirect struct {
+	EnableForcedRedirect bool `json:"EnableForcedRedirect"`
+	//跳转状态码：301、302
+	StatusCode string `json:"StatusCode,omitempty"`
+}
+
+type Hsts struct {
+	Switch bool  `json:"Switch"`
+	Ttl    int64 `json:"Ttl,omitempty"`
+	//是否包含子域名：include、exclude
+	Subdomain string `json:"Subdomain,omitempty"`
+}
+
+type CertInfo struct {
+	CertId string `json:"CertId"`
+}
+
+type Https struct {
+	Switch   bool      `json:"Switch"`
+	CertInfo *CertInfo `json:"CertInfo,omitempty"`
+	//是否停用http访问
+	DisableHttp    bool            `json:"DisableHttp"`
+	Http2          bool            `json:"HTTP2"`
+	Ocsp           bool            `json:"OCSP"`
+	Quic           *Switch         `json:"Quic,omitempty"`
+	ForcedRedirect *ForcedRedirect `json:"ForcedRedirect,omitempty"`
+	Hsts           *Hsts           `json:"HSTS,omitempty"`
+	//TLS版本：tlsv1.0、tlsv1.1、tlsv1.2、tlsv1.3
+	TlsVersion []string `json:"TlsVersion,omitempty"`
+}
+
+type Timeout struct {
+	Switch bool `json:"Switch"`
+	//回源超时时间,单位秒
+	HttpTimeout int64 `json:"HttpTimeout,omitempty"`
+	//tcp连接超时时间,单位秒
+	TcpTimeout int64 `json:"TcpTimeout,omitempty"`
+}
+
+// DomainConfig 域名配置,查询时返回全部配置,修改时只提交需更新的配置项
+type DomainConfig struct {
+	Domain              string              `json:"Domain"`
+	Cname               string              `json:"Cname,omitempty"`
+	Status              string              `json:"Status,omitempty"`
+	ServiceType         string              `json:"ServiceType,omitempty"`
+	ServiceRegion       string              `json:"ServiceRegion,omitempty"`
+	CreateTime          int64               `json:"CreateTime,omitempty"`
+	UpdateTime          int64               `json:"UpdateTime,omitempty"`
+	Origin              []*OriginRule       `json:"Origin,omitempty"`
+	OriginProtocol      string              `json:"OriginProtocol,omitempty"`
+	OriginHost          string              `json:"OriginHost,omitempty"`
+	OriginRange         *bool               `json:"OriginRange,omitempty"`
+	FollowRedirect      *bool               `json:"FollowRedirect,omitempty"`
+	Timeout             *Timeout            `json:"Timeout,omitempty"`
+	OriginRequestHeader []*HeaderInstance   `json:"OriginRequestHeader,omitempty"`
+	IPv6                *Switch             `json:"IPv6,omitempty"`
+	IpAccessRule        *AccessRule         `json:"IpAccessRule,omitempty"`
+	IpFreqLimit         *IpFreqLimit        `json:"IpFreqLimit,omitempty"`
+	RefererAccessRule   *AccessRule         `json:"RefererAccessRule,omitempty"`
+	UaAccessRule        *AccessRule         `json:"UaAccessRule,omitempty"`
+	DownloadSpeedLimit  *DownloadSpeedLimit `json:"DownloadSpeedLimit,omitempty"`
+	SignedUrlAuth       *SignedUrlAuth      `json:"SignedUrlAuth,omitempty"`
+	Cache               []*CacheRule        `json:"Cache,omitempty"`
+	NegativeCache       []*NegativeCache    `json:"NegativeCache,omitempty"`
+	CustomErrorPage     *CustomErrorPage    `json:"CustomErrorPage,omitempty"`
+	Compression         *Compression        `json:"Compression,omitempty"`
+	ResponseHeader      []*HeaderInstance   `json:"ResponseHeader,omitempty"`
+	Https               *Https              `json:"HTTPS,omitempty"`
+}
+
+type Certificate struct {
+	Certificate string `json:"Certificate"`
+	PrivateKey  string `json:"PrivateKey"`
+}
+
+type AddCertificateRequest struct {
+	Certificate *Certificate `json:"Certificate"`
+	Desc        string       `json:"Desc,omitempty"`
+	//证书托管位置：cdn_cert_hosting、volc_cert_center
+	Source string `json:"Source"`
+}
+
+type AddCertificateResult struct {
+	CertId string `json:"CertId"`
+}
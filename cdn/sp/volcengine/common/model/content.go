@@ -0,0 +1,44 @@
+package model
+
+type SubmitRefreshTaskRequest struct {
+	//刷新类型：file、dir
+	Type string `json:"Type"`
+	//多个url以换行分隔
+	UrlList string `json:"UrlList"`
+}
+
+type SubmitPreloadTaskRequest struct {
+	//多个url以换行分隔
+	UrlList string `json:"UrlList"`
+}
+
+type SubmitTaskResult struct {
+	TaskID string `json:"TaskID"`
+}
+
+type DescribeContentTasksRequest struct {
+	TaskID string `json:"TaskID,omitempty"`
+	//任务类型：refresh_file、refresh_dir、preload
+	TaskType string `json:"TaskType"`
+	//任务状态：complete、running、failed
+	Status    string `json:"Status,omitempty"`
+	StartTime int64  `json:"StartTime,omitempty"`
+	EndTime   int64  `json:"EndTime,omitempty"`
+	PageNum   int64  `json:"PageNum"`
+	PageSize  int64  `json:"PageSize"`
+}
+
+type ContentTask struct {
+	TaskID     string `json:"TaskID"`
+	TaskType   string `json:"TaskType"`
+	Url        string `json:"Url"`
+	Status     string `json:"Status"`
+	CreateTime int64  `json:"CreateTime"`
+}
+
+type DescribeContentTasksResult struct {
+	Data     []*ContentTask `json:"Data"`
+	Total    int64          `json:"Total"`
+	PageNum  int64          `json:"PageNum"`
+	PageSize int64          `json:"PageSize"`
+}
@@ -0,0 +1,68 @@
+package model
+
+type OriginLine struct {
+	//源站类型：primary、backup
+	OriginType string `json:"OriginType"`
+	//源站地址类型：ip、domain
+	InstanceType string `json:"InstanceType"`
+	Address      string `json:"Address"`
+	HttpPort     string `json:"HttpPort,omitempty"`
+	HttpsPort    string `json:"HttpsPort,omitempty"`
+	//回源host,为空时使用域名配置的回源host
+	OriginHost string `json:"OriginHost,omitempty"`
+	//权重,取值1-100
+	Weight string `json:"Weight,omitempty"`
+}
+
+type OriginAction struct {
+	OriginLines []*OriginLine `json:"OriginLines"`
+}
+
+type OriginRule struct {
+	OriginAction *OriginAction `json:"OriginAction"`
+}
+
+type AddCdnDomainRequest struct {
+	Domain string `json:"Domain"`
+	//业务类型：web、download、video
+	ServiceType string `json:"ServiceType"`
+	//加速区域：chinese_mainland、global、outside_chinese_mainland
+	ServiceRegion string        `json:"ServiceRegion"`
+	Origin        []*OriginRule `json:"Origin"`
+	//回源协议：http、https、followclient
+	OriginProtocol string `json:"OriginProtocol"`
+	OriginHost     string `json:"OriginHost,omitempty"`
+}
+
+type DomainRequest struct {
+	Domain string `json:"Domain"`
+}
+
+type ListCdnDomainsRequest struct {
+	Domain string `json:"Domain,omitempty"`
+	//域名状态：online、offline、configuring、configure_failed、stopping、deleting
+	Status   string `json:"Status,omitempty"`
+	PageNum  int64  `json:"PageNum"`
+	PageSize int64  `json:"PageSize"`
+}
+
+type DomainSummary struct {
+	Domain        string `json:"Domain"`
+	Status        string `json:"Status"`
+	Cname         string `json:"Cname"`
+	ServiceType   string `json:"ServiceType"`
+	ServiceRegion string `json:"ServiceRegion"`
+	CreateTime    int64  `json:"CreateTime"`
+	UpdateTime    int64  `json:"UpdateTime"`
+}
+
+type ListCdnDomainsResult struct {
+	Data     []*DomainSummary `json:"Data"`
+	Total    int64            `json:"Total"`
+	PageNum  int64            `json:"PageNum"`
+	PageSize int64            `json:"PageSize"`
+}
+
+type DescribeCdnConfigResult struct {
+	DomainConfig *DomainConfig `json:"DomainConfig"`
+}
@@ -0,0 +1,56 @@
+package model
+
+type DescribeCdnDataRequest struct {
+	StartTime int64 `json:"StartTime"`
+	EndTime   int64 `json:"EndTime"`
+	//指标：flux、bandwidth、request
+	Metric string `json:"Metric"`
+	//多个域名以逗号分隔
+	Domain string `json:"Domain,omitempty"`
+	//时间粒度：5min、hour、day
+	Interval string `json:"Interval"`
+	//区域：China、Overseas
+	Area string `json:"Area,omitempty"`
+	//聚合维度,domain表示按域名返回
+	Aggregate string `json:"Aggregate,omitempty"`
+}
+
+type DataValue struct {
+	Timestamp int64   `json:"Timestamp"`
+	Value     float64 `json:"Value"`
+}
+
+type MetricValue struct {
+	Metric string       `json:"Metric"`
+	Values []*DataValue `json:"Values"`
+}
+
+type Resource struct {
+	Name    string         `json:"Name"`
+	Metrics []*MetricValue `json:"Metrics"`
+}
+
+type DescribeCdnDataResult struct {
+	Resources []*Resource `json:"Resources"`
+}
+
+type DescribeEdgeTopStatisticalDataRequest struct {
+	Domain    string `json:"Domain"`
+	StartTime int64  `json:"StartTime"`
+	EndTime   int64  `json:"EndTime"`
+	//排行维度：url
+	Item string `json:"Item"`
+	//指标：flux、request
+	Metric string `json:"Metric"`
+	//区域：China、Overseas
+	Area string `json:"Area,omitempty"`
+}
+
+type TopDataDetail struct {
+	ItemKey string  `json:"ItemKey"`
+	Value   float64 `json:"Value"`
+}
+
+type DescribeEdgeTopStatisticalDataResult struct {
+	TopDataDetails []*TopDataDetail `json:"TopDataDetails"`
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	Algorithm     = "HMAC-SHA256"
+	Region        = "cn-north-1"
+	Service       = "cdn"
+	TimeFormat    = "20060102T150405Z"
+	DateFormat    = "20060102"
+	XDate         = "X-Date"
+	XContentSha   = "X-Content-Sha256"
+	Authorization = "Authorization"
+)
+
+// Signer 火山引擎HMAC-SHA256签名,实现rest.Signer
+type Signer struct {
+	AccessKey string
+	SecretKey string
+	Region    string
+	Service   string
+}
+
+func NewSigner(accessKey string, secretKey string) *Signer {
+	return &Signer{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Region:    Region,
+		Service:   Service,
+	}
+}
+
+// Sign 生成Authorization头部,签名头部为content-type、host、x-content-sha256与x-date
+func (s *Signer) Sign(req *http.Request, body []byte, t time.Time) error {
+	t = t.UTC()
+	xDate := t.Format(TimeFormat)
+	payloadHash := sha256Hex(body)
+	req.Header.Set(XDate, xDate)
+	req.Header.Set(XContentSha, payloadHash)
+	headers, signedHeaders := canonicalHeaders(req)
+	path := req.URL.Path
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		headers,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{t.Format(DateFormat), s.Region, s.Service, "request"}, "/")
+	stringToSign := strings.Join([]string{Algorithm, xDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	key := hmacSha256([]byte(s.SecretKey), t.Format(DateFormat))
+	key = hmacSha256(key, s.Region)
+	key = hmacSha256(key, s.Service)
+	key = hmacSha256(key, "request")
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+	req.Header.Set(Authorization, Algorithm+" Credential="+s.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+	return nil
+}
+
+// canonicalQuery 查询参数按名称排序,空格编码为%20
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// canonicalHeaders 获取参与签名的头部及头部名称列表,每个头部以换行结尾
+func canonicalHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.Host}
+	for _, k := range []string{"Content-Type", XDate, XContentSha} {
+		if v := strings.TrimSpace(req.Header.Get(k)); v != "" {
+			headers[strings.ToLower(k)] = v
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var build strings.Builder
+	for _, k := range names {
+		build.WriteString(k + ":" + headers[k] + "\n")
+	}
+	return build.String(), strings.Join(names, ";")
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
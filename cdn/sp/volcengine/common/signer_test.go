@@ -0,0 +1,62 @@
+package common
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignGolden(t *testing.T) {
+	body := `{"Domain":"www.example.com"}`
+	req, err := http.NewRequest(http.MethodPost, "https://cdn.volcengineapi.com/?Action=DescribeCdnConfig&Version=2021-03-01", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	//非UTC时间需转换为UTC,日期以UTC为准
+	ts := time.Date(2024, 1, 2, 11, 4, 5, 0, time.FixedZone("CST", 8*3600))
+	if err := NewSigner("AKLTexample", "c2VjcmV0").Sign(req, []byte(body), ts); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get(XDate); got != "20240102T030405Z" {
+		t.Fatalf("got %s %s", XDate, got)
+	}
+	if got := req.Header.Get(XContentSha); got != "e2cee24e39b7ed468550269fa94b11b84732ea770561b4961df40b59e17dffc7" {
+		t.Fatalf("got %s %s", XContentSha, got)
+	}
+	//Accept不参与签名
+	headers, signedHeaders := canonicalHeaders(req)
+	wantHeaders := "content-type:application/json\nhost:cdn.volcengineapi.com\n" +
+		"x-content-sha256:e2cee24e39b7ed468550269fa94b11b84732ea770561b4961df40b59e17dffc7\nx-date:20240102T030405Z\n"
+	if headers != wantHeaders || signedHeaders != "content-type;host;x-content-sha256;x-date" {
+		t.Fatalf("got canonical headers\n%s\n%s", headers, signedHeaders)
+	}
+	want := "HMAC-SHA256 Credential=AKLTexample/20240102/cn-north-1/cdn/request, SignedHeaders=content-type;host;x-content-sha256;x-date, " +
+		"Signature=ded01191c3ec930cb2c61db4b57d29e371abb51a654ab8d42cdd1d9e0f3841f5"
+	if got := req.Header.Get(Authorization); got != want {
+		t.Fatalf("got authorization\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSignEmptyBody(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://cdn.volcengineapi.com?Version=2021-03-01&Action=ListCdnDomains&Domain=a+b", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewSigner("AKLTexample", "c2VjcmV0").Sign(req, nil, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	//空请求体的sha256,空格编码为%20
+	if got := req.Header.Get(XContentSha); got != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Fatalf("got %s %s", XContentSha, got)
+	}
+	if got, want := canonicalQuery(req.URL.Query()), "Action=ListCdnDomains&Domain=a%20b&Version=2021-03-01"; got != want {
+		t.Fatalf("got canonical query %s, want %s", got, want)
+	}
+	want := "HMAC-SHA256 Credential=AKLTexample/20240102/cn-north-1/cdn/request, SignedHeaders=host;x-content-sha256;x-date, Signature=59455217f4752a3286da9d87f99a4567598171e725b5738aa7543f12d572a577"
+	if got := req.Header.Get(Authorization); got != want {
+		t.Fatalf("got authorization\n%s\nwant\n%s", got, want)
+	}
+}
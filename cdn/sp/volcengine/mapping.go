@@ -0,0 +1,355 @@
+package volcengine
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine/common/model"
+	"strconv"
+	"strings"
+)
+
+const (
+	refreshTypeFile    = "file"
+	refreshTypeDir     = "dir"
+	taskTypeRefreshUrl = "refresh_file"
+	taskTypeRefreshDir = "refresh_dir"
+	taskTypePreload    = "preload"
+	metricFlux         = "flux"
+	metricBandwidth    = "bandwidth"
+	metricRequest      = "request"
+	areaChina          = "China"
+	areaOverseas       = "Overseas"
+)
+
+// GetServiceType 业务类型转火山引擎业务类型,全站加速按网页处理
+func getServiceType(channelType int64) string {
+	switch channelType {
+	case consts.ChannelTypeWeb, consts.ChannelTypeHybrid:
+		return "web"
+	case consts.ChannelTypeDownload:
+		return "download"
+	case consts.ChannelTypeMedia:
+		return "video"
+	default:
+		return "web"
+	}
+}
+
+// MapServiceType 火山引擎业务类型转业务类型
+func mapServiceType(serviceType string) int64 {
+	switch serviceType {
+	case "web":
+		return consts.ChannelTypeWeb
+	case "download":
+		return consts.ChannelTypeDownload
+	case "video":
+		return consts.ChannelTypeMedia
+	default:
+		return consts.ChannelTypeWeb
+	}
+}
+
+// GetServiceRegion 加速区域转火山引擎加速区域
+func getServiceRegion(areaCode int64) string {
+	switch areaCode {
+	case consts.AreaCodeChinaMainland:
+		return "chinese_mainland"
+	case consts.AreaCodeOversea:
+		return "outside_chinese_mainland"
+	case consts.AreaCodeGlobal:
+		return "global"
+	default:
+		return "chinese_mainland"
+	}
+}
+
+// MapServiceRegion 火山引擎加速区域转加速区域
+func mapServiceRegion(region string) int64 {
+	switch region {
+	case "chinese_mainland":
+		return consts.AreaCodeChinaMainland
+	case "outside_chinese_mainland":
+		return consts.AreaCodeOversea
+	case "global":
+		return consts.AreaCodeGlobal
+	default:
+		return consts.AreaCodeChinaMainland
+	}
+}
+
+// GetDomainStatus 获取域名状态
+func getDomainStatus(status string) int64 {
+	switch status {
+	case "online":
+		return consts.CdnDomainStatusDeployed
+	case "offline":
+		return consts.CdnDomainStatusStoped
+	case "configuring":
+		return consts.CdnDomainStatusDeploying
+	case "configure_failed":
+		return consts.CdnDomainStatusFaild
+	case "stopping":
+		return consts.CdnDomainStatusStoping
+	case "deleting":
+		return consts.CdnDomainStatusDeleting
+	default:
+		return consts.CdnDomainStatusDeploying
+	}
+}
+
+// SetDomainStatus 域名状态转火山引擎域名状态
+func setDomainStatus(status int64) string {
+	switch status {
+	case consts.CdnDomainStatusDeployed:
+		return "online"
+	case consts.CdnDomainStatusStoped:
+		return "offline"
+	case consts.CdnDomainStatusDeploying:
+		return "configuring"
+	case consts.CdnDomainStatusFaild:
+		return "configure_failed"
+	case consts.CdnDomainStatusStoping:
+		return "stopping"
+	case consts.CdnDomainStatusDeleting:
+		return "deleting"
+	default:
+		return ""
+	}
+}
+
+// GetOriginProtocol 获取回源协议
+func getOriginProtocol(protocol int64) string {
+	switch protocol {
+	case consts.OriginProtocolHttp:
+		return "http"
+	case consts.OriginProtocolHttps:
+		return "https"
+	case consts.OriginProtocolFollow:
+		return "followclient"
+	default:
+		return "http"
+	}
+}
+
+// GetOriginType 获取源站地址类型
+func getOriginType(originType int64) string {
+	if originType == consts.OriginTypeIp {
+		return "ip"
+	}
+	return "domain"
+}
+
+// GetOriginPriority 获取源站优先级
+func getOriginPriority(priority int64) string {
+	if priority == consts.OriginPriorityBackup {
+		return "backup"
+	}
+	return "primary"
+}
+
+// GetPort 端口转字符串,未设置时为空
+func getPort(port int64) string {
+	if port <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(port, 10)
+}
+
+// GetOrigins 源站信息转火山引擎源站,多个地址以逗号分隔
+func getOrigins(sources []*entity.OriginServerConf) []*model.OriginRule {
+	lines := make([]*model.OriginLine, 0, len(sources))
+	for _, v := range sources {
+		for _, address := range strings.Split(v.OriginAddressList, ",") {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				continue
+			}
+			line := &model.OriginLine{
+				OriginType:   getOriginPriority(v.OriginPriority),
+				InstanceType: getOriginType(v.OriginType),
+				Address:      address,
+				HttpPort:     getPort(v.OriginHttpPort),
+				HttpsPort:    getPort(v.OriginHttpsPort),
+				OriginHost:   v.OriginHost,
+			}
+			if v.OriginWeight > 0 {
+				line.Weight = strconv.FormatInt(v.OriginWeight, 10)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return []*model.OriginRule{{OriginAction: &model.OriginAction{OriginLines: lines}}}
+}
+
+// GetRuleType 黑白名单转火山引擎规则类型
+func getRuleType(listType int64) string {
+	if listType == consts.WhiteList {
+		return "allow"
+	}
+	return "deny"
+}
+
+// GetHeaderAction 获取头部操作类型,火山引擎添加与设置均为set
+func getHeaderAction(action int64) string {
+	if action == consts.OriginHeaderActionDelete {
+		return "delete"
+	}
+	return "set"
+}
+
+// GetAuthType 获取鉴权类型
+func getAuthType(manner int64) string {
+	switch manner {
+	case consts.AccessAuthMannerTypeA:
+		return "typea"
+	case consts.AccessAuthMannerTypeB:
+		return "typeb"
+	case consts.AccessAuthMannerTypeC:
+		return "typec"
+	case consts.AccessAuthMannerTypeD:
+		return "typed"
+	default:
+		return "typea"
+	}
+}
+
+// GetCacheRule 缓存规则转火山引擎缓存规则,同一规则的多项内容为或关系
+func getCacheRule(item *entity.CacheListItem) *model.CacheRule {
+	action := &model.CacheAction{Action: "cache", Ttl: cachettl.Seconds(item.CacheTTL, item.CacheUnit), DefaultPolicy: "force_cache"}
+	switch item.CacheStatus {
+	case consts.CacheStatusOff:
+		action.Action = "no-cache"
+		action.Ttl = 0
+	case consts.CacheStatusFollow:
+		action.DefaultPolicy = "follow_origin"
+	}
+	conditionType := ""
+	switch item.CacheType {
+	case consts.RuleTypeFileSuffix:
+		conditionType = "filetype"
+	case consts.RuleTypeDirectory:
+		conditionType = "directory"
+	case consts.RuleTypePath:
+		conditionType = "path"
+	default:
+		return &model.CacheRule{CacheAction: action}
+	}
+	rules := make([]*model.ConditionRule, 0, len(item.CacheContent))
+	for _, v := range item.CacheContent {
+		rules = append(rules, &model.ConditionRule{Type: conditionType, Value: v})
+	}
+	return &model.CacheRule{CacheAction: action, Condition: &model.Condition{ConditionRule: rules, Connective: "or"}}
+}
+
+// GetRedirectCode 获取跳转状态码
+func getRedirectCode(code int64) string {
+	if code == consts.RedirectCode301 {
+		return "301"
+	}
+	return "302"
+}
+
+// GetCompressionType 获取压缩方式
+func getCompressionType(method int64) string {
+	if method == consts.IntelligentCompressionCompressMethodBrotli {
+		return "br"
+	}
+	return "gzip"
+}
+
+// GetTlsVersion 获取TLS版本
+func getTlsVersion(version int64) string {
+	switch version {
+	case consts.HttpsTlsVersionSSLv0:
+		return "tlsv1.0"
+	case consts.HttpsTlsVersionSSLv1:
+		return "tlsv1.1"
+	case consts.HttpsTlsVersionSSLv2:
+		return "tlsv1.2"
+	case consts.HttpsTlsVersionTLSv3:
+		return "tlsv1.3"
+	default:
+		return ""
+	}
+}
+
+// GetShowContentPurgeOrPushStatus 获取刷新或预热任务状态
+func getShowContentPurgeOrPushStatus(status string) int64 {
+	switch status {
+	case "complete":
+		return consts.ShowContentPurgeOrPushStatusSuccess
+	case "running":
+		return consts.ShowContentPurgeOrPushStatusDoing
+	case "failed":
+		return consts.ShowContentPurgeOrPushStatusFail
+	default:
+		return consts.ShowContentPurgeOrPushStatusFail
+	}
+}
+
+// GetRefreshTaskType 获取刷新任务类型
+func getRefreshTaskType(t int64) string {
+	if t == consts.ShowContentPurgeTypePath {
+		return taskTypeRefreshDir
+	}
+	return taskTypeRefreshUrl
+}
+
+// GetInterval 获取统计粒度
+func getInterval(t int64) string {
+	switch t {
+	case consts.DataIntervalTypeFiveMinute:
+		return "5min"
+	case consts.DataIntervalTypeHour:
+		return "hour"
+	case consts.DataIntervalTypeDay:
+		return "day"
+	default:
+		return "5min"
+	}
+}
+
+// GetArea 获取统计区域
+func getArea(area int64) string {
+	if area == consts.AreaCodeOversea {
+		return areaOverseas
+	}
+	return areaChina
+}
+
+// GetDataAccessMetric 获取访问数据指标,不支持的指标返回空
+func getDataAccessMetric(t int64) string {
+	switch t {
+	case consts.DataAccessMetricTypeFlux:
+		return metricFlux
+	case consts.DataAccessMetricTypeBandwidth:
+		return metricBandwidth
+	case consts.DataAccessMetricTypeRequest:
+		return metricRequest
+	default:
+		return ""
+	}
+}
+
+// GetDataOriginMetric 获取回源数据指标,不支持的指标返回空
+func getDataOriginMetric(t int64) string {
+	switch t {
+	case consts.DataOriginMetricTypeFlux:
+		return metricFlux
+	case consts.DataOriginMetricTypeBandwidth:
+		return metricBandwidth
+	case consts.DataOriginMetricTypeRequest:
+		return metricRequest
+	default:
+		return ""
+	}
+}
+
+// GetTopMetric 获取TOP URL排序指标
+func getTopMetric(filter int64) string {
+	if filter == consts.ListTopFilterRequest {
+		return metricRequest
+	}
+	return metricFlux
+}
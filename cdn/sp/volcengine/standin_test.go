@@ -0,0 +1,236 @@
+package volcengine
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine/common/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// standIn 火山引擎CDN OpenAPI的本地替身,校验请求签名并在内存中保存域名配置
+type standIn struct {
+	*httptest.Server
+	signer  *common.Signer
+	mu      sync.Mutex
+	domains map[string]*model.DomainConfig
+	certs   map[string]*model.AddCertificateRequest
+	calls   []string
+	updates []*model.DomainConfig
+}
+
+func newStandIn(t *testing.T) (*standIn, *Volcengine) {
+	t.Helper()
+	s := &standIn{
+		signer:  common.NewSigner("ak", "sk"),
+		domains: make(map[string]*model.DomainConfig),
+		certs:   make(map[string]*model.AddCertificateRequest),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	v, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, v
+}
+
+// addDomain 添加已上线的域名
+func (s *standIn) addDomain(name string) *model.DomainConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := s.create(&model.AddCdnDomainRequest{Domain: name, ServiceType: "web", ServiceRegion: "chinese_mainland", OriginProtocol: "http"})
+	d.Status = "online"
+	return d
+}
+
+func (s *standIn) create(req *model.AddCdnDomainRequest) *model.DomainConfig {
+	d := &model.DomainConfig{
+		Domain:         req.Domain,
+		Cname:          req.Domain + ".volcgslb.com",
+		Status:         "configuring",
+		ServiceType:    req.ServiceType,
+		ServiceRegion:  req.ServiceRegion,
+		CreateTime:     1704038400,
+		UpdateTime:     1704124800,
+		Origin:         req.Origin,
+		OriginProtocol: req.OriginProtocol,
+		OriginHost:     req.OriginHost,
+	}
+	s.domains[req.Domain] = d
+	return d
+}
+
+// domain 获取域名配置
+func (s *standIn) domain(name string) *model.DomainConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.domains[name]
+}
+
+func (s *standIn) callList() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func (s *standIn) resetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+	s.updates = nil
+}
+
+// lastUpdate 最近一次UpdateCdnConfig提交的配置
+func (s *standIn) lastUpdate() *model.DomainConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.updates) == 0 {
+		return nil
+	}
+	return s.updates[len(s.updates)-1]
+}
+
+// verify 以请求中的X-Date重新签名
+func (s *standIn) verify(r *http.Request, body []byte) bool {
+	ts, err := time.Parse(common.TimeFormat, r.Header.Get(common.XDate))
+	if err != nil || time.Since(ts) > 5*time.Minute {
+		return false
+	}
+	req := r.Clone(context.Background())
+	req.Header.Del(common.Authorization)
+	if err := s.signer.Sign(req, body, ts); err != nil {
+		return false
+	}
+	return req.Header.Get(common.Authorization) == r.Header.Get(common.Authorization)
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	action := r.URL.Query().Get("Action")
+	s.calls = append(s.calls, action)
+	if !s.verify(r, body) {
+		fail(w, http.StatusUnauthorized, "SignatureDoesNotMatch")
+		return
+	}
+	if r.Method != http.MethodPost || r.URL.Query().Get("Version") != common.Version {
+		fail(w, http.StatusBadRequest, "InvalidActionOrVersion")
+		return
+	}
+	switch action {
+	case "AddCdnDomain":
+		req := &model.AddCdnDomainRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			fail(w, http.StatusBadRequest, "MalformedJSON")
+			return
+		}
+		if _, ok := s.domains[req.Domain]; ok {
+			fail(w, http.StatusOK, "InvalidDomain.AlreadyExist")
+			return
+		}
+		s.create(req)
+		reply(w, &model.CommonResult{})
+	case "ListCdnDomains":
+		req := &model.ListCdnDomainsRequest{}
+		_ = json.Unmarshal(body, req)
+		s.listDomains(w, req)
+	case "AddCertificate":
+		req := &model.AddCertificateRequest{}
+		if err := json.Unmarshal(body, req); err != nil || req.Certificate == nil {
+			fail(w, http.StatusOK, "InvalidCertificate")
+			return
+		}
+		id := "cert-" + strconv.Itoa(len(s.certs)+1)
+		s.certs[id] = req
+		reply(w, &model.AddCertificateResult{CertId: id})
+	default:
+		s.serveDomain(w, action, body)
+	}
+}
+
+// serveDomain 处理需指定已存在域名的接口,业务错误以200状态码返回
+func (s *standIn) serveDomain(w http.ResponseWriter, action string, body []byte) {
+	req := &model.DomainConfig{}
+	if err := json.Unmarshal(body, req); err != nil {
+		fail(w, http.StatusBadRequest, "MalformedJSON")
+		return
+	}
+	d, ok := s.domains[req.Domain]
+	if !ok {
+		fail(w, http.StatusOK, "InvalidDomain.NotFound")
+		return
+	}
+	switch action {
+	case "DescribeCdnConfig":
+		reply(w, &model.DescribeCdnConfigResult{DomainConfig: d})
+	case "UpdateCdnConfig":
+		if req.Https != nil && req.Https.CertInfo != nil && s.certs[req.Https.CertInfo.CertId] == nil {
+			fail(w, http.StatusOK, "InvalidCertInfo.NotFound")
+			return
+		}
+		s.updates = append(s.updates, req)
+		mergeConfig(d, req)
+		reply(w, &model.CommonResult{})
+	case "StartCdnDomain":
+		d.Status = "online"
+		reply(w, &model.CommonResult{})
+	case "StopCdnDomain":
+		d.Status = "offline"
+		reply(w, &model.CommonResult{})
+	case "DeleteCdnDomain":
+		if d.Status != "offline" {
+			fail(w, http.StatusOK, "InvalidDomain.Status")
+			return
+		}
+		delete(s.domains, req.Domain)
+		reply(w, &model.CommonResult{})
+	default:
+		fail(w, http.StatusBadRequest, "InvalidAction")
+	}
+}
+
+// mergeConfig 只修改请求中提交的配置项
+func mergeConfig(dst *model.DomainConfig, src *model.DomainConfig) {
+	dv, sv := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		if !sv.Field(i).IsZero() {
+			dv.Field(i).Set(sv.Field(i))
+		}
+	}
+}
+
+func (s *standIn) listDomains(w http.ResponseWriter, req *model.ListCdnDomainsRequest) {
+	res := &model.ListCdnDomainsResult{Data: make([]*model.DomainSummary, 0), PageNum: req.PageNum, PageSize: req.PageSize}
+	for _, v := range s.domains {
+		if req.Status != "" && v.Status != req.Status {
+			continue
+		}
+		res.Data = append(res.Data, &model.DomainSummary{Domain: v.Domain, Status: v.Status, Cname: v.Cname})
+	}
+	sort.Slice(res.Data, func(i, j int) bool { return res.Data[i].Domain < res.Data[j].Domain })
+	res.Total = int64(len(res.Data))
+	reply(w, res)
+}
+
+func fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&model.Response[*model.CommonResult]{
+		ResponseMetadata: model.ResponseMetadata{RequestId: "req-1", Error: &model.Error{Code: code, Message: code}},
+	})
+}
+
+func reply[T any](w http.ResponseWriter, v T) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&model.Response[T]{ResponseMetadata: model.ResponseMetadata{RequestId: "req-1"}, Result: v})
+}
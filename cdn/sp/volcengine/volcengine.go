@@ -0,0 +1,1061 @@
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine/common"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// listPageSize 域名列表与任务列表每页条数
+const listPageSize = 100
+
+type Volcengine struct {
+	ctx     context.Context
+	config  *Config
+	limiter *ratelimit.Limiter
+	client  *common.Client
+}
+
+type Config struct {
+	Ak        string
+	Sk        string
+	Endpoint  string              // 接口地址,默认https://cdn.volcengineapi.com
+	Retry     *retry.Policy       // 重试策略,为空时不重试
+	RateLimit *ratelimit.Limiter  // 限流器,每次调用厂商接口(含重试)前获取令牌,为空时使用ratelimit.DefaultLimits,不限制时传入ratelimit.Unlimited()
+	Http      *httpclient.Options // HTTP客户端选项,为空时使用默认客户端,超时为httpclient.DefaultTimeout
+	Logger    *slog.Logger        // 记录HTTP请求的日志,为空时不记录
+}
+
+// NewVolcengineSdkClient 创建火山引擎CDN客户端,创建失败时返回nil,需要错误信息时使用 New
+func NewVolcengineSdkClient(ctx context.Context, conf *Config) *Volcengine {
+	client, err := New(ctx, conf)
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// New 创建火山引擎CDN客户端
+func New(ctx context.Context, conf *Config) (*Volcengine, error) {
+	if conf == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.VolcengineSdkName, "config is nil")
+	}
+	if conf.Ak == "" || conf.Sk == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.VolcengineSdkName, "ak or sk is empty")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	httpClient, err := conf.Http.NewClient()
+	if err != nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.VolcengineSdkName, "invalid http options: "+err.Error())
+	}
+	httpClient.Transport = cdnlog.NewTransport(conf.Logger, httpClient.Transport)
+	return &Volcengine{
+		ctx:     ctx,
+		config:  conf,
+		limiter: ratelimit.OrDefault(conf.RateLimit, types.VolcengineSdkName),
+		client:  common.NewClient(common.NewSigner(conf.Ak, conf.Sk), conf.Endpoint).WithHttpClient(httpClient),
+	}, nil
+}
+
+func (v *Volcengine) GetSdkName() string {
+	return types.VolcengineSdkName
+}
+
+// invoke 调用厂商接口,每次尝试前按class获取限流令牌,转换错误并按重试策略重试
+func invoke[T any](ctx context.Context, v *Volcengine, class ratelimit.Class, idempotent bool, fn func() (T, error)) (T, error) {
+	return retry.Do(ctx, v.config.Retry, idempotent, func() (T, error) {
+		if err := v.limiter.Wait(ctx, class); err != nil {
+			var zero T
+			return zero, err
+		}
+		res, err := fn()
+		return res, wrapError(err)
+	})
+}
+
+// getDomainName 火山引擎以域名作为域名ID,未传入域名时使用域名ID
+func getDomainName(domain string, domainId string) string {
+	if domain != "" {
+		return domain
+	}
+	return domainId
+}
+
+// CreateDomain 创建域名
+func (v *Volcengine) CreateDomain(data *types.CreateDomainRequest) error {
+	return v.CreateDomainWithContext(v.ctx, data)
+}
+
+// CreateDomainWithContext 创建域名
+func (v *Volcengine) CreateDomainWithContext(ctx context.Context, data *types.CreateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, v, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.CommonResult, error) {
+		return v.client.AddCdnDomain(ctx, &model.AddCdnDomainRequest{
+			Domain:         data.Domain,
+			ServiceType:    getServiceType(data.ChannelType),
+			ServiceRegion:  getServiceRegion(data.AreaCode),
+			Origin:         getOrigins(data.Sources),
+			OriginProtocol: getOriginProtocol(data.OriginProtocol),
+		})
+	})
+	return err
+}
+
+// UpdateDomain 更新域名
+func (v *Volcengine) UpdateDomain(data *types.UpdateDomainRequest) error {
+	return v.UpdateDomainWithContext(v.ctx, data)
+}
+
+// UpdateDomainWithContext 更新域名
+func (v *Volcengine) UpdateDomainWithContext(ctx context.Context, data *types.UpdateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	updateDomain := v.newUpdateDomainConfigModel(data)
+	switch data.UpdateAction {
+	case types.UpdateBaseConf:
+		updateDomain.WithBaseConf()
+	case types.UpdateArea:
+		updateDomain.WithArea()
+	case types.UpdateOriginConf:
+		updateDomain.WithOriginConf()
+	case types.UpdateOriginServerConf:
+		updateDomain.WithOriginServerConf()
+	case types.UpdateOriginRequestHeaderConf:
+		updateDomain.WithOriginRequestHeaderConf()
+	case types.UpdateIpFilterConf:
+		updateDomain.WithIpFilterConf()
+	case types.UpdateIpFrequencyConf:
+		updateDomain.WithIpFrequencyConf()
+	case types.UpdateRefererConf:
+		updateDomain.WithRefererConf()
+	case types.UpdateUserAgentConf:
+		updateDomain.WithUserAgentConf()
+	case types.UpdateSpeedConf:
+		updateDomain.WithSpeedConf()
+	case types.UpdateAuthConf:
+		updateDomain.WithAuthConf()
+	case types.UpdateCacheListConf:
+		updateDomain.WithCacheListConf()
+	case types.UpdateCacheCodeConf:
+		updateDomain.WithCacheCodeConf()
+	case types.UpdateCustomErrorPageConf:
+		updateDomain.WithCustomErrorPageConf()
+	case types.UpdateIntelligentCompressionConf:
+		updateDomain.WithIntelligentCompressionConf()
+	case types.UpdateResponseHeaderConf:
+		updateDomain.WithResponseHeaderConf()
+	case types.UpdateHttpsConf:
+		updateDomain.WithHttpsConf()
+	case types.UpdateRecommendConf:
+		updateDomain.WithOriginConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithHttpsConf()
+		updateDomain.WithIntelligentCompressionConf()
+	case types.UpdateFullConf:
+		updateDomain.WithBaseConf()
+		updateDomain.WithOriginConf()
+		updateDomain.WithOriginServerConf()
+		updateDomain.WithOriginRequestHeaderConf()
+		updateDomain.WithIpFilterConf()
+		updateDomain.WithIpFrequencyConf()
+		updateDomain.WithRefererConf()
+		updateDomain.WithUserAgentConf()
+		updateDomain.WithSpeedConf()
+		updateDomain.WithAuthConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithCacheCodeConf()
+		updateDomain.WithCustomErrorPageConf()
+		updateDomain.WithIntelligentCompressionConf()
+		updateDomain.WithResponseHeaderConf()
+		updateDomain.WithHttpsConf()
+	default:
+		//回源高级配置、回源url改写、远程鉴权、浏览器缓存与访问url重写暂不支持
+		return cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, fmt.Sprintf("update action %s not support", data.UpdateAction))
+	}
+	return updateDomain.send(ctx)
+}
+
+// DisableDomain 停用域名
+func (v *Volcengine) DisableDomain(data *types.DisableDomainRequest) error {
+	return v.DisableDomainWithContext(v.ctx, data)
+}
+
+// DisableDomainWithContext 停用域名
+func (v *Volcengine) DisableDomainWithContext(ctx context.Context, data *types.DisableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, v, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResult, error) {
+		return v.client.StopCdnDomain(ctx, getDomainName(data.Domain, data.DomainId))
+	})
+	return err
+}
+
+// EnableDomain 启用域名
+func (v *Volcengine) EnableDomain(data *types.EnableDomainRequest) error {
+	return v.EnableDomainWithContext(v.ctx, data)
+}
+
+// EnableDomainWithContext 启用域名
+func (v *Volcengine) EnableDomainWithContext(ctx context.Context, data *types.EnableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, v, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResult, error) {
+		return v.client.StartCdnDomain(ctx, getDomainName(data.Domain, data.DomainId))
+	})
+	return err
+}
+
+// DeleteDomain 删除域名
+func (v *Volcengine) DeleteDomain(data *types.DeleteDomainRequest) error {
+	return v.DeleteDomainWithContext(v.ctx, data)
+}
+
+// DeleteDomainWithContext 删除域名,域名需先停用,域名不存在时视为删除成功
+func (v *Volcengine) DeleteDomainWithContext(ctx context.Context, data *types.DeleteDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, v, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResult, error) {
+		return v.client.DeleteCdnDomain(ctx, getDomainName(data.Domain, data.DomainId))
+	})
+	if errors.Is(err, cdnerr.ErrDomainNotFound) {
+		return nil
+	}
+	return err
+}
+
+// CreateVerifyRecord 创建域名验证记录
+func (v *Volcengine) CreateVerifyRecord(data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return v.CreateVerifyRecordWithContext(v.ctx, data)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录,暂不支持
+func (v *Volcengine) CreateVerifyRecordWithContext(ctx context.Context, data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, "create verify record not support")
+}
+
+// VerifyDomainRecord 验证域名解析记录
+func (v *Volcengine) VerifyDomainRecord(data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return v.VerifyDomainRecordWithContext(v.ctx, data)
+}
+
+// VerifyDomainRecordWithContext 验证域名解析记录,暂不支持
+func (v *Volcengine) VerifyDomainRecordWithContext(ctx context.Context, data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, "verify domain record not support")
+}
+
+// ShowDomainDetail 获取域名详情
+func (v *Volcengine) ShowDomainDetail(data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	return v.ShowDomainDetailWithContext(v.ctx, data)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (v *Volcengine) ShowDomainDetailWithContext(ctx context.Context, data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeCdnConfigResult, error) {
+		return v.client.DescribeCdnConfig(ctx, getDomainName(data.Domain, data.DomainId))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.DomainConfig == nil {
+		return nil, cdnerr.New(cdnerr.ErrDomainNotFound, types.VolcengineSdkName, "domain not found")
+	}
+	conf := res.DomainConfig
+	return &types.ShowDomainDetailResponse{
+		DomainId:    conf.Domain,
+		Domain:      conf.Domain,
+		Cname:       conf.Cname,
+		ChannelType: mapServiceType(conf.ServiceType),
+		AreaCode:    mapServiceRegion(conf.ServiceRegion),
+		Status:      getDomainStatus(conf.Status),
+		CreateTime:  conf.CreateTime,
+		UpdateTime:  conf.UpdateTime,
+	}, nil
+}
+
+// ShowDomainStatusList 展示域名状态列表
+func (v *Volcengine) ShowDomainStatusList(data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	return v.ShowDomainStatusListWithContext(v.ctx, data)
+}
+
+// ShowDomainStatusListWithContext 展示域名状态列表
+func (v *Volcengine) ShowDomainStatusListWithContext(ctx context.Context, data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	status := setDomainStatus(data.Status)
+	if status == "" {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.VolcengineSdkName, fmt.Sprintf("domain status %d not support", data.Status))
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ListCdnDomainsResult, error) {
+		return v.client.ListCdnDomains(ctx, &model.ListCdnDomainsRequest{Status: status, PageNum: data.Page, PageSize: data.Limit})
+	})
+	if err != nil {
+		return nil, err
+	}
+	domains := make([]string, 0, len(res.Data))
+	for _, item := range res.Data {
+		domains = append(domains, item.Domain)
+	}
+	return &types.ShowDomainStatusListResponse{Total: res.Total, List: domains}, nil
+}
+
+// PurgePathCache 刷新目录缓存
+func (v *Volcengine) PurgePathCache(data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	return v.PurgePathCacheWithContext(v.ctx, data)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存,火山引擎目录刷新不区分刷新模式
+func (v *Volcengine) PurgePathCacheWithContext(ctx context.Context, data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	return v.purge(ctx, refreshTypeDir, data.Paths)
+}
+
+// PurgeUrlsCache 刷新URL缓存
+func (v *Volcengine) PurgeUrlsCache(data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	return v.PurgeUrlsCacheWithContext(v.ctx, data)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (v *Volcengine) PurgeUrlsCacheWithContext(ctx context.Context, data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	return v.purge(ctx, refreshTypeFile, data.Urls)
+}
+
+func (v *Volcengine) purge(ctx context.Context, refreshType string, urls []string) (*types.PurgeCacheResponse, error) {
+	res, err := invoke(ctx, v, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.SubmitTaskResult, error) {
+		return v.client.SubmitRefreshTask(ctx, &model.SubmitRefreshTaskRequest{Type: refreshType, UrlList: strings.Join(urls, "\n")})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PurgeCacheResponse{TaskId: res.TaskID}, nil
+}
+
+// PushUrlsCache 预热URL缓存
+func (v *Volcengine) PushUrlsCache(data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	return v.PushUrlsCacheWithContext(v.ctx, data)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (v *Volcengine) PushUrlsCacheWithContext(ctx context.Context, data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.SubmitTaskResult, error) {
+		return v.client.SubmitPreloadTask(ctx, &model.SubmitPreloadTaskRequest{UrlList: strings.Join(data.Urls, "\n")})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PushUrlsCacheResponse{TaskId: res.TaskID}, nil
+}
+
+// ShowPurgeTaskStatus 展示刷新任务状态
+func (v *Volcengine) ShowPurgeTaskStatus(data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	return v.ShowPurgeTaskStatusWithContext(v.ctx, data)
+}
+
+// ShowPurgeTaskStatusWithContext 展示刷新任务状态,任务类型未知时依次查询url与目录刷新任务
+func (v *Volcengine) ShowPurgeTaskStatusWithContext(ctx context.Context, data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	for _, taskType := range []string{taskTypeRefreshUrl, taskTypeRefreshDir} {
+		tasks, err := v.listContentTasks(ctx, &model.DescribeContentTasksRequest{TaskID: data.TaskId, TaskType: taskType})
+		if err != nil {
+			return nil, err
+		}
+		if len(tasks) > 0 {
+			return &types.ShowPurgeTaskStatusResponse{TaskId: data.TaskId, Status: mergeTaskStatus(tasks)}, nil
+		}
+	}
+	return nil, newNotFoundError("task not found", "")
+}
+
+// ShowPushTaskStatus 展示预热任务状态
+func (v *Volcengine) ShowPushTaskStatus(data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	return v.ShowPushTaskStatusWithContext(v.ctx, data)
+}
+
+// ShowPushTaskStatusWithContext 展示预热任务状态
+func (v *Volcengine) ShowPushTaskStatusWithContext(ctx context.Context, data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	tasks, err := v.listContentTasks(ctx, &model.DescribeContentTasksRequest{TaskID: data.TaskId, TaskType: taskTypePreload})
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, newNotFoundError("task not found", "")
+	}
+	return &types.ShowPushTaskStatusResponse{TaskId: data.TaskId, Status: mergeTaskStatus(tasks)}, nil
+}
+
+// listContentTasks 分页获取全部刷新预热记录,每条记录对应任务中的一个url
+func (v *Volcengine) listContentTasks(ctx context.Context, req *model.DescribeContentTasksRequest) ([]*model.ContentTask, error) {
+	return rest.Pages(ctx, listPageSize, func(page int64) ([]*model.ContentTask, int64, error) {
+		r := *req
+		r.PageNum, r.PageSize = page, listPageSize
+		res, err := invoke(ctx, v, ratelimit.ClassQuery, retry.Idempotent, func() (*model.DescribeContentTasksResult, error) {
+			return v.client.DescribeContentTasks(ctx, &r)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return res.Data, res.Total, nil
+	})
+}
+
+// mergeTaskStatus 汇总任务下所有url的状态,存在进行中则为进行中,存在失败则为失败
+func mergeTaskStatus(tasks []*model.ContentTask) int64 {
+	taskStatus := int64(consts.ShowContentPurgeOrPushStatusSuccess)
+	for _, t := range tasks {
+		status := getShowContentPurgeOrPushStatus(t.Status)
+		if status == consts.ShowContentPurgeOrPushStatusDoing {
+			return status
+		}
+		if status == consts.ShowContentPurgeOrPushStatusFail {
+			taskStatus = status
+		}
+	}
+	return taskStatus
+}
+
+// getTaskIds 按任务ID汇总各url的状态,返回指定状态的任务ID
+func getTaskIds(tasks []*model.ContentTask, taskStatus int64) []string {
+	ids := make([]string, 0)
+	grouped := make(map[string][]*model.ContentTask)
+	for _, t := range tasks {
+		if _, ok := grouped[t.TaskID]; !ok {
+			ids = append(ids, t.TaskID)
+		}
+		grouped[t.TaskID] = append(grouped[t.TaskID], t)
+	}
+	list := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if mergeTaskStatus(grouped[id]) == taskStatus {
+			list = append(list, id)
+		}
+	}
+	return list
+}
+
+// ShowPurgeTaskList 展示刷新任务列表
+func (v *Volcengine) ShowPurgeTaskList(data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	return v.ShowPurgeTaskListWithContext(v.ctx, data)
+}
+
+// ShowPurgeTaskListWithContext 展示刷新任务列表,记录按url返回,汇总为任务后分页
+func (v *Volcengine) ShowPurgeTaskListWithContext(ctx context.Context, data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	tasks, err := v.listContentTasks(ctx, &model.DescribeContentTasksRequest{
+		TaskType:  getRefreshTaskType(data.PurgeType),
+		StartTime: data.StartTime,
+		EndTime:   data.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	total, list := rest.Slice(getTaskIds(tasks, data.TaskStatus), data.Page, data.Limit)
+	return &types.ShowPurgeTaskListResponse{Total: total, List: list}, nil
+}
+
+// ShowPushTaskList 展示预热任务列表
+func (v *Volcengine) ShowPushTaskList(data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	return v.ShowPushTaskListWithContext(v.ctx, data)
+}
+
+// ShowPushTaskListWithContext 展示预热任务列表,记录按url返回,汇总为任务后分页
+func (v *Volcengine) ShowPushTaskListWithContext(ctx context.Context, data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	tasks, err := v.listContentTasks(ctx, &model.DescribeContentTasksRequest{
+		TaskType:  taskTypePreload,
+		StartTime: data.StartTime,
+		EndTime:   data.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	total, list := rest.Slice(getTaskIds(tasks, data.TaskStatus), data.Page, data.Limit)
+	return &types.ShowPushTaskListResponse{Total: total, List: list}, nil
+}
+
+// DomainAccessDataStatic 获取域名访问数据统计
+func (v *Volcengine) DomainAccessDataStatic(data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	return v.DomainAccessDataStaticWithContext(v.ctx, data)
+}
+
+// DomainAccessDataStaticWithContext 获取域名访问数据统计
+func (v *Volcengine) DomainAccessDataStaticWithContext(ctx context.Context, data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.District != nil || data.Isp != nil {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, "district and isp not support")
+	}
+	metric := getDataAccessMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassStats, retry.Idempotent, func() (*model.DescribeCdnDataResult, error) {
+		return v.client.DescribeCdnData(ctx, newDataRequest(metric, data.Domains, data.StartTime, data.EndTime, data.Interval, data.Area))
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := getStaticData(res)
+	if len(responseData) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return responseData, nil
+}
+
+// DomainOriginDataStatic 获取域名回源数据统计
+func (v *Volcengine) DomainOriginDataStatic(data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	return v.DomainOriginDataStaticWithContext(v.ctx, data)
+}
+
+// DomainOriginDataStaticWithContext 获取域名回源数据统计
+func (v *Volcengine) DomainOriginDataStaticWithContext(ctx context.Context, data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	metric := getDataOriginMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassStats, retry.Idempotent, func() (*model.DescribeCdnDataResult, error) {
+		return v.client.DescribeCdnOriginData(ctx, newDataRequest(metric, data.Domains, data.StartTime, data.EndTime, data.Interval, data.Area))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types.DomainOriginDataStaticResponse(getStaticData(res)), nil
+}
+
+// newDataRequest 创建按域名返回的统计数据请求
+func newDataRequest(metric string, domains []string, startTime int64, endTime int64, interval int64, area int64) *model.DescribeCdnDataRequest {
+	return &model.DescribeCdnDataRequest{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Metric:    metric,
+		Domain:    strings.Join(domains, ","),
+		Interval:  getInterval(interval),
+		Area:      getArea(area),
+		Aggregate: "domain",
+	}
+}
+
+func getStaticData(res *model.DescribeCdnDataResult) types.DomainAccessDataStaticResponse {
+	responseData := make(types.DomainAccessDataStaticResponse)
+	for _, resource := range res.Resources {
+		for _, metric := range resource.Metrics {
+			for _, value := range metric.Values {
+				responseData[resource.Name] = append(responseData[resource.Name], &types.StaticData{
+					Value: value.Value,
+					Time:  value.Timestamp,
+				})
+			}
+		}
+	}
+	return responseData
+}
+
+// getTotalData 汇总各时间点数据,带宽取峰值,其余指标求和
+func getTotalData(metric string, res *model.DescribeCdnDataResult) types.DataTotalDataResponse {
+	responseData := make(types.DataTotalDataResponse)
+	for domain, values := range getStaticData(res) {
+		for _, value := range values {
+			if metric == metricBandwidth {
+				if int64(value.Value) > responseData[domain] {
+					responseData[domain] = int64(value.Value)
+				}
+				continue
+			}
+			responseData[domain] += int64(value.Value)
+		}
+	}
+	return responseData
+}
+
+// ListTopUrlDataStatic 获取TOP URL数据统计
+func (v *Volcengine) ListTopUrlDataStatic(data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	return v.ListTopUrlDataStaticWithContext(v.ctx, data)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL数据统计
+func (v *Volcengine) ListTopUrlDataStaticWithContext(ctx context.Context, data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassStats, retry.Idempotent, func() (*model.DescribeEdgeTopStatisticalDataResult, error) {
+		return v.client.DescribeEdgeTopStatisticalData(ctx, &model.DescribeEdgeTopStatisticalDataRequest{
+			Domain:    data.Domain,
+			StartTime: data.StartTime,
+			EndTime:   data.EndTime,
+			Item:      "url",
+			Metric:    getTopMetric(data.Filter),
+			Area:      getArea(data.Area),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := make([]*types.ListTopUrlDataStaticResponse, 0, len(res.TopDataDetails))
+	for _, item := range res.TopDataDetails {
+		responseData = append(responseData, &types.ListTopUrlDataStaticResponse{Url: item.ItemKey, Value: item.Value})
+	}
+	return responseData, nil
+}
+
+// DomainAccessTotalData 获取域名总流量
+func (v *Volcengine) DomainAccessTotalData(data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return v.DomainAccessTotalDataWithContext(v.ctx, data)
+}
+
+// DomainAccessTotalDataWithContext 获取域名总流量
+func (v *Volcengine) DomainAccessTotalDataWithContext(ctx context.Context, data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	metric := getDataAccessMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassStats, retry.Idempotent, func() (*model.DescribeCdnDataResult, error) {
+		return v.client.DescribeCdnData(ctx, newDataRequest(metric, data.Domains, data.StartTime, data.EndTime, consts.DataIntervalTypeDay, data.Area))
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := getTotalData(metric, res)
+	if len(responseData) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	return responseData, nil
+}
+
+// DomainOriginTotalData 获取域名回源总流量
+func (v *Volcengine) DomainOriginTotalData(data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	return v.DomainOriginTotalDataWithContext(v.ctx, data)
+}
+
+// DomainOriginTotalDataWithContext 获取域名回源总流量
+func (v *Volcengine) DomainOriginTotalDataWithContext(ctx context.Context, data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	metric := getDataOriginMetric(data.Metric)
+	if metric == "" {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	res, err := invoke(ctx, v, ratelimit.ClassStats, retry.Idempotent, func() (*model.DescribeCdnDataResult, error) {
+		return v.client.DescribeCdnOriginData(ctx, newDataRequest(metric, data.Domains, data.StartTime, data.EndTime, consts.DataIntervalTypeDay, data.Area))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getTotalData(metric, res), nil
+}
+
+// UserAccessRegionDistribution 获取用户访问区域分布
+func (v *Volcengine) UserAccessRegionDistribution(data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	return v.UserAccessRegionDistributionWithContext(v.ctx, data)
+}
+
+// UserAccessRegionDistributionWithContext 获取用户访问区域分布,分别查询境内与境外的汇总数据
+func (v *Volcengine) UserAccessRegionDistributionWithContext(ctx context.Context, data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	responseData := make(types.UserAccessRegionDistributionResponse)
+	if data == nil {
+		return responseData, cdnerr.ErrNilRequest
+	}
+	metric := getDataAccessMetric(data.Metric)
+	if metric == "" || metric == metricBandwidth {
+		return responseData, cdnerr.New(cdnerr.ErrUnsupported, types.VolcengineSdkName, fmt.Sprintf("metric %d not support", data.Metric))
+	}
+	for _, area := range []int64{consts.AreaCodeChinaMainland, consts.AreaCodeOversea} {
+		area := area
+		res, err := invoke(ctx, v, ratelimit.ClassStats, retry.Idempotent, func() (*model.DescribeCdnDataResult, error) {
+			return v.client.DescribeCdnData(ctx, newDataRequest(metric, data.Domains, data.StartTime, data.EndTime, consts.DataIntervalTypeDay, area))
+		})
+		if err != nil {
+			return responseData, err
+		}
+		for domain, value := range getTotalData(metric, res) {
+			if _, ok := responseData[domain]; !ok {
+				responseData[domain] = &types.RegionDistribution{}
+			}
+			if area == consts.AreaCodeChinaMainland {
+				responseData[domain].MainLandValue = value
+			} else {
+				responseData[domain].OverSeaValue = value
+			}
+		}
+	}
+	return responseData, nil
+}
+
+//设置更新的各项信息
+
+// UpdateDomainConfigModel 火山引擎修改域名配置只提交需更新的配置项,各配置项收集到同一请求中一次提交
+type UpdateDomainConfigModel struct {
+	v           *Volcengine
+	req         *types.UpdateDomainRequest
+	err         error
+	changed     bool
+	config      *model.DomainConfig
+	certificate *model.Certificate
+}
+
+func (v *Volcengine) newUpdateDomainConfigModel(req *types.UpdateDomainRequest) *UpdateDomainConfigModel {
+	return &UpdateDomainConfigModel{
+		v:      v,
+		req:    req,
+		config: &model.DomainConfig{Domain: getDomainName(req.Domain, req.DomainId)},
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithBaseConf() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	u.WithArea()
+	u.config.IPv6 = &model.Switch{Switch: u.req.CdnDomain.SupportIpv6 == consts.SwitchOn}
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithArea() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	u.config.ServiceRegion = getServiceRegion(u.req.CdnDomain.AreaCode)
+	u.changed = true
+}
+
+// WithOriginConf 回源SNI与Etag校验暂不支持,忽略
+func (u *UpdateDomainConfigModel) WithOriginConf() {
+	if u.req.OriginConf == nil {
+		return
+	}
+	conf := u.req.OriginConf
+	originRange := conf.OriginRange == consts.SwitchOn
+	followRedirect := conf.OriginFollow == consts.SwitchOn
+	u.config.OriginProtocol = getOriginProtocol(conf.OriginProtocol)
+	u.config.OriginRange = &originRange
+	u.config.FollowRedirect = &followRedirect
+	if conf.OriginTimeOut > 0 || conf.TcpTimeout > 0 {
+		u.config.Timeout = &model.Timeout{Switch: true, HttpTimeout: conf.OriginTimeOut, TcpTimeout: conf.TcpTimeout}
+	}
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithOriginServerConf() {
+	if len(u.req.OriginServerConf) == 0 {
+		return
+	}
+	u.config.Origin = getOrigins(u.req.OriginServerConf)
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithOriginRequestHeaderConf() {
+	headers := make([]*model.HeaderInstance, 0, len(u.req.OriginRequestHeaderConf))
+	for _, item := range u.req.OriginRequestHeaderConf {
+		headers = append(headers, newHeader(item.Action, item.ParameterKey, item.ParameterValue))
+	}
+	u.config.OriginRequestHeader = headers
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithResponseHeaderConf() {
+	headers := make([]*model.HeaderInstance, 0, len(u.req.ResponseHeaderConf))
+	for _, item := range u.req.ResponseHeaderConf {
+		headers = append(headers, newHeader(item.Action, item.ParameterKey, item.ParameterValue))
+	}
+	u.config.ResponseHeader = headers
+	u.changed = true
+}
+
+func newHeader(action int64, key string, value string) *model.HeaderInstance {
+	return &model.HeaderInstance{
+		Action:    getHeaderAction(action),
+		Key:       key,
+		Value:     value,
+		ValueType: "constant",
+	}
+}
+
+// getAccessRule 获取黑白名单规则,火山引擎同一规则只能为黑名单或白名单,以第一条有效规则的类型为准
+func getAccessRule(status int64, listType int64, values []string) *model.AccessRule {
+	if status != types.ON {
+		return &model.AccessRule{Switch: false}
+	}
+	return &model.AccessRule{Switch: true, RuleType: getRuleType(listType), Values: values}
+}
+
+func (u *UpdateDomainConfigModel) WithIpFilterConf() {
+	if u.req.IpFilterConf == nil {
+		return
+	}
+	ips := make([]string, 0)
+	ipType := int64(-1)
+	for _, item := range u.req.IpFilterConf.IpFilterConf {
+		if len(item.IpList) == 0 {
+			continue
+		}
+		if ipType == -1 {
+			ipType = item.IpType
+		}
+		if item.IpType == ipType {
+			ips = append(ips, item.IpList...)
+		}
+	}
+	u.config.IpAccessRule = getAccessRule(u.req.IpFilterConf.Status, ipType, ips)
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithIpFrequencyConf() {
+	if u.req.IpFrequencyConf == nil {
+		return
+	}
+	limit := &model.IpFreqLimit{Switch: u.req.IpFrequencyConf.Status == types.ON}
+	if limit.Switch {
+		limit.Frequency = u.req.IpFrequencyConf.Frequency
+	}
+	u.config.IpFreqLimit = limit
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithRefererConf() {
+	if u.req.RefererConf == nil {
+		return
+	}
+	conf := u.req.RefererConf
+	rule := getAccessRule(conf.Status, conf.RefererType, conf.RefererList)
+	if rule.Switch {
+		allowEmpty := conf.IncludeEmpty == consts.SwitchOn
+		rule.AllowEmpty = &allowEmpty
+	}
+	u.config.RefererAccessRule = rule
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithUserAgentConf() {
+	if u.req.UserAgentConf == nil {
+		return
+	}
+	agents := make([]string, 0)
+	agentType := int64(-1)
+	for _, item := range u.req.UserAgentConf.UserAgentConf {
+		if len(item.AgentList) == 0 {
+			continue
+		}
+		if agentType == -1 {
+			agentType = item.AgentType
+		}
+		if item.AgentType == agentType {
+			agents = append(agents, item.AgentList...)
+		}
+	}
+	u.config.UaAccessRule = getAccessRule(u.req.UserAgentConf.Status, agentType, agents)
+	u.changed = true
+}
+
+// WithSpeedConf 单请求限速对全部内容生效,取第一条有效规则
+func (u *UpdateDomainConfigModel) WithSpeedConf() {
+	if u.req.SpeedConf == nil {
+		return
+	}
+	limit := &model.DownloadSpeedLimit{}
+	if u.req.SpeedConf.Status == types.ON {
+		for _, item := range u.req.SpeedConf.SpeedConf {
+			if item.SpeedValues <= 0 {
+				continue
+			}
+			limit.Switch = true
+			limit.SpeedLimitRate = item.SpeedValues
+			break
+		}
+	}
+	u.config.DownloadSpeedLimit = limit
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithAuthConf() {
+	if u.req.AuthConf == nil {
+		return
+	}
+	conf := u.req.AuthConf
+	if conf.Status != types.ON {
+		u.config.SignedUrlAuth = &model.SignedUrlAuth{Switch: false}
+		u.changed = true
+		return
+	}
+	u.config.SignedUrlAuth = &model.SignedUrlAuth{
+		Switch: true,
+		SignedUrlAuthAction: &model.SignedUrlAuthAction{
+			AuthType:        getAuthType(conf.AuthManner),
+			MasterSecretKey: conf.AuthKey,
+			BackupSecretKey: conf.AuthKeyBackup,
+			SignatureParam:  conf.AuthParameter,
+			Duration:        conf.TimeValue,
+		},
+	}
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithCacheListConf() {
+	rules := make([]*model.CacheRule, 0, len(u.req.CacheListConf))
+	for _, item := range u.req.CacheListConf {
+		rules = append(rules, getCacheRule(item))
+	}
+	u.config.Cache = rules
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithCacheCodeConf() {
+	rules := make([]*model.NegativeCache, 0, len(u.req.CacheCodeConf))
+	for _, item := range u.req.CacheCodeConf {
+		rules = append(rules, &model.NegativeCache{NegativeCacheRule: &model.NegativeCacheRule{
+			StatusCode: strconv.FormatInt(item.HttpCode, 10),
+			Action:     "cache",
+			Ttl:        cachettl.Seconds(item.CacheTTL, item.CacheUnit),
+		}})
+	}
+	u.config.NegativeCache = rules
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithCustomErrorPageConf() {
+	pages := make([]*model.ErrorPageAction, 0, len(u.req.CustomErrorPageConf))
+	for _, item := range u.req.CustomErrorPageConf {
+		pages = append(pages, &model.ErrorPageAction{
+			StatusCode:   strconv.FormatInt(item.StatusCode, 10),
+			RedirectCode: getRedirectCode(item.RedirectCode),
+			RedirectUrl:  item.GoalAddress,
+			Action:       "redirect",
+		})
+	}
+	u.config.CustomErrorPage = &model.CustomErrorPage{Switch: len(pages) > 0, ErrorPageRule: pages}
+	u.changed = true
+}
+
+// WithIntelligentCompressionConf 压缩对全部内容生效,只取规则中的压缩方式
+func (u *UpdateDomainConfigModel) WithIntelligentCompressionConf() {
+	if u.req.IntelligentCompressionConf == nil {
+		return
+	}
+	compression := &model.Compression{}
+	if u.req.IntelligentCompressionConf.Status == types.ON {
+		methods := make(map[string]bool)
+		for _, item := range u.req.IntelligentCompressionConf.IntelligentCompressionConf {
+			method := getCompressionType(item.CompressMethod)
+			if !methods[method] {
+				methods[method] = true
+				compression.CompressionType = append(compression.CompressionType, method)
+			}
+		}
+		compression.Switch = len(compression.CompressionType) > 0
+	}
+	u.config.Compression = compression
+	u.changed = true
+}
+
+func (u *UpdateDomainConfigModel) WithHttpsConf() {
+	if u.req.HttpsConf == nil {
+		return
+	}
+	conf := u.req.HttpsConf
+	u.changed = true
+	if conf.HttpsStatus != types.ON {
+		u.config.Https = &model.Https{Switch: false}
+		return
+	}
+	u.certificate = &model.Certificate{Certificate: conf.CertValue, PrivateKey: conf.CertKey}
+	https := &model.Https{
+		Switch: true,
+		Http2:  conf.HttpTwo == consts.SwitchOn,
+		Ocsp:   conf.OcspStatus == consts.SwitchOn,
+		Quic:   &model.Switch{Switch: conf.QuicStatus == consts.SwitchOn},
+	}
+	for _, version := range conf.TlsVersion {
+		if tls := getTlsVersion(version); tls != "" {
+			https.TlsVersion = append(https.TlsVersion, tls)
+		}
+	}
+	if conf.JumpForceStatus == consts.SwitchOn && conf.JumpType == consts.HttpsJumpTypeHttps {
+		https.ForcedRedirect = &model.ForcedRedirect{EnableForcedRedirect: true, StatusCode: "301"}
+	} else {
+		https.ForcedRedirect = &model.ForcedRedirect{EnableForcedRedirect: false}
+	}
+	https.Hsts = &model.Hsts{Switch: conf.HstsStatus == consts.SwitchOn}
+	if https.Hsts.Switch {
+		https.Hsts.Ttl = conf.HstsExpirationTime
+		https.Hsts.Subdomain = "exclude"
+		if conf.HstsSubdomain == consts.SwitchOn {
+			https.Hsts.Subdomain = "include"
+		}
+	}
+	u.config.Https = https
+}
+
+// send 先上传证书,再一次提交全部配置项
+func (u *UpdateDomainConfigModel) send(ctx context.Context) error {
+	if u.err != nil {
+		return u.err
+	}
+	if !u.changed {
+		return nil
+	}
+	client := u.v.client
+	if u.certificate != nil {
+		res, err := invoke(ctx, u.v, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.AddCertificateResult, error) {
+			return client.AddCertificate(ctx, &model.AddCertificateRequest{
+				Certificate: u.certificate,
+				Desc:        u.req.HttpsConf.CertName,
+				Source:      "volc_cert_center",
+			})
+		})
+		if err != nil {
+			return err
+		}
+		u.config.Https.CertInfo = &model.CertInfo{CertId: res.CertId}
+	}
+	_, err := invoke(ctx, u.v, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CommonResult, error) {
+		return client.UpdateCdnConfig(ctx, u.config)
+	})
+	return err
+}
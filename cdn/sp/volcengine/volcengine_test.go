@@ -0,0 +1,240 @@
+package volcengine
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/volcengine/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"reflect"
+	"testing"
+)
+
+func TestDomainLifecycle(t *testing.T) {
+	s, v := newStandIn(t)
+	err := v.CreateDomain(&types.CreateDomainRequest{
+		Domain:         "www.example.com",
+		AreaCode:       consts.AreaCodeGlobal,
+		ChannelType:    consts.ChannelTypeMedia,
+		OriginProtocol: consts.OriginProtocolFollow,
+		Sources: []*entity.OriginServerConf{
+			{OriginAddressList: "1.1.1.1, 2.2.2.2", OriginType: consts.OriginTypeIp, OriginHttpPort: 8080, OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityPrimary, OriginWeight: 10},
+			{OriginAddressList: "backup.example.com", OriginType: consts.OriginTypeDomain, OriginPriority: consts.OriginPriorityBackup},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := s.domain("www.example.com")
+	lines := []*model.OriginLine{
+		{OriginType: "primary", InstanceType: "ip", Address: "1.1.1.1", HttpPort: "8080", OriginHost: "origin.example.com", Weight: "10"},
+		{OriginType: "primary", InstanceType: "ip", Address: "2.2.2.2", HttpPort: "8080", OriginHost: "origin.example.com", Weight: "10"},
+		{OriginType: "backup", InstanceType: "domain", Address: "backup.example.com"},
+	}
+	if d.ServiceType != "video" || d.ServiceRegion != "global" || d.OriginProtocol != "followclient" ||
+		len(d.Origin) != 1 || !reflect.DeepEqual(d.Origin[0].OriginAction.OriginLines, lines) {
+		t.Fatalf("unexpected created domain %+v", d)
+	}
+	//业务错误以200状态码返回
+	err = v.CreateDomain(&types.CreateDomainRequest{Domain: "www.example.com"})
+	var e *cdnerr.Error
+	if !errors.Is(err, cdnerr.ErrDomainAlreadyExists) || !errors.As(err, &e) || e.StatusCode != 200 || e.RequestId != "req-1" || e.Temporary {
+		t.Fatalf("got %v, want domain already exists", err)
+	}
+
+	detail, err := v.ShowDomainDetail(&types.ShowDomainDetailRequest{DomainId: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDetail := &types.ShowDomainDetailResponse{
+		DomainId:    "www.example.com",
+		Domain:      "www.example.com",
+		Cname:       "www.example.com.volcgslb.com",
+		ChannelType: consts.ChannelTypeMedia,
+		AreaCode:    consts.AreaCodeGlobal,
+		Status:      consts.CdnDomainStatusDeploying,
+		CreateTime:  1704038400,
+		UpdateTime:  1704124800,
+	}
+	if !reflect.DeepEqual(detail, wantDetail) {
+		t.Fatalf("got detail %+v, want %+v", detail, wantDetail)
+	}
+
+	if err := v.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	s.addDomain("a.example.com")
+	list, err := v.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusStoped, Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 1 || !reflect.DeepEqual(list.List, []string{"www.example.com"}) {
+		t.Fatalf("unexpected stopped domains %+v", list)
+	}
+	if _, err := v.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: 99}); !errors.Is(err, cdnerr.ErrInvalidParameter) {
+		t.Fatalf("got %v, want invalid status", err)
+	}
+	if err := v.EnableDomain(&types.EnableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if status := s.domain("www.example.com").Status; status != "online" {
+		t.Fatalf("got status %s after enable", status)
+	}
+	//未停用的域名不能删除
+	if err := v.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrInvalidParameter) {
+		t.Fatalf("got %v, want invalid parameter", err)
+	}
+	if err := v.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if s.domain("www.example.com") != nil {
+		t.Fatal("domain not deleted")
+	}
+	//域名不存在时删除视为成功
+	if err := v.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = v.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	if !errors.Is(err, cdnerr.ErrDomainNotFound) || !errors.As(err, &e) || e.Code != "InvalidDomain.NotFound" {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+}
+
+func TestUpdateDomainConfig(t *testing.T) {
+	s, v := newStandIn(t)
+	s.addDomain("www.example.com")
+	s.resetCalls()
+	err := v.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateRecommendConf,
+		Domain:       "www.example.com",
+		OriginConf:   &entity.OriginConf{OriginProtocol: consts.OriginProtocolHttps, OriginRange: consts.SwitchOn, OriginTimeOut: 30},
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{"jpg", "png"}, CacheTTL: 2, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOn},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"/api/"}, CacheStatus: consts.CacheStatusOff},
+		},
+		HttpsConf: &entity.HttpsConf{
+			HttpsStatus:        types.ON,
+			HttpTwo:            consts.SwitchOn,
+			JumpForceStatus:    consts.SwitchOn,
+			JumpType:           consts.HttpsJumpTypeHttps,
+			HstsStatus:         consts.SwitchOn,
+			HstsExpirationTime: 3600,
+			HstsSubdomain:      consts.SwitchOn,
+			TlsVersion:         []int64{consts.HttpsTlsVersionSSLv2, consts.HttpsTlsVersionTLSv3},
+			CertName:           "example",
+			CertValue:          "cert",
+			CertKey:            "key",
+		},
+		IntelligentCompressionConf: &types.IntelligentCompressionConf{
+			Status: types.ON,
+			IntelligentCompressionConf: []*entity.IntelligentCompressionConf{
+				{CompressMethod: consts.IntelligentCompressionCompressMethodGzip},
+				{CompressMethod: consts.IntelligentCompressionCompressMethodBrotli},
+				{CompressMethod: consts.IntelligentCompressionCompressMethodGzip},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//先上传证书,再一次提交全部配置项
+	if calls := s.callList(); !reflect.DeepEqual(calls, []string{"AddCertificate", "UpdateCdnConfig"}) {
+		t.Fatalf("got calls %v", calls)
+	}
+	cert := s.certs["cert-1"]
+	if cert == nil || cert.Source != "volc_cert_center" || cert.Desc != "example" || cert.Certificate.PrivateKey != "key" {
+		t.Fatalf("unexpected certificate %+v", cert)
+	}
+	update := s.lastUpdate()
+	if update.OriginProtocol != "https" || update.OriginRange == nil || !*update.OriginRange ||
+		update.Timeout == nil || update.Timeout.HttpTimeout != 30 || update.Origin != nil || update.ResponseHeader != nil {
+		t.Fatalf("unexpected origin update %+v", update)
+	}
+	cache := []*model.CacheRule{
+		{
+			CacheAction: &model.CacheAction{Action: "cache", Ttl: 7200, DefaultPolicy: "force_cache"},
+			Condition: &model.Condition{Connective: "or", ConditionRule: []*model.ConditionRule{
+				{Type: "filetype", Value: "jpg"}, {Type: "filetype", Value: "png"},
+			}},
+		},
+		{
+			CacheAction: &model.CacheAction{Action: "no-cache", DefaultPolicy: "force_cache"},
+			Condition:   &model.Condition{Connective: "or", ConditionRule: []*model.ConditionRule{{Type: "directory", Value: "/api/"}}},
+		},
+	}
+	if !reflect.DeepEqual(update.Cache, cache) {
+		t.Fatalf("got cache %+v", update.Cache)
+	}
+	https := &model.Https{
+		Switch:         true,
+		CertInfo:       &model.CertInfo{CertId: "cert-1"},
+		Http2:          true,
+		Quic:           &model.Switch{},
+		ForcedRedirect: &model.ForcedRedirect{EnableForcedRedirect: true, StatusCode: "301"},
+		Hsts:           &model.Hsts{Switch: true, Ttl: 3600, Subdomain: "include"},
+		TlsVersion:     []string{"tlsv1.2", "tlsv1.3"},
+	}
+	if !reflect.DeepEqual(update.Https, https) {
+		t.Fatalf("got https %+v, want %+v", update.Https, https)
+	}
+	if c := update.Compression; c == nil || !c.Switch || !reflect.DeepEqual(c.CompressionType, []string{"gzip", "br"}) {
+		t.Fatalf("got compression %+v", c)
+	}
+
+	//关闭https不上传证书
+	s.resetCalls()
+	err = v.UpdateDomain(&types.UpdateDomainRequest{UpdateAction: types.UpdateHttpsConf, DomainId: "www.example.com", HttpsConf: &entity.HttpsConf{HttpsStatus: types.OFF}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls := s.callList(); !reflect.DeepEqual(calls, []string{"UpdateCdnConfig"}) {
+		t.Fatalf("got calls %v", calls)
+	}
+	if update := s.lastUpdate(); !reflect.DeepEqual(update, &model.DomainConfig{Domain: "www.example.com", Https: &model.Https{}}) {
+		t.Fatalf("unexpected https update %+v", update)
+	}
+
+	//未提交配置时不调用接口
+	s.resetCalls()
+	if err := v.UpdateDomain(&types.UpdateDomainRequest{UpdateAction: types.UpdateOriginConf, Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls := s.callList(); len(calls) != 0 {
+		t.Fatalf("empty update called %v", calls)
+	}
+	err = v.UpdateDomain(&types.UpdateDomainRequest{UpdateAction: types.UpdateArea, Domain: "none.example.com", CdnDomain: &entity.UpdateCdnDomainBaseConf{AreaCode: consts.AreaCodeOversea}})
+	if !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+}
+
+func TestUpdateDomainUnsupported(t *testing.T) {
+	s, v := newStandIn(t)
+	s.addDomain("www.example.com")
+	s.resetCalls()
+	for _, action := range []string{types.UpdateOriginAdvanceServerConf, types.UpdateOriginUrlConf, types.UpdateBrowserCacheConf} {
+		if err := v.UpdateDomain(&types.UpdateDomainRequest{UpdateAction: action, Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrUnsupported) {
+			t.Fatalf("%s: got %v, want unsupported", action, err)
+		}
+	}
+	if calls := s.callList(); len(calls) != 0 {
+		t.Fatalf("unsupported updates called %v", calls)
+	}
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	s, _ := newStandIn(t)
+	v, err := New(context.Background(), &Config{Ak: "ak", Sk: "wrong", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = v.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Status: consts.CdnDomainStatusDeployed, Page: 1, Limit: 10})
+	if !errors.Is(err, cdnerr.ErrAuthFailed) {
+		t.Fatalf("got %v, want auth failed", err)
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
+	"net/http"
 	"net/url"
 	"sort"
 	"strconv"
@@ -14,14 +14,23 @@ import (
 	"time"
 )
 
+// Auth 只保存不可变的鉴权信息,可在多个goroutine间共享
 type Auth struct {
-	AccessKey      string
-	SecretKey      string
-	EndPoint       string
-	SignedHeaders  string // 参与计算的头部
+	AccessKey     string
+	SecretKey     string
+	EndPoint      string
+	Scheme        string // 请求协议,默认https
+	Host          string // 请求域名
+	SignedHeaders string // 参与计算的头部
+	// HttpRequestMsg 最近一次WithAuth生成的请求信息
+	//
+	// Deprecated: 并发请求间共享不安全,使用WithAuth的返回值或Sign
 	HttpRequestMsg *model.HttpRequestMsg
 }
 
+// SignParams WithAuth的签名参数
+//
+// Deprecated: 仅为兼容WithAuth保留
 type SignParams struct {
 	Url    string
 	Method string
@@ -29,42 +38,66 @@ type SignParams struct {
 }
 
 func NewAuth(accessKey string, secretKey string, endPoint string) *Auth {
+	scheme, host := splitEndpoint(endPoint)
+	if len(host) == 0 || "{endPoint}" == host {
+		host = constant.HttpRequestDomain
+	}
 	return &Auth{
-		AccessKey:      accessKey,
-		SecretKey:      secretKey,
-		EndPoint:       endPoint,
-		HttpRequestMsg: newHttpRequestMsg(endPoint),
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		EndPoint:  endPoint,
+		Scheme:    scheme,
+		Host:      host,
 	}
 }
 
-func newHttpRequestMsg(endpoint string) *model.HttpRequestMsg {
-	var requestMsg = model.HttpRequestMsg{Params: map[string]string{}, Headers: map[string]string{}}
-	if len(endpoint) == 0 || "{endPoint}" == endpoint {
-		requestMsg.Host = constant.HttpRequestDomain
-	} else {
-		requestMsg.Host = endpoint
+// splitEndpoint 拆分endpoint中的协议与域名,未指定协议时使用https
+func splitEndpoint(endpoint string) (string, string) {
+	if i := strings.Index(endpoint, "://"); i > 0 {
+		return endpoint[:i], strings.TrimSuffix(endpoint[i+3:], "/")
 	}
-	requestMsg.SignedHeaders = getSignedHeaders("")
-	timeStamp := getCurrentTimeSeconds()
-	requestMsg.Headers[constant.HeadSignTimeStamp] = timeStamp
-	requestMsg.Headers[constant.Host] = requestMsg.Host
-	requestMsg.Headers[constant.ContentType] = constant.ApplicationJson
-	requestMsg.Headers[constant.XCncAuthMethod] = constant.AKSK
-	return &requestMsg
+	return constant.HttpRequestScheme, endpoint
 }
 
-func (a *Auth) WithAuth(signParams *SignParams) {
-	a.HttpRequestMsg.Headers[constant.HeadSignAccessKey] = a.AccessKey
-	a.HttpRequestMsg.Uri = signParams.Url
-	a.HttpRequestMsg.Url = fmt.Sprintf("https://%s%s", a.HttpRequestMsg.Headers[constant.Host], signParams.Url)
-	a.HttpRequestMsg.Body = string(signParams.Body)
-	signature := getSignature(a.HttpRequestMsg, a.SecretKey, a.HttpRequestMsg.Headers[constant.HeadSignTimeStamp])
-	a.HttpRequestMsg.Headers[constant.Authorization] = genAuthorization(a.AccessKey, a.HttpRequestMsg.SignedHeaders, signature)
+// Sign 实现rest.Signer,使用CNC-HMAC-SHA256算法对请求签名
+func (a *Auth) Sign(req *http.Request, body []byte, t time.Time) error {
+	timeStamp := strconv.FormatInt(t.UTC().Unix(), 10)
+	signedHeaders := getSignedHeaders(a.SignedHeaders)
+	req.Header.Set(constant.HeadSignTimeStamp, timeStamp)
+	req.Header.Set(constant.ContentType, constant.ApplicationJson)
+	req.Header.Set(constant.XCncAuthMethod, constant.AKSK)
+	req.Header.Set(constant.HeadSignAccessKey, a.AccessKey)
+	signature := getSignature(req, body, a.SecretKey, signedHeaders, timeStamp)
+	req.Header.Set(constant.Authorization, genAuthorization(a.AccessKey, signedHeaders, signature))
+	return nil
 }
 
-func getCurrentTimeSeconds() string {
-	timeStamp := time.Now().UTC().Unix()
-	return strconv.FormatInt(timeStamp, 10)
+// WithAuth 使用当前时间生成已签名的请求信息,配合util.Call发送
+// 为兼容旧调用方同时写入HttpRequestMsg,并发调用时应只使用返回值
+//
+// Deprecated: 使用Sign对http.Request签名,或直接使用common.Client
+func (a *Auth) WithAuth(signParams *SignParams) *model.HttpRequestMsg {
+	requestMsg := &model.HttpRequestMsg{
+		Uri:           signParams.Url,
+		Url:           a.Scheme + "://" + a.Host + signParams.Url,
+		Method:        signParams.Method,
+		Host:          a.Host,
+		Params:        map[string]string{},
+		Headers:       map[string]string{constant.Host: a.Host},
+		Body:          string(signParams.Body),
+		SignedHeaders: getSignedHeaders(a.SignedHeaders),
+	}
+	a.HttpRequestMsg = requestMsg
+	req, err := http.NewRequest(signParams.Method, requestMsg.Url, nil)
+	if err != nil {
+		requestMsg.Msg = err.Error()
+		return requestMsg
+	}
+	_ = a.Sign(req, signParams.Body, time.Now())
+	for key := range req.Header {
+		requestMsg.Headers[key] = req.Header.Get(key)
+	}
+	return requestMsg
 }
 
 /*
@@ -86,17 +119,17 @@ func genAuthorization(accessKey string, signedHeaders string, signature string)
 	return build.String()
 }
 
-func getSignature(requestMsg *model.HttpRequestMsg, secretKey string, timeStamp string) string {
-	var bodyStr = requestMsg.Body
-	if len(requestMsg.Body) == 0 || "GET" == requestMsg.Method {
+func getSignature(req *http.Request, body []byte, secretKey string, signedHeaders string, timeStamp string) string {
+	var bodyStr = string(body)
+	if len(body) == 0 || http.MethodGet == req.Method {
 		bodyStr = ""
 	}
 	hashedRequestPayload := hmacSha256(bodyStr)
-	canonicalRequest := requestMsg.Method + "\n" +
-		requestMsg.Uri + "\n" +
-		getQueryString(requestMsg) + "\n" +
-		getCanonicalHeaders(requestMsg.Headers, requestMsg.SignedHeaders) + "\n" +
-		getSignedHeaders(requestMsg.SignedHeaders) + "\n" +
+	canonicalRequest := req.Method + "\n" +
+		req.URL.RequestURI() + "\n" +
+		getQueryString(req) + "\n" +
+		getCanonicalHeaders(req, signedHeaders) + "\n" +
+		signedHeaders + "\n" +
 		hashedRequestPayload
 	stringToSign := constant.HeadSignAlgorithm + "\n" + timeStamp + "\n" + hmacSha256(canonicalRequest)
 	return hmac256(secretKey, stringToSign)
@@ -106,14 +139,13 @@ func getSignature(requestMsg *model.HttpRequestMsg, secretKey string, timeStamp
 *
 获取uri参数
 */
-func getQueryString(requestMsg *model.HttpRequestMsg) string {
-	indexOfQueryStringSeparator := strings.Index(requestMsg.Uri, "?")
-	if "POST" == requestMsg.Method || indexOfQueryStringSeparator == -1 {
+func getQueryString(req *http.Request) string {
+	if http.MethodPost == req.Method || req.URL.RawQuery == "" {
 		return ""
 	}
-	s, err := url.QueryUnescape(requestMsg.Uri[indexOfQueryStringSeparator:len(requestMsg.Uri)])
+	s, err := url.QueryUnescape("?" + req.URL.RawQuery)
 	if err != nil {
-		fmt.Println("decode请求参数失败.")
+		return "?" + req.URL.RawQuery
 	}
 	return s
 }
@@ -135,12 +167,13 @@ func getSignedHeaders(signedHeaders string) string {
 *
 获取k-v字符串
 */
-func getCanonicalHeaders(headerMap map[string]string, signedHeaders string) string {
+func getCanonicalHeaders(req *http.Request, signedHeaders string) string {
 	keys := strings.Split(signedHeaders, ";")
 	var headers = make(map[string]string)
-	for k, v := range headerMap {
-		headers[strings.ToLower(k)] = v
+	for k := range req.Header {
+		headers[strings.ToLower(k)] = req.Header.Get(k)
 	}
+	headers["host"] = req.Host
 	var build strings.Builder
 	for i := 0; i < len(keys); i++ {
 		build.WriteString(keys[i])
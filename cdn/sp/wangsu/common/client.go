@@ -1,200 +1,159 @@
 package common
 
 import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/auth"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
-	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/util"
 	"net/http"
 )
 
 type Client struct {
-	httpRequestMsg *model.HttpRequestMsg
-	auth           *auth.Auth
-}
-
-type Unmarshaler interface {
-	Unmarshal(data []byte) error
+	rest *rest.Client
 }
 
 func NewClient(auth *auth.Auth) *Client {
+	client := rest.NewClient(auth.Scheme+"://"+auth.Host, auth)
+	client.ErrorDecoder = rest.NewErrorDecoder(rest.ErrorFields{
+		RequestIdHeader: constant.XCncRequestId,
+		Code:            []string{"code"},
+		Message:         []string{"message"},
+	})
 	return &Client{
-		auth: auth,
+		rest: client,
 	}
 }
 
-func (c *Client) send(response Unmarshaler) error {
-	res, err := util.Call(c.httpRequestMsg)
-	if err != nil {
-		return err
-	}
-	return response.Unmarshal(res)
+// WithHttpClient 设置发送请求使用的HTTP客户端,为空时使用http.DefaultClient
+func (c *Client) WithHttpClient(client *http.Client) *Client {
+	c.rest.HttpClient = client
+	return c
 }
 
 // CreateDomain 创建域名
-func (c *Client) CreateDomain(req *model.CreateDomainRequest) (*model.CreateDomainResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/domain",
-		Method: http.MethodPost,
-		Body:   req.Marshal(),
-	})
-	resp := &model.CreateDomainResponse{}
-	err := c.send(resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+func (c *Client) CreateDomain(ctx context.Context, req *model.CreateDomainRequest) (*model.CreateDomainResponse, error) {
+	return rest.Do[model.CreateDomainResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/domain").WithBody(req.Marshal()))
 }
 
 // EnableDomain 启用域名
-func (c *Client) EnableDomain(req *model.EnableDomainRequest) (*model.EnableDomainResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/domain/enable",
-		Method: http.MethodPost,
-		Body:   req.Marshal(),
-	})
-	resp := &model.EnableDomainResponse{}
-	err := c.send(resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+func (c *Client) EnableDomain(ctx context.Context, req *model.EnableDomainRequest) (*model.EnableDomainResponse, error) {
+	return rest.Do[model.EnableDomainResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/domain/enable").WithBody(req.Marshal()))
 }
 
 // ShowDomainList 获取域名列表
-func (c *Client) ShowDomainList(req *model.ShowDomainListRequest) (*model.ShowDomainListResponse, error) {
-	s := &auth.SignParams{
-		Url:    "/api/domain",
-		Method: http.MethodGet,
-	}
-	if req.CnameLabel != "" {
-		s.Url += "?cname_label=" + req.CnameLabel
-	}
-	c.auth.WithAuth(s)
-	resp := &model.ShowDomainListResponse{}
-	err := c.send(resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+func (c *Client) ShowDomainList(ctx context.Context, req *model.ShowDomainListRequest) (*model.ShowDomainListResponse, error) {
+	r := rest.NewRequest(http.MethodGet, "/api/domain").WithQuery("cname_label", req.CnameLabel)
+	return rest.Do[model.ShowDomainListResponse](ctx, c.rest, r)
 }
 
 // DisableDomain 停用域名
-func (c *Client) DisableDomain(req *model.DisableDomainRequest) (*model.DisableDomainResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/domain/disable",
-		Method: http.MethodPost,
-		Body:   req.Marshal(),
-	})
-	resp := &model.DisableDomainResponse{}
-	err := c.send(resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+func (c *Client) DisableDomain(ctx context.Context, req *model.DisableDomainRequest) (*model.DisableDomainResponse, error) {
+	return rest.Do[model.DisableDomainResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/domain/disable").WithBody(req.Marshal()))
 }
 
 // SetSrcConfig 设置回源策略
-func (c *Client) SetSrcConfig(req *model.SrcConfigRequest) (*model.SrcConfigResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/domain/setsrcconfig",
-		Method: http.MethodPost,
-		Body:   req.Marshal(),
-	})
-	resp := &model.SrcConfigResponse{}
-	err := c.send(resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+func (c *Client) SetSrcConfig(ctx context.Context, req *model.SrcConfigRequest) (*model.SrcConfigResponse, error) {
+	return rest.Do[model.SrcConfigResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/domain/setsrcconfig").WithBody(req.Marshal()))
 }
 
 // CacheTime 设置缓存时间
-func (c *Client) CacheTime(req *model.CacheTimeRequest) (*model.CacheTimeResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/config/cachetime/" + req.DomainName,
-		Method: http.MethodPut,
-		Body:   req.Marshal(),
-	})
-	resp := &model.CacheTimeResponse{}
-	err := c.send(resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+func (c *Client) CacheTime(ctx context.Context, req *model.CacheTimeRequest) (*model.CacheTimeResponse, error) {
+	return rest.Do[model.CacheTimeResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/api/config/cachetime/"+req.DomainName).WithBody(req.Marshal()))
 }
 
 // HttpCodeCache 设置http状态码缓存
-func (c *Client) HttpCodeCache(req *model.HttpCodeCacheRequest) (*model.HttpCodeCacheResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/config/httpcodecache/" + req.DomainName,
-		Method: http.MethodPut,
-		Body:   req.Marshal(),
-	})
-	resp := &model.HttpCodeCacheResponse{}
-	err := c.send(resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+func (c *Client) HttpCodeCache(ctx context.Context, req *model.HttpCodeCacheRequest) (*model.HttpCodeCacheResponse, error) {
+	return rest.Do[model.HttpCodeCacheResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/api/config/httpcodecache/"+req.DomainName).WithBody(req.Marshal()))
 }
 
 // AccessSpeed 设置限速
-func (c *Client) AccessSpeed(req *model.AccessSpeedRequest) (model.AccessSpeedResponse, error) {
-	req.DomainName = ""
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/config/accessspeed/" + req.DomainName,
-		Method: http.MethodPut,
-		Body:   req.Marshal(),
-	})
-	resp := model.AccessSpeedResponse{}
-	err := c.send(&resp)
+func (c *Client) AccessSpeed(ctx context.Context, req *model.AccessSpeedRequest) (model.AccessSpeedResponse, error) {
+	resp, err := rest.Do[model.AccessSpeedResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/api/config/accessspeed/"+req.DomainName).WithBody(req.Marshal()))
 	if err != nil {
-		return resp, err
+		return model.AccessSpeedResponse{}, err
 	}
-	return resp, nil
+	return *resp, nil
 }
 
 // HeaderModify 设置header
-func (c *Client) HeaderModify(req *model.HeaderModifyRequest) (model.HeaderModifyResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/config/headermodify/" + req.DomainName,
-		Method: http.MethodPut,
-		Body:   req.Marshal(),
-	})
-	resp := model.HeaderModifyResponse{}
-	err := c.send(&resp)
+func (c *Client) HeaderModify(ctx context.Context, req *model.HeaderModifyRequest) (model.HeaderModifyResponse, error) {
+	resp, err := rest.Do[model.HeaderModifyResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/api/config/headermodify/"+req.DomainName).WithBody(req.Marshal()))
 	if err != nil {
-		return resp, err
+		return model.HeaderModifyResponse{}, err
 	}
-	return resp, nil
+	return *resp, nil
 }
 
 // InnerRedirect 设置内网跳转
-func (c *Client) InnerRedirect(req *model.InnerRedirectRequest) (model.InnerRedirectResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/config/InnerRedirect/" + req.DomainName,
-		Method: http.MethodPut,
-		Body:   req.Marshal(),
-	})
-	resp := model.InnerRedirectResponse{}
-	err := c.send(&resp)
+func (c *Client) InnerRedirect(ctx context.Context, req *model.InnerRedirectRequest) (model.InnerRedirectResponse, error) {
+	resp, err := rest.Do[model.InnerRedirectResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/api/config/InnerRedirect/"+req.DomainName).WithBody(req.Marshal()))
 	if err != nil {
-		return resp, err
+		return model.InnerRedirectResponse{}, err
 	}
-	return resp, nil
+	return *resp, nil
 }
 
 // VisitControl 设置访问控制
-func (c *Client) VisitControl(req *model.VisitControlRequest) (model.VisitControlResponse, error) {
-	c.auth.WithAuth(&auth.SignParams{
-		Url:    "/api/config/visitcontrol/" + req.DomainName,
-		Method: http.MethodPut,
-		Body:   req.Marshal(),
-	})
-	resp := model.VisitControlResponse{}
-	err := c.send(&resp)
+func (c *Client) VisitControl(ctx context.Context, req *model.VisitControlRequest) (model.VisitControlResponse, error) {
+	resp, err := rest.Do[model.VisitControlResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/api/config/visitcontrol/"+req.DomainName).WithBody(req.Marshal()))
 	if err != nil {
-		return resp, err
+		return model.VisitControlResponse{}, err
 	}
-	return resp, nil
+	return *resp, nil
+}
+
+// ShowDomainDetail 获取域名详情
+func (c *Client) ShowDomainDetail(ctx context.Context, req *model.ShowDomainDetailRequest) (*model.ShowDomainDetailResponse, error) {
+	return rest.Do[model.ShowDomainDetailResponse](ctx, c.rest, rest.NewRequest(http.MethodGet, "/api/domain/"+req.DomainName))
+}
+
+// ModifyDomain 修改域名基础配置
+func (c *Client) ModifyDomain(ctx context.Context, req *model.ModifyDomainRequest) (*model.ModifyDomainResponse, error) {
+	return rest.Do[model.ModifyDomainResponse](ctx, c.rest, rest.NewRequest(http.MethodPut, "/api/domain/"+req.DomainName).WithBody(req.Marshal()))
+}
+
+// DeleteDomain 删除域名
+func (c *Client) DeleteDomain(ctx context.Context, req *model.DeleteDomainRequest) (*model.DeleteDomainResponse, error) {
+	return rest.Do[model.DeleteDomainResponse](ctx, c.rest, rest.NewRequest(http.MethodDelete, "/api/domain/"+req.DomainName))
+}
+
+// CreateCertificate 新增证书
+func (c *Client) CreateCertificate(ctx context.Context, req *model.CreateCertificateRequest) (*model.CreateCertificateResponse, error) {
+	return rest.Do[model.CreateCertificateResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/certificate").WithBody(req.Marshal()))
+}
+
+// Purge 提交刷新任务
+func (c *Client) Purge(ctx context.Context, req *model.PurgeRequest) (*model.PurgeResponse, error) {
+	return rest.Do[model.PurgeResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/ccm/purge/ItemIdReceiver").WithBody(req.Marshal()))
+}
+
+// PurgeQuery 查询刷新任务
+func (c *Client) PurgeQuery(ctx context.Context, req *model.PurgeQueryRequest) (*model.PurgeQueryResponse, error) {
+	return rest.Do[model.PurgeQueryResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/ccm/purge/ItemIdQuery").WithBody(req.Marshal()))
+}
+
+// Prefetch 提交预热任务
+func (c *Client) Prefetch(ctx context.Context, req *model.PrefetchRequest) (*model.PrefetchResponse, error) {
+	return rest.Do[model.PrefetchResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/ccm/fetch/ItemIdReceiver").WithBody(req.Marshal()))
+}
+
+// PrefetchQuery 查询预热任务
+func (c *Client) PrefetchQuery(ctx context.Context, req *model.PrefetchQueryRequest) (*model.PrefetchQueryResponse, error) {
+	return rest.Do[model.PrefetchQueryResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/ccm/fetch/ItemIdQuery").WithBody(req.Marshal()))
+}
+
+// DomainStatistics 查询域名统计数据
+func (c *Client) DomainStatistics(ctx context.Context, req *model.DomainStatisticsRequest) (*model.DomainStatisticsResponse, error) {
+	return rest.Do[model.DomainStatisticsResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/statistics/domain").WithBody(req.Marshal()))
+}
+
+// TopUrl 查询热门url
+func (c *Client) TopUrl(ctx context.Context, req *model.TopUrlRequest) (*model.TopUrlResponse, error) {
+	return rest.Do[model.TopUrlResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/statistics/topurl").WithBody(req.Marshal()))
+}
+
+// RegionStatistics 查询访问区域分布
+func (c *Client) RegionStatistics(ctx context.Context, req *model.RegionStatisticsRequest) (*model.RegionStatisticsResponse, error) {
+	return rest.Do[model.RegionStatisticsResponse](ctx, c.rest, rest.NewRequest(http.MethodPost, "/api/statistics/region").WithBody(req.Marshal()))
 }
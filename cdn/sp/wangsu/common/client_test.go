@@ -0,0 +1,137 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/auth"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/util"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newVerifyingServer 按收到的时间戳重新计算签名并与请求中的签名比对,
+// 同时检查时间戳是否为当前时间,响应头Location返回请求体中的域名
+func newVerifyingServer(t *testing.T, a *auth.Auth) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, err := strconv.ParseInt(r.Header.Get(constant.HeadSignTimeStamp), 10, 64)
+		if err != nil {
+			http.Error(w, `{"code":"InvalidTimestamp"}`, http.StatusUnauthorized)
+			return
+		}
+		if d := time.Since(time.Unix(ts, 0)); d > time.Minute || d < -time.Minute {
+			http.Error(w, `{"code":"ExpiredSignature"}`, http.StatusUnauthorized)
+			return
+		}
+		want := r.Clone(r.Context())
+		want.Header = r.Header.Clone()
+		if err := a.Sign(want, body, time.Unix(ts, 0)); err != nil || want.Header.Get(constant.Authorization) != r.Header.Get(constant.Authorization) {
+			http.Error(w, `{"code":"SignatureDoesNotMatch"}`, http.StatusUnauthorized)
+			return
+		}
+		req := &model.CreateDomainRequest{}
+		_ = json.NewDecoder(bytes.NewReader(body)).Decode(req)
+		w.Header().Set(constant.Location, "/api/domain/"+req.DomainName)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestClientConcurrentSigning(t *testing.T) {
+	a := auth.NewAuth("ak", "sk", "")
+	s := newVerifyingServer(t, a)
+	a = auth.NewAuth("ak", "sk", s.URL)
+	client := NewClient(a)
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			domain := fmt.Sprintf("www%d.example.com", i)
+			res, err := client.CreateDomain(context.Background(), &model.CreateDomainRequest{DomainName: domain, Comment: strconv.Itoa(i)})
+			if err != nil {
+				errs <- fmt.Errorf("%s: %w", domain, err)
+				return
+			}
+			if res.Location != "/api/domain/"+domain {
+				errs <- fmt.Errorf("%s: got response for %s", domain, res.Location)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestSignUsesCallTime(t *testing.T) {
+	a := auth.NewAuth("ak", "sk", "")
+	sign := func(ts time.Time) http.Header {
+		req, _ := http.NewRequest(http.MethodGet, "https://open.chinanetcenter.com/api/domain", nil)
+		if err := a.Sign(req, nil, ts); err != nil {
+			t.Fatal(err)
+		}
+		return req.Header
+	}
+	first := sign(time.Unix(1700000000, 0))
+	second := sign(time.Unix(1700003600, 0))
+	if first.Get(constant.HeadSignTimeStamp) != "1700000000" || second.Get(constant.HeadSignTimeStamp) != "1700003600" {
+		t.Fatalf("unexpected timestamps %s %s", first.Get(constant.HeadSignTimeStamp), second.Get(constant.HeadSignTimeStamp))
+	}
+	if first.Get(constant.Authorization) == second.Get(constant.Authorization) {
+		t.Fatal("signature does not depend on timestamp")
+	}
+}
+
+// TestWithAuthCall 兼容保留的WithAuth与util.Call生成的签名应与Sign一致
+func TestWithAuthCall(t *testing.T) {
+	a := auth.NewAuth("ak", "sk", "")
+	s := newVerifyingServer(t, a)
+	a = auth.NewAuth("ak", "sk", s.URL)
+	req := &model.CreateDomainRequest{DomainName: "www.example.com"}
+	res, err := util.Call(context.Background(), a.WithAuth(&auth.SignParams{
+		Url:    "/api/domain",
+		Method: http.MethodPost,
+		Body:   req.Marshal(),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusAccepted {
+		t.Fatalf("got status %d: %s", res.StatusCode, res.Body)
+	}
+	resp := &model.CreateDomainResponse{}
+	resp.UnmarshalHeader(res.StatusCode, res.Header)
+	if resp.Location != "/api/domain/www.example.com" {
+		t.Fatalf("got location %s", resp.Location)
+	}
+}
+
+// TestWithAuthLegacyField 旧调用方忽略返回值,从HttpRequestMsg读取签名结果
+func TestWithAuthLegacyField(t *testing.T) {
+	a := auth.NewAuth("ak", "sk", "")
+	s := newVerifyingServer(t, a)
+	a = auth.NewAuth("ak", "sk", s.URL)
+	req := &model.CreateDomainRequest{DomainName: "www.example.com"}
+	a.WithAuth(&auth.SignParams{Url: "/api/domain", Method: http.MethodPost, Body: req.Marshal()})
+	res, err := util.Call(context.Background(), a.HttpRequestMsg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusAccepted {
+		t.Fatalf("got status %d: %s", res.StatusCode, res.Body)
+	}
+}
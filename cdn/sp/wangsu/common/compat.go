@@ -0,0 +1,16 @@
+package common
+
+import "github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+
+// Unmarshaler 可从响应体解析的响应
+//
+// Deprecated: 客户端已统一使用encoding/json解析响应体
+type Unmarshaler interface {
+	Unmarshal(data []byte) error
+}
+
+// HeaderUnmarshaler 需要从响应头中获取信息的响应
+type HeaderUnmarshaler = rest.HeaderUnmarshaler
+
+// ServiceResponseError 网宿接口返回的错误,客户端请求失败时返回该类型
+type ServiceResponseError = rest.ServiceError
@@ -3,13 +3,16 @@ package constant
 const (
 	HttpRequestPrefix = "https://open.chinanetcenter.com"
 	HttpRequestDomain = "open.chinanetcenter.com"
+	HttpRequestScheme = "https"
 	ApplicationJson   = "application/json"
 	HeadSignAccessKey = "x-cnc-accessKey"
 	HeadSignTimeStamp = "x-cnc-timestamp"
 	HeadSignAlgorithm = "CNC-HMAC-SHA256"
 	XCncAuthMethod    = "x-cnc-auth-method"
+	XCncRequestId     = "x-cnc-request-id"
 	Authorization     = "Authorization"
 	ContentType       = "Content-Type"
+	Location          = "Location"
 	Host              = "Host"
 	AKSK              = "AKSK"
 )
@@ -29,7 +29,3 @@ func (o *AccessSpeedRequest) Marshal() []byte {
 	}
 	return data
 }
-
-func (o *AccessSpeedResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
-}
@@ -48,7 +48,3 @@ func (o *CacheKeyRequest) Marshal() []byte {
 	}
 	return b
 }
-
-func (o *CacheKeyResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
-}
@@ -35,7 +35,3 @@ func (o *CacheTimeRequest) Marshal() []byte {
 	}
 	return data
 }
-
-func (o *CacheTimeResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
-}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+	"path"
+)
+
+type CreateCertificateRequest struct {
+	//证书名称，不允许重名
+	Name string `json:"name"`
+	//证书内容，PEM格式
+	Certificate string `json:"certificate"`
+	//证书私钥，PEM格式
+	PrivateKey string `json:"private-key"`
+	//备注信息
+	Comment string `json:"comment"`
+}
+
+type CreateCertificateResponse struct {
+	//httpstatus=202; 表示成功调用新增证书接口
+	HttpStatusCode int `json:"http-status-code"`
+	//唯一标示的id，用于查询每次请求的任务 （适用全部接口）
+	XCncRequestId string `json:"x-cnc-request-id"`
+	//用于访问该证书信息的URL，其中最后一段为证书ID
+	Location string `json:"location"`
+	//证书ID，从Location中解析
+	CertificateId string `json:"certificate-id"`
+	//错误代码，当HTTPStatus不为202时出现，表示当前请求调用的错误类型
+	Code int `json:"code"`
+	//响应信息，成功时为success
+	Message string `json:"message"`
+}
+
+func (o *CreateCertificateRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (o *CreateCertificateResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
+	o.Location = header.Get(constant.Location)
+	if o.Location != "" {
+		o.CertificateId = path.Base(o.Location)
+	}
+}
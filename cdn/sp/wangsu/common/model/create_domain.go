@@ -1,6 +1,10 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
 
 type CreateDomainRequest struct {
 	// 版本号，当前版本号1.0.0
@@ -37,6 +41,27 @@ type CreateDomainRequest struct {
 type OriginConfig struct {
 	OriginIps               string `json:"origin-ips"`
 	DefaultOriginHostHeader string `json:"default-origin-host-header"`
+	//以下字段只在查询域名详情时返回,修改时通过 SrcConfigRequest 设置
+	//回源协议：http、https或follow（跟随请求协议）
+	OriginProtocol string `json:"origin-protocol,omitempty"`
+	//是否开启Range回源
+	UseRange *bool `json:"use-range,omitempty"`
+	//回源是否跟随301、302跳转
+	Follow301 *bool `json:"follow301,omitempty"`
+	Follow302 *bool `json:"follow302,omitempty"`
+	//高级回源配置，开启主备源站时返回
+	AdvOriginConfigs *AdvOriginConfigs `json:"adv-origin-configs,omitempty"`
+}
+
+type AdvOriginConfigs struct {
+	AdvOriginConfig []*AdvOriginConfig `json:"adv-origin-config"`
+}
+
+type AdvOriginConfig struct {
+	//主源站地址，多个以分号分隔
+	MasterIps string `json:"master-ips"`
+	//备源站地址，多个以分号分隔
+	BackupIps string `json:"backup-ips"`
 }
 
 type CreateDomainResponse struct {
@@ -62,6 +87,8 @@ func (o *CreateDomainRequest) Marshal() []byte {
 	return data
 }
 
-func (o *CreateDomainResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
+func (o *CreateDomainResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
+	o.Location = header.Get(constant.Location)
 }
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
+
+type DeleteDomainRequest struct {
+	//加速域名名称
+	DomainName string `path:"domain-name" json:"-"`
+}
+
+type DeleteDomainResponse struct {
+	//httpstatus=202; 表示成功调用删除域名接口，可使用header中的x-cnc-request-id查看当前删除域名的部署情况
+	HttpStatusCode int `json:"http-status-code"`
+	//唯一标示的id，用于查询每次请求的任务 （适用全部接口）
+	XCncRequestId string `json:"x-cnc-request-id"`
+	//错误代码，当HTTPStatus不为202时出现，表示当前请求调用的错误类型
+	Code int `json:"code"`
+	//响应信息，成功时为success
+	Message string `json:"message"`
+}
+
+func (o *DeleteDomainResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
+}
@@ -1,6 +1,10 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
 
 type DisableDomainRequest struct {
 	DomainName string `json:"domain-name"`
@@ -25,6 +29,7 @@ func (o *DisableDomainRequest) Marshal() []byte {
 	return data
 }
 
-func (o *DisableDomainResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
+func (o *DisableDomainResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
 }
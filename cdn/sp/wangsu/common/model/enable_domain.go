@@ -1,6 +1,10 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
 
 type EnableDomainRequest struct {
 	//加速域名名称，每次启用的域名总数上限为20个，多个域名以逗号分隔
@@ -26,6 +30,7 @@ func (o *EnableDomainRequest) Marshal() []byte {
 	return data
 }
 
-func (o *EnableDomainResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
+func (o *EnableDomainResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
 }
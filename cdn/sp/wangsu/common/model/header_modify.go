@@ -1,6 +1,10 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
 
 type HeaderModifyRequest struct {
 	DomainName        string              `path:"domain-name" json:"-"`
@@ -102,6 +106,8 @@ func (o *HeaderModifyRequest) Marshal() []byte {
 	return data
 }
 
-func (o *HeaderModifyResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
+func (o *HeaderModifyResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
+	o.Location = header.Get(constant.Location)
 }
@@ -24,7 +24,3 @@ func (o *HttpCodeCacheRequest) Marshal() []byte {
 	}
 	return b
 }
-
-func (o *HttpCodeCacheResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
-}
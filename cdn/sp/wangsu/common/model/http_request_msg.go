@@ -1,5 +1,8 @@
 package model
 
+// HttpRequestMsg 已签名的请求信息
+//
+// Deprecated: 客户端已改为基于http.Request签名发送,仅为兼容auth.Auth.WithAuth与util.Call保留
 type HttpRequestMsg struct {
 	Uri           string
 	Url           string
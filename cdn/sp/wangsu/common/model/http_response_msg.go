@@ -0,0 +1,12 @@
+package model
+
+import "net/http"
+
+// HttpResponseMsg util.Call返回的响应信息
+//
+// Deprecated: 仅为兼容util.Call保留
+type HttpResponseMsg struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
@@ -1,6 +1,10 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
 
 type InnerRedirectRequest struct {
 	DomainName          string                `path:"domain-name" json:"-"`
@@ -66,6 +70,7 @@ func (o *InnerRedirectRequest) Marshal() []byte {
 	return data
 }
 
-func (o *InnerRedirectResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
+func (o *InnerRedirectResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
 }
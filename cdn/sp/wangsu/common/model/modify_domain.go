@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
+
+type ModifyDomainRequest struct {
+	DomainName string `path:"domain-name" json:"-"`
+	// 版本号，当前版本号1.0.0
+	Version string `json:"version"`
+	//加速域名的加速区域，多个区域以分号分隔，支持配置的区域如下：cn（中国大陆）、am（美洲）、emea（欧洲、中东、非洲）、apac（亚太地区）
+	ServiceAreas *string `json:"service-areas,omitempty"`
+	//源站地址
+	OriginConfig *OriginConfig `json:"origin-config,omitempty"`
+	//https配置
+	Ssl *SslConfig `json:"ssl,omitempty"`
+}
+
+type SslConfig struct {
+	//是否开启https
+	UseSsl bool `json:"use-ssl"`
+	//是否使用SNI
+	UseForSni bool `json:"use-for-sni"`
+	//证书ID，通过新增证书接口获得
+	SslCertificateId string `json:"ssl-certificate-id,omitempty"`
+	//支持的tls版本，多个以分号分隔，如：TLSv1.2;TLSv1.3
+	TlsVersion string `json:"tls-version,omitempty"`
+	//是否开启http2
+	EnableHttp2 bool `json:"enable-http2"`
+	//是否开启ocsp
+	EnableOcsp bool `json:"enable-ocsp"`
+}
+
+type ModifyDomainResponse struct {
+	//httpstatus=202; 表示成功调用修改域名接口，可使用header中的x-cnc-request-id查看当前修改域名的部署情况
+	HttpStatusCode int `json:"http-status-code"`
+	//唯一标示的id，用于查询每次请求的任务 （适用全部接口）
+	XCncRequestId string `json:"x-cnc-request-id"`
+	//错误代码，当HTTPStatus不为202时出现，表示当前请求调用的错误类型
+	Code int `json:"code"`
+	//响应信息，成功时为success
+	Message string `json:"message"`
+}
+
+func (o *ModifyDomainRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (o *ModifyDomainResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
+}
@@ -0,0 +1,59 @@
+package model
+
+import "encoding/json"
+
+type PrefetchRequest struct {
+	//需要预热的url列表，单次最多提交400条
+	Urls []string `json:"urls"`
+}
+
+type PrefetchResponse struct {
+	//返回码，1表示成功
+	Code int `json:"Code"`
+	//响应信息
+	Message string `json:"Message"`
+	//预热任务ID，用于查询预热结果
+	ItemId string `json:"itemId"`
+}
+
+type PrefetchQueryRequest struct {
+	//预热任务ID，与时间范围二选一
+	ItemId string `json:"itemId,omitempty"`
+	//查询开始时间，格式如：2006-01-02 15:04:05
+	StartTime string `json:"startTime,omitempty"`
+	//查询结束时间，格式如：2006-01-02 15:04:05
+	EndTime string `json:"endTime,omitempty"`
+	//任务状态：SUCCESS、FAILURE、PROCESSING
+	Status string `json:"status,omitempty"`
+	//页码，从1开始
+	PageNumber int64 `json:"pageNumber,omitempty"`
+	//每页条数
+	PageSize int64 `json:"pageSize,omitempty"`
+}
+
+type PrefetchQueryResponse struct {
+	//返回码，1表示成功
+	Code int `json:"code"`
+	//响应信息
+	Message string `json:"message"`
+	//总条数
+	Total int64 `json:"total"`
+	//结果明细
+	ResultDetail []*TaskResultDetail `json:"resultDetail"`
+}
+
+func (o *PrefetchRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (o *PrefetchQueryRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,80 @@
+package model
+
+import "encoding/json"
+
+type PurgeRequest struct {
+	//需要刷新的url列表，单次最多提交1000条
+	Urls []string `json:"urls,omitempty"`
+	//需要刷新的目录列表，单次最多提交500条
+	Dirs []string `json:"dirs,omitempty"`
+	//url刷新方式：delete表示删除缓存，expire表示过期缓存，默认delete
+	UrlAction string `json:"urlAction,omitempty"`
+	//目录刷新方式：delete表示删除缓存，expire表示过期缓存，默认expire
+	DirAction string `json:"dirAction,omitempty"`
+}
+
+type PurgeResponse struct {
+	//返回码，1表示成功
+	Code int `json:"Code"`
+	//响应信息
+	Message string `json:"Message"`
+	//刷新任务ID，用于查询刷新结果
+	ItemId string `json:"itemId"`
+}
+
+type PurgeQueryRequest struct {
+	//刷新任务ID，与时间范围二选一
+	ItemId string `json:"itemId,omitempty"`
+	//查询开始时间，格式如：2006-01-02 15:04:05
+	StartTime string `json:"startTime,omitempty"`
+	//查询结束时间，格式如：2006-01-02 15:04:05
+	EndTime string `json:"endTime,omitempty"`
+	//刷新类型：url、dir
+	Type string `json:"type,omitempty"`
+	//任务状态：SUCCESS、FAILURE、PROCESSING
+	Status string `json:"status,omitempty"`
+	//页码，从1开始
+	PageNumber int64 `json:"pageNumber,omitempty"`
+	//每页条数
+	PageSize int64 `json:"pageSize,omitempty"`
+}
+
+type PurgeQueryResponse struct {
+	//返回码，1表示成功
+	Code int `json:"code"`
+	//响应信息
+	Message string `json:"message"`
+	//总条数
+	Total int64 `json:"total"`
+	//结果明细
+	ResultDetail []*TaskResultDetail `json:"resultDetail"`
+}
+
+type TaskResultDetail struct {
+	//任务ID
+	ItemId string `json:"itemId"`
+	//url或目录
+	Url string `json:"url"`
+	//类型：url、dir
+	Type string `json:"type"`
+	//任务状态：SUCCESS、FAILURE、PROCESSING、UNPROCESSED
+	Status string `json:"status"`
+	//任务创建时间
+	CreateTime string `json:"createTime"`
+}
+
+func (o *PurgeRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (o *PurgeQueryRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
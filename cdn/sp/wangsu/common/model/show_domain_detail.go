@@ -0,0 +1,56 @@
+package model
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
+
+type ShowDomainDetailRequest struct {
+	//加速域名名称
+	DomainName string `path:"domain-name" json:"-"`
+}
+
+type ShowDomainDetailResponse struct {
+	//唯一标示的id，用于查询每次请求的任务 （适用全部接口）
+	XCncRequestId string `json:"-"`
+	//域名ID
+	DomainId string `json:"domain-id"`
+	//加速域名的名称
+	DomainName string `json:"domain-name"`
+	//加速域名的服务类型，取值参考新增域名接口
+	ServiceType string `json:"service-type"`
+	//加速域名的加速区域，多个区域以分号分隔：cn（中国大陆）、am（美洲）、emea（欧洲、中东、非洲）、apac（亚太地区）
+	ServiceAreas string `json:"service-areas"`
+	//加速域名对应的CNAME域名
+	Cname string `json:"cname"`
+	//加速域名的部署状态：Deployed表示该加速域名配置完成部署；InProgress表示该加速域名配置的部署任务还在进行中
+	Status string `json:"status"`
+	//加速域名的CDN服务状态：当取消加速域名CDN服务后，此项为false；当恢复加速域名CDN服务后，此项为true
+	CdnServiceStatus string `json:"cdn-service-status"`
+	//加速域名的启用状态：当禁用加速域名服务后，此项为false；当启用加速域名服务后，此项为true
+	Enabled string `json:"enabled"`
+	//源站配置
+	OriginConfig *OriginConfig `json:"origin-config"`
+	//https配置
+	Ssl *SslConfig `json:"ssl"`
+	//缓存时间配置
+	CacheTimeBehaviors []*CacheTimeBehavior `json:"cache-time-behaviors"`
+	//状态码缓存配置
+	HttpCodeCacheRules []*HttpCodeCacheRule `json:"http-code-cache-rules"`
+	//http头配置
+	HeaderModifyRules []*HeaderModifyRule `json:"header-modify-rules"`
+	//访问控制配置
+	VisitControlRules []*VisitControlRule `json:"visit-control-rules"`
+	//内部重定向配置
+	RewriteRuleSettings []*RewriteRuleSetting `json:"rewrite-rule-settings"`
+	//限速配置
+	AccessSpeedRules []AccessSpeedRule `json:"access-speed-rules"`
+	//创建时间，格式如：2006-01-02T15:04:05+08:00
+	CreatedDate string `json:"created-date"`
+	//最后修改时间，格式如：2006-01-02T15:04:05+08:00
+	LastModified string `json:"last-modified"`
+}
+
+func (o *ShowDomainDetailResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
+}
@@ -9,6 +9,11 @@ type ShowDomainListRequest struct {
 }
 
 type ShowDomainListResponse struct {
+	//域名列表
+	DomainSummary []*DomainSummary `json:"domain-summary"`
+}
+
+type DomainSummary struct {
 	//域名ID
 	DomainID string `json:"domain-id"`
 	//加速域名的名称
@@ -38,7 +43,3 @@ func (o *ShowDomainListRequest) Marshal() []byte {
 	}
 	return data
 }
-
-func (o *ShowDomainListResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
-}
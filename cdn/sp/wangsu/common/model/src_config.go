@@ -1,13 +1,19 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
 
 type SrcConfigRequest struct {
-	DomainName    string         `json:"domainName"`
-	UseRange      *bool          `json:"useRange,omitempty"`
-	Follow301     *bool          `json:"follow301,omitempty"`
-	Follow302     *bool          `json:"follow302,omitempty"`
-	AdvSrcSetting *AdvSrcSetting `json:"advSrcSetting"`
+	DomainName string `json:"domainName"`
+	UseRange   *bool  `json:"useRange,omitempty"`
+	Follow301  *bool  `json:"follow301,omitempty"`
+	Follow302  *bool  `json:"follow302,omitempty"`
+	//回源协议：http、https或follow（跟随请求协议）
+	OriginProtocol string         `json:"originProtocol,omitempty"`
+	AdvSrcSetting  *AdvSrcSetting `json:"advSrcSetting"`
 }
 
 type AdvSrcSetting struct {
@@ -41,6 +47,7 @@ func (o *SrcConfigRequest) Marshal() []byte {
 	return data
 }
 
-func (o *SrcConfigResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
+func (o *SrcConfigResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
 }
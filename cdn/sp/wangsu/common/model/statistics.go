@@ -0,0 +1,133 @@
+package model
+
+import "encoding/json"
+
+type DomainStatisticsRequest struct {
+	//域名列表
+	DomainList []string `json:"domain-list"`
+	//开始时间，格式如：2006-01-02T15:04:05+08:00
+	StartTime string `json:"start-time"`
+	//结束时间，格式如：2006-01-02T15:04:05+08:00
+	EndTime string `json:"end-time"`
+	//数据类型：flow、bandwidth、request、hit-request、hit-flow、fail-request、2xx、3xx、4xx、5xx
+	DataType string `json:"data-type"`
+	//数据来源：edge表示边缘访问数据，origin表示回源数据
+	DataSource string `json:"data-source"`
+	//时间粒度：fiveminutes、hourly、daily
+	Granularity string `json:"granularity,omitempty"`
+	//区域：cn表示中国大陆，overseas表示中国境外，不传表示全部
+	Region string `json:"region,omitempty"`
+	//运营商：dx、yd、lt、tt、jyw、other，不传表示全部
+	Isp string `json:"isp,omitempty"`
+}
+
+type DomainStatisticsResponse struct {
+	//返回码，0表示成功
+	Code string `json:"code"`
+	//响应信息
+	Message string `json:"message"`
+	//数据
+	Data []*DomainStatisticsData `json:"data"`
+}
+
+type DomainStatisticsData struct {
+	//域名
+	Domain string `json:"domain"`
+	//时间范围内的汇总值
+	Total float64 `json:"total"`
+	//明细数据
+	Details []*StatisticsDetail `json:"details"`
+}
+
+type StatisticsDetail struct {
+	//时间点，格式如：2006-01-02T15:04:05+08:00
+	Time string `json:"time"`
+	//值
+	Value float64 `json:"value"`
+}
+
+type TopUrlRequest struct {
+	//域名
+	Domain string `json:"domain"`
+	//开始时间，格式如：2006-01-02T15:04:05+08:00
+	StartTime string `json:"start-time"`
+	//结束时间，格式如：2006-01-02T15:04:05+08:00
+	EndTime string `json:"end-time"`
+	//排序依据：flow、request
+	TopType string `json:"top-type"`
+	//区域：cn表示中国大陆，overseas表示中国境外，不传表示全部
+	Region string `json:"region,omitempty"`
+	//返回条数，最大1000
+	Limit int64 `json:"limit"`
+}
+
+type TopUrlResponse struct {
+	//返回码，0表示成功
+	Code string `json:"code"`
+	//响应信息
+	Message string `json:"message"`
+	//数据
+	Data []*TopUrlData `json:"data"`
+}
+
+type TopUrlData struct {
+	Url   string  `json:"url"`
+	Value float64 `json:"value"`
+}
+
+type RegionStatisticsRequest struct {
+	//域名列表
+	DomainList []string `json:"domain-list"`
+	//开始时间，格式如：2006-01-02T15:04:05+08:00
+	StartTime string `json:"start-time"`
+	//结束时间，格式如：2006-01-02T15:04:05+08:00
+	EndTime string `json:"end-time"`
+	//数据类型：flow、request
+	DataType string `json:"data-type"`
+}
+
+type RegionStatisticsResponse struct {
+	//返回码，0表示成功
+	Code string `json:"code"`
+	//响应信息
+	Message string `json:"message"`
+	//数据
+	Data []*RegionStatisticsData `json:"data"`
+}
+
+type RegionStatisticsData struct {
+	//域名
+	Domain string `json:"domain"`
+	//各区域数据
+	Regions []*RegionValue `json:"regions"`
+}
+
+type RegionValue struct {
+	//区域：cn表示中国大陆，其他为境外区域
+	Region string  `json:"region"`
+	Value  float64 `json:"value"`
+}
+
+func (o *DomainStatisticsRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (o *TopUrlRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (o *RegionStatisticsRequest) Marshal() []byte {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,120 @@
+package model
+
+import "encoding/json"
+
+// 以下Unmarshal方法为兼容common.Unmarshaler保留,客户端已统一解析响应体,不再调用
+
+// Deprecated: 使用encoding/json解析
+func (o *AccessSpeedResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *CacheKeyResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *CacheTimeResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *CreateCertificateResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *CreateDomainResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *DeleteDomainResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *DisableDomainResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *EnableDomainResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *HeaderModifyResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *HttpCodeCacheResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *InnerRedirectResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *ModifyDomainResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *PrefetchResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *PrefetchQueryResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *PurgeResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *PurgeQueryResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *ShowDomainDetailResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *ShowDomainListResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *SrcConfigResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *DomainStatisticsResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *TopUrlResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *RegionStatisticsResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Deprecated: 使用encoding/json解析
+func (o *VisitControlResponse) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, o)
+}
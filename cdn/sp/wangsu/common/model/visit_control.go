@@ -1,6 +1,10 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"net/http"
+)
 
 type VisitControlRequest struct {
 	DomainName string `path:"domain-name" json:"-"`
@@ -171,6 +175,8 @@ func (o *VisitControlRequest) Marshal() []byte {
 	return data
 }
 
-func (o *VisitControlResponse) Unmarshal(data []byte) error {
-	return json.Unmarshal(data, o)
+func (o *VisitControlResponse) UnmarshalHeader(statusCode int, header http.Header) {
+	o.HttpStatusCode = statusCode
+	o.XCncRequestId = header.Get(constant.XCncRequestId)
+	o.Location = header.Get(constant.Location)
 }
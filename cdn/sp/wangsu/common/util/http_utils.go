@@ -1,32 +1,36 @@
 package util
 
 import (
-	"fmt"
+	"context"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
 	"io"
 	"net/http"
 	"strings"
 )
 
-func Call(requestMsg *model.HttpRequestMsg) ([]byte, error) {
-	client := http.DefaultClient
-	req, err := http.NewRequest(requestMsg.Method, requestMsg.Url, strings.NewReader(requestMsg.Body))
+// Call 使用http.DefaultClient发送auth.Auth.WithAuth生成的请求
+//
+// Deprecated: 使用common.Client,支持自定义HTTP客户端并将错误响应解析为rest.ServiceError
+func Call(ctx context.Context, requestMsg *model.HttpRequestMsg) (*model.HttpResponseMsg, error) {
+	req, err := http.NewRequestWithContext(ctx, requestMsg.Method, requestMsg.Url, strings.NewReader(requestMsg.Body))
 	if err != nil {
 		return nil, err
 	}
 	for key := range requestMsg.Headers {
 		req.Header.Set(key, requestMsg.Headers[key])
 	}
-	resp, err := client.Do(req)
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Println(err)
-		}
-	}(resp.Body)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	return body, nil
+	return &model.HttpResponseMsg{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}, nil
 }
@@ -0,0 +1,287 @@
+package wangsu
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ShowDomainConfig 获取域名完整配置
+func (w *Wangsu) ShowDomainConfig(data *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	return w.ShowDomainConfigWithContext(w.ctx, data)
+}
+
+// ShowDomainConfigWithContext 获取域名完整配置,网宿只返回证书ID,证书内容与私钥为空,
+// 不支持的配置项为nil
+func (w *Wangsu) ShowDomainConfigWithContext(ctx context.Context, data *types.ShowDomainConfigRequest) (*types.UpdateDomainRequest, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowDomainDetailResponse, error) {
+		return w.client.ShowDomainDetail(ctx, &model.ShowDomainDetailRequest{DomainName: data.Domain})
+	})
+	if err != nil {
+		return nil, err
+	}
+	c := &DomainConfigModel{detail: res, req: &types.UpdateDomainRequest{
+		UpdateAction: types.UpdateFullConf,
+		Domain:       data.Domain,
+		DomainId:     res.DomainId,
+	}}
+	c.ReadArea()
+	c.ReadOriginConf()
+	c.ReadOriginServerConf()
+	c.ReadHeaderModifyConf()
+	c.ReadVisitControlConf()
+	c.ReadSpeedConf()
+	c.ReadCacheListConf()
+	c.ReadCacheCodeConf()
+	c.ReadRequestUrlRewriteConf()
+	c.ReadHttpsConf()
+	return c.req, nil
+}
+
+// DomainConfigModel 将网宿域名配置反向映射为通用的更新请求,与 UpdateDomainConfigModel 的With*方法一一对应
+type DomainConfigModel struct {
+	detail *model.ShowDomainDetailResponse
+	req    *types.UpdateDomainRequest
+}
+
+func (c *DomainConfigModel) ReadArea() {
+	c.req.CdnDomain = &entity.UpdateCdnDomainBaseConf{AreaCode: mapServiceAreas(c.detail.ServiceAreas)}
+}
+
+// ReadOriginConf 网宿不支持回源Sni,跟随301与302跳转需同时开启
+func (c *DomainConfigModel) ReadOriginConf() {
+	origin := c.detail.OriginConfig
+	if origin == nil {
+		return
+	}
+	c.req.OriginConf = &entity.OriginConf{
+		OriginProtocol: mapOriginProtocol(origin.OriginProtocol),
+		OriginRange:    types.OFF,
+		OriginFollow:   types.OFF,
+	}
+	if utils.BoolValue(origin.UseRange) {
+		c.req.OriginConf.OriginRange = types.ON
+	}
+	if utils.BoolValue(origin.Follow301) && utils.BoolValue(origin.Follow302) {
+		c.req.OriginConf.OriginFollow = types.ON
+	}
+}
+
+// ReadOriginServerConf 网宿源站地址以分号分隔,主源站来自源站配置,备源站来自高级回源配置,
+// 回源host对所有源站生效
+func (c *DomainConfigModel) ReadOriginServerConf() {
+	c.req.OriginServerConf = make([]*entity.OriginServerConf, 0)
+	origin := c.detail.OriginConfig
+	if origin == nil {
+		return
+	}
+	c.appendOrigins(origin.OriginIps, consts.OriginPriorityPrimary)
+	if origin.AdvOriginConfigs == nil {
+		return
+	}
+	for _, v := range origin.AdvOriginConfigs.AdvOriginConfig {
+		c.appendOrigins(v.BackupIps, consts.OriginPriorityBackup)
+	}
+}
+
+func (c *DomainConfigModel) appendOrigins(ips string, priority int64) {
+	for _, v := range utils.SplitString(ips, ";") {
+		originType := int64(consts.OriginTypeDomain)
+		if net.ParseIP(v) != nil {
+			originType = consts.OriginTypeIp
+		}
+		c.req.OriginServerConf = append(c.req.OriginServerConf, &entity.OriginServerConf{
+			OriginType:        originType,
+			OriginAddressList: v,
+			OriginHost:        c.detail.OriginConfig.DefaultOriginHostHeader,
+			OriginPriority:    priority,
+		})
+	}
+}
+
+// ReadHeaderModifyConf 按头部方向拆分为回源请求头与响应头
+func (c *DomainConfigModel) ReadHeaderModifyConf() {
+	c.req.OriginRequestHeaderConf = make([]*entity.OriginRequestHeaderConf, 0)
+	c.req.ResponseHeaderConf = make([]*entity.ResponseHeaderConf, 0)
+	for _, v := range c.detail.HeaderModifyRules {
+		switch v.HeaderDirection {
+		case "cache2origin":
+			c.req.OriginRequestHeaderConf = append(c.req.OriginRequestHeaderConf, &entity.OriginRequestHeaderConf{
+				Action:         mapHeaderAction(v.Action),
+				ParameterKey:   v.HeaderName,
+				ParameterValue: v.HeaderValue,
+			})
+		case "cache2visitor":
+			c.req.ResponseHeaderConf = append(c.req.ResponseHeaderConf, &entity.ResponseHeaderConf{
+				Action:         mapHeaderAction(v.Action),
+				ParameterKey:   v.HeaderName,
+				ParameterValue: v.HeaderValue,
+			})
+		}
+	}
+}
+
+// ReadVisitControlConf 访问控制规则按类型拆分为IP黑白名单、防盗链与UA黑白名单
+func (c *DomainConfigModel) ReadVisitControlConf() {
+	c.req.IpFilterConf = &types.IpFilterConf{Status: consts.SwitchOff}
+	c.req.RefererConf = &entity.Referer{Status: consts.SwitchOff}
+	c.req.UserAgentConf = &types.UserAgentConf{Status: consts.SwitchOff}
+	for _, v := range c.detail.VisitControlRules {
+		effectiveType, effectiveRules := mapPathPattern(v.PathPattern)
+		if rule := v.IpControlRule; rule != nil && (rule.AllowedIps != "" || rule.ForbiddenIps != "") {
+			ipFilter := &entity.IpFilter{
+				IpType:         consts.BlackList,
+				IpList:         utils.SplitString(rule.ForbiddenIps, ";"),
+				EffectiveType:  effectiveType,
+				EffectiveRules: effectiveRules,
+			}
+			if rule.AllowedIps != "" {
+				ipFilter.IpType = consts.WhiteList
+				ipFilter.IpList = utils.SplitString(rule.AllowedIps, ";")
+			}
+			c.req.IpFilterConf.Status = consts.SwitchOn
+			c.req.IpFilterConf.IpFilterConf = append(c.req.IpFilterConf.IpFilterConf, ipFilter)
+		}
+		if rule := v.RefererControlRule; rule != nil && (rule.ValidReferer != "" || rule.InvalidReferer != "") {
+			c.req.RefererConf.Status = consts.SwitchOn
+			c.req.RefererConf.RefererType = consts.BlackList
+			c.req.RefererConf.RefererList = strings.Fields(rule.InvalidReferer)
+			if rule.ValidReferer != "" {
+				c.req.RefererConf.RefererType = consts.WhiteList
+				c.req.RefererConf.RefererList = strings.Fields(rule.ValidReferer)
+			}
+			if rule.AllowNullReferer == "true" {
+				c.req.RefererConf.IncludeEmpty = consts.SwitchOn
+			}
+		}
+		if rule := v.UaControlRule; rule != nil && (rule.ValidUserAgents != "" || rule.InvalidUserAgents != "") {
+			userAgent := &entity.UserAgent{
+				AgentType:      consts.BlackList,
+				AgentList:      mapUserAgents(rule.InvalidUserAgents),
+				EffectiveType:  effectiveType,
+				EffectiveRules: effectiveRules,
+			}
+			if rule.ValidUserAgents != "" {
+				userAgent.AgentType = consts.WhiteList
+				userAgent.AgentList = mapUserAgents(rule.ValidUserAgents)
+			}
+			c.req.UserAgentConf.Status = consts.SwitchOn
+			c.req.UserAgentConf.UserAgentConf = append(c.req.UserAgentConf.UserAgentConf, userAgent)
+		}
+	}
+}
+
+// mapUserAgents 网宿UA规则以|分隔,空格以\s代替
+func mapUserAgents(agents string) []string {
+	return utils.SplitString(strings.ReplaceAll(agents, `\s`, " "), "|")
+}
+
+func (c *DomainConfigModel) ReadSpeedConf() {
+	c.req.SpeedConf = &types.SpeedConf{Status: consts.SwitchOff}
+	for _, v := range c.detail.AccessSpeedRules {
+		ruleType, ruleContent := mapPathPattern(v.PathPattern)
+		speed, _ := strconv.ParseInt(v.Speed, 10, 64)
+		c.req.SpeedConf.Status = consts.SwitchOn
+		c.req.SpeedConf.SpeedConf = append(c.req.SpeedConf.SpeedConf, &entity.SpeedConf{
+			RuleType:    ruleType,
+			RuleContent: ruleContent,
+			SpeedValues: speed,
+		})
+	}
+}
+
+func (c *DomainConfigModel) ReadCacheListConf() {
+	c.req.CacheListConf = make([]*entity.CacheListItem, 0)
+	for _, v := range c.detail.CacheTimeBehaviors {
+		cacheType, cacheContent := mapPathPattern(v.PathPattern)
+		seconds, _ := strconv.ParseInt(v.CacheTTL, 10, 64)
+		item := &entity.CacheListItem{
+			CacheType:    cacheType,
+			CacheContent: cacheContent,
+			CacheStatus:  consts.CacheStatusOn,
+			Priority:     int64(v.Priority),
+		}
+		item.CacheTTL, item.CacheUnit = mapCacheTime(seconds)
+		switch {
+		case utils.BoolValue(v.IsRespectServer):
+			item.CacheStatus = consts.CacheStatusFollow
+		case seconds == 0:
+			item.CacheStatus = consts.CacheStatusOff
+		}
+		c.req.CacheListConf = append(c.req.CacheListConf, item)
+	}
+}
+
+// ReadCacheCodeConf 相同缓存时间的状态码合并在一条规则中,按状态码拆分
+func (c *DomainConfigModel) ReadCacheCodeConf() {
+	c.req.CacheCodeConf = make([]*entity.CacheCodeListItem, 0)
+	for _, v := range c.detail.HttpCodeCacheRules {
+		seconds, _ := strconv.ParseInt(v.CacheTtl, 10, 64)
+		ttl, unit := mapCacheTime(seconds)
+		for _, code := range v.HttpCodes {
+			httpCode, err := strconv.ParseInt(code, 10, 64)
+			if err != nil {
+				continue
+			}
+			c.req.CacheCodeConf = append(c.req.CacheCodeConf, &entity.CacheCodeListItem{
+				HttpCode:  httpCode,
+				CacheTTL:  ttl,
+				CacheUnit: unit,
+			})
+		}
+	}
+}
+
+func (c *DomainConfigModel) ReadRequestUrlRewriteConf() {
+	c.req.RequestUrlRewriteConf = make([]*entity.RequestUrlRewriteConf, 0)
+	const urlPrefix = `^https?://[^/]+`
+	for _, v := range c.detail.RewriteRuleSettings {
+		item := &entity.RequestUrlRewriteConf{
+			MateMethod:   consts.RequestUrlRewriteTypeFullPath,
+			RedirectCode: consts.RedirectCode302,
+			TargetUrl:    v.AfterValue,
+			Priority:     int64(v.Priority),
+		}
+		beforeValue := strings.TrimPrefix(v.BeforeValue, urlPrefix)
+		if strings.HasSuffix(beforeValue, "(.*)$") {
+			item.MateMethod = consts.RequestUrlRewriteTypeDirectory
+			beforeValue = strings.TrimSuffix(beforeValue, "(.*)$")
+			item.TargetUrl = strings.TrimSuffix(item.TargetUrl, "$1")
+		}
+		item.RewriteUrl = unquoteMeta(strings.TrimSuffix(beforeValue, "$"))
+		if code, target, ok := strings.Cut(item.TargetUrl, ":"); ok && (code == "301" || code == "302") {
+			item.RedirectCode = mapRedirectCode(code)
+			item.TargetUrl = target
+		}
+		c.req.RequestUrlRewriteConf = append(c.req.RequestUrlRewriteConf, item)
+	}
+}
+
+// ReadHttpsConf 网宿只返回证书ID,证书内容与私钥为空
+func (c *DomainConfigModel) ReadHttpsConf() {
+	c.req.HttpsConf = &entity.HttpsConf{HttpsStatus: consts.SwitchOff}
+	ssl := c.detail.Ssl
+	if ssl == nil || !ssl.UseSsl {
+		return
+	}
+	c.req.HttpsConf.HttpsStatus = consts.SwitchOn
+	c.req.HttpsConf.TlsVersion = mapTlsVersions(ssl.TlsVersion)
+	if ssl.EnableHttp2 {
+		c.req.HttpsConf.HttpTwo = consts.SwitchOn
+	}
+	if ssl.EnableOcsp {
+		c.req.HttpsConf.OcspStatus = consts.SwitchOn
+	}
+}
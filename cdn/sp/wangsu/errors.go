@@ -0,0 +1,87 @@
+package wangsu
+
+import (
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"strings"
+)
+
+// wrapError 将网宿接口错误转换为统一错误
+func wrapError(err error) error {
+	var e *rest.ServiceError
+	if !errors.As(err, &e) {
+		return err
+	}
+	return &cdnerr.Error{
+		Kind:       getErrorKind(e.ErrorCode, e.StatusCode),
+		Provider:   types.WangsuSdkName,
+		Code:       e.ErrorCode,
+		Message:    e.ErrorMessage,
+		RequestId:  e.RequestId,
+		StatusCode: e.StatusCode,
+		Temporary:  e.StatusCode >= http.StatusInternalServerError,
+		Err:        err,
+	}
+}
+
+// newResponseError 接口返回业务失败码时生成统一错误
+func newResponseError(code string, message string) error {
+	kind := getErrorKind(code, 0)
+	if kind == nil {
+		kind = cdnerr.ErrProvider
+	}
+	return &cdnerr.Error{
+		Kind:     kind,
+		Provider: types.WangsuSdkName,
+		Code:     code,
+		Message:  message,
+	}
+}
+
+// GetErrorKind 错误码转错误分类
+func getErrorKind(code string, statusCode int) error {
+	c := strings.ToLower(code)
+	switch {
+	case strings.Contains(c, "nosuchdomain"), strings.Contains(c, "domainnotexist"), strings.Contains(c, "domainnotfound"):
+		return cdnerr.ErrDomainNotFound
+	case strings.Contains(c, "domainalreadyexist"), strings.Contains(c, "domainexist"):
+		return cdnerr.ErrDomainAlreadyExists
+	case strings.Contains(c, "icp"):
+		return cdnerr.ErrIcpNotFiled
+	case strings.Contains(c, "throttl"), strings.Contains(c, "ratelimit"), strings.Contains(c, "requestlimit"), strings.Contains(c, "toomanyrequests"):
+		return cdnerr.ErrRateLimited
+	case strings.Contains(c, "quota"), strings.Contains(c, "exceed"):
+		return cdnerr.ErrQuotaExceeded
+	case strings.Contains(c, "auth"), strings.Contains(c, "signature"), strings.Contains(c, "accessdenied"):
+		return cdnerr.ErrAuthFailed
+	case strings.Contains(c, "invalid"), strings.Contains(c, "missing"):
+		return cdnerr.ErrInvalidParameter
+	}
+	switch statusCode {
+	case http.StatusNotFound:
+		return cdnerr.ErrDomainNotFound
+	case http.StatusConflict:
+		return cdnerr.ErrDomainAlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return cdnerr.ErrAuthFailed
+	case http.StatusTooManyRequests:
+		return cdnerr.ErrRateLimited
+	case http.StatusBadRequest:
+		return cdnerr.ErrInvalidParameter
+	default:
+		return nil
+	}
+}
+
+// newNotFoundError 查询的任务或统计数据不存在
+func newNotFoundError(message string, requestId string) error {
+	return &cdnerr.Error{
+		Kind:      cdnerr.ErrNotFound,
+		Provider:  types.WangsuSdkName,
+		Message:   message,
+		RequestId: requestId,
+	}
+}
@@ -0,0 +1,65 @@
+package wangsu
+
+import (
+	"errors"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/rest"
+	"net/http"
+	"testing"
+)
+
+func TestWrapError(t *testing.T) {
+	for _, tt := range []struct {
+		code      string
+		status    int
+		kind      error
+		temporary bool
+	}{
+		{"NoSuchDomain", http.StatusBadRequest, cdnerr.ErrDomainNotFound, false},
+		{"DomainNotExist", http.StatusBadRequest, cdnerr.ErrDomainNotFound, false},
+		{"DomainAlreadyExist", http.StatusBadRequest, cdnerr.ErrDomainAlreadyExists, false},
+		{"IcpNotFiled", http.StatusBadRequest, cdnerr.ErrIcpNotFiled, false},
+		{"Throttling", http.StatusBadRequest, cdnerr.ErrRateLimited, false},
+		{"QuotaExceeded", http.StatusBadRequest, cdnerr.ErrQuotaExceeded, false},
+		{"SignatureDoesNotMatch", http.StatusBadRequest, cdnerr.ErrAuthFailed, false},
+		{"AccessDenied", http.StatusBadRequest, cdnerr.ErrAuthFailed, false},
+		{"InvalidParameter", http.StatusBadRequest, cdnerr.ErrInvalidParameter, false},
+		{"MissingParameter", http.StatusBadRequest, cdnerr.ErrInvalidParameter, false},
+		{"", http.StatusNotFound, cdnerr.ErrDomainNotFound, false},
+		{"", http.StatusConflict, cdnerr.ErrDomainAlreadyExists, false},
+		{"", http.StatusUnauthorized, cdnerr.ErrAuthFailed, false},
+		{"", http.StatusTooManyRequests, cdnerr.ErrRateLimited, false},
+		{"", http.StatusBadRequest, cdnerr.ErrInvalidParameter, false},
+		{"InternalError", http.StatusInternalServerError, nil, true},
+		{"", http.StatusBadGateway, nil, true},
+	} {
+		origin := &rest.ServiceError{StatusCode: tt.status, RequestId: "req-1", ErrorCode: tt.code, ErrorMessage: "message"}
+		err := wrapError(origin)
+		var e *cdnerr.Error
+		if !errors.As(err, &e) {
+			t.Fatalf("%s %d: got %T", tt.code, tt.status, err)
+		}
+		if e.Kind != tt.kind || e.Temporary != tt.temporary || e.Code != tt.code || e.RequestId != "req-1" || e.StatusCode != tt.status {
+			t.Errorf("%s %d: got %+v", tt.code, tt.status, e)
+		}
+		var serviceErr *rest.ServiceError
+		if !errors.As(err, &serviceErr) || serviceErr != origin {
+			t.Errorf("%s %d: service error not kept", tt.code, tt.status)
+		}
+	}
+	other := errors.New("network error")
+	if wrapError(other) != other || wrapError(nil) != nil {
+		t.Fatal("non service error changed")
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	for code, kind := range map[string]error{
+		"DomainNotFound": cdnerr.ErrDomainNotFound,
+		"unknown":        cdnerr.ErrProvider,
+	} {
+		if err := newResponseError(code, "failed"); !errors.Is(err, kind) {
+			t.Errorf("%s: got %v, want %v", code, err, kind)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package wangsu
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest/proxy"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport 统计经由的请求数后交给base发送
+type countingTransport struct {
+	base  http.RoundTripper
+	count atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count.Add(1)
+	return c.base.RoundTrip(req)
+}
+
+func TestHttpProxy(t *testing.T) {
+	s := &standIn{
+		domains: make(map[string]*model.ShowDomainDetailResponse),
+		purges:  make(map[string][]string),
+		faults:  make(map[string][]int),
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	s.addDomain("www.example.com")
+	p := proxy.New(s.Listener.Addr().String())
+	t.Cleanup(p.Close)
+	w, err := New(context.Background(), &Config{
+		Ak:       "ak",
+		Sk:       "sk",
+		Endpoint: "https://open.chinanetcenter.com",
+		Http: &httpclient.Options{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+			Proxy:     p.URL,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail, err := w.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Domain != "www.example.com" {
+		t.Fatalf("unexpected detail %+v", detail)
+	}
+	if hosts := p.Hosts(); !reflect.DeepEqual(hosts, []string{"open.chinanetcenter.com:443"}) {
+		t.Fatalf("got proxied hosts %v", hosts)
+	}
+}
+
+func TestHttpTransportAndTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	for _, tt := range []struct {
+		name string
+		http func(rt http.RoundTripper) *httpclient.Options
+	}{
+		{"options", func(rt http.RoundTripper) *httpclient.Options {
+			return &httpclient.Options{Transport: rt, Timeout: 100 * time.Millisecond}
+		}},
+		//未指定Transport与Timeout时使用Client中的设置
+		{"client", func(rt http.RoundTripper) *httpclient.Options {
+			return &httpclient.Options{Client: &http.Client{Transport: rt, Timeout: 100 * time.Millisecond}}
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &countingTransport{base: http.DefaultTransport}
+			w, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Endpoint: srv.URL, Http: tt.http(rt)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := time.Now()
+			if _, err := w.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); err == nil {
+				t.Fatal("want timeout error")
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Fatalf("call returned after %s", elapsed)
+			}
+			if rt.count.Load() == 0 {
+				t.Fatal("custom transport not used")
+			}
+		})
+	}
+}
+
+func TestHttpInvalidProxy(t *testing.T) {
+	for _, opts := range []*httpclient.Options{
+		{Proxy: "://bad"},
+		{Proxy: "127.0.0.1:8080"},
+		{Proxy: "http://127.0.0.1:8080", Transport: &countingTransport{}},
+	} {
+		_, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Http: opts})
+		if !errors.Is(err, cdnerr.ErrInvalidParameter) {
+			t.Fatalf("proxy %q: got %v, want invalid parameter", opts.Proxy, err)
+		}
+	}
+}
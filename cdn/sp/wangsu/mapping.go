@@ -2,7 +2,9 @@ package wangsu
 
 import (
 	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"regexp"
 	"strings"
+	"time"
 )
 
 func getServiceType(channelType int64) string {
@@ -20,6 +22,22 @@ func getServiceType(channelType int64) string {
 	}
 }
 
+// MapServiceType 服务类型转业务类型
+func mapServiceType(serviceType string) int64 {
+	switch {
+	case strings.HasPrefix(serviceType, "web"):
+		return consts.ChannelTypeWeb
+	case strings.HasPrefix(serviceType, "dl"), strings.HasPrefix(serviceType, "download"):
+		return consts.ChannelTypeDownload
+	case strings.HasPrefix(serviceType, "vod"):
+		return consts.ChannelTypeMedia
+	case strings.HasPrefix(serviceType, "wsa"):
+		return consts.ChannelTypeHybrid
+	default:
+		return consts.ChannelTypeWeb
+	}
+}
+
 func getServiceAreas(area int64) string {
 	switch area {
 	case consts.AreaCodeChinaMainland:
@@ -32,3 +50,428 @@ func getServiceAreas(area int64) string {
 		return "cn"
 	}
 }
+
+// MapServiceAreas 加速区域转区域代码
+func mapServiceAreas(serviceAreas string) int64 {
+	if serviceAreas == "" {
+		return consts.AreaCodeChinaMainland
+	}
+	areas := strings.Split(serviceAreas, ";")
+	hasMainland := false
+	for _, v := range areas {
+		if v == "cn" {
+			hasMainland = true
+		}
+	}
+	switch {
+	case hasMainland && len(areas) == 1:
+		return consts.AreaCodeChinaMainland
+	case hasMainland:
+		return consts.AreaCodeGlobal
+	default:
+		return consts.AreaCodeOversea
+	}
+}
+
+// GetDomainStatus 获取域名状态
+func getDomainStatus(status string, enabled string) int64 {
+	if enabled == "false" {
+		if status == "Deployed" {
+			return consts.CdnDomainStatusStoped
+		}
+		return consts.CdnDomainStatusStoping
+	}
+	switch status {
+	case "Deployed":
+		return consts.CdnDomainStatusDeployed
+	case "InProgress":
+		return consts.CdnDomainStatusDeploying
+	case "Failed":
+		return consts.CdnDomainStatusFaild
+	default:
+		return consts.CdnDomainStatusDeploying
+	}
+}
+
+// GetPathPattern 规则类型转url匹配正则
+func getPathPattern(t int64, data []string) string {
+	const urlPrefix = `^https?://[^/]+`
+	if len(data) == 0 && t != consts.RuleTypeIndex {
+		return ".*"
+	}
+	items := make([]string, 0, len(data))
+	switch t {
+	case consts.RuleTypeFileSuffix:
+		for _, v := range data {
+			items = append(items, regexp.QuoteMeta(strings.TrimPrefix(v, ".")))
+		}
+		return `.*\.(` + strings.Join(items, "|") + `)(\?.*)?$`
+	case consts.RuleTypeDirectory:
+		for _, v := range data {
+			items = append(items, regexp.QuoteMeta("/"+strings.Trim(v, "/")+"/"))
+		}
+		return urlPrefix + `(` + strings.Join(items, "|") + `).*`
+	case consts.RuleTypePath:
+		for _, v := range data {
+			items = append(items, regexp.QuoteMeta(v))
+		}
+		return urlPrefix + `(` + strings.Join(items, "|") + `)(\?.*)?$`
+	case consts.RuleTypeIndex:
+		return urlPrefix + `/?(\?.*)?$`
+	default:
+		return ".*"
+	}
+}
+
+// GetOriginProtocol 获取回源协议
+func getOriginProtocol(t int64) string {
+	switch t {
+	case consts.OriginProtocolHttps:
+		return "https"
+	case consts.OriginProtocolFollow:
+		return "follow"
+	default:
+		return "http"
+	}
+}
+
+// GetHeaderAction 获取头部操作类型
+func getHeaderAction(t int64) string {
+	switch t {
+	case consts.OriginHeaderActionDelete:
+		return "delete"
+	case consts.OriginHeaderActionSet:
+		return "set"
+	case consts.OriginHeaderActionAdd:
+		return "add"
+	default:
+		return "set"
+	}
+}
+
+// GetRedirectCode 获取跳转码
+func getRedirectCode(t int64) string {
+	switch t {
+	case consts.RedirectCode301:
+		return "301"
+	case consts.RedirectCode302:
+		return "302"
+	default:
+		return "302"
+	}
+}
+
+// GetTlsVersions 获取TLS版本
+func getTlsVersions(ts []int64) string {
+	versions := make([]string, 0, len(ts))
+	for _, t := range ts {
+		switch t {
+		case consts.HttpsTlsVersionSSLv0:
+			versions = append(versions, "TLSv1")
+		case consts.HttpsTlsVersionSSLv1:
+			versions = append(versions, "TLSv1.1")
+		case consts.HttpsTlsVersionSSLv2:
+			versions = append(versions, "TLSv1.2")
+		case consts.HttpsTlsVersionTLSv3:
+			versions = append(versions, "TLSv1.3")
+		}
+	}
+	return strings.Join(versions, ";")
+}
+
+// GetShowContentPurgeOrPushStatus 获取显示内容刷新或预热状态
+func getShowContentPurgeOrPushStatus(status string) int64 {
+	switch status {
+	case "FAILURE":
+		return consts.ShowContentPurgeOrPushStatusFail
+	case "SUCCESS":
+		return consts.ShowContentPurgeOrPushStatusSuccess
+	case "PROCESSING", "UNPROCESSED":
+		return consts.ShowContentPurgeOrPushStatusDoing
+	default:
+		return consts.ShowContentPurgeOrPushStatusFail
+	}
+}
+
+// SetShowContentPurgeOrPushStatus 获取显示内容刷新或预热状态
+func setShowContentPurgeOrPushStatus(status int64) string {
+	switch status {
+	case consts.ShowContentPurgeOrPushStatusDoing:
+		return "PROCESSING"
+	case consts.ShowContentPurgeOrPushStatusSuccess:
+		return "SUCCESS"
+	case consts.ShowContentPurgeOrPushStatusFail:
+		return "FAILURE"
+	default:
+		return "FAILURE"
+	}
+}
+
+// GetShowContentPurgeType 获取显示内容刷新类型
+func getShowContentPurgeType(t int64) string {
+	switch t {
+	case consts.ShowContentPurgeTypeUrl:
+		return "url"
+	case consts.ShowContentPurgeTypePath:
+		return "dir"
+	default:
+		return "url"
+	}
+}
+
+// GetPurgeDirAction 获取目录刷新方式
+func getPurgeDirAction(mode int64) string {
+	switch mode {
+	case consts.ContentPurgePathModeAll:
+		return "delete"
+	case consts.ContentPurgePathModeFile:
+		return "expire"
+	default:
+		return "delete"
+	}
+}
+
+// GetRegion 获取统计区域
+func getRegion(t int64) string {
+	switch t {
+	case consts.AreaCodeChinaMainland:
+		return "cn"
+	case consts.AreaCodeOversea:
+		return "overseas"
+	default:
+		return ""
+	}
+}
+
+// GetDataAccessMetricType 获取访问数据指标
+func getDataAccessMetricType(t int64) string {
+	switch t {
+	case consts.DataAccessMetricTypeFlux:
+		return "flow"
+	case consts.DataAccessMetricTypeBandwidth:
+		return "bandwidth"
+	case consts.DataAccessMetricTypeRequest:
+		return "request"
+	case consts.DataAccessMetricTypeHitRequest:
+		return "hit-request"
+	case consts.DataAccessMetricTypeHitFlux:
+		return "hit-flow"
+	case consts.DataAccessMetricTypeStatusCode2xx:
+		return "2xx"
+	case consts.DataAccessMetricTypeStatusCode3xx:
+		return "3xx"
+	case consts.DataAccessMetricTypeStatusCode4xx:
+		return "4xx"
+	case consts.DataAccessMetricTypeStatusCode5xx:
+		return "5xx"
+	default:
+		return "flow"
+	}
+}
+
+// GetDataOriginMetricType 获取回源数据指标
+func getDataOriginMetricType(t int64) string {
+	switch t {
+	case consts.DataOriginMetricTypeFlux:
+		return "flow"
+	case consts.DataOriginMetricTypeBandwidth:
+		return "bandwidth"
+	case consts.DataOriginMetricTypeRequest:
+		return "request"
+	case consts.DataOriginMetricTypeFailRequest:
+		return "fail-request"
+	case consts.DataOriginMetricTypeStatusCode2xx:
+		return "2xx"
+	case consts.DataOriginMetricTypeStatusCode3xx:
+		return "3xx"
+	case consts.DataOriginMetricTypeStatusCode4xx:
+		return "4xx"
+	case consts.DataOriginMetricTypeStatusCode5xx:
+		return "5xx"
+	default:
+		return "flow"
+	}
+}
+
+// GetDataIntervalType 获取时间粒度
+func getDataIntervalType(t int64) string {
+	switch t {
+	case consts.DataIntervalTypeFiveMinute:
+		return "fiveminutes"
+	case consts.DataIntervalTypeHour:
+		return "hourly"
+	case consts.DataIntervalTypeDay:
+		return "daily"
+	default:
+		return "fiveminutes"
+	}
+}
+
+// GetIspCode 获取运营商
+func getIspCode(t int64) string {
+	switch t {
+	case consts.IspCodeDianxin:
+		return "dx"
+	case consts.IspCodeYidong:
+		return "yd"
+	case consts.IspCodeLiantong:
+		return "lt"
+	case consts.IspCodeTietong:
+		return "tt"
+	case consts.IspCodeJiaoyuwang:
+		return "jyw"
+	default:
+		return "other"
+	}
+}
+
+// GetTopUrlFilter 获取TOP URL排序条件
+func getTopUrlFilter(t int64) string {
+	switch t {
+	case consts.ListTopFilterFlux:
+		return "flow"
+	case consts.ListTopFilterRequest:
+		return "request"
+	default:
+		return "flow"
+	}
+}
+
+// FormatTimeWithTimezone 时间戳格式化为RFC3339时间
+func formatTimeWithTimezone(timestamp int64, timezone string) string {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.Local
+	}
+	return time.Unix(timestamp, 0).In(location).Format(time.RFC3339)
+}
+
+// ParseTime RFC3339时间转时间戳
+func parseTime(t string) int64 {
+	tm, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return 0
+	}
+	return tm.Unix()
+}
+
+// MapCacheTime 秒数转换为缓存时间与缓存单位,取能整除的最大单位
+func mapCacheTime(seconds int64) (ttl int64, unit int64) {
+	switch {
+	case seconds == 0:
+		return 0, consts.CacheUnitSecond
+	case seconds%86400 == 0:
+		return seconds / 86400, consts.CacheUnitDay
+	case seconds%3600 == 0:
+		return seconds / 3600, consts.CacheUnitHour
+	case seconds%60 == 0:
+		return seconds / 60, consts.CacheUnitMinute
+	default:
+		return seconds, consts.CacheUnitSecond
+	}
+}
+
+// MapPathPattern url匹配正则转规则类型,为 getPathPattern 的逆运算,无法识别的正则按指定路径原样返回
+func mapPathPattern(pattern string) (int64, []string) {
+	const urlPrefix = `^https?://[^/]+`
+	switch {
+	case pattern == "" || pattern == ".*":
+		return consts.RuleTypeAll, nil
+	case pattern == urlPrefix+`/?(\?.*)?$`:
+		return consts.RuleTypeIndex, nil
+	case strings.HasPrefix(pattern, `.*\.(`) && strings.HasSuffix(pattern, `)(\?.*)?$`):
+		items := splitQuoted(strings.TrimSuffix(strings.TrimPrefix(pattern, `.*\.(`), `)(\?.*)?$`))
+		return consts.RuleTypeFileSuffix, items
+	case strings.HasPrefix(pattern, urlPrefix+`(`) && strings.HasSuffix(pattern, `).*`):
+		items := splitQuoted(strings.TrimSuffix(strings.TrimPrefix(pattern, urlPrefix+`(`), `).*`))
+		for i, v := range items {
+			items[i] = "/" + strings.Trim(v, "/")
+		}
+		return consts.RuleTypeDirectory, items
+	case strings.HasPrefix(pattern, urlPrefix+`(`) && strings.HasSuffix(pattern, `)(\?.*)?$`):
+		return consts.RuleTypePath, splitQuoted(strings.TrimSuffix(strings.TrimPrefix(pattern, urlPrefix+`(`), `)(\?.*)?$`))
+	default:
+		return consts.RuleTypePath, []string{pattern}
+	}
+}
+
+// SplitQuoted 按未转义的|拆分并还原 regexp.QuoteMeta 转义的内容
+func splitQuoted(s string) []string {
+	items := make([]string, 0)
+	var item strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			i++
+			item.WriteByte(s[i])
+		case s[i] == '|':
+			items = append(items, item.String())
+			item.Reset()
+		default:
+			item.WriteByte(s[i])
+		}
+	}
+	return append(items, item.String())
+}
+
+// UnquoteMeta 还原 regexp.QuoteMeta 转义的内容
+func unquoteMeta(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// MapOriginProtocol 回源协议转回源协议代码
+func mapOriginProtocol(protocol string) int64 {
+	switch protocol {
+	case "https":
+		return consts.OriginProtocolHttps
+	case "follow":
+		return consts.OriginProtocolFollow
+	default:
+		return consts.OriginProtocolHttp
+	}
+}
+
+// MapHeaderAction 头部操作类型转回源头操作
+func mapHeaderAction(action string) int64 {
+	switch action {
+	case "delete":
+		return consts.OriginHeaderActionDelete
+	case "add":
+		return consts.OriginHeaderActionAdd
+	default:
+		return consts.OriginHeaderActionSet
+	}
+}
+
+// MapRedirectCode 跳转码转自定义错误页跳转码
+func mapRedirectCode(code string) int64 {
+	if code == "301" {
+		return consts.RedirectCode301
+	}
+	return consts.RedirectCode302
+}
+
+// MapTlsVersions TLS版本转TLS版本代码
+func mapTlsVersions(versions string) []int64 {
+	list := make([]int64, 0)
+	for _, v := range strings.Split(versions, ";") {
+		switch strings.TrimSpace(v) {
+		case "TLSv1", "TLSv1.0":
+			list = append(list, consts.HttpsTlsVersionSSLv0)
+		case "TLSv1.1":
+			list = append(list, consts.HttpsTlsVersionSSLv1)
+		case "TLSv1.2":
+			list = append(list, consts.HttpsTlsVersionSSLv2)
+		case "TLSv1.3":
+			list = append(list, consts.HttpsTlsVersionTLSv3)
+		}
+	}
+	return list
+}
@@ -0,0 +1,113 @@
+package wangsu
+
+import (
+	"context"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest/replay"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"path/filepath"
+	"testing"
+)
+
+// golden文件由replay.ModeRecord录制并脱敏,回放时请求方法、URL与请求体必须与录制时一致,
+// mapping的改动导致请求变化时需重新录制并核对差异
+
+const (
+	replayAk      = "test-ak"
+	replaySk      = "test-sk"
+	replayCertKey = "test-private-key"
+)
+
+// newReplayClient 使用testdata下的golden文件创建回放客户端,测试结束时检查录制的请求均已发送
+func newReplayClient(t *testing.T, name string) *Wangsu {
+	t.Helper()
+	rt, err := replay.New(&replay.Config{
+		Path:     filepath.Join("testdata", name+".json"),
+		Redactor: replay.NewRedactor(replayAk, replaySk, replayCertKey),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		for _, v := range rt.Unused() {
+			t.Errorf("recorded request not sent: %s %s", v.Request.Method, v.Request.Url)
+		}
+	})
+	w, err := New(context.Background(), &Config{
+		Ak:   replayAk,
+		Sk:   replaySk,
+		Http: &httpclient.Options{Transport: rt},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestReplayCacheListConf(t *testing.T) {
+	w := newReplayClient(t, "cache_list_conf")
+	err := w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateCacheListConf,
+		Domain:       "www.example.com",
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{"jpg", "png"}, CacheTTL: 1, CacheUnit: consts.CacheUnitDay, CacheStatus: consts.CacheStatusOn, Priority: 20},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"/static"}, CacheTTL: 10, CacheUnit: consts.CacheUnitMinute, CacheStatus: consts.CacheStatusFollow},
+			{CacheType: consts.RuleTypeAll, CacheStatus: consts.CacheStatusOff, Priority: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplayHttpsConf(t *testing.T) {
+	w := newReplayClient(t, "https_conf")
+	err := w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateHttpsConf,
+		Domain:       "www.example.com",
+		HttpsConf: &entity.HttpsConf{
+			HttpsStatus: types.ON,
+			TlsVersion:  []int64{consts.HttpsTlsVersionSSLv2, consts.HttpsTlsVersionTLSv3},
+			HttpTwo:     types.ON,
+			OcspStatus:  types.ON,
+			CertName:    "example-cert",
+			CertValue:   "test-certificate",
+			CertKey:     replayCertKey,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReplayStatsTimeAlignment 请求时间按时区格式化,返回的时间点转换回时间戳
+func TestReplayStatsTimeAlignment(t *testing.T) {
+	w := newReplayClient(t, "stats_time_alignment")
+	start := int64(1704067200) //2024-01-01T00:00:00Z
+	for _, tz := range []*string{nil, utils.StringPtr("UTC")} {
+		res, err := w.DomainAccessDataStatic(&types.DomainAccessDataStaticRequest{
+			Domains:   []string{"www.example.com"},
+			Metric:    consts.DataAccessMetricTypeFlux,
+			StartTime: start,
+			EndTime:   start + 600,
+			Interval:  consts.DataIntervalTypeFiveMinute,
+			Area:      consts.AreaCodeChinaMainland,
+			TimeZone:  tz,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		items := res["www.example.com"]
+		if len(items) != 3 {
+			t.Fatalf("got %d points, want 3", len(items))
+		}
+		for i, v := range items {
+			if want := start + int64(i)*300; v.Time != want || v.Value != float64(i+1)*100 {
+				t.Errorf("point %d: got %d=%v, want %d=%v", i, v.Time, v.Value, want, float64(i+1)*100)
+			}
+		}
+	}
+}
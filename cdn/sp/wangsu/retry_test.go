@@ -0,0 +1,155 @@
+package wangsu
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPolicy() *retry.Policy {
+	return &retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+}
+
+func countCalls(calls []string, call string) int {
+	n := 0
+	for _, v := range calls {
+		if v == call {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRetryIdempotentCall(t *testing.T) {
+	s, w := newStandIn(t)
+	w.config.Retry = testPolicy()
+	s.addDomain("www.example.com")
+	s.fail("/api/domain/www.example.com", http.StatusTooManyRequests, http.StatusServiceUnavailable)
+	if _, err := w.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countCalls(s.callList(), "GET /api/domain/www.example.com"); n != 3 {
+		t.Fatalf("got %d calls, want 3", n)
+	}
+}
+
+func TestRetryStopsAtMaxAttempts(t *testing.T) {
+	s, w := newStandIn(t)
+	w.config.Retry = testPolicy()
+	s.addDomain("www.example.com")
+	s.fail("/api/domain/www.example.com", http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	_, err := w.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	var e *cdnerr.Error
+	if !errors.Is(err, cdnerr.ErrRateLimited) || !errors.As(err, &e) || e.RequestId != "req-1" {
+		t.Fatalf("got %v, want rate limited with request id", err)
+	}
+	if n := countCalls(s.callList(), "GET /api/domain/www.example.com"); n != 3 {
+		t.Fatalf("got %d calls, want 3", n)
+	}
+}
+
+func TestRetryNonIdempotentOnlyWhenThrottled(t *testing.T) {
+	s, w := newStandIn(t)
+	w.config.Retry = testPolicy()
+	s.fail("/ccm/purge/ItemIdReceiver", http.StatusTooManyRequests)
+	res, err := w.PurgeUrlsCache(&types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(res.TaskId, "purge-") || countCalls(s.callList(), "POST /ccm/purge/ItemIdReceiver") != 2 {
+		t.Fatalf("throttled purge: task %q, calls %v", res.TaskId, s.callList())
+	}
+	//服务端内部错误时无法确定任务是否已提交,非幂等操作不重试
+	s.fail("/ccm/purge/ItemIdReceiver", http.StatusServiceUnavailable)
+	if _, err := w.PurgeUrlsCache(&types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/b"}}); err == nil {
+		t.Fatal("expected server error")
+	}
+	if n := countCalls(s.callList(), "POST /ccm/purge/ItemIdReceiver"); n != 3 {
+		t.Fatalf("got %d purge calls, want 3", n)
+	}
+}
+
+// slowLimiter 返回桶容量为burst且几乎不再生成令牌的限流器
+func slowLimiter(class ratelimit.Class, burst int) *ratelimit.Limiter {
+	return ratelimit.New(ratelimit.Limits{class: {QPS: 0.001, Burst: burst}})
+}
+
+func TestRateLimitEachAttempt(t *testing.T) {
+	s, w := newStandIn(t)
+	w.config.Retry = testPolicy()
+	w.limiter = slowLimiter(ratelimit.ClassQuery, 2)
+	s.addDomain("www.example.com")
+	s.fail("/api/domain/www.example.com", http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	//重试同样需要令牌,第三次尝试等待时间超过ctx的截止时间
+	if _, err := w.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: "www.example.com"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	if n := countCalls(s.callList(), "GET /api/domain/www.example.com"); n != 2 {
+		t.Fatalf("got %d calls, want 2", n)
+	}
+}
+
+func TestRateLimitEachCall(t *testing.T) {
+	s, w := newStandIn(t)
+	w.limiter = slowLimiter(ratelimit.ClassConfig, 1)
+	s.addDomain("www.example.com")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	//一次更新提交多个配置接口,每个接口调用各需一个令牌
+	err := w.UpdateDomainWithContext(ctx, &types.UpdateDomainRequest{
+		UpdateAction: types.UpdateRecommendConf,
+		Domain:       "www.example.com",
+		OriginConf:   &entity.OriginConf{OriginProtocol: consts.OriginProtocolHttps},
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeAll, CacheStatus: consts.CacheStatusOff},
+		},
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	if calls := s.callList(); len(calls) != 1 {
+		t.Fatalf("got calls %v, want only the first config call", calls)
+	}
+}
+
+func TestDefaultRateLimit(t *testing.T) {
+	s, w := newStandIn(t)
+	s.addDomain("www.example.com")
+	show := func(w *Wangsu, timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_, err := w.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: "www.example.com"})
+		return err
+	}
+	//未指定限流器时使用默认速率,查询类桶容量用尽后等待时间超过截止时间
+	burst := int(ratelimit.DefaultLimits(types.WangsuSdkName)[ratelimit.ClassQuery].QPS)
+	for i := 0; i < burst; i++ {
+		if err := show(w, time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := show(w, 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	//显式关闭限流
+	unlimited, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Endpoint: s.URL, RateLimit: ratelimit.Unlimited()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2*burst; i++ {
+		if err := show(unlimited, time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
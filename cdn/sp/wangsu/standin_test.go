@@ -0,0 +1,262 @@
+package wangsu
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// standIn 网宿接口的本地替身,在内存中保存域名配置,全量覆盖类接口的语义与网宿一致
+type standIn struct {
+	*httptest.Server
+	mu      sync.Mutex
+	domains map[string]*model.ShowDomainDetailResponse
+	calls   []string
+	purges  map[string][]string
+	faults  map[string][]int // 按路径依次返回的错误状态码,用尽后正常处理
+}
+
+func newStandIn(t *testing.T) (*standIn, *Wangsu) {
+	t.Helper()
+	s := &standIn{
+		domains: make(map[string]*model.ShowDomainDetailResponse),
+		purges:  make(map[string][]string),
+		faults:  make(map[string][]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	w, err := New(context.Background(), &Config{Ak: "ak", Sk: "sk", Endpoint: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, w
+}
+
+// addDomain 添加线上域名,返回的配置可直接修改
+func (s *standIn) addDomain(name string) *model.ShowDomainDetailResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := &model.ShowDomainDetailResponse{
+		DomainId:     "id-" + name,
+		DomainName:   name,
+		ServiceType:  "web-https",
+		ServiceAreas: "cn",
+		Cname:        name + ".wscdns.com",
+		Status:       "Deployed",
+		Enabled:      "true",
+		OriginConfig: &model.OriginConfig{OriginIps: "1.1.1.1"},
+	}
+	s.domains[name] = d
+	return d
+}
+
+func (s *standIn) domain(name string) *model.ShowDomainDetailResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.domains[name]
+}
+
+// fail 使路径的后续请求依次返回指定的错误状态码
+func (s *standIn) fail(path string, statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[path] = append(s.faults[path], statuses...)
+}
+
+func (s *standIn) callList() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, r.Method+" "+r.URL.Path)
+	w.Header().Set(constant.XCncRequestId, "req-1")
+	if !strings.HasPrefix(r.Header.Get(constant.Authorization), constant.HeadSignAlgorithm+" Credential=ak,") {
+		reply(w, http.StatusUnauthorized, map[string]interface{}{"code": "AuthFailed", "message": "missing signature"})
+		return
+	}
+	path := r.URL.Path
+	name := path[strings.LastIndex(path, "/")+1:]
+	if queue := s.faults[path]; len(queue) > 0 {
+		s.faults[path] = queue[1:]
+		code := map[bool]string{true: "TooManyRequests", false: "InternalError"}[queue[0] == http.StatusTooManyRequests]
+		reply(w, queue[0], map[string]interface{}{"code": code, "message": http.StatusText(queue[0])})
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && path == "/api/domain":
+		req := &model.CreateDomainRequest{}
+		decode(r, req)
+		s.domains[req.DomainName] = &model.ShowDomainDetailResponse{
+			DomainId:     "id-" + req.DomainName,
+			DomainName:   req.DomainName,
+			ServiceType:  req.ServiceType,
+			ServiceAreas: req.ServiceAreas,
+			Status:       "InProgress",
+			Enabled:      "true",
+			OriginConfig: &req.OriginConfig,
+		}
+		reply(w, http.StatusAccepted, nil)
+	case r.Method == http.MethodGet && path == "/api/domain":
+		list := &model.ShowDomainListResponse{DomainSummary: make([]*model.DomainSummary, 0)}
+		for _, v := range s.domains {
+			list.DomainSummary = append(list.DomainSummary, &model.DomainSummary{DomainName: v.DomainName, Status: v.Status, Enabled: v.Enabled})
+		}
+		reply(w, http.StatusOK, list)
+	case r.Method == http.MethodPost && (path == "/api/domain/enable" || path == "/api/domain/disable"):
+		req := &model.EnableDomainRequest{}
+		decode(r, req)
+		d, ok := s.domains[req.DomainName]
+		if !ok {
+			notFound(w)
+			return
+		}
+		d.Enabled = map[bool]string{true: "true", false: "false"}[path == "/api/domain/enable"]
+		reply(w, http.StatusAccepted, nil)
+	case path == "/api/domain/setsrcconfig":
+		req := &model.SrcConfigRequest{}
+		decode(r, req)
+		d, ok := s.domains[req.DomainName]
+		if !ok {
+			notFound(w)
+			return
+		}
+		if d.OriginConfig == nil {
+			d.OriginConfig = &model.OriginConfig{}
+		}
+		//未传的字段保持不变
+		if req.OriginProtocol != "" {
+			d.OriginConfig.OriginProtocol = req.OriginProtocol
+		}
+		if req.UseRange != nil {
+			d.OriginConfig.UseRange = req.UseRange
+		}
+		if req.Follow301 != nil {
+			d.OriginConfig.Follow301 = req.Follow301
+		}
+		if req.Follow302 != nil {
+			d.OriginConfig.Follow302 = req.Follow302
+		}
+		if req.AdvSrcSetting != nil {
+			d.OriginConfig.AdvOriginConfigs = nil
+			if req.AdvSrcSetting.UseAdvSrc {
+				d.OriginConfig.AdvOriginConfigs = &model.AdvOriginConfigs{AdvOriginConfig: []*model.AdvOriginConfig{{
+					MasterIps: strings.Join(req.AdvSrcSetting.AdvSrcConfigs.MasterIps, ";"),
+					BackupIps: strings.Join(req.AdvSrcSetting.AdvSrcConfigs.BackupIps, ";"),
+				}}}
+			}
+		}
+		reply(w, http.StatusAccepted, nil)
+	case strings.HasPrefix(path, "/api/domain/"):
+		d, ok := s.domains[name]
+		if !ok {
+			notFound(w)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			reply(w, http.StatusOK, d)
+		case http.MethodDelete:
+			delete(s.domains, name)
+			reply(w, http.StatusAccepted, nil)
+		case http.MethodPut:
+			req := &model.ModifyDomainRequest{}
+			decode(r, req)
+			if req.ServiceAreas != nil {
+				d.ServiceAreas = *req.ServiceAreas
+			}
+			//修改域名只更新源站地址与回源host,其余回源配置通过 setsrcconfig 修改
+			if req.OriginConfig != nil {
+				if d.OriginConfig == nil {
+					d.OriginConfig = &model.OriginConfig{}
+				}
+				d.OriginConfig.OriginIps = req.OriginConfig.OriginIps
+				d.OriginConfig.DefaultOriginHostHeader = req.OriginConfig.DefaultOriginHostHeader
+			}
+			if req.Ssl != nil {
+				d.Ssl = req.Ssl
+			}
+			reply(w, http.StatusAccepted, nil)
+		}
+	case strings.HasPrefix(path, "/api/config/"):
+		d, ok := s.domains[name]
+		if !ok {
+			notFound(w)
+			return
+		}
+		switch {
+		case strings.HasPrefix(path, "/api/config/headermodify/"):
+			req := &model.HeaderModifyRequest{}
+			decode(r, req)
+			d.HeaderModifyRules = req.HeaderModifyRules
+		case strings.HasPrefix(path, "/api/config/visitcontrol/"):
+			req := &model.VisitControlRequest{}
+			decode(r, req)
+			d.VisitControlRules = req.VisitControlRules
+		case strings.HasPrefix(path, "/api/config/cachetime/"):
+			//缓存时间接口的请求体为规则数组
+			behaviors := make([]*model.CacheTimeBehavior, 0)
+			decode(r, &behaviors)
+			d.CacheTimeBehaviors = behaviors
+		}
+		reply(w, http.StatusAccepted, nil)
+	case path == "/ccm/purge/ItemIdReceiver":
+		req := &model.PurgeRequest{}
+		decode(r, req)
+		id := "purge-" + strconv.Itoa(len(s.purges)+1)
+		s.purges[id] = append(req.Urls, req.Dirs...)
+		reply(w, http.StatusOK, &model.PurgeResponse{Code: 1, ItemId: id})
+	case path == "/ccm/purge/ItemIdQuery":
+		req := &model.PurgeQueryRequest{}
+		decode(r, req)
+		res := &model.PurgeQueryResponse{Code: 1, ResultDetail: make([]*model.TaskResultDetail, 0)}
+		for _, v := range s.purges[req.ItemId] {
+			res.ResultDetail = append(res.ResultDetail, &model.TaskResultDetail{ItemId: req.ItemId, Url: v, Status: "SUCCESS"})
+		}
+		res.Total = int64(len(res.ResultDetail))
+		reply(w, http.StatusOK, res)
+	case path == "/api/statistics/domain":
+		req := &model.DomainStatisticsRequest{}
+		decode(r, req)
+		res := &model.DomainStatisticsResponse{Code: "0", Data: make([]*model.DomainStatisticsData, 0)}
+		for _, v := range req.DomainList {
+			res.Data = append(res.Data, &model.DomainStatisticsData{
+				Domain: v,
+				Total:  300,
+				Details: []*model.StatisticsDetail{
+					{Time: req.StartTime, Value: 100},
+					{Time: req.EndTime, Value: 200},
+				},
+			})
+		}
+		reply(w, http.StatusOK, res)
+	default:
+		reply(w, http.StatusNotFound, map[string]interface{}{"code": "NotFound", "message": path})
+	}
+}
+
+func notFound(w http.ResponseWriter) {
+	reply(w, http.StatusNotFound, map[string]interface{}{"code": "NoSuchDomain", "message": "domain not exist"})
+}
+
+func decode(r *http.Request, v interface{}) {
+	_ = json.NewDecoder(r.Body).Decode(v)
+}
+
+func reply(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
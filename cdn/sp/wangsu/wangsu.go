@@ -2,159 +2,1245 @@ package wangsu
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdnlog"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/httpclient"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/ratelimit"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/auth"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/constant"
 	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
 	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Wangsu struct {
-	ctx    context.Context
-	config *Config
-	client *common.Client
+	ctx     context.Context
+	config  *Config
+	limiter *ratelimit.Limiter
+	client  *common.Client
 }
 
 type Config struct {
-	Ak       string
-	Sk       string
-	Endpoint string
+	Ak        string
+	Sk        string
+	Endpoint  string
+	Retry     *retry.Policy       // 重试策略,为空时不重试
+	RateLimit *ratelimit.Limiter  // 限流器,每次调用厂商接口(含重试)前获取令牌,为空时使用ratelimit.DefaultLimits,不限制时传入ratelimit.Unlimited()
+	Http      *httpclient.Options // HTTP客户端选项,为空时使用默认客户端,超时为httpclient.DefaultTimeout
+	Logger    *slog.Logger        // 记录HTTP请求的日志,为空时不记录
 }
 
+// NewWangsuSdkClient 创建网宿CDN客户端,创建失败时返回nil,需要错误信息时使用 New
 func NewWangsuSdkClient(ctx context.Context, conf *Config) *Wangsu {
-	return &Wangsu{
-		ctx:    ctx,
-		config: conf,
-		client: common.NewClient(auth.NewAuth(conf.Ak, conf.Sk, conf.Endpoint)),
+	client, err := New(ctx, conf)
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// New 创建网宿CDN客户端
+func New(ctx context.Context, conf *Config) (*Wangsu, error) {
+	if conf == nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.WangsuSdkName, "config is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	httpClient, err := conf.Http.NewClient()
+	if err != nil {
+		return nil, cdnerr.New(cdnerr.ErrInvalidParameter, types.WangsuSdkName, "invalid http options: "+err.Error())
 	}
+	httpClient.Transport = cdnlog.NewTransport(conf.Logger, httpClient.Transport)
+	return &Wangsu{
+		ctx:     ctx,
+		config:  conf,
+		limiter: ratelimit.OrDefault(conf.RateLimit, types.WangsuSdkName),
+		client:  common.NewClient(auth.NewAuth(conf.Ak, conf.Sk, conf.Endpoint)).WithHttpClient(httpClient),
+	}, nil
 }
 
 func (w *Wangsu) GetSdkName() string {
 	return types.WangsuSdkName
 }
 
+// invoke 调用厂商接口,每次尝试前按class获取限流令牌,转换错误并按重试策略重试
+func invoke[T any](ctx context.Context, w *Wangsu, class ratelimit.Class, idempotent bool, fn func() (T, error)) (T, error) {
+	return retry.Do(ctx, w.config.Retry, idempotent, func() (T, error) {
+		if err := w.limiter.Wait(ctx, class); err != nil {
+			var zero T
+			return zero, err
+		}
+		res, err := fn()
+		return res, wrapError(err)
+	})
+}
+
+// CreateDomain 创建域名
 func (w *Wangsu) CreateDomain(data *types.CreateDomainRequest) error {
+	return w.CreateDomainWithContext(w.ctx, data)
+}
+
+// CreateDomainWithContext 创建域名
+func (w *Wangsu) CreateDomainWithContext(ctx context.Context, data *types.CreateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
 	req := &model.CreateDomainRequest{
 		Version:                   constant.SDKVersion,
 		DomainName:                data.Domain,
 		ServiceType:               getServiceType(data.ChannelType),
 		ServiceAreas:              getServiceAreas(data.AreaCode),
+		OriginConfig:              getOriginConfig(data.Sources),
 		Comment:                   "",
 		CnameWithCustomizedPrefix: true,
-		AccelerateNoChina:         false,
+		AccelerateNoChina:         data.AreaCode == consts.AreaCodeOversea,
 	}
-	res, err := w.client.CreateDomain(req)
+	res, err := invoke(ctx, w, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.CreateDomainResponse, error) {
+		return w.client.CreateDomain(ctx, req)
+	})
 	if err != nil {
 		return err
 	}
-	if res.HttpStatusCode != 202 {
-		return fmt.Errorf("create domain fail code %d message %s ", res.Code, res.Message)
+	if res.HttpStatusCode != http.StatusAccepted {
+		return newResponseError(strconv.Itoa(res.Code), "create domain fail: "+res.Message)
 	}
 	return nil
 }
 
+// getOriginConfig 获取主源站配置,网宿源站地址以分号分隔
+func getOriginConfig(sources []*entity.OriginServerConf) model.OriginConfig {
+	originConfig := model.OriginConfig{}
+	ips := make([]string, 0, len(sources))
+	for _, v := range sources {
+		if v.OriginPriority != consts.OriginPriorityPrimary {
+			continue
+		}
+		if originConfig.DefaultOriginHostHeader == "" {
+			originConfig.DefaultOriginHostHeader = v.OriginHost
+		}
+		ips = append(ips, v.OriginAddressList)
+	}
+	originConfig.OriginIps = strings.Join(ips, ";")
+	return originConfig
+}
+
+// UpdateDomain 更新域名
 func (w *Wangsu) UpdateDomain(data *types.UpdateDomainRequest) error {
-	//TODO implement me
-	panic("implement me")
+	return w.UpdateDomainWithContext(w.ctx, data)
+}
+
+// UpdateDomainWithContext 更新域名
+func (w *Wangsu) UpdateDomainWithContext(ctx context.Context, data *types.UpdateDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	updateDomain := w.newUpdateDomainConfigModel(data)
+	switch data.UpdateAction {
+	case types.UpdateArea:
+		updateDomain.WithArea()
+	case types.UpdateOriginConf:
+		updateDomain.WithOriginConf()
+	case types.UpdateOriginServerConf:
+		updateDomain.WithOriginServerConf()
+	case types.UpdateOriginRequestHeaderConf:
+		updateDomain.WithOriginRequestHeaderConf()
+	case types.UpdateResponseHeaderConf:
+		updateDomain.WithResponseHeaderConf()
+	case types.UpdateCacheListConf:
+		updateDomain.WithCacheListConf()
+	case types.UpdateCacheCodeConf:
+		updateDomain.WithCacheCodeConf()
+	case types.UpdateRequestUrlRewriteConf:
+		updateDomain.WithRequestUrlRewriteConf()
+	case types.UpdateIpFilterConf:
+		updateDomain.WithIpFilterConf()
+	case types.UpdateRefererConf:
+		updateDomain.WithRefererConf()
+	case types.UpdateUserAgentConf:
+		updateDomain.WithUserAgentConf()
+	case types.UpdateSpeedConf:
+		updateDomain.WithSpeedConf()
+	case types.UpdateHttpsConf:
+		updateDomain.WithHttpsConf()
+	case types.UpdateRecommendConf:
+		updateDomain.WithOriginConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithHttpsConf()
+	case types.UpdateFullConf:
+		updateDomain.WithOriginConf()
+		updateDomain.WithOriginServerConf()
+		updateDomain.WithOriginRequestHeaderConf()
+		updateDomain.WithIpFilterConf()
+		updateDomain.WithRefererConf()
+		updateDomain.WithUserAgentConf()
+		updateDomain.WithSpeedConf()
+		updateDomain.WithCacheListConf()
+		updateDomain.WithCacheCodeConf()
+		updateDomain.WithRequestUrlRewriteConf()
+		updateDomain.WithResponseHeaderConf()
+		updateDomain.WithHttpsConf()
+	default:
+		return cdnerr.New(cdnerr.ErrUnsupported, types.WangsuSdkName, fmt.Sprintf("update action %s not support", data.UpdateAction))
+	}
+	return updateDomain.send(ctx)
 }
 
+// DisableDomain 停用域名
 func (w *Wangsu) DisableDomain(data *types.DisableDomainRequest) error {
-	//TODO implement me
-	panic("implement me")
+	return w.DisableDomainWithContext(w.ctx, data)
+}
+
+// DisableDomainWithContext 停用域名
+func (w *Wangsu) DisableDomainWithContext(ctx context.Context, data *types.DisableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, w, ratelimit.ClassConfig, retry.Idempotent, func() (*model.DisableDomainResponse, error) {
+		return w.client.DisableDomain(ctx, &model.DisableDomainRequest{DomainName: data.Domain})
+	})
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
+// EnableDomain 启用域名
 func (w *Wangsu) EnableDomain(data *types.EnableDomainRequest) error {
-	//TODO implement me
-	panic("implement me")
+	return w.EnableDomainWithContext(w.ctx, data)
+}
+
+// EnableDomainWithContext 启用域名
+func (w *Wangsu) EnableDomainWithContext(ctx context.Context, data *types.EnableDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	_, err := invoke(ctx, w, ratelimit.ClassConfig, retry.Idempotent, func() (*model.EnableDomainResponse, error) {
+		return w.client.EnableDomain(ctx, &model.EnableDomainRequest{DomainName: data.Domain})
+	})
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
+// DeleteDomain 删除域名
 func (w *Wangsu) DeleteDomain(data *types.DeleteDomainRequest) error {
-	//TODO implement me
-	panic("implement me")
+	return w.DeleteDomainWithContext(w.ctx, data)
+}
+
+// DeleteDomainWithContext 删除域名
+func (w *Wangsu) DeleteDomainWithContext(ctx context.Context, data *types.DeleteDomainRequest) error {
+	if data == nil {
+		return cdnerr.ErrNilRequest
+	}
+	//检测域名是否已存在
+	_, err := w.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: data.Domain})
+	if err != nil {
+		if errors.Is(err, cdnerr.ErrDomainNotFound) {
+			return nil
+		}
+		return err
+	}
+	_, err = invoke(ctx, w, ratelimit.ClassConfig, retry.Idempotent, func() (*model.DeleteDomainResponse, error) {
+		return w.client.DeleteDomain(ctx, &model.DeleteDomainRequest{DomainName: data.Domain})
+	})
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
+// CreateVerifyRecord 创建域名验证记录
 func (w *Wangsu) CreateVerifyRecord(data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.CreateVerifyRecordWithContext(w.ctx, data)
+}
+
+// CreateVerifyRecordWithContext 创建域名验证记录
+func (w *Wangsu) CreateVerifyRecordWithContext(ctx context.Context, data *types.CreateVerifyRecordRequest) (*types.CreateVerifyRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.WangsuSdkName, "create verify record not support")
 }
 
+// VerifyDomainRecord 验证域名解析记录
 func (w *Wangsu) VerifyDomainRecord(data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.VerifyDomainRecordWithContext(w.ctx, data)
+}
+
+// VerifyDomainRecordWithContext 验证域名解析记录
+func (w *Wangsu) VerifyDomainRecordWithContext(ctx context.Context, data *types.VerifyDomainRecordRequest) (*types.VerifyDomainRecordResponse, error) {
+	return nil, cdnerr.New(cdnerr.ErrUnsupported, types.WangsuSdkName, "verify domain record not support")
 }
 
+// ShowDomainDetail 获取域名详情
 func (w *Wangsu) ShowDomainDetail(data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.ShowDomainDetailWithContext(w.ctx, data)
+}
+
+// ShowDomainDetailWithContext 获取域名详情
+func (w *Wangsu) ShowDomainDetailWithContext(ctx context.Context, data *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowDomainDetailResponse, error) {
+		return w.client.ShowDomainDetail(ctx, &model.ShowDomainDetailRequest{DomainName: data.Domain})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ShowDomainDetailResponse{
+		DomainId:    res.DomainId,
+		Domain:      res.DomainName,
+		Cname:       res.Cname,
+		ChannelType: mapServiceType(res.ServiceType),
+		AreaCode:    mapServiceAreas(res.ServiceAreas),
+		Status:      getDomainStatus(res.Status, res.Enabled),
+		CreateTime:  parseTime(res.CreatedDate),
+		UpdateTime:  parseTime(res.LastModified),
+	}, nil
 }
 
+// ShowDomainStatusList 展示域名状态列表
 func (w *Wangsu) ShowDomainStatusList(data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.ShowDomainStatusListWithContext(w.ctx, data)
+}
+
+// ShowDomainStatusListWithContext 展示域名状态列表
+func (w *Wangsu) ShowDomainStatusListWithContext(ctx context.Context, data *types.ShowDomainStatusListRequest) (*types.ShowDomainStatusListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowDomainListResponse, error) {
+		return w.client.ShowDomainList(ctx, &model.ShowDomainListRequest{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	//网宿域名列表接口不支持按状态筛选与分页,在本地处理
+	domains := make([]string, 0)
+	for _, v := range res.DomainSummary {
+		if getDomainStatus(v.Status, v.Enabled) == data.Status {
+			domains = append(domains, v.DomainName)
+		}
+	}
+	total := int64(len(domains))
+	offset, limit := utils.CalcOffsetAndLimit(data.Page, data.Limit)
+	if offset >= total {
+		return &types.ShowDomainStatusListResponse{Total: total, List: make([]string, 0)}, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return &types.ShowDomainStatusListResponse{Total: total, List: domains[offset:end]}, nil
 }
 
+// PurgePathCache 刷新目录缓存
 func (w *Wangsu) PurgePathCache(data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.PurgePathCacheWithContext(w.ctx, data)
+}
+
+// PurgePathCacheWithContext 刷新目录缓存
+func (w *Wangsu) PurgePathCacheWithContext(ctx context.Context, data *types.PurgePathCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.PurgeResponse, error) {
+		return w.client.Purge(ctx, &model.PurgeRequest{
+			Dirs:      data.Paths,
+			DirAction: getPurgeDirAction(data.Mode),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 1 {
+		return nil, newResponseError(strconv.Itoa(res.Code), "purge path cache fail: "+res.Message)
+	}
+	return &types.PurgeCacheResponse{TaskId: res.ItemId}, nil
 }
 
+// PurgeUrlsCache 刷新URL缓存
 func (w *Wangsu) PurgeUrlsCache(data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.PurgeUrlsCacheWithContext(w.ctx, data)
+}
+
+// PurgeUrlsCacheWithContext 刷新URL缓存
+func (w *Wangsu) PurgeUrlsCacheWithContext(ctx context.Context, data *types.PurgeUrlsCacheRequest) (*types.PurgeCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.PurgeResponse, error) {
+		return w.client.Purge(ctx, &model.PurgeRequest{
+			Urls:      data.Urls,
+			UrlAction: "delete",
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 1 {
+		return nil, newResponseError(strconv.Itoa(res.Code), "purge urls cache fail: "+res.Message)
+	}
+	return &types.PurgeCacheResponse{TaskId: res.ItemId}, nil
 }
 
+// PushUrlsCache 预热URL缓存
 func (w *Wangsu) PushUrlsCache(data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.PushUrlsCacheWithContext(w.ctx, data)
+}
+
+// PushUrlsCacheWithContext 预热URL缓存
+func (w *Wangsu) PushUrlsCacheWithContext(ctx context.Context, data *types.PushUrlsCacheRequest) (*types.PushUrlsCacheResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassContent, retry.NonIdempotent, func() (*model.PrefetchResponse, error) {
+		return w.client.Prefetch(ctx, &model.PrefetchRequest{Urls: data.Urls})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 1 {
+		return nil, newResponseError(strconv.Itoa(res.Code), "push urls cache fail: "+res.Message)
+	}
+	return &types.PushUrlsCacheResponse{TaskId: res.ItemId}, nil
 }
 
+// ShowPurgeTaskStatus 展示刷新任务状态
 func (w *Wangsu) ShowPurgeTaskStatus(data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.ShowPurgeTaskStatusWithContext(w.ctx, data)
 }
 
+// ShowPurgeTaskStatusWithContext 展示刷新任务状态
+func (w *Wangsu) ShowPurgeTaskStatusWithContext(ctx context.Context, data *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.PurgeQueryResponse, error) {
+		return w.client.PurgeQuery(ctx, &model.PurgeQueryRequest{ItemId: data.TaskId})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.ResultDetail) == 0 {
+		return nil, newNotFoundError("task not found", "")
+	}
+	return &types.ShowPurgeTaskStatusResponse{
+		TaskId: data.TaskId,
+		Status: getTaskStatus(res.ResultDetail),
+	}, nil
+}
+
+// ShowPushTaskStatus 展示预热任务状态
 func (w *Wangsu) ShowPushTaskStatus(data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.ShowPushTaskStatusWithContext(w.ctx, data)
+}
+
+// ShowPushTaskStatusWithContext 展示预热任务状态
+func (w *Wangsu) ShowPushTaskStatusWithContext(ctx context.Context, data *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.PrefetchQueryResponse, error) {
+		return w.client.PrefetchQuery(ctx, &model.PrefetchQueryRequest{ItemId: data.TaskId})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.ResultDetail) == 0 {
+		return nil, newNotFoundError("task not found", "")
+	}
+	return &types.ShowPushTaskStatusResponse{
+		TaskId: data.TaskId,
+		Status: getTaskStatus(res.ResultDetail),
+	}, nil
+}
+
+// getTaskStatus 汇总任务下所有url的状态,存在进行中则为进行中,存在失败则为失败
+func getTaskStatus(details []*model.TaskResultDetail) int64 {
+	taskStatus := int64(consts.ShowContentPurgeOrPushStatusSuccess)
+	for _, v := range details {
+		status := getShowContentPurgeOrPushStatus(v.Status)
+		if status == consts.ShowContentPurgeOrPushStatusDoing {
+			return status
+		}
+		if status == consts.ShowContentPurgeOrPushStatusFail {
+			taskStatus = status
+		}
+	}
+	return taskStatus
+}
+
+// getTaskIds 任务明细按url维度返回,去重得到任务ID列表
+func getTaskIds(details []*model.TaskResultDetail) []string {
+	tasks := make([]string, 0, len(details))
+	exists := make(map[string]struct{}, len(details))
+	for _, v := range details {
+		if _, ok := exists[v.ItemId]; ok {
+			continue
+		}
+		exists[v.ItemId] = struct{}{}
+		tasks = append(tasks, v.ItemId)
+	}
+	return tasks
 }
 
+// ShowPurgeTaskList 展示刷新任务列表
 func (w *Wangsu) ShowPurgeTaskList(data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.ShowPurgeTaskListWithContext(w.ctx, data)
+}
+
+// ShowPurgeTaskListWithContext 展示刷新任务列表
+func (w *Wangsu) ShowPurgeTaskListWithContext(ctx context.Context, data *types.ShowPurgeTaskListRequest) (*types.ShowPurgeTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.TimeZone == "" {
+		data.TimeZone = "Asia/Shanghai"
+	}
+	offset, limit := utils.CalcOffsetAndLimit(data.Page, data.Limit)
+	res, err := invoke(ctx, w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.PurgeQueryResponse, error) {
+		return w.client.PurgeQuery(ctx, &model.PurgeQueryRequest{
+			StartTime:  utils.FormatTimeWithTimezone(data.StartTime, data.TimeZone),
+			EndTime:    utils.FormatTimeWithTimezone(data.EndTime, data.TimeZone),
+			Type:       getShowContentPurgeType(data.PurgeType),
+			Status:     setShowContentPurgeOrPushStatus(data.TaskStatus),
+			PageNumber: offset/limit + 1,
+			PageSize:   limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Total == 0 {
+		return &types.ShowPurgeTaskListResponse{Total: res.Total, List: make([]string, 0)}, nil
+	}
+	return &types.ShowPurgeTaskListResponse{Total: res.Total, List: getTaskIds(res.ResultDetail)}, nil
 }
 
+// ShowPushTaskList 展示预热任务列表
 func (w *Wangsu) ShowPushTaskList(data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.ShowPushTaskListWithContext(w.ctx, data)
+}
+
+// ShowPushTaskListWithContext 展示预热任务列表
+func (w *Wangsu) ShowPushTaskListWithContext(ctx context.Context, data *types.ShowPushTaskListRequest) (*types.ShowPushTaskListResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.TimeZone == "" {
+		data.TimeZone = "Asia/Shanghai"
+	}
+	offset, limit := utils.CalcOffsetAndLimit(data.Page, data.Limit)
+	res, err := invoke(ctx, w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.PrefetchQueryResponse, error) {
+		return w.client.PrefetchQuery(ctx, &model.PrefetchQueryRequest{
+			StartTime:  utils.FormatTimeWithTimezone(data.StartTime, data.TimeZone),
+			EndTime:    utils.FormatTimeWithTimezone(data.EndTime, data.TimeZone),
+			Status:     setShowContentPurgeOrPushStatus(data.TaskStatus),
+			PageNumber: offset/limit + 1,
+			PageSize:   limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Total == 0 {
+		return &types.ShowPushTaskListResponse{Total: res.Total, List: make([]string, 0)}, nil
+	}
+	return &types.ShowPushTaskListResponse{Total: res.Total, List: getTaskIds(res.ResultDetail)}, nil
 }
 
+// DomainAccessDataStatic 获取域名访问数据统计
 func (w *Wangsu) DomainAccessDataStatic(data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.DomainAccessDataStaticWithContext(w.ctx, data)
 }
 
+// DomainAccessDataStaticWithContext 获取域名访问数据统计
+func (w *Wangsu) DomainAccessDataStaticWithContext(ctx context.Context, data *types.DomainAccessDataStaticRequest) (types.DomainAccessDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.District != nil {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.WangsuSdkName, "district not support")
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	request := &model.DomainStatisticsRequest{
+		DomainList:  data.Domains,
+		StartTime:   formatTimeWithTimezone(data.StartTime, *data.TimeZone),
+		EndTime:     formatTimeWithTimezone(data.EndTime, *data.TimeZone),
+		DataType:    getDataAccessMetricType(data.Metric),
+		DataSource:  "edge",
+		Granularity: getDataIntervalType(data.Interval),
+		Region:      getRegion(data.Area),
+	}
+	if data.Isp != nil {
+		request.Isp = getIspCode(*data.Isp)
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassStats, retry.Idempotent, func() (*model.DomainStatisticsResponse, error) {
+		return w.client.DomainStatistics(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Data) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	responseData := make(types.DomainAccessDataStaticResponse)
+	for _, v := range res.Data {
+		responseData[v.Domain] = getStaticData(v.Details)
+	}
+	return responseData, nil
+}
+
+// DomainOriginDataStatic 获取域名回源数据统计
 func (w *Wangsu) DomainOriginDataStatic(data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.DomainOriginDataStaticWithContext(w.ctx, data)
 }
 
+// DomainOriginDataStaticWithContext 获取域名回源数据统计
+func (w *Wangsu) DomainOriginDataStaticWithContext(ctx context.Context, data *types.DomainOriginDataStaticRequest) (types.DomainOriginDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassStats, retry.Idempotent, func() (*model.DomainStatisticsResponse, error) {
+		return w.client.DomainStatistics(ctx, &model.DomainStatisticsRequest{
+			DomainList:  data.Domains,
+			StartTime:   formatTimeWithTimezone(data.StartTime, *data.TimeZone),
+			EndTime:     formatTimeWithTimezone(data.EndTime, *data.TimeZone),
+			DataType:    getDataOriginMetricType(data.Metric),
+			DataSource:  "origin",
+			Granularity: getDataIntervalType(data.Interval),
+			Region:      getRegion(data.Area),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := make(types.DomainOriginDataStaticResponse)
+	for _, v := range res.Data {
+		responseData[v.Domain] = getStaticData(v.Details)
+	}
+	return responseData, nil
+}
+
+func getStaticData(details []*model.StatisticsDetail) []*types.StaticData {
+	items := make([]*types.StaticData, 0, len(details))
+	for _, v := range details {
+		items = append(items, &types.StaticData{
+			Value: v.Value,
+			Time:  parseTime(v.Time),
+		})
+	}
+	return items
+}
+
+// ListTopUrlDataStatic 获取TOP URL数据统计
 func (w *Wangsu) ListTopUrlDataStatic(data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.ListTopUrlDataStaticWithContext(w.ctx, data)
+}
+
+// ListTopUrlDataStaticWithContext 获取TOP URL数据统计
+func (w *Wangsu) ListTopUrlDataStaticWithContext(ctx context.Context, data *types.ListTopUrlDataStaticRequest) ([]*types.ListTopUrlDataStaticResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassStats, retry.Idempotent, func() (*model.TopUrlResponse, error) {
+		return w.client.TopUrl(ctx, &model.TopUrlRequest{
+			Domain:    data.Domain,
+			StartTime: formatTimeWithTimezone(data.StartTime, "Asia/Shanghai"),
+			EndTime:   formatTimeWithTimezone(data.EndTime, "Asia/Shanghai"),
+			TopType:   getTopUrlFilter(data.Filter),
+			Region:    getRegion(data.Area),
+			Limit:     100,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := make([]*types.ListTopUrlDataStaticResponse, 0, len(res.Data))
+	for _, v := range res.Data {
+		responseData = append(responseData, &types.ListTopUrlDataStaticResponse{
+			Url:   v.Url,
+			Value: v.Value,
+		})
+	}
+	return responseData, nil
 }
 
+// DomainAccessTotalData 获取域名总流量
 func (w *Wangsu) DomainAccessTotalData(data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.DomainAccessTotalDataWithContext(w.ctx, data)
+}
+
+// DomainAccessTotalDataWithContext 获取域名总流量
+func (w *Wangsu) DomainAccessTotalDataWithContext(ctx context.Context, data *types.DomainAccessTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	dataType := getDataAccessMetricType(data.Metric)
+	if strings.Contains(dataType, "xx") {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.WangsuSdkName, "status code not support")
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassStats, retry.Idempotent, func() (*model.DomainStatisticsResponse, error) {
+		return w.client.DomainStatistics(ctx, &model.DomainStatisticsRequest{
+			DomainList: data.Domains,
+			StartTime:  formatTimeWithTimezone(data.StartTime, *data.TimeZone),
+			EndTime:    formatTimeWithTimezone(data.EndTime, *data.TimeZone),
+			DataType:   dataType,
+			DataSource: "edge",
+			Region:     getRegion(data.Area),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Data) == 0 {
+		return nil, newNotFoundError("data not found", "")
+	}
+	responseData := make(types.DataTotalDataResponse)
+	for _, v := range res.Data {
+		responseData[v.Domain] += int64(v.Total)
+	}
+	return responseData, nil
 }
 
+// DomainOriginTotalData 获取域名回源总流量
 func (w *Wangsu) DomainOriginTotalData(data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.DomainOriginTotalDataWithContext(w.ctx, data)
+}
+
+// DomainOriginTotalDataWithContext 获取域名回源总流量
+func (w *Wangsu) DomainOriginTotalDataWithContext(ctx context.Context, data *types.DomainOriginTotalDataRequest) (types.DataTotalDataResponse, error) {
+	if data == nil {
+		return nil, cdnerr.ErrNilRequest
+	}
+	if data.TimeZone == nil {
+		data.TimeZone = utils.StringPtr("Asia/Shanghai")
+	}
+	dataType := getDataOriginMetricType(data.Metric)
+	if strings.Contains(dataType, "xx") {
+		return nil, cdnerr.New(cdnerr.ErrUnsupported, types.WangsuSdkName, "status code not support")
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassStats, retry.Idempotent, func() (*model.DomainStatisticsResponse, error) {
+		return w.client.DomainStatistics(ctx, &model.DomainStatisticsRequest{
+			DomainList: data.Domains,
+			StartTime:  formatTimeWithTimezone(data.StartTime, *data.TimeZone),
+			EndTime:    formatTimeWithTimezone(data.EndTime, *data.TimeZone),
+			DataType:   dataType,
+			DataSource: "origin",
+			Region:     getRegion(data.Area),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseData := make(types.DataTotalDataResponse)
+	for _, v := range res.Data {
+		responseData[v.Domain] += int64(v.Total)
+	}
+	return responseData, nil
 }
 
+// UserAccessRegionDistribution 获取用户访问区域分布
 func (w *Wangsu) UserAccessRegionDistribution(data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
-	//TODO implement me
-	panic("implement me")
+	return w.UserAccessRegionDistributionWithContext(w.ctx, data)
+}
+
+// UserAccessRegionDistributionWithContext 获取用户访问区域分布
+func (w *Wangsu) UserAccessRegionDistributionWithContext(ctx context.Context, data *types.UserAccessRegionDistributionRequest) (types.UserAccessRegionDistributionResponse, error) {
+	responseData := make(types.UserAccessRegionDistributionResponse)
+	if data == nil {
+		return responseData, cdnerr.ErrNilRequest
+	}
+	res, err := invoke(ctx, w, ratelimit.ClassStats, retry.Idempotent, func() (*model.RegionStatisticsResponse, error) {
+		return w.client.RegionStatistics(ctx, &model.RegionStatisticsRequest{
+			DomainList: data.Domains,
+			StartTime:  formatTimeWithTimezone(data.StartTime, "Asia/Shanghai"),
+			EndTime:    formatTimeWithTimezone(data.EndTime, "Asia/Shanghai"),
+			DataType:   getDataAccessMetricType(data.Metric),
+		})
+	})
+	if err != nil {
+		return responseData, err
+	}
+	for _, v := range res.Data {
+		responseData[v.Domain] = &types.RegionDistribution{}
+		for _, vv := range v.Regions {
+			if vv.Region == "cn" {
+				responseData[v.Domain].MainLandValue += int64(vv.Value)
+				continue
+			}
+			responseData[v.Domain].OverSeaValue += int64(vv.Value)
+		}
+	}
+	return responseData, nil
+}
+
+//设置更新的各项信息
+
+// UpdateDomainConfigModel 网宿的各项配置分属不同接口,先收集各接口的请求再依次提交。
+// 访问控制与http头配置接口均为全量覆盖,同一次更新中的多项配置会合并到同一个请求中,
+// 提交前读取线上规则,保留不属于本次更新的配置项的规则
+type UpdateDomainConfigModel struct {
+	w             *Wangsu
+	req           *types.UpdateDomainRequest
+	modifyDomain  *model.ModifyDomainRequest
+	certificate   *model.CreateCertificateRequest
+	srcConfig     *model.SrcConfigRequest
+	cacheTime     *model.CacheTimeRequest
+	httpCodeCache *model.HttpCodeCacheRequest
+	headerModify  *model.HeaderModifyRequest
+	visitControl  *model.VisitControlRequest
+	innerRedirect *model.InnerRedirectRequest
+	accessSpeed   *model.AccessSpeedRequest
+	headerOwned   map[string]bool // 本次更新覆盖的http头方向
+	visitOwned    visitControlOwned
+}
+
+// visitControlOwned 本次更新覆盖的访问控制规则类型
+type visitControlOwned struct {
+	ip      bool
+	referer bool
+	ua      bool
+}
+
+func (w *Wangsu) newUpdateDomainConfigModel(req *types.UpdateDomainRequest) *UpdateDomainConfigModel {
+	return &UpdateDomainConfigModel{
+		w:   w,
+		req: req,
+	}
+}
+
+func (u *UpdateDomainConfigModel) getModifyDomain() *model.ModifyDomainRequest {
+	if u.modifyDomain == nil {
+		u.modifyDomain = &model.ModifyDomainRequest{DomainName: u.req.Domain, Version: constant.SDKVersion}
+	}
+	return u.modifyDomain
+}
+
+func (u *UpdateDomainConfigModel) getSrcConfig() *model.SrcConfigRequest {
+	if u.srcConfig == nil {
+		u.srcConfig = &model.SrcConfigRequest{DomainName: u.req.Domain}
+	}
+	return u.srcConfig
+}
+
+func (u *UpdateDomainConfigModel) getHeaderModify(direction string) *model.HeaderModifyRequest {
+	if u.headerModify == nil {
+		u.headerModify = &model.HeaderModifyRequest{DomainName: u.req.Domain, HeaderModifyRules: make([]*model.HeaderModifyRule, 0)}
+		u.headerOwned = make(map[string]bool)
+	}
+	u.headerOwned[direction] = true
+	return u.headerModify
+}
+
+func (u *UpdateDomainConfigModel) getVisitControl() *model.VisitControlRequest {
+	if u.visitControl == nil {
+		u.visitControl = &model.VisitControlRequest{DomainName: u.req.Domain, VisitControlRules: make([]*model.VisitControlRule, 0)}
+	}
+	return u.visitControl
+}
+
+func (u *UpdateDomainConfigModel) WithArea() {
+	if u.req.CdnDomain == nil {
+		return
+	}
+	u.getModifyDomain().ServiceAreas = utils.StringPtr(getServiceAreas(u.req.CdnDomain.AreaCode))
+}
+
+func (u *UpdateDomainConfigModel) WithOriginConf() {
+	if u.req.OriginConf == nil {
+		return
+	}
+	srcConfig := u.getSrcConfig()
+	srcConfig.UseRange = utils.BoolPtr(u.req.OriginConf.OriginRange == types.ON)
+	srcConfig.Follow301 = utils.BoolPtr(u.req.OriginConf.OriginFollow == types.ON)
+	srcConfig.Follow302 = utils.BoolPtr(u.req.OriginConf.OriginFollow == types.ON)
+	srcConfig.OriginProtocol = getOriginProtocol(u.req.OriginConf.OriginProtocol)
+}
+
+func (u *UpdateDomainConfigModel) WithOriginServerConf() {
+	if len(u.req.OriginServerConf) == 0 {
+		return
+	}
+	originConfig := getOriginConfig(u.req.OriginServerConf)
+	u.getModifyDomain().OriginConfig = &originConfig
+	var primary, back []string
+	for _, v := range u.req.OriginServerConf {
+		if v.OriginPriority == consts.OriginPriorityPrimary {
+			primary = append(primary, v.OriginAddressList)
+			continue
+		}
+		back = append(back, v.OriginAddressList)
+	}
+	advSrcSetting := &model.AdvSrcSetting{UseAdvSrc: false}
+	if len(back) > 0 {
+		advSrcSetting.UseAdvSrc = true
+		advSrcSetting.AdvSrcConfigs = model.AdvSrcConfigs{MasterIps: primary, BackupIps: back}
+	}
+	u.getSrcConfig().AdvSrcSetting = advSrcSetting
+}
+
+func (u *UpdateDomainConfigModel) WithOriginRequestHeaderConf() {
+	headerModify := u.getHeaderModify("cache2origin")
+	for _, v := range u.req.OriginRequestHeaderConf {
+		headerModify.HeaderModifyRules = append(headerModify.HeaderModifyRules, &model.HeaderModifyRule{
+			PathPattern:     ".*",
+			HeaderDirection: "cache2origin",
+			Action:          getHeaderAction(v.Action),
+			HeaderName:      v.ParameterKey,
+			HeaderValue:     v.ParameterValue,
+		})
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithResponseHeaderConf() {
+	headerModify := u.getHeaderModify("cache2visitor")
+	for _, v := range u.req.ResponseHeaderConf {
+		headerModify.HeaderModifyRules = append(headerModify.HeaderModifyRules, &model.HeaderModifyRule{
+			PathPattern:     ".*",
+			HeaderDirection: "cache2visitor",
+			Action:          getHeaderAction(v.Action),
+			HeaderName:      v.ParameterKey,
+			HeaderValue:     v.ParameterValue,
+		})
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithCacheListConf() {
+	behaviors := make([]*model.CacheTimeBehavior, 0, len(u.req.CacheListConf))
+	for _, v := range u.req.CacheListConf {
+		priority := int(v.Priority)
+		if priority == 0 {
+			priority = 10
+		}
+		behavior := &model.CacheTimeBehavior{
+			PathPattern: getPathPattern(v.CacheType, v.CacheContent),
+			CacheTTL:    strconv.FormatInt(cachettl.Seconds(v.CacheTTL, v.CacheUnit), 10),
+			Priority:    priority,
+		}
+		switch v.CacheStatus {
+		case consts.CacheStatusFollow:
+			behavior.IsRespectServer = utils.BoolPtr(true)
+		case consts.CacheStatusOff:
+			behavior.CacheTTL = "0"
+		case consts.CacheStatusOn:
+			behavior.IgnoreCacheControl = utils.BoolPtr(true)
+		}
+		behaviors = append(behaviors, behavior)
+	}
+	u.cacheTime = &model.CacheTimeRequest{DomainName: u.req.Domain, CacheTimeBehaviors: behaviors}
+}
+
+func (u *UpdateDomainConfigModel) WithCacheCodeConf() {
+	rules := make([]*model.HttpCodeCacheRule, 0)
+	//相同缓存时间的状态码合并为一条规则
+	ttlIndex := make(map[int64]int)
+	for _, v := range u.req.CacheCodeConf {
+		ttl := cachettl.Seconds(v.CacheTTL, v.CacheUnit)
+		if i, ok := ttlIndex[ttl]; ok {
+			rules[i].HttpCodes = append(rules[i].HttpCodes, strconv.FormatInt(v.HttpCode, 10))
+			continue
+		}
+		ttlIndex[ttl] = len(rules)
+		rules = append(rules, &model.HttpCodeCacheRule{
+			CacheTtl:  strconv.FormatInt(ttl, 10),
+			HttpCodes: []string{strconv.FormatInt(v.HttpCode, 10)},
+		})
+	}
+	u.httpCodeCache = &model.HttpCodeCacheRequest{DomainName: u.req.Domain, HttpCodeCacheRules: rules}
+}
+
+func (u *UpdateDomainConfigModel) WithRequestUrlRewriteConf() {
+	settings := make([]*model.RewriteRuleSetting, 0, len(u.req.RequestUrlRewriteConf))
+	for _, v := range u.req.RequestUrlRewriteConf {
+		beforeValue := `^https?://[^/]+` + regexp.QuoteMeta(v.RewriteUrl)
+		afterValue := getRedirectCode(v.RedirectCode) + ":" + v.TargetUrl
+		if v.MateMethod == consts.RequestUrlRewriteTypeFullPath {
+			beforeValue += "$"
+		} else {
+			beforeValue += "(.*)$"
+			afterValue += "$1"
+		}
+		priority := int(v.Priority)
+		if priority == 0 {
+			priority = 10
+		}
+		settings = append(settings, &model.RewriteRuleSetting{
+			PathPattern:      ".*",
+			IgnoreLetterCase: true,
+			PublishType:      "Cache",
+			Priority:         priority,
+			BeforeValue:      beforeValue,
+			AfterValue:       afterValue,
+			RewriteType:      "before",
+		})
+	}
+	u.innerRedirect = &model.InnerRedirectRequest{DomainName: u.req.Domain, RewriteRuleSettings: settings}
+}
+
+func (u *UpdateDomainConfigModel) WithIpFilterConf() {
+	visitControl := u.getVisitControl()
+	u.visitOwned.ip = true
+	if u.req.IpFilterConf == nil || u.req.IpFilterConf.Status != types.ON {
+		return
+	}
+	for _, v := range u.req.IpFilterConf.IpFilterConf {
+		if len(v.IpList) == 0 {
+			continue
+		}
+		ipControlRule := &model.IpControlRule{}
+		if v.IpType == consts.WhiteList {
+			ipControlRule.AllowedIps = strings.Join(v.IpList, ";")
+		} else {
+			ipControlRule.ForbiddenIps = strings.Join(v.IpList, ";")
+		}
+		visitControl.VisitControlRules = append(visitControl.VisitControlRules, &model.VisitControlRule{
+			PathPattern:   getPathPattern(v.EffectiveType, v.EffectiveRules),
+			ControlAction: "403",
+			Priority:      10,
+			IpControlRule: ipControlRule,
+		})
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithRefererConf() {
+	visitControl := u.getVisitControl()
+	u.visitOwned.referer = true
+	if u.req.RefererConf == nil || u.req.RefererConf.Status != types.ON || len(u.req.RefererConf.RefererList) == 0 {
+		return
+	}
+	refererControlRule := &model.RefererControlRule{
+		AllowNullReferer: strconv.FormatBool(u.req.RefererConf.IncludeEmpty == types.ON),
+	}
+	if u.req.RefererConf.RefererType == consts.WhiteList {
+		refererControlRule.ValidReferer = strings.Join(u.req.RefererConf.RefererList, " ")
+	} else {
+		refererControlRule.InvalidReferer = strings.Join(u.req.RefererConf.RefererList, " ")
+	}
+	visitControl.VisitControlRules = append(visitControl.VisitControlRules, &model.VisitControlRule{
+		PathPattern:        ".*",
+		ControlAction:      "403",
+		Priority:           10,
+		RefererControlRule: refererControlRule,
+	})
+}
+
+func (u *UpdateDomainConfigModel) WithUserAgentConf() {
+	visitControl := u.getVisitControl()
+	u.visitOwned.ua = true
+	if u.req.UserAgentConf == nil || u.req.UserAgentConf.Status != types.ON {
+		return
+	}
+	for _, v := range u.req.UserAgentConf.UserAgentConf {
+		if len(v.AgentList) == 0 {
+			continue
+		}
+		//网宿UA规则不允许空格
+		agents := strings.ReplaceAll(strings.Join(v.AgentList, "|"), " ", `\s`)
+		uaControlRule := &model.UaControlRule{}
+		if v.AgentType == consts.WhiteList {
+			uaControlRule.ValidUserAgents = agents
+		} else {
+			uaControlRule.InvalidUserAgents = agents
+		}
+		visitControl.VisitControlRules = append(visitControl.VisitControlRules, &model.VisitControlRule{
+			PathPattern:   getPathPattern(v.EffectiveType, v.EffectiveRules),
+			ControlAction: "403",
+			Priority:      10,
+			UaControlRule: uaControlRule,
+		})
+	}
+}
+
+func (u *UpdateDomainConfigModel) WithSpeedConf() {
+	rules := make([]model.AccessSpeedRule, 0)
+	if u.req.SpeedConf != nil && u.req.SpeedConf.Status == types.ON {
+		for _, v := range u.req.SpeedConf.SpeedConf {
+			rules = append(rules, model.AccessSpeedRule{
+				PathPattern: getPathPattern(v.RuleType, v.RuleContent),
+				LimitMode:   "single_request",
+				Speed:       strconv.FormatInt(v.SpeedValues, 10),
+				Priority:    10,
+			})
+		}
+	}
+	u.accessSpeed = &model.AccessSpeedRequest{DomainName: u.req.Domain, AccessSpeedRules: rules}
+}
+
+func (u *UpdateDomainConfigModel) WithHttpsConf() {
+	if u.req.HttpsConf == nil {
+		return
+	}
+	ssl := &model.SslConfig{UseSsl: u.req.HttpsConf.HttpsStatus == types.ON}
+	if ssl.UseSsl {
+		ssl.UseForSni = true
+		ssl.TlsVersion = getTlsVersions(u.req.HttpsConf.TlsVersion)
+		ssl.EnableHttp2 = u.req.HttpsConf.HttpTwo == types.ON
+		ssl.EnableOcsp = u.req.HttpsConf.OcspStatus == types.ON
+		name := u.req.HttpsConf.CertName
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", u.req.Domain, time.Now().Unix())
+		}
+		u.certificate = &model.CreateCertificateRequest{
+			Name:        name,
+			Certificate: u.req.HttpsConf.CertValue,
+			PrivateKey:  u.req.HttpsConf.CertKey,
+		}
+	}
+	u.getModifyDomain().Ssl = ssl
+}
+
+// send 依次提交收集到的配置,证书需先上传以获取证书ID
+func (u *UpdateDomainConfigModel) send(ctx context.Context) error {
+	client := u.w.client
+	if u.certificate != nil {
+		res, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.NonIdempotent, func() (*model.CreateCertificateResponse, error) {
+			return client.CreateCertificate(ctx, u.certificate)
+		})
+		if err != nil {
+			return err
+		}
+		if res.CertificateId == "" {
+			return &cdnerr.Error{
+				Kind:      cdnerr.ErrProvider,
+				Provider:  types.WangsuSdkName,
+				Message:   "create certificate fail: certificate id is empty",
+				RequestId: res.XCncRequestId,
+			}
+		}
+		u.modifyDomain.Ssl.SslCertificateId = res.CertificateId
+	}
+	if u.modifyDomain != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (*model.ModifyDomainResponse, error) {
+			return client.ModifyDomain(ctx, u.modifyDomain)
+		}); err != nil {
+			return err
+		}
+	}
+	if u.srcConfig != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (*model.SrcConfigResponse, error) {
+			return client.SetSrcConfig(ctx, u.srcConfig)
+		}); err != nil {
+			return err
+		}
+	}
+	if u.cacheTime != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (*model.CacheTimeResponse, error) {
+			return client.CacheTime(ctx, u.cacheTime)
+		}); err != nil {
+			return err
+		}
+	}
+	if u.httpCodeCache != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (*model.HttpCodeCacheResponse, error) {
+			return client.HttpCodeCache(ctx, u.httpCodeCache)
+		}); err != nil {
+			return err
+		}
+	}
+	if u.headerModify != nil || u.visitControl != nil {
+		if err := u.mergeLiveRules(ctx); err != nil {
+			return err
+		}
+	}
+	if u.headerModify != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (model.HeaderModifyResponse, error) {
+			return client.HeaderModify(ctx, u.headerModify)
+		}); err != nil {
+			return err
+		}
+	}
+	if u.visitControl != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (model.VisitControlResponse, error) {
+			return client.VisitControl(ctx, u.visitControl)
+		}); err != nil {
+			return err
+		}
+	}
+	if u.innerRedirect != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (model.InnerRedirectResponse, error) {
+			return client.InnerRedirect(ctx, u.innerRedirect)
+		}); err != nil {
+			return err
+		}
+	}
+	if u.accessSpeed != nil {
+		if _, err := invoke(ctx, u.w, ratelimit.ClassConfig, retry.Idempotent, func() (model.AccessSpeedResponse, error) {
+			return client.AccessSpeed(ctx, u.accessSpeed)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeLiveRules 访问控制与http头接口全量覆盖,读取线上规则并保留本次更新未覆盖的规则,
+// 线上规则排在本次更新的规则之前
+func (u *UpdateDomainConfigModel) mergeLiveRules(ctx context.Context) error {
+	detail, err := invoke(ctx, u.w, ratelimit.ClassQuery, retry.Idempotent, func() (*model.ShowDomainDetailResponse, error) {
+		return u.w.client.ShowDomainDetail(ctx, &model.ShowDomainDetailRequest{DomainName: u.req.Domain})
+	})
+	if err != nil {
+		return err
+	}
+	if u.headerModify != nil {
+		rules := make([]*model.HeaderModifyRule, 0, len(detail.HeaderModifyRules)+len(u.headerModify.HeaderModifyRules))
+		for _, v := range detail.HeaderModifyRules {
+			if !u.headerOwned[v.HeaderDirection] {
+				rules = append(rules, v)
+			}
+		}
+		u.headerModify.HeaderModifyRules = append(rules, u.headerModify.HeaderModifyRules...)
+	}
+	if u.visitControl != nil {
+		rules := make([]*model.VisitControlRule, 0, len(detail.VisitControlRules)+len(u.visitControl.VisitControlRules))
+		for _, v := range detail.VisitControlRules {
+			if rule := u.visitOwned.strip(v); rule != nil {
+				rules = append(rules, rule)
+			}
+		}
+		u.visitControl.VisitControlRules = append(rules, u.visitControl.VisitControlRules...)
+	}
+	return nil
+}
+
+// strip 去掉线上规则中本次更新覆盖的部分,剩余部分不包含任何控制规则时返回nil
+func (o visitControlOwned) strip(rule *model.VisitControlRule) *model.VisitControlRule {
+	r := *rule
+	if o.ip {
+		r.IpControlRule = nil
+	}
+	if o.referer {
+		r.RefererControlRule = nil
+	}
+	if o.ua {
+		r.UaControlRule = nil
+	}
+	if r.IpControlRule == nil && r.RefererControlRule == nil && r.UaControlRule == nil &&
+		r.AdvanceControlRules == nil && r.CookieControlRules == nil && r.CustomHeaderControlRules == nil {
+		return nil
+	}
+	return &r
 }
@@ -0,0 +1,316 @@
+package wangsu
+
+import (
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/sp/wangsu/common/model"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"sync"
+	"testing"
+)
+
+func TestDomainLifecycle(t *testing.T) {
+	s, w := newStandIn(t)
+	err := w.CreateDomain(&types.CreateDomainRequest{
+		Domain:      "www.example.com",
+		AreaCode:    consts.AreaCodeGlobal,
+		ChannelType: consts.ChannelTypeDownload,
+		Sources: []*entity.OriginServerConf{
+			{OriginAddressList: "1.1.1.1", OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityPrimary},
+			{OriginAddressList: "2.2.2.2", OriginPriority: consts.OriginPriorityBackup},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := s.domain("www.example.com"); d.ServiceType != "dl-https" || d.ServiceAreas != "am;emea;apac;cn" || d.OriginConfig.OriginIps != "1.1.1.1" {
+		t.Fatalf("unexpected created domain %+v", d)
+	}
+	detail, err := w.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.AreaCode != consts.AreaCodeGlobal || detail.ChannelType != consts.ChannelTypeDownload || detail.Status != consts.CdnDomainStatusDeploying {
+		t.Fatalf("unexpected detail %+v", detail)
+	}
+	s.domain("www.example.com").Status = "Deployed"
+	if err := w.DisableDomain(&types.DisableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := w.ShowDomainStatusList(&types.ShowDomainStatusListRequest{Page: 1, Limit: 10, Status: consts.CdnDomainStatusStoped})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 1 || list.List[0] != "www.example.com" {
+		t.Fatalf("unexpected stopped list %+v", list)
+	}
+	if err := w.EnableDomain(&types.EnableDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: "www.example.com"}); !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("want ErrDomainNotFound, got %v", err)
+	}
+	//删除不存在的域名视为成功
+	if err := w.DeleteDomain(&types.DeleteDomainRequest{Domain: "www.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateVisitControlKeepsOtherSections(t *testing.T) {
+	s, w := newStandIn(t)
+	d := s.addDomain("www.example.com")
+	d.VisitControlRules = []*model.VisitControlRule{
+		{PathPattern: ".*", ControlAction: "403", IpControlRule: &model.IpControlRule{ForbiddenIps: "1.1.1.1;2.2.2.0/24"}},
+		{PathPattern: ".*", ControlAction: "403", UaControlRule: &model.UaControlRule{InvalidUserAgents: "curl"}},
+		{PathPattern: ".*", ControlAction: "403", RefererControlRule: &model.RefererControlRule{InvalidReferer: "old.com"}},
+		{PathPattern: ".*", ControlAction: "403", AdvanceControlRules: &model.AdvanceControlRule{InvalidVisitorRegion: "JP"}},
+	}
+	err := w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateRefererConf,
+		Domain:       "www.example.com",
+		RefererConf:  &entity.Referer{Status: consts.SwitchOn, RefererType: consts.WhiteList, RefererList: []string{"example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := w.ShowDomainConfig(&types.ShowDomainConfigRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.IpFilterConf.IpFilterConf) != 1 || len(conf.IpFilterConf.IpFilterConf[0].IpList) != 2 {
+		t.Fatalf("ip filter rules lost: %+v", conf.IpFilterConf)
+	}
+	if len(conf.UserAgentConf.UserAgentConf) != 1 || conf.UserAgentConf.UserAgentConf[0].AgentList[0] != "curl" {
+		t.Fatalf("user agent rules lost: %+v", conf.UserAgentConf)
+	}
+	if conf.RefererConf.RefererType != consts.WhiteList || len(conf.RefererConf.RefererList) != 1 || conf.RefererConf.RefererList[0] != "example.com" {
+		t.Fatalf("referer not replaced: %+v", conf.RefererConf)
+	}
+	rules := s.domain("www.example.com").VisitControlRules
+	if len(rules) != 4 || rules[2].AdvanceControlRules == nil {
+		t.Fatalf("unowned rules lost: %d rules", len(rules))
+	}
+
+	//关闭IP黑白名单只清除IP规则
+	err = w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateIpFilterConf,
+		Domain:       "www.example.com",
+		IpFilterConf: &types.IpFilterConf{Status: consts.SwitchOff},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err = w.ShowDomainConfig(&types.ShowDomainConfigRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.IpFilterConf.Status != consts.SwitchOff || conf.UserAgentConf.Status != consts.SwitchOn || conf.RefererConf.Status != consts.SwitchOn {
+		t.Fatalf("unexpected sections after disabling ip filter: ip %+v ua %+v referer %+v", conf.IpFilterConf, conf.UserAgentConf, conf.RefererConf)
+	}
+}
+
+func TestReadBackOrigins(t *testing.T) {
+	s, w := newStandIn(t)
+	s.addDomain("www.example.com")
+	err := w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateFullConf,
+		Domain:       "www.example.com",
+		OriginConf:   &entity.OriginConf{OriginProtocol: consts.OriginProtocolHttps, OriginRange: types.ON, OriginFollow: types.ON},
+		OriginServerConf: []*entity.OriginServerConf{
+			{OriginType: consts.OriginTypeIp, OriginAddressList: "1.1.1.1", OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityPrimary},
+			{OriginType: consts.OriginTypeDomain, OriginAddressList: "backup.example.com", OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityBackup},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := w.ShowDomainConfig(&types.ShowDomainConfigRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o := conf.OriginConf; o == nil || o.OriginProtocol != consts.OriginProtocolHttps || o.OriginRange != types.ON || o.OriginFollow != types.ON {
+		t.Fatalf("unexpected origin conf %+v", conf.OriginConf)
+	}
+	if len(conf.OriginServerConf) != 2 {
+		t.Fatalf("got %d origins, want 2", len(conf.OriginServerConf))
+	}
+	for i, want := range []entity.OriginServerConf{
+		{OriginType: consts.OriginTypeIp, OriginAddressList: "1.1.1.1", OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityPrimary},
+		{OriginType: consts.OriginTypeDomain, OriginAddressList: "backup.example.com", OriginHost: "origin.example.com", OriginPriority: consts.OriginPriorityBackup},
+	} {
+		if got := conf.OriginServerConf[i]; got.OriginType != want.OriginType || got.OriginAddressList != want.OriginAddressList || got.OriginHost != want.OriginHost || got.OriginPriority != want.OriginPriority {
+			t.Errorf("origin %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	//只更新回源配置时保留备源站
+	err = w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateOriginConf,
+		Domain:       "www.example.com",
+		OriginConf:   &entity.OriginConf{OriginProtocol: consts.OriginProtocolFollow},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err = w.ShowDomainConfig(&types.ShowDomainConfigRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.OriginConf.OriginProtocol != consts.OriginProtocolFollow || conf.OriginConf.OriginRange != types.OFF || len(conf.OriginServerConf) != 2 {
+		t.Fatalf("unexpected config after origin conf update: %+v %d origins", conf.OriginConf, len(conf.OriginServerConf))
+	}
+}
+
+func TestUpdateHeaderModifyKeepsOtherDirection(t *testing.T) {
+	s, w := newStandIn(t)
+	d := s.addDomain("www.example.com")
+	d.HeaderModifyRules = []*model.HeaderModifyRule{
+		{PathPattern: ".*", HeaderDirection: "cache2visitor", Action: "add", HeaderName: "X-Resp", HeaderValue: "1"},
+		{PathPattern: ".*", HeaderDirection: "cache2origin", Action: "set", HeaderName: "X-Old", HeaderValue: "1"},
+		{PathPattern: ".*", HeaderDirection: "visitor2cache", Action: "delete", HeaderName: "Cookie"},
+	}
+	err := w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateOriginRequestHeaderConf,
+		Domain:       "www.example.com",
+		OriginRequestHeaderConf: []*entity.OriginRequestHeaderConf{
+			{Action: consts.OriginHeaderActionSet, ParameterKey: "X-New", ParameterValue: "2"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err := w.ShowDomainConfig(&types.ShowDomainConfigRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.ResponseHeaderConf) != 1 || conf.ResponseHeaderConf[0].ParameterKey != "X-Resp" {
+		t.Fatalf("response headers lost: %+v", conf.ResponseHeaderConf)
+	}
+	if len(conf.OriginRequestHeaderConf) != 1 || conf.OriginRequestHeaderConf[0].ParameterKey != "X-New" {
+		t.Fatalf("origin request headers not replaced: %+v", conf.OriginRequestHeaderConf)
+	}
+	if rules := s.domain("www.example.com").HeaderModifyRules; len(rules) != 3 {
+		t.Fatalf("unowned header rules lost: %d rules", len(rules))
+	}
+
+	err = w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction:       types.UpdateResponseHeaderConf,
+		Domain:             "www.example.com",
+		ResponseHeaderConf: []*entity.ResponseHeaderConf{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, err = w.ShowDomainConfig(&types.ShowDomainConfigRequest{Domain: "www.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.ResponseHeaderConf) != 0 || len(conf.OriginRequestHeaderConf) != 1 {
+		t.Fatalf("unexpected headers after clearing response headers: %+v %+v", conf.ResponseHeaderConf, conf.OriginRequestHeaderConf)
+	}
+}
+
+func TestUpdateCacheListConf(t *testing.T) {
+	s, w := newStandIn(t)
+	s.addDomain("www.example.com")
+	err := w.UpdateDomain(&types.UpdateDomainRequest{
+		UpdateAction: types.UpdateCacheListConf,
+		Domain:       "www.example.com",
+		CacheListConf: []*entity.CacheListItem{
+			{CacheType: consts.RuleTypeFileSuffix, CacheContent: []string{"jpg", "png"}, CacheTTL: 2, CacheUnit: consts.CacheUnitHour, CacheStatus: consts.CacheStatusOn},
+			{CacheType: consts.RuleTypeDirectory, CacheContent: []string{"/api"}, CacheStatus: consts.CacheStatusOff},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	behaviors := s.domain("www.example.com").CacheTimeBehaviors
+	if len(behaviors) != 2 || behaviors[0].CacheTTL != "7200" || behaviors[1].CacheTTL != "0" {
+		t.Fatalf("unexpected cache behaviors %+v", behaviors)
+	}
+	if behaviors[0].PathPattern != `.*\.(jpg|png)(\?.*)?$` {
+		t.Fatalf("unexpected path pattern %s", behaviors[0].PathPattern)
+	}
+	//未覆盖的访问控制与http头接口不会被调用
+	for _, v := range s.callList() {
+		if v == "GET /api/domain/www.example.com" {
+			t.Fatalf("unexpected live rule read for cache update: %v", s.callList())
+		}
+	}
+}
+
+func TestPurgeAndTaskStatus(t *testing.T) {
+	_, w := newStandIn(t)
+	res, err := w.PurgeUrlsCache(&types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a.jpg"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := w.ShowPurgeTaskStatus(&types.ShowPurgeTaskStatusRequest{TaskId: res.TaskId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != consts.ShowContentPurgeOrPushStatusSuccess {
+		t.Fatalf("unexpected task status %+v", status)
+	}
+	if _, err := w.ShowPurgeTaskStatus(&types.ShowPurgeTaskStatusRequest{TaskId: "missing"}); err == nil {
+		t.Fatal("want error for missing task")
+	}
+}
+
+func TestDomainAccessDataStatic(t *testing.T) {
+	_, w := newStandIn(t)
+	res, err := w.DomainAccessDataStatic(&types.DomainAccessDataStaticRequest{
+		Domains:   []string{"www.example.com"},
+		StartTime: 1700000000,
+		EndTime:   1700000300,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := res["www.example.com"]
+	if len(data) != 2 || data[0].Time != 1700000000 || data[1].Time != 1700000300 || data[1].Value != 200 {
+		t.Fatalf("unexpected statistics %+v", data)
+	}
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	s, w := newStandIn(t)
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+	for i := 0; i < 32; i++ {
+		domain := fmt.Sprintf("www%d.example.com", i)
+		s.addDomain(domain)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := w.UpdateDomain(&types.UpdateDomainRequest{
+				UpdateAction: types.UpdateArea,
+				Domain:       domain,
+				CdnDomain:    &entity.UpdateCdnDomainBaseConf{AreaCode: int64(i % 3)},
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			detail, err := w.ShowDomainDetail(&types.ShowDomainDetailRequest{Domain: domain})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if detail.Domain != domain || detail.AreaCode != int64(i%3) {
+				errs <- fmt.Errorf("%s: got %s area %d", domain, detail.Domain, detail.AreaCode)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
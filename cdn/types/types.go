@@ -32,7 +32,11 @@ const (
 	HuaWeiSdkName                    = "huawei"
 	TencentSdkName                   = "tencent"
 	KsYunSdkName                     = "ksyun"
+	AliyunSdkName                    = "aliyun"
 	WangsuSdkName                    = "wangsu"
+	BaiduSdkName                     = "baidu"
+	VolcengineSdkName                = "volcengine"
+	MultiSdkName                     = "multi"
 )
 const (
 	TypeA = iota
@@ -111,6 +115,11 @@ type (
 		DomainId string `json:"domain_id"` // 域名ID
 	}
 
+	ShowDomainConfigRequest struct {
+		Domain   string `json:"domain"`    // 域名
+		DomainId string `json:"domain_id"` // 域名ID
+	}
+
 	ShowDomainDetailResponse struct {
 		DomainId    string `json:"domain_id"`    // 域名ID
 		Domain      string `json:"domain"`       // 域名
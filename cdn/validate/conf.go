@@ -0,0 +1,363 @@
+package validate
+
+import (
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/internal/cachettl"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/utils"
+	"net"
+)
+
+// checkActions 校验更新动作是否支持,UpdateAction为空时按请求中的配置项逐个校验
+func (v *validator) checkActions(req *types.UpdateDomainRequest) {
+	if req.UpdateAction == "" {
+		for _, s := range sections {
+			if !s.present(req) || v.supported(s.action) {
+				continue
+			}
+			//基础配置与加速区域共用cdn_domain,厂商支持其中之一即可
+			if s.path == "cdn_domain" && (v.supported(types.UpdateBaseConf) || v.supported(types.UpdateArea)) {
+				continue
+			}
+			v.unsupported(s.path, "update action %s not supported", s.action)
+		}
+		return
+	}
+	if !v.supported(req.UpdateAction) {
+		v.unsupported("update_action", "update action %s not supported", req.UpdateAction)
+		return
+	}
+	if containsString(multiActions, req.UpdateAction) {
+		return
+	}
+	for _, s := range sections {
+		if s.action == req.UpdateAction && !s.present(req) {
+			v.invalid(s.path, "is required by update action %s", req.UpdateAction)
+		}
+	}
+}
+
+func (v *validator) supported(action string) bool {
+	return v.c.Supports(action)
+}
+
+func (v *validator) checkBaseConf(req *types.UpdateDomainRequest) {
+	if req.CdnDomain == nil {
+		return
+	}
+	v.oneOf("cdn_domain.area_code", req.CdnDomain.AreaCode, v.c.AreaCodes)
+}
+
+func (v *validator) checkOriginConf(req *types.UpdateDomainRequest) {
+	if req.OriginConf == nil {
+		return
+	}
+	if req.OriginConf.OriginSniSwitch == consts.SwitchOn {
+		v.required("origin_conf.origin_sni_value", req.OriginConf.OriginSniValue)
+	}
+}
+
+func (v *validator) checkOriginServerConf(req *types.UpdateDomainRequest) {
+	if req.OriginServerConf == nil {
+		return
+	}
+	count := make(map[int64]int)
+	for i, item := range req.OriginServerConf {
+		path := fmt.Sprintf("origin_server_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		count[item.OriginPriority]++
+		v.required(path+".origin_address_list", item.OriginAddressList)
+		v.port(path+".origin_http_port", item.OriginHttpPort)
+		v.port(path+".origin_https_port", item.OriginHttpsPort)
+	}
+	v.limit("origin_server_conf", count[consts.OriginPriorityPrimary], v.c.OriginServers)
+	v.limit("origin_server_conf", count[consts.OriginPriorityBackup], v.c.OriginServers)
+}
+
+func (v *validator) checkOriginAdvanceServerConf(req *types.UpdateDomainRequest) {
+	v.limit("origin_advance_server_conf", len(req.OriginAdvanceServerConf), v.c.OriginAdvanceRules)
+	for i, item := range req.OriginAdvanceServerConf {
+		path := fmt.Sprintf("origin_advance_server_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		if len(item.UrlMatchRule) == 0 {
+			v.invalid(path+".url_match_rule", "is required")
+		}
+		v.required(path+".origin_address_list", item.OriginAddressList)
+		v.port(path+".origin_http_port", item.OriginHttpPort)
+		v.port(path+".origin_https_port", item.OriginHttpsPort)
+	}
+}
+
+func (v *validator) checkOriginRequestHeaderConf(req *types.UpdateDomainRequest) {
+	v.limit("origin_request_header_conf", len(req.OriginRequestHeaderConf), v.c.HeaderRules)
+	for i, item := range req.OriginRequestHeaderConf {
+		path := fmt.Sprintf("origin_request_header_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.required(path+".parameter_key", item.ParameterKey)
+	}
+}
+
+func (v *validator) checkOriginUrlConf(req *types.UpdateDomainRequest) {
+	v.limit("origin_url_conf", len(req.OriginUrlConf), v.c.OriginUrlRules)
+	for i, item := range req.OriginUrlConf {
+		path := fmt.Sprintf("origin_url_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.required(path+".rewrite_url", item.RewriteUrl)
+		v.required(path+".target_url", item.TargetUrl)
+	}
+}
+
+func (v *validator) checkIpFilterConf(req *types.UpdateDomainRequest) {
+	if req.IpFilterConf == nil {
+		return
+	}
+	if req.IpFilterConf.Status == consts.SwitchOn && len(req.IpFilterConf.IpFilterConf) == 0 {
+		v.invalid("ip_filter_conf.ip_filter_conf", "at least one rule is required when status is on")
+	}
+	for i, item := range req.IpFilterConf.IpFilterConf {
+		path := fmt.Sprintf("ip_filter_conf.ip_filter_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.limit(path+".ip_list", len(item.IpList), v.c.IpFilterIps)
+		for j, ip := range item.IpList {
+			if net.ParseIP(ip) == nil {
+				if _, _, err := net.ParseCIDR(ip); err != nil {
+					v.invalid(fmt.Sprintf("%s.ip_list[%d]", path, j), "%q is not an IP or CIDR", ip)
+				}
+			}
+		}
+	}
+}
+
+func (v *validator) checkIpFrequencyConf(req *types.UpdateDomainRequest) {
+	if req.IpFrequencyConf == nil {
+		return
+	}
+	if req.IpFrequencyConf.Status == consts.SwitchOn && req.IpFrequencyConf.Frequency <= 0 {
+		v.invalid("ip_frequency_conf.frequency", "must be greater than 0 when status is on")
+	}
+}
+
+func (v *validator) checkRefererConf(req *types.UpdateDomainRequest) {
+	if req.RefererConf == nil {
+		return
+	}
+	if req.RefererConf.Status == consts.SwitchOn && len(req.RefererConf.RefererList) == 0 {
+		v.invalid("referer_conf.referer_list", "is required when status is on")
+	}
+	v.limit("referer_conf.referer_list", len(req.RefererConf.RefererList), v.c.Referers)
+}
+
+func (v *validator) checkUserAgentConf(req *types.UpdateDomainRequest) {
+	if req.UserAgentConf == nil {
+		return
+	}
+	if req.UserAgentConf.Status == consts.SwitchOn && len(req.UserAgentConf.UserAgentConf) == 0 {
+		v.invalid("user_agent_conf.user_agent_conf", "at least one rule is required when status is on")
+	}
+	for i, item := range req.UserAgentConf.UserAgentConf {
+		path := fmt.Sprintf("user_agent_conf.user_agent_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		if len(item.AgentList) == 0 {
+			v.invalid(path+".agent_list", "is required")
+		}
+		v.limit(path+".agent_list", len(item.AgentList), v.c.UserAgents)
+	}
+}
+
+func (v *validator) checkSpeedConf(req *types.UpdateDomainRequest) {
+	if req.SpeedConf == nil {
+		return
+	}
+	for i, item := range req.SpeedConf.SpeedConf {
+		path := fmt.Sprintf("speed_conf.speed_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		if item.SpeedValues <= 0 {
+			v.invalid(path+".speed_values", "must be greater than 0")
+		}
+	}
+}
+
+func (v *validator) checkAuthConf(req *types.UpdateDomainRequest) {
+	if req.AuthConf == nil || req.AuthConf.Status != consts.SwitchOn {
+		return
+	}
+	v.oneOf("auth_conf.auth_manner", req.AuthConf.AuthManner, v.c.AuthManners)
+	v.required("auth_conf.auth_key", req.AuthConf.AuthKey)
+}
+
+func (v *validator) checkRemoteAuthConf(req *types.UpdateDomainRequest) {
+	if req.RemoteAuthConf == nil || req.RemoteAuthConf.Status != consts.SwitchOn {
+		return
+	}
+	v.required("remote_auth_conf.auth_url", req.RemoteAuthConf.AuthUrl)
+}
+
+func (v *validator) checkCacheListConf(req *types.UpdateDomainRequest) {
+	v.limit("cache_list_conf", len(req.CacheListConf), v.c.CacheRules)
+	for i, item := range req.CacheListConf {
+		path := fmt.Sprintf("cache_list_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		if item.CacheType != consts.RuleTypeAll && item.CacheType != consts.RuleTypeIndex && len(item.CacheContent) == 0 {
+			v.invalid(path+".cache_content", "is required")
+		}
+		v.limit(path+".cache_content", len(item.CacheContent), v.c.CacheRuleContents)
+		v.ttl(path+".cache_ttl", item.CacheTTL, item.CacheUnit)
+		if item.ParametersStatus == consts.CacheParameterStatusInclude || item.ParametersStatus == consts.CacheParameterStatusExclude {
+			if len(item.ParametersValue) == 0 {
+				v.invalid(path+".parameters_value", "is required when parameters_status is include or exclude")
+			}
+			v.limit(path+".parameters_value", len(item.ParametersValue), v.c.CacheParameters)
+		}
+	}
+}
+
+func (v *validator) checkCacheCodeConf(req *types.UpdateDomainRequest) {
+	for i, item := range req.CacheCodeConf {
+		path := fmt.Sprintf("cache_code_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.between(path+".http_code", item.HttpCode, 100, 599)
+		v.ttl(path+".cache_ttl", item.CacheTTL, item.CacheUnit)
+	}
+}
+
+func (v *validator) checkBrowserCacheConf(req *types.UpdateDomainRequest) {
+	v.limit("browser_cache_conf", len(req.BrowserCacheConf), v.c.CacheRules)
+	for i, item := range req.BrowserCacheConf {
+		path := fmt.Sprintf("browser_cache_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		if item.CacheType != consts.RuleTypeAll && item.CacheType != consts.RuleTypeIndex && len(item.CacheContent) == 0 {
+			v.invalid(path+".cache_content", "is required")
+		}
+		v.limit(path+".cache_content", len(item.CacheContent), v.c.CacheRuleContents)
+		v.ttl(path+".cache_ttl", item.CacheTTL, item.CacheUnit)
+	}
+}
+
+func (v *validator) checkRequestUrlRewriteConf(req *types.UpdateDomainRequest) {
+	v.limit("request_url_rewrite_conf", len(req.RequestUrlRewriteConf), v.c.RewriteRules)
+	for i, item := range req.RequestUrlRewriteConf {
+		path := fmt.Sprintf("request_url_rewrite_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.required(path+".rewrite_url", item.RewriteUrl)
+		v.required(path+".target_url", item.TargetUrl)
+		v.oneOf(path+".redirect_code", item.RedirectCode, v.c.RedirectCodes)
+	}
+}
+
+func (v *validator) checkCustomErrorPageConf(req *types.UpdateDomainRequest) {
+	v.limit("custom_error_page_conf", len(req.CustomErrorPageConf), v.c.ErrorPages)
+	for i, item := range req.CustomErrorPageConf {
+		path := fmt.Sprintf("custom_error_page_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.between(path+".status_code", item.StatusCode, 400, 599)
+		v.required(path+".goal_address", item.GoalAddress)
+	}
+}
+
+func (v *validator) checkIntelligentCompressionConf(req *types.UpdateDomainRequest) {
+	if req.IntelligentCompressionConf == nil || req.IntelligentCompressionConf.Status != consts.SwitchOn {
+		return
+	}
+	for i, item := range req.IntelligentCompressionConf.IntelligentCompressionConf {
+		path := fmt.Sprintf("intelligent_compression_conf.intelligent_compression_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.oneOf(path+".compress_method", item.CompressMethod, v.c.CompressMethods)
+	}
+}
+
+func (v *validator) checkResponseHeaderConf(req *types.UpdateDomainRequest) {
+	v.limit("response_header_conf", len(req.ResponseHeaderConf), v.c.HeaderRules)
+	for i, item := range req.ResponseHeaderConf {
+		path := fmt.Sprintf("response_header_conf[%d]", i)
+		if item == nil {
+			v.invalid(path, "is required")
+			continue
+		}
+		v.required(path+".parameter_key", item.ParameterKey)
+	}
+}
+
+func (v *validator) checkHttpsConf(req *types.UpdateDomainRequest) {
+	conf := req.HttpsConf
+	if conf == nil {
+		return
+	}
+	for i, version := range conf.TlsVersion {
+		v.between(fmt.Sprintf("https_conf.tls_version[%d]", i), version, consts.HttpsTlsVersionSSLv0, consts.HttpsTlsVersionTLSv3)
+	}
+	//多个TLS版本必须连续递增,厂商按起止版本下发
+	if len(conf.TlsVersion) > 1 && !utils.IsIncreasingSlice(conf.TlsVersion) {
+		v.invalid("https_conf.tls_version", "versions must be consecutive and increasing")
+	}
+	if conf.HttpsStatus != consts.SwitchOn {
+		if conf.HstsStatus == consts.SwitchOn {
+			v.invalid("https_conf.hsts_status", "requires https_status on")
+		}
+		if conf.JumpForceStatus == consts.SwitchOn {
+			v.invalid("https_conf.jump_force_status", "requires https_status on")
+		}
+		if conf.HttpTwo == consts.SwitchOn {
+			v.invalid("https_conf.http_two", "requires https_status on")
+		}
+		return
+	}
+	if conf.CertName == "" && (conf.CertValue == "" || conf.CertKey == "") {
+		v.invalid("https_conf", "cert_name or both cert_value and cert_key are required when https_status is on")
+	}
+}
+
+// port 端口为0时使用厂商默认端口
+func (v *validator) port(path string, port int64) {
+	v.between(path, port, 0, 65535)
+}
+
+// ttl 缓存时间不能为负数,且不能超过厂商的上限
+func (v *validator) ttl(path string, ttl, unit int64) {
+	if ttl < 0 {
+		v.invalid(path, "must not be negative")
+		return
+	}
+	if max := v.c.CacheTTL; max > 0 && cachettl.Seconds(ttl, unit) > max {
+		v.invalid(path, "exceeds the limit of %d seconds", max)
+	}
+}
@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+)
+
+// sections 更新动作与其对应的配置项,path为配置项在配置文件中的字段名,present返回请求中是否包含该配置项
+var sections = []struct {
+	action  string
+	path    string
+	present func(req *types.UpdateDomainRequest) bool
+}{
+	{types.UpdateBaseConf, "cdn_domain", func(req *types.UpdateDomainRequest) bool { return req.CdnDomain != nil }},
+	{types.UpdateArea, "cdn_domain", func(req *types.UpdateDomainRequest) bool { return req.CdnDomain != nil }},
+	{types.UpdateOriginConf, "origin_conf", func(req *types.UpdateDomainRequest) bool { return req.OriginConf != nil }},
+	{types.UpdateOriginServerConf, "origin_server_conf", func(req *types.UpdateDomainRequest) bool { return req.OriginServerConf != nil }},
+	{types.UpdateOriginAdvanceServerConf, "origin_advance_server_conf", func(req *types.UpdateDomainRequest) bool { return req.OriginAdvanceServerConf != nil }},
+	{types.UpdateOriginRequestHeaderConf, "origin_request_header_conf", func(req *types.UpdateDomainRequest) bool { return req.OriginRequestHeaderConf != nil }},
+	{types.UpdateOriginUrlConf, "origin_url_conf", func(req *types.UpdateDomainRequest) bool { return req.OriginUrlConf != nil }},
+	{types.UpdateIpFilterConf, "ip_filter_conf", func(req *types.UpdateDomainRequest) bool { return req.IpFilterConf != nil }},
+	{types.UpdateIpFrequencyConf, "ip_frequency_conf", func(req *types.UpdateDomainRequest) bool { return req.IpFrequencyConf != nil }},
+	{types.UpdateRefererConf, "referer_conf", func(req *types.UpdateDomainRequest) bool { return req.RefererConf != nil }},
+	{types.UpdateUserAgentConf, "user_agent_conf", func(req *types.UpdateDomainRequest) bool { return req.UserAgentConf != nil }},
+	{types.UpdateSpeedConf, "speed_conf", func(req *types.UpdateDomainRequest) bool { return req.SpeedConf != nil }},
+	{types.UpdateAuthConf, "auth_conf", func(req *types.UpdateDomainRequest) bool { return req.AuthConf != nil }},
+	{types.UpdateRemoteAuthConf, "remote_auth_conf", func(req *types.UpdateDomainRequest) bool { return req.RemoteAuthConf != nil }},
+	{types.UpdateCacheListConf, "cache_list_conf", func(req *types.UpdateDomainRequest) bool { return req.CacheListConf != nil }},
+	{types.UpdateCacheCodeConf, "cache_code_conf", func(req *types.UpdateDomainRequest) bool { return req.CacheCodeConf != nil }},
+	{types.UpdateBrowserCacheConf, "browser_cache_conf", func(req *types.UpdateDomainRequest) bool { return req.BrowserCacheConf != nil }},
+	{types.UpdateRequestUrlRewriteConf, "request_url_rewrite_conf", func(req *types.UpdateDomainRequest) bool { return req.RequestUrlRewriteConf != nil }},
+	{types.UpdateCustomErrorPageConf, "custom_error_page_conf", func(req *types.UpdateDomainRequest) bool { return req.CustomErrorPageConf != nil }},
+	{types.UpdateIntelligentCompressionConf, "intelligent_compression_conf", func(req *types.UpdateDomainRequest) bool { return req.IntelligentCompressionConf != nil }},
+	{types.UpdateResponseHeaderConf, "response_header_conf", func(req *types.UpdateDomainRequest) bool { return req.ResponseHeaderConf != nil }},
+	{types.UpdateHttpsConf, "https_conf", func(req *types.UpdateDomainRequest) bool { return req.HttpsConf != nil }},
+}
+
+// multiActions 同时更新多个配置项的动作,不要求请求包含某个配置项
+var multiActions = []string{types.UpdateRecommendConf, types.UpdateFullConf}
+
+func containsInt(list []int64, v int64) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+// Package validate 在调用厂商接口前按厂商的能力与数量限制校验更新请求,一次返回全部违规项,
+// 避免请求在厂商接口处失败时部分配置已经生效
+package validate
+
+import (
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"strings"
+)
+
+// Violation 违规项,errors.Is 可以匹配 Kind
+type Violation struct {
+	Path    string // 字段路径,与配置文件中的字段名一致,如 https_conf.tls_version
+	Kind    error  // 厂商不支持时为 cdnerr.ErrUnsupported,取值或数量不合法时为 cdnerr.ErrInvalidParameter
+	Message string // 违规描述
+}
+
+func (v *Violation) Error() string {
+	return v.Path + ": " + v.Message
+}
+
+func (v *Violation) Unwrap() error {
+	return v.Kind
+}
+
+// Violations 请求中的全部违规项,按配置项的定义顺序排列
+type Violations []*Violation
+
+func (v Violations) Error() string {
+	list := make([]string, 0, len(v))
+	for _, item := range v {
+		list = append(list, item.Error())
+	}
+	return strings.Join(list, "; ")
+}
+
+func (v Violations) Unwrap() []error {
+	list := make([]error, 0, len(v))
+	for _, item := range v {
+		list = append(list, item)
+	}
+	return list
+}
+
+// Limits 数量与取值上限,小于等于0时不限制
+type Limits struct {
+	OriginServers      int   // 主源站与备源站各自的条数
+	OriginAdvanceRules int   // 高级回源规则条数
+	OriginUrlRules     int   // 回源URL改写规则条数
+	HeaderRules        int   // 回源请求头与响应头各自的规则条数
+	IpFilterIps        int   // 单条IP黑白名单规则的IP条数
+	Referers           int   // 防盗链名单条数
+	UserAgents         int   // 单条UA黑白名单规则的UA条数
+	CacheRules         int   // 缓存规则条数
+	CacheRuleContents  int   // 单条缓存规则的内容条数
+	CacheParameters    int   // 单条缓存规则保留或忽略的参数条数
+	CacheTTL           int64 // 缓存时间上限,单位秒
+	RewriteRules       int   // URL重写规则条数
+	ErrorPages         int   // 自定义错误页条数
+}
+
+// Capability 厂商能力,取值列表为空时不限制
+type Capability struct {
+	Actions         []string // 支持的更新动作
+	AreaCodes       []int64  // 支持的加速区域
+	AuthManners     []int64  // 支持的鉴权方式
+	RedirectCodes   []int64  // URL重写支持的跳转码
+	CompressMethods []int64  // 支持的压缩方法
+	Limits
+}
+
+// defaultCapabilities 各厂商的能力,更新动作与取值来自各厂商 UpdateDomain 的实现,数量上限来自厂商接口文档
+var defaultCapabilities = map[string]Capability{
+	types.HuaWeiSdkName: {
+		Actions: []string{
+			types.UpdateBaseConf, types.UpdateArea, types.UpdateOriginConf, types.UpdateOriginServerConf,
+			types.UpdateOriginRequestHeaderConf, types.UpdateOriginUrlConf, types.UpdateOriginAdvanceServerConf,
+			types.UpdateResponseHeaderConf, types.UpdateIntelligentCompressionConf, types.UpdateCustomErrorPageConf,
+			types.UpdateCacheListConf, types.UpdateCacheCodeConf, types.UpdateBrowserCacheConf, types.UpdateRequestUrlRewriteConf,
+			types.UpdateIpFilterConf, types.UpdateRefererConf, types.UpdateUserAgentConf, types.UpdateAuthConf,
+			types.UpdateRemoteAuthConf, types.UpdateIpFrequencyConf, types.UpdateHttpsConf, types.UpdateRecommendConf,
+			types.UpdateFullConf,
+		},
+		Limits: Limits{
+			OriginServers:      50,
+			OriginAdvanceRules: 20,
+			OriginUrlRules:     20,
+			HeaderRules:        10,
+			IpFilterIps:        150,
+			Referers:           400,
+			UserAgents:         10,
+			CacheRules:         60,
+			CacheRuleContents:  20,
+			CacheParameters:    10,
+			CacheTTL:           365 * 24 * 3600,
+			RewriteRules:       20,
+			ErrorPages:         10,
+		},
+	},
+	types.TencentSdkName: {
+		Actions: []string{
+			types.UpdateBaseConf, types.UpdateArea, types.UpdateOriginConf, types.UpdateOriginServerConf,
+			types.UpdateOriginUrlConf, types.UpdateOriginAdvanceServerConf, types.UpdateOriginRequestHeaderConf,
+			types.UpdateBrowserCacheConf, types.UpdateCacheListConf, types.UpdateCacheCodeConf, types.UpdateRequestUrlRewriteConf,
+			types.UpdateIpFilterConf, types.UpdateRefererConf, types.UpdateUserAgentConf, types.UpdateAuthConf,
+			types.UpdateRemoteAuthConf, types.UpdateSpeedConf, types.UpdateIpFrequencyConf, types.UpdateHttpsConf,
+			types.UpdateIntelligentCompressionConf, types.UpdateResponseHeaderConf, types.UpdateCustomErrorPageConf,
+			types.UpdateRecommendConf, types.UpdateFullConf,
+		},
+		Limits: Limits{
+			OriginAdvanceRules: 10,
+			OriginUrlRules:     10,
+			HeaderRules:        10,
+			IpFilterIps:        200,
+			Referers:           400,
+			UserAgents:         10,
+			CacheRules:         100,
+			CacheTTL:           365 * 24 * 3600,
+			RewriteRules:       10,
+			ErrorPages:         10,
+		},
+	},
+	types.AliyunSdkName: {
+		Actions: []string{
+			types.UpdateBaseConf, types.UpdateArea, types.UpdateOriginConf, types.UpdateOriginServerConf,
+			types.UpdateOriginRequestHeaderConf, types.UpdateOriginUrlConf, types.UpdateIpFilterConf, types.UpdateRefererConf,
+			types.UpdateUserAgentConf, types.UpdateSpeedConf, types.UpdateAuthConf, types.UpdateRemoteAuthConf,
+			types.UpdateCacheListConf, types.UpdateCacheCodeConf, types.UpdateRequestUrlRewriteConf, types.UpdateCustomErrorPageConf,
+			types.UpdateIntelligentCompressionConf, types.UpdateResponseHeaderConf, types.UpdateHttpsConf,
+			types.UpdateRecommendConf, types.UpdateFullConf,
+		},
+		AuthManners:   []int64{consts.AccessAuthMannerTypeA, consts.AccessAuthMannerTypeB, consts.AccessAuthMannerTypeC},
+		RedirectCodes: []int64{consts.RedirectCode302},
+		Limits: Limits{
+			OriginServers:  20,
+			OriginUrlRules: 50,
+			HeaderRules:    20,
+			IpFilterIps:    1000,
+			Referers:       100,
+			CacheRules:     100,
+			CacheTTL:       3 * 365 * 24 * 3600,
+			RewriteRules:   50,
+			ErrorPages:     10,
+		},
+	},
+	types.BaiduSdkName: {
+		Actions: []string{
+			types.UpdateBaseConf, types.UpdateArea, types.UpdateOriginConf, types.UpdateOriginServerConf,
+			types.UpdateOriginRequestHeaderConf, types.UpdateIpFilterConf, types.UpdateIpFrequencyConf, types.UpdateRefererConf,
+			types.UpdateUserAgentConf, types.UpdateSpeedConf, types.UpdateAuthConf, types.UpdateCacheListConf,
+			types.UpdateCacheCodeConf, types.UpdateCustomErrorPageConf, types.UpdateIntelligentCompressionConf,
+			types.UpdateResponseHeaderConf, types.UpdateHttpsConf, types.UpdateRecommendConf, types.UpdateFullConf,
+		},
+		AreaCodes:   []int64{consts.AreaCodeChinaMainland},
+		AuthManners: []int64{consts.AccessAuthMannerTypeA, consts.AccessAuthMannerTypeB, consts.AccessAuthMannerTypeC},
+		Limits: Limits{
+			HeaderRules: 20,
+			IpFilterIps: 500,
+			Referers:    500,
+			CacheRules:  20,
+			CacheTTL:    365 * 24 * 3600,
+			ErrorPages:  10,
+		},
+	},
+	types.VolcengineSdkName: {
+		Actions: []string{
+			types.UpdateBaseConf, types.UpdateArea, types.UpdateOriginConf, types.UpdateOriginServerConf,
+			types.UpdateOriginRequestHeaderConf, types.UpdateIpFilterConf, types.UpdateIpFrequencyConf, types.UpdateRefererConf,
+			types.UpdateUserAgentConf, types.UpdateSpeedConf, types.UpdateAuthConf, types.UpdateCacheListConf,
+			types.UpdateCacheCodeConf, types.UpdateCustomErrorPageConf, types.UpdateIntelligentCompressionConf,
+			types.UpdateResponseHeaderConf, types.UpdateHttpsConf, types.UpdateRecommendConf, types.UpdateFullConf,
+		},
+		Limits: Limits{
+			OriginServers: 50,
+			HeaderRules:   50,
+			IpFilterIps:   1000,
+			Referers:      1000,
+			CacheRules:    50,
+			CacheTTL:      3 * 365 * 24 * 3600,
+			ErrorPages:    20,
+		},
+	},
+	types.KsYunSdkName: {
+		Actions: []string{
+			types.UpdateBaseConf, types.UpdateArea, types.UpdateOriginConf, types.UpdateOriginServerConf,
+			types.UpdateIpFilterConf, types.UpdateRefererConf, types.UpdateAuthConf, types.UpdateCacheListConf,
+			types.UpdateCustomErrorPageConf, types.UpdateResponseHeaderConf, types.UpdateHttpsConf,
+			types.UpdateRecommendConf, types.UpdateFullConf,
+		},
+		AuthManners: []int64{consts.AccessAuthMannerTypeA, consts.AccessAuthMannerTypeB, consts.AccessAuthMannerTypeC},
+		Limits: Limits{
+			HeaderRules: 10,
+			IpFilterIps: 500,
+			Referers:    500,
+			CacheRules:  20,
+			CacheTTL:    365 * 24 * 3600,
+			ErrorPages:  10,
+		},
+	},
+	types.WangsuSdkName: {
+		Actions: []string{
+			types.UpdateArea, types.UpdateOriginConf, types.UpdateOriginServerConf, types.UpdateOriginRequestHeaderConf,
+			types.UpdateResponseHeaderConf, types.UpdateCacheListConf, types.UpdateCacheCodeConf, types.UpdateRequestUrlRewriteConf,
+			types.UpdateIpFilterConf, types.UpdateRefererConf, types.UpdateUserAgentConf, types.UpdateSpeedConf,
+			types.UpdateHttpsConf, types.UpdateRecommendConf, types.UpdateFullConf,
+		},
+		Limits: Limits{
+			HeaderRules:  20,
+			IpFilterIps:  500,
+			Referers:     200,
+			UserAgents:   20,
+			CacheRules:   50,
+			CacheTTL:     365 * 24 * 3600,
+			RewriteRules: 20,
+		},
+	},
+}
+
+// DefaultCapability 获取厂商的能力,返回副本,可修改后调用 Capability.Validate,未知厂商不限制
+func DefaultCapability(provider string) Capability {
+	c := defaultCapabilities[provider]
+	c.Actions = append([]string(nil), c.Actions...)
+	c.AreaCodes = append([]int64(nil), c.AreaCodes...)
+	c.AuthManners = append([]int64(nil), c.AuthManners...)
+	c.RedirectCodes = append([]int64(nil), c.RedirectCodes...)
+	c.CompressMethods = append([]int64(nil), c.CompressMethods...)
+	return c
+}
+
+// Supports 是否支持更新动作,Actions为空时支持全部动作
+func (c Capability) Supports(action string) bool {
+	return len(c.Actions) == 0 || containsString(c.Actions, action)
+}
+
+// Validate 按厂商的默认能力校验更新请求,校验通过时返回nil,否则返回 Violations
+func Validate(provider string, req *types.UpdateDomainRequest) error {
+	return DefaultCapability(provider).Validate(req)
+}
+
+// Validate 校验更新请求,校验通过时返回nil,否则返回包含全部违规项的 Violations。
+//
+// 请求中不为nil的配置项都会被校验,UpdateAction为空时校验每个配置项对应的更新动作是否支持,
+// 否则校验UpdateAction是否支持以及该动作需要的配置项是否存在
+func (c Capability) Validate(req *types.UpdateDomainRequest) error {
+	if req == nil {
+		return cdnerr.ErrNilRequest
+	}
+	v := &validator{c: c, violations: make(Violations, 0)}
+	v.checkActions(req)
+	v.checkBaseConf(req)
+	v.checkOriginConf(req)
+	v.checkOriginServerConf(req)
+	v.checkOriginAdvanceServerConf(req)
+	v.checkOriginRequestHeaderConf(req)
+	v.checkOriginUrlConf(req)
+	v.checkIpFilterConf(req)
+	v.checkIpFrequencyConf(req)
+	v.checkRefererConf(req)
+	v.checkUserAgentConf(req)
+	v.checkSpeedConf(req)
+	v.checkAuthConf(req)
+	v.checkRemoteAuthConf(req)
+	v.checkCacheListConf(req)
+	v.checkCacheCodeConf(req)
+	v.checkBrowserCacheConf(req)
+	v.checkRequestUrlRewriteConf(req)
+	v.checkCustomErrorPageConf(req)
+	v.checkIntelligentCompressionConf(req)
+	v.checkResponseHeaderConf(req)
+	v.checkHttpsConf(req)
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return v.violations
+}
+
+type validator struct {
+	c          Capability
+	violations Violations
+}
+
+func (v *validator) unsupported(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, &Violation{Path: path, Kind: cdnerr.ErrUnsupported, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) invalid(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, &Violation{Path: path, Kind: cdnerr.ErrInvalidParameter, Message: fmt.Sprintf(format, args...)})
+}
+
+// limit 条数超过上限时记录违规项
+func (v *validator) limit(path string, n, max int) {
+	if max > 0 && n > max {
+		v.invalid(path, "%d items exceeds the limit of %d", n, max)
+	}
+}
+
+// oneOf 厂商限制了取值且取值不在其中时记录违规项
+func (v *validator) oneOf(path string, value int64, allowed []int64) {
+	if len(allowed) > 0 && !containsInt(allowed, value) {
+		v.unsupported(path, "%d not supported, must be one of %v", value, allowed)
+	}
+}
+
+// between 取值不在[min, max]范围内时记录违规项
+func (v *validator) between(path string, value, min, max int64) {
+	if value < min || value > max {
+		v.invalid(path, "%d out of range [%d, %d]", value, min, max)
+	}
+}
+
+// required 字符串为空时记录违规项
+func (v *validator) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.invalid(path, "is required")
+	}
+}
@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/entity"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"testing"
+)
+
+// cacheRules 生成n条全部内容的缓存规则
+func cacheRules(n int) []*entity.CacheListItem {
+	list := make([]*entity.CacheListItem, 0, n)
+	for i := 0; i < n; i++ {
+		list = append(list, &entity.CacheListItem{CacheType: consts.RuleTypeAll, CacheTTL: 1, CacheUnit: consts.CacheUnitDay, CacheStatus: consts.CacheStatusOn})
+	}
+	return list
+}
+
+func TestCacheRuleLimits(t *testing.T) {
+	for provider, c := range defaultCapabilities {
+		if c.CacheRules <= 0 || c.CacheTTL <= 0 {
+			t.Errorf("%s: cache rule limits not set", provider)
+			continue
+		}
+		if err := Validate(provider, &types.UpdateDomainRequest{CacheListConf: cacheRules(c.CacheRules)}); err != nil {
+			t.Errorf("%s: got %v at the limit", provider, err)
+		}
+		err := Validate(provider, &types.UpdateDomainRequest{CacheListConf: cacheRules(c.CacheRules + 1)})
+		var violations Violations
+		if !errors.As(err, &violations) || len(violations) != 1 || violations[0].Path != "cache_list_conf" || !errors.Is(err, cdnerr.ErrInvalidParameter) {
+			t.Errorf("%s: got %v, want cache_list_conf over limit", provider, err)
+		}
+	}
+}
+
+func TestCacheTTLLimit(t *testing.T) {
+	rules := cacheRules(1)
+	rules[0].CacheTTL, rules[0].CacheUnit = 366, consts.CacheUnitDay
+	err := Validate(types.HuaWeiSdkName, &types.UpdateDomainRequest{CacheListConf: rules})
+	if !errors.Is(err, cdnerr.ErrInvalidParameter) {
+		t.Fatalf("got %v, want cache ttl over limit", err)
+	}
+	if err := Validate(types.AliyunSdkName, &types.UpdateDomainRequest{CacheListConf: rules}); err != nil {
+		t.Fatalf("got %v, want within aliyun limit", err)
+	}
+}
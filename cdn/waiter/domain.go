@@ -0,0 +1,68 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"time"
+)
+
+// WaitForDomainStatus 等待域名状态变为target,域名部署失败时立即返回 ErrDeployFailed
+// 超时或ctx结束时返回最后一次查询到的域名详情与ctx的错误,等待删除时查询不到域名视为已删除并返回空的域名详情
+func (w *Waiter) WaitForDomainStatus(ctx context.Context, domain string, target int64, opts *Options) (*types.ShowDomainDetailResponse, error) {
+	return WaitForDomainStatus(ctx, w.cdn, domain, target, opts)
+}
+
+// WaitForDomainStatus 使用只提供域名查询的客户端等待域名状态变为target,行为与 Waiter.WaitForDomainStatus 相同
+func WaitForDomainStatus(ctx context.Context, c DomainClient, domain string, target int64, opts *Options) (*types.ShowDomainDetailResponse, error) {
+	var res *types.ShowDomainDetailResponse
+	previous := StatusUnknown
+	change := func(status int64) {
+		if status != previous {
+			if opts != nil && opts.OnChange != nil {
+				opts.OnChange(Event{Domain: domain, Previous: previous, Status: status, Time: time.Now()})
+			}
+			previous = status
+		}
+	}
+	err := poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		r, err := c.ShowDomainDetailWithContext(ctx, &types.ShowDomainDetailRequest{Domain: domain})
+		if err != nil {
+			//域名删除后部分厂商查询不到域名
+			if target == consts.CdnDomainStatusDeleted && errors.Is(err, cdnerr.ErrDomainNotFound) {
+				change(consts.CdnDomainStatusDeleted)
+				res = nil
+				return true, nil
+			}
+			return false, err
+		}
+		res = r
+		change(r.Status)
+		if r.Status == target {
+			return true, nil
+		}
+		if r.Status == consts.CdnDomainStatusFaild {
+			return true, fmt.Errorf("%s: domain %s: %w", c.GetSdkName(), domain, ErrDeployFailed)
+		}
+		return false, nil
+	})
+	return res, err
+}
+
+// WaitForDomainDeployed 等待创建、更新或启用后的域名部署完成
+func (w *Waiter) WaitForDomainDeployed(ctx context.Context, domain string, opts *Options) (*types.ShowDomainDetailResponse, error) {
+	return w.WaitForDomainStatus(ctx, domain, consts.CdnDomainStatusDeployed, opts)
+}
+
+// WaitForDomainStopped 等待停用后的域名停止完成
+func (w *Waiter) WaitForDomainStopped(ctx context.Context, domain string, opts *Options) (*types.ShowDomainDetailResponse, error) {
+	return w.WaitForDomainStatus(ctx, domain, consts.CdnDomainStatusStoped, opts)
+}
+
+// WaitForDomainDeleted 等待域名删除完成,查询不到域名时视为已删除并返回空的域名详情
+func (w *Waiter) WaitForDomainDeleted(ctx context.Context, domain string, opts *Options) (*types.ShowDomainDetailResponse, error) {
+	return w.WaitForDomainStatus(ctx, domain, consts.CdnDomainStatusDeleted, opts)
+}
@@ -0,0 +1,162 @@
+package waiter_test
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/cdn/waiter"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testDomain = "www.example.com"
+
+// newDomainFake 创建已部署完成的域名,每次轮询间隔为1分钟,部署耗时为5分钟
+func newDomainFake(t *testing.T) (*cdntest.Fake, *waiter.Waiter, *[]waiter.Event, *waiter.Options) {
+	t.Helper()
+	f := cdntest.New(context.Background(), nil)
+	fakeSleep(t, f)
+	if err := f.CreateDomain(&types.CreateDomainRequest{Domain: testDomain}); err != nil {
+		t.Fatal(err)
+	}
+	f.Settle()
+	events := make([]waiter.Event, 0)
+	opts := &waiter.Options{Interval: time.Minute, Multiplier: 1, OnChange: func(e waiter.Event) { events = append(events, e) }}
+	return f, waiter.New(f), &events, opts
+}
+
+// transitions 提取事件中的状态变化
+func transitions(events []waiter.Event) [][2]int64 {
+	list := make([][2]int64, 0, len(events))
+	for _, e := range events {
+		list = append(list, [2]int64{e.Previous, e.Status})
+	}
+	return list
+}
+
+func TestWaitForDomainDeployed(t *testing.T) {
+	ctx := context.Background()
+	f, w, events, opts := newDomainFake(t)
+	if err := f.UpdateDomain(&types.UpdateDomainRequest{UpdateAction: types.UpdateFullConf, Domain: testDomain}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := w.WaitForDomainDeployed(ctx, testDomain, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Domain != testDomain || res.Status != consts.CdnDomainStatusDeployed {
+		t.Fatalf("got %+v", res)
+	}
+	want := [][2]int64{
+		{waiter.StatusUnknown, consts.CdnDomainStatusDeploying},
+		{consts.CdnDomainStatusDeploying, consts.CdnDomainStatusDeployed},
+	}
+	if got := transitions(*events); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got transitions %v", got)
+	}
+	if (*events)[0].Domain != testDomain || (*events)[0].TaskId != "" {
+		t.Fatalf("got event %+v", (*events)[0])
+	}
+	//部署耗时5分钟,轮询6次
+	if n := f.CallCount("ShowDomainDetail"); n != 6 {
+		t.Fatalf("got %d calls", n)
+	}
+}
+
+func TestWaitForDomainFailed(t *testing.T) {
+	ctx := context.Background()
+	f, w, events, opts := newDomainFake(t)
+	if err := f.EnableDomain(&types.EnableDomainRequest{Domain: testDomain}); err != nil {
+		t.Fatal(err)
+	}
+	f.Advance(time.Minute)
+	if err := f.SetDomainStatus(testDomain, consts.CdnDomainStatusFaild); err != nil {
+		t.Fatal(err)
+	}
+	//部署失败时立即返回,不等待超时
+	res, err := w.WaitForDomainDeployed(ctx, testDomain, opts)
+	if !errors.Is(err, waiter.ErrDeployFailed) {
+		t.Fatalf("got %v, want deploy failed", err)
+	}
+	if res == nil || res.Status != consts.CdnDomainStatusFaild {
+		t.Fatalf("got %+v", res)
+	}
+	if n := f.CallCount("ShowDomainDetail"); n != 1 {
+		t.Fatalf("got %d calls", n)
+	}
+	if got := transitions(*events); !reflect.DeepEqual(got, [][2]int64{{waiter.StatusUnknown, consts.CdnDomainStatusFaild}}) {
+		t.Fatalf("got transitions %v", got)
+	}
+	//目标状态为部署失败时视为完成
+	if _, err := waiter.WaitForDomainStatus(ctx, f, testDomain, consts.CdnDomainStatusFaild, opts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForDomainStopped(t *testing.T) {
+	ctx := context.Background()
+	f, w, events, opts := newDomainFake(t)
+	if err := f.DisableDomain(&types.DisableDomainRequest{Domain: testDomain}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := w.WaitForDomainStopped(ctx, testDomain, opts)
+	if err != nil || res.Status != consts.CdnDomainStatusStoped {
+		t.Fatalf("got %+v %v", res, err)
+	}
+	want := [][2]int64{
+		{waiter.StatusUnknown, consts.CdnDomainStatusStoping},
+		{consts.CdnDomainStatusStoping, consts.CdnDomainStatusStoped},
+	}
+	if got := transitions(*events); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got transitions %v", got)
+	}
+}
+
+func TestWaitForDomainDeleted(t *testing.T) {
+	ctx := context.Background()
+	f, w, events, opts := newDomainFake(t)
+	if err := f.DeleteDomain(&types.DeleteDomainRequest{Domain: testDomain}); err != nil {
+		t.Fatal(err)
+	}
+	//删除后查询不到域名视为已删除
+	res, err := w.WaitForDomainDeleted(ctx, testDomain, opts)
+	if err != nil || res != nil {
+		t.Fatalf("got %+v %v", res, err)
+	}
+	want := [][2]int64{
+		{waiter.StatusUnknown, consts.CdnDomainStatusDeleting},
+		{consts.CdnDomainStatusDeleting, consts.CdnDomainStatusDeleted},
+	}
+	if got := transitions(*events); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got transitions %v", got)
+	}
+	//已删除的域名查询一次即返回
+	if _, err := waiter.WaitForDomainStatus(ctx, f, testDomain, consts.CdnDomainStatusDeleted, nil); err != nil {
+		t.Fatal(err)
+	}
+	//等待其他状态时查询不到域名立即返回错误
+	calls := f.CallCount("ShowDomainDetail")
+	if _, err := w.WaitForDomainDeployed(ctx, testDomain, opts); !errors.Is(err, cdnerr.ErrDomainNotFound) {
+		t.Fatalf("got %v, want domain not found", err)
+	}
+	if n := f.CallCount("ShowDomainDetail"); n != calls+1 {
+		t.Fatalf("got %d calls", n-calls)
+	}
+}
+
+func TestWaitForDomainRetryable(t *testing.T) {
+	ctx := context.Background()
+	f, w, _, opts := newDomainFake(t)
+	f.AddFault(cdntest.FailTimes(2, cdnerr.New(cdnerr.ErrRateLimited, cdntest.SdkName, "throttled"), "ShowDomainDetail"))
+	res, err := w.WaitForDomainDeployed(ctx, testDomain, opts)
+	if err != nil || res.Status != consts.CdnDomainStatusDeployed {
+		t.Fatalf("got %+v %v", res, err)
+	}
+	if n := f.CallCount("ShowDomainDetail"); n != 3 {
+		t.Fatalf("got %d calls", n)
+	}
+}
@@ -0,0 +1,13 @@
+package waiter
+
+import (
+	"context"
+	"time"
+)
+
+// SetSleep 替换轮询等待函数,返回恢复函数
+func SetSleep(fn func(ctx context.Context, d time.Duration) error) (restore func()) {
+	old := sleep
+	sleep = fn
+	return func() { sleep = old }
+}
@@ -0,0 +1,61 @@
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"time"
+)
+
+// WaitForPurgeTask 等待刷新任务完成,任务失败时返回 ErrTaskFailed,超时或ctx结束时返回最后一次查询到的状态与ctx的错误
+func (w *Waiter) WaitForPurgeTask(ctx context.Context, taskId string, opts *Options) (*types.ShowPurgeTaskStatusResponse, error) {
+	var res *types.ShowPurgeTaskStatusResponse
+	err := w.waitForTask(ctx, taskId, opts, func(ctx context.Context) (int64, error) {
+		r, err := w.cdn.ShowPurgeTaskStatusWithContext(ctx, &types.ShowPurgeTaskStatusRequest{TaskId: taskId})
+		if err != nil {
+			return 0, err
+		}
+		res = r
+		return r.Status, nil
+	})
+	return res, err
+}
+
+// WaitForPushTask 等待预热任务完成,任务失败时返回 ErrTaskFailed,超时或ctx结束时返回最后一次查询到的状态与ctx的错误
+func (w *Waiter) WaitForPushTask(ctx context.Context, taskId string, opts *Options) (*types.ShowPushTaskStatusResponse, error) {
+	var res *types.ShowPushTaskStatusResponse
+	err := w.waitForTask(ctx, taskId, opts, func(ctx context.Context) (int64, error) {
+		r, err := w.cdn.ShowPushTaskStatusWithContext(ctx, &types.ShowPushTaskStatusRequest{TaskId: taskId})
+		if err != nil {
+			return 0, err
+		}
+		res = r
+		return r.Status, nil
+	})
+	return res, err
+}
+
+// waitForTask 轮询任务状态,状态变化时调用OnChange
+func (w *Waiter) waitForTask(ctx context.Context, taskId string, opts *Options, show func(ctx context.Context) (int64, error)) error {
+	previous := StatusUnknown
+	return poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		status, err := show(ctx)
+		if err != nil {
+			return false, err
+		}
+		if status != previous {
+			if opts != nil && opts.OnChange != nil {
+				opts.OnChange(Event{TaskId: taskId, Previous: previous, Status: status, Time: time.Now()})
+			}
+			previous = status
+		}
+		switch status {
+		case consts.ShowContentPurgeOrPushStatusSuccess:
+			return true, nil
+		case consts.ShowContentPurgeOrPushStatusFail:
+			return true, fmt.Errorf("%s: task %s: %w", w.cdn.GetSdkName(), taskId, ErrTaskFailed)
+		}
+		return false, nil
+	})
+}
@@ -0,0 +1,103 @@
+package waiter_test
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/cdn/waiter"
+	"testing"
+	"time"
+)
+
+func TestOnChange(t *testing.T) {
+	ctx := context.Background()
+	f := cdntest.New(ctx, &cdntest.Config{TaskDuration: 10 * time.Second})
+	fakeSleep(t, f)
+	res, err := f.PushUrlsCacheWithContext(ctx, &types.PushUrlsCacheRequest{Urls: []string{"https://www.example.com/a.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make([]waiter.Event, 0)
+	opts := &waiter.Options{Interval: time.Second, Multiplier: 1, OnChange: func(e waiter.Event) { events = append(events, e) }}
+	if _, err := waiter.New(f).WaitForPushTask(ctx, res.TaskId, opts); err != nil {
+		t.Fatal(err)
+	}
+	//轮询11次,状态只变化两次
+	if n := f.CallCount("ShowPushTaskStatus"); n != 11 {
+		t.Fatalf("got %d calls", n)
+	}
+	want := []struct{ previous, status int64 }{
+		{waiter.StatusUnknown, consts.ShowContentPurgeOrPushStatusDoing},
+		{consts.ShowContentPurgeOrPushStatusDoing, consts.ShowContentPurgeOrPushStatusSuccess},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got events %+v", events)
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.TaskId != res.TaskId || e.Domain != "" || e.Previous != w.previous || e.Status != w.status || e.Time.IsZero() {
+			t.Fatalf("event %d: got %+v", i, e)
+		}
+	}
+}
+
+func TestTaskStatus(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name   string
+		status int64
+		err    error
+	}{
+		{"success", consts.ShowContentPurgeOrPushStatusSuccess, nil},
+		{"fail", consts.ShowContentPurgeOrPushStatusFail, waiter.ErrTaskFailed},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := cdntest.New(ctx, nil)
+			fakeSleep(t, f)
+			w := waiter.New(f)
+			purge, err := f.PurgeUrlsCacheWithContext(ctx, &types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a.png"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			push, err := f.PushUrlsCacheWithContext(ctx, &types.PushUrlsCacheRequest{Urls: []string{"https://www.example.com/a.png"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, id := range []string{purge.TaskId, push.TaskId} {
+				if err := f.SetTaskStatus(id, c.status); err != nil {
+					t.Fatal(err)
+				}
+			}
+			purgeStatus, err := w.WaitForPurgeTask(ctx, purge.TaskId, nil)
+			if !errors.Is(err, c.err) || (c.err == nil) != (err == nil) {
+				t.Fatalf("purge: got %v, want %v", err, c.err)
+			}
+			if purgeStatus == nil || purgeStatus.Status != c.status {
+				t.Fatalf("purge: got %+v", purgeStatus)
+			}
+			pushStatus, err := w.WaitForPushTask(ctx, push.TaskId, nil)
+			if !errors.Is(err, c.err) || (c.err == nil) != (err == nil) {
+				t.Fatalf("push: got %v, want %v", err, c.err)
+			}
+			if pushStatus == nil || pushStatus.Status != c.status {
+				t.Fatalf("push: got %+v", pushStatus)
+			}
+			//已完成的任务查询一次即返回
+			if f.CallCount("ShowPurgeTaskStatus") != 1 || f.CallCount("ShowPushTaskStatus") != 1 {
+				t.Fatalf("got calls %+v", f.Calls())
+			}
+		})
+	}
+	//刷新任务ID不能用于查询预热任务
+	f := cdntest.New(ctx, nil)
+	purge, err := f.PurgeUrlsCacheWithContext(ctx, &types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := waiter.New(f).WaitForPushTask(ctx, purge.TaskId, nil); err == nil {
+		t.Fatal("want error for purge task id")
+	}
+}
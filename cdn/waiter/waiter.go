@@ -0,0 +1,126 @@
+// Package waiter 轮询刷新预热任务与域名状态直到完成,支持退避、超时与状态变化回调
+package waiter
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/retry"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"time"
+)
+
+const (
+	DefaultInterval    = 5 * time.Second // 默认首次轮询间隔
+	DefaultMaxInterval = time.Minute     // 默认最大轮询间隔
+	DefaultMultiplier  = 1.5             // 默认轮询间隔增长倍数
+)
+
+// StatusUnknown 首次查询到状态时 Event.Previous 的取值
+const StatusUnknown int64 = -1
+
+var (
+	ErrTaskFailed   = errors.New("task failed")          // 刷新预热任务执行失败
+	ErrDeployFailed = errors.New("domain deploy failed") // 域名部署失败
+)
+
+// Options 轮询配置,为空时使用默认值
+type Options struct {
+	Interval    time.Duration // 首次轮询间隔,默认5s
+	MaxInterval time.Duration // 最大轮询间隔,默认1min
+	Multiplier  float64       // 每次轮询后间隔的增长倍数,小于1时为 DefaultMultiplier
+	Timeout     time.Duration // 等待超时时间,小于等于0时只受ctx控制
+	OnChange    func(Event)   // 状态变化回调,首次查询到状态时也会调用,在轮询的goroutine中同步执行
+}
+
+// Event 状态变化事件
+type Event struct {
+	TaskId   string    // 任务ID,等待域名状态时为空
+	Domain   string    // 域名,等待任务时为空
+	Previous int64     // 变化前的状态,首次查询时为 StatusUnknown
+	Status   int64     // 当前状态,任务为consts.ShowContentPurgeOrPushStatus*,域名为consts.CdnDomainStatus*
+	Time     time.Time // 查询到状态的时间
+}
+
+// DomainClient 等待域名状态所需的厂商接口
+type DomainClient interface {
+	GetSdkName() string
+	ShowDomainDetailWithContext(ctx context.Context, req *types.ShowDomainDetailRequest) (*types.ShowDomainDetailResponse, error)
+}
+
+// Client 等待器所需的厂商接口,各厂商实现均满足该接口,其他Cdn实现可通过cdn.WithContext转换
+type Client interface {
+	DomainClient
+	ShowPurgeTaskStatusWithContext(ctx context.Context, req *types.ShowPurgeTaskStatusRequest) (*types.ShowPurgeTaskStatusResponse, error)
+	ShowPushTaskStatusWithContext(ctx context.Context, req *types.ShowPushTaskStatusRequest) (*types.ShowPushTaskStatusResponse, error)
+}
+
+// Waiter 等待刷新预热任务完成或域名状态变为目标状态
+type Waiter struct {
+	cdn Client
+}
+
+// New 创建等待器
+func New(c Client) *Waiter {
+	return &Waiter{cdn: c}
+}
+
+func (o *Options) interval() time.Duration {
+	if o == nil || o.Interval <= 0 {
+		return DefaultInterval
+	}
+	return o.Interval
+}
+
+func (o *Options) next(d time.Duration) time.Duration {
+	max, multiplier := DefaultMaxInterval, DefaultMultiplier
+	if o != nil && o.MaxInterval > 0 {
+		max = o.MaxInterval
+	}
+	if o != nil && o.Multiplier >= 1 {
+		multiplier = o.Multiplier
+	}
+	d = time.Duration(float64(d) * multiplier)
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// poll 按轮询间隔调用check直到done为true、check返回不可重试的错误或ctx结束
+// 限流、服务端临时错误等可重试的错误不会中断轮询
+func poll(ctx context.Context, opts *Options, check func(ctx context.Context) (done bool, err error)) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts != nil && opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	interval := opts.interval()
+	for {
+		done, err := check(ctx)
+		if err != nil && (ctx.Err() != nil || !retry.IsRetryable(err)) {
+			return err
+		}
+		if err == nil && done {
+			return nil
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+		interval = opts.next(interval)
+	}
+}
+
+// sleep 等待d或ctx结束,测试中替换以跳过等待
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+package waiter_test
+
+import (
+	"context"
+	"errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/cdntest"
+	"github.com/run-bigpig/cloud-sdk/cdn/consts"
+	cdnerr "github.com/run-bigpig/cloud-sdk/cdn/errors"
+	"github.com/run-bigpig/cloud-sdk/cdn/types"
+	"github.com/run-bigpig/cloud-sdk/cdn/waiter"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeSleep 记录每次轮询间隔并推进模拟时间,不实际等待
+func fakeSleep(t *testing.T, f *cdntest.Fake) *[]time.Duration {
+	t.Helper()
+	intervals := make([]time.Duration, 0)
+	t.Cleanup(waiter.SetSleep(func(ctx context.Context, d time.Duration) error {
+		intervals = append(intervals, d)
+		f.Advance(d)
+		return ctx.Err()
+	}))
+	return &intervals
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		name string
+		opts *waiter.Options
+		want []time.Duration
+	}{
+		//任务耗时15s,默认间隔在模拟时间0s、5s、12.5s时进行中,23.75s时成功
+		{"default", nil, []time.Duration{5 * time.Second, 7500 * time.Millisecond, 11250 * time.Millisecond}},
+		{"max", &waiter.Options{Interval: time.Second, MaxInterval: 5 * time.Second, Multiplier: 2}, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		//倍数小于1时使用默认倍数
+		{"multiplier", &waiter.Options{Interval: 2 * time.Second, Multiplier: 0.5}, []time.Duration{2 * time.Second, 3 * time.Second, 4500 * time.Millisecond, 6750 * time.Millisecond}},
+		//等于1时间隔固定
+		{"fixed", &waiter.Options{Interval: 4 * time.Second, Multiplier: 1}, []time.Duration{4 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := cdntest.New(ctx, &cdntest.Config{TaskDuration: 15 * time.Second})
+			intervals := fakeSleep(t, f)
+			res, err := f.PurgeUrlsCacheWithContext(ctx, &types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a.png"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			status, err := waiter.New(f).WaitForPurgeTask(ctx, res.TaskId, c.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Status != consts.ShowContentPurgeOrPushStatusSuccess {
+				t.Fatalf("got status %d", status.Status)
+			}
+			if !reflect.DeepEqual(*intervals, c.want) {
+				t.Fatalf("got intervals %v, want %v", *intervals, c.want)
+			}
+			if n := f.CallCount("ShowPurgeTaskStatus"); n != len(c.want)+1 {
+				t.Fatalf("got %d calls", n)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	ctx := context.Background()
+	f := cdntest.New(ctx, nil)
+	res, err := f.PushUrlsCacheWithContext(ctx, &types.PushUrlsCacheRequest{Urls: []string{"https://www.example.com/a.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	status, err := waiter.New(f).WaitForPushTask(ctx, res.TaskId, &waiter.Options{Interval: 10 * time.Millisecond, Timeout: 100 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("timeout returned after %s", elapsed)
+	}
+	//超时时返回最后一次查询到的状态
+	if status == nil || status.Status != consts.ShowContentPurgeOrPushStatusDoing {
+		t.Fatalf("got %+v", status)
+	}
+	if f.CallCount("ShowPushTaskStatus") < 2 {
+		t.Fatal("want polling before timeout")
+	}
+	//ctx取消时同样返回
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := waiter.New(f).WaitForPushTask(ctx, res.TaskId, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want canceled", err)
+	}
+}
+
+func TestRetryableErrors(t *testing.T) {
+	ctx := context.Background()
+	f := cdntest.New(ctx, nil)
+	intervals := fakeSleep(t, f)
+	res, err := f.PurgeUrlsCacheWithContext(ctx, &types.PurgeUrlsCacheRequest{Urls: []string{"https://www.example.com/a.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//限流与服务端错误不中断轮询
+	f.AddFault(cdntest.FailTimes(1, cdnerr.New(cdnerr.ErrRateLimited, cdntest.SdkName, "throttled"), "ShowPurgeTaskStatus"))
+	f.AddFault(cdntest.FailTimes(1, &cdnerr.Error{Kind: cdnerr.ErrProvider, Provider: cdntest.SdkName, StatusCode: 503}, "ShowPurgeTaskStatus"))
+	events := make([]waiter.Event, 0)
+	opts := &waiter.Options{Interval: time.Minute, OnChange: func(e waiter.Event) { events = append(events, e) }}
+	status, err := waiter.New(f).WaitForPurgeTask(ctx, res.TaskId, opts)
+	if err != nil || status.Status != consts.ShowContentPurgeOrPushStatusSuccess {
+		t.Fatalf("got %+v %v", status, err)
+	}
+	if len(*intervals) != 2 || len(events) != 1 || events[0].Status != consts.ShowContentPurgeOrPushStatusSuccess {
+		t.Fatalf("got intervals %v events %+v", *intervals, events)
+	}
+	//不可重试的错误立即返回
+	calls := f.CallCount("ShowPurgeTaskStatus")
+	if _, err := waiter.New(f).WaitForPurgeTask(ctx, "none", opts); err == nil {
+		t.Fatal("want error for unknown task")
+	}
+	f.AddFault(cdntest.FailOp(cdnerr.New(cdnerr.ErrAuthFailed, cdntest.SdkName, "denied"), "ShowPurgeTaskStatus"))
+	if _, err := waiter.New(f).WaitForPurgeTask(ctx, res.TaskId, opts); !errors.Is(err, cdnerr.ErrAuthFailed) {
+		t.Fatalf("got %v, want auth failed", err)
+	}
+	if n := f.CallCount("ShowPurgeTaskStatus"); n != calls+2 {
+		t.Fatalf("got %d calls, want no polling after permanent errors", n-calls)
+	}
+}
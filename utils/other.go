@@ -1,5 +1,7 @@
 package utils
 
+import "strings"
+
 // 计算翻页 CalcOffsetAndLimit
 func CalcOffsetAndLimit(page, pageSize int64) (offset, limit int64) {
 	if page <= 0 {
@@ -12,3 +14,27 @@ func CalcOffsetAndLimit(page, pageSize int64) (offset, limit int64) {
 	limit = pageSize
 	return
 }
+
+// SplitString 按分隔符拆分字符串,去除空白与空值
+func SplitString(s string, sep string) []string {
+	list := make([]string, 0)
+	for _, v := range strings.Split(s, sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// IsIncreasingSlice 判断切片是否逐个加1递增,少于两个元素时返回false
+func IsIncreasingSlice(slice []int64) bool {
+	if len(slice) < 2 {
+		return false
+	}
+	for i := 1; i < len(slice); i++ {
+		if slice[i] != slice[i-1]+1 {
+			return false
+		}
+	}
+	return true
+}
@@ -32,6 +32,41 @@ func StringPtr(v string) *string {
 	return &v
 }
 
+func StringValue(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
+
+func Int32Value(ptr *int32) int32 {
+	if ptr == nil {
+		return 0
+	}
+	return *ptr
+}
+
+func Int64Value(ptr *int64) int64 {
+	if ptr == nil {
+		return 0
+	}
+	return *ptr
+}
+
+func Uint64Value(ptr *uint64) uint64 {
+	if ptr == nil {
+		return 0
+	}
+	return *ptr
+}
+
+func BoolValue(ptr *bool) bool {
+	if ptr == nil {
+		return false
+	}
+	return *ptr
+}
+
 func StringValues(ptrs []*string) []string {
 	values := make([]string, len(ptrs))
 	for i := 0; i < len(ptrs); i++ {